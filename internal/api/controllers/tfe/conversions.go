@@ -19,7 +19,7 @@ func TharsisWorkspaceToWorkspace(workspace *models.Workspace) *Workspace {
 		Operations:       true,
 		AutoApply:        false,
 		TerraformVersion: workspace.TerraformVersion,
-		Locked:           workspace.Locked,
+		Locked:           workspace.IsLocked(),
 		Permissions: &WorkspacePermissions{
 			CanQueueRun:     true,
 			CanQueueApply:   true,
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -190,8 +191,14 @@ func (c *vcsController) DesignateEventHandler(w http.ResponseWriter, r *http.Req
 }
 
 func (c *vcsController) gitLabHandler(r *http.Request) error {
+	// Keep the raw body since the signature is computed over it.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+
 	var req gitLabWebhookRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := json.Unmarshal(body, &req); err != nil {
 		return err
 	}
 
@@ -205,12 +212,20 @@ func (c *vcsController) gitLabHandler(r *http.Request) error {
 		Before:           req.Before,
 		After:            req.After,
 		Ref:              req.Ref,
+		Headers:          r.Header,
+		Body:             body,
 	})
 }
 
 func (c *vcsController) gitHubHandler(r *http.Request) error {
+	// Keep the raw body since the signature is computed over it.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+
 	var req gitHubWebhookRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := json.Unmarshal(body, &req); err != nil {
 		return err
 	}
 
@@ -224,6 +239,8 @@ func (c *vcsController) gitHubHandler(r *http.Request) error {
 		Before:           req.Before,
 		After:            req.After,
 		Ref:              req.Ref,
+		Headers:          r.Header,
+		Body:             body,
 	})
 }
 
@@ -22,9 +22,15 @@ var tharsisErrorToStatusCode = map[errors.CodeType]string{
 
 func getErrExtensions(err error) map[string]interface{} {
 	code := errors.ErrorCode(err)
-	return map[string]interface{}{
+	extensions := map[string]interface{}{
 		"code": tharsisErrorToStatusCode[code],
 	}
+
+	if details := errors.ErrorDetails(err); details != nil {
+		extensions["details"] = details
+	}
+
+	return extensions
 }
 
 type slicer interface {
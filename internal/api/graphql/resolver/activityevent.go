@@ -274,6 +274,48 @@ func (r *ActivityEventPayloadResolver) ToActivityEventMoveManagedIdentityPayload
 	return res, ok
 }
 
+// ToActivityEventForceUnlockWorkspacePayload resolver
+func (r *ActivityEventPayloadResolver) ToActivityEventForceUnlockWorkspacePayload() (*ActivityEventForceUnlockWorkspacePayloadResolver, bool) {
+	res, ok := r.result.(*ActivityEventForceUnlockWorkspacePayloadResolver)
+	return res, ok
+}
+
+// ToActivityEventLimitExceededPayload resolves the custom payload for a request rejected by a resource limit.
+func (r *ActivityEventPayloadResolver) ToActivityEventLimitExceededPayload() (*models.ActivityEventLimitExceededPayload, bool) {
+	res, ok := r.result.(*models.ActivityEventLimitExceededPayload)
+	return res, ok
+}
+
+// ToActivityEventAccessRuleDeniedPayload resolves the custom payload for a run rejected by a managed identity access rule.
+func (r *ActivityEventPayloadResolver) ToActivityEventAccessRuleDeniedPayload() (*ActivityEventAccessRuleDeniedPayloadResolver, bool) {
+	res, ok := r.result.(*ActivityEventAccessRuleDeniedPayloadResolver)
+	return res, ok
+}
+
+// ToActivityEventUpdateServiceAccountTrustPoliciesPayload resolves the custom payload for replacing service account trust policies.
+func (r *ActivityEventPayloadResolver) ToActivityEventUpdateServiceAccountTrustPoliciesPayload() (*models.ActivityEventUpdateServiceAccountTrustPoliciesPayload, bool) {
+	res, ok := r.result.(*models.ActivityEventUpdateServiceAccountTrustPoliciesPayload)
+	return res, ok
+}
+
+// ToActivityEventReplaceManagedIdentityAccessRulesPayload resolves the custom payload for replacing managed identity access rules.
+func (r *ActivityEventPayloadResolver) ToActivityEventReplaceManagedIdentityAccessRulesPayload() (*models.ActivityEventReplaceManagedIdentityAccessRulesPayload, bool) {
+	res, ok := r.result.(*models.ActivityEventReplaceManagedIdentityAccessRulesPayload)
+	return res, ok
+}
+
+// ToActivityEventUpdateWorkspaceAutoApplyPayload resolves the custom payload for changing the workspace auto apply setting.
+func (r *ActivityEventPayloadResolver) ToActivityEventUpdateWorkspaceAutoApplyPayload() (*models.ActivityEventUpdateWorkspaceAutoApplyPayload, bool) {
+	res, ok := r.result.(*models.ActivityEventUpdateWorkspaceAutoApplyPayload)
+	return res, ok
+}
+
+// ToActivityEventCancelWorkspaceRunsPayload resolves the custom payload for canceling all the active runs in a workspace.
+func (r *ActivityEventPayloadResolver) ToActivityEventCancelWorkspaceRunsPayload() (*models.ActivityEventCancelWorkspaceRunsPayload, bool) {
+	res, ok := r.result.(*models.ActivityEventCancelWorkspaceRunsPayload)
+	return res, ok
+}
+
 // ActivityEventResolver resolves an activity event resource
 type ActivityEventResolver struct {
 	activityEvent *models.ActivityEvent
@@ -469,6 +511,11 @@ func (r *ActivityEventResolver) TargetID() string {
 	return gid.ToGlobalID(gid.ActivityEventType, r.activityEvent.TargetID)
 }
 
+// Backfilled resolver
+func (r *ActivityEventResolver) Backfilled() bool {
+	return r.activityEvent.Backfilled
+}
+
 // Payload resolver
 func (r *ActivityEventResolver) Payload() (*ActivityEventPayloadResolver, error) {
 	if r.activityEvent.Payload != nil {
@@ -547,6 +594,53 @@ func (r *ActivityEventResolver) Payload() (*ActivityEventPayloadResolver, error)
 				return nil, err
 			}
 			return &ActivityEventPayloadResolver{result: &ActivityEventMoveManagedIdentityPayloadResolver{payload: &payload}}, nil
+		case (r.activityEvent.Action == models.ActionUnlock) &&
+			(r.activityEvent.TargetType == models.TargetWorkspace):
+			var payload models.ActivityEventForceUnlockWorkspacePayload
+			if err := json.Unmarshal(r.activityEvent.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return &ActivityEventPayloadResolver{result: &ActivityEventForceUnlockWorkspacePayloadResolver{payload: &payload}}, nil
+		case (r.activityEvent.Action == models.ActionUpdate) &&
+			(r.activityEvent.TargetType == models.TargetServiceAccount):
+			var payload models.ActivityEventUpdateServiceAccountTrustPoliciesPayload
+			if err := json.Unmarshal(r.activityEvent.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return &ActivityEventPayloadResolver{result: &payload}, nil
+		case (r.activityEvent.Action == models.ActionUpdate) &&
+			(r.activityEvent.TargetType == models.TargetManagedIdentity):
+			var payload models.ActivityEventReplaceManagedIdentityAccessRulesPayload
+			if err := json.Unmarshal(r.activityEvent.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return &ActivityEventPayloadResolver{result: &payload}, nil
+		case (r.activityEvent.Action == models.ActionUpdate) &&
+			(r.activityEvent.TargetType == models.TargetWorkspace):
+			var payload models.ActivityEventUpdateWorkspaceAutoApplyPayload
+			if err := json.Unmarshal(r.activityEvent.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return &ActivityEventPayloadResolver{result: &payload}, nil
+		case (r.activityEvent.Action == models.ActionCancel) &&
+			(r.activityEvent.TargetType == models.TargetWorkspace):
+			var payload models.ActivityEventCancelWorkspaceRunsPayload
+			if err := json.Unmarshal(r.activityEvent.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return &ActivityEventPayloadResolver{result: &payload}, nil
+		case r.activityEvent.Action == models.ActionLimitExceeded:
+			var payload models.ActivityEventLimitExceededPayload
+			if err := json.Unmarshal(r.activityEvent.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return &ActivityEventPayloadResolver{result: &payload}, nil
+		case r.activityEvent.Action == models.ActionAccessRuleDenied:
+			var payload models.ActivityEventAccessRuleDeniedPayload
+			if err := json.Unmarshal(r.activityEvent.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return &ActivityEventPayloadResolver{result: &ActivityEventAccessRuleDeniedPayloadResolver{payload: &payload}}, nil
 		default:
 			return nil, fmt.Errorf("payload supplied without a supported target type and action")
 
@@ -648,6 +742,33 @@ func (r *ActivityEventMoveManagedIdentityPayloadResolver) PreviousGroupPath() st
 	return r.payload.PreviousGroupPath
 }
 
+// ActivityEventForceUnlockWorkspacePayloadResolver resolves an activity event
+// force unlock workspace payload resource
+type ActivityEventForceUnlockWorkspacePayloadResolver struct {
+	payload *models.ActivityEventForceUnlockWorkspacePayload
+}
+
+// RunID resolver
+func (r *ActivityEventForceUnlockWorkspacePayloadResolver) RunID() string {
+	return r.payload.RunID
+}
+
+// ActivityEventAccessRuleDeniedPayloadResolver resolves an activity event
+// access rule denied payload resource
+type ActivityEventAccessRuleDeniedPayloadResolver struct {
+	payload *models.ActivityEventAccessRuleDeniedPayload
+}
+
+// Evaluations resolver
+func (r *ActivityEventAccessRuleDeniedPayloadResolver) Evaluations() []*ManagedIdentityAccessRuleEvaluationResolver {
+	resolvers := []*ManagedIdentityAccessRuleEvaluationResolver{}
+	for _, evaluation := range r.payload.Evaluations {
+		evaluationCopy := evaluation
+		resolvers = append(resolvers, &ManagedIdentityAccessRuleEvaluationResolver{evaluation: &evaluationCopy})
+	}
+	return resolvers
+}
+
 func activityEventsQuery(ctx context.Context, args *ActivityEventConnectionQueryArgs) (*ActivityEventConnectionResolver, error) {
 	input, err := getActivityEventsInputFromQueryArgs(ctx, args)
 	if err != nil {
@@ -729,4 +850,68 @@ func getActivityEventsInputFromQueryArgs(ctx context.Context,
 	return &input, nil
 }
 
-/* ActivityEvent Mutation Resolvers do not exist. */
+/* ActivityEvent Mutation Resolvers */
+
+// ActivityEventMutationPayload is the response payload for an activity event mutation
+type ActivityEventMutationPayload struct {
+	ClientMutationID *string
+	ActivityEvent    *models.ActivityEvent
+	Problems         []Problem
+}
+
+// ActivityEventMutationPayloadResolver resolves an ActivityEventMutationPayload
+type ActivityEventMutationPayloadResolver struct {
+	ActivityEventMutationPayload
+}
+
+// ActivityEvent field resolver
+func (r *ActivityEventMutationPayloadResolver) ActivityEvent() *ActivityEventResolver {
+	if r.ActivityEventMutationPayload.ActivityEvent == nil {
+		return nil
+	}
+	return &ActivityEventResolver{activityEvent: r.ActivityEventMutationPayload.ActivityEvent}
+}
+
+// BackfillActivityEventInput contains the input for backfilling a missing activity event
+type BackfillActivityEventInput struct {
+	ClientMutationID *string
+	NamespacePath    *string
+	// Payload is the JSON encoded custom payload for the action and target type
+	Payload    *string
+	Action     models.ActivityEventAction
+	TargetType models.ActivityEventTargetType
+	TargetID   string
+}
+
+func handleActivityEventMutationProblem(e error, clientMutationID *string) (*ActivityEventMutationPayloadResolver, error) {
+	problem, err := buildProblem(e)
+	if err != nil {
+		return nil, err
+	}
+	payload := ActivityEventMutationPayload{ClientMutationID: clientMutationID, Problems: []Problem{*problem}}
+	return &ActivityEventMutationPayloadResolver{ActivityEventMutationPayload: payload}, nil
+}
+
+func backfillActivityEventMutation(ctx context.Context, input *BackfillActivityEventInput) (*ActivityEventMutationPayloadResolver, error) {
+	toCreate := &activityevent.CreateActivityEventInput{
+		NamespacePath: input.NamespacePath,
+		Action:        input.Action,
+		TargetType:    input.TargetType,
+		TargetID:      gid.FromGlobalID(input.TargetID),
+	}
+
+	if input.Payload != nil {
+		if !json.Valid([]byte(*input.Payload)) {
+			return nil, errors.New("activity event payload must be valid JSON", errors.WithErrorCode(errors.EInvalid))
+		}
+		toCreate.Payload = json.RawMessage(*input.Payload)
+	}
+
+	activityEvent, err := getActivityService(ctx).BackfillActivityEvent(ctx, toCreate)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := ActivityEventMutationPayload{ClientMutationID: input.ClientMutationID, ActivityEvent: activityEvent, Problems: []Problem{}}
+	return &ActivityEventMutationPayloadResolver{ActivityEventMutationPayload: payload}, nil
+}
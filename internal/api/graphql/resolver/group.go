@@ -30,8 +30,11 @@ import (
 // GroupConnectionQueryArgs are used to query a group connection
 type GroupConnectionQueryArgs struct {
 	ConnectionQueryArgs
-	ParentPath *string
-	Search     *string
+	ParentPath            *string
+	Search                *string
+	MigrationTargetsFor   *string
+	HasRunnerTags         *bool
+	DirectMembershipsOnly *bool
 }
 
 // GroupQueryArgs are used to query a single group
@@ -157,6 +160,14 @@ func (r *GroupResolver) FullPath() string {
 	return r.group.FullPath
 }
 
+// RunnerTags resolver
+func (r *GroupResolver) RunnerTags() *[]string {
+	if r.group.RunnerTags == nil {
+		return nil
+	}
+	return &r.group.RunnerTags
+}
+
 // Metadata resolver
 func (r *GroupResolver) Metadata() *MetadataResolver {
 	return &MetadataResolver{metadata: &r.group.Metadata}
@@ -319,6 +330,10 @@ func (r *GroupResolver) ManagedIdentities(ctx context.Context, args *ManagedIden
 		input.Sort = &sort
 	}
 
+	if args.UnusedSince != nil {
+		input.UnusedSince = &args.UnusedSince.Time
+	}
+
 	if args.IncludeInherited != nil && *args.IncludeInherited {
 		input.IncludeInherited = true
 	}
@@ -446,6 +461,11 @@ func groupsQuery(ctx context.Context, args *GroupConnectionQueryArgs) (*GroupCon
 		PaginationOptions: &pagination.Options{First: args.First, Last: args.Last, After: args.After, Before: args.Before},
 		Search:            args.Search,
 		RootOnly:          (args.ParentPath != nil) && (*args.ParentPath == ""),
+		HasRunnerTags:     args.HasRunnerTags,
+	}
+
+	if args.DirectMembershipsOnly != nil {
+		input.DirectMembershipsOnly = *args.DirectMembershipsOnly
 	}
 
 	if (args.ParentPath != nil) && (*args.ParentPath != "") {
@@ -456,6 +476,14 @@ func groupsQuery(ctx context.Context, args *GroupConnectionQueryArgs) (*GroupCon
 		input.ParentGroup = parent
 	}
 
+	if args.MigrationTargetsFor != nil {
+		group, err := getGroupService(ctx).GetGroupByFullPath(ctx, *args.MigrationTargetsFor)
+		if err != nil {
+			return nil, err
+		}
+		input.MigrationTargetsFor = group
+	}
+
 	if args.Sort != nil {
 		sort := db.GroupSortableField(*args.Sort)
 		input.Sort = &sort
@@ -491,6 +519,7 @@ type CreateGroupInput struct {
 	ClientMutationID *string
 	Name             string
 	ParentPath       *string
+	RunnerTags       *[]string
 	Description      string
 }
 
@@ -499,6 +528,7 @@ type UpdateGroupInput struct {
 	ClientMutationID *string
 	Metadata         *MetadataInput
 	Description      *string
+	RunnerTags       *[]string
 	GroupPath        *string
 	ID               *string
 }
@@ -519,6 +549,13 @@ type MigrateGroupInput struct {
 	GroupPath        string
 }
 
+// RenameGroupInput contains the input for renaming a group
+type RenameGroupInput struct {
+	ClientMutationID *string
+	GroupPath        string
+	NewName          string
+}
+
 func handleGroupMutationProblem(e error, clientMutationID *string) (*GroupMutationPayloadResolver, error) {
 	problem, err := buildProblem(e)
 	if err != nil {
@@ -530,6 +567,9 @@ func handleGroupMutationProblem(e error, clientMutationID *string) (*GroupMutati
 
 func createGroupMutation(ctx context.Context, input *CreateGroupInput) (*GroupMutationPayloadResolver, error) {
 	groupCreateOptions := models.Group{Name: input.Name, Description: input.Description}
+	if input.RunnerTags != nil {
+		groupCreateOptions.RunnerTags = *input.RunnerTags
+	}
 	groupService := getGroupService(ctx)
 
 	if input.ParentPath != nil {
@@ -582,6 +622,10 @@ func updateGroupMutation(ctx context.Context, input *UpdateGroupInput) (*GroupMu
 		group.Description = *input.Description
 	}
 
+	if input.RunnerTags != nil {
+		group.RunnerTags = *input.RunnerTags
+	}
+
 	group, err = groupService.UpdateGroup(ctx, group)
 	if err != nil {
 		return nil, err
@@ -661,6 +705,23 @@ func migrateGroupMutation(ctx context.Context, input *MigrateGroupInput) (*Group
 	return &GroupMutationPayloadResolver{GroupMutationPayload: payload}, nil
 }
 
+func renameGroupMutation(ctx context.Context, input *RenameGroupInput) (*GroupMutationPayloadResolver, error) {
+	groupService := getGroupService(ctx)
+
+	group, err := groupService.GetGroupByFullPath(ctx, input.GroupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err = groupService.RenameGroup(ctx, group.Metadata.ID, input.NewName)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := GroupMutationPayload{ClientMutationID: input.ClientMutationID, Group: group, Problems: []Problem{}}
+	return &GroupMutationPayloadResolver{GroupMutationPayload: payload}, nil
+}
+
 /* Group loader */
 
 const groupLoaderKey = "group"
@@ -690,15 +751,16 @@ func loadGroup(ctx context.Context, id string) (*models.Group, error) {
 }
 
 func groupBatchFunc(ctx context.Context, ids []string) (loader.DataBatch, error) {
-	groups, err := getGroupService(ctx).GetGroupsByIDs(ctx, ids)
+	// Groups the caller can't view are excluded, which results in a not found error for those keys only
+	groups, err := getGroupService(ctx).GetGroupsByIDsMap(ctx, ids)
 	if err != nil {
 		return nil, err
 	}
 
 	// Build map of results
 	batch := loader.DataBatch{}
-	for _, result := range groups {
-		batch[result.Metadata.ID] = result
+	for id, result := range groups {
+		batch[id] = result
 	}
 
 	return batch, nil
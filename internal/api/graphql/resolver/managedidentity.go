@@ -26,6 +26,8 @@ type ManagedIdentityConnectionQueryArgs struct {
 	GroupPath        *string
 	IncludeInherited *bool
 	Search           *string
+	UnusedSince      *graphql.Time
+	Assignable       *bool
 }
 
 // ManagedIdentityQueryArgs are used to query a single managedIdentity
@@ -301,6 +303,14 @@ func (r *ManagedIdentityResolver) CreatedBy() string {
 	return r.managedIdentity.CreatedBy
 }
 
+// LastUsedAt resolver
+func (r *ManagedIdentityResolver) LastUsedAt() *graphql.Time {
+	if r.managedIdentity.LastUsedAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.managedIdentity.LastUsedAt}
+}
+
 // AliasSourceID resolver
 func (r *ManagedIdentityResolver) AliasSourceID() *string {
 	if r.managedIdentity.AliasSourceID == nil {
@@ -317,6 +327,10 @@ func (r *ManagedIdentityResolver) AliasSource(ctx context.Context) (*ManagedIden
 		return nil, nil
 	}
 
+	if r.managedIdentity.AliasSource != nil {
+		return &ManagedIdentityResolver{managedIdentity: r.managedIdentity.AliasSource}, nil
+	}
+
 	identity, err := loadManagedIdentity(ctx, *r.managedIdentity.AliasSourceID)
 	if err != nil {
 		return nil, err
@@ -385,7 +399,7 @@ func managedIdentityQuery(ctx context.Context, args *ManagedIdentityQueryArgs) (
 
 	switch {
 	case args.ID != nil:
-		managedIdentity, err = managedIdentityService.GetManagedIdentityByID(ctx, gid.FromGlobalID(*args.ID))
+		managedIdentity, err = managedIdentityService.GetManagedIdentityByID(ctx, gid.FromGlobalID(*args.ID), managedidentity.WithNotFoundWhenForbidden())
 	case args.Path != nil:
 		managedIdentity, err = managedIdentityService.GetManagedIdentityByPath(ctx, *args.Path)
 	default:
@@ -526,11 +540,12 @@ type CreateManagedIdentityInput struct {
 		Type                      models.ManagedIdentityAccessRuleType
 		RunStage                  models.JobType
 	}
-	Type        string
-	Name        string
-	Description string
-	GroupPath   string
-	Data        string
+	RequireAccessRulesForAllRunStages *bool
+	Type                              string
+	Name                              string
+	Description                       string
+	GroupPath                         string
+	Data                              string
 }
 
 // UpdateManagedIdentityInput contains the input for updating a managedIdentity
@@ -927,6 +942,10 @@ func createManagedIdentityMutation(ctx context.Context, input *CreateManagedIden
 		}
 	}
 
+	if input.RequireAccessRulesForAllRunStages != nil {
+		managedIdentityCreateOptions.RequireAccessRulesForAllRunStages = *input.RequireAccessRulesForAllRunStages
+	}
+
 	managedIdentityService := getManagedIdentityService(ctx)
 
 	createdManagedIdentity, err := managedIdentityService.CreateManagedIdentity(ctx, &managedIdentityCreateOptions)
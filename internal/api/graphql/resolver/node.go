@@ -6,6 +6,7 @@ import (
 
 	graphql "github.com/graph-gophers/graphql-go"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/gid"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/managedidentity"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
 )
 
@@ -265,7 +266,7 @@ func node(ctx context.Context, globalID string) (*NodeResolver, error) {
 		}
 		resolver = &RunnerSessionResolver{session: session}
 	case gid.ManagedIdentityType:
-		managedIdentity, err := getManagedIdentityService(ctx).GetManagedIdentityByID(ctx, parsedGlobalID.ID)
+		managedIdentity, err := getManagedIdentityService(ctx).GetManagedIdentityByID(ctx, parsedGlobalID.ID, managedidentity.WithNotFoundWhenForbidden())
 		if err != nil {
 			retErr = err
 			break
@@ -1,6 +1,10 @@
 package resolver
 
-import "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
+import (
+	"sort"
+
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
+)
 
 // ProblemType represents the type of problem
 type ProblemType string
@@ -34,9 +38,16 @@ func buildProblem(err error) (*Problem, error) {
 	code := errors.ErrorCode(err)
 	pType, ok := tharsisErrorToProblemType[code]
 	if ok {
+		// Report the fields named in the error details, if there are any.
+		fields := []string{}
+		for field := range errors.ErrorDetails(err) {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
 		return &Problem{
 			Message: errors.ErrorMessage(err),
-			Field:   &[]string{},
+			Field:   &fields,
 			Type:    pType,
 		}, nil
 	}
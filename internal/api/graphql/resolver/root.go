@@ -100,6 +100,16 @@ func (r RootResolver) UnlockWorkspace(ctx context.Context, args *struct{ Input *
 	return response, nil
 }
 
+// ForceUnlockWorkspace mutation releases a stale run lock on a workspace
+func (r RootResolver) ForceUnlockWorkspace(ctx context.Context, args *struct{ Input *ForceUnlockWorkspaceInput }) (*WorkspaceMutationPayloadResolver, error) {
+	response, err := forceUnlockWorkspaceMutation(ctx, args.Input)
+	if err != nil {
+		return handleWorkspaceMutationProblem(err, args.Input.ClientMutationID)
+	}
+
+	return response, nil
+}
+
 // MigrateWorkspace migrates an existing workspace
 func (r RootResolver) MigrateWorkspace(ctx context.Context,
 	args *struct{ Input *MigrateWorkspaceInput }) (*WorkspaceMutationPayloadResolver, error) {
@@ -176,6 +186,17 @@ func (r RootResolver) MigrateGroup(ctx context.Context,
 	return response, nil
 }
 
+// RenameGroup renames an existing group
+func (r RootResolver) RenameGroup(ctx context.Context,
+	args *struct{ Input *RenameGroupInput }) (*GroupMutationPayloadResolver, error) {
+	response, err := renameGroupMutation(ctx, args.Input)
+	if err != nil {
+		return handleGroupMutationProblem(err, args.Input.ClientMutationID)
+	}
+
+	return response, nil
+}
+
 /* Run Queries and Mutations */
 
 // Run query returns a run by ID
@@ -955,7 +976,7 @@ func (r RootResolver) CreateSCIMToken(ctx context.Context) (*SCIMTokenPayload, e
 	return response, nil
 }
 
-/* ActivityEvents Query */
+/* ActivityEvents Query and Mutations */
 
 // ActivityEvents query returns an activity event connection
 func (r RootResolver) ActivityEvents(ctx context.Context,
@@ -964,6 +985,16 @@ func (r RootResolver) ActivityEvents(ctx context.Context,
 	return activityEventsQuery(ctx, args)
 }
 
+// BackfillActivityEvent creates an activity event that's missing from the audit history
+func (r RootResolver) BackfillActivityEvent(ctx context.Context,
+	args *struct{ Input *BackfillActivityEventInput }) (*ActivityEventMutationPayloadResolver, error) {
+	response, err := backfillActivityEventMutation(ctx, args.Input)
+	if err != nil {
+		return handleActivityEventMutationProblem(err, args.Input.ClientMutationID)
+	}
+	return response, nil
+}
+
 /* VCSProvider queries and mutations */
 
 // ResetVCSProviderOAuthToken returns a new OAuth authorization code URL that can
@@ -23,8 +23,9 @@ import (
 // RunConnectionQueryArgs are used to query a run connection
 type RunConnectionQueryArgs struct {
 	ConnectionQueryArgs
-	WorkspacePath *string
-	WorkspaceID   *string
+	WorkspacePath              *string
+	WorkspaceID                *string
+	AccessRuleEvaluationFailed *bool
 }
 
 // RunQueryArgs are used to query a single run
@@ -150,6 +151,16 @@ func (r *RunResolver) TargetAddresses() []string {
 	return r.run.TargetAddresses
 }
 
+// AccessRuleEvaluations resolver
+func (r *RunResolver) AccessRuleEvaluations() []*ManagedIdentityAccessRuleEvaluationResolver {
+	resolvers := []*ManagedIdentityAccessRuleEvaluationResolver{}
+	for _, evaluation := range r.run.AccessRuleEvaluations {
+		evaluationCopy := evaluation
+		resolvers = append(resolvers, &ManagedIdentityAccessRuleEvaluationResolver{evaluation: &evaluationCopy})
+	}
+	return resolvers
+}
+
 // Refresh resolver
 func (r *RunResolver) Refresh() bool {
 	return r.run.Refresh
@@ -332,6 +343,44 @@ func (r *RunVariableResolver) Value() *string {
 	return r.variable.Value
 }
 
+// ManagedIdentityAccessRuleEvaluationResolver resolves the outcome of a managed identity access rule evaluated for a run
+type ManagedIdentityAccessRuleEvaluationResolver struct {
+	evaluation *models.ManagedIdentityAccessRuleEvaluation
+}
+
+// ManagedIdentityID resolver
+func (r *ManagedIdentityAccessRuleEvaluationResolver) ManagedIdentityID() string {
+	return gid.ToGlobalID(gid.ManagedIdentityType, r.evaluation.ManagedIdentityID)
+}
+
+// RuleID resolver
+func (r *ManagedIdentityAccessRuleEvaluationResolver) RuleID() string {
+	return gid.ToGlobalID(gid.ManagedIdentityAccessRuleType, r.evaluation.RuleID)
+}
+
+// RuleType resolver
+func (r *ManagedIdentityAccessRuleEvaluationResolver) RuleType() string {
+	return string(r.evaluation.RuleType)
+}
+
+// RunStage resolver
+func (r *ManagedIdentityAccessRuleEvaluationResolver) RunStage() string {
+	return string(r.evaluation.RunStage)
+}
+
+// Passed resolver
+func (r *ManagedIdentityAccessRuleEvaluationResolver) Passed() bool {
+	return r.evaluation.Passed
+}
+
+// Diagnostic resolver
+func (r *ManagedIdentityAccessRuleEvaluationResolver) Diagnostic() *string {
+	if r.evaluation.Diagnostic == "" {
+		return nil
+	}
+	return &r.evaluation.Diagnostic
+}
+
 func runQuery(ctx context.Context, args *RunQueryArgs) (*RunResolver, error) {
 	runService := getRunService(ctx)
 
@@ -352,7 +401,8 @@ func runsQuery(ctx context.Context, args *RunConnectionQueryArgs) (*RunConnectio
 	}
 
 	input := run.GetRunsInput{
-		PaginationOptions: &pagination.Options{First: args.First, Last: args.Last, After: args.After, Before: args.Before},
+		PaginationOptions:          &pagination.Options{First: args.First, Last: args.Last, After: args.After, Before: args.Before},
+		AccessRuleEvaluationFailed: args.AccessRuleEvaluationFailed,
 	}
 
 	if args.WorkspaceID != nil && args.WorkspacePath != nil {
@@ -419,6 +469,12 @@ type CreateRunInput struct {
 		Category string
 		Hcl      bool
 	}
+	VariableOverrides *[]struct {
+		Key      string
+		Value    string
+		Category string
+		Hcl      bool
+	}
 	TerraformVersion *string
 	TargetAddresses  *[]string
 	Refresh          *bool
@@ -494,6 +550,22 @@ func createRunMutation(ctx context.Context, input *CreateRunInput) (*RunMutation
 		runOptions.Variables = variables
 	}
 
+	if input.VariableOverrides != nil {
+		overrides := []models.Variable{}
+
+		for _, v := range *input.VariableOverrides {
+			vCopy := v
+			overrides = append(overrides, models.Variable{
+				Key:      v.Key,
+				Value:    &vCopy.Value,
+				Hcl:      v.Hcl,
+				Category: models.VariableCategory(v.Category),
+			})
+		}
+
+		runOptions.VariableOverrides = overrides
+	}
+
 	if input.IsDestroy != nil {
 		runOptions.IsDestroy = *input.IsDestroy
 	}
@@ -159,6 +159,14 @@ func (r *RunnerResolver) Disabled() bool {
 	return r.runner.Disabled
 }
 
+// Tags resolver
+func (r *RunnerResolver) Tags() []string {
+	if r.runner.Tags == nil {
+		return []string{}
+	}
+	return r.runner.Tags
+}
+
 // Metadata resolver
 func (r *RunnerResolver) Metadata() *MetadataResolver {
 	return &MetadataResolver{metadata: &r.runner.Metadata}
@@ -298,6 +306,7 @@ type CreateRunnerInput struct {
 	ClientMutationID *string
 	GroupPath        string
 	Disabled         *bool
+	Tags             *[]string
 	Name             string
 	Description      string
 }
@@ -308,6 +317,7 @@ type UpdateRunnerInput struct {
 	ID               string
 	Metadata         *MetadataInput
 	Disabled         *bool
+	Tags             *[]string
 	Description      string
 }
 
@@ -341,12 +351,18 @@ func createRunnerMutation(ctx context.Context, input *CreateRunnerInput) (*Runne
 		return nil, err
 	}
 
-	createdRunner, err := getRunnerService(ctx).CreateRunner(ctx, &runner.CreateRunnerInput{
+	toCreate := &runner.CreateRunnerInput{
 		Name:        input.Name,
 		Description: input.Description,
 		GroupID:     group.Metadata.ID,
 		Disabled:    input.Disabled,
-	})
+	}
+
+	if input.Tags != nil {
+		toCreate.Tags = *input.Tags
+	}
+
+	createdRunner, err := getRunnerService(ctx).CreateRunner(ctx, toCreate)
 	if err != nil {
 		return nil, err
 	}
@@ -379,6 +395,10 @@ func updateRunnerMutation(ctx context.Context, input *UpdateRunnerInput) (*Runne
 		runner.Disabled = *input.Disabled
 	}
 
+	if input.Tags != nil {
+		runner.Tags = *input.Tags
+	}
+
 	runner, err = service.UpdateRunner(ctx, runner)
 	if err != nil {
 		return nil, err
@@ -47,6 +47,11 @@ func (r *NamespaceVariableResolver) Value() *string {
 	return r.variable.Value
 }
 
+// Overridable resolver
+func (r *NamespaceVariableResolver) Overridable() bool {
+	return !r.variable.NonOverridable
+}
+
 // Metadata resolver
 func (r *NamespaceVariableResolver) Metadata() *MetadataResolver {
 	return &MetadataResolver{metadata: &r.variable.Metadata}
@@ -113,6 +118,7 @@ type CreateNamespaceVariableInput struct {
 	Key              string
 	Value            string
 	Hcl              bool
+	Overridable      *bool
 }
 
 // UpdateNamespaceVariableInput is the input for updating a variable
@@ -122,6 +128,7 @@ type UpdateNamespaceVariableInput struct {
 	Key              string
 	Value            string
 	Hcl              bool
+	Overridable      *bool
 }
 
 // DeleteNamespaceVariableInput is the input for deleting a variable
@@ -136,9 +143,10 @@ type SetNamespaceVariablesInput struct {
 	NamespacePath    string
 	Category         models.VariableCategory
 	Variables        []struct {
-		Key   string
-		Value string
-		Hcl   bool
+		Key         string
+		Value       string
+		Hcl         bool
+		Overridable *bool
 	}
 }
 
@@ -162,6 +170,8 @@ func setNamespaceVariablesMutation(ctx context.Context, input *SetNamespaceVaria
 			Value:         &vCopy.Value,
 			Category:      input.Category,
 			NamespacePath: input.NamespacePath,
+			// Variables are overridable unless explicitly disabled
+			NonOverridable: v.Overridable != nil && !*v.Overridable,
 		})
 	}
 
@@ -184,6 +194,8 @@ func createNamespaceVariableMutation(ctx context.Context, input *CreateNamespace
 		Hcl:           input.Hcl,
 		Key:           input.Key,
 		Value:         &input.Value,
+		// Variables are overridable unless explicitly disabled
+		NonOverridable: input.Overridable != nil && !*input.Overridable,
 	})
 	if err != nil {
 		return nil, err
@@ -205,6 +217,10 @@ func updateNamespaceVariableMutation(ctx context.Context, input *UpdateNamespace
 	variable.Key = input.Key
 	variable.Value = &input.Value
 
+	if input.Overridable != nil {
+		variable.NonOverridable = !*input.Overridable
+	}
+
 	updatedVar, err := service.UpdateVariable(ctx, variable)
 	if err != nil {
 		return nil, err
@@ -25,8 +25,9 @@ import (
 // WorkspaceConnectionQueryArgs are used to query a workspace connection
 type WorkspaceConnectionQueryArgs struct {
 	ConnectionQueryArgs
-	GroupPath *string
-	Search    *string
+	GroupPath   *string
+	Search      *string
+	Environment *string
 }
 
 // WorkspaceQueryArgs are used to query a single workspace
@@ -234,7 +235,17 @@ func (r *WorkspaceResolver) DirtyState() bool {
 
 // Locked resolver
 func (r *WorkspaceResolver) Locked() bool {
-	return r.workspace.Locked
+	return r.workspace.IsLocked()
+}
+
+// LockedByRunID resolver
+func (r *WorkspaceResolver) LockedByRunID() *string {
+	return r.workspace.LockedByRunID
+}
+
+// EffectiveRunnerTags resolver
+func (r *WorkspaceResolver) EffectiveRunnerTags(ctx context.Context) ([]string, error) {
+	return getWorkspaceService(ctx).GetEffectiveRunnerTags(ctx, r.workspace.Metadata.ID)
 }
 
 // ServiceAccounts resolver
@@ -278,10 +289,18 @@ func (r *WorkspaceResolver) ManagedIdentities(ctx context.Context, args *Managed
 		input.Sort = &sort
 	}
 
+	if args.UnusedSince != nil {
+		input.UnusedSince = &args.UnusedSince.Time
+	}
+
 	if args.IncludeInherited != nil && *args.IncludeInherited {
 		input.IncludeInherited = true
 	}
 
+	if args.Assignable != nil && *args.Assignable {
+		input.AssignableToWorkspaceID = &r.workspace.Metadata.ID
+	}
+
 	return NewManagedIdentityConnectionResolver(ctx, &input)
 }
 
@@ -392,6 +411,28 @@ func (r *WorkspaceResolver) PreventDestroyPlan() bool {
 	return r.workspace.PreventDestroyPlan
 }
 
+// Environment resolver
+func (r *WorkspaceResolver) Environment() *string {
+	if r.workspace.Environment == "" {
+		return nil
+	}
+	return &r.workspace.Environment
+}
+
+// AutoApply resolver
+func (r *WorkspaceResolver) AutoApply() bool {
+	return r.workspace.AutoApply
+}
+
+// AllowedManagedIdentityTypes resolver
+func (r *WorkspaceResolver) AllowedManagedIdentityTypes() []string {
+	types := []string{}
+	for _, identityType := range r.workspace.AllowedManagedIdentityTypes {
+		types = append(types, string(identityType))
+	}
+	return types
+}
+
 // VCSEvents resolver
 func (r *WorkspaceResolver) VCSEvents(ctx context.Context, args *VCSEventConnectionQueryArgs) (*VCSEventConnectionResolver, error) {
 	if err := args.Validate(); err != nil {
@@ -433,6 +474,7 @@ func workspacesQuery(ctx context.Context, args *WorkspaceConnectionQueryArgs) (*
 	input := workspace.GetWorkspacesInput{
 		PaginationOptions: &pagination.Options{First: args.First, Last: args.Last, After: args.After, Before: args.Before},
 		Search:            args.Search,
+		Environment:       args.Environment,
 	}
 
 	if args.GroupPath != nil {
@@ -479,27 +521,33 @@ func (r *WorkspaceMutationPayloadResolver) Workspace() *WorkspaceResolver {
 
 // CreateWorkspaceInput contains the input for creating a new workspace
 type CreateWorkspaceInput struct {
-	ClientMutationID   *string
-	MaxJobDuration     *int32
-	TerraformVersion   *string
-	PreventDestroyPlan *bool
-	Name               string
-	GroupPath          string
-	Description        string
+	ClientMutationID            *string
+	MaxJobDuration              *int32
+	TerraformVersion            *string
+	PreventDestroyPlan          *bool
+	Environment                 *string
+	AutoApply                   *bool
+	AllowedManagedIdentityTypes *[]string
+	Name                        string
+	GroupPath                   string
+	Description                 string
 }
 
 // UpdateWorkspaceInput contains the input for updating a workspace
 // Find the workspace via either ID or WorkspacePath.
 // Modify the other fields.
 type UpdateWorkspaceInput struct {
-	ClientMutationID   *string
-	Metadata           *MetadataInput
-	MaxJobDuration     *int32
-	TerraformVersion   *string
-	Description        *string
-	PreventDestroyPlan *bool
-	WorkspacePath      *string
-	ID                 *string
+	ClientMutationID            *string
+	Metadata                    *MetadataInput
+	MaxJobDuration              *int32
+	TerraformVersion            *string
+	Description                 *string
+	PreventDestroyPlan          *bool
+	Environment                 *string
+	AutoApply                   *bool
+	AllowedManagedIdentityTypes *[]string
+	WorkspacePath               *string
+	ID                          *string
 }
 
 // DeleteWorkspaceInput contains the input for deleting a workspace
@@ -523,6 +571,12 @@ type UnlockWorkspaceInput struct {
 	WorkspacePath    string
 }
 
+// ForceUnlockWorkspaceInput contains the input for force unlocking a workspace
+type ForceUnlockWorkspaceInput struct {
+	ClientMutationID *string
+	WorkspacePath    string
+}
+
 // MigrateWorkspaceInput contains the input for migrating a workspace
 type MigrateWorkspaceInput struct {
 	ClientMutationID *string
@@ -566,6 +620,18 @@ func createWorkspaceMutation(ctx context.Context, input *CreateWorkspaceInput) (
 		PreventDestroyPlan: preventDestroyPlan,
 	}
 
+	if input.Environment != nil {
+		wsCreateOptions.Environment = *input.Environment
+	}
+
+	if input.AutoApply != nil {
+		wsCreateOptions.AutoApply = *input.AutoApply
+	}
+
+	if input.AllowedManagedIdentityTypes != nil {
+		wsCreateOptions.AllowedManagedIdentityTypes = toManagedIdentityTypes(*input.AllowedManagedIdentityTypes)
+	}
+
 	createdWorkspace, err := getWorkspaceService(ctx).CreateWorkspace(ctx, &wsCreateOptions)
 	if err != nil {
 		return nil, err
@@ -621,6 +687,21 @@ func updateWorkspaceMutation(ctx context.Context, input *UpdateWorkspaceInput) (
 		ws.PreventDestroyPlan = *input.PreventDestroyPlan
 	}
 
+	// Update Environment if specified; an empty string clears it.
+	if input.Environment != nil {
+		ws.Environment = *input.Environment
+	}
+
+	// Update AutoApply if specified.
+	if input.AutoApply != nil {
+		ws.AutoApply = *input.AutoApply
+	}
+
+	// Update AllowedManagedIdentityTypes if specified; an empty list allows all types.
+	if input.AllowedManagedIdentityTypes != nil {
+		ws.AllowedManagedIdentityTypes = toManagedIdentityTypes(*input.AllowedManagedIdentityTypes)
+	}
+
 	ws, err = wsService.UpdateWorkspace(ctx, ws)
 	if err != nil {
 		return nil, err
@@ -704,6 +785,28 @@ func unlockWorkspaceMutation(ctx context.Context, input *UnlockWorkspaceInput) (
 	return &WorkspaceMutationPayloadResolver{WorkspaceMutationPayload: payload}, nil
 }
 
+func forceUnlockWorkspaceMutation(ctx context.Context, input *ForceUnlockWorkspaceInput) (*WorkspaceMutationPayloadResolver, error) {
+	wsService := getWorkspaceService(ctx)
+
+	ws, err := wsService.GetWorkspaceByFullPath(ctx, input.WorkspacePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = wsService.ForceUnlockWorkspace(ctx, ws.Metadata.ID); err != nil {
+		return nil, err
+	}
+
+	// Get the latest version of the workspace.
+	ws, err = wsService.GetWorkspaceByID(ctx, ws.Metadata.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := WorkspaceMutationPayload{ClientMutationID: input.ClientMutationID, Workspace: ws, Problems: []Problem{}}
+	return &WorkspaceMutationPayloadResolver{WorkspaceMutationPayload: payload}, nil
+}
+
 func migrateWorkspaceMutation(ctx context.Context, input *MigrateWorkspaceInput) (*WorkspaceMutationPayloadResolver, error) {
 	groupService := getGroupService(ctx)
 	workspaceService := getWorkspaceService(ctx)
@@ -826,3 +929,11 @@ func workspaceBatchFunc(ctx context.Context, ids []string) (loader.DataBatch, er
 
 	return batch, nil
 }
+
+func toManagedIdentityTypes(types []string) []models.ManagedIdentityType {
+	result := []models.ManagedIdentityType{}
+	for _, identityType := range types {
+		result = append(result, models.ManagedIdentityType(identityType))
+	}
+	return result
+}
@@ -97,6 +97,11 @@ func (r *WorkspaceVCSProviderLinkResolver) WebhookDisabled() bool {
 	return r.workspaceVCSProviderLink.WebhookDisabled
 }
 
+// RunDebounceWindowSeconds resolver
+func (r *WorkspaceVCSProviderLinkResolver) RunDebounceWindowSeconds() int32 {
+	return r.workspaceVCSProviderLink.RunDebounceWindowSeconds
+}
+
 /* WorkspaceVCSProviderLink Mutation Resolvers */
 
 // WorkspaceVCSProviderLinkMutationPayload is the response payload for a workspace vcs provider mutation
@@ -108,8 +113,9 @@ type WorkspaceVCSProviderLinkMutationPayload struct {
 
 // WorkspaceVCSProviderLinkMutationPayloadResolver resolver a WorkspaceVCSProviderLinkMutationPayload
 type WorkspaceVCSProviderLinkMutationPayloadResolver struct {
-	webhookToken []byte
-	webhookURL   *string
+	webhookToken  []byte
+	webhookURL    *string
+	webhookSecret *string
 	WorkspaceVCSProviderLinkMutationPayload
 }
 
@@ -137,31 +143,38 @@ func (r *WorkspaceVCSProviderLinkMutationPayloadResolver) WebhookURL() *string {
 	return r.webhookURL
 }
 
+// WebhookSecret field resolver
+func (r *WorkspaceVCSProviderLinkMutationPayloadResolver) WebhookSecret() *string {
+	return r.webhookSecret
+}
+
 // CreateWorkspaceVCSProviderLinkInput is the input for creating a workspace VCS provider link.
 type CreateWorkspaceVCSProviderLinkInput struct {
-	ClientMutationID    *string
-	ModuleDirectory     *string
-	Branch              *string
-	TagRegex            *string
-	WorkspacePath       string
-	ProviderID          string
-	RepositoryPath      string
-	GlobPatterns        []string
-	AutoSpeculativePlan bool
-	WebhookDisabled     bool
+	ClientMutationID         *string
+	ModuleDirectory          *string
+	Branch                   *string
+	TagRegex                 *string
+	WorkspacePath            string
+	ProviderID               string
+	RepositoryPath           string
+	GlobPatterns             []string
+	AutoSpeculativePlan      bool
+	WebhookDisabled          bool
+	RunDebounceWindowSeconds *int32
 }
 
 // UpdateWorkspaceVCSProviderLinkInput is the input for updating a workspace VCS provider link.
 type UpdateWorkspaceVCSProviderLinkInput struct {
-	ClientMutationID    *string
-	Metadata            *MetadataInput
-	ModuleDirectory     *string
-	TagRegex            *string
-	Branch              *string
-	AutoSpeculativePlan *bool
-	WebhookDisabled     *bool
-	ID                  string
-	GlobPatterns        []string
+	ClientMutationID         *string
+	Metadata                 *MetadataInput
+	ModuleDirectory          *string
+	TagRegex                 *string
+	Branch                   *string
+	AutoSpeculativePlan      *bool
+	WebhookDisabled          *bool
+	RunDebounceWindowSeconds *int32
+	ID                       string
+	GlobPatterns             []string
 }
 
 // DeleteWorkspaceVCSProviderLinkInput is the input for deleting a workspace VCS provider link.
@@ -202,6 +215,10 @@ func createWorkspaceVCSProviderLinkMutation(ctx context.Context, input *CreateWo
 		WebhookDisabled:     input.WebhookDisabled,
 	}
 
+	if input.RunDebounceWindowSeconds != nil {
+		linkCreateOptions.RunDebounceWindowSeconds = *input.RunDebounceWindowSeconds
+	}
+
 	response, err := service.CreateWorkspaceVCSProviderLink(ctx, linkCreateOptions)
 	if err != nil {
 		return nil, err
@@ -217,6 +234,7 @@ func createWorkspaceVCSProviderLinkMutation(ctx context.Context, input *CreateWo
 		WorkspaceVCSProviderLinkMutationPayload: payload,
 		webhookToken:                            response.WebhookToken,
 		webhookURL:                              response.WebhookURL,
+		webhookSecret:                           response.WebhookSecret,
 	}, nil
 }
 
@@ -268,6 +286,10 @@ func updateWorkspaceVCSProviderLinkMutation(ctx context.Context, input *UpdateWo
 		link.WebhookDisabled = *input.WebhookDisabled
 	}
 
+	if input.RunDebounceWindowSeconds != nil {
+		link.RunDebounceWindowSeconds = *input.RunDebounceWindowSeconds
+	}
+
 	updatedLink, err := vcsService.UpdateWorkspaceVCSProviderLink(ctx, &vcs.UpdateWorkspaceVCSProviderLinkInput{Link: link})
 	if err != nil {
 		return nil, err
@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/maintenance"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/plugin"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/reservednames"
 	rnr "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/runner"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/activityevent"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/cli"
@@ -178,30 +180,50 @@ func New(ctx context.Context, cfg *config.Config, logger logger.Logger, apiVersi
 
 	limits := limits.NewLimitChecker(dbClient)
 
+	workspaceEnvironments := []string{}
+	for _, environment := range strings.Split(cfg.WorkspaceEnvironments, ",") {
+		if environment = strings.TrimSpace(environment); environment != "" {
+			workspaceEnvironments = append(workspaceEnvironments, environment)
+		}
+	}
+
+	reservedNames := reservednames.New(strings.Split(cfg.ReservedNames, ","))
+
+	vcsProviderAllowedHosts := []string{}
+	for _, host := range strings.Split(cfg.VCSProviderAllowedHosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			vcsProviderAllowedHosts = append(vcsProviderAllowedHosts, host)
+		}
+	}
+
 	// Services.
 	var (
 		versionService             = version.NewService(dbClient, apiVersion)
 		activityService            = activityevent.NewService(dbClient, logger)
 		userService                = user.NewService(logger, dbClient)
 		namespaceMembershipService = namespacemembership.NewService(logger, dbClient, activityService)
-		groupService               = group.NewService(logger, dbClient, limits, namespaceMembershipService, activityService)
+		groupService               = group.NewService(logger, dbClient, limits, namespaceMembershipService, activityService, reservedNames)
 		cliService                 = cli.NewService(logger, httpClient, taskManager, cliStore, cfg.TerraformCLIVersionConstraint)
-		workspaceService           = workspace.NewService(logger, dbClient, limits, artifactStore, eventManager, cliService, activityService)
+		workspaceService           = workspace.NewService(logger, dbClient, limits, artifactStore, eventManager, cliService, activityService, workspaceEnvironments)
 		jobService                 = job.NewService(logger, dbClient, tharsisIDP, logStreamManager, eventManager, runStateManager)
-		managedIdentityService     = managedidentity.NewService(logger, dbClient, limits, managedIdentityDelegates, workspaceService, jobService, activityService)
-		saService                  = serviceaccount.NewService(logger, dbClient, limits, tharsisIDP, openIDConfigFetcher, activityService)
-		variableService            = variable.NewService(logger, dbClient, limits, activityService)
-		teamService                = team.NewService(logger, dbClient, activityService)
-		providerRegistryService    = providerregistry.NewService(logger, dbClient, limits, providerRegistryStore, activityService)
-		moduleRegistryService      = moduleregistry.NewService(logger, dbClient, limits, moduleRegistryStore, activityService, taskManager)
-		gpgKeyService              = gpgkey.NewService(logger, dbClient, limits, activityService)
-		scimService                = scim.NewService(logger, dbClient, tharsisIDP)
-		runService                 = run.NewService(logger, dbClient, artifactStore, eventManager, jobService, cliService, activityService, moduleRegistryService, run.NewModuleResolver(moduleRegistryService, httpClient, logger, cfg.TharsisAPIURL), runStateManager, limits)
-		runnerService              = runner.NewService(logger, dbClient, limits, activityService, logStreamManager, eventManager)
-		roleService                = role.NewService(logger, dbClient, activityService)
-		resourceLimitService       = resourcelimit.NewService(logger, dbClient)
-		providerMirrorService      = providermirror.NewService(logger, dbClient, httpClient, limits, activityService, mirrorStore)
-		maintenanceModeService     = maint.NewService(logger, dbClient)
+		managedIdentityService     = managedidentity.NewService(logger, dbClient, limits, managedIdentityDelegates, workspaceService, jobService, activityService, managedidentity.ServiceOptions{
+			ReservedNames:              reservedNames,
+			MaxPageSize:                int32(cfg.ManagedIdentityMaxPageSize),
+			LimitActivityEventsEnabled: cfg.ResourceLimitActivityEventsEnabled,
+		})
+		saService               = serviceaccount.NewService(logger, dbClient, limits, tharsisIDP, openIDConfigFetcher, activityService)
+		variableService         = variable.NewService(logger, dbClient, limits, activityService)
+		teamService             = team.NewService(logger, dbClient, activityService)
+		providerRegistryService = providerregistry.NewService(logger, dbClient, limits, providerRegistryStore, activityService)
+		moduleRegistryService   = moduleregistry.NewService(logger, dbClient, limits, moduleRegistryStore, activityService, taskManager)
+		gpgKeyService           = gpgkey.NewService(logger, dbClient, limits, activityService)
+		scimService             = scim.NewService(logger, dbClient, tharsisIDP)
+		runService              = run.NewService(logger, dbClient, artifactStore, eventManager, jobService, workspaceService, cliService, activityService, moduleRegistryService, run.NewModuleResolver(moduleRegistryService, httpClient, logger, cfg.TharsisAPIURL), runStateManager, limits, run.NewNoopRunPolicyEvaluator(), cfg.PlanDiffMaxDepth)
+		runnerService           = runner.NewService(logger, dbClient, limits, activityService, logStreamManager, eventManager)
+		roleService             = role.NewService(logger, dbClient, activityService)
+		resourceLimitService    = resourcelimit.NewService(logger, dbClient)
+		providerMirrorService   = providermirror.NewService(logger, dbClient, httpClient, limits, activityService, mirrorStore)
+		maintenanceModeService  = maint.NewService(logger, dbClient)
 	)
 
 	vcsService, err := vcs.NewService(
@@ -217,6 +239,7 @@ func New(ctx context.Context, cfg *config.Config, logger logger.Logger, apiVersi
 		taskManager,
 		cfg.TharsisAPIURL,
 		cfg.VCSRepositorySizeLimit,
+		vcsProviderAllowedHosts,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize vcs service %v", err)
@@ -28,6 +28,9 @@ const (
 	defaultOtelTraceEnabled            = false
 	defaultHTTPRateLimit               = 60 // in calls per second
 	defaultTerraformCLIVersions        = ">= 1.0.0"
+	defaultWorkspaceEnvironments       = "production,staging,development"
+	defaultReservedNames               = ""
+	defaultManagedIdentityMaxPageSize  = 1000
 )
 
 // IdpConfig contains the config fields for an Identity Provider
@@ -96,6 +99,16 @@ type Config struct {
 	// TerraformCLIVersionConstraint is a comma-separated list of constraints used to limit the returned Terraform CLI versions.
 	TerraformCLIVersionConstraint string `yaml:"terraform_cli_version_constraint" env:"TERRAFORM_CLI_VERSION_CONSTRAINT"`
 
+	// WorkspaceEnvironments is a comma-separated list of the environments a workspace can be tagged with.
+	WorkspaceEnvironments string `yaml:"workspace_environments" env:"WORKSPACE_ENVIRONMENTS"`
+
+	// ReservedNames is a comma-separated list of names that can't be used for groups or managed identities.
+	ReservedNames string `yaml:"reserved_names" env:"RESERVED_NAMES"`
+
+	// VCSProviderAllowedHosts is an optional comma-separated list of host patterns (e.g. *.example.com) that VCS providers can use.
+	// Any host is allowed when it's empty.
+	VCSProviderAllowedHosts string `yaml:"vcs_provider_allowed_hosts" env:"VCS_PROVIDER_ALLOWED_HOSTS"`
+
 	// The OIDC identity providers
 	OauthProviders []IdpConfig `yaml:"oauth_providers"`
 
@@ -119,6 +132,12 @@ type Config struct {
 	// HTTP rate limit value
 	HTTPRateLimit int `yaml:"http_rate_limit" env:"HTTP_RATE_LIMIT"`
 
+	// Max number of managed identities returned in a single page, zero disables the cap
+	ManagedIdentityMaxPageSize int `yaml:"managed_identity_max_page_size" env:"MANAGED_IDENTITY_MAX_PAGE_SIZE"`
+
+	// Max depth of nested values rendered in a plan diff, zero disables the limit
+	PlanDiffMaxDepth int `yaml:"plan_diff_max_depth" env:"PLAN_DIFF_MAX_DEPTH"`
+
 	OtelTraceCollectorPort int  `yaml:"otel_trace_port" env:"OTEL_TRACE_PORT"`
 	OtelTraceEnabled       bool `yaml:"otel_trace_enabled" env:"OTEL_TRACE_ENABLED"`
 
@@ -127,6 +146,9 @@ type Config struct {
 
 	// Whether to auto migrate the database
 	DBAutoMigrateEnabled bool `yaml:"db_auto_migrate_enabled" env:"DB_AUTO_MIGRATE_ENABLED"`
+
+	// Whether to create an activity event when a request is rejected because of a resource limit
+	ResourceLimitActivityEventsEnabled bool `yaml:"resource_limit_activity_events_enabled" env:"RESOURCE_LIMIT_ACTIVITY_EVENTS_ENABLED"`
 }
 
 // Validate validates the application configuration.
@@ -153,6 +175,9 @@ func Load(file string, logger logger.Logger) (*Config, error) {
 		OtelTraceEnabled:              defaultOtelTraceEnabled,
 		HTTPRateLimit:                 defaultHTTPRateLimit,
 		TerraformCLIVersionConstraint: defaultTerraformCLIVersions,
+		WorkspaceEnvironments:         defaultWorkspaceEnvironments,
+		ReservedNames:                 defaultReservedNames,
+		ManagedIdentityMaxPageSize:    defaultManagedIdentityMaxPageSize,
 	}
 
 	// load from YAML config file
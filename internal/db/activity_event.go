@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
@@ -20,6 +21,7 @@ import (
 type ActivityEvents interface {
 	GetActivityEvents(ctx context.Context, input *GetActivityEventsInput) (*ActivityEventsResult, error)
 	CreateActivityEvent(ctx context.Context, input *models.ActivityEvent) (*models.ActivityEvent, error)
+	GetActivityEventCountsByDay(ctx context.Context, filter *ActivityEventFilter, from, to time.Time) (map[string]int, error)
 }
 
 // ActivityEventSortableField represents the fields that an activity event can be sorted by
@@ -122,6 +124,7 @@ var activityEventFieldList = append(metadataFieldList,
 	"role_target_id",
 	"runner_target_id",
 	"terraform_provider_version_mirror_target_id",
+	"backfilled",
 )
 
 // NewActivityEvents returns an instance of the ActivityEvents interface
@@ -136,64 +139,23 @@ func (m *activityEvents) GetActivityEvents(ctx context.Context,
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
-	ex := goqu.And()
-	if input.Filter != nil {
-		if input.Filter.ActivityEventIDs != nil {
-			ex = ex.Append(goqu.I("activity_events.id").In(input.Filter.ActivityEventIDs))
-		}
-		if input.Filter.UserID != nil {
-			ex = ex.Append(goqu.I("activity_events.user_id").Eq(input.Filter.UserID))
-		}
-		if input.Filter.ServiceAccountID != nil {
-			ex = ex.Append(goqu.I("activity_events.service_account_id").Eq(input.Filter.ServiceAccountID))
-		}
-		if input.Filter.NamespacePath != nil {
-			if input.Filter.IncludeNested {
-				// Return activity events connected directly to the specified namespace
-				// _OR_ to any namespace in/under the specified namespace.
-				orex := goqu.Or()
-				// Add both plain path and with slash anything else.
-				orex = orex.Append(goqu.I("namespaces.path").Eq(input.Filter.NamespacePath),
-					goqu.I("namespaces.path").Like(*input.Filter.NamespacePath+"/%"))
-				ex = ex.Append(orex)
-			} else {
-				// Return only activity events connected directly to a specified namespace.
-				ex = ex.Append(goqu.I("namespaces.path").In(input.Filter.NamespacePath))
-			}
-		}
-		if input.Filter.TimeRangeStart != nil {
-			// Must use UTC here otherwise, queries will return unexpected results.
-			ex = ex.Append(goqu.I("activity_events.created_at").Gte(input.Filter.TimeRangeStart.UTC()))
-		}
-		if input.Filter.TimeRangeEnd != nil {
-			// Must use UTC here otherwise, queries will return unexpected results.
-			ex = ex.Append(goqu.I("activity_events.created_at").Lte(input.Filter.TimeRangeEnd.UTC()))
-		}
-		if input.Filter.Actions != nil {
-			ex = ex.Append(goqu.I("activity_events.action").In(input.Filter.Actions))
-		}
-		if input.Filter.TargetTypes != nil {
-			ex = ex.Append(goqu.I("activity_events.target_type").In(input.Filter.TargetTypes))
-		}
-
-		// This filters out any activity events related to any namespace to which a user or
-		//  service account may have LOST membership after the activity events were created.
-		if input.Filter.NamespaceMembershipRequirement != nil {
-			ex = ex.Append(namespaceMembershipExpressionBuilder{
-				userID:           input.Filter.NamespaceMembershipRequirement.UserID,
-				serviceAccountID: input.Filter.NamespaceMembershipRequirement.ServiceAccountID,
-			}.build())
-		}
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
 	}
 
-	sortDirection := pagination.AscSort
+	ex := activityEventFilterExpression(input.Filter)
 
-	var sortBy *pagination.FieldDescriptor
+	// Default to sorting by creation time so the cursor is keyed on (created_at, id); since new events are
+	// always appended at the end, events inserted while paginating won't cause duplicates or gaps.
+	sort := ActivityEventSortableFieldCreatedAtAsc
 	if input.Sort != nil {
-		sortDirection = input.Sort.getSortDirection()
-		sortBy = input.Sort.getFieldDescriptor()
+		sort = *input.Sort
 	}
 
+	sortDirection := sort.getSortDirection()
+	sortBy := sort.getFieldDescriptor()
+
 	// Do a join with the namespaces table in order to get the namespace path rather than just the ID.
 	// Use a left join in order to get all the activity events, even those with no path.
 	query := dialect.From("activity_events").
@@ -244,6 +206,63 @@ func (m *activityEvents) GetActivityEvents(ctx context.Context,
 	return &result, nil
 }
 
+// GetActivityEventCountsByDay returns the number of activity events matching the filter for each UTC day
+// in the time range, keyed by date in YYYY-MM-DD format. Days without any events are omitted.
+func (m *activityEvents) GetActivityEventCountsByDay(ctx context.Context,
+	filter *ActivityEventFilter, from, to time.Time,
+) (map[string]int, error) {
+	ctx, span := tracer.Start(ctx, "db.GetActivityEventCountsByDay")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	// Must use UTC here otherwise, queries will return unexpected results.
+	ex := activityEventFilterExpression(filter).Append(
+		goqu.I("activity_events.created_at").Gte(from.UTC()),
+		goqu.I("activity_events.created_at").Lte(to.UTC()),
+	)
+
+	day := goqu.L("to_char(activity_events.created_at, 'YYYY-MM-DD')")
+
+	sql, args, err := dialect.From("activity_events").
+		Prepared(true).
+		LeftJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"activity_events.namespace_id": goqu.I("namespaces.id")})).
+		Select(day, goqu.COUNT("*")).
+		Where(ex).
+		GroupBy(day).
+		ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return nil, err
+	}
+
+	rows, err := m.dbClient.getConnection(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to execute query")
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var date string
+		var count int
+		if err := rows.Scan(&date, &count); err != nil {
+			tracing.RecordError(span, err, "failed to scan row")
+			return nil, err
+		}
+
+		counts[date] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		tracing.RecordError(span, err, "failed to read rows")
+		return nil, err
+	}
+
+	return counts, nil
+}
+
 func (m *activityEvents) CreateActivityEvent(ctx context.Context, input *models.ActivityEvent) (*models.ActivityEvent, error) {
 	ctx, span := tracer.Start(ctx, "db.CreateActivityEvent")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -370,6 +389,7 @@ func (m *activityEvents) CreateActivityEvent(ctx context.Context, input *models.
 		"role_target_id":                       roleTargetID,
 		"runner_target_id":                     runnerTargetID,
 		"terraform_provider_version_mirror_target_id": terraformProviderVersionMirrorTargetID,
+		"backfilled": input.Backfilled,
 	}
 
 	sql, args, err := dialect.Insert("activity_events").
@@ -534,6 +554,7 @@ func scanActivityEvent(row scanner, withOtherTables bool) (*models.ActivityEvent
 		&roleTargetID,
 		&runnerTargetID,
 		&terraformProviderVersionMirrorTargetID,
+		&activityEvent.Backfilled,
 	}
 
 	// Balance the number of selected fields and fields to scan out.
@@ -592,3 +613,58 @@ func scanActivityEvent(row scanner, withOtherTables bool) (*models.ActivityEvent
 
 	return activityEvent, nil
 }
+
+// activityEventFilterExpression builds the where expression for the supported activity event filter fields
+func activityEventFilterExpression(filter *ActivityEventFilter) exp.ExpressionList {
+	ex := goqu.And()
+	if filter != nil {
+		if filter.ActivityEventIDs != nil {
+			ex = ex.Append(goqu.I("activity_events.id").In(filter.ActivityEventIDs))
+		}
+		if filter.UserID != nil {
+			ex = ex.Append(goqu.I("activity_events.user_id").Eq(filter.UserID))
+		}
+		if filter.ServiceAccountID != nil {
+			ex = ex.Append(goqu.I("activity_events.service_account_id").Eq(filter.ServiceAccountID))
+		}
+		if filter.NamespacePath != nil {
+			if filter.IncludeNested {
+				// Return activity events connected directly to the specified namespace
+				// _OR_ to any namespace in/under the specified namespace.
+				orex := goqu.Or()
+				// Add both plain path and with slash anything else.
+				orex = orex.Append(goqu.I("namespaces.path").Eq(filter.NamespacePath),
+					goqu.I("namespaces.path").Like(*filter.NamespacePath+"/%"))
+				ex = ex.Append(orex)
+			} else {
+				// Return only activity events connected directly to a specified namespace.
+				ex = ex.Append(goqu.I("namespaces.path").In(filter.NamespacePath))
+			}
+		}
+		if filter.TimeRangeStart != nil {
+			// Must use UTC here otherwise, queries will return unexpected results.
+			ex = ex.Append(goqu.I("activity_events.created_at").Gte(filter.TimeRangeStart.UTC()))
+		}
+		if filter.TimeRangeEnd != nil {
+			// Must use UTC here otherwise, queries will return unexpected results.
+			ex = ex.Append(goqu.I("activity_events.created_at").Lte(filter.TimeRangeEnd.UTC()))
+		}
+		if filter.Actions != nil {
+			ex = ex.Append(goqu.I("activity_events.action").In(filter.Actions))
+		}
+		if filter.TargetTypes != nil {
+			ex = ex.Append(goqu.I("activity_events.target_type").In(filter.TargetTypes))
+		}
+
+		// This filters out any activity events related to any namespace to which a user or
+		//  service account may have LOST membership after the activity events were created.
+		if filter.NamespaceMembershipRequirement != nil {
+			ex = ex.Append(namespaceMembershipExpressionBuilder{
+				userID:           filter.NamespaceMembershipRequirement.UserID,
+				serviceAccountID: filter.NamespaceMembershipRequirement.ServiceAccountID,
+			}.build())
+		}
+	}
+
+	return ex
+}
@@ -804,6 +804,157 @@ func TestGetActivityEvents(t *testing.T) {
 	}
 }
 
+func TestGetActivityEventsWithConcurrentInserts(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	warmupItems, err := createWarmupActivityEvents(ctx, testClient, activityEventWarmups{
+		groups:          standardWarmupGroupsForActivityEvents,
+		workspaces:      standardWarmupWorkspacesForActivityEvents,
+		users:           standardWarmupUsersForActivityEvents,
+		serviceAccounts: standardWarmupServiceAccountsForActivityEvents,
+		variables:       standardWarmupVariablesForActivityEvents,
+		activityEvents:  []models.ActivityEvent{},
+	})
+	require.Nil(t, err)
+
+	createdIDs := []string{}
+	createEvents := func(count int) {
+		for i := 0; i < count; i++ {
+			event, cErr := testClient.client.ActivityEvents.CreateActivityEvent(ctx, &models.ActivityEvent{
+				UserID:     ptr.String(warmupItems.users[0].Metadata.ID),
+				Action:     models.ActionUpdate,
+				TargetType: models.TargetVariable,
+				TargetID:   warmupItems.variables[0].Metadata.ID,
+			})
+			require.Nil(t, cErr)
+			createdIDs = append(createdIDs, event.Metadata.ID)
+		}
+	}
+
+	createEvents(3)
+
+	// Scan the events two at a time, inserting new events between page fetches.
+	seenIDs := []string{}
+	var after *string
+	for page := 0; ; page++ {
+		result, gErr := testClient.client.ActivityEvents.GetActivityEvents(ctx, &GetActivityEventsInput{
+			PaginationOptions: &pagination.Options{
+				First: ptr.Int32(2),
+				After: after,
+			},
+		})
+		require.Nil(t, gErr)
+
+		for _, event := range result.ActivityEvents {
+			seenIDs = append(seenIDs, event.Metadata.ID)
+		}
+
+		if !result.PageInfo.HasNextPage {
+			break
+		}
+
+		after, gErr = result.PageInfo.Cursor(&result.ActivityEvents[len(result.ActivityEvents)-1])
+		require.Nil(t, gErr)
+
+		if page < 2 {
+			createEvents(2)
+		}
+	}
+
+	// Every event, including those inserted during the scan, must be returned exactly once in creation order.
+	assert.Equal(t, createdIDs, seenIDs)
+}
+
+func TestGetActivityEventCountsByDay(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	warmupItems, err := createWarmupActivityEvents(ctx, testClient, activityEventWarmups{
+		groups:          standardWarmupGroupsForActivityEvents,
+		workspaces:      standardWarmupWorkspacesForActivityEvents,
+		users:           standardWarmupUsersForActivityEvents,
+		serviceAccounts: standardWarmupServiceAccountsForActivityEvents,
+		variables:       standardWarmupVariablesForActivityEvents,
+		activityEvents:  buildStandardWarmupActivityEvents(t),
+	})
+	require.Nil(t, err)
+
+	// Spread the warmup events across several days.
+	firstDay := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	creationTimes := []time.Time{
+		firstDay.Add(2 * time.Hour),
+		firstDay.Add(23 * time.Hour),
+		firstDay.Add(26 * time.Hour),
+		firstDay.Add(73 * time.Hour),
+	}
+	require.Equal(t, len(creationTimes), len(warmupItems.activityEvents))
+
+	for ix, event := range warmupItems.activityEvents {
+		_, err = testClient.client.conn.Exec(ctx, "UPDATE activity_events SET created_at = $1 WHERE id = $2",
+			creationTimes[ix], event.Metadata.ID)
+		require.Nil(t, err)
+	}
+
+	type testCase struct {
+		filter       *ActivityEventFilter
+		from         time.Time
+		to           time.Time
+		expectCounts map[string]int
+		name         string
+	}
+
+	testCases := []testCase{
+		{
+			name: "all days in range",
+			from: firstDay,
+			to:   firstDay.Add(7 * 24 * time.Hour),
+			expectCounts: map[string]int{
+				"2024-03-01": 2,
+				"2024-03-02": 1,
+				"2024-03-04": 1,
+			},
+		},
+		{
+			name: "range excludes first and last events",
+			from: firstDay.Add(3 * time.Hour),
+			to:   firstDay.Add(48 * time.Hour),
+			expectCounts: map[string]int{
+				"2024-03-01": 1,
+				"2024-03-02": 1,
+			},
+		},
+		{
+			name: "filter by action",
+			filter: &ActivityEventFilter{
+				Actions: []models.ActivityEventAction{models.ActionCancel, models.ActionLock},
+			},
+			from: firstDay,
+			to:   firstDay.Add(7 * 24 * time.Hour),
+			expectCounts: map[string]int{
+				"2024-03-02": 1,
+				"2024-03-04": 1,
+			},
+		},
+		{
+			name:         "no events in range",
+			from:         firstDay.Add(-7 * 24 * time.Hour),
+			to:           firstDay.Add(-time.Hour),
+			expectCounts: map[string]int{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			counts, err := testClient.client.ActivityEvents.GetActivityEventCountsByDay(ctx, test.filter, test.from, test.to)
+			require.Nil(t, err)
+			assert.Equal(t, test.expectCounts, counts)
+		})
+	}
+}
+
 func TestCreateActivityEvent(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -858,6 +1009,30 @@ func TestCreateActivityEvent(t *testing.T) {
 			},
 		},
 
+		{
+			name: "positive, backfilled",
+			toCreate: &models.ActivityEvent{
+				UserID:        ptr.String(warmupItems.users[0].Metadata.ID),
+				NamespacePath: ptr.String("top-level-group-0-for-activity-events/workspace-0-for-activity-events"),
+				Action:        models.ActionCreate,
+				TargetType:    models.TargetVariable,
+				TargetID:      positiveTargetID,
+				Backfilled:    true,
+			},
+			expectCreated: &models.ActivityEvent{
+				Metadata: models.ResourceMetadata{
+					Version:           initialResourceVersion,
+					CreationTimestamp: &now,
+				},
+				UserID:        ptr.String(warmupItems.users[0].Metadata.ID),
+				NamespacePath: ptr.String("top-level-group-0-for-activity-events/workspace-0-for-activity-events"),
+				Action:        models.ActionCreate,
+				TargetType:    models.TargetVariable,
+				TargetID:      positiveTargetID,
+				Backfilled:    true,
+			},
+		},
+
 		{
 			name: "negative, non-existent user ID",
 			toCreate: &models.ActivityEvent{
@@ -1271,6 +1446,7 @@ func compareActivityEvents(t *testing.T, expected, actual *models.ActivityEvent,
 	assert.Equal(t, expected.TargetType, actual.TargetType)
 	assert.Equal(t, expected.TargetID, actual.TargetID)
 	assert.Equal(t, expected.Payload, actual.Payload)
+	assert.Equal(t, expected.Backfilled, actual.Backfilled)
 }
 
 // compareStringPointers compares two string pointers.
@@ -115,6 +115,11 @@ func (a *applies) GetApplies(ctx context.Context, input *GetAppliesInput) (*Appl
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
+	}
+
 	ex := goqu.Ex{}
 
 	if input.Filter != nil {
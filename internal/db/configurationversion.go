@@ -96,6 +96,11 @@ func (c *configurationVersions) GetConfigurationVersions(ctx context.Context, in
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
+	}
+
 	ex := goqu.And()
 
 	if input.Filter != nil {
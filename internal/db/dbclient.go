@@ -102,6 +102,7 @@ type Client struct {
 	LogStreams                       LogStreams
 	RunnerSessions                   RunnerSessions
 	SchemaMigrations                 SchemaMigrations
+	WorkspaceRunLocks                WorkspaceRunLocks
 }
 
 // NewClient creates a new Client
@@ -202,6 +203,7 @@ func NewClient(
 	dbClient.LogStreams = NewLogStreams(dbClient)
 	dbClient.RunnerSessions = NewRunnerSessions(dbClient)
 	dbClient.SchemaMigrations = NewSchemaMigrations(dbClient)
+	dbClient.WorkspaceRunLocks = NewWorkspaceRunLocks(dbClient)
 
 	return dbClient, nil
 }
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jackc/pgx/v4"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
@@ -19,6 +20,7 @@ import (
 type GPGKeys interface {
 	GetGPGKeyByID(ctx context.Context, id string) (*models.GPGKey, error)
 	GetGPGKeys(ctx context.Context, input *GetGPGKeysInput) (*GPGKeysResult, error)
+	GetGPGKeyCount(ctx context.Context, filter *GPGKeyFilter) (int32, error)
 	CreateGPGKey(ctx context.Context, gpgKey *models.GPGKey) (*models.GPGKey, error)
 	DeleteGPGKey(ctx context.Context, gpgKey *models.GPGKey) error
 }
@@ -110,22 +112,13 @@ func (t *terraformGPGKeys) GetGPGKeys(ctx context.Context, input *GetGPGKeysInpu
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
-	ex := goqu.And()
-
-	if input.Filter != nil {
-		if input.Filter.GPGKeyID != nil {
-			ex = ex.Append(goqu.I("gpg_keys.gpg_key_id").Eq(*input.Filter.GPGKeyID))
-		}
-
-		if input.Filter.KeyIDs != nil {
-			ex = ex.Append(goqu.I("gpg_keys.id").In(input.Filter.KeyIDs))
-		}
-
-		if input.Filter.NamespacePaths != nil {
-			ex = ex.Append(goqu.I("namespaces.path").In(input.Filter.NamespacePaths))
-		}
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
 	}
 
+	ex := gpgKeyFilterExpression(input.Filter)
+
 	query := dialect.From(goqu.T("gpg_keys")).
 		Select(t.getSelectFields()...).
 		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"gpg_keys.group_id": goqu.I("namespaces.group_id")})).
@@ -186,6 +179,24 @@ func (t *terraformGPGKeys) GetGPGKeys(ctx context.Context, input *GetGPGKeysInpu
 	return &result, nil
 }
 
+func (t *terraformGPGKeys) GetGPGKeyCount(ctx context.Context, filter *GPGKeyFilter) (int32, error) {
+	ctx, span := tracer.Start(ctx, "db.GetGPGKeyCount")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	query := dialect.From(goqu.T("gpg_keys")).
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"gpg_keys.group_id": goqu.I("namespaces.group_id")})).
+		Where(gpgKeyFilterExpression(filter))
+
+	count, err := pagination.Count(ctx, t.dbClient.getConnection(ctx), query)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to count GPG keys")
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (t *terraformGPGKeys) CreateGPGKey(ctx context.Context, gpgKey *models.GPGKey) (*models.GPGKey, error) {
 	ctx, span := tracer.Start(ctx, "db.CreateGPGKey")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -359,3 +370,26 @@ func scanGPGKey(row scanner, withResourcePath bool) (*models.GPGKey, error) {
 
 	return gpgKey, nil
 }
+
+// gpgKeyFilterExpression builds the where expression for a GPG key filter
+func gpgKeyFilterExpression(filter *GPGKeyFilter) exp.ExpressionList {
+	ex := goqu.And()
+
+	if filter == nil {
+		return ex
+	}
+
+	if filter.GPGKeyID != nil {
+		ex = ex.Append(goqu.I("gpg_keys.gpg_key_id").Eq(*filter.GPGKeyID))
+	}
+
+	if filter.KeyIDs != nil {
+		ex = ex.Append(goqu.I("gpg_keys.id").In(filter.KeyIDs))
+	}
+
+	if filter.NamespacePaths != nil {
+		ex = ex.Append(goqu.I("namespaces.path").In(filter.NamespacePaths))
+	}
+
+	return ex
+}
@@ -568,6 +568,52 @@ func TestGetGPGKeys(t *testing.T) {
 	}
 }
 
+func TestGetGPGKeyCount(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	createdWarmupGroups, createdWarmupGPGKeys, err := createWarmupGPGKeys(ctx, testClient,
+		standardWarmupGroupsForGPGKeys, standardWarmupGPGKeys)
+	require.Nil(t, err)
+
+	type testCase struct {
+		filter *GPGKeyFilter
+		name   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "no filter",
+		},
+		{
+			name:   "filter by namespace path",
+			filter: &GPGKeyFilter{NamespacePaths: []string{createdWarmupGroups[0].FullPath}},
+		},
+		{
+			name:   "filter by key IDs",
+			filter: &GPGKeyFilter{KeyIDs: []string{createdWarmupGPGKeys[0].Metadata.ID}},
+		},
+		{
+			name:   "filter matches nothing",
+			filter: &GPGKeyFilter{NamespacePaths: []string{"this-path-does-not-exist"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.GPGKeys.GetGPGKeys(ctx, &GetGPGKeysInput{Filter: test.filter})
+			require.Nil(t, err)
+
+			count, err := testClient.client.GPGKeys.GetGPGKeyCount(ctx, test.filter)
+			require.Nil(t, err)
+
+			assert.Equal(t, result.PageInfo.TotalCount, count)
+			assert.Equal(t, int32(len(result.GPGKeys)), count)
+		})
+	}
+}
+
 func TestCreateGPGKey(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -5,6 +5,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -25,10 +26,14 @@ type Groups interface {
 	GetGroupByID(ctx context.Context, id string) (*models.Group, error)
 	// GetGroupByFullPath returns a group by full path
 	GetGroupByFullPath(ctx context.Context, path string) (*models.Group, error)
+	// GetRootGroup returns the top-level ancestor of a group, or the group itself if it is a root group
+	GetRootGroup(ctx context.Context, groupID string) (*models.Group, error)
 	// DeleteGroup deletes a group
 	DeleteGroup(ctx context.Context, group *models.Group) error
 	// GetGroups returns a list of groups
 	GetGroups(ctx context.Context, input *GetGroupsInput) (*GroupsResult, error)
+	// GetGroupCount returns the number of groups that match the filter
+	GetGroupCount(ctx context.Context, filter *GroupFilter) (int32, error)
 	// CreateGroup creates a new group
 	CreateGroup(ctx context.Context, group *models.Group) (*models.Group, error)
 	// UpdateGroup updates an existing group
@@ -37,17 +42,47 @@ type Groups interface {
 	GetChildDepth(ctx context.Context, group *models.Group) (int, error)
 	// MigrateGroup re-parents an existing group
 	MigrateGroup(ctx context.Context, group, newParentGroup *models.Group) (*models.Group, error)
+	// RenameGroup changes the name of a group and the paths of its descendants
+	RenameGroup(ctx context.Context, group *models.Group, newName string) (*models.Group, error)
+	// GetGroupDeletionPreview returns the number of resources that would be removed if the group was deleted
+	GetGroupDeletionPreview(ctx context.Context, group *models.Group) (*GroupDeletionPreview, error)
+	// ReassignCreatedBy changes the creator of the groups created by a subject, optionally only within a namespace path,
+	// and returns the number of groups updated
+	ReassignCreatedBy(ctx context.Context, fromSubject, toSubject string, namespacePath *string) (int, error)
+	// GetCreatedBySubjects returns the distinct subjects that created the groups in or under a namespace path
+	GetCreatedBySubjects(ctx context.Context, namespacePath string) ([]string, error)
+}
+
+// GroupDeletionPreview contains the number of resources that would be removed along with a group
+type GroupDeletionPreview struct {
+	DescendantGroupCount     int32
+	WorkspaceCount           int32
+	ManagedIdentityCount     int32
+	TerraformProviderCount   int32
+	NamespaceMembershipCount int32
+	// ExternalAliasCount is the number of aliases outside the group's subtree whose sources would be deleted
+	// with the group; only the count is returned since the caller may not be able to view those aliases
+	ExternalAliasCount int32
 }
 
 // GroupFilter contains the supported fields for filtering Group resources
 type GroupFilter struct {
-	ParentID               *string
+	ParentID   *string
+	PathPrefix *string
+	// ExcludePathPrefix omits the group with this path and all of its descendants
+	ExcludePathPrefix      *string
 	UserMemberID           *string
 	ServiceAccountMemberID *string
 	Search                 *string
 	GroupIDs               []string
 	NamespaceIDs           []string
 	RootOnly               bool
+	// HasRunnerTags filters the groups by whether they define a non-empty list of runner tags
+	// instead of inheriting them from their parent
+	HasRunnerTags *bool
+	// DirectMembershipsOnly excludes groups where the user or service account
+	// is only a member of an ancestor group
+	DirectMembershipsOnly bool
 }
 
 // GroupSortableField represents the fields that a group can be sorted by
@@ -59,6 +94,8 @@ const (
 	GroupSortableFieldFullPathDesc   GroupSortableField = "FULL_PATH_DESC"
 	GroupSortableFieldGroupLevelAsc  GroupSortableField = "GROUP_LEVEL_ASC"
 	GroupSortableFieldGroupLevelDesc GroupSortableField = "GROUP_LEVEL_DESC"
+	GroupSortableFieldCreatedAtAsc   GroupSortableField = "CREATED_AT_ASC"
+	GroupSortableFieldCreatedAtDesc  GroupSortableField = "CREATED_AT_DESC"
 )
 
 func (gs GroupSortableField) getFieldDescriptor() *pagination.FieldDescriptor {
@@ -67,6 +104,8 @@ func (gs GroupSortableField) getFieldDescriptor() *pagination.FieldDescriptor {
 		return &pagination.FieldDescriptor{Key: "full_path", Table: "namespaces", Col: "path"}
 	case GroupSortableFieldGroupLevelAsc, GroupSortableFieldGroupLevelDesc:
 		return &pagination.FieldDescriptor{Key: "full_path", Table: "namespaces", Col: "path"}
+	case GroupSortableFieldCreatedAtAsc, GroupSortableFieldCreatedAtDesc:
+		return &pagination.FieldDescriptor{Key: "created_at", Table: "groups", Col: "created_at"}
 	default:
 		return nil
 	}
@@ -104,9 +143,12 @@ type GetGroupsInput struct {
 type GroupsResult struct {
 	PageInfo *pagination.PageInfo
 	Groups   []models.Group
+	// CallerRoles maps group IDs to the caller's effective role in each group; it's only
+	// populated by the group service when requested
+	CallerRoles map[string]models.EffectiveRole
 }
 
-var groupFieldList = append(metadataFieldList, "name", "description", "parent_id", "created_by")
+var groupFieldList = append(metadataFieldList, "name", "description", "parent_id", "created_by", "runner_tags")
 
 type groups struct {
 	dbClient *Client
@@ -122,7 +164,7 @@ func (g *groups) GetGroupByID(ctx context.Context, id string) (*models.Group, er
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
-	return g.getGroup(ctx, goqu.Ex{"groups.id": id})
+	return g.getGroup(ctx, g.dbClient.getConnection(ctx), goqu.Ex{"groups.id": id})
 }
 
 func (g *groups) GetGroupByFullPath(ctx context.Context, path string) (*models.Group, error) {
@@ -130,64 +172,36 @@ func (g *groups) GetGroupByFullPath(ctx context.Context, path string) (*models.G
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
-	return g.getGroup(ctx, goqu.Ex{"namespaces.path": path})
+	return g.getGroup(ctx, g.dbClient.getConnection(ctx), goqu.Ex{"namespaces.path": path})
 }
 
-func (g *groups) GetGroups(ctx context.Context, input *GetGroupsInput) (*GroupsResult, error) {
-	ctx, span := tracer.Start(ctx, "db.GetGroups")
+func (g *groups) GetRootGroup(ctx context.Context, groupID string) (*models.Group, error) {
+	ctx, span := tracer.Start(ctx, "db.GetRootGroup")
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
-	ex := goqu.And()
-
-	if input.Filter != nil {
-		if input.Filter.RootOnly {
-			ex = ex.Append(goqu.I("groups.parent_id").Eq(nil))
-		}
-
-		if input.Filter.GroupIDs != nil {
-			// This check avoids an SQL syntax error if an empty slice is provided.
-			if len(input.Filter.GroupIDs) > 0 {
-				ex = ex.Append(goqu.I("groups.id").In(input.Filter.GroupIDs))
-			}
-		}
-
-		if input.Filter.ParentID != nil {
-			ex = ex.Append(goqu.I("groups.parent_id").Eq(*input.Filter.ParentID))
-		}
-
-		if input.Filter.NamespaceIDs != nil {
-			if len(input.Filter.NamespaceIDs) == 0 {
-				return &GroupsResult{
-					PageInfo: &pagination.PageInfo{},
-					Groups:   []models.Group{},
-				}, nil
-			}
-
-			ex = ex.Append(goqu.I("namespaces.id").In(input.Filter.NamespaceIDs))
-		}
+	return g.getRootGroup(ctx, g.dbClient.getConnection(ctx), groupID)
+}
 
-		if input.Filter.UserMemberID != nil {
-			ex = ex.Append(
-				namespaceMembershipExpressionBuilder{
-					userID: input.Filter.UserMemberID,
-				}.build(),
-			)
-		}
+func (g *groups) GetGroups(ctx context.Context, input *GetGroupsInput) (*GroupsResult, error) {
+	ctx, span := tracer.Start(ctx, "db.GetGroups")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
 
-		if input.Filter.ServiceAccountMemberID != nil {
-			ex = ex.Append(
-				namespaceMembershipExpressionBuilder{
-					serviceAccountID: input.Filter.ServiceAccountMemberID,
-				}.build(),
-			)
-		}
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
+	}
 
-		if input.Filter.Search != nil && *input.Filter.Search != "" {
-			ex = ex.Append(goqu.I("namespaces.path").ILike("%" + *input.Filter.Search + "%"))
-		}
+	if input.Filter != nil && input.Filter.NamespaceIDs != nil && len(input.Filter.NamespaceIDs) == 0 {
+		return &GroupsResult{
+			PageInfo: &pagination.PageInfo{},
+			Groups:   []models.Group{},
+		}, nil
 	}
 
+	ex := groupFilterExpression(input.Filter)
+
 	query := dialect.From(goqu.T("groups")).
 		Select(g.getSelectFields()...).
 		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"groups.id": goqu.I("namespaces.group_id")})).
@@ -247,6 +261,28 @@ func (g *groups) GetGroups(ctx context.Context, input *GetGroupsInput) (*GroupsR
 	return &result, nil
 }
 
+func (g *groups) GetGroupCount(ctx context.Context, filter *GroupFilter) (int32, error) {
+	ctx, span := tracer.Start(ctx, "db.GetGroupCount")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	if filter != nil && filter.NamespaceIDs != nil && len(filter.NamespaceIDs) == 0 {
+		return 0, nil
+	}
+
+	query := dialect.From(goqu.T("groups")).
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"groups.id": goqu.I("namespaces.group_id")})).
+		Where(groupFilterExpression(filter))
+
+	count, err := pagination.Count(ctx, g.dbClient.getConnection(ctx), query)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to count groups")
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (g *groups) CreateGroup(ctx context.Context, group *models.Group) (*models.Group, error) {
 	ctx, span := tracer.Start(ctx, "db.CreateGroup")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -267,6 +303,12 @@ func (g *groups) CreateGroup(ctx context.Context, group *models.Group) (*models.
 		}
 	}()
 
+	runnerTags, err := marshalRunnerTags(group.RunnerTags)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to marshal runner tags")
+		return nil, err
+	}
+
 	timestamp := currentTime()
 
 	sql, args, err := dialect.Insert("groups").
@@ -280,6 +322,7 @@ func (g *groups) CreateGroup(ctx context.Context, group *models.Group) (*models.
 			"description": nullableString(group.Description),
 			"parent_id":   nullableString(group.ParentID),
 			"created_by":  group.CreatedBy,
+			"runner_tags": runnerTags,
 		}).
 		Returning(groupFieldList...).ToSQL()
 	if err != nil {
@@ -340,6 +383,12 @@ func (g *groups) UpdateGroup(ctx context.Context, group *models.Group) (*models.
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	runnerTags, err := marshalRunnerTags(group.RunnerTags)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to marshal runner tags")
+		return nil, err
+	}
+
 	timestamp := currentTime()
 
 	sql, args, err := dialect.Update("groups").
@@ -349,6 +398,7 @@ func (g *groups) UpdateGroup(ctx context.Context, group *models.Group) (*models.
 				"version":     goqu.L("? + ?", goqu.C("version"), 1),
 				"updated_at":  timestamp,
 				"description": nullableString(group.Description),
+				"runner_tags": runnerTags,
 			},
 		).Where(goqu.Ex{"id": group.Metadata.ID, "version": group.Metadata.Version}).Returning(groupFieldList...).ToSQL()
 	if err != nil {
@@ -377,6 +427,34 @@ func (g *groups) UpdateGroup(ctx context.Context, group *models.Group) (*models.
 	return updatedGroup, nil
 }
 
+func (g *groups) ReassignCreatedBy(ctx context.Context, fromSubject, toSubject string, namespacePath *string) (int, error) {
+	ctx, span := tracer.Start(ctx, "db.ReassignCreatedBy")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	count, err := reassignCreatedBy(ctx, g.dbClient.getConnection(ctx), "groups", "id", fromSubject, toSubject, namespacePath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to reassign created by for groups")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (g *groups) GetCreatedBySubjects(ctx context.Context, namespacePath string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "db.GetCreatedBySubjects")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	subjects, err := getCreatedBySubjects(ctx, g.dbClient.getConnection(ctx), "groups", "id", namespacePath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get created by subjects for groups")
+		return nil, err
+	}
+
+	return subjects, nil
+}
+
 // GetChildDepth returns the depth of the descendant tree, EXCLUDING this group.
 func (g *groups) GetChildDepth(ctx context.Context, group *models.Group) (int, error) {
 	ctx, span := tracer.Start(ctx, "db.GetChildDepth")
@@ -423,6 +501,71 @@ func (g *groups) getChildDepth(ctx context.Context, conn connection, span trace.
 	return maxChildDepth + 1, nil
 }
 
+// RenameGroup changes the name of a group and rewrites the paths of all descendant namespaces in a single transaction.
+func (g *groups) RenameGroup(ctx context.Context, group *models.Group, newName string) (*models.Group, error) {
+	ctx, span := tracer.Start(ctx, "db.RenameGroup")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	newPath := newName
+	if parentPath, ok := group.ParentPath(); ok {
+		newPath = parentPath + "/" + newName
+	}
+
+	tx, err := g.dbClient.getConnection(ctx).Begin(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to begin DB transaction")
+		return nil, err
+	}
+
+	// Rollback is safe to call even if the tx is already closed, so if
+	// the tx commits successfully, this is a no-op
+	defer func() {
+		if txErr := tx.Rollback(ctx); txErr != nil && txErr != pgx.ErrTxClosed {
+			g.dbClient.logger.Errorf("failed to rollback tx for RenameGroup: %v", txErr)
+		}
+	}()
+
+	// Rewrite the namespace paths first, this will fail with a conflict if a sibling already has the new name.
+	if err = migrateNamespaces(ctx, tx, group.FullPath, newPath); err != nil {
+		tracing.RecordError(span, err, "failed to rename namespaces")
+		return nil, err
+	}
+
+	sql, args, err := dialect.Update("groups").
+		Prepared(true).
+		Set(
+			goqu.Record{
+				"version":    goqu.L("? + ?", goqu.C("version"), 1),
+				"updated_at": currentTime(),
+				"name":       newName,
+			},
+		).Where(goqu.Ex{"id": group.Metadata.ID, "version": group.Metadata.Version}).Returning(groupFieldList...).ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return nil, err
+	}
+
+	renamedGroup, err := scanGroup(tx.QueryRow(ctx, sql, args...), false)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			tracing.RecordError(span, err, "optimistic lock error")
+			return nil, ErrOptimisticLockError
+		}
+		tracing.RecordError(span, err, "failed to execute query")
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		tracing.RecordError(span, err, "failed to commit DB transaction")
+		return nil, err
+	}
+
+	renamedGroup.FullPath = newPath
+
+	return renamedGroup, nil
+}
+
 // MigrateGroup migrates a group.  If moving group to become a root group, newParentGroup must be set to nil.
 func (g *groups) MigrateGroup(ctx context.Context, group, newParentGroup *models.Group) (*models.Group, error) {
 	ctx, span := tracer.Start(ctx, "db.MigrateGroup")
@@ -657,16 +800,16 @@ func (g *groups) MigrateGroup(ctx context.Context, group, newParentGroup *models
 	}
 
 	// Find the new root group ID.
-	newRootGroupRow, err := getNamespaceByPath(ctx, tx, migratedGroup.GetRootGroupPath())
+	newRootGroup, err := g.getRootGroup(ctx, tx, migratedGroup.Metadata.ID)
 	if err != nil {
 		tracing.RecordError(span, err, "failed to get new root group")
 		return nil, fmt.Errorf("failed to get new root group: %v", err)
 	}
-	if newRootGroupRow == nil {
+	if newRootGroup == nil {
 		tracing.RecordError(span, nil, "failed to get new root group")
 		return nil, fmt.Errorf("failed to get new root group")
 	}
-	newRootGroupID := newRootGroupRow.groupID
+	newRootGroupID := newRootGroup.Metadata.ID
 
 	// For any affected Terraform providers, find all of them under the new path and update the root_group_id
 	// wherever it is not equal to the new root group ID.
@@ -832,7 +975,76 @@ func (g *groups) DeleteGroup(ctx context.Context, group *models.Group) error {
 	return nil
 }
 
-func (g *groups) getGroup(ctx context.Context, exp exp.Expression) (*models.Group, error) {
+func (g *groups) GetGroupDeletionPreview(ctx context.Context, group *models.Group) (*GroupDeletionPreview, error) {
+	ctx, span := tracer.Start(ctx, "db.GetGroupDeletionPreview")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	descendantPath := goqu.I("namespaces.path").Like(escapeLikePattern(group.FullPath) + "/%")
+	// Namespaces for the group itself and everything nested under it
+	subtreeNamespaces := goqu.Or(goqu.I("namespaces.path").Eq(group.FullPath), descendantPath)
+
+	subtreeGroupIDs := dialect.From("namespaces").
+		Select("namespaces.group_id").
+		Where(subtreeNamespaces, goqu.I("namespaces.group_id").IsNotNull())
+
+	sql, args, err := dialect.Select(
+		dialect.From("namespaces").
+			Select(goqu.COUNT("*")).
+			Where(descendantPath, goqu.I("namespaces.group_id").IsNotNull()),
+		dialect.From("namespaces").
+			Select(goqu.COUNT("*")).
+			Where(descendantPath, goqu.I("namespaces.workspace_id").IsNotNull()),
+		dialect.From("managed_identities").
+			Select(goqu.COUNT("*")).
+			Where(goqu.I("managed_identities.group_id").In(subtreeGroupIDs)),
+		dialect.From("terraform_providers").
+			Select(goqu.COUNT("*")).
+			Where(goqu.I("terraform_providers.group_id").In(subtreeGroupIDs)),
+		dialect.From("namespace_memberships").
+			Select(goqu.COUNT("*")).
+			InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"namespace_memberships.namespace_id": goqu.I("namespaces.id")})).
+			Where(subtreeNamespaces),
+	).Prepared(true).ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return nil, err
+	}
+
+	preview := &GroupDeletionPreview{}
+	if err = g.dbClient.getConnection(ctx).QueryRow(ctx, sql, args...).Scan(
+		&preview.DescendantGroupCount,
+		&preview.WorkspaceCount,
+		&preview.ManagedIdentityCount,
+		&preview.TerraformProviderCount,
+		&preview.NamespaceMembershipCount,
+	); err != nil {
+		tracing.RecordError(span, err, "failed to execute query")
+		return nil, err
+	}
+
+	// Aliases are deleted along with their source, so any outside the subtree would be removed as well.
+	preview.ExternalAliasCount, err = g.dbClient.ManagedIdentities.GetManagedIdentityCount(ctx, &ManagedIdentityFilter{
+		ExternalAliasesOfGroupPath: &group.FullPath,
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get external alias count")
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// getRootGroup uses the first segment of the group's path to find its root group.
+func (g *groups) getRootGroup(ctx context.Context, conn connection, groupID string) (*models.Group, error) {
+	rootPath := dialect.From(goqu.T("namespaces").As("group_namespace")).
+		Select(goqu.Func("split_part", goqu.I("group_namespace.path"), "/", 1)).
+		Where(goqu.Ex{"group_namespace.group_id": groupID})
+
+	return g.getGroup(ctx, conn, goqu.I("namespaces.path").Eq(rootPath))
+}
+
+func (g *groups) getGroup(ctx context.Context, conn connection, exp exp.Expression) (*models.Group, error) {
 	query := dialect.From(goqu.T("groups")).
 		Prepared(true).
 		Select(g.getSelectFields()...).
@@ -843,7 +1055,7 @@ func (g *groups) getGroup(ctx context.Context, exp exp.Expression) (*models.Grou
 		return nil, err
 	}
 
-	group, err := scanGroup(g.dbClient.getConnection(ctx).QueryRow(ctx, sql, args...), true)
+	group, err := scanGroup(conn.QueryRow(ctx, sql, args...), true)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
@@ -880,6 +1092,7 @@ func scanGroup(row scanner, withFullPath bool) (*models.Group, error) {
 		&description,
 		&parentID,
 		&group.CreatedBy,
+		&group.RunnerTags,
 	}
 
 	if withFullPath {
@@ -902,3 +1115,101 @@ func scanGroup(row scanner, withFullPath bool) (*models.Group, error) {
 
 	return group, nil
 }
+
+// marshalRunnerTags returns nil when the group doesn't define runner tags so they're inherited from the parent
+func marshalRunnerTags(runnerTags []string) (interface{}, error) {
+	if runnerTags == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(runnerTags)
+}
+
+// escapeLikePattern escapes the LIKE wildcard characters so the value is matched literally.
+func escapeLikePattern(value string) string {
+	return likePatternReplacer.Replace(value)
+}
+
+var likePatternReplacer = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// groupFilterExpression builds the where expression for a group filter
+func groupFilterExpression(filter *GroupFilter) exp.ExpressionList {
+	ex := goqu.And()
+
+	if filter == nil {
+		return ex
+	}
+
+	if filter.RootOnly {
+		ex = ex.Append(goqu.I("groups.parent_id").Eq(nil))
+	}
+
+	if filter.GroupIDs != nil {
+		// This check avoids an SQL syntax error if an empty slice is provided.
+		if len(filter.GroupIDs) > 0 {
+			ex = ex.Append(goqu.I("groups.id").In(filter.GroupIDs))
+		}
+	}
+
+	if filter.ParentID != nil {
+		ex = ex.Append(goqu.I("groups.parent_id").Eq(*filter.ParentID))
+	}
+
+	if filter.PathPrefix != nil {
+		// The trailing slash ensures only descendants match, i.e. "a/b" won't match "a/bc".
+		ex = ex.Append(goqu.I("namespaces.path").Like(escapeLikePattern(strings.TrimSuffix(*filter.PathPrefix, "/")) + "/%"))
+	}
+
+	if filter.ExcludePathPrefix != nil {
+		excludePath := strings.TrimSuffix(*filter.ExcludePathPrefix, "/")
+		ex = ex.Append(
+			goqu.I("namespaces.path").Neq(excludePath),
+			goqu.I("namespaces.path").NotLike(escapeLikePattern(excludePath)+"/%"),
+		)
+	}
+
+	if filter.NamespaceIDs != nil {
+		ex = ex.Append(goqu.I("namespaces.id").In(filter.NamespaceIDs))
+	}
+
+	if filter.UserMemberID != nil {
+		ex = ex.Append(
+			namespaceMembershipExpressionBuilder{
+				userID:     filter.UserMemberID,
+				directOnly: filter.DirectMembershipsOnly,
+			}.build(),
+		)
+	}
+
+	if filter.ServiceAccountMemberID != nil {
+		ex = ex.Append(
+			namespaceMembershipExpressionBuilder{
+				serviceAccountID: filter.ServiceAccountMemberID,
+				directOnly:       filter.DirectMembershipsOnly,
+			}.build(),
+		)
+	}
+
+	if filter.Search != nil && *filter.Search != "" {
+		ex = ex.Append(goqu.I("namespaces.path").ILike("%" + *filter.Search + "%"))
+	}
+
+	if filter.HasRunnerTags != nil {
+		// A NULL column means the tags are inherited and an empty array means no tags are set
+		if *filter.HasRunnerTags {
+			ex = ex.Append(
+				goqu.I("groups.runner_tags").IsNotNull(),
+				goqu.L("jsonb_array_length(groups.runner_tags)").Gt(0),
+			)
+		} else {
+			ex = ex.Append(
+				goqu.Or(
+					goqu.I("groups.runner_tags").IsNull(),
+					goqu.L("jsonb_array_length(groups.runner_tags)").Eq(0),
+				),
+			)
+		}
+	}
+
+	return ex
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/pagination"
 	tharsis "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-sdk-go/pkg"
 )
@@ -385,6 +386,81 @@ func TestDeleteGroup(t *testing.T) {
 }
 
 // TestGetGroups tests GetGroups
+func TestGetRootGroup(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	// Build a deep hierarchy: root-group/level-1/level-2/.../level-5
+	rootGroup, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Name:      "root-group",
+		CreatedBy: "db-integration-tests",
+	})
+	require.Nil(t, err)
+
+	groups := []*models.Group{rootGroup}
+	for i := 1; i <= 5; i++ {
+		group, cErr := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+			Name:      fmt.Sprintf("level-%d", i),
+			ParentID:  groups[len(groups)-1].Metadata.ID,
+			CreatedBy: "db-integration-tests",
+		})
+		require.Nil(t, cErr)
+		groups = append(groups, group)
+	}
+
+	// A separate root group with a name that has the first root group's name as a prefix
+	_, err = testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Name:      "root-group-2",
+		CreatedBy: "db-integration-tests",
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		name         string
+		groupID      string
+		expectRootID *string
+	}
+
+	testCases := []testCase{
+		{
+			name:         "root group is its own root",
+			groupID:      rootGroup.Metadata.ID,
+			expectRootID: &rootGroup.Metadata.ID,
+		},
+		{
+			name:         "direct child of the root group",
+			groupID:      groups[1].Metadata.ID,
+			expectRootID: &rootGroup.Metadata.ID,
+		},
+		{
+			name:         "deepest group in the hierarchy",
+			groupID:      groups[len(groups)-1].Metadata.ID,
+			expectRootID: &rootGroup.Metadata.ID,
+		},
+		{
+			name:    "group does not exist",
+			groupID: nonExistentID,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			root, err := testClient.client.Groups.GetRootGroup(ctx, test.groupID)
+			require.Nil(t, err)
+
+			if test.expectRootID == nil {
+				assert.Nil(t, root)
+				return
+			}
+
+			require.NotNil(t, root)
+			assert.Equal(t, *test.expectRootID, root.Metadata.ID)
+			assert.Equal(t, "root-group", root.FullPath)
+		})
+	}
+}
+
 func TestGetGroups(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -1202,6 +1278,55 @@ func TestGetGroups(t *testing.T) {
 	}
 }
 
+func TestGetGroupCount(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	createdWarmupGroups, _, err := createInitialGroups(ctx, testClient, standardWarmupGroups)
+	require.Nil(t, err)
+
+	type testCase struct {
+		filter *GroupFilter
+		name   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "no filter",
+		},
+		{
+			name:   "filter by parent ID",
+			filter: &GroupFilter{ParentID: &createdWarmupGroups[0].Metadata.ID},
+		},
+		{
+			name:   "filter by root only",
+			filter: &GroupFilter{RootOnly: true},
+		},
+		{
+			name:   "filter by search",
+			filter: &GroupFilter{Search: ptr.String("1")},
+		},
+		{
+			name:   "filter by empty namespace IDs",
+			filter: &GroupFilter{NamespaceIDs: []string{}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.Groups.GetGroups(ctx, &GetGroupsInput{Filter: test.filter})
+			require.Nil(t, err)
+
+			count, err := testClient.client.Groups.GetGroupCount(ctx, test.filter)
+			require.Nil(t, err)
+
+			assert.Equal(t, result.PageInfo.TotalCount, count)
+			assert.Equal(t, int32(len(result.Groups)), count)
+		})
+	}
+}
+
 // TestCreateGroup tests CreateGroup
 func TestCreateGroup(t *testing.T) {
 	ctx := context.Background()
@@ -1483,6 +1608,257 @@ func TestUpdateGroup(t *testing.T) {
 }
 
 // TestMigrateGroupBasics tests MigrateGroup's basic function of setting the parent ID and updating namespace paths.
+func TestGroupRunnerTags(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	// Runner tags are not defined when they aren't specified
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Name:      "group-with-runner-tags",
+		CreatedBy: "db-integration-tests",
+	})
+	require.Nil(t, err)
+	assert.Nil(t, group.RunnerTags)
+
+	group.RunnerTags = []string{"tag1", "tag2"}
+	updatedGroup, err := testClient.client.Groups.UpdateGroup(ctx, group)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"tag1", "tag2"}, updatedGroup.RunnerTags)
+
+	// An empty list of tags is defined and isn't the same as no tags
+	updatedGroup.RunnerTags = []string{}
+	updatedGroup, err = testClient.client.Groups.UpdateGroup(ctx, updatedGroup)
+	require.Nil(t, err)
+	assert.NotNil(t, updatedGroup.RunnerTags)
+	assert.Empty(t, updatedGroup.RunnerTags)
+
+	retrievedGroup, err := testClient.client.Groups.GetGroupByID(ctx, group.Metadata.ID)
+	require.Nil(t, err)
+	require.NotNil(t, retrievedGroup)
+	assert.Equal(t, updatedGroup.RunnerTags, retrievedGroup.RunnerTags)
+}
+
+func TestGetGroupDeletionPreview(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	createdWarmupGroups, groupPath2ID, err := createInitialGroups(ctx, testClient, standardWarmupGroups)
+	require.Nil(t, err)
+
+	allResources, err := createMigrateResources(ctx, testClient, &migrateGroupWarmupsInput{
+		groups:             deriveGroupNames(createdWarmupGroups),
+		groupPath2ID:       groupPath2ID,
+		workspaces:         warmupWorkspacesForGroupMigration,
+		serviceAccounts:    warmupServiceAccountsForGroupMigration,
+		managedIdentities:  warmupManagedIdentitiesForGroupMigration,
+		gpgKeys:            warmupGPGKeysForGroupMigration,
+		terraformProviders: warmupTerraformProvidersForGroupMigration,
+		teams:              warmupTeamsForGroupMigration,
+		membershipInputs:   warmupMembershipInputsForGroupMigration,
+		variables:          warmupVariablesForGroupMigration,
+		users:              warmupUsersForGroupMigration,
+		activityEvents:     warmupActivityEventsForGroupMigration,
+		vcsProviders:       warmupVCSProvidersForGroupMigration,
+		roles:              warmupRolesForGroupMigration,
+		runners:            warmupRunnersForGroupMigration,
+	})
+	require.Nil(t, err)
+
+	// isInSubtree returns true if the path is the group's path or is nested under it.
+	isInSubtree := func(groupPath, path string) bool {
+		return path == groupPath || strings.HasPrefix(path, groupPath+"/")
+	}
+
+	// Build the expected preview for each group from the warmup resources.
+	expectPreview := func(group *models.Group) *GroupDeletionPreview {
+		preview := &GroupDeletionPreview{}
+		for _, g := range createdWarmupGroups {
+			if strings.HasPrefix(g.FullPath, group.FullPath+"/") {
+				preview.DescendantGroupCount++
+			}
+		}
+		for _, w := range allResources.workspaces {
+			if strings.HasPrefix(w.FullPath, group.FullPath+"/") {
+				preview.WorkspaceCount++
+			}
+		}
+		for _, mi := range allResources.managedIdentities {
+			if isInSubtree(group.FullPath, allResources.groupID2Path[mi.GroupID]) {
+				preview.ManagedIdentityCount++
+			}
+		}
+		for _, tp := range allResources.terraformProviders {
+			if isInSubtree(group.FullPath, allResources.groupID2Path[tp.GroupID]) {
+				preview.TerraformProviderCount++
+			}
+		}
+		for _, m := range allResources.memberships {
+			if isInSubtree(group.FullPath, m.Namespace.Path) {
+				preview.NamespaceMembershipCount++
+			}
+		}
+		return preview
+	}
+
+	type testCase struct {
+		group *models.Group
+		name  string
+	}
+
+	testCases := []testCase{}
+	for ix := range createdWarmupGroups {
+		group := createdWarmupGroups[ix]
+		testCases = append(testCases, testCase{
+			name:  group.FullPath,
+			group: &group,
+		})
+	}
+
+	testCases = append(testCases, testCase{
+		name: "group does not exist",
+		group: &models.Group{
+			Metadata: models.ResourceMetadata{ID: nonExistentID},
+			FullPath: "this-group-does-not-exist",
+		},
+	})
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			preview, err := testClient.client.Groups.GetGroupDeletionPreview(ctx, test.group)
+			require.Nil(t, err)
+			require.NotNil(t, preview)
+
+			assert.Equal(t, expectPreview(test.group), preview)
+		})
+	}
+}
+
+func TestGetGroupDeletionPreviewWithExternalAliases(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	createdWarmupGroups, groupPath2ID, err := createInitialGroups(ctx, testClient, standardWarmupGroups)
+	require.Nil(t, err)
+
+	createIdentity := func(identity *models.ManagedIdentity, groupPath string) *models.ManagedIdentity {
+		identity.GroupID = groupPath2ID[groupPath]
+		created, cErr := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, identity)
+		require.Nil(t, cErr)
+		return created
+	}
+
+	source1b := createIdentity(&models.ManagedIdentity{
+		Name: "source-1b",
+		Type: models.ManagedIdentityAWSFederated,
+		Data: []byte("data"),
+	}, "top-level-group-1/2nd-level-group-1b")
+	source3 := createIdentity(&models.ManagedIdentity{
+		Name: "source-3",
+		Type: models.ManagedIdentityAWSFederated,
+		Data: []byte("data"),
+	}, "top-level-group-3")
+
+	for _, alias := range []struct {
+		name      string
+		sourceID  string
+		groupPath string
+	}{
+		{name: "alias-1b-in-1b1", sourceID: source1b.Metadata.ID, groupPath: "top-level-group-1/2nd-level-group-1b/3rd-level-group-1b1"},
+		{name: "alias-1b-in-1a", sourceID: source1b.Metadata.ID, groupPath: "top-level-group-1/2nd-level-group-1a"},
+		{name: "alias-1b-in-2", sourceID: source1b.Metadata.ID, groupPath: "top-level-group-2"},
+		{name: "alias-3-in-1", sourceID: source3.Metadata.ID, groupPath: "top-level-group-1"},
+	} {
+		sourceID := alias.sourceID
+		createIdentity(&models.ManagedIdentity{
+			Name:          alias.name,
+			AliasSourceID: &sourceID,
+		}, alias.groupPath)
+	}
+
+	type testCase struct {
+		name                string
+		groupPath           string
+		expectAliasCount    int32
+		expectIdentityCount int32
+	}
+
+	testCases := []testCase{
+		{
+			name:                "aliases inside the subtree are not external",
+			groupPath:           "top-level-group-1",
+			expectAliasCount:    1, // alias-1b-in-2
+			expectIdentityCount: 4,
+		},
+		{
+			name:                "aliases in sibling and other top-level groups are external",
+			groupPath:           "top-level-group-1/2nd-level-group-1b",
+			expectAliasCount:    2, // alias-1b-in-1a and alias-1b-in-2
+			expectIdentityCount: 2,
+		},
+		{
+			name:                "alias in an ancestor group is external",
+			groupPath:           "top-level-group-3",
+			expectAliasCount:    1, // alias-3-in-1
+			expectIdentityCount: 1,
+		},
+		{
+			name:                "no sources in the subtree",
+			groupPath:           "top-level-group-1/2nd-level-group-1b/3rd-level-group-1b1",
+			expectAliasCount:    0,
+			expectIdentityCount: 1,
+		},
+		{
+			name:                "only aliases in the group",
+			groupPath:           "top-level-group-2",
+			expectAliasCount:    0,
+			expectIdentityCount: 1,
+		},
+	}
+
+	groupsByPath := map[string]models.Group{}
+	for _, group := range createdWarmupGroups {
+		groupsByPath[group.FullPath] = group
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			group := groupsByPath[test.groupPath]
+
+			preview, err := testClient.client.Groups.GetGroupDeletionPreview(ctx, &group)
+			require.Nil(t, err)
+			require.NotNil(t, preview)
+
+			assert.Equal(t, test.expectAliasCount, preview.ExternalAliasCount)
+			assert.Equal(t, test.expectIdentityCount, preview.ManagedIdentityCount)
+		})
+	}
+}
+
+func TestGetGroupDeletionPreviewEscapesPath(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	// The underscore would match any character if the path wasn't escaped.
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{Name: "group_a"})
+	require.Nil(t, err)
+
+	similarGroup, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{Name: "groupxa"})
+	require.Nil(t, err)
+
+	_, err = testClient.client.Groups.CreateGroup(ctx, &models.Group{Name: "child", ParentID: similarGroup.Metadata.ID})
+	require.Nil(t, err)
+
+	preview, err := testClient.client.Groups.GetGroupDeletionPreview(ctx, group)
+	require.Nil(t, err)
+	require.NotNil(t, preview)
+
+	assert.Equal(t, int32(0), preview.DescendantGroupCount)
+}
+
 func TestMigrateGroupBasics(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -1975,6 +2351,544 @@ func TestGetChildDepth(t *testing.T) {
 	}
 }
 
+func TestRenameGroup(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	_, groupMap, err := createInitialGroups(ctx, testClient, standardWarmupGroups)
+	require.Nil(t, err)
+
+	// A workspace under the group being renamed must have its path rewritten as well.
+	_, err = testClient.client.Workspaces.CreateWorkspace(ctx, &models.Workspace{
+		Name:           "workspace-1b1",
+		GroupID:        groupMap["top-level-group-1/2nd-level-group-1b/3rd-level-group-1b1"],
+		MaxJobDuration: ptr.Int32(60),
+		CreatedBy:      "someone",
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		expectErrorCode      errors.CodeType
+		name                 string
+		groupPath            string
+		newName              string
+		expectGroupPaths     []string
+		expectWorkspacePaths []string
+	}
+
+	testCases := []testCase{
+		{
+			name:            "sibling group already has the new name",
+			groupPath:       "top-level-group-1/2nd-level-group-1b",
+			newName:         "2nd-level-group-1a",
+			expectErrorCode: errors.EConflict,
+		},
+		{
+			name:      "rename cascades to descendant groups and workspaces",
+			groupPath: "top-level-group-1/2nd-level-group-1b",
+			newName:   "2nd-level-group-1c",
+			expectGroupPaths: []string{
+				"top-level-group-1/2nd-level-group-1c",
+				"top-level-group-1/2nd-level-group-1c/3rd-level-group-1b1",
+			},
+			expectWorkspacePaths: []string{
+				"top-level-group-1/2nd-level-group-1c/3rd-level-group-1b1/workspace-1b1",
+			},
+		},
+		{
+			name:      "rename a top-level group",
+			groupPath: "top-level-group-2",
+			newName:   "top-level-group-2-renamed",
+			expectGroupPaths: []string{
+				"top-level-group-2-renamed",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			group, err := testClient.client.Groups.GetGroupByFullPath(ctx, test.groupPath)
+			require.Nil(t, err)
+			require.NotNil(t, group)
+
+			renamed, err := testClient.client.Groups.RenameGroup(ctx, group, test.newName)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+
+				// Nothing should have changed.
+				unchanged, gErr := testClient.client.Groups.GetGroupByFullPath(ctx, test.groupPath)
+				require.Nil(t, gErr)
+				assert.Equal(t, group.Metadata.Version, unchanged.Metadata.Version)
+				return
+			}
+
+			require.Nil(t, err)
+			require.NotNil(t, renamed)
+			assert.Equal(t, test.newName, renamed.Name)
+			assert.Equal(t, test.expectGroupPaths[0], renamed.FullPath)
+			assert.Equal(t, group.Metadata.Version+1, renamed.Metadata.Version)
+
+			// The old path no longer exists.
+			oldGroup, err := testClient.client.Groups.GetGroupByFullPath(ctx, test.groupPath)
+			require.Nil(t, err)
+			assert.Nil(t, oldGroup)
+
+			for _, path := range test.expectGroupPaths {
+				g, gErr := testClient.client.Groups.GetGroupByFullPath(ctx, path)
+				require.Nil(t, gErr)
+				assert.NotNil(t, g, "expected group at path %s", path)
+			}
+
+			for _, path := range test.expectWorkspacePaths {
+				ws, wErr := testClient.client.Workspaces.GetWorkspaceByFullPath(ctx, path)
+				require.Nil(t, wErr)
+				assert.NotNil(t, ws, "expected workspace at path %s", path)
+			}
+		})
+	}
+}
+
+func TestGetGroupsWithInheritedMemberships(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	_, _, err := createInitialGroups(ctx, testClient, []models.Group{
+		{FullPath: "parent-group", CreatedBy: "someone"},
+		{FullPath: "parent-group/child-group", CreatedBy: "someone"},
+		{FullPath: "parent-group/child-group/grandchild-group", CreatedBy: "someone"},
+		{FullPath: "other-group", CreatedBy: "someone"},
+	})
+	require.Nil(t, err)
+
+	user, err := testClient.client.Users.CreateUser(ctx, &models.User{
+		Username: "parent-group-member",
+		Email:    "parent-group-member@example.invalid",
+	})
+	require.Nil(t, err)
+
+	role, err := testClient.client.Roles.CreateRole(ctx, &models.Role{Name: "viewer"})
+	require.Nil(t, err)
+
+	_, err = testClient.client.NamespaceMemberships.CreateNamespaceMembership(ctx, &CreateNamespaceMembershipInput{
+		NamespacePath: "parent-group",
+		UserID:        &user.Metadata.ID,
+		RoleID:        role.Metadata.ID,
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		name                  string
+		expectGroupPaths      []string
+		directMembershipsOnly bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "inherited memberships are included by default",
+			expectGroupPaths: []string{
+				"parent-group",
+				"parent-group/child-group",
+				"parent-group/child-group/grandchild-group",
+			},
+		},
+		{
+			name:                  "direct memberships only",
+			directMembershipsOnly: true,
+			expectGroupPaths:      []string{"parent-group"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			sort := GroupSortableFieldFullPathAsc
+			groupsResult, err := testClient.client.Groups.GetGroups(ctx, &GetGroupsInput{
+				Sort: &sort,
+				Filter: &GroupFilter{
+					UserMemberID:          &user.Metadata.ID,
+					DirectMembershipsOnly: test.directMembershipsOnly,
+				},
+			})
+			require.Nil(t, err)
+
+			actualPaths := []string{}
+			for _, group := range groupsResult.Groups {
+				actualPaths = append(actualPaths, group.FullPath)
+			}
+
+			assert.Equal(t, test.expectGroupPaths, actualPaths)
+		})
+	}
+}
+
+func TestGetGroupsWithPathPrefix(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	// In addition to the standard hierarchy, create groups that would only match if the
+	// path boundary or the LIKE wildcard characters were not handled correctly.
+	toCreate := append([]models.Group{}, standardWarmupGroups...)
+	toCreate = append(toCreate,
+		models.Group{FullPath: "top-level-group-1x", CreatedBy: "someone"},
+		models.Group{FullPath: "top-level-group-1x/2nd-level-group-1x1", CreatedBy: "someone"},
+		models.Group{FullPath: "top_level_group_1", CreatedBy: "someone"},
+		models.Group{FullPath: "top_level_group_1/2nd_level_group", CreatedBy: "someone"},
+	)
+
+	_, _, err := createInitialGroups(ctx, testClient, toCreate)
+	require.Nil(t, err)
+
+	type testCase struct {
+		name             string
+		pathPrefix       string
+		expectGroupPaths []string
+	}
+
+	testCases := []testCase{
+		{
+			name:       "all descendants of a top-level group",
+			pathPrefix: "top-level-group-1",
+			expectGroupPaths: []string{
+				"top-level-group-1/2nd-level-group-1a",
+				"top-level-group-1/2nd-level-group-1b",
+				"top-level-group-1/2nd-level-group-1b/3rd-level-group-1b1",
+			},
+		},
+		{
+			name:       "trailing slash is ignored",
+			pathPrefix: "top-level-group-1/",
+			expectGroupPaths: []string{
+				"top-level-group-1/2nd-level-group-1a",
+				"top-level-group-1/2nd-level-group-1b",
+				"top-level-group-1/2nd-level-group-1b/3rd-level-group-1b1",
+			},
+		},
+		{
+			name:             "descendants of a nested group",
+			pathPrefix:       "top-level-group-1/2nd-level-group-1b",
+			expectGroupPaths: []string{"top-level-group-1/2nd-level-group-1b/3rd-level-group-1b1"},
+		},
+		{
+			name:             "underscores are matched literally",
+			pathPrefix:       "top_level_group_1",
+			expectGroupPaths: []string{"top_level_group_1/2nd_level_group"},
+		},
+		{
+			name:             "leaf group has no descendants",
+			pathPrefix:       "top-level-group-2",
+			expectGroupPaths: []string{},
+		},
+		{
+			name:             "non-existent path",
+			pathPrefix:       "non-existent-group",
+			expectGroupPaths: []string{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			sort := GroupSortableFieldFullPathAsc
+			groupsResult, err := testClient.client.Groups.GetGroups(ctx, &GetGroupsInput{
+				Sort: &sort,
+				Filter: &GroupFilter{
+					PathPrefix: &test.pathPrefix,
+				},
+			})
+			require.Nil(t, err)
+
+			actualPaths := []string{}
+			for _, group := range groupsResult.Groups {
+				actualPaths = append(actualPaths, group.FullPath)
+			}
+
+			assert.Equal(t, test.expectGroupPaths, actualPaths)
+		})
+	}
+}
+
+func TestGetGroupsWithExcludePathPrefix(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	// Include a group whose path shares a prefix with the excluded subtree without being part of it.
+	toCreate := append([]models.Group{}, standardWarmupGroups...)
+	toCreate = append(toCreate,
+		models.Group{FullPath: "top-level-group-1/2nd-level-group-1bx", CreatedBy: "someone"},
+	)
+
+	_, _, err := createInitialGroups(ctx, testClient, toCreate)
+	require.Nil(t, err)
+
+	type testCase struct {
+		name              string
+		excludePathPrefix string
+		expectGroupPaths  []string
+	}
+
+	testCases := []testCase{
+		{
+			name:              "exclude a nested subtree",
+			excludePathPrefix: "top-level-group-1/2nd-level-group-1b",
+			expectGroupPaths: []string{
+				"top-level-group-1",
+				"top-level-group-1/2nd-level-group-1a",
+				"top-level-group-1/2nd-level-group-1bx",
+				"top-level-group-2",
+				"top-level-group-3",
+			},
+		},
+		{
+			name:              "exclude a top-level subtree",
+			excludePathPrefix: "top-level-group-1",
+			expectGroupPaths: []string{
+				"top-level-group-2",
+				"top-level-group-3",
+			},
+		},
+		{
+			name:              "exclude a leaf group",
+			excludePathPrefix: "top-level-group-1/2nd-level-group-1b/3rd-level-group-1b1",
+			expectGroupPaths: []string{
+				"top-level-group-1",
+				"top-level-group-1/2nd-level-group-1a",
+				"top-level-group-1/2nd-level-group-1b",
+				"top-level-group-1/2nd-level-group-1bx",
+				"top-level-group-2",
+				"top-level-group-3",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			sort := GroupSortableFieldFullPathAsc
+			groupsResult, err := testClient.client.Groups.GetGroups(ctx, &GetGroupsInput{
+				Sort: &sort,
+				Filter: &GroupFilter{
+					ExcludePathPrefix: &test.excludePathPrefix,
+				},
+			})
+			require.Nil(t, err)
+
+			actualPaths := []string{}
+			for _, group := range groupsResult.Groups {
+				actualPaths = append(actualPaths, group.FullPath)
+			}
+
+			assert.Equal(t, test.expectGroupPaths, actualPaths)
+		})
+	}
+}
+
+func TestGetGroupsWithHasRunnerTags(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	// Copy the warmup groups so some of them can define runner tags; an empty
+	// list is treated the same as not defining any tags.
+	toCreate := append([]models.Group{}, standardWarmupGroups...)
+	toCreate[0].RunnerTags = []string{"tag1", "tag2"}
+	toCreate[4].RunnerTags = []string{"tag3"}
+	toCreate[5].RunnerTags = []string{}
+
+	_, _, err := createInitialGroups(ctx, testClient, toCreate)
+	require.Nil(t, err)
+
+	type testCase struct {
+		name             string
+		hasRunnerTags    bool
+		expectGroupPaths []string
+	}
+
+	testCases := []testCase{
+		{
+			name:          "groups with runner tags",
+			hasRunnerTags: true,
+			expectGroupPaths: []string{
+				"top-level-group-1",
+				"top-level-group-1/2nd-level-group-1b",
+			},
+		},
+		{
+			name:          "groups without runner tags",
+			hasRunnerTags: false,
+			expectGroupPaths: []string{
+				"top-level-group-1/2nd-level-group-1a",
+				"top-level-group-1/2nd-level-group-1b/3rd-level-group-1b1",
+				"top-level-group-2",
+				"top-level-group-3",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			sort := GroupSortableFieldFullPathAsc
+			groupsResult, err := testClient.client.Groups.GetGroups(ctx, &GetGroupsInput{
+				Sort: &sort,
+				Filter: &GroupFilter{
+					HasRunnerTags: &test.hasRunnerTags,
+				},
+			})
+			require.Nil(t, err)
+
+			actualPaths := []string{}
+			for _, group := range groupsResult.Groups {
+				actualPaths = append(actualPaths, group.FullPath)
+			}
+
+			assert.Equal(t, test.expectGroupPaths, actualPaths)
+		})
+	}
+}
+func TestReassignCreatedBy(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	fromSubject := "leaving-user"
+	toSubject := "new-owner"
+
+	type testCase struct {
+		namespacePath    *string
+		name             string
+		expectGroupPaths []string
+	}
+
+	testCases := []testCase{
+		{
+			name: "reassign all groups",
+			expectGroupPaths: []string{
+				"top-level-group-a",
+				"top-level-group-a/nested-group",
+				"top-level-group-b",
+			},
+		},
+		{
+			name:          "reassign groups within a namespace path",
+			namespacePath: ptr.String("top-level-group-a"),
+			expectGroupPaths: []string{
+				"top-level-group-a",
+				"top-level-group-a/nested-group",
+			},
+		},
+		{
+			name:             "namespace path with no matching groups",
+			namespacePath:    ptr.String("top-level-group-c"),
+			expectGroupPaths: []string{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			created, _, err := createInitialGroups(ctx, testClient, []models.Group{
+				{FullPath: "top-level-group-a", CreatedBy: fromSubject},
+				{FullPath: "top-level-group-a/nested-group", CreatedBy: fromSubject},
+				{FullPath: "top-level-group-a/other-creator", CreatedBy: "someone-else"},
+				{FullPath: "top-level-group-b", CreatedBy: fromSubject},
+				{FullPath: "top-level-group-c", CreatedBy: "someone-else"},
+			})
+			require.Nil(t, err)
+
+			defer func() {
+				for ix := len(created) - 1; ix >= 0; ix-- {
+					group, err := testClient.client.Groups.GetGroupByID(ctx, created[ix].Metadata.ID)
+					require.Nil(t, err)
+					require.Nil(t, testClient.client.Groups.DeleteGroup(ctx, group))
+				}
+			}()
+
+			count, err := testClient.client.Groups.ReassignCreatedBy(ctx, fromSubject, toSubject, test.namespacePath)
+			require.Nil(t, err)
+			assert.Equal(t, len(test.expectGroupPaths), count)
+
+			for _, group := range created {
+				updated, err := testClient.client.Groups.GetGroupByID(ctx, group.Metadata.ID)
+				require.Nil(t, err)
+
+				expectCreatedBy := group.CreatedBy
+				for _, path := range test.expectGroupPaths {
+					if path == group.FullPath {
+						expectCreatedBy = toSubject
+					}
+				}
+
+				assert.Equal(t, expectCreatedBy, updated.CreatedBy, group.FullPath)
+			}
+		})
+	}
+}
+
+func TestGetGroupCreatedBySubjects(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	created, _, err := createInitialGroups(ctx, testClient, []models.Group{
+		{FullPath: "top-level-group-a", CreatedBy: "user-1"},
+		{FullPath: "top-level-group-a/nested-group", CreatedBy: "user-2"},
+		{FullPath: "top-level-group-a/nested-group/deeper-group", CreatedBy: "user-1"},
+		{FullPath: "top-level-group-ab", CreatedBy: "user-3"},
+		{FullPath: "top-level-group-b", CreatedBy: "service-account-1"},
+	})
+	require.Nil(t, err)
+
+	defer func() {
+		for ix := len(created) - 1; ix >= 0; ix-- {
+			group, err := testClient.client.Groups.GetGroupByID(ctx, created[ix].Metadata.ID)
+			require.Nil(t, err)
+			require.Nil(t, testClient.client.Groups.DeleteGroup(ctx, group))
+		}
+	}()
+
+	type testCase struct {
+		name           string
+		namespacePath  string
+		expectSubjects []string
+	}
+
+	testCases := []testCase{
+		{
+			name:           "top-level group includes creators of nested groups",
+			namespacePath:  "top-level-group-a",
+			expectSubjects: []string{"user-1", "user-2"},
+		},
+		{
+			name:           "nested group",
+			namespacePath:  "top-level-group-a/nested-group",
+			expectSubjects: []string{"user-1", "user-2"},
+		},
+		{
+			name:           "deepest group",
+			namespacePath:  "top-level-group-a/nested-group/deeper-group",
+			expectSubjects: []string{"user-1"},
+		},
+		{
+			name:           "other top-level group",
+			namespacePath:  "top-level-group-b",
+			expectSubjects: []string{"service-account-1"},
+		},
+		{
+			name:           "namespace path with no groups",
+			namespacePath:  "top-level-group-c",
+			expectSubjects: []string{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			subjects, err := testClient.client.Groups.GetCreatedBySubjects(ctx, test.namespacePath)
+			require.Nil(t, err)
+			assert.Equal(t, test.expectSubjects, subjects)
+		})
+	}
+}
+
 //////////////////////////////////////////////////////////////////////////////
 
 // Common utility structures and functions:
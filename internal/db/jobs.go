@@ -133,6 +133,11 @@ func (j *jobs) GetJobs(ctx context.Context, input *GetJobsInput) (*JobsResult, e
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
+	}
+
 	ex := goqu.Ex{}
 
 	if input.Filter != nil {
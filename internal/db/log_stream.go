@@ -108,6 +108,10 @@ func (l *logStreams) GetLogStreams(ctx context.Context, input *GetLogStreamsInpu
 	ctx, span := tracer.Start(ctx, "db.GetLogStreams")
 	defer span.End()
 
+	if err := input.PaginationOptions.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid pagination options", errors.WithSpan(span))
+	}
+
 	ex := goqu.Ex{}
 
 	if input.Filter != nil {
@@ -8,8 +8,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jackc/pgx/v4"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
@@ -22,11 +24,19 @@ type ManagedIdentities interface {
 	GetManagedIdentityByID(ctx context.Context, id string) (*models.ManagedIdentity, error)
 	GetManagedIdentityByPath(ctx context.Context, path string) (*models.ManagedIdentity, error)
 	GetManagedIdentitiesForWorkspace(ctx context.Context, workspaceID string) ([]models.ManagedIdentity, error)
+	GetManagedIdentitiesForServiceAccount(ctx context.Context, serviceAccountID string) ([]models.ManagedIdentity, error)
+	GetOrphanedManagedIdentityAliases(ctx context.Context) ([]models.ManagedIdentity, error)
 	AddManagedIdentityToWorkspace(ctx context.Context, managedIdentityID string, workspaceID string) error
 	RemoveManagedIdentityFromWorkspace(ctx context.Context, managedIdentityID string, workspaceID string) error
 	CreateManagedIdentity(ctx context.Context, managedIdentity *models.ManagedIdentity) (*models.ManagedIdentity, error)
 	UpdateManagedIdentity(ctx context.Context, managedIdentity *models.ManagedIdentity) (*models.ManagedIdentity, error)
+	UpdateManagedIdentityLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) error
+	CreateCredentialIssuance(ctx context.Context, managedIdentityID string, runID string, jobID string) error
+	ReassignCreatedBy(ctx context.Context, fromSubject, toSubject string, namespacePath *string) (int, error)
+	GetCreatedBySubjects(ctx context.Context, namespacePath string) ([]string, error)
 	GetManagedIdentities(ctx context.Context, input *GetManagedIdentitiesInput) (*ManagedIdentitiesResult, error)
+	GetManagedIdentityCount(ctx context.Context, filter *ManagedIdentityFilter) (int32, error)
+	GetManagedIdentityTypes(ctx context.Context, filter *ManagedIdentityFilter) ([]models.ManagedIdentityType, error)
 	DeleteManagedIdentity(ctx context.Context, managedIdentity *models.ManagedIdentity) error
 	GetManagedIdentityAccessRules(ctx context.Context, input *GetManagedIdentityAccessRulesInput) (*ManagedIdentityAccessRulesResult, error)
 	GetManagedIdentityAccessRule(ctx context.Context, ruleID string) (*models.ManagedIdentityAccessRule, error)
@@ -46,6 +56,8 @@ const (
 	ManagedIdentitySortableFieldUpdatedAtDesc  ManagedIdentitySortableField = "UPDATED_AT_DESC"
 	ManagedIdentitySortableFieldGroupLevelAsc  ManagedIdentitySortableField = "GROUP_LEVEL_ASC"
 	ManagedIdentitySortableFieldGroupLevelDesc ManagedIdentitySortableField = "GROUP_LEVEL_DESC"
+	ManagedIdentitySortableFieldLastUsedAtAsc  ManagedIdentitySortableField = "LAST_USED_AT_ASC"
+	ManagedIdentitySortableFieldLastUsedAtDesc ManagedIdentitySortableField = "LAST_USED_AT_DESC"
 )
 
 func (sf ManagedIdentitySortableField) getFieldDescriptor() *pagination.FieldDescriptor {
@@ -56,6 +68,8 @@ func (sf ManagedIdentitySortableField) getFieldDescriptor() *pagination.FieldDes
 		return &pagination.FieldDescriptor{Key: "updated_at", Table: "t1", Col: "updated_at"}
 	case ManagedIdentitySortableFieldGroupLevelAsc, ManagedIdentitySortableFieldGroupLevelDesc:
 		return &pagination.FieldDescriptor{Key: "group_path", Table: "namespaces", Col: "path"}
+	case ManagedIdentitySortableFieldLastUsedAtAsc, ManagedIdentitySortableFieldLastUsedAtDesc:
+		return &pagination.FieldDescriptor{Key: "last_used_at", Table: "t1", Col: "last_used_at"}
 	default:
 		return nil
 	}
@@ -79,6 +93,22 @@ func (sf ManagedIdentitySortableField) getTransformFunc() pagination.SortTransfo
 	}
 }
 
+func (sf ManagedIdentitySortableField) getExtraOptions() []pagination.ExtraOptionFunc {
+	options := []pagination.ExtraOptionFunc{
+		pagination.WithSortByField(sf.getFieldDescriptor(), sf.getSortDirection()),
+		pagination.WithSortByTransform(sf.getTransformFunc()),
+	}
+
+	switch sf {
+	case ManagedIdentitySortableFieldLastUsedAtAsc, ManagedIdentitySortableFieldLastUsedAtDesc:
+		// Identities that have never been used are sorted as if last used at the zero time,
+		// which is what their cursors resolve to.
+		options = append(options, pagination.WithSortByNullValue(time.Time{}.Format(time.RFC3339Nano)))
+	}
+
+	return options
+}
+
 // ManagedIdentityAccessRuleSortableField represents the fields that a managed identity access rule can be sorted by
 type ManagedIdentityAccessRuleSortableField string
 
@@ -100,13 +130,25 @@ func (sf ManagedIdentityAccessRuleSortableField) getRuleSortDirection() paginati
 type ManagedIdentityFilter struct {
 	Search             *string
 	AliasSourceID      *string
+	GroupID            *string
+	UnusedSince        *time.Time
 	NamespacePaths     []string
 	ManagedIdentityIDs []string
+	// WorkspaceID returns only the managed identities assigned to the workspace
+	WorkspaceID *string
+	// NotAssignedToWorkspaceID excludes the managed identities already assigned to the workspace
+	NotAssignedToWorkspaceID *string
+	// AliasesOnly returns only aliases when true and only source managed identities when false
+	AliasesOnly *bool
+	// ExternalAliasesOfGroupPath returns only the aliases outside the group with this path and its descendants
+	// whose source managed identity is inside them
+	ExternalAliasesOfGroupPath *string
 }
 
 // ManagedIdentityAccessRuleFilter contains the supported fields for filtering ManagedIdentityAccessRule resources
 type ManagedIdentityAccessRuleFilter struct {
 	ManagedIdentityID            *string
+	RunStage                     *models.JobType
 	ManagedIdentityAccessRuleIDs []string
 }
 
@@ -118,6 +160,8 @@ type GetManagedIdentitiesInput struct {
 	PaginationOptions *pagination.Options
 	// Filter is used to filter the results
 	Filter *ManagedIdentityFilter
+	// IncludeAssignedWorkspaceCount will return the number of workspaces each managed identity is assigned to
+	IncludeAssignedWorkspaceCount bool
 }
 
 // GetManagedIdentityAccessRulesInput is the input for listing managed identity access rules
@@ -134,6 +178,9 @@ type GetManagedIdentityAccessRulesInput struct {
 type ManagedIdentitiesResult struct {
 	PageInfo          *pagination.PageInfo
 	ManagedIdentities []models.ManagedIdentity
+	// AssignedWorkspaceCount maps a managed identity ID to the number of workspaces it's assigned to;
+	// it's only populated when IncludeAssignedWorkspaceCount is set in the input
+	AssignedWorkspaceCount map[string]int32
 }
 
 // ManagedIdentityAccessRulesResult contains the response data and page information
@@ -148,7 +195,7 @@ type managedIdentities struct {
 
 var (
 	managedIdentityFieldList = append(metadataFieldList,
-		"name", "description", "type", "group_id", "data", "created_by", "alias_source_id")
+		"name", "description", "type", "group_id", "data", "created_by", "alias_source_id", "last_used_at", "deletion_protected")
 	managedIdentityRuleFieldList = append(metadataFieldList,
 		"run_stage", "managed_identity_id", "type", "module_attestation_policies", "verify_state_lineage")
 )
@@ -171,6 +218,11 @@ func (m *managedIdentities) GetManagedIdentityAccessRules(ctx context.Context,
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
+	}
+
 	conn := m.dbClient.getConnection(ctx)
 	ex := goqu.And()
 
@@ -194,6 +246,10 @@ func (m *managedIdentities) GetManagedIdentityAccessRules(ctx context.Context,
 		if input.Filter.ManagedIdentityAccessRuleIDs != nil {
 			ex = ex.Append(goqu.I("id").In(input.Filter.ManagedIdentityAccessRuleIDs))
 		}
+
+		if input.Filter.RunStage != nil {
+			ex = ex.Append(goqu.I("run_stage").Eq(*input.Filter.RunStage))
+		}
 	}
 
 	query := dialect.From("managed_identity_rules").
@@ -713,6 +769,98 @@ func (m *managedIdentities) GetManagedIdentitiesForWorkspace(ctx context.Context
 	return results, nil
 }
 
+func (m *managedIdentities) GetManagedIdentitiesForServiceAccount(ctx context.Context, serviceAccountID string) ([]models.ManagedIdentity, error) {
+	ctx, span := tracer.Start(ctx, "db.GetManagedIdentitiesForServiceAccount")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	// Access rules only belong to source identities, so aliases are matched using their source ID.
+	ruleIdentityIDs := dialect.From("managed_identity_rules").
+		Select("managed_identity_rules.managed_identity_id").
+		InnerJoin(goqu.T("managed_identity_rule_allowed_service_accounts"),
+			goqu.On(goqu.Ex{"managed_identity_rules.id": goqu.I("managed_identity_rule_allowed_service_accounts.rule_id")})).
+		Where(goqu.Ex{"managed_identity_rule_allowed_service_accounts.service_account_id": serviceAccountID})
+
+	sql, args, err := dialect.From(t1).
+		Prepared(true).
+		Select(m.getSelectFields(true)...).
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"t1.group_id": goqu.I("namespaces.group_id")})).
+		LeftJoin(t2, goqu.On(goqu.Ex{"t1.alias_source_id": goqu.I("t2.id")})).
+		Where(goqu.COALESCE(goqu.I("t1.alias_source_id"), goqu.I("t1.id")).In(ruleIdentityIDs)).
+		Order(goqu.I("namespaces.path").Asc(), goqu.I("t1.name").Asc()).
+		ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return nil, err
+	}
+
+	rows, err := m.dbClient.getConnection(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to execute query")
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	// Scan rows
+	results := []models.ManagedIdentity{}
+	for rows.Next() {
+		item, err := scanManagedIdentity(rows, true, true)
+		if err != nil {
+			tracing.RecordError(span, err, "failed to scan row")
+			return nil, err
+		}
+
+		results = append(results, *item)
+	}
+
+	return results, nil
+}
+
+// GetOrphanedManagedIdentityAliases returns the aliases whose source managed identity no longer exists
+func (m *managedIdentities) GetOrphanedManagedIdentityAliases(ctx context.Context) ([]models.ManagedIdentity, error) {
+	ctx, span := tracer.Start(ctx, "db.GetOrphanedManagedIdentityAliases")
+	defer span.End()
+
+	sql, args, err := dialect.From(t1).
+		Prepared(true).
+		Select(m.getSelectFields(true)...).
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"t1.group_id": goqu.I("namespaces.group_id")})).
+		LeftJoin(t2, goqu.On(goqu.Ex{"t1.alias_source_id": goqu.I("t2.id")})).
+		Where(
+			goqu.I("t1.alias_source_id").IsNotNull(),
+			goqu.I("t2.id").IsNull(),
+		).
+		Order(goqu.I("namespaces.path").Asc(), goqu.I("t1.name").Asc()).
+		ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return nil, err
+	}
+
+	rows, err := m.dbClient.getConnection(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to execute query")
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	// Scan rows
+	results := []models.ManagedIdentity{}
+	for rows.Next() {
+		item, err := scanManagedIdentity(rows, true, true)
+		if err != nil {
+			tracing.RecordError(span, err, "failed to scan row")
+			return nil, err
+		}
+
+		results = append(results, *item)
+	}
+
+	return results, nil
+}
+
 func (m *managedIdentities) AddManagedIdentityToWorkspace(ctx context.Context, managedIdentityID string, workspaceID string) error {
 	ctx, span := tracer.Start(ctx, "db.AddManagedIdentityToWorkspace")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -743,6 +891,40 @@ func (m *managedIdentities) AddManagedIdentityToWorkspace(ctx context.Context, m
 	return nil
 }
 
+// CreateCredentialIssuance records that credentials were issued for a managed identity to a job within a run.
+func (m *managedIdentities) CreateCredentialIssuance(ctx context.Context, managedIdentityID string, runID string, jobID string) error {
+	ctx, span := tracer.Start(ctx, "db.CreateCredentialIssuance")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	sql, args, err := dialect.Insert("managed_identity_credential_issuances").
+		Prepared(true).
+		Rows(goqu.Record{
+			"id":                  newResourceID(),
+			"created_at":          currentTime(),
+			"managed_identity_id": managedIdentityID,
+			"run_id":              runID,
+			"job_id":              jobID,
+		}).ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return err
+	}
+
+	if _, err = m.dbClient.getConnection(ctx).Exec(ctx, sql, args...); err != nil {
+		if pgErr := asPgError(err); pgErr != nil {
+			if isForeignKeyViolation(pgErr) {
+				tracing.RecordError(span, nil, "managed identity, run or job does not exist")
+				return errors.New("managed identity, run or job does not exist", errors.WithErrorCode(errors.ENotFound))
+			}
+		}
+		tracing.RecordError(span, err, "failed to execute DB query")
+		return err
+	}
+
+	return nil
+}
+
 func (m *managedIdentities) RemoveManagedIdentityFromWorkspace(ctx context.Context, managedIdentityID string, workspaceID string) error {
 	ctx, span := tracer.Start(ctx, "db.RemoveManagedIdentityFromWorkspace")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -829,91 +1011,61 @@ func (m *managedIdentities) GetManagedIdentityByPath(ctx context.Context, path s
 	return managedIdentity, nil
 }
 
-func (m *managedIdentities) GetManagedIdentities(ctx context.Context, input *GetManagedIdentitiesInput) (*ManagedIdentitiesResult, error) {
-	ctx, span := tracer.Start(ctx, "db.GetManagedIdentities")
+func (m *managedIdentities) ReassignCreatedBy(ctx context.Context, fromSubject, toSubject string, namespacePath *string) (int, error) {
+	ctx, span := tracer.Start(ctx, "db.ReassignCreatedBy")
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
-	ex := goqu.And()
-
-	if input.Filter != nil {
-		if input.Filter.NamespacePaths != nil {
-			ex = ex.Append(goqu.I("namespaces.path").In(input.Filter.NamespacePaths))
-		}
+	count, err := reassignCreatedBy(ctx, m.dbClient.getConnection(ctx), "managed_identities", "group_id", fromSubject, toSubject, namespacePath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to reassign created by for managed identities")
+		return 0, err
+	}
 
-		if input.Filter.Search != nil {
-			search := *input.Filter.Search
+	return count, nil
+}
 
-			lastDelimiterIndex := strings.LastIndex(search, "/")
+func (m *managedIdentities) GetCreatedBySubjects(ctx context.Context, namespacePath string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "db.GetCreatedBySubjects")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
 
-			if lastDelimiterIndex != -1 {
-				namespacePath := search[:lastDelimiterIndex]
-				managedIdentityName := search[lastDelimiterIndex+1:]
+	subjects, err := getCreatedBySubjects(ctx, m.dbClient.getConnection(ctx), "managed_identities", "group_id", namespacePath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get created by subjects for managed identities")
+		return nil, err
+	}
 
-				if managedIdentityName != "" {
-					// An OR condition is used here since the last component of the search path could be part of
-					// the namespace or it can be a managed identity name prefix
-					ex = ex.Append(
-						goqu.Or(
-							goqu.And(
-								goqu.I("namespaces.path").Eq(namespacePath),
-								goqu.I("t1.name").ILike(managedIdentityName+"%"),
-							),
-							goqu.Or(
-								goqu.I("namespaces.path").ILike(search+"%"),
-								goqu.I("t1.name").ILike(managedIdentityName+"%"),
-							),
-						),
-					)
-				} else {
-					// We know the search is a namespace path since it ends with a "/"
-					ex = ex.Append(goqu.I("namespaces.path").ILike(namespacePath + "%"))
-				}
-			} else {
-				// We don't know if the search is for a namespace path or managed identity name; therefore, use
-				// an OR condition to search both
-				ex = ex.Append(
-					goqu.Or(
-						goqu.I("namespaces.path").ILike(search+"%"),
-						goqu.I("t1.name").ILike(search+"%"),
-					),
-				)
-			}
-		}
+	return subjects, nil
+}
 
-		if input.Filter.AliasSourceID != nil {
-			ex = ex.Append(goqu.Ex{"t1.alias_source_id": *input.Filter.AliasSourceID})
-		}
+func (m *managedIdentities) GetManagedIdentities(ctx context.Context, input *GetManagedIdentitiesInput) (*ManagedIdentitiesResult, error) {
+	ctx, span := tracer.Start(ctx, "db.GetManagedIdentities")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
 
-		if input.Filter.ManagedIdentityIDs != nil {
-			// This check avoids an SQL syntax error if an empty slice is provided.
-			if len(input.Filter.ManagedIdentityIDs) > 0 {
-				ex = ex.Append(goqu.I("t1.id").In(input.Filter.ManagedIdentityIDs))
-			}
-		}
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
 	}
 
+	ex := managedIdentityFilterExpression(input.Filter)
+
 	query := dialect.From(t1).
 		Select(m.getSelectFields(true)...).
 		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"t1.group_id": goqu.I("namespaces.group_id")})).
 		LeftJoin(t2, goqu.On(goqu.Ex{"t1.alias_source_id": goqu.I("t2.id")})).
 		Where(ex)
 
-	sortDirection := pagination.AscSort
-
-	var sortBy *pagination.FieldDescriptor
-	var sortTransformFunc pagination.SortTransformFunc
+	extraOptions := []pagination.ExtraOptionFunc{}
 	if input.Sort != nil {
-		sortDirection = input.Sort.getSortDirection()
-		sortBy = input.Sort.getFieldDescriptor()
-		sortTransformFunc = input.Sort.getTransformFunc()
+		extraOptions = input.Sort.getExtraOptions()
 	}
 
 	qBuilder, err := pagination.NewPaginatedQueryBuilder(
 		input.PaginationOptions,
 		&pagination.FieldDescriptor{Key: "id", Table: "t1", Col: "id"},
-		pagination.WithSortByField(sortBy, sortDirection),
-		pagination.WithSortByTransform(sortTransformFunc),
+		extraOptions...,
 	)
 	if err != nil {
 		tracing.RecordError(span, err, "failed to build query")
@@ -950,9 +1102,82 @@ func (m *managedIdentities) GetManagedIdentities(ctx context.Context, input *Get
 		ManagedIdentities: results,
 	}
 
+	if input.IncludeAssignedWorkspaceCount {
+		counts, err := m.getAssignedWorkspaceCounts(ctx, results)
+		if err != nil {
+			tracing.RecordError(span, err, "failed to get assigned workspace counts")
+			return nil, err
+		}
+
+		result.AssignedWorkspaceCount = counts
+	}
+
 	return &result, nil
 }
 
+func (m *managedIdentities) GetManagedIdentityCount(ctx context.Context, filter *ManagedIdentityFilter) (int32, error) {
+	ctx, span := tracer.Start(ctx, "db.GetManagedIdentityCount")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	query := dialect.From(t1).
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"t1.group_id": goqu.I("namespaces.group_id")})).
+		LeftJoin(t2, goqu.On(goqu.Ex{"t1.alias_source_id": goqu.I("t2.id")})).
+		Where(managedIdentityFilterExpression(filter))
+
+	count, err := pagination.Count(ctx, m.dbClient.getConnection(ctx), query)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to count managed identities")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetManagedIdentityTypes returns the distinct managed identity types that match the filter
+func (m *managedIdentities) GetManagedIdentityTypes(ctx context.Context, filter *ManagedIdentityFilter) ([]models.ManagedIdentityType, error) {
+	ctx, span := tracer.Start(ctx, "db.GetManagedIdentityTypes")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	// Aliases don't have a type of their own, so the type of the source managed identity is used
+	identityType := goqu.COALESCE(goqu.I("t2.type"), goqu.I("t1.type"))
+
+	sql, args, err := dialect.From(t1).
+		Prepared(true).
+		SelectDistinct(identityType).
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"t1.group_id": goqu.I("namespaces.group_id")})).
+		LeftJoin(t2, goqu.On(goqu.Ex{"t1.alias_source_id": goqu.I("t2.id")})).
+		Where(managedIdentityFilterExpression(filter)).
+		Order(identityType.Asc()).
+		ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return nil, err
+	}
+
+	rows, err := m.dbClient.getConnection(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to execute query")
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	results := []models.ManagedIdentityType{}
+	for rows.Next() {
+		var typeName string
+		if err := rows.Scan(&typeName); err != nil {
+			tracing.RecordError(span, err, "failed to scan row")
+			return nil, err
+		}
+
+		results = append(results, models.ManagedIdentityType(typeName))
+	}
+
+	return results, nil
+}
+
 // CreateManagedIdentity creates a new managedIdentity
 func (m *managedIdentities) CreateManagedIdentity(ctx context.Context, managedIdentity *models.ManagedIdentity) (*models.ManagedIdentity, error) {
 	ctx, span := tracer.Start(ctx, "db.CreateManagedIdentity")
@@ -979,17 +1204,18 @@ func (m *managedIdentities) CreateManagedIdentity(ctx context.Context, managedId
 	sql, args, err := dialect.Insert("managed_identities").
 		Prepared(true).
 		Rows(goqu.Record{
-			"id":              createdID,
-			"version":         initialResourceVersion,
-			"created_at":      timestamp,
-			"updated_at":      timestamp,
-			"name":            managedIdentity.Name,
-			"description":     managedIdentity.Description,
-			"type":            managedIdentity.Type,
-			"group_id":        managedIdentity.GroupID,
-			"data":            managedIdentity.Data,
-			"created_by":      managedIdentity.CreatedBy,
-			"alias_source_id": managedIdentity.AliasSourceID,
+			"id":                 createdID,
+			"version":            initialResourceVersion,
+			"created_at":         timestamp,
+			"updated_at":         timestamp,
+			"name":               managedIdentity.Name,
+			"description":        managedIdentity.Description,
+			"type":               managedIdentity.Type,
+			"group_id":           managedIdentity.GroupID,
+			"data":               managedIdentity.Data,
+			"created_by":         managedIdentity.CreatedBy,
+			"alias_source_id":    managedIdentity.AliasSourceID,
+			"deletion_protected": managedIdentity.DeletionProtected,
 		}).ToSQL()
 	if err != nil {
 		tracing.RecordError(span, err, "failed to generate SQL")
@@ -1071,11 +1297,12 @@ func (m *managedIdentities) UpdateManagedIdentity(ctx context.Context,
 		Prepared(true).
 		Set(
 			goqu.Record{
-				"version":     goqu.L("? + ?", goqu.C("version"), 1),
-				"updated_at":  timestamp,
-				"description": managedIdentity.Description,
-				"data":        managedIdentity.Data,
-				"group_id":    managedIdentity.GroupID,
+				"version":            goqu.L("? + ?", goqu.C("version"), 1),
+				"updated_at":         timestamp,
+				"description":        managedIdentity.Description,
+				"data":               managedIdentity.Data,
+				"group_id":           managedIdentity.GroupID,
+				"deletion_protected": managedIdentity.DeletionProtected,
 			},
 		).Where(goqu.Ex{"id": managedIdentity.Metadata.ID, "version": managedIdentity.Metadata.Version}).Returning(managedIdentityFieldList...).ToSQL()
 	if err != nil {
@@ -1110,6 +1337,38 @@ func (m *managedIdentities) UpdateManagedIdentity(ctx context.Context,
 	return updatedManagedIdentity, nil
 }
 
+// UpdateManagedIdentityLastUsedAt records when credentials were last issued for a managed identity. The
+// update is skipped if the row is currently locked so that it never blocks credential issuance; it also
+// doesn't increment the resource version since it isn't a user initiated change.
+func (m *managedIdentities) UpdateManagedIdentityLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) error {
+	ctx, span := tracer.Start(ctx, "db.UpdateManagedIdentityLastUsedAt")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	sql, args, err := dialect.Update("managed_identities").
+		Prepared(true).
+		Set(goqu.Record{"last_used_at": lastUsedAt.UTC()}).
+		Where(
+			goqu.I("id").In(
+				dialect.From("managed_identities").
+					Select("id").
+					Where(goqu.Ex{"id": id}).
+					ForUpdate(exp.SkipLocked),
+			),
+		).ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return err
+	}
+
+	if _, err = m.dbClient.getConnection(ctx).Exec(ctx, sql, args...); err != nil {
+		tracing.RecordError(span, err, "failed to execute DB query")
+		return err
+	}
+
+	return nil
+}
+
 func (m *managedIdentities) DeleteManagedIdentity(ctx context.Context, managedIdentity *models.ManagedIdentity) error {
 	ctx, span := tracer.Start(ctx, "db.DeleteManagedIdentity")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -1163,6 +1422,50 @@ func (m *managedIdentities) getSelectFields(withNamespacePath bool) []interface{
 	return selectFields
 }
 
+// getAssignedWorkspaceCounts returns the number of workspaces each of the managed identities is assigned to.
+func (m *managedIdentities) getAssignedWorkspaceCounts(ctx context.Context, managedIdentities []models.ManagedIdentity) (map[string]int32, error) {
+	counts := make(map[string]int32, len(managedIdentities))
+	if len(managedIdentities) == 0 {
+		return counts, nil
+	}
+
+	ids := []string{}
+	for _, managedIdentity := range managedIdentities {
+		// Identities that aren't assigned to any workspace won't be returned by the query below.
+		counts[managedIdentity.Metadata.ID] = 0
+		ids = append(ids, managedIdentity.Metadata.ID)
+	}
+
+	sql, args, err := dialect.From("workspace_managed_identity_relation").
+		Prepared(true).
+		Select("managed_identity_id", goqu.COUNT("workspace_id")).
+		Where(goqu.I("managed_identity_id").In(ids)).
+		GroupBy("managed_identity_id").
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := m.dbClient.getConnection(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var managedIdentityID string
+		var count int32
+		if err := rows.Scan(&managedIdentityID, &count); err != nil {
+			return nil, err
+		}
+
+		counts[managedIdentityID] = count
+	}
+
+	return counts, nil
+}
+
 func buildManagedIdentityResourcePath(groupPath string, name string) string {
 	return fmt.Sprintf("%s/%s", groupPath, name)
 }
@@ -1281,6 +1584,8 @@ func scanManagedIdentity(row scanner, withAliasFields, withResourcePath bool) (*
 		&managedIdentity.Data,
 		&managedIdentity.CreatedBy,
 		&managedIdentity.AliasSourceID,
+		&managedIdentity.LastUsedAt,
+		&managedIdentity.DeletionProtected,
 	}
 
 	if withAliasFields {
@@ -1340,3 +1645,134 @@ func scanManagedIdentityRule(row scanner) (*models.ManagedIdentityAccessRule, er
 
 	return rule, nil
 }
+
+// managedIdentityFilterExpression builds the where expression for a managed identity filter
+func managedIdentityFilterExpression(filter *ManagedIdentityFilter) exp.ExpressionList {
+	ex := goqu.And()
+
+	if filter == nil {
+		return ex
+	}
+
+	if filter.NamespacePaths != nil {
+		ex = ex.Append(goqu.I("namespaces.path").In(filter.NamespacePaths))
+	}
+
+	if filter.Search != nil {
+		search := *filter.Search
+
+		// Aliases inherit the description of their source managed identity
+		descriptionMatch := goqu.COALESCE(goqu.I("t2.description"), goqu.I("t1.description")).ILike("%" + search + "%")
+
+		lastDelimiterIndex := strings.LastIndex(search, "/")
+
+		if lastDelimiterIndex != -1 {
+			namespacePath := search[:lastDelimiterIndex]
+			managedIdentityName := search[lastDelimiterIndex+1:]
+
+			if managedIdentityName != "" {
+				// An OR condition is used here since the last component of the search path could be part of
+				// the namespace or it can be a managed identity name prefix
+				ex = ex.Append(
+					goqu.Or(
+						goqu.And(
+							goqu.I("namespaces.path").Eq(namespacePath),
+							goqu.I("t1.name").ILike(managedIdentityName+"%"),
+						),
+						goqu.Or(
+							goqu.I("namespaces.path").ILike(search+"%"),
+							goqu.I("t1.name").ILike(managedIdentityName+"%"),
+						),
+						descriptionMatch,
+					),
+				)
+			} else {
+				// We know the search is a namespace path since it ends with a "/"
+				ex = ex.Append(goqu.I("namespaces.path").ILike(namespacePath + "%"))
+			}
+		} else {
+			// We don't know if the search is for a namespace path, managed identity name or description; therefore,
+			// use an OR condition to search all of them
+			ex = ex.Append(
+				goqu.Or(
+					goqu.I("namespaces.path").ILike(search+"%"),
+					goqu.I("t1.name").ILike(search+"%"),
+					descriptionMatch,
+				),
+			)
+		}
+	}
+
+	if filter.AliasSourceID != nil {
+		ex = ex.Append(goqu.Ex{"t1.alias_source_id": *filter.AliasSourceID})
+	}
+
+	if filter.AliasesOnly != nil {
+		if *filter.AliasesOnly {
+			ex = ex.Append(goqu.I("t1.alias_source_id").IsNotNull())
+		} else {
+			ex = ex.Append(goqu.I("t1.alias_source_id").IsNull())
+		}
+	}
+
+	if filter.GroupID != nil {
+		ex = ex.Append(goqu.Ex{"t1.group_id": *filter.GroupID})
+	}
+
+	if filter.ExternalAliasesOfGroupPath != nil {
+		groupPath := *filter.ExternalAliasesOfGroupPath
+		descendantPattern := escapeLikePattern(groupPath) + "/%"
+
+		ex = ex.Append(
+			// The alias source is in the group or one of its descendants.
+			goqu.I("t1.alias_source_id").In(
+				dialect.From(goqu.T("managed_identities").As("sources")).
+					Select("sources.id").
+					InnerJoin(goqu.T("namespaces").As("source_namespaces"),
+						goqu.On(goqu.Ex{"sources.group_id": goqu.I("source_namespaces.group_id")})).
+					Where(goqu.Or(
+						goqu.I("source_namespaces.path").Eq(groupPath),
+						goqu.I("source_namespaces.path").Like(descendantPattern),
+					)),
+			),
+			// The alias itself is not.
+			goqu.I("namespaces.path").Neq(groupPath),
+			goqu.I("namespaces.path").NotLike(descendantPattern),
+		)
+	}
+
+	if filter.WorkspaceID != nil {
+		// A subquery is used instead of a join so the assignments don't affect the paginated query's ordering or count
+		ex = ex.Append(goqu.I("t1.id").In(
+			dialect.From("workspace_managed_identity_relation").
+				Select("managed_identity_id").
+				Where(goqu.Ex{"workspace_id": *filter.WorkspaceID}),
+		))
+	}
+
+	if filter.NotAssignedToWorkspaceID != nil {
+		ex = ex.Append(goqu.I("t1.id").NotIn(
+			dialect.From("workspace_managed_identity_relation").
+				Select("managed_identity_id").
+				Where(goqu.Ex{"workspace_id": *filter.NotAssignedToWorkspaceID}),
+		))
+	}
+
+	if filter.UnusedSince != nil {
+		ex = ex.Append(
+			goqu.Or(
+				goqu.I("t1.last_used_at").IsNull(),
+				goqu.I("t1.last_used_at").Lt(filter.UnusedSince.UTC()),
+			),
+		)
+	}
+
+	if filter.ManagedIdentityIDs != nil {
+		// This check avoids an SQL syntax error if an empty slice is provided.
+		if len(filter.ManagedIdentityIDs) > 0 {
+			ex = ex.Append(goqu.I("t1.id").In(filter.ManagedIdentityIDs))
+		}
+	}
+
+	return ex
+}
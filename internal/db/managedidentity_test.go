@@ -4,6 +4,7 @@ package db
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"testing"
 	"time"
@@ -124,6 +125,57 @@ func TestGetManagedIdentityByID(t *testing.T) {
 	}
 }
 
+func TestGetManagedIdentityGroupPath(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	parentGroup, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Name:      "top-level-group-for-managed-identity-group-path",
+		FullPath:  "top-level-group-for-managed-identity-group-path",
+		CreatedBy: "someone-g0",
+	})
+	require.Nil(t, err)
+
+	childGroup, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Name:      "child-group",
+		ParentID:  parentGroup.Metadata.ID,
+		FullPath:  parentGroup.FullPath + "/child-group",
+		CreatedBy: "someone-g1",
+	})
+	require.Nil(t, err)
+
+	managedIdentity, err := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:      "managed-identity-with-group-path",
+		GroupID:   childGroup.Metadata.ID,
+		CreatedBy: "someone-sa0",
+		Type:      models.ManagedIdentityAWSFederated,
+		Data:      []byte("managed-identity-data"),
+	})
+	require.Nil(t, err)
+
+	// The group path is resolved by the same query that reads the managed identity,
+	// so no additional group lookup is needed.
+	byID, err := testClient.client.ManagedIdentities.GetManagedIdentityByID(ctx, managedIdentity.Metadata.ID)
+	require.Nil(t, err)
+	require.NotNil(t, byID)
+	assert.Equal(t, childGroup.FullPath, byID.GetGroupPath())
+
+	byPath, err := testClient.client.ManagedIdentities.GetManagedIdentityByPath(ctx, byID.ResourcePath)
+	require.Nil(t, err)
+	require.NotNil(t, byPath)
+	assert.Equal(t, childGroup.FullPath, byPath.GetGroupPath())
+
+	result, err := testClient.client.ManagedIdentities.GetManagedIdentities(ctx, &GetManagedIdentitiesInput{
+		Filter: &ManagedIdentityFilter{
+			ManagedIdentityIDs: []string{managedIdentity.Metadata.ID},
+		},
+	})
+	require.Nil(t, err)
+	require.Len(t, result.ManagedIdentities, 1)
+	assert.Equal(t, childGroup.FullPath, result.ManagedIdentities[0].GetGroupPath())
+}
+
 func TestGetManagedIdentityByPath(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -312,6 +364,114 @@ func TestGetManagedIdentitiesForWorkspace(t *testing.T) {
 	}
 }
 
+func TestGetManagedIdentitiesForServiceAccount(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group for testing managed identities for a service account",
+		FullPath:    "top-level-group-for-service-account-identities",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	serviceAccounts := []*models.ServiceAccount{}
+	for i := 0; i < 2; i++ {
+		serviceAccount, sErr := testClient.client.ServiceAccounts.CreateServiceAccount(ctx, &models.ServiceAccount{
+			Name:              fmt.Sprintf("service-account-%d", i),
+			Description:       fmt.Sprintf("service account %d for testing managed identities", i),
+			GroupID:           group.Metadata.ID,
+			CreatedBy:         "someone-sa0",
+			OIDCTrustPolicies: []models.OIDCTrustPolicy{},
+		})
+		require.Nil(t, sErr)
+
+		serviceAccounts = append(serviceAccounts, serviceAccount)
+	}
+
+	allowedIdentity, err := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:      "allowed-identity",
+		GroupID:   group.Metadata.ID,
+		CreatedBy: "someone-mi0",
+		Type:      models.ManagedIdentityAWSFederated,
+		Data:      []byte("allowed-identity-data"),
+	})
+	require.Nil(t, err)
+
+	allowedAlias, err := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:          "allowed-identity-alias",
+		GroupID:       group.Metadata.ID,
+		CreatedBy:     "someone-mi1",
+		AliasSourceID: &allowedIdentity.Metadata.ID,
+	})
+	require.Nil(t, err)
+
+	otherIdentity, err := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:      "other-identity",
+		GroupID:   group.Metadata.ID,
+		CreatedBy: "someone-mi2",
+		Type:      models.ManagedIdentityAWSFederated,
+		Data:      []byte("other-identity-data"),
+	})
+	require.Nil(t, err)
+
+	// Only the first service account is allowed by the rule for the first identity.
+	_, err = testClient.client.ManagedIdentities.CreateManagedIdentityAccessRule(ctx, &models.ManagedIdentityAccessRule{
+		Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:                 models.JobPlanType,
+		ManagedIdentityID:        allowedIdentity.Metadata.ID,
+		AllowedServiceAccountIDs: []string{serviceAccounts[0].Metadata.ID},
+	})
+	require.Nil(t, err)
+
+	// The other identity has a rule that doesn't allow any service accounts.
+	_, err = testClient.client.ManagedIdentities.CreateManagedIdentityAccessRule(ctx, &models.ManagedIdentityAccessRule{
+		Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:          models.JobPlanType,
+		ManagedIdentityID: otherIdentity.Metadata.ID,
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		name              string
+		serviceAccountID  string
+		expectIdentityIDs []string
+	}
+
+	testCases := []testCase{
+		{
+			name:              "identity and its alias are returned for an allowed service account",
+			serviceAccountID:  serviceAccounts[0].Metadata.ID,
+			expectIdentityIDs: []string{allowedIdentity.Metadata.ID, allowedAlias.Metadata.ID},
+		},
+		{
+			name:              "no identities are returned for a service account that isn't allowed by any rule",
+			serviceAccountID:  serviceAccounts[1].Metadata.ID,
+			expectIdentityIDs: []string{},
+		},
+		{
+			name:              "no identities are returned for a non-existent service account",
+			serviceAccountID:  nonExistentID,
+			expectIdentityIDs: []string{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			identities, err := testClient.client.ManagedIdentities.GetManagedIdentitiesForServiceAccount(ctx, test.serviceAccountID)
+			require.Nil(t, err)
+
+			actualIDs := []string{}
+			for _, identity := range identities {
+				actualIDs = append(actualIDs, identity.Metadata.ID)
+			}
+
+			assert.ElementsMatch(t, test.expectIdentityIDs, actualIDs)
+		})
+	}
+}
+
 func TestAddManagedIdentityToWorkspace(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -803,41 +963,702 @@ func TestUpdateManagedIdentity(t *testing.T) {
 			expectErrorCode: errors.EInternal,
 		},
 		{
-			name: "defective-id",
-			toUpdate: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID:      invalidID,
-					Version: managedIdentity1.Metadata.Version,
-				},
+			name: "defective-id",
+			toUpdate: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID:      invalidID,
+					Version: managedIdentity1.Metadata.Version,
+				},
+			},
+			expectErrorCode: errors.EInternal,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			actualManagedIdentity, err := testClient.client.ManagedIdentities.UpdateManagedIdentity(ctx, test.toUpdate)
+
+			if test.expectErrorCode == "" {
+				assert.Nil(t, err)
+			} else {
+				// Uses require rather than assert to avoid a nil pointer dereference.
+				require.NotNil(t, err)
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+			}
+
+			now := currentTime()
+			if test.expectManagedIdentity != nil {
+				require.NotNil(t, actualManagedIdentity)
+				compareManagedIdentities(t, test.expectManagedIdentity, actualManagedIdentity, false, &timeBounds{
+					createLow:  &createdLow,
+					createHigh: &createdHigh,
+					updateLow:  &createdLow,
+					updateHigh: &now,
+				})
+			} else {
+				assert.Nil(t, actualManagedIdentity)
+			}
+		})
+	}
+}
+
+func TestUpdateManagedIdentityLastUsedAt(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group 0 for testing managed identity functions",
+		Name:        "top-level-group-0-for-managed-identities",
+		FullPath:    "top-level-group-0-for-managed-identities",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	managedIdentity, err := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:      "managed-identity-0",
+		GroupID:   group.Metadata.ID,
+		CreatedBy: "someone-sa0",
+		Type:      models.ManagedIdentityAWSFederated,
+		Data:      []byte("managed-identity-0-data"),
+	})
+	require.Nil(t, err)
+
+	// A newly created managed identity has never been used.
+	assert.Nil(t, managedIdentity.LastUsedAt)
+
+	firstUse := time.Now().UTC().Add(-time.Minute)
+	secondUse := firstUse.Add(30 * time.Second)
+
+	var previous *time.Time
+	for _, lastUsedAt := range []time.Time{firstUse, secondUse} {
+		err = testClient.client.ManagedIdentities.UpdateManagedIdentityLastUsedAt(ctx, managedIdentity.Metadata.ID, lastUsedAt)
+		require.Nil(t, err)
+
+		updated, gErr := testClient.client.ManagedIdentities.GetManagedIdentityByID(ctx, managedIdentity.Metadata.ID)
+		require.Nil(t, gErr)
+		require.NotNil(t, updated)
+		require.NotNil(t, updated.LastUsedAt)
+
+		assert.WithinDuration(t, lastUsedAt, *updated.LastUsedAt, time.Millisecond)
+		if previous != nil {
+			assert.True(t, updated.LastUsedAt.After(*previous))
+		}
+
+		// Recording usage must not bump the resource version.
+		assert.Equal(t, managedIdentity.Metadata.Version, updated.Metadata.Version)
+
+		previous = updated.LastUsedAt
+	}
+
+	// Sort by last used so the identity that was used comes after one that never was.
+	neverUsed, err := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:      "managed-identity-1",
+		GroupID:   group.Metadata.ID,
+		CreatedBy: "someone-sa1",
+		Type:      models.ManagedIdentityAWSFederated,
+		Data:      []byte("managed-identity-1-data"),
+	})
+	require.Nil(t, err)
+
+	sort := ManagedIdentitySortableFieldLastUsedAtAsc
+	result, err := testClient.client.ManagedIdentities.GetManagedIdentities(ctx, &GetManagedIdentitiesInput{
+		Sort: &sort,
+		Filter: &ManagedIdentityFilter{
+			NamespacePaths: []string{group.FullPath},
+		},
+	})
+	require.Nil(t, err)
+	require.Len(t, result.ManagedIdentities, 2)
+
+	// Identities that have never been used sort as if last used at the zero time.
+	assert.Equal(t, neverUsed.Metadata.ID, result.ManagedIdentities[0].Metadata.ID)
+	assert.Equal(t, managedIdentity.Metadata.ID, result.ManagedIdentities[1].Metadata.ID)
+
+	// Paginating one at a time must neither skip nor repeat the identity that was never used.
+	seen := []string{}
+	var after *string
+	for {
+		page, pErr := testClient.client.ManagedIdentities.GetManagedIdentities(ctx, &GetManagedIdentitiesInput{
+			Sort: &sort,
+			PaginationOptions: &pagination.Options{
+				First: ptr.Int32(1),
+				After: after,
+			},
+			Filter: &ManagedIdentityFilter{
+				NamespacePaths: []string{group.FullPath},
+			},
+		})
+		require.Nil(t, pErr)
+
+		for _, identity := range page.ManagedIdentities {
+			seen = append(seen, identity.Metadata.ID)
+		}
+
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+
+		after, pErr = page.PageInfo.Cursor(&page.ManagedIdentities[len(page.ManagedIdentities)-1])
+		require.Nil(t, pErr)
+	}
+
+	assert.Equal(t, []string{neverUsed.Metadata.ID, managedIdentity.Metadata.ID}, seen)
+}
+
+func TestCreateCredentialIssuance(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group 0 for testing managed identity functions",
+		Name:        "top-level-group-0-for-managed-identities",
+		FullPath:    "top-level-group-0-for-managed-identities",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	workspace, err := testClient.client.Workspaces.CreateWorkspace(ctx, &models.Workspace{
+		Name:           "workspace-0",
+		GroupID:        group.Metadata.ID,
+		CreatedBy:      "someone-w0",
+		MaxJobDuration: ptr.Int32(60),
+	})
+	require.Nil(t, err)
+
+	managedIdentity, err := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:      "managed-identity-0",
+		GroupID:   group.Metadata.ID,
+		CreatedBy: "someone-sa0",
+		Type:      models.ManagedIdentityAWSFederated,
+		Data:      []byte("managed-identity-0-data"),
+	})
+	require.Nil(t, err)
+
+	runIDs := []string{}
+	jobIDs := []string{}
+	for i := 0; i < 3; i++ {
+		run, rErr := testClient.client.Runs.CreateRun(ctx, &models.Run{
+			WorkspaceID: workspace.Metadata.ID,
+			CreatedBy:   "someone-r0",
+		})
+		require.Nil(t, rErr)
+
+		job, jErr := testClient.client.Jobs.CreateJob(ctx, &models.Job{
+			WorkspaceID: workspace.Metadata.ID,
+			RunID:       run.Metadata.ID,
+			Type:        models.JobPlanType,
+		})
+		require.Nil(t, jErr)
+
+		runIDs = append(runIDs, run.Metadata.ID)
+		jobIDs = append(jobIDs, job.Metadata.ID)
+	}
+
+	// The first run is issued credentials twice, the second once and the third never.
+	for _, i := range []int{0, 0, 1} {
+		require.Nil(t, testClient.client.ManagedIdentities.CreateCredentialIssuance(ctx,
+			managedIdentity.Metadata.ID, runIDs[i], jobIDs[i]))
+	}
+
+	// Recording an issuance for a run that doesn't exist fails.
+	err = testClient.client.ManagedIdentities.CreateCredentialIssuance(ctx, managedIdentity.Metadata.ID, nonExistentID, jobIDs[0])
+	assert.Equal(t, errors.ENotFound, errors.ErrorCode(err))
+
+	result, err := testClient.client.Runs.GetRuns(ctx, &GetRunsInput{
+		Filter: &RunFilter{
+			ManagedIdentityID: &managedIdentity.Metadata.ID,
+		},
+	})
+	require.Nil(t, err)
+
+	actualRunIDs := []string{}
+	for _, run := range result.Runs {
+		actualRunIDs = append(actualRunIDs, run.Metadata.ID)
+	}
+	assert.ElementsMatch(t, runIDs[:2], actualRunIDs)
+}
+
+func TestGetManagedIdentityCount(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group 0 for testing managed identity functions",
+		Name:        "top-level-group-0-for-managed-identities",
+		FullPath:    "top-level-group-0-for-managed-identities",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	otherGroup, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group 1 for testing managed identity functions",
+		Name:        "top-level-group-1-for-managed-identities",
+		FullPath:    "top-level-group-1-for-managed-identities",
+		CreatedBy:   "someone-g1",
+	})
+	require.Nil(t, err)
+
+	var source *models.ManagedIdentity
+	for _, name := range []string{"identity-a", "identity-b", "identity-c"} {
+		identity, cErr := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+			Name:      name,
+			GroupID:   group.Metadata.ID,
+			CreatedBy: "someone-sa0",
+			Type:      models.ManagedIdentityAWSFederated,
+			Data:      []byte("managed-identity-data"),
+		})
+		require.Nil(t, cErr)
+
+		if source == nil {
+			source = identity
+		}
+	}
+
+	_, err = testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:          "identity-a-alias",
+		GroupID:       otherGroup.Metadata.ID,
+		CreatedBy:     "someone-sa0",
+		AliasSourceID: &source.Metadata.ID,
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		filter *ManagedIdentityFilter
+		name   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "no filter",
+		},
+		{
+			name:   "filter by namespace path",
+			filter: &ManagedIdentityFilter{NamespacePaths: []string{group.FullPath}},
+		},
+		{
+			name:   "filter by alias source",
+			filter: &ManagedIdentityFilter{AliasSourceID: &source.Metadata.ID},
+		},
+		{
+			name:   "filter by search",
+			filter: &ManagedIdentityFilter{Search: ptr.String("identity-a")},
+		},
+		{
+			name:   "filter matches nothing",
+			filter: &ManagedIdentityFilter{NamespacePaths: []string{"this-path-does-not-exist"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.ManagedIdentities.GetManagedIdentities(ctx, &GetManagedIdentitiesInput{Filter: test.filter})
+			require.Nil(t, err)
+
+			count, err := testClient.client.ManagedIdentities.GetManagedIdentityCount(ctx, test.filter)
+			require.Nil(t, err)
+
+			assert.Equal(t, result.PageInfo.TotalCount, count)
+			assert.Equal(t, int32(len(result.ManagedIdentities)), count)
+		})
+	}
+}
+
+func TestGetManagedIdentitiesUnusedSince(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group 0 for testing managed identity functions",
+		Name:        "top-level-group-0-for-managed-identities",
+		FullPath:    "top-level-group-0-for-managed-identities",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	now := time.Now().UTC()
+	cutoff := now.Add(-30 * 24 * time.Hour)
+
+	// Maps the identity name to when it was last used, nil means never used.
+	lastUsed := map[string]*time.Time{
+		"never-used":    nil,
+		"recently-used": ptr.Time(now.Add(-time.Hour)),
+		"stale":         ptr.Time(cutoff.Add(-24 * time.Hour)),
+	}
+
+	for name, lastUsedAt := range lastUsed {
+		identity, cErr := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+			Name:      name,
+			GroupID:   group.Metadata.ID,
+			CreatedBy: "someone-sa0",
+			Type:      models.ManagedIdentityAWSFederated,
+			Data:      []byte("managed-identity-data"),
+		})
+		require.Nil(t, cErr)
+
+		if lastUsedAt != nil {
+			require.Nil(t, testClient.client.ManagedIdentities.UpdateManagedIdentityLastUsedAt(ctx, identity.Metadata.ID, *lastUsedAt))
+		}
+	}
+
+	type testCase struct {
+		unusedSince *time.Time
+		name        string
+		expectNames []string
+	}
+
+	testCases := []testCase{
+		{
+			name:        "no cutoff returns all identities",
+			expectNames: []string{"never-used", "recently-used", "stale"},
+		},
+		{
+			name:        "cutoff returns never used and stale identities",
+			unusedSince: &cutoff,
+			expectNames: []string{"never-used", "stale"},
+		},
+		{
+			name:        "cutoff in the future returns all identities",
+			unusedSince: ptr.Time(now.Add(time.Hour)),
+			expectNames: []string{"never-used", "recently-used", "stale"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.ManagedIdentities.GetManagedIdentities(ctx, &GetManagedIdentitiesInput{
+				Filter: &ManagedIdentityFilter{
+					NamespacePaths: []string{group.FullPath},
+					UnusedSince:    test.unusedSince,
+				},
+			})
+			require.Nil(t, err)
+
+			actualNames := []string{}
+			for _, identity := range result.ManagedIdentities {
+				actualNames = append(actualNames, identity.Name)
+			}
+
+			assert.ElementsMatch(t, test.expectNames, actualNames)
+		})
+	}
+}
+
+func TestGetManagedIdentitiesAliasesOnly(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group for testing the aliases only filter",
+		Name:        "top-level-group-for-aliases-only",
+		FullPath:    "top-level-group-for-aliases-only",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	sourceNames := []string{"source-0", "source-1"}
+	aliasNames := []string{"alias-0", "alias-1", "alias-2"}
+
+	sources := []*models.ManagedIdentity{}
+	for _, name := range sourceNames {
+		source, cErr := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+			Name:      name,
+			GroupID:   group.Metadata.ID,
+			CreatedBy: "someone-mi0",
+			Type:      models.ManagedIdentityAWSFederated,
+			Data:      []byte("managed-identity-data"),
+		})
+		require.Nil(t, cErr)
+		sources = append(sources, source)
+	}
+
+	for i, name := range aliasNames {
+		_, cErr := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+			Name:          name,
+			GroupID:       group.Metadata.ID,
+			CreatedBy:     "someone-ma0",
+			AliasSourceID: &sources[i%len(sources)].Metadata.ID,
+		})
+		require.Nil(t, cErr)
+	}
+
+	type testCase struct {
+		aliasesOnly *bool
+		name        string
+		expectNames []string
+	}
+
+	testCases := []testCase{
+		{
+			name:        "nil returns sources and aliases",
+			expectNames: append(append([]string{}, sourceNames...), aliasNames...),
+		},
+		{
+			name:        "true returns only aliases",
+			aliasesOnly: ptr.Bool(true),
+			expectNames: aliasNames,
+		},
+		{
+			name:        "false returns only sources",
+			aliasesOnly: ptr.Bool(false),
+			expectNames: sourceNames,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.ManagedIdentities.GetManagedIdentities(ctx, &GetManagedIdentitiesInput{
+				Filter: &ManagedIdentityFilter{
+					NamespacePaths: []string{group.FullPath},
+					AliasesOnly:    test.aliasesOnly,
+				},
+			})
+			require.Nil(t, err)
+
+			actualNames := []string{}
+			for _, identity := range result.ManagedIdentities {
+				actualNames = append(actualNames, identity.Name)
+			}
+
+			assert.ElementsMatch(t, test.expectNames, actualNames)
+		})
+	}
+}
+
+func TestGetManagedIdentityTypes(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	parentGroup, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group for testing managed identity types",
+		Name:        "top-level-group-for-types",
+		FullPath:    "top-level-group-for-types",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	childGroup, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "nested group for testing managed identity types",
+		Name:        "nested-group",
+		ParentID:    parentGroup.Metadata.ID,
+		FullPath:    "top-level-group-for-types/nested-group",
+		CreatedBy:   "someone-g1",
+	})
+	require.Nil(t, err)
+
+	identities := []struct {
+		groupID      string
+		name         string
+		identityType models.ManagedIdentityType
+	}{
+		{groupID: parentGroup.Metadata.ID, name: "aws-0", identityType: models.ManagedIdentityAWSFederated},
+		{groupID: parentGroup.Metadata.ID, name: "aws-1", identityType: models.ManagedIdentityAWSFederated},
+		{groupID: parentGroup.Metadata.ID, name: "azure-0", identityType: models.ManagedIdentityAzureFederated},
+		{groupID: childGroup.Metadata.ID, name: "tharsis-0", identityType: models.ManagedIdentityTharsisFederated},
+	}
+
+	for _, identity := range identities {
+		_, cErr := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+			Name:      identity.name,
+			GroupID:   identity.groupID,
+			CreatedBy: "someone-mi0",
+			Type:      identity.identityType,
+			Data:      []byte("managed-identity-data"),
+		})
+		require.Nil(t, cErr)
+	}
+
+	type testCase struct {
+		name           string
+		namespacePaths []string
+		expectTypes    []models.ManagedIdentityType
+	}
+
+	testCases := []testCase{
+		{
+			name:           "parent group only",
+			namespacePaths: []string{parentGroup.FullPath},
+			expectTypes: []models.ManagedIdentityType{
+				models.ManagedIdentityAWSFederated,
+				models.ManagedIdentityAzureFederated,
+			},
+		},
+		{
+			name:           "nested group including inherited identities",
+			namespacePaths: []string{childGroup.FullPath, parentGroup.FullPath},
+			expectTypes: []models.ManagedIdentityType{
+				models.ManagedIdentityAWSFederated,
+				models.ManagedIdentityAzureFederated,
+				models.ManagedIdentityTharsisFederated,
+			},
+		},
+		{
+			name:           "namespace without any managed identities",
+			namespacePaths: []string{"non-existent-group"},
+			expectTypes:    []models.ManagedIdentityType{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			types, err := testClient.client.ManagedIdentities.GetManagedIdentityTypes(ctx, &ManagedIdentityFilter{
+				NamespacePaths: test.namespacePaths,
+			})
+			require.Nil(t, err)
+
+			assert.ElementsMatch(t, test.expectTypes, types)
+		})
+	}
+}
+
+func TestGetManagedIdentitiesSearchDescription(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group for testing the description search",
+		Name:        "top-level-group-for-description-search",
+		FullPath:    "top-level-group-for-description-search",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	source, err := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:        "identity-0",
+		Description: "Deploys the Billing service",
+		GroupID:     group.Metadata.ID,
+		CreatedBy:   "someone-mi0",
+		Type:        models.ManagedIdentityAWSFederated,
+		Data:        []byte("managed-identity-data"),
+	})
+	require.Nil(t, err)
+
+	_, err = testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:        "identity-1",
+		Description: "Deploys the inventory service",
+		GroupID:     group.Metadata.ID,
+		CreatedBy:   "someone-mi0",
+		Type:        models.ManagedIdentityAWSFederated,
+		Data:        []byte("managed-identity-data"),
+	})
+	require.Nil(t, err)
+
+	_, err = testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:          "alias-0",
+		GroupID:       group.Metadata.ID,
+		CreatedBy:     "someone-ma0",
+		AliasSourceID: &source.Metadata.ID,
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		filter      *ManagedIdentityFilter
+		name        string
+		expectNames []string
+	}
+
+	testCases := []testCase{
+		{
+			name:        "term only matches the description, case insensitive",
+			filter:      &ManagedIdentityFilter{Search: ptr.String("billing")},
+			expectNames: []string{"identity-0", "alias-0"},
+		},
+		{
+			name:        "term matches the description of multiple identities",
+			filter:      &ManagedIdentityFilter{Search: ptr.String("deploys the")},
+			expectNames: []string{"identity-0", "identity-1", "alias-0"},
+		},
+		{
+			name: "description search is combined with other filters",
+			filter: &ManagedIdentityFilter{
+				Search:      ptr.String("billing"),
+				AliasesOnly: ptr.Bool(false),
 			},
-			expectErrorCode: errors.EInternal,
+			expectNames: []string{"identity-0"},
+		},
+		{
+			name:        "term matches nothing",
+			filter:      &ManagedIdentityFilter{Search: ptr.String("payroll")},
+			expectNames: []string{},
 		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
-			actualManagedIdentity, err := testClient.client.ManagedIdentities.UpdateManagedIdentity(ctx, test.toUpdate)
+			test.filter.NamespacePaths = []string{group.FullPath}
 
-			if test.expectErrorCode == "" {
-				assert.Nil(t, err)
-			} else {
-				// Uses require rather than assert to avoid a nil pointer dereference.
-				require.NotNil(t, err)
-				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
-			}
+			result, err := testClient.client.ManagedIdentities.GetManagedIdentities(ctx, &GetManagedIdentitiesInput{
+				Filter: test.filter,
+			})
+			require.Nil(t, err)
 
-			now := currentTime()
-			if test.expectManagedIdentity != nil {
-				require.NotNil(t, actualManagedIdentity)
-				compareManagedIdentities(t, test.expectManagedIdentity, actualManagedIdentity, false, &timeBounds{
-					createLow:  &createdLow,
-					createHigh: &createdHigh,
-					updateLow:  &createdLow,
-					updateHigh: &now,
-				})
-			} else {
-				assert.Nil(t, actualManagedIdentity)
+			actualNames := []string{}
+			for _, identity := range result.ManagedIdentities {
+				actualNames = append(actualNames, identity.Name)
 			}
+
+			assert.ElementsMatch(t, test.expectNames, actualNames)
+		})
+	}
+}
+
+func TestGetManagedIdentitiesByGroupID(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	parentGroup, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Name:      "top-level-group-0-for-managed-identities",
+		FullPath:  "top-level-group-0-for-managed-identities",
+		CreatedBy: "someone-g0",
+	})
+	require.Nil(t, err)
+
+	childGroup, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Name:      "child-group",
+		ParentID:  parentGroup.Metadata.ID,
+		FullPath:  parentGroup.FullPath + "/child-group",
+		CreatedBy: "someone-g1",
+	})
+	require.Nil(t, err)
+
+	for _, group := range []*models.Group{parentGroup, childGroup} {
+		for _, name := range []string{"identity-a", "identity-b"} {
+			_, err = testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+				Name:      name,
+				GroupID:   group.Metadata.ID,
+				CreatedBy: "someone-sa0",
+				Type:      models.ManagedIdentityAWSFederated,
+				Data:      []byte("managed-identity-data"),
+			})
+			require.Nil(t, err)
+		}
+	}
+
+	for _, group := range []*models.Group{parentGroup, childGroup} {
+		t.Run(group.FullPath, func(t *testing.T) {
+			byPath, err := testClient.client.ManagedIdentities.GetManagedIdentities(ctx, &GetManagedIdentitiesInput{
+				Filter: &ManagedIdentityFilter{
+					NamespacePaths: []string{group.FullPath},
+				},
+			})
+			require.Nil(t, err)
+
+			byID, err := testClient.client.ManagedIdentities.GetManagedIdentities(ctx, &GetManagedIdentitiesInput{
+				Filter: &ManagedIdentityFilter{
+					GroupID: &group.Metadata.ID,
+				},
+			})
+			require.Nil(t, err)
+
+			assert.Len(t, byID.ManagedIdentities, 2)
+			assert.ElementsMatch(t, byPath.ManagedIdentities, byID.ManagedIdentities)
 		})
 	}
 }
@@ -949,6 +1770,150 @@ func TestGetManagedIdentitiesWithPagination(t *testing.T) {
 	assert.False(t, page2.PageInfo.HasNextPage)
 }
 
+func TestGetManagedIdentitiesForWorkspaceWithPagination(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group 0 for testing managed identity functions",
+		FullPath:    "top-level-group-0-for-managed-identities",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	maxJobDuration := int32((time.Hour * 12).Minutes())
+	workspace, err := testClient.client.Workspaces.CreateWorkspace(ctx, &models.Workspace{
+		Description:    "workspace 0 for testing managed identity functions",
+		FullPath:       "top-level-group-0-for-managed-identities/workspace-0-for-managed-identities",
+		GroupID:        group.Metadata.ID,
+		CreatedBy:      "someone-w0",
+		MaxJobDuration: &maxJobDuration,
+	})
+	require.Nil(t, err)
+
+	createIdentity := func(name string) *models.ManagedIdentity {
+		identity, cErr := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+			Name:      name,
+			GroupID:   group.Metadata.ID,
+			CreatedBy: "someone-mi",
+			Type:      models.ManagedIdentityAWSFederated,
+			Data:      []byte(name + "-data"),
+		})
+		require.Nil(t, cErr)
+		return identity
+	}
+
+	assignedIDs := map[string]bool{}
+	for i := 0; i < 4; i++ {
+		identity := createIdentity(fmt.Sprintf("managed-identity-%d", i))
+		require.Nil(t, testClient.client.ManagedIdentities.AddManagedIdentityToWorkspace(ctx, identity.Metadata.ID, workspace.Metadata.ID))
+		assignedIDs[identity.Metadata.ID] = true
+	}
+
+	// An identity that isn't assigned to the workspace must never be returned
+	unassigned := createIdentity("unassigned-managed-identity")
+
+	// All identities are in the same group so sorting by group level makes every row tie on the
+	// sort key and the ordering relies entirely on the primary key tiebreak.
+	sort := ManagedIdentitySortableFieldGroupLevelAsc
+
+	page1, err := testClient.client.ManagedIdentities.GetManagedIdentities(ctx, &GetManagedIdentitiesInput{
+		Sort:              &sort,
+		PaginationOptions: &pagination.Options{First: ptr.Int32(2)},
+		Filter:            &ManagedIdentityFilter{WorkspaceID: &workspace.Metadata.ID},
+	})
+	require.Nil(t, err)
+	require.Len(t, page1.ManagedIdentities, 2)
+	assert.True(t, page1.PageInfo.HasNextPage)
+	assert.Equal(t, int32(4), page1.PageInfo.TotalCount)
+
+	cursor, err := page1.PageInfo.Cursor(&page1.ManagedIdentities[len(page1.ManagedIdentities)-1])
+	require.Nil(t, err)
+
+	// Assign another identity between page requests
+	added := createIdentity("managed-identity-added-between-pages")
+	require.Nil(t, testClient.client.ManagedIdentities.AddManagedIdentityToWorkspace(ctx, added.Metadata.ID, workspace.Metadata.ID))
+
+	page2, err := testClient.client.ManagedIdentities.GetManagedIdentities(ctx, &GetManagedIdentitiesInput{
+		Sort:              &sort,
+		PaginationOptions: &pagination.Options{First: ptr.Int32(10), After: cursor},
+		Filter:            &ManagedIdentityFilter{WorkspaceID: &workspace.Metadata.ID},
+	})
+	require.Nil(t, err)
+	assert.False(t, page2.PageInfo.HasNextPage)
+
+	seen := map[string]bool{}
+	for _, identity := range append(page1.ManagedIdentities, page2.ManagedIdentities...) {
+		assert.False(t, seen[identity.Metadata.ID], "managed identity %s returned on more than one page", identity.Metadata.ID)
+		assert.NotEqual(t, unassigned.Metadata.ID, identity.Metadata.ID)
+		seen[identity.Metadata.ID] = true
+	}
+
+	// Every identity assigned before the scan started must be returned exactly once; the one added
+	// mid-scan is only returned if it sorts after the cursor.
+	for id := range assignedIDs {
+		assert.True(t, seen[id], "managed identity %s was skipped", id)
+	}
+	if seen[added.Metadata.ID] {
+		assert.Len(t, seen, 5)
+	} else {
+		assert.Len(t, seen, 4)
+	}
+}
+
+func TestGetManagedIdentitiesNotAssignedToWorkspace(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group 0 for testing managed identity functions",
+		FullPath:    "top-level-group-0-for-managed-identities",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	maxJobDuration := int32((time.Hour * 12).Minutes())
+	workspace, err := testClient.client.Workspaces.CreateWorkspace(ctx, &models.Workspace{
+		Description:    "workspace 0 for testing managed identity functions",
+		FullPath:       "top-level-group-0-for-managed-identities/workspace-0-for-managed-identities",
+		GroupID:        group.Metadata.ID,
+		CreatedBy:      "someone-w0",
+		MaxJobDuration: &maxJobDuration,
+	})
+	require.Nil(t, err)
+
+	unassignedIDs := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		identity, cErr := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+			Name:      fmt.Sprintf("managed-identity-%d", i),
+			GroupID:   group.Metadata.ID,
+			CreatedBy: "someone-mi",
+			Type:      models.ManagedIdentityAWSFederated,
+			Data:      []byte(fmt.Sprintf("managed-identity-%d-data", i)),
+		})
+		require.Nil(t, cErr)
+
+		// Only the first identity is assigned to the workspace
+		if i == 0 {
+			require.Nil(t, testClient.client.ManagedIdentities.AddManagedIdentityToWorkspace(ctx, identity.Metadata.ID, workspace.Metadata.ID))
+		} else {
+			unassignedIDs[identity.Metadata.ID] = true
+		}
+	}
+
+	result, err := testClient.client.ManagedIdentities.GetManagedIdentities(ctx, &GetManagedIdentitiesInput{
+		Filter: &ManagedIdentityFilter{NotAssignedToWorkspaceID: &workspace.Metadata.ID},
+	})
+	require.Nil(t, err)
+
+	require.Len(t, result.ManagedIdentities, len(unassignedIDs))
+	for _, identity := range result.ManagedIdentities {
+		assert.True(t, unassignedIDs[identity.Metadata.ID], "managed identity %s is already assigned to the workspace", identity.Metadata.ID)
+	}
+}
+
 func TestGetManagedIdentities(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -1503,6 +2468,87 @@ func TestGetManagedIdentities(t *testing.T) {
 	}
 }
 
+func TestGetManagedIdentitiesWithAssignedWorkspaceCount(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group for testing assigned workspace counts",
+		FullPath:    "top-level-group-for-assigned-workspace-counts",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	maxJobDuration := int32((time.Hour * 12).Minutes())
+	workspaceIDs := []string{}
+	for i := 0; i < 2; i++ {
+		workspace, wErr := testClient.client.Workspaces.CreateWorkspace(ctx, &models.Workspace{
+			Description:    fmt.Sprintf("workspace %d for testing assigned workspace counts", i),
+			FullPath:       fmt.Sprintf("top-level-group-for-assigned-workspace-counts/workspace-%d", i),
+			GroupID:        group.Metadata.ID,
+			CreatedBy:      "someone-w0",
+			MaxJobDuration: &maxJobDuration,
+		})
+		require.Nil(t, wErr)
+
+		workspaceIDs = append(workspaceIDs, workspace.Metadata.ID)
+	}
+
+	// The number of workspaces each managed identity will be assigned to.
+	assignments := []int{2, 1, 0}
+	expectCounts := map[string]int32{}
+	for i, assignmentCount := range assignments {
+		managedIdentity, mErr := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+			Name:      fmt.Sprintf("managed-identity-%d", i),
+			GroupID:   group.Metadata.ID,
+			CreatedBy: "someone-mi0",
+			Type:      models.ManagedIdentityAWSFederated,
+			Data:      []byte(fmt.Sprintf("managed-identity-%d-data", i)),
+		})
+		require.Nil(t, mErr)
+
+		for _, workspaceID := range workspaceIDs[:assignmentCount] {
+			err = testClient.client.ManagedIdentities.AddManagedIdentityToWorkspace(ctx, managedIdentity.Metadata.ID, workspaceID)
+			require.Nil(t, err)
+		}
+
+		expectCounts[managedIdentity.Metadata.ID] = int32(assignmentCount)
+	}
+
+	type testCase struct {
+		name                          string
+		expectCounts                  map[string]int32
+		includeAssignedWorkspaceCount bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "counts are not returned by default",
+		},
+		{
+			name:                          "counts are returned when requested",
+			includeAssignedWorkspaceCount: true,
+			expectCounts:                  expectCounts,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.ManagedIdentities.GetManagedIdentities(ctx, &GetManagedIdentitiesInput{
+				Filter: &ManagedIdentityFilter{
+					NamespacePaths: []string{group.FullPath},
+				},
+				IncludeAssignedWorkspaceCount: test.includeAssignedWorkspaceCount,
+			})
+			require.Nil(t, err)
+
+			assert.Equal(t, len(assignments), len(result.ManagedIdentities))
+			assert.Equal(t, test.expectCounts, result.AssignedWorkspaceCount)
+		})
+	}
+}
+
 func TestDeleteManagedIdentity(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -1574,6 +2620,68 @@ func TestDeleteManagedIdentity(t *testing.T) {
 	}
 }
 
+func TestGetOrphanedManagedIdentityAliases(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group for testing orphaned aliases",
+		FullPath:    "top-level-group-for-orphaned-aliases",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	sources := []*models.ManagedIdentity{}
+	for i := 0; i < 2; i++ {
+		source, cErr := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+			Name:      fmt.Sprintf("source-managed-identity-%d", i),
+			GroupID:   group.Metadata.ID,
+			CreatedBy: "someone-mi0",
+			Type:      models.ManagedIdentityAWSFederated,
+			Data:      []byte("managed-identity-data"),
+		})
+		require.Nil(t, cErr)
+		sources = append(sources, source)
+	}
+
+	orphanedAlias, err := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:          "alias-to-be-orphaned",
+		GroupID:       group.Metadata.ID,
+		CreatedBy:     "someone-ma0",
+		AliasSourceID: &sources[0].Metadata.ID,
+	})
+	require.Nil(t, err)
+
+	_, err = testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:          "alias-with-source",
+		GroupID:       group.Metadata.ID,
+		CreatedBy:     "someone-ma1",
+		AliasSourceID: &sources[1].Metadata.ID,
+	})
+	require.Nil(t, err)
+
+	// No orphans exist yet
+	aliases, err := testClient.client.ManagedIdentities.GetOrphanedManagedIdentityAliases(ctx)
+	require.Nil(t, err)
+	assert.Empty(t, aliases)
+
+	// Delete the source without firing the foreign key triggers, which would otherwise cascade to the alias
+	tx, err := testClient.client.conn.Begin(ctx)
+	require.Nil(t, err)
+	_, err = tx.Exec(ctx, "SET LOCAL session_replication_role = replica")
+	require.Nil(t, err)
+	_, err = tx.Exec(ctx, "DELETE FROM managed_identities WHERE id = $1", sources[0].Metadata.ID)
+	require.Nil(t, err)
+	require.Nil(t, tx.Commit(ctx))
+
+	aliases, err = testClient.client.ManagedIdentities.GetOrphanedManagedIdentityAliases(ctx)
+	require.Nil(t, err)
+	require.Len(t, aliases, 1)
+	assert.Equal(t, orphanedAlias.Metadata.ID, aliases[0].Metadata.ID)
+	assert.Equal(t, sources[0].Metadata.ID, *aliases[0].AliasSourceID)
+}
+
 func TestGetManagedIdentityAccessRules(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -1707,6 +2815,96 @@ func TestGetManagedIdentityAccessRules(t *testing.T) {
 	}
 }
 
+func TestGetManagedIdentityAccessRulesByRunStage(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group 0 for testing managed identity functions",
+		FullPath:    "top-level-group-0-for-managed-identities",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	managedIdentity, err := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+		Name:        "1-managed-identity-0",
+		Description: "managed identity 0 for testing managed identities",
+		GroupID:     group.Metadata.ID,
+		CreatedBy:   "someone-sa0",
+		Type:        models.ManagedIdentityAWSFederated,
+		Data:        []byte("managed-identity-0-data"),
+	})
+	require.Nil(t, err)
+
+	user, err := testClient.client.Users.CreateUser(ctx, &models.User{
+		Username: "user-0",
+		Email:    "user-0@example.invalid",
+	})
+	require.Nil(t, err)
+
+	planRule, err := testClient.client.ManagedIdentities.CreateManagedIdentityAccessRule(ctx, &models.ManagedIdentityAccessRule{
+		RunStage:          models.JobPlanType,
+		Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+		ManagedIdentityID: managedIdentity.Metadata.ID,
+		AllowedUserIDs:    []string{user.Metadata.ID},
+	})
+	require.Nil(t, err)
+
+	applyRule, err := testClient.client.ManagedIdentities.CreateManagedIdentityAccessRule(ctx, &models.ManagedIdentityAccessRule{
+		RunStage:          models.JobApplyType,
+		Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+		ManagedIdentityID: managedIdentity.Metadata.ID,
+		AllowedUserIDs:    []string{user.Metadata.ID},
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		runStage      *models.JobType
+		name          string
+		expectRuleIDs []string
+	}
+
+	testCases := []testCase{
+		{
+			name:          "plan stage rules only",
+			runStage:      ptrJobType(models.JobPlanType),
+			expectRuleIDs: []string{planRule.Metadata.ID},
+		},
+		{
+			name:          "apply stage rules only",
+			runStage:      ptrJobType(models.JobApplyType),
+			expectRuleIDs: []string{applyRule.Metadata.ID},
+		},
+		{
+			name:          "all run stages",
+			expectRuleIDs: []string{planRule.Metadata.ID, applyRule.Metadata.ID},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.ManagedIdentities.GetManagedIdentityAccessRules(ctx, &GetManagedIdentityAccessRulesInput{
+				Filter: &ManagedIdentityAccessRuleFilter{
+					ManagedIdentityID: &managedIdentity.Metadata.ID,
+					RunStage:          test.runStage,
+				},
+			})
+			require.Nil(t, err)
+
+			actualRuleIDs := []string{}
+			for _, rule := range result.ManagedIdentityAccessRules {
+				if test.runStage != nil {
+					assert.Equal(t, *test.runStage, rule.RunStage)
+				}
+				actualRuleIDs = append(actualRuleIDs, rule.Metadata.ID)
+			}
+
+			assert.ElementsMatch(t, test.expectRuleIDs, actualRuleIDs)
+		})
+	}
+}
+
 func TestGetManagedIdentityAccessRule(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -2231,6 +3429,10 @@ func ptrManagedIdentitySortableField(arg ManagedIdentitySortableField) *ManagedI
 	return &arg
 }
 
+func ptrJobType(arg models.JobType) *models.JobType {
+	return &arg
+}
+
 func (miis managedIdentityInfoIDSlice) Len() int {
 	return len(miis)
 }
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS workspace_run_locks_notify_event ON workspace_run_locks;
+DROP TABLE IF EXISTS workspace_run_locks;
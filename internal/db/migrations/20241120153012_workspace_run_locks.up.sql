@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS workspace_run_locks (
+    id UUID PRIMARY KEY,
+    version INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    created_by VARCHAR NOT NULL,
+    workspace_id UUID NOT NULL,
+    run_id UUID NOT NULL,
+    CONSTRAINT fk_workspace_id FOREIGN KEY(workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE,
+    CONSTRAINT fk_run_id FOREIGN KEY(run_id) REFERENCES runs(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX index_workspace_run_locks_on_workspace_id ON workspace_run_locks(workspace_id);
+CREATE INDEX index_workspace_run_locks_on_run_id ON workspace_run_locks(run_id);
+
+CREATE TRIGGER workspace_run_locks_notify_event
+AFTER INSERT OR UPDATE OR DELETE ON workspace_run_locks
+    FOR EACH ROW EXECUTE PROCEDURE notify_event();
@@ -0,0 +1,2 @@
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS runner_tags JSONB;
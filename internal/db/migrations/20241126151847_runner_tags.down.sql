@@ -0,0 +1,2 @@
+ALTER TABLE runners
+    DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,2 @@
+ALTER TABLE runners
+    ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS index_workspaces_on_environment;
+
+ALTER TABLE workspaces
+    DROP COLUMN IF EXISTS environment;
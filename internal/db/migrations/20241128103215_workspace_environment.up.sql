@@ -0,0 +1,4 @@
+ALTER TABLE workspaces
+    ADD COLUMN IF NOT EXISTS environment VARCHAR;
+
+CREATE INDEX IF NOT EXISTS index_workspaces_on_environment ON workspaces(environment);
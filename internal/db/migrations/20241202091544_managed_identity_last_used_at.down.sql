@@ -0,0 +1,2 @@
+ALTER TABLE managed_identities
+    DROP COLUMN IF EXISTS last_used_at;
@@ -0,0 +1,2 @@
+ALTER TABLE managed_identities
+    ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS group_resource_limits (
+    id UUID PRIMARY KEY,
+    version INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    group_id UUID NOT NULL,
+    name VARCHAR NOT NULL,
+    value INTEGER NOT NULL,
+    CONSTRAINT fk_group_id FOREIGN KEY(group_id) REFERENCES groups(id) ON DELETE CASCADE,
+    CONSTRAINT fk_name FOREIGN KEY(name) REFERENCES resource_limits(name) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS index_group_resource_limits_on_group_id_name ON group_resource_limits(group_id, name);
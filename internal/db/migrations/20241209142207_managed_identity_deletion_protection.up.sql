@@ -0,0 +1,2 @@
+ALTER TABLE managed_identities
+    ADD COLUMN IF NOT EXISTS deletion_protected BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE namespace_variables
+    DROP COLUMN IF EXISTS non_overridable;
@@ -0,0 +1,2 @@
+ALTER TABLE namespace_variables
+    ADD COLUMN IF NOT EXISTS non_overridable BOOLEAN NOT NULL DEFAULT FALSE;
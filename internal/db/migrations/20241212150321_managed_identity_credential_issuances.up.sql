@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS managed_identity_credential_issuances (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    managed_identity_id UUID NOT NULL,
+    run_id UUID NOT NULL,
+    job_id UUID NOT NULL,
+    CONSTRAINT fk_managed_identity_id FOREIGN KEY(managed_identity_id) REFERENCES managed_identities(id) ON DELETE CASCADE,
+    CONSTRAINT fk_run_id FOREIGN KEY(run_id) REFERENCES runs(id) ON DELETE CASCADE,
+    CONSTRAINT fk_job_id FOREIGN KEY(job_id) REFERENCES jobs(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS index_managed_identity_credential_issuances_on_managed_identity_id ON managed_identity_credential_issuances(managed_identity_id);
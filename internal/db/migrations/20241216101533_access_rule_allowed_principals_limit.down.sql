@@ -0,0 +1 @@
+DELETE FROM resource_limits WHERE id = '5b0e6a47-3f1d-4c8e-9a52-7d2e8c41b9f3';
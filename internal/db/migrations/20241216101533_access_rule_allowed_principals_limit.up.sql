@@ -0,0 +1,5 @@
+INSERT INTO resource_limits
+(id, version, created_at, updated_at, name, value)
+VALUES
+('5b0e6a47-3f1d-4c8e-9a52-7d2e8c41b9f3', 1, CURRENT_TIMESTAMP(7), CURRENT_TIMESTAMP(7), 'ResourceLimitAllowedPrincipalsPerManagedIdentityAccessRule', 100) -- number of allowed users, service accounts and teams per managed identity access rule
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+ALTER TABLE workspaces
+    ADD COLUMN IF NOT EXISTS allowed_managed_identity_types JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+ALTER TABLE runs
+    DROP COLUMN IF EXISTS access_rule_evaluations;
@@ -0,0 +1,2 @@
+ALTER TABLE runs
+    ADD COLUMN IF NOT EXISTS access_rule_evaluations JSONB NOT NULL DEFAULT '[]';
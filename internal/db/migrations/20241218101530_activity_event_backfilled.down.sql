@@ -0,0 +1,2 @@
+ALTER TABLE activity_events
+    DROP COLUMN IF EXISTS backfilled;
@@ -0,0 +1,2 @@
+ALTER TABLE activity_events
+    ADD COLUMN IF NOT EXISTS backfilled BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE workspace_vcs_provider_links
+    DROP COLUMN IF EXISTS webhook_secret;
@@ -0,0 +1,2 @@
+ALTER TABLE workspace_vcs_provider_links
+    ADD COLUMN IF NOT EXISTS webhook_secret VARCHAR;
@@ -4,6 +4,7 @@ package db
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 	models "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
@@ -40,6 +41,32 @@ func (_m *MockActivityEvents) CreateActivityEvent(ctx context.Context, input *mo
 	return r0, r1
 }
 
+// GetActivityEventCountsByDay provides a mock function with given fields: ctx, filter, from, to
+func (_m *MockActivityEvents) GetActivityEventCountsByDay(ctx context.Context, filter *ActivityEventFilter, from time.Time, to time.Time) (map[string]int, error) {
+	ret := _m.Called(ctx, filter, from, to)
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *ActivityEventFilter, time.Time, time.Time) (map[string]int, error)); ok {
+		return rf(ctx, filter, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *ActivityEventFilter, time.Time, time.Time) map[string]int); ok {
+		r0 = rf(ctx, filter, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *ActivityEventFilter, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, filter, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetActivityEvents provides a mock function with given fields: ctx, input
 func (_m *MockActivityEvents) GetActivityEvents(ctx context.Context, input *GetActivityEventsInput) (*ActivityEventsResult, error) {
 	ret := _m.Called(ctx, input)
@@ -80,6 +80,30 @@ func (_m *MockGPGKeys) GetGPGKeyByID(ctx context.Context, id string) (*models.GP
 	return r0, r1
 }
 
+// GetGPGKeyCount provides a mock function with given fields: ctx, filter
+func (_m *MockGPGKeys) GetGPGKeyCount(ctx context.Context, filter *GPGKeyFilter) (int32, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *GPGKeyFilter) (int32, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *GPGKeyFilter) int32); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *GPGKeyFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetGPGKeys provides a mock function with given fields: ctx, input
 func (_m *MockGPGKeys) GetGPGKeys(ctx context.Context, input *GetGPGKeysInput) (*GPGKeysResult, error) {
 	ret := _m.Called(ctx, input)
@@ -78,6 +78,32 @@ func (_m *MockGroups) GetChildDepth(ctx context.Context, group *models.Group) (i
 	return r0, r1
 }
 
+// GetCreatedBySubjects provides a mock function with given fields: ctx, namespacePath
+func (_m *MockGroups) GetCreatedBySubjects(ctx context.Context, namespacePath string) ([]string, error) {
+	ret := _m.Called(ctx, namespacePath)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, namespacePath)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, namespacePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespacePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetGroupByFullPath provides a mock function with given fields: ctx, path
 func (_m *MockGroups) GetGroupByFullPath(ctx context.Context, path string) (*models.Group, error) {
 	ret := _m.Called(ctx, path)
@@ -130,6 +156,56 @@ func (_m *MockGroups) GetGroupByID(ctx context.Context, id string) (*models.Grou
 	return r0, r1
 }
 
+// GetGroupCount provides a mock function with given fields: ctx, filter
+func (_m *MockGroups) GetGroupCount(ctx context.Context, filter *GroupFilter) (int32, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *GroupFilter) (int32, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *GroupFilter) int32); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *GroupFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupDeletionPreview provides a mock function with given fields: ctx, group
+func (_m *MockGroups) GetGroupDeletionPreview(ctx context.Context, group *models.Group) (*GroupDeletionPreview, error) {
+	ret := _m.Called(ctx, group)
+
+	var r0 *GroupDeletionPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Group) (*GroupDeletionPreview, error)); ok {
+		return rf(ctx, group)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Group) *GroupDeletionPreview); ok {
+		r0 = rf(ctx, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*GroupDeletionPreview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Group) error); ok {
+		r1 = rf(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetGroups provides a mock function with given fields: ctx, input
 func (_m *MockGroups) GetGroups(ctx context.Context, input *GetGroupsInput) (*GroupsResult, error) {
 	ret := _m.Called(ctx, input)
@@ -156,6 +232,32 @@ func (_m *MockGroups) GetGroups(ctx context.Context, input *GetGroupsInput) (*Gr
 	return r0, r1
 }
 
+// GetRootGroup provides a mock function with given fields: ctx, groupID
+func (_m *MockGroups) GetRootGroup(ctx context.Context, groupID string) (*models.Group, error) {
+	ret := _m.Called(ctx, groupID)
+
+	var r0 *models.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Group, error)); ok {
+		return rf(ctx, groupID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Group); ok {
+		r0 = rf(ctx, groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MigrateGroup provides a mock function with given fields: ctx, group, newParentGroup
 func (_m *MockGroups) MigrateGroup(ctx context.Context, group *models.Group, newParentGroup *models.Group) (*models.Group, error) {
 	ret := _m.Called(ctx, group, newParentGroup)
@@ -182,6 +284,56 @@ func (_m *MockGroups) MigrateGroup(ctx context.Context, group *models.Group, new
 	return r0, r1
 }
 
+// ReassignCreatedBy provides a mock function with given fields: ctx, fromSubject, toSubject, namespacePath
+func (_m *MockGroups) ReassignCreatedBy(ctx context.Context, fromSubject string, toSubject string, namespacePath *string) (int, error) {
+	ret := _m.Called(ctx, fromSubject, toSubject, namespacePath)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *string) (int, error)); ok {
+		return rf(ctx, fromSubject, toSubject, namespacePath)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *string) int); ok {
+		r0 = rf(ctx, fromSubject, toSubject, namespacePath)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *string) error); ok {
+		r1 = rf(ctx, fromSubject, toSubject, namespacePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenameGroup provides a mock function with given fields: ctx, group, newName
+func (_m *MockGroups) RenameGroup(ctx context.Context, group *models.Group, newName string) (*models.Group, error) {
+	ret := _m.Called(ctx, group, newName)
+
+	var r0 *models.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Group, string) (*models.Group, error)); ok {
+		return rf(ctx, group, newName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Group, string) *models.Group); ok {
+		r0 = rf(ctx, group, newName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Group, string) error); ok {
+		r1 = rf(ctx, group, newName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateGroup provides a mock function with given fields: ctx, group
 func (_m *MockGroups) UpdateGroup(ctx context.Context, group *models.Group) (*models.Group, error) {
 	ret := _m.Called(ctx, group)
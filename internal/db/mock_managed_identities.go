@@ -4,6 +4,7 @@ package db
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 	models "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
@@ -28,6 +29,20 @@ func (_m *MockManagedIdentities) AddManagedIdentityToWorkspace(ctx context.Conte
 	return r0
 }
 
+// CreateCredentialIssuance provides a mock function with given fields: ctx, managedIdentityID, runID, jobID
+func (_m *MockManagedIdentities) CreateCredentialIssuance(ctx context.Context, managedIdentityID string, runID string, jobID string) error {
+	ret := _m.Called(ctx, managedIdentityID, runID, jobID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, managedIdentityID, runID, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateManagedIdentity provides a mock function with given fields: ctx, managedIdentity
 func (_m *MockManagedIdentities) CreateManagedIdentity(ctx context.Context, managedIdentity *models.ManagedIdentity) (*models.ManagedIdentity, error) {
 	ret := _m.Called(ctx, managedIdentity)
@@ -108,6 +123,32 @@ func (_m *MockManagedIdentities) DeleteManagedIdentityAccessRule(ctx context.Con
 	return r0
 }
 
+// GetCreatedBySubjects provides a mock function with given fields: ctx, namespacePath
+func (_m *MockManagedIdentities) GetCreatedBySubjects(ctx context.Context, namespacePath string) ([]string, error) {
+	ret := _m.Called(ctx, namespacePath)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, namespacePath)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, namespacePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespacePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetManagedIdentities provides a mock function with given fields: ctx, input
 func (_m *MockManagedIdentities) GetManagedIdentities(ctx context.Context, input *GetManagedIdentitiesInput) (*ManagedIdentitiesResult, error) {
 	ret := _m.Called(ctx, input)
@@ -134,6 +175,32 @@ func (_m *MockManagedIdentities) GetManagedIdentities(ctx context.Context, input
 	return r0, r1
 }
 
+// GetManagedIdentitiesForServiceAccount provides a mock function with given fields: ctx, serviceAccountID
+func (_m *MockManagedIdentities) GetManagedIdentitiesForServiceAccount(ctx context.Context, serviceAccountID string) ([]models.ManagedIdentity, error) {
+	ret := _m.Called(ctx, serviceAccountID)
+
+	var r0 []models.ManagedIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.ManagedIdentity, error)); ok {
+		return rf(ctx, serviceAccountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.ManagedIdentity); ok {
+		r0 = rf(ctx, serviceAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ManagedIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, serviceAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetManagedIdentitiesForWorkspace provides a mock function with given fields: ctx, workspaceID
 func (_m *MockManagedIdentities) GetManagedIdentitiesForWorkspace(ctx context.Context, workspaceID string) ([]models.ManagedIdentity, error) {
 	ret := _m.Called(ctx, workspaceID)
@@ -264,6 +331,106 @@ func (_m *MockManagedIdentities) GetManagedIdentityByPath(ctx context.Context, p
 	return r0, r1
 }
 
+// GetManagedIdentityCount provides a mock function with given fields: ctx, filter
+func (_m *MockManagedIdentities) GetManagedIdentityCount(ctx context.Context, filter *ManagedIdentityFilter) (int32, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *ManagedIdentityFilter) (int32, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *ManagedIdentityFilter) int32); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *ManagedIdentityFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManagedIdentityTypes provides a mock function with given fields: ctx, filter
+func (_m *MockManagedIdentities) GetManagedIdentityTypes(ctx context.Context, filter *ManagedIdentityFilter) ([]models.ManagedIdentityType, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []models.ManagedIdentityType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *ManagedIdentityFilter) ([]models.ManagedIdentityType, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *ManagedIdentityFilter) []models.ManagedIdentityType); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ManagedIdentityType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *ManagedIdentityFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrphanedManagedIdentityAliases provides a mock function with given fields: ctx
+func (_m *MockManagedIdentities) GetOrphanedManagedIdentityAliases(ctx context.Context) ([]models.ManagedIdentity, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []models.ManagedIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.ManagedIdentity, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.ManagedIdentity); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ManagedIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReassignCreatedBy provides a mock function with given fields: ctx, fromSubject, toSubject, namespacePath
+func (_m *MockManagedIdentities) ReassignCreatedBy(ctx context.Context, fromSubject string, toSubject string, namespacePath *string) (int, error) {
+	ret := _m.Called(ctx, fromSubject, toSubject, namespacePath)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *string) (int, error)); ok {
+		return rf(ctx, fromSubject, toSubject, namespacePath)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *string) int); ok {
+		r0 = rf(ctx, fromSubject, toSubject, namespacePath)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *string) error); ok {
+		r1 = rf(ctx, fromSubject, toSubject, namespacePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveManagedIdentityFromWorkspace provides a mock function with given fields: ctx, managedIdentityID, workspaceID
 func (_m *MockManagedIdentities) RemoveManagedIdentityFromWorkspace(ctx context.Context, managedIdentityID string, workspaceID string) error {
 	ret := _m.Called(ctx, managedIdentityID, workspaceID)
@@ -330,6 +497,20 @@ func (_m *MockManagedIdentities) UpdateManagedIdentityAccessRule(ctx context.Con
 	return r0, r1
 }
 
+// UpdateManagedIdentityLastUsedAt provides a mock function with given fields: ctx, id, lastUsedAt
+func (_m *MockManagedIdentities) UpdateManagedIdentityLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) error {
+	ret := _m.Called(ctx, id, lastUsedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, lastUsedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewMockManagedIdentities interface {
 	mock.TestingT
 	Cleanup(func())
@@ -54,6 +54,32 @@ func (_m *MockNamespaceMemberships) DeleteNamespaceMembership(ctx context.Contex
 	return r0
 }
 
+// GetNamespaceMembership provides a mock function with given fields: ctx, namespacePath, userID, serviceAccountID, teamID
+func (_m *MockNamespaceMemberships) GetNamespaceMembership(ctx context.Context, namespacePath string, userID *string, serviceAccountID *string, teamID *string) (*models.NamespaceMembership, error) {
+	ret := _m.Called(ctx, namespacePath, userID, serviceAccountID, teamID)
+
+	var r0 *models.NamespaceMembership
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string, *string, *string) (*models.NamespaceMembership, error)); ok {
+		return rf(ctx, namespacePath, userID, serviceAccountID, teamID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string, *string, *string) *models.NamespaceMembership); ok {
+		r0 = rf(ctx, namespacePath, userID, serviceAccountID, teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NamespaceMembership)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *string, *string, *string) error); ok {
+		r1 = rf(ctx, namespacePath, userID, serviceAccountID, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNamespaceMembershipByID provides a mock function with given fields: ctx, id
 func (_m *MockNamespaceMemberships) GetNamespaceMembershipByID(ctx context.Context, id string) (*models.NamespaceMembership, error) {
 	ret := _m.Called(ctx, id)
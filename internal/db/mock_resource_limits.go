@@ -14,6 +14,58 @@ type MockResourceLimits struct {
 	mock.Mock
 }
 
+// GetGroupResourceLimit provides a mock function with given fields: ctx, groupID, name
+func (_m *MockResourceLimits) GetGroupResourceLimit(ctx context.Context, groupID string, name string) (*models.GroupResourceLimit, error) {
+	ret := _m.Called(ctx, groupID, name)
+
+	var r0 *models.GroupResourceLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.GroupResourceLimit, error)); ok {
+		return rf(ctx, groupID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.GroupResourceLimit); ok {
+		r0 = rf(ctx, groupID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GroupResourceLimit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, groupID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNearestGroupResourceLimit provides a mock function with given fields: ctx, groupPath, name
+func (_m *MockResourceLimits) GetNearestGroupResourceLimit(ctx context.Context, groupPath string, name string) (*models.GroupResourceLimit, error) {
+	ret := _m.Called(ctx, groupPath, name)
+
+	var r0 *models.GroupResourceLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.GroupResourceLimit, error)); ok {
+		return rf(ctx, groupPath, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.GroupResourceLimit); ok {
+		r0 = rf(ctx, groupPath, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GroupResourceLimit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, groupPath, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetResourceLimit provides a mock function with given fields: ctx, name
 func (_m *MockResourceLimits) GetResourceLimit(ctx context.Context, name string) (*models.ResourceLimit, error) {
 	ret := _m.Called(ctx, name)
@@ -66,6 +118,32 @@ func (_m *MockResourceLimits) GetResourceLimits(ctx context.Context) ([]models.R
 	return r0, r1
 }
 
+// SetGroupResourceLimit provides a mock function with given fields: ctx, groupResourceLimit
+func (_m *MockResourceLimits) SetGroupResourceLimit(ctx context.Context, groupResourceLimit *models.GroupResourceLimit) (*models.GroupResourceLimit, error) {
+	ret := _m.Called(ctx, groupResourceLimit)
+
+	var r0 *models.GroupResourceLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GroupResourceLimit) (*models.GroupResourceLimit, error)); ok {
+		return rf(ctx, groupResourceLimit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GroupResourceLimit) *models.GroupResourceLimit); ok {
+		r0 = rf(ctx, groupResourceLimit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GroupResourceLimit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.GroupResourceLimit) error); ok {
+		r1 = rf(ctx, groupResourceLimit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateResourceLimit provides a mock function with given fields: ctx, resourceLimit
 func (_m *MockResourceLimits) UpdateResourceLimit(ctx context.Context, resourceLimit *models.ResourceLimit) (*models.ResourceLimit, error) {
 	ret := _m.Called(ctx, resourceLimit)
@@ -106,6 +106,30 @@ func (_m *MockRunners) GetRunnerByPath(ctx context.Context, path string) (*model
 	return r0, r1
 }
 
+// GetRunnerCount provides a mock function with given fields: ctx, filter
+func (_m *MockRunners) GetRunnerCount(ctx context.Context, filter *RunnerFilter) (int32, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *RunnerFilter) (int32, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *RunnerFilter) int32); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *RunnerFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRunners provides a mock function with given fields: ctx, input
 func (_m *MockRunners) GetRunners(ctx context.Context, input *GetRunnersInput) (*RunnersResult, error) {
 	ret := _m.Called(ctx, input)
@@ -120,6 +120,30 @@ func (_m *MockServiceAccounts) GetServiceAccountByPath(ctx context.Context, path
 	return r0, r1
 }
 
+// GetServiceAccountCount provides a mock function with given fields: ctx, filter
+func (_m *MockServiceAccounts) GetServiceAccountCount(ctx context.Context, filter *ServiceAccountFilter) (int32, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *ServiceAccountFilter) (int32, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *ServiceAccountFilter) int32); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *ServiceAccountFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetServiceAccounts provides a mock function with given fields: ctx, input
 func (_m *MockServiceAccounts) GetServiceAccounts(ctx context.Context, input *GetServiceAccountsInput) (*ServiceAccountsResult, error) {
 	ret := _m.Called(ctx, input)
@@ -146,6 +170,32 @@ func (_m *MockServiceAccounts) GetServiceAccounts(ctx context.Context, input *Ge
 	return r0, r1
 }
 
+// GetServiceAccountsByIDs provides a mock function with given fields: ctx, ids
+func (_m *MockServiceAccounts) GetServiceAccountsByIDs(ctx context.Context, ids []string) (map[string]*models.ServiceAccount, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 map[string]*models.ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]*models.ServiceAccount, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]*models.ServiceAccount); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*models.ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnassignServiceAccountFromRunner provides a mock function with given fields: ctx, serviceAccountID, runnerID
 func (_m *MockServiceAccounts) UnassignServiceAccountFromRunner(ctx context.Context, serviceAccountID string, runnerID string) error {
 	ret := _m.Called(ctx, serviceAccountID, runnerID)
@@ -106,6 +106,30 @@ func (_m *MockTerraformModules) GetModuleByPath(ctx context.Context, path string
 	return r0, r1
 }
 
+// GetModuleCount provides a mock function with given fields: ctx, filter
+func (_m *MockTerraformModules) GetModuleCount(ctx context.Context, filter *TerraformModuleFilter) (int32, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *TerraformModuleFilter) (int32, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *TerraformModuleFilter) int32); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *TerraformModuleFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetModules provides a mock function with given fields: ctx, input
 func (_m *MockTerraformModules) GetModules(ctx context.Context, input *GetModulesInput) (*ModulesResult, error) {
 	ret := _m.Called(ctx, input)
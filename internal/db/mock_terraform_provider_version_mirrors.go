@@ -80,6 +80,30 @@ func (_m *MockTerraformProviderVersionMirrors) GetVersionMirrorByID(ctx context.
 	return r0, r1
 }
 
+// GetVersionMirrorCount provides a mock function with given fields: ctx, filter
+func (_m *MockTerraformProviderVersionMirrors) GetVersionMirrorCount(ctx context.Context, filter *TerraformProviderVersionMirrorFilter) (int32, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *TerraformProviderVersionMirrorFilter) (int32, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *TerraformProviderVersionMirrorFilter) int32); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *TerraformProviderVersionMirrorFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVersionMirrors provides a mock function with given fields: ctx, input
 func (_m *MockTerraformProviderVersionMirrors) GetVersionMirrors(ctx context.Context, input *GetProviderVersionMirrorsInput) (*ProviderVersionMirrorsResult, error) {
 	ret := _m.Called(ctx, input)
@@ -106,6 +106,30 @@ func (_m *MockTerraformProviders) GetProviderByPath(ctx context.Context, path st
 	return r0, r1
 }
 
+// GetProviderCount provides a mock function with given fields: ctx, filter
+func (_m *MockTerraformProviders) GetProviderCount(ctx context.Context, filter *TerraformProviderFilter) (int32, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *TerraformProviderFilter) (int32, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *TerraformProviderFilter) int32); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *TerraformProviderFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProviders provides a mock function with given fields: ctx, input
 func (_m *MockTerraformProviders) GetProviders(ctx context.Context, input *GetProvidersInput) (*ProvidersResult, error) {
 	ret := _m.Called(ctx, input)
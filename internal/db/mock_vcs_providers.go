@@ -54,6 +54,32 @@ func (_m *MockVCSProviders) DeleteProvider(ctx context.Context, provider *models
 	return r0
 }
 
+// GetCreatedBySubjects provides a mock function with given fields: ctx, namespacePath
+func (_m *MockVCSProviders) GetCreatedBySubjects(ctx context.Context, namespacePath string) ([]string, error) {
+	ret := _m.Called(ctx, namespacePath)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, namespacePath)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, namespacePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespacePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProviderByID provides a mock function with given fields: ctx, id
 func (_m *MockVCSProviders) GetProviderByID(ctx context.Context, id string) (*models.VCSProvider, error) {
 	ret := _m.Called(ctx, id)
@@ -106,6 +132,30 @@ func (_m *MockVCSProviders) GetProviderByOAuthState(ctx context.Context, state s
 	return r0, r1
 }
 
+// GetProviderCount provides a mock function with given fields: ctx, filter
+func (_m *MockVCSProviders) GetProviderCount(ctx context.Context, filter *VCSProviderFilter) (int32, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *VCSProviderFilter) (int32, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *VCSProviderFilter) int32); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *VCSProviderFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProviders provides a mock function with given fields: ctx, input
 func (_m *MockVCSProviders) GetProviders(ctx context.Context, input *GetVCSProvidersInput) (*VCSProvidersResult, error) {
 	ret := _m.Called(ctx, input)
@@ -132,6 +182,30 @@ func (_m *MockVCSProviders) GetProviders(ctx context.Context, input *GetVCSProvi
 	return r0, r1
 }
 
+// ReassignCreatedBy provides a mock function with given fields: ctx, fromSubject, toSubject, namespacePath
+func (_m *MockVCSProviders) ReassignCreatedBy(ctx context.Context, fromSubject string, toSubject string, namespacePath *string) (int, error) {
+	ret := _m.Called(ctx, fromSubject, toSubject, namespacePath)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *string) (int, error)); ok {
+		return rf(ctx, fromSubject, toSubject, namespacePath)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *string) int); ok {
+		r0 = rf(ctx, fromSubject, toSubject, namespacePath)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *string) error); ok {
+		r1 = rf(ctx, fromSubject, toSubject, namespacePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateProvider provides a mock function with given fields: ctx, provider
 func (_m *MockVCSProviders) UpdateProvider(ctx context.Context, provider *models.VCSProvider) (*models.VCSProvider, error) {
 	ret := _m.Called(ctx, provider)
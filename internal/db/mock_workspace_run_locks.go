@@ -0,0 +1,96 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package db
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	models "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+)
+
+// MockWorkspaceRunLocks is an autogenerated mock type for the WorkspaceRunLocks type
+type MockWorkspaceRunLocks struct {
+	mock.Mock
+}
+
+// CreateWorkspaceRunLock provides a mock function with given fields: ctx, lock
+func (_m *MockWorkspaceRunLocks) CreateWorkspaceRunLock(ctx context.Context, lock *models.WorkspaceRunLock) (*models.WorkspaceRunLock, error) {
+	ret := _m.Called(ctx, lock)
+
+	var r0 *models.WorkspaceRunLock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WorkspaceRunLock) (*models.WorkspaceRunLock, error)); ok {
+		return rf(ctx, lock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WorkspaceRunLock) *models.WorkspaceRunLock); ok {
+		r0 = rf(ctx, lock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WorkspaceRunLock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.WorkspaceRunLock) error); ok {
+		r1 = rf(ctx, lock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWorkspaceRunLock provides a mock function with given fields: ctx, lock
+func (_m *MockWorkspaceRunLocks) DeleteWorkspaceRunLock(ctx context.Context, lock *models.WorkspaceRunLock) error {
+	ret := _m.Called(ctx, lock)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WorkspaceRunLock) error); ok {
+		r0 = rf(ctx, lock)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetWorkspaceRunLock provides a mock function with given fields: ctx, workspaceID
+func (_m *MockWorkspaceRunLocks) GetWorkspaceRunLock(ctx context.Context, workspaceID string) (*models.WorkspaceRunLock, error) {
+	ret := _m.Called(ctx, workspaceID)
+
+	var r0 *models.WorkspaceRunLock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.WorkspaceRunLock, error)); ok {
+		return rf(ctx, workspaceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.WorkspaceRunLock); ok {
+		r0 = rf(ctx, workspaceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WorkspaceRunLock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, workspaceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockWorkspaceRunLocks interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockWorkspaceRunLocks creates a new instance of MockWorkspaceRunLocks. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockWorkspaceRunLocks(t mockConstructorTestingTNewMockWorkspaceRunLocks) *MockWorkspaceRunLocks {
+	mock := &MockWorkspaceRunLocks{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
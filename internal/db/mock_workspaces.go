@@ -54,6 +54,32 @@ func (_m *MockWorkspaces) DeleteWorkspace(ctx context.Context, workspace *models
 	return r0
 }
 
+// GetCreatedBySubjects provides a mock function with given fields: ctx, namespacePath
+func (_m *MockWorkspaces) GetCreatedBySubjects(ctx context.Context, namespacePath string) ([]string, error) {
+	ret := _m.Called(ctx, namespacePath)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, namespacePath)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, namespacePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespacePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWorkspaceByFullPath provides a mock function with given fields: ctx, path
 func (_m *MockWorkspaces) GetWorkspaceByFullPath(ctx context.Context, path string) (*models.Workspace, error) {
 	ret := _m.Called(ctx, path)
@@ -106,6 +132,30 @@ func (_m *MockWorkspaces) GetWorkspaceByID(ctx context.Context, id string) (*mod
 	return r0, r1
 }
 
+// GetWorkspaceCount provides a mock function with given fields: ctx, filter
+func (_m *MockWorkspaces) GetWorkspaceCount(ctx context.Context, filter *WorkspaceFilter) (int32, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *WorkspaceFilter) (int32, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *WorkspaceFilter) int32); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *WorkspaceFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWorkspaces provides a mock function with given fields: ctx, input
 func (_m *MockWorkspaces) GetWorkspaces(ctx context.Context, input *GetWorkspacesInput) (*WorkspacesResult, error) {
 	ret := _m.Called(ctx, input)
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jackc/pgx/v4"
 
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
@@ -153,6 +154,81 @@ func migrateNamespaces(ctx context.Context, conn connection, oldPath, newPath st
 	return nil
 }
 
+// reassignCreatedBy updates the created by field from one subject to another for all rows in the table,
+// optionally restricted to the groups in or under a namespace path, and returns the number of rows updated.
+func reassignCreatedBy(ctx context.Context, conn connection, table, groupIDColumn, fromSubject, toSubject string, namespacePath *string) (int, error) {
+	ex := goqu.And(goqu.I(table + ".created_by").Eq(fromSubject))
+
+	if namespacePath != nil {
+		ex = ex.Append(inNamespaceExpression(table, groupIDColumn, *namespacePath))
+	}
+
+	sql, args, err := dialect.Update(table).
+		Prepared(true).
+		Set(
+			goqu.Record{
+				"version":    goqu.L("? + ?", goqu.C("version"), 1),
+				"updated_at": currentTime(),
+				"created_by": toSubject,
+			},
+		).Where(ex).ToSQL()
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// getCreatedBySubjects returns the distinct created by subjects for the rows in the table
+// that belong to the groups in or under a namespace path.
+func getCreatedBySubjects(ctx context.Context, conn connection, table, groupIDColumn, namespacePath string) ([]string, error) {
+	sql, args, err := dialect.From(table).
+		Prepared(true).
+		SelectDistinct(goqu.I(table + ".created_by")).
+		Where(inNamespaceExpression(table, groupIDColumn, namespacePath)).
+		Order(goqu.I(table + ".created_by").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	subjects := []string{}
+	for rows.Next() {
+		var subject string
+		if err := rows.Scan(&subject); err != nil {
+			return nil, err
+		}
+
+		subjects = append(subjects, subject)
+	}
+
+	return subjects, nil
+}
+
+// inNamespaceExpression restricts the rows in the table to the groups in or under a namespace path.
+func inNamespaceExpression(table, groupIDColumn, namespacePath string) exp.Expression {
+	return goqu.I(table + "." + groupIDColumn).In(
+		dialect.From("namespaces").
+			Select("group_id").
+			Where(goqu.Or(
+				goqu.I("path").Eq(namespacePath),
+				goqu.I("path").Like(escapeLikePattern(namespacePath)+"/%"),
+			)),
+	)
+}
+
 func scanNamespace(row scanner) (*namespaceRow, error) {
 	var groupID sql.NullString
 	var workspaceID sql.NullString
@@ -30,6 +30,7 @@ type CreateNamespaceMembershipInput struct {
 type NamespaceMemberships interface {
 	GetNamespaceMemberships(ctx context.Context, input *GetNamespaceMembershipsInput) (*NamespaceMembershipResult, error)
 	GetNamespaceMembershipByID(ctx context.Context, id string) (*models.NamespaceMembership, error)
+	GetNamespaceMembership(ctx context.Context, namespacePath string, userID, serviceAccountID, teamID *string) (*models.NamespaceMembership, error)
 	CreateNamespaceMembership(ctx context.Context, input *CreateNamespaceMembershipInput) (*models.NamespaceMembership, error)
 	UpdateNamespaceMembership(ctx context.Context, namespaceMembership *models.NamespaceMembership) (*models.NamespaceMembership, error)
 	DeleteNamespaceMembership(ctx context.Context, namespaceMembership *models.NamespaceMembership) error
@@ -131,6 +132,65 @@ func (m *namespaceMemberships) GetNamespaceMembershipByID(ctx context.Context, i
 	return namespaceMembership, nil
 }
 
+// GetNamespaceMembership returns the membership that a principal has directly in a namespace; exactly one of
+// userID, serviceAccountID or teamID must be specified.
+func (m *namespaceMemberships) GetNamespaceMembership(ctx context.Context,
+	namespacePath string, userID, serviceAccountID, teamID *string,
+) (*models.NamespaceMembership, error) {
+	ctx, span := tracer.Start(ctx, "db.GetNamespaceMembership")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	ex := goqu.Ex{"namespaces.path": namespacePath}
+
+	principalCount := 0
+	if userID != nil {
+		ex["namespace_memberships.user_id"] = *userID
+		principalCount++
+	}
+	if serviceAccountID != nil {
+		ex["namespace_memberships.service_account_id"] = *serviceAccountID
+		principalCount++
+	}
+	if teamID != nil {
+		ex["namespace_memberships.team_id"] = *teamID
+		principalCount++
+	}
+
+	if principalCount != 1 {
+		tracing.RecordError(span, nil, "exactly one principal must be specified")
+		return nil, errors.New("exactly one of user ID, service account ID or team ID must be specified", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	sql, args, err := dialect.From("namespace_memberships").
+		Prepared(true).
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"namespace_memberships.namespace_id": goqu.I("namespaces.id")})).
+		Select(m.getSelectFields()...).
+		Where(ex).ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return nil, err
+	}
+
+	namespaceMembership, err := scanNamespaceMembership(m.dbClient.getConnection(ctx).QueryRow(ctx, sql, args...), true)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+
+		if pgErr := asPgError(err); pgErr != nil {
+			if isInvalidIDViolation(pgErr) {
+				return nil, errors.Wrap(pgErr, "invalid ID; %s", pgErr.Message, errors.WithSpan(span), errors.WithErrorCode(errors.EInvalid))
+			}
+		}
+
+		tracing.RecordError(span, err, "failed to execute query")
+		return nil, err
+	}
+
+	return namespaceMembership, nil
+}
+
 func (m *namespaceMemberships) CreateNamespaceMembership(ctx context.Context,
 	input *CreateNamespaceMembershipInput,
 ) (*models.NamespaceMembership, error) {
@@ -309,6 +369,11 @@ func (m *namespaceMemberships) GetNamespaceMemberships(ctx context.Context,
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
+	}
+
 	ex := goqu.And()
 
 	if input.Filter != nil {
@@ -496,6 +561,8 @@ func scanNamespaceMembership(row scanner, withNamespacePath bool) (*models.Names
 type namespaceMembershipExpressionBuilder struct {
 	userID           *string
 	serviceAccountID *string
+	// directOnly excludes namespaces that are only accessible through a membership in an ancestor namespace
+	directOnly bool
 }
 
 func (n namespaceMembershipExpressionBuilder) build() exp.Expression {
@@ -514,13 +581,19 @@ func (n namespaceMembershipExpressionBuilder) build() exp.Expression {
 		whereEx = goqu.I("namespace_memberships.service_account_id").Eq(*n.serviceAccountID)
 	}
 
+	directMatch := goqu.I("namespaces.path").In(
+		dialect.From("namespace_memberships").
+			Select(goqu.L("path")).
+			InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"namespace_memberships.namespace_id": goqu.I("namespaces.id")})).
+			Where(whereEx),
+	)
+
+	if n.directOnly {
+		return directMatch
+	}
+
 	return goqu.Or(
-		goqu.I("namespaces.path").In(
-			dialect.From("namespace_memberships").
-				Select(goqu.L("path")).
-				InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"namespace_memberships.namespace_id": goqu.I("namespaces.id")})).
-				Where(whereEx),
-		),
+		directMatch,
 		goqu.I("namespaces.path").Like(goqu.Any(
 			dialect.From("namespace_memberships").
 				Select(goqu.L("path || '/%'")).
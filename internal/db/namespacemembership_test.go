@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/pagination"
 )
 
@@ -1289,6 +1290,103 @@ func TestGetNamespaceMembershipByID(t *testing.T) {
 	}
 }
 
+func TestGetNamespaceMembership(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	createdWarmupOutput, err := createWarmupNamespaceMemberships(ctx, testClient, namespaceMembershipWarmupsInput{
+		teams:                standardWarmupTeamsForNamespaceMemberships,
+		users:                standardWarmupUsersForNamespaceMemberships,
+		teamMembers:          standardWarmupTeamMembersForNamespaceMemberships,
+		groups:               standardWarmupGroupsForNamespaceMemberships,
+		serviceAccounts:      standardWarmupServiceAccountsForNamespaceMemberships,
+		workspaces:           standardWarmupWorkspacesForNamespaceMemberships,
+		namespaceMemberships: standardWarmupNamespaceMemberships,
+		roles:                standardWarmupRolesForNamespaceMemberships,
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		userID           *string
+		serviceAccountID *string
+		teamID           *string
+		expectErrorCode  errors.CodeType
+		name             string
+		namespacePath    string
+		expectID         string
+	}
+
+	testCases := []testCase{}
+	for _, positiveNamespaceMembership := range createdWarmupOutput.namespaceMemberships {
+		testCases = append(testCases, testCase{
+			name:             "positive, " + positiveNamespaceMembership.Metadata.ID,
+			namespacePath:    positiveNamespaceMembership.Namespace.Path,
+			userID:           positiveNamespaceMembership.UserID,
+			serviceAccountID: positiveNamespaceMembership.ServiceAccountID,
+			teamID:           positiveNamespaceMembership.TeamID,
+			expectID:         positiveNamespaceMembership.Metadata.ID,
+		})
+	}
+
+	someMembership := createdWarmupOutput.namespaceMemberships[0]
+	nonExistent := nonExistentID
+	invalid := invalidID
+
+	testCases = append(testCases,
+		testCase{
+			name:          "negative, principal does not have a membership in the namespace",
+			namespacePath: someMembership.Namespace.Path,
+			userID:        &nonExistent,
+		},
+		testCase{
+			name:          "negative, namespace does not exist",
+			namespacePath: "this-namespace-does-not-exist",
+			userID:        someMembership.UserID,
+		},
+		testCase{
+			name:            "defective, no principal specified",
+			namespacePath:   someMembership.Namespace.Path,
+			expectErrorCode: errors.EInvalid,
+		},
+		testCase{
+			name:             "defective, more than one principal specified",
+			namespacePath:    someMembership.Namespace.Path,
+			userID:           &nonExistent,
+			serviceAccountID: &nonExistent,
+			expectErrorCode:  errors.EInvalid,
+		},
+		testCase{
+			name:            "defective, invalid ID",
+			namespacePath:   someMembership.Namespace.Path,
+			teamID:          &invalid,
+			expectErrorCode: errors.EInvalid,
+		},
+	)
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			namespaceMembership, err := testClient.client.NamespaceMemberships.GetNamespaceMembership(ctx,
+				test.namespacePath, test.userID, test.serviceAccountID, test.teamID)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			require.Nil(t, err)
+
+			if test.expectID != "" {
+				require.NotNil(t, namespaceMembership)
+				assert.Equal(t, test.expectID, namespaceMembership.Metadata.ID)
+				assert.Equal(t, test.namespacePath, namespaceMembership.Namespace.Path)
+			} else {
+				assert.Nil(t, namespaceMembership)
+			}
+		})
+	}
+}
+
 func TestCreateNamespaceMembership(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -132,6 +132,11 @@ func (p *plans) GetPlans(ctx context.Context, input *GetPlansInput) (*PlansResul
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
+	}
+
 	ex := goqu.Ex{}
 
 	if input.Filter != nil {
@@ -4,11 +4,13 @@ package db
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/jackc/pgx/v4"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
 )
 
 // ResourceLimits encapsulates the logic to access resource-limits from the database
@@ -17,6 +19,9 @@ type ResourceLimits interface {
 	GetResourceLimit(ctx context.Context, name string) (*models.ResourceLimit, error)
 	GetResourceLimits(ctx context.Context) ([]models.ResourceLimit, error)
 	UpdateResourceLimit(ctx context.Context, resourceLimit *models.ResourceLimit) (*models.ResourceLimit, error)
+	GetGroupResourceLimit(ctx context.Context, groupID string, name string) (*models.GroupResourceLimit, error)
+	GetNearestGroupResourceLimit(ctx context.Context, groupPath string, name string) (*models.GroupResourceLimit, error)
+	SetGroupResourceLimit(ctx context.Context, groupResourceLimit *models.GroupResourceLimit) (*models.GroupResourceLimit, error)
 }
 
 type resourceLimits struct {
@@ -25,6 +30,8 @@ type resourceLimits struct {
 
 var resourceLimitFieldList = append(metadataFieldList, "name", "value")
 
+var groupResourceLimitFieldList = append(metadataFieldList, "group_id", "name", "value")
+
 // NewResourceLimits returns an instance of the ResourceLimits interface
 func NewResourceLimits(dbClient *Client) ResourceLimits {
 	return &resourceLimits{dbClient: dbClient}
@@ -134,6 +141,131 @@ func (t *resourceLimits) UpdateResourceLimit(ctx context.Context, resourceLimit
 	return updatedResourceLimit, nil
 }
 
+func (t *resourceLimits) GetGroupResourceLimit(ctx context.Context, groupID string, name string) (*models.GroupResourceLimit, error) {
+	ctx, span := tracer.Start(ctx, "db.GetGroupResourceLimit")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	query := dialect.From(goqu.T("group_resource_limits")).
+		Prepared(true).
+		Select(t.getGroupResourceLimitSelectFields()...).
+		Where(goqu.Ex{
+			"group_resource_limits.group_id": groupID,
+			"group_resource_limits.name":     name,
+		})
+
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return nil, err
+	}
+
+	groupResourceLimit, err := scanGroupResourceLimit(t.dbClient.getConnection(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		tracing.RecordError(span, err, "failed to execute query")
+		return nil, err
+	}
+
+	return groupResourceLimit, nil
+}
+
+// GetNearestGroupResourceLimit returns the override set on the group or its closest ancestor, or nil if there is none.
+func (t *resourceLimits) GetNearestGroupResourceLimit(ctx context.Context, groupPath string, name string) (*models.GroupResourceLimit, error) {
+	ctx, span := tracer.Start(ctx, "db.GetNearestGroupResourceLimit")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	query := dialect.From(goqu.T("group_resource_limits")).
+		Prepared(true).
+		Select(t.getGroupResourceLimitSelectFields()...).
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"group_resource_limits.group_id": goqu.I("namespaces.group_id")})).
+		Where(goqu.Ex{
+			"group_resource_limits.name": name,
+			"namespaces.path":            models.ExpandGroupPath(groupPath),
+		}).
+		Order(goqu.L("LENGTH(namespaces.path)").Desc()).
+		Limit(1)
+
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return nil, err
+	}
+
+	groupResourceLimit, err := scanGroupResourceLimit(t.dbClient.getConnection(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		tracing.RecordError(span, err, "failed to execute query")
+		return nil, err
+	}
+
+	return groupResourceLimit, nil
+}
+
+// SetGroupResourceLimit creates the override for the group or updates its value if one already exists.
+func (t *resourceLimits) SetGroupResourceLimit(ctx context.Context, groupResourceLimit *models.GroupResourceLimit) (*models.GroupResourceLimit, error) {
+	ctx, span := tracer.Start(ctx, "db.SetGroupResourceLimit")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	timestamp := currentTime()
+
+	sql, args, err := dialect.Insert("group_resource_limits").
+		Prepared(true).
+		Rows(goqu.Record{
+			"id":         newResourceID(),
+			"version":    initialResourceVersion,
+			"created_at": timestamp,
+			"updated_at": timestamp,
+			"group_id":   groupResourceLimit.GroupID,
+			"name":       groupResourceLimit.Name,
+			"value":      groupResourceLimit.Value,
+		}).
+		OnConflict(goqu.DoUpdate("group_id, name", goqu.Record{
+			"version":    goqu.L("group_resource_limits.version + 1"),
+			"updated_at": timestamp,
+			"value":      groupResourceLimit.Value,
+		})).
+		Returning(groupResourceLimitFieldList...).ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return nil, err
+	}
+
+	setGroupResourceLimit, err := scanGroupResourceLimit(t.dbClient.getConnection(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if pgErr := asPgError(err); pgErr != nil {
+			if isForeignKeyViolation(pgErr) {
+				switch pgErr.ConstraintName {
+				case "fk_group_id":
+					tracing.RecordError(span, nil, "group does not exist")
+					return nil, errors.New("group does not exist", errors.WithErrorCode(errors.ENotFound))
+				case "fk_name":
+					tracing.RecordError(span, nil, "resource limit does not exist")
+					return nil, errors.New("resource limit %s does not exist", groupResourceLimit.Name, errors.WithErrorCode(errors.EInvalid))
+				}
+			}
+		}
+		tracing.RecordError(span, err, "failed to execute query")
+		return nil, err
+	}
+
+	return setGroupResourceLimit, nil
+}
+
+func (t *resourceLimits) getGroupResourceLimitSelectFields() []interface{} {
+	selectFields := []interface{}{}
+	for _, field := range groupResourceLimitFieldList {
+		selectFields = append(selectFields, fmt.Sprintf("group_resource_limits.%s", field))
+	}
+	return selectFields
+}
+
 func scanResourceLimit(row scanner) (*models.ResourceLimit, error) {
 	resourceLimit := &models.ResourceLimit{}
 
@@ -153,3 +285,24 @@ func scanResourceLimit(row scanner) (*models.ResourceLimit, error) {
 
 	return resourceLimit, nil
 }
+
+func scanGroupResourceLimit(row scanner) (*models.GroupResourceLimit, error) {
+	groupResourceLimit := &models.GroupResourceLimit{}
+
+	fields := []interface{}{
+		&groupResourceLimit.Metadata.ID,
+		&groupResourceLimit.Metadata.CreationTimestamp,
+		&groupResourceLimit.Metadata.LastUpdatedTimestamp,
+		&groupResourceLimit.Metadata.Version,
+		&groupResourceLimit.GroupID,
+		&groupResourceLimit.Name,
+		&groupResourceLimit.Value,
+	}
+
+	err := row.Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupResourceLimit, nil
+}
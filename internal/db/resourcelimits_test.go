@@ -112,6 +112,76 @@ func TestUpdateResourceLimits(t *testing.T) {
 // Common utility structures and functions:
 
 // Standard warmup resource limits for tests in this module:
+func TestGroupResourceLimits(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	_, groupPath2ID, err := createInitialGroups(ctx, testClient, []models.Group{
+		{FullPath: "top-level-group-for-limits", CreatedBy: "someone"},
+		{FullPath: "top-level-group-for-limits/child", CreatedBy: "someone"},
+		{FullPath: "top-level-group-for-limits/child/grandchild", CreatedBy: "someone"},
+	})
+	require.Nil(t, err)
+
+	limitName := "ResourceLimitManagedIdentitiesPerGroup"
+
+	// Nothing is overridden yet.
+	nearest, err := testClient.client.ResourceLimits.GetNearestGroupResourceLimit(ctx, "top-level-group-for-limits/child/grandchild", limitName)
+	require.Nil(t, err)
+	assert.Nil(t, nearest)
+
+	rootLimit, err := testClient.client.ResourceLimits.SetGroupResourceLimit(ctx, &models.GroupResourceLimit{
+		GroupID: groupPath2ID["top-level-group-for-limits"],
+		Name:    limitName,
+		Value:   50,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, 50, rootLimit.Value)
+
+	// The grandchild inherits the root group's override.
+	nearest, err = testClient.client.ResourceLimits.GetNearestGroupResourceLimit(ctx, "top-level-group-for-limits/child/grandchild", limitName)
+	require.Nil(t, err)
+	require.NotNil(t, nearest)
+	assert.Equal(t, rootLimit.Metadata.ID, nearest.Metadata.ID)
+
+	childLimit, err := testClient.client.ResourceLimits.SetGroupResourceLimit(ctx, &models.GroupResourceLimit{
+		GroupID: groupPath2ID["top-level-group-for-limits/child"],
+		Name:    limitName,
+		Value:   75,
+	})
+	require.Nil(t, err)
+
+	// The closest ancestor's override wins.
+	nearest, err = testClient.client.ResourceLimits.GetNearestGroupResourceLimit(ctx, "top-level-group-for-limits/child/grandchild", limitName)
+	require.Nil(t, err)
+	require.NotNil(t, nearest)
+	assert.Equal(t, childLimit.Metadata.ID, nearest.Metadata.ID)
+	assert.Equal(t, 75, nearest.Value)
+
+	// Setting the override again updates it in place.
+	updatedLimit, err := testClient.client.ResourceLimits.SetGroupResourceLimit(ctx, &models.GroupResourceLimit{
+		GroupID: groupPath2ID["top-level-group-for-limits/child"],
+		Name:    limitName,
+		Value:   80,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, childLimit.Metadata.ID, updatedLimit.Metadata.ID)
+	assert.Equal(t, childLimit.Metadata.Version+1, updatedLimit.Metadata.Version)
+	assert.Equal(t, 80, updatedLimit.Value)
+
+	found, err := testClient.client.ResourceLimits.GetGroupResourceLimit(ctx, groupPath2ID["top-level-group-for-limits/child"], limitName)
+	require.Nil(t, err)
+	assert.Equal(t, updatedLimit, found)
+
+	_, err = testClient.client.ResourceLimits.SetGroupResourceLimit(ctx, &models.GroupResourceLimit{
+		GroupID: groupPath2ID["top-level-group-for-limits"],
+		Name:    "not-a-valid-resource-limit-name",
+		Value:   1,
+	})
+	assert.NotNil(t, err)
+}
+
 var standardWarmupResourceLimits = []models.ResourceLimit{
 	{
 		Name:  "resource-limit-a",
@@ -67,6 +67,12 @@ type RunFilter struct {
 	GroupID        *string
 	UserMemberID   *string
 	RunIDs         []string
+	// ManagedIdentityID filters the runs to those that were issued credentials for the managed identity
+	ManagedIdentityID *string
+	// Statuses filters the runs to those with one of the specified statuses
+	Statuses []models.RunStatus
+	// AccessRuleEvaluationFailed filters the runs by whether any of their managed identity access rule evaluations failed
+	AccessRuleEvaluationFailed *bool
 }
 
 // GetRunsInput is the input for listing runs
@@ -111,6 +117,7 @@ var runFieldList = append(
 	"targets",
 	"refresh",
 	"refresh_only",
+	"access_rule_evaluations",
 )
 
 // NewRuns returns an instance of the Run interface
@@ -212,6 +219,11 @@ func (r *runs) GetRuns(ctx context.Context, input *GetRunsInput) (*RunsResult, e
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
+	}
+
 	selectEx := dialect.From("runs").
 		Select(r.getSelectFields()...).
 		InnerJoin(goqu.T("workspaces"), goqu.On(goqu.Ex{"runs.workspace_id": goqu.I("workspaces.id")}))
@@ -239,15 +251,36 @@ func (r *runs) GetRuns(ctx context.Context, input *GetRunsInput) (*RunsResult, e
 			ex = ex.Append(goqu.I("workspaces.group_id").Eq(*input.Filter.GroupID))
 		}
 
+		if len(input.Filter.Statuses) > 0 {
+			ex = ex.Append(goqu.I("runs.status").In(input.Filter.Statuses))
+		}
+
 		if input.Filter.UserMemberID != nil {
 			selectEx = selectEx.InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"workspaces.id": goqu.I("namespaces.workspace_id")}))
 			ex = ex.Append(namespaceMembershipFilterQuery("namespace_memberships.user_id", *input.Filter.UserMemberID))
 		}
 
+		if input.Filter.ManagedIdentityID != nil {
+			ex = ex.Append(goqu.I("runs.id").In(
+				dialect.From("managed_identity_credential_issuances").
+					Select("run_id").
+					Where(goqu.Ex{"managed_identity_id": *input.Filter.ManagedIdentityID}),
+			))
+		}
+
 		if input.Filter.TimeRangeStart != nil {
 			// Must use UTC here otherwise, queries will return unexpected results.
 			ex = ex.Append(goqu.I("runs.created_at").Gte(input.Filter.TimeRangeStart.UTC()))
 		}
+
+		if input.Filter.AccessRuleEvaluationFailed != nil {
+			failedEvaluation := goqu.L("runs.access_rule_evaluations @> ?::jsonb", `[{"passed": false}]`)
+			if *input.Filter.AccessRuleEvaluationFailed {
+				ex = ex.Append(failedEvaluation)
+			} else {
+				ex = ex.Append(goqu.L("NOT ?", failedEvaluation))
+			}
+		}
 	}
 
 	query := selectEx.Where(ex)
@@ -318,6 +351,12 @@ func (r *runs) CreateRun(ctx context.Context, run *models.Run) (*models.Run, err
 		return nil, err
 	}
 
+	accessRuleEvaluations, err := json.Marshal(runAccessRuleEvaluations(run.AccessRuleEvaluations))
+	if err != nil {
+		tracing.RecordError(span, err, "failed to marshal access rule evaluations")
+		return nil, err
+	}
+
 	sql, args, err := dialect.Insert("runs").
 		Prepared(true).
 		Rows(goqu.Record{
@@ -340,11 +379,12 @@ func (r *runs) CreateRun(ctx context.Context, run *models.Run) (*models.Run, err
 			"force_cancel_available_at": run.ForceCancelAvailableAt,
 			"force_canceled":            run.ForceCanceled,
 			"comment":                   run.Comment,
-			"auto_apply":                false,
+			"auto_apply":                run.AutoApply,
 			"terraform_version":         run.TerraformVersion,
 			"targets":                   targets,
 			"refresh":                   run.Refresh,
 			"refresh_only":              run.RefreshOnly,
+			"access_rule_evaluations":   accessRuleEvaluations,
 		}).
 		Returning(runFieldList...).ToSQL()
 
@@ -369,6 +409,12 @@ func (r *runs) UpdateRun(ctx context.Context, run *models.Run) (*models.Run, err
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	accessRuleEvaluations, err := json.Marshal(runAccessRuleEvaluations(run.AccessRuleEvaluations))
+	if err != nil {
+		tracing.RecordError(span, err, "failed to marshal access rule evaluations")
+		return nil, err
+	}
+
 	timestamp := currentTime()
 
 	sql, args, err := dialect.Update("runs").
@@ -387,6 +433,7 @@ func (r *runs) UpdateRun(ctx context.Context, run *models.Run) (*models.Run, err
 				"force_canceled_by":         run.ForceCanceledBy,
 				"force_cancel_available_at": run.ForceCancelAvailableAt,
 				"force_canceled":            run.ForceCanceled,
+				"access_rule_evaluations":   accessRuleEvaluations,
 			},
 		).Where(goqu.Ex{"id": run.Metadata.ID, "version": run.Metadata.Version}).Returning(r.getSelectFields()...).ToSQL()
 
@@ -426,6 +473,7 @@ func scanRun(row scanner) (*models.Run, error) {
 
 	run := &models.Run{}
 	run.TargetAddresses = []string{}
+	run.AccessRuleEvaluations = []models.ManagedIdentityAccessRuleEvaluation{}
 
 	err := row.Scan(
 		&run.Metadata.ID,
@@ -452,6 +500,7 @@ func scanRun(row scanner) (*models.Run, error) {
 		&run.TargetAddresses,
 		&run.Refresh,
 		&run.RefreshOnly,
+		&run.AccessRuleEvaluations,
 	)
 	if err != nil {
 		return nil, err
@@ -479,3 +528,11 @@ func scanRun(row scanner) (*models.Run, error) {
 
 	return run, nil
 }
+
+// runAccessRuleEvaluations returns an empty list instead of nil so the evaluations are never stored as JSON null
+func runAccessRuleEvaluations(evaluations []models.ManagedIdentityAccessRuleEvaluation) []models.ManagedIdentityAccessRuleEvaluation {
+	if evaluations == nil {
+		return []models.ManagedIdentityAccessRuleEvaluation{}
+	}
+	return evaluations
+}
@@ -1088,6 +1088,90 @@ var standardWarmupAppliesForRuns = []models.Apply{
 
 // createWarmupRuns creates some warmup runs for a test
 // The warmup runs to create can be standard or otherwise.
+func TestGetRunsWithAccessRuleEvaluationFailed(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	_, warmupWorkspaces, _, _, _, err := createWarmupRuns(ctx, testClient,
+		standardWarmupGroupsForRuns, standardWarmupWorkspacesForRuns, nil,
+		standardWarmupPlansForRuns, standardWarmupAppliesForRuns, false)
+	require.Nil(t, err)
+	workspaceID := warmupWorkspaces[0].Metadata.ID
+
+	passedEvaluation := models.ManagedIdentityAccessRuleEvaluation{
+		ManagedIdentityID: "managed-identity-1",
+		RuleID:            "rule-1",
+		RuleType:          models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:          models.JobPlanType,
+		Passed:            true,
+	}
+
+	failedEvaluation := models.ManagedIdentityAccessRuleEvaluation{
+		ManagedIdentityID: "managed-identity-1",
+		RuleID:            "rule-2",
+		RuleType:          models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:          models.JobApplyType,
+		Diagnostic:        "user is not an eligible principal",
+	}
+
+	runWithoutEvaluations, err := testClient.client.Runs.CreateRun(ctx, &models.Run{WorkspaceID: workspaceID})
+	require.Nil(t, err)
+
+	runWithPassedEvaluations, err := testClient.client.Runs.CreateRun(ctx, &models.Run{
+		WorkspaceID:           workspaceID,
+		AccessRuleEvaluations: []models.ManagedIdentityAccessRuleEvaluation{passedEvaluation},
+	})
+	require.Nil(t, err)
+
+	runWithFailedEvaluation, err := testClient.client.Runs.CreateRun(ctx, &models.Run{WorkspaceID: workspaceID})
+	require.Nil(t, err)
+
+	// Failed evaluations are recorded by updating the run when the apply is blocked
+	runWithFailedEvaluation.AccessRuleEvaluations = []models.ManagedIdentityAccessRuleEvaluation{passedEvaluation, failedEvaluation}
+	runWithFailedEvaluation, err = testClient.client.Runs.UpdateRun(ctx, runWithFailedEvaluation)
+	require.Nil(t, err)
+	assert.Equal(t, []models.ManagedIdentityAccessRuleEvaluation{passedEvaluation, failedEvaluation}, runWithFailedEvaluation.AccessRuleEvaluations)
+
+	type testCase struct {
+		failed       bool
+		name         string
+		expectRunIDs []string
+	}
+
+	testCases := []testCase{
+		{
+			name:         "runs with a failed evaluation",
+			failed:       true,
+			expectRunIDs: []string{runWithFailedEvaluation.Metadata.ID},
+		},
+		{
+			name:         "runs without a failed evaluation",
+			failed:       false,
+			expectRunIDs: []string{runWithoutEvaluations.Metadata.ID, runWithPassedEvaluations.Metadata.ID},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.Runs.GetRuns(ctx, &GetRunsInput{
+				Filter: &RunFilter{
+					WorkspaceID:                &workspaceID,
+					AccessRuleEvaluationFailed: &test.failed,
+				},
+			})
+			require.Nil(t, err)
+
+			actualRunIDs := []string{}
+			for _, run := range result.Runs {
+				actualRunIDs = append(actualRunIDs, run.Metadata.ID)
+			}
+
+			assert.ElementsMatch(t, test.expectRunIDs, actualRunIDs)
+		})
+	}
+}
+
 func createWarmupRuns(ctx context.Context, testClient *testClient,
 	newGroups []models.Group,
 	newWorkspaces []models.Workspace,
@@ -1238,6 +1322,7 @@ func compareRuns(t *testing.T, expected, actual *models.Run,
 	assert.Equal(t, expected.ForceCanceled, actual.ForceCanceled)
 	assert.Equal(t, expected.Comment, actual.Comment)
 	assert.Equal(t, expected.AutoApply, actual.AutoApply)
+	assert.ElementsMatch(t, expected.AccessRuleEvaluations, actual.AccessRuleEvaluations)
 
 	if checkID {
 		assert.Equal(t, expected.Metadata.ID, actual.Metadata.ID)
@@ -5,10 +5,12 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jackc/pgx/v4"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
@@ -21,6 +23,7 @@ type Runners interface {
 	GetRunnerByPath(ctx context.Context, path string) (*models.Runner, error)
 	GetRunnerByID(ctx context.Context, id string) (*models.Runner, error)
 	GetRunners(ctx context.Context, input *GetRunnersInput) (*RunnersResult, error)
+	GetRunnerCount(ctx context.Context, filter *RunnerFilter) (int32, error)
 	CreateRunner(ctx context.Context, runner *models.Runner) (*models.Runner, error)
 	UpdateRunner(ctx context.Context, runner *models.Runner) (*models.Runner, error)
 	DeleteRunner(ctx context.Context, runner *models.Runner) error
@@ -97,7 +100,7 @@ type terraformRunners struct {
 	dbClient *Client
 }
 
-var runnerFieldList = append(metadataFieldList, "type", "name", "description", "group_id", "created_by", "disabled")
+var runnerFieldList = append(metadataFieldList, "type", "name", "description", "group_id", "created_by", "disabled", "tags")
 
 // NewRunners returns an instance of the Runners interface
 func NewRunners(dbClient *Client) Runners {
@@ -133,38 +136,13 @@ func (t *terraformRunners) GetRunners(ctx context.Context, input *GetRunnersInpu
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
-	ex := goqu.And()
-
-	if input.Filter != nil {
-		if input.Filter.RunnerID != nil {
-			ex = ex.Append(goqu.I("runners.id").Eq(*input.Filter.RunnerID))
-		}
-
-		if input.Filter.RunnerIDs != nil {
-			ex = ex.Append(goqu.I("runners.id").In(input.Filter.RunnerIDs))
-		}
-
-		if input.Filter.NamespacePaths != nil {
-			ex = ex.Append(goqu.I("namespaces.path").In(input.Filter.NamespacePaths))
-		}
-
-		if input.Filter.RunnerName != nil {
-			ex = ex.Append(goqu.I("runners.name").Eq(*input.Filter.RunnerName))
-		}
-
-		if input.Filter.GroupID != nil {
-			ex = ex.Append(goqu.I("runners.group_id").Eq(*input.Filter.GroupID))
-		}
-
-		if input.Filter.Enabled != nil {
-			ex = ex.Append(goqu.I("runners.disabled").Eq(!(*input.Filter.Enabled)))
-		}
-
-		if input.Filter.RunnerType != nil {
-			ex = ex.Append(goqu.I("runners.type").Eq(*input.Filter.RunnerType))
-		}
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
 	}
 
+	ex := runnerFilterExpression(input.Filter)
+
 	query := dialect.From(goqu.T("runners")).
 		Select(t.getSelectFields()...).
 		LeftJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"runners.group_id": goqu.I("namespaces.group_id")})).
@@ -225,6 +203,24 @@ func (t *terraformRunners) GetRunners(ctx context.Context, input *GetRunnersInpu
 	return &result, nil
 }
 
+func (t *terraformRunners) GetRunnerCount(ctx context.Context, filter *RunnerFilter) (int32, error) {
+	ctx, span := tracer.Start(ctx, "db.GetRunnerCount")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	query := dialect.From(goqu.T("runners")).
+		LeftJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"runners.group_id": goqu.I("namespaces.group_id")})).
+		Where(runnerFilterExpression(filter))
+
+	count, err := pagination.Count(ctx, t.dbClient.getConnection(ctx), query)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to count runners")
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (t *terraformRunners) CreateRunner(ctx context.Context, runner *models.Runner) (*models.Runner, error) {
 	ctx, span := tracer.Start(ctx, "db.CreateRunner")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -246,6 +242,12 @@ func (t *terraformRunners) CreateRunner(ctx context.Context, runner *models.Runn
 		}
 	}()
 
+	tags, err := json.Marshal(runnerTags(runner.Tags))
+	if err != nil {
+		tracing.RecordError(span, err, "failed to marshal runner tags")
+		return nil, err
+	}
+
 	sql, args, err := dialect.Insert("runners").
 		Prepared(true).
 		Rows(goqu.Record{
@@ -259,6 +261,7 @@ func (t *terraformRunners) CreateRunner(ctx context.Context, runner *models.Runn
 			"description": runner.Description,
 			"created_by":  runner.CreatedBy,
 			"disabled":    runner.Disabled,
+			"tags":        tags,
 		}).
 		Returning(runnerFieldList...).ToSQL()
 	if err != nil {
@@ -322,6 +325,12 @@ func (t *terraformRunners) UpdateRunner(ctx context.Context, runner *models.Runn
 		}
 	}()
 
+	tags, err := json.Marshal(runnerTags(runner.Tags))
+	if err != nil {
+		tracing.RecordError(span, err, "failed to marshal runner tags")
+		return nil, err
+	}
+
 	sql, args, err := dialect.Update("runners").
 		Prepared(true).
 		Set(goqu.Record{
@@ -329,6 +338,7 @@ func (t *terraformRunners) UpdateRunner(ctx context.Context, runner *models.Runn
 			"updated_at":  timestamp,
 			"description": runner.Description,
 			"disabled":    runner.Disabled,
+			"tags":        tags,
 		}).
 		Where(goqu.Ex{"id": runner.Metadata.ID, "version": runner.Metadata.Version}).
 		Returning(runnerFieldList...).ToSQL()
@@ -442,6 +452,14 @@ func buildGroupRunnerResourcePath(groupPath string, name string) string {
 	return fmt.Sprintf("%s/%s", groupPath, name)
 }
 
+// runnerTags returns an empty list instead of nil so the tags are never stored as JSON null
+func runnerTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
 func scanRunner(row scanner, withResourcePath bool) (*models.Runner, error) {
 	runner := &models.Runner{}
 
@@ -456,6 +474,7 @@ func scanRunner(row scanner, withResourcePath bool) (*models.Runner, error) {
 		&runner.GroupID,
 		&runner.CreatedBy,
 		&runner.Disabled,
+		&runner.Tags,
 	}
 	var path sql.NullString
 	if withResourcePath {
@@ -477,3 +496,42 @@ func scanRunner(row scanner, withResourcePath bool) (*models.Runner, error) {
 
 	return runner, nil
 }
+
+// runnerFilterExpression builds the where expression for a runner filter
+func runnerFilterExpression(filter *RunnerFilter) exp.ExpressionList {
+	ex := goqu.And()
+
+	if filter == nil {
+		return ex
+	}
+
+	if filter.RunnerID != nil {
+		ex = ex.Append(goqu.I("runners.id").Eq(*filter.RunnerID))
+	}
+
+	if filter.RunnerIDs != nil {
+		ex = ex.Append(goqu.I("runners.id").In(filter.RunnerIDs))
+	}
+
+	if filter.NamespacePaths != nil {
+		ex = ex.Append(goqu.I("namespaces.path").In(filter.NamespacePaths))
+	}
+
+	if filter.RunnerName != nil {
+		ex = ex.Append(goqu.I("runners.name").Eq(*filter.RunnerName))
+	}
+
+	if filter.GroupID != nil {
+		ex = ex.Append(goqu.I("runners.group_id").Eq(*filter.GroupID))
+	}
+
+	if filter.Enabled != nil {
+		ex = ex.Append(goqu.I("runners.disabled").Eq(!(*filter.Enabled)))
+	}
+
+	if filter.RunnerType != nil {
+		ex = ex.Append(goqu.I("runners.type").Eq(*filter.RunnerType))
+	}
+
+	return ex
+}
@@ -101,6 +101,11 @@ func (a *sessions) GetRunnerSessions(ctx context.Context, input *GetRunnerSessio
 	ctx, span := tracer.Start(ctx, "db.GetRunnerSessions")
 	defer span.End()
 
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
+	}
+
 	ex := goqu.And()
 
 	if input.Filter != nil {
@@ -398,6 +398,58 @@ func TestGetRunners(t *testing.T) {
 	}
 }
 
+func TestGetRunnerCount(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	warmupItems, err := createWarmupRunners(ctx, testClient, warmupRunners{
+		groups:  standardWarmupGroupsForRunners,
+		runners: standardWarmupRunners,
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		filter *RunnerFilter
+		name   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "no filter",
+		},
+		{
+			name:   "filter by namespace path",
+			filter: &RunnerFilter{NamespacePaths: []string{warmupItems.groups[0].FullPath}},
+		},
+		{
+			name:   "filter by runner IDs",
+			filter: &RunnerFilter{RunnerIDs: []string{warmupItems.runners[0].Metadata.ID}},
+		},
+		{
+			name:   "filter by group ID",
+			filter: &RunnerFilter{GroupID: &warmupItems.groups[0].Metadata.ID},
+		},
+		{
+			name:   "filter matches nothing",
+			filter: &RunnerFilter{NamespacePaths: []string{"this-path-does-not-exist"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.Runners.GetRunners(ctx, &GetRunnersInput{Filter: test.filter})
+			require.Nil(t, err)
+
+			count, err := testClient.client.Runners.GetRunnerCount(ctx, test.filter)
+			require.Nil(t, err)
+
+			assert.Equal(t, result.PageInfo.TotalCount, count)
+			assert.Equal(t, int32(len(result.Runners)), count)
+		})
+	}
+}
+
 func TestCreateRunner(t *testing.T) {
 
 	ctx := context.Background()
@@ -24,6 +24,8 @@ type ServiceAccounts interface {
 	CreateServiceAccount(ctx context.Context, serviceAccount *models.ServiceAccount) (*models.ServiceAccount, error)
 	UpdateServiceAccount(ctx context.Context, serviceAccount *models.ServiceAccount) (*models.ServiceAccount, error)
 	GetServiceAccounts(ctx context.Context, input *GetServiceAccountsInput) (*ServiceAccountsResult, error)
+	GetServiceAccountCount(ctx context.Context, filter *ServiceAccountFilter) (int32, error)
+	GetServiceAccountsByIDs(ctx context.Context, ids []string) (map[string]*models.ServiceAccount, error)
 	DeleteServiceAccount(ctx context.Context, serviceAccount *models.ServiceAccount) error
 	AssignServiceAccountToRunner(ctx context.Context, serviceAccountID string, runnerID string) error
 	UnassignServiceAccountFromRunner(ctx context.Context, serviceAccountID string, runnerID string) error
@@ -141,73 +143,18 @@ func (s *serviceAccounts) GetServiceAccounts(ctx context.Context, input *GetServ
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
-	ex := goqu.And()
-
-	if input.Filter != nil {
-		if input.Filter.ServiceAccountIDs != nil {
-			ex = ex.Append(goqu.I("service_accounts.id").In(input.Filter.ServiceAccountIDs))
-		}
-
-		if input.Filter.NamespacePaths != nil {
-			ex = ex.Append(goqu.I("namespaces.path").In(input.Filter.NamespacePaths))
-		}
-
-		if input.Filter.RunnerID != nil {
-			ex = ex.Append(goqu.I("service_account_runner_relation.runner_id").In(*input.Filter.RunnerID))
-		}
-
-		if input.Filter.Search != nil {
-			search := *input.Filter.Search
-
-			lastDelimiterIndex := strings.LastIndex(search, "/")
-
-			if lastDelimiterIndex != -1 {
-				namespacePath := search[:lastDelimiterIndex]
-				serviceAccountName := search[lastDelimiterIndex+1:]
-
-				if serviceAccountName != "" {
-					// An OR condition is used here since the last component of the search path could be part of
-					// the namespace or it can be a service account name prefix
-					ex = ex.Append(
-						goqu.Or(
-							goqu.And(
-								goqu.I("namespaces.path").Eq(namespacePath),
-								goqu.I("service_accounts.name").ILike(serviceAccountName+"%"),
-							),
-							goqu.Or(
-								goqu.I("namespaces.path").ILike(search+"%"),
-								goqu.I("service_accounts.name").ILike(serviceAccountName+"%"),
-							),
-						),
-					)
-				} else {
-					// We know the search is a namespace path since it ends with a "/"
-					ex = ex.Append(goqu.I("namespaces.path").ILike(namespacePath + "%"))
-				}
-			} else {
-				// We don't know if the search is for a namespace path or service account name; therefore, use
-				// an OR condition to search both
-				ex = ex.Append(
-					goqu.Or(
-						goqu.I("namespaces.path").ILike(search+"%"),
-						goqu.I("service_accounts.name").ILike(search+"%"),
-					),
-				)
-			}
-		}
-
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
 	}
 
+	ex := serviceAccountFilterExpression(input.Filter)
+
 	query := dialect.From("service_accounts").
 		Select(s.getSelectFields()...).
 		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"service_accounts.group_id": goqu.I("namespaces.group_id")}))
 
-	if input.Filter != nil && input.Filter.RunnerID != nil {
-		// Add inner join for runner relation table
-		query = query.InnerJoin(goqu.T("service_account_runner_relation"), goqu.On(goqu.Ex{"service_accounts.id": goqu.I("service_account_runner_relation.service_account_id")}))
-	}
-
-	query = query.Where(ex)
+	query = joinServiceAccountFilterTables(query, input.Filter).Where(ex)
 
 	sortDirection := pagination.AscSort
 
@@ -263,6 +210,54 @@ func (s *serviceAccounts) GetServiceAccounts(ctx context.Context, input *GetServ
 	return &result, nil
 }
 
+func (s *serviceAccounts) GetServiceAccountCount(ctx context.Context, filter *ServiceAccountFilter) (int32, error) {
+	ctx, span := tracer.Start(ctx, "db.GetServiceAccountCount")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	query := dialect.From("service_accounts").
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"service_accounts.group_id": goqu.I("namespaces.group_id")}))
+
+	query = joinServiceAccountFilterTables(query, filter).Where(serviceAccountFilterExpression(filter))
+
+	count, err := pagination.Count(ctx, s.dbClient.getConnection(ctx), query)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to count service accounts")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetServiceAccountsByIDs returns the service accounts for the IDs keyed by ID, IDs that don't exist are omitted from the map
+func (s *serviceAccounts) GetServiceAccountsByIDs(ctx context.Context, ids []string) (map[string]*models.ServiceAccount, error) {
+	ctx, span := tracer.Start(ctx, "db.GetServiceAccountsByIDs")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	serviceAccountMap := make(map[string]*models.ServiceAccount, len(ids))
+	if len(ids) == 0 {
+		return serviceAccountMap, nil
+	}
+
+	result, err := s.GetServiceAccounts(ctx, &GetServiceAccountsInput{
+		Filter: &ServiceAccountFilter{
+			ServiceAccountIDs: ids,
+		},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get service accounts")
+		return nil, err
+	}
+
+	for ix := range result.ServiceAccounts {
+		sa := &result.ServiceAccounts[ix]
+		serviceAccountMap[sa.Metadata.ID] = sa
+	}
+
+	return serviceAccountMap, nil
+}
+
 // CreateServiceAccount creates a new serviceAccount
 func (s *serviceAccounts) CreateServiceAccount(ctx context.Context, serviceAccount *models.ServiceAccount) (*models.ServiceAccount, error) {
 	ctx, span := tracer.Start(ctx, "db.CreateServiceAccount")
@@ -616,3 +611,76 @@ func scanServiceAccount(row scanner, withResourcePath bool) (*models.ServiceAcco
 
 	return serviceAccount, nil
 }
+
+// serviceAccountFilterExpression builds the where expression for a service account filter
+func serviceAccountFilterExpression(filter *ServiceAccountFilter) exp.ExpressionList {
+	ex := goqu.And()
+
+	if filter == nil {
+		return ex
+	}
+
+	if filter.ServiceAccountIDs != nil {
+		ex = ex.Append(goqu.I("service_accounts.id").In(filter.ServiceAccountIDs))
+	}
+
+	if filter.NamespacePaths != nil {
+		ex = ex.Append(goqu.I("namespaces.path").In(filter.NamespacePaths))
+	}
+
+	if filter.RunnerID != nil {
+		ex = ex.Append(goqu.I("service_account_runner_relation.runner_id").In(*filter.RunnerID))
+	}
+
+	if filter.Search != nil {
+		search := *filter.Search
+
+		lastDelimiterIndex := strings.LastIndex(search, "/")
+
+		if lastDelimiterIndex != -1 {
+			namespacePath := search[:lastDelimiterIndex]
+			serviceAccountName := search[lastDelimiterIndex+1:]
+
+			if serviceAccountName != "" {
+				// An OR condition is used here since the last component of the search path could be part of
+				// the namespace or it can be a service account name prefix
+				ex = ex.Append(
+					goqu.Or(
+						goqu.And(
+							goqu.I("namespaces.path").Eq(namespacePath),
+							goqu.I("service_accounts.name").ILike(serviceAccountName+"%"),
+						),
+						goqu.Or(
+							goqu.I("namespaces.path").ILike(search+"%"),
+							goqu.I("service_accounts.name").ILike(serviceAccountName+"%"),
+						),
+					),
+				)
+			} else {
+				// We know the search is a namespace path since it ends with a "/"
+				ex = ex.Append(goqu.I("namespaces.path").ILike(namespacePath + "%"))
+			}
+		} else {
+			// We don't know if the search is for a namespace path or service account name; therefore, use
+			// an OR condition to search both
+			ex = ex.Append(
+				goqu.Or(
+					goqu.I("namespaces.path").ILike(search+"%"),
+					goqu.I("service_accounts.name").ILike(search+"%"),
+				),
+			)
+		}
+	}
+
+	return ex
+}
+
+// joinServiceAccountFilterTables adds the joins needed by the service account filter expression
+func joinServiceAccountFilterTables(query *goqu.SelectDataset, filter *ServiceAccountFilter) *goqu.SelectDataset {
+	if filter != nil && filter.RunnerID != nil {
+		// Add inner join for runner relation table
+		query = query.InnerJoin(goqu.T("service_account_runner_relation"), goqu.On(goqu.Ex{"service_accounts.id": goqu.I("service_account_runner_relation.service_account_id")}))
+	}
+
+	return query
+}
@@ -181,6 +181,71 @@ func TestGetServiceAccountByPath(t *testing.T) {
 	}
 }
 
+func TestGetServiceAccountsByIDs(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	_, warmupServiceAccounts, err := createWarmupServiceAccounts(ctx, testClient,
+		standardWarmupGroupsForServiceAccounts, standardWarmupServiceAccounts,
+		standardWarmupOIDCTrustPoliciesForServiceAccounts)
+	require.Nil(t, err)
+
+	type testCase struct {
+		expectMsg *string
+		name      string
+		ids       []string
+		expectIDs []string
+	}
+
+	testCases := []testCase{
+		{
+			name:      "all IDs exist",
+			ids:       []string{warmupServiceAccounts[0].Metadata.ID, warmupServiceAccounts[1].Metadata.ID},
+			expectIDs: []string{warmupServiceAccounts[0].Metadata.ID, warmupServiceAccounts[1].Metadata.ID},
+		},
+		{
+			name:      "mixed existent and missing IDs",
+			ids:       []string{warmupServiceAccounts[0].Metadata.ID, nonExistentID, warmupServiceAccounts[2].Metadata.ID},
+			expectIDs: []string{warmupServiceAccounts[0].Metadata.ID, warmupServiceAccounts[2].Metadata.ID},
+		},
+		{
+			name:      "only missing IDs",
+			ids:       []string{nonExistentID},
+			expectIDs: []string{},
+		},
+		{
+			name:      "no IDs",
+			ids:       []string{},
+			expectIDs: []string{},
+		},
+		{
+			name:      "defective ID",
+			ids:       []string{invalidID},
+			expectMsg: invalidUUIDMsg2,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			serviceAccountMap, err := testClient.client.ServiceAccounts.GetServiceAccountsByIDs(ctx, test.ids)
+
+			checkError(t, test.expectMsg, err)
+			if test.expectMsg != nil {
+				return
+			}
+
+			actualIDs := []string{}
+			for id, sa := range serviceAccountMap {
+				assert.Equal(t, id, sa.Metadata.ID)
+				actualIDs = append(actualIDs, id)
+			}
+
+			assert.ElementsMatch(t, test.expectIDs, actualIDs)
+		})
+	}
+}
+
 func TestCreateServiceAccount(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -930,6 +995,57 @@ func TestGetServiceAccounts(t *testing.T) {
 	}
 }
 
+func TestGetServiceAccountCount(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	createdWarmupGroups, createdWarmupServiceAccounts, err := createWarmupServiceAccounts(ctx, testClient,
+		standardWarmupGroupsForServiceAccounts, standardWarmupServiceAccounts,
+		standardWarmupOIDCTrustPoliciesForServiceAccounts)
+	require.Nil(t, err)
+
+	type testCase struct {
+		filter *ServiceAccountFilter
+		name   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "no filter",
+		},
+		{
+			name:   "filter by namespace path",
+			filter: &ServiceAccountFilter{NamespacePaths: []string{createdWarmupGroups[0].FullPath}},
+		},
+		{
+			name:   "filter by service account IDs",
+			filter: &ServiceAccountFilter{ServiceAccountIDs: []string{createdWarmupServiceAccounts[0].Metadata.ID}},
+		},
+		{
+			name:   "filter by search",
+			filter: &ServiceAccountFilter{Search: ptr.String("1")},
+		},
+		{
+			name:   "filter matches nothing",
+			filter: &ServiceAccountFilter{NamespacePaths: []string{"this-path-does-not-exist"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.ServiceAccounts.GetServiceAccounts(ctx, &GetServiceAccountsInput{Filter: test.filter})
+			require.Nil(t, err)
+
+			count, err := testClient.client.ServiceAccounts.GetServiceAccountCount(ctx, test.filter)
+			require.Nil(t, err)
+
+			assert.Equal(t, result.PageInfo.TotalCount, count)
+			assert.Equal(t, int32(len(result.ServiceAccounts)), count)
+		})
+	}
+}
+
 func TestDeleteServiceAccount(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -107,6 +107,11 @@ func (tm *teamMembers) GetTeamMembers(ctx context.Context, input *GetTeamMembers
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
+	}
+
 	ex := goqu.Ex{}
 
 	if input.Filter != nil {
@@ -100,6 +100,11 @@ func (t *terraformProviderPlatformMirrors) GetPlatformMirrors(ctx context.Contex
 	ctx, span := tracer.Start(ctx, "db.GetPlatformMirrors")
 	defer span.End()
 
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
+	}
+
 	ex := goqu.And()
 
 	if input.Filter != nil {
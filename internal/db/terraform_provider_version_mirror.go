@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jackc/pgx/v4"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
@@ -20,6 +21,7 @@ import (
 type TerraformProviderVersionMirrors interface {
 	GetVersionMirrorByID(ctx context.Context, id string) (*models.TerraformProviderVersionMirror, error)
 	GetVersionMirrors(ctx context.Context, input *GetProviderVersionMirrorsInput) (*ProviderVersionMirrorsResult, error)
+	GetVersionMirrorCount(ctx context.Context, filter *TerraformProviderVersionMirrorFilter) (int32, error)
 	CreateVersionMirror(ctx context.Context, versionMirror *models.TerraformProviderVersionMirror) (*models.TerraformProviderVersionMirror, error)
 	DeleteVersionMirror(ctx context.Context, versionMirror *models.TerraformProviderVersionMirror) error
 }
@@ -114,33 +116,13 @@ func (t *terraformProviderVersionMirrors) GetVersionMirrors(ctx context.Context,
 	ctx, span := tracer.Start(ctx, "db.GetVersionMirrors")
 	defer span.End()
 
-	ex := goqu.And()
-
-	if input.Filter != nil {
-		if len(input.Filter.NamespacePaths) > 0 {
-			ex = ex.Append(goqu.I("namespaces.path").In(input.Filter.NamespacePaths))
-		}
-		if input.Filter.RegistryHostname != nil {
-			ex = ex.Append(goqu.I("terraform_provider_version_mirrors.registry_hostname").Eq(*input.Filter.RegistryHostname))
-		}
-		if input.Filter.RegistryNamespace != nil {
-			ex = ex.Append(goqu.I("terraform_provider_version_mirrors.registry_namespace").Eq(*input.Filter.RegistryNamespace))
-		}
-		if input.Filter.Type != nil {
-			ex = ex.Append(goqu.I("terraform_provider_version_mirrors.type").Eq(*input.Filter.Type))
-		}
-		if input.Filter.SemanticVersion != nil {
-			ex = ex.Append(goqu.I("terraform_provider_version_mirrors.semantic_version").Eq(*input.Filter.SemanticVersion))
-		}
-		if len(input.Filter.VersionMirrorIDs) > 0 {
-			ex = ex.Append(goqu.I("terraform_provider_version_mirrors.id").In(input.Filter.VersionMirrorIDs))
-		}
-		if input.Filter.GroupID != nil {
-			// GroupID is mainly for convenience as it avoids querying for a group prior to calling this function.
-			ex = ex.Append(goqu.I("terraform_provider_version_mirrors.group_id").Eq(*input.Filter.GroupID))
-		}
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
 	}
 
+	ex := versionMirrorFilterExpression(input.Filter)
+
 	query := dialect.From(goqu.T("terraform_provider_version_mirrors")).
 		Select(t.getSelectFields()...).
 		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"terraform_provider_version_mirrors.group_id": goqu.I("namespaces.group_id")})).
@@ -196,6 +178,24 @@ func (t *terraformProviderVersionMirrors) GetVersionMirrors(ctx context.Context,
 	return result, nil
 }
 
+func (t *terraformProviderVersionMirrors) GetVersionMirrorCount(ctx context.Context, filter *TerraformProviderVersionMirrorFilter) (int32, error) {
+	ctx, span := tracer.Start(ctx, "db.GetVersionMirrorCount")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	query := dialect.From(goqu.T("terraform_provider_version_mirrors")).
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"terraform_provider_version_mirrors.group_id": goqu.I("namespaces.group_id")})).
+		Where(versionMirrorFilterExpression(filter))
+
+	count, err := pagination.Count(ctx, t.dbClient.getConnection(ctx), query)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to count version mirrors")
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (t *terraformProviderVersionMirrors) CreateVersionMirror(ctx context.Context, versionMirror *models.TerraformProviderVersionMirror) (*models.TerraformProviderVersionMirror, error) {
 	ctx, span := tracer.Start(ctx, "db.CreateVersionMirror")
 	defer span.End()
@@ -328,3 +328,37 @@ func scanVersionMirror(row scanner) (*models.TerraformProviderVersionMirror, err
 
 	return versionMirror, nil
 }
+
+// versionMirrorFilterExpression builds the where expression for a provider version mirror filter
+func versionMirrorFilterExpression(filter *TerraformProviderVersionMirrorFilter) exp.ExpressionList {
+	ex := goqu.And()
+
+	if filter == nil {
+		return ex
+	}
+
+	if len(filter.NamespacePaths) > 0 {
+		ex = ex.Append(goqu.I("namespaces.path").In(filter.NamespacePaths))
+	}
+	if filter.RegistryHostname != nil {
+		ex = ex.Append(goqu.I("terraform_provider_version_mirrors.registry_hostname").Eq(*filter.RegistryHostname))
+	}
+	if filter.RegistryNamespace != nil {
+		ex = ex.Append(goqu.I("terraform_provider_version_mirrors.registry_namespace").Eq(*filter.RegistryNamespace))
+	}
+	if filter.Type != nil {
+		ex = ex.Append(goqu.I("terraform_provider_version_mirrors.type").Eq(*filter.Type))
+	}
+	if filter.SemanticVersion != nil {
+		ex = ex.Append(goqu.I("terraform_provider_version_mirrors.semantic_version").Eq(*filter.SemanticVersion))
+	}
+	if len(filter.VersionMirrorIDs) > 0 {
+		ex = ex.Append(goqu.I("terraform_provider_version_mirrors.id").In(filter.VersionMirrorIDs))
+	}
+	if filter.GroupID != nil {
+		// GroupID is mainly for convenience as it avoids querying for a group prior to calling this function.
+		ex = ex.Append(goqu.I("terraform_provider_version_mirrors.group_id").Eq(*filter.GroupID))
+	}
+
+	return ex
+}
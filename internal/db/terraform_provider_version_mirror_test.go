@@ -673,6 +673,54 @@ func TestGetVersionMirrors(t *testing.T) {
 	}
 }
 
+func TestGetVersionMirrorCount(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	warmupItems, err := createWarmupTerraformProviderVersionMirrors(ctx, testClient, warmupTerraformProviderVersionMirrors{
+		groups:                 standardWarmupGroupsForTerraformProviderVersionMirrors,
+		providerVersionMirrors: standardWarmupTerraformProviderVersionMirrors,
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		filter *TerraformProviderVersionMirrorFilter
+		name   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "no filter",
+		},
+		{
+			name:   "filter by group ID",
+			filter: &TerraformProviderVersionMirrorFilter{GroupID: &warmupItems.groups[0].Metadata.ID},
+		},
+		{
+			name:   "filter by version mirror IDs",
+			filter: &TerraformProviderVersionMirrorFilter{VersionMirrorIDs: []string{warmupItems.providerVersionMirrors[0].Metadata.ID}},
+		},
+		{
+			name:   "filter matches nothing",
+			filter: &TerraformProviderVersionMirrorFilter{NamespacePaths: []string{"this-path-does-not-exist"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.TerraformProviderVersionMirrors.GetVersionMirrors(ctx, &GetProviderVersionMirrorsInput{Filter: test.filter})
+			require.Nil(t, err)
+
+			count, err := testClient.client.TerraformProviderVersionMirrors.GetVersionMirrorCount(ctx, test.filter)
+			require.Nil(t, err)
+
+			assert.Equal(t, result.PageInfo.TotalCount, count)
+			assert.Equal(t, int32(len(result.VersionMirrors)), count)
+		})
+	}
+}
+
 func TestCreateVersionMirror(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
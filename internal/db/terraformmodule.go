@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jackc/pgx/v4"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
@@ -20,6 +21,7 @@ type TerraformModules interface {
 	GetModuleByID(ctx context.Context, id string) (*models.TerraformModule, error)
 	GetModuleByPath(ctx context.Context, path string) (*models.TerraformModule, error)
 	GetModules(ctx context.Context, input *GetModulesInput) (*ModulesResult, error)
+	GetModuleCount(ctx context.Context, filter *TerraformModuleFilter) (int32, error)
 	CreateModule(ctx context.Context, module *models.TerraformModule) (*models.TerraformModule, error)
 	UpdateModule(ctx context.Context, module *models.TerraformModule) (*models.TerraformModule, error)
 	DeleteModule(ctx context.Context, module *models.TerraformModule) error
@@ -124,78 +126,13 @@ func (t *terraformModules) GetModules(ctx context.Context, input *GetModulesInpu
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
-	ex := goqu.And()
-
-	if input.Filter != nil {
-		if input.Filter.TerraformModuleIDs != nil {
-			ex = ex.Append(goqu.I("terraform_modules.id").In(input.Filter.TerraformModuleIDs))
-		}
-		if input.Filter.Search != nil && *input.Filter.Search != "" {
-			search := *input.Filter.Search
-
-			lastDelimiterIndex := strings.LastIndex(search, "/")
-
-			if lastDelimiterIndex != -1 {
-				// TODO: do we need to include system in the search?
-				registryNamespace := search[:lastDelimiterIndex]
-				moduleName := search[lastDelimiterIndex+1:]
-
-				if moduleName != "" {
-					// An AND condition is used here since the first part of the search is the registry namespace path
-					// and the second part is the module name
-					ex = ex.Append(
-						goqu.And(
-							goqu.I("namespaces.path").ILike(registryNamespace+"%"),
-							goqu.I("terraform_modules.name").ILike(moduleName+"%"),
-						),
-					)
-				} else {
-					// We know the search is a namespace path since it ends with a "/"
-					ex = ex.Append(goqu.I("namespaces.path").ILike(registryNamespace + "%"))
-				}
-			} else {
-				// We don't know if the search is for a namespace path or module name; therefore, use
-				// an OR condition to search both
-				ex = ex.Append(
-					goqu.Or(
-						goqu.I("namespaces.path").ILike(search+"%"),
-						goqu.I("terraform_modules.name").ILike(search+"%"),
-					),
-				)
-			}
-		}
-		if input.Filter.GroupID != nil {
-			ex = ex.Append(goqu.I("terraform_modules.group_id").Eq(*input.Filter.GroupID))
-		}
-		if input.Filter.RootGroupID != nil {
-			ex = ex.Append(goqu.I("terraform_modules.root_group_id").Eq(*input.Filter.RootGroupID))
-		}
-		if input.Filter.Name != nil {
-			ex = ex.Append(goqu.I("terraform_modules.name").Eq(*input.Filter.Name))
-		}
-		if input.Filter.System != nil {
-			ex = ex.Append(goqu.I("terraform_modules.system").Eq(*input.Filter.System))
-		}
-		if input.Filter.UserID != nil {
-			ex = ex.Append(
-				goqu.Or(
-					goqu.I("terraform_modules.private").Eq(false),
-					namespaceMembershipExpressionBuilder{
-						userID: input.Filter.UserID,
-					}.build(),
-				))
-		}
-		if input.Filter.ServiceAccountID != nil {
-			ex = ex.Append(
-				goqu.Or(
-					goqu.I("terraform_modules.private").Eq(false),
-					namespaceMembershipExpressionBuilder{
-						serviceAccountID: input.Filter.ServiceAccountID,
-					}.build(),
-				))
-		}
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
 	}
 
+	ex := moduleFilterExpression(input.Filter)
+
 	query := dialect.From(goqu.T("terraform_modules")).
 		Select(t.getSelectFields()...).
 		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"terraform_modules.group_id": goqu.I("namespaces.group_id")})).
@@ -253,6 +190,24 @@ func (t *terraformModules) GetModules(ctx context.Context, input *GetModulesInpu
 	return &result, nil
 }
 
+func (t *terraformModules) GetModuleCount(ctx context.Context, filter *TerraformModuleFilter) (int32, error) {
+	ctx, span := tracer.Start(ctx, "db.GetModuleCount")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	query := dialect.From(goqu.T("terraform_modules")).
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"terraform_modules.group_id": goqu.I("namespaces.group_id")})).
+		Where(moduleFilterExpression(filter))
+
+	count, err := pagination.Count(ctx, t.dbClient.getConnection(ctx), query)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to count modules")
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (t *terraformModules) CreateModule(ctx context.Context, module *models.TerraformModule) (*models.TerraformModule, error) {
 	ctx, span := tracer.Start(ctx, "db.CreateModule")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -499,3 +454,82 @@ func scanTerraformModule(row scanner, withResourcePath bool) (*models.TerraformM
 
 	return module, nil
 }
+
+// moduleFilterExpression builds the where expression for a Terraform module filter
+func moduleFilterExpression(filter *TerraformModuleFilter) exp.ExpressionList {
+	ex := goqu.And()
+
+	if filter == nil {
+		return ex
+	}
+
+	if filter.TerraformModuleIDs != nil {
+		ex = ex.Append(goqu.I("terraform_modules.id").In(filter.TerraformModuleIDs))
+	}
+	if filter.Search != nil && *filter.Search != "" {
+		search := *filter.Search
+
+		lastDelimiterIndex := strings.LastIndex(search, "/")
+
+		if lastDelimiterIndex != -1 {
+			// TODO: do we need to include system in the search?
+			registryNamespace := search[:lastDelimiterIndex]
+			moduleName := search[lastDelimiterIndex+1:]
+
+			if moduleName != "" {
+				// An AND condition is used here since the first part of the search is the registry namespace path
+				// and the second part is the module name
+				ex = ex.Append(
+					goqu.And(
+						goqu.I("namespaces.path").ILike(registryNamespace+"%"),
+						goqu.I("terraform_modules.name").ILike(moduleName+"%"),
+					),
+				)
+			} else {
+				// We know the search is a namespace path since it ends with a "/"
+				ex = ex.Append(goqu.I("namespaces.path").ILike(registryNamespace + "%"))
+			}
+		} else {
+			// We don't know if the search is for a namespace path or module name; therefore, use
+			// an OR condition to search both
+			ex = ex.Append(
+				goqu.Or(
+					goqu.I("namespaces.path").ILike(search+"%"),
+					goqu.I("terraform_modules.name").ILike(search+"%"),
+				),
+			)
+		}
+	}
+	if filter.GroupID != nil {
+		ex = ex.Append(goqu.I("terraform_modules.group_id").Eq(*filter.GroupID))
+	}
+	if filter.RootGroupID != nil {
+		ex = ex.Append(goqu.I("terraform_modules.root_group_id").Eq(*filter.RootGroupID))
+	}
+	if filter.Name != nil {
+		ex = ex.Append(goqu.I("terraform_modules.name").Eq(*filter.Name))
+	}
+	if filter.System != nil {
+		ex = ex.Append(goqu.I("terraform_modules.system").Eq(*filter.System))
+	}
+	if filter.UserID != nil {
+		ex = ex.Append(
+			goqu.Or(
+				goqu.I("terraform_modules.private").Eq(false),
+				namespaceMembershipExpressionBuilder{
+					userID: filter.UserID,
+				}.build(),
+			))
+	}
+	if filter.ServiceAccountID != nil {
+		ex = ex.Append(
+			goqu.Or(
+				goqu.I("terraform_modules.private").Eq(false),
+				namespaceMembershipExpressionBuilder{
+					serviceAccountID: filter.ServiceAccountID,
+				}.build(),
+			))
+	}
+
+	return ex
+}
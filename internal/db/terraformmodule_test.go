@@ -606,6 +606,58 @@ func TestGetModules(t *testing.T) {
 	}
 }
 
+func TestGetModuleCount(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	warmupItems, err := createWarmupTerraformModules(ctx, testClient, warmupTerraformModules{
+		groups:           standardWarmupGroupsForTerraformModules,
+		terraformModules: standardWarmupTerraformModules,
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		filter *TerraformModuleFilter
+		name   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "no filter",
+		},
+		{
+			name:   "filter by group ID",
+			filter: &TerraformModuleFilter{GroupID: &warmupItems.groups[0].Metadata.ID},
+		},
+		{
+			name:   "filter by module IDs",
+			filter: &TerraformModuleFilter{TerraformModuleIDs: []string{warmupItems.terraformModules[0].Metadata.ID}},
+		},
+		{
+			name:   "filter by search",
+			filter: &TerraformModuleFilter{Search: ptr.String("1")},
+		},
+		{
+			name:   "filter matches nothing",
+			filter: &TerraformModuleFilter{Name: ptr.String("this-module-does-not-exist")},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.TerraformModules.GetModules(ctx, &GetModulesInput{Filter: test.filter})
+			require.Nil(t, err)
+
+			count, err := testClient.client.TerraformModules.GetModuleCount(ctx, test.filter)
+			require.Nil(t, err)
+
+			assert.Equal(t, result.PageInfo.TotalCount, count)
+			assert.Equal(t, int32(len(result.Modules)), count)
+		})
+	}
+}
+
 func TestCreateModule(t *testing.T) {
 
 	ctx := context.Background()
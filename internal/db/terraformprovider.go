@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jackc/pgx/v4"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
@@ -20,6 +21,7 @@ type TerraformProviders interface {
 	GetProviderByID(ctx context.Context, id string) (*models.TerraformProvider, error)
 	GetProviderByPath(ctx context.Context, path string) (*models.TerraformProvider, error)
 	GetProviders(ctx context.Context, input *GetProvidersInput) (*ProvidersResult, error)
+	GetProviderCount(ctx context.Context, filter *TerraformProviderFilter) (int32, error)
 	CreateProvider(ctx context.Context, provider *models.TerraformProvider) (*models.TerraformProvider, error)
 	UpdateProvider(ctx context.Context, provider *models.TerraformProvider) (*models.TerraformProvider, error)
 	DeleteProvider(ctx context.Context, provider *models.TerraformProvider) error
@@ -114,74 +116,13 @@ func (t *terraformProviders) GetProviders(ctx context.Context, input *GetProvide
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
-	ex := goqu.And()
-
-	if input.Filter != nil {
-		if input.Filter.TerraformProviderIDs != nil {
-			ex = ex.Append(goqu.I("terraform_providers.id").In(input.Filter.TerraformProviderIDs))
-		}
-		if input.Filter.Search != nil && *input.Filter.Search != "" {
-			search := *input.Filter.Search
-
-			lastDelimiterIndex := strings.LastIndex(search, "/")
-
-			if lastDelimiterIndex != -1 {
-				registryNamespace := search[:lastDelimiterIndex]
-				providerName := search[lastDelimiterIndex+1:]
-
-				if providerName != "" {
-					// An AND condition is used here since the first part of the search is the registry namespace path
-					// and the second part is the provider name
-					ex = ex.Append(
-						goqu.And(
-							goqu.I("namespaces.path").ILike(registryNamespace+"%"),
-							goqu.I("terraform_providers.name").ILike(providerName+"%"),
-						),
-					)
-				} else {
-					// We know the search is a namespace path since it ends with a "/"
-					ex = ex.Append(goqu.I("namespaces.path").ILike(registryNamespace + "%"))
-				}
-			} else {
-				// We don't know if the search is for a namespace path or provider name; therefore, use
-				// an OR condition to search both
-				ex = ex.Append(
-					goqu.Or(
-						goqu.I("namespaces.path").ILike(search+"%"),
-						goqu.I("terraform_providers.name").ILike(search+"%"),
-					),
-				)
-			}
-		}
-		if input.Filter.GroupID != nil {
-			ex = ex.Append(goqu.I("terraform_providers.group_id").Eq(*input.Filter.GroupID))
-		}
-		if input.Filter.RootGroupID != nil {
-			ex = ex.Append(goqu.I("terraform_providers.root_group_id").Eq(*input.Filter.RootGroupID))
-		}
-		if input.Filter.Name != nil {
-			ex = ex.Append(goqu.I("terraform_providers.name").Eq(*input.Filter.Name))
-		}
-		if input.Filter.UserID != nil {
-			ex = ex.Append(
-				goqu.Or(
-					goqu.I("terraform_providers.private").Eq(false),
-					namespaceMembershipExpressionBuilder{
-						userID: input.Filter.UserID,
-					}.build(),
-				))
-		}
-		if input.Filter.ServiceAccountID != nil {
-			ex = ex.Append(
-				goqu.Or(
-					goqu.I("terraform_providers.private").Eq(false),
-					namespaceMembershipExpressionBuilder{
-						serviceAccountID: input.Filter.ServiceAccountID,
-					}.build(),
-				))
-		}
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
 	}
 
+	ex := providerFilterExpression(input.Filter)
+
 	query := dialect.From(goqu.T("terraform_providers")).
 		Select(t.getSelectFields()...).
 		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"terraform_providers.group_id": goqu.I("namespaces.group_id")})).
@@ -239,6 +180,24 @@ func (t *terraformProviders) GetProviders(ctx context.Context, input *GetProvide
 	return &result, nil
 }
 
+func (t *terraformProviders) GetProviderCount(ctx context.Context, filter *TerraformProviderFilter) (int32, error) {
+	ctx, span := tracer.Start(ctx, "db.GetProviderCount")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	query := dialect.From(goqu.T("terraform_providers")).
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"terraform_providers.group_id": goqu.I("namespaces.group_id")})).
+		Where(providerFilterExpression(filter))
+
+	count, err := pagination.Count(ctx, t.dbClient.getConnection(ctx), query)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to count providers")
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (t *terraformProviders) CreateProvider(ctx context.Context, provider *models.TerraformProvider) (*models.TerraformProvider, error) {
 	ctx, span := tracer.Start(ctx, "db.CreateProvider")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -476,3 +435,78 @@ func scanTerraformProvider(row scanner, withResourcePath bool) (*models.Terrafor
 
 	return provider, nil
 }
+
+// providerFilterExpression builds the where expression for a Terraform provider filter
+func providerFilterExpression(filter *TerraformProviderFilter) exp.ExpressionList {
+	ex := goqu.And()
+
+	if filter == nil {
+		return ex
+	}
+
+	if filter.TerraformProviderIDs != nil {
+		ex = ex.Append(goqu.I("terraform_providers.id").In(filter.TerraformProviderIDs))
+	}
+	if filter.Search != nil && *filter.Search != "" {
+		search := *filter.Search
+
+		lastDelimiterIndex := strings.LastIndex(search, "/")
+
+		if lastDelimiterIndex != -1 {
+			registryNamespace := search[:lastDelimiterIndex]
+			providerName := search[lastDelimiterIndex+1:]
+
+			if providerName != "" {
+				// An AND condition is used here since the first part of the search is the registry namespace path
+				// and the second part is the provider name
+				ex = ex.Append(
+					goqu.And(
+						goqu.I("namespaces.path").ILike(registryNamespace+"%"),
+						goqu.I("terraform_providers.name").ILike(providerName+"%"),
+					),
+				)
+			} else {
+				// We know the search is a namespace path since it ends with a "/"
+				ex = ex.Append(goqu.I("namespaces.path").ILike(registryNamespace + "%"))
+			}
+		} else {
+			// We don't know if the search is for a namespace path or provider name; therefore, use
+			// an OR condition to search both
+			ex = ex.Append(
+				goqu.Or(
+					goqu.I("namespaces.path").ILike(search+"%"),
+					goqu.I("terraform_providers.name").ILike(search+"%"),
+				),
+			)
+		}
+	}
+	if filter.GroupID != nil {
+		ex = ex.Append(goqu.I("terraform_providers.group_id").Eq(*filter.GroupID))
+	}
+	if filter.RootGroupID != nil {
+		ex = ex.Append(goqu.I("terraform_providers.root_group_id").Eq(*filter.RootGroupID))
+	}
+	if filter.Name != nil {
+		ex = ex.Append(goqu.I("terraform_providers.name").Eq(*filter.Name))
+	}
+	if filter.UserID != nil {
+		ex = ex.Append(
+			goqu.Or(
+				goqu.I("terraform_providers.private").Eq(false),
+				namespaceMembershipExpressionBuilder{
+					userID: filter.UserID,
+				}.build(),
+			))
+	}
+	if filter.ServiceAccountID != nil {
+		ex = ex.Append(
+			goqu.Or(
+				goqu.I("terraform_providers.private").Eq(false),
+				namespaceMembershipExpressionBuilder{
+					serviceAccountID: filter.ServiceAccountID,
+				}.build(),
+			))
+	}
+
+	return ex
+}
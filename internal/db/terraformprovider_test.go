@@ -899,6 +899,58 @@ func TestGetProviders(t *testing.T) {
 	}
 }
 
+func TestGetProviderCount(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	warmupItems, err := createWarmupTerraformProviders(ctx, testClient, warmupTerraformProviders{
+		groups:             standardWarmupGroupsForTerraformProviders,
+		terraformProviders: standardWarmupTerraformProviders,
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		filter *TerraformProviderFilter
+		name   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "no filter",
+		},
+		{
+			name:   "filter by group ID",
+			filter: &TerraformProviderFilter{GroupID: &warmupItems.groups[0].Metadata.ID},
+		},
+		{
+			name:   "filter by provider IDs",
+			filter: &TerraformProviderFilter{TerraformProviderIDs: []string{warmupItems.terraformProviders[0].Metadata.ID}},
+		},
+		{
+			name:   "filter by search",
+			filter: &TerraformProviderFilter{Search: ptr.String("1")},
+		},
+		{
+			name:   "filter matches nothing",
+			filter: &TerraformProviderFilter{Name: ptr.String("this-provider-does-not-exist")},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.TerraformProviders.GetProviders(ctx, &GetProvidersInput{Filter: test.filter})
+			require.Nil(t, err)
+
+			count, err := testClient.client.TerraformProviders.GetProviderCount(ctx, test.filter)
+			require.Nil(t, err)
+
+			assert.Equal(t, result.PageInfo.TotalCount, count)
+			assert.Equal(t, int32(len(result.Providers)), count)
+		})
+	}
+}
+
 func TestCreateProvider(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -98,6 +98,11 @@ func (t *terraformProviderPlatforms) GetProviderPlatforms(ctx context.Context, i
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
+	}
+
 	ex := goqu.Ex{}
 
 	if input.Filter != nil {
@@ -85,7 +85,7 @@ type variables struct {
 	dbClient *Client
 }
 
-var variableFieldList = append(metadataFieldList, "key", "value", "category", "hcl")
+var variableFieldList = append(metadataFieldList, "key", "value", "category", "hcl", "non_overridable")
 
 // NewVariables returns an instance of the Variables interface
 func NewVariables(dbClient *Client) Variables {
@@ -139,15 +139,16 @@ func (m *variables) CreateVariable(ctx context.Context, input *models.Variable)
 	timestamp := currentTime()
 
 	record := goqu.Record{
-		"id":           newResourceID(),
-		"version":      initialResourceVersion,
-		"created_at":   timestamp,
-		"updated_at":   timestamp,
-		"namespace_id": namespace.id,
-		"key":          input.Key,
-		"value":        input.Value,
-		"category":     input.Category,
-		"hcl":          input.Hcl,
+		"id":              newResourceID(),
+		"version":         initialResourceVersion,
+		"created_at":      timestamp,
+		"updated_at":      timestamp,
+		"namespace_id":    namespace.id,
+		"key":             input.Key,
+		"value":           input.Value,
+		"category":        input.Category,
+		"hcl":             input.Hcl,
+		"non_overridable": input.NonOverridable,
 	}
 
 	sql, args, err := dialect.Insert("namespace_variables").
@@ -210,15 +211,16 @@ func (m *variables) CreateVariables(ctx context.Context, namespacePath string, v
 	records := []goqu.Record{}
 	for _, v := range variables {
 		records = append(records, goqu.Record{
-			"id":           newResourceID(),
-			"version":      initialResourceVersion,
-			"created_at":   timestamp,
-			"updated_at":   timestamp,
-			"namespace_id": namespace.id,
-			"key":          v.Key,
-			"value":        v.Value,
-			"category":     v.Category,
-			"hcl":          v.Hcl,
+			"id":              newResourceID(),
+			"version":         initialResourceVersion,
+			"created_at":      timestamp,
+			"updated_at":      timestamp,
+			"namespace_id":    namespace.id,
+			"key":             v.Key,
+			"value":           v.Value,
+			"category":        v.Category,
+			"hcl":             v.Hcl,
+			"non_overridable": v.NonOverridable,
 		})
 	}
 
@@ -267,11 +269,12 @@ func (m *variables) UpdateVariable(ctx context.Context, variable *models.Variabl
 	sql, args, err := dialect.Update("namespace_variables").
 		Prepared(true).
 		Set(goqu.Record{
-			"version":    goqu.L("? + ?", goqu.C("version"), 1),
-			"updated_at": timestamp,
-			"key":        variable.Key,
-			"value":      variable.Value,
-			"hcl":        variable.Hcl,
+			"version":         goqu.L("? + ?", goqu.C("version"), 1),
+			"updated_at":      timestamp,
+			"key":             variable.Key,
+			"value":           variable.Value,
+			"hcl":             variable.Hcl,
+			"non_overridable": variable.NonOverridable,
 		}).
 		Where(goqu.Ex{"id": variable.Metadata.ID, "version": variable.Metadata.Version}).Returning(variableFieldList...).ToSQL()
 
@@ -368,6 +371,11 @@ func (m *variables) GetVariables(ctx context.Context, input *GetVariablesInput)
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
+	}
+
 	ex := goqu.And()
 
 	if input.Filter != nil {
@@ -465,6 +473,7 @@ func scanVariable(row scanner, withNamespacePath bool) (*models.Variable, error)
 		&variable.Value,
 		&variable.Category,
 		&variable.Hcl,
+		&variable.NonOverridable,
 	}
 
 	if withNamespacePath {
@@ -933,6 +933,59 @@ func TestUpdateVariable(t *testing.T) {
 	}
 }
 
+func TestVariableNonOverridable(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Name: "variable-overridable-group",
+	})
+	require.Nil(t, err)
+
+	created, err := testClient.client.Variables.CreateVariable(ctx, &models.Variable{
+		NamespacePath: group.FullPath,
+		Category:      models.TerraformVariableCategory,
+		Key:           "overridable-key",
+		Value:         ptr.String("value"),
+	})
+	require.Nil(t, err)
+	assert.False(t, created.NonOverridable)
+
+	// Disable overrides for the variable.
+	created.NonOverridable = true
+	updated, err := testClient.client.Variables.UpdateVariable(ctx, created)
+	require.Nil(t, err)
+	assert.True(t, updated.NonOverridable)
+
+	fetched, err := testClient.client.Variables.GetVariableByID(ctx, created.Metadata.ID)
+	require.Nil(t, err)
+	require.NotNil(t, fetched)
+	assert.True(t, fetched.NonOverridable)
+
+	// Variables created in bulk keep their own flag.
+	err = testClient.client.Variables.CreateVariables(ctx, group.FullPath, []models.Variable{
+		{Category: models.EnvironmentVariableCategory, Key: "LOCKED", Value: ptr.String("a"), NonOverridable: true},
+		{Category: models.EnvironmentVariableCategory, Key: "OPEN", Value: ptr.String("b")},
+	})
+	require.Nil(t, err)
+
+	result, err := testClient.client.Variables.GetVariables(ctx, &GetVariablesInput{
+		Filter: &VariableFilter{
+			NamespacePaths: []string{group.FullPath},
+		},
+	})
+	require.Nil(t, err)
+
+	actual := map[string]bool{}
+	for _, v := range result.Variables {
+		if v.Category == models.EnvironmentVariableCategory {
+			actual[v.Key] = v.NonOverridable
+		}
+	}
+	assert.Equal(t, map[string]bool{"LOCKED": true, "OPEN": false}, actual)
+}
+
 func TestDeleteVariable(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -1241,6 +1294,7 @@ func compareVariables(t *testing.T, expected, actual *models.Variable,
 	assert.Equal(t, expected.Category, actual.Category)
 	assert.Equal(t, expected.NamespacePath, actual.NamespacePath)
 	assert.Equal(t, expected.Hcl, actual.Hcl)
+	assert.Equal(t, expected.NonOverridable, actual.NonOverridable)
 	assert.Equal(t, expected.Key, actual.Key)
 	assert.Equal(t, expected.Value, actual.Value)
 
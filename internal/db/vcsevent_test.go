@@ -534,6 +534,83 @@ func TestGetEvents(t *testing.T) {
 	}
 }
 
+func TestGetEventsWithIdenticalCreationTimes(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	warmupItems, err := createWarmupVCSEvents(ctx, testClient,
+		warmupVCSEvents{
+			standardWarmupGroupsForVCSEvents,
+			standardWarmupWorkspacesForVCSEvents,
+			standardWarmupVCSEvents,
+		})
+	require.Nil(t, err)
+
+	// Give every event the same creation time so only the ID tiebreak can keep the cursors stable.
+	sharedCreationTime := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	allVCSEventIDs := []string{}
+	for _, event := range warmupItems.events {
+		_, err = testClient.client.conn.Exec(ctx, "UPDATE vcs_events SET created_at = $1 WHERE id = $2",
+			sharedCreationTime, event.Metadata.ID)
+		require.Nil(t, err)
+		allVCSEventIDs = append(allVCSEventIDs, event.Metadata.ID)
+	}
+
+	sort.Strings(allVCSEventIDs)
+
+	type testCase struct {
+		name              string
+		sort              VCSEventSortableField
+		expectVCSEventIDs []string
+	}
+
+	testCases := []testCase{
+		{
+			name:              "ascending creation time falls back to ascending ID",
+			sort:              VCSEventSortableFieldCreatedAtAsc,
+			expectVCSEventIDs: allVCSEventIDs,
+		},
+		{
+			name:              "descending creation time falls back to descending ID",
+			sort:              VCSEventSortableFieldCreatedAtDesc,
+			expectVCSEventIDs: reverseStringSlice(allVCSEventIDs),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			actualVCSEventIDs := []string{}
+			var after *string
+
+			// Walk through the events one small page at a time.
+			for {
+				result, err := testClient.client.VCSEvents.GetEvents(ctx, &GetVCSEventsInput{
+					Sort: ptrVCSEventSortableField(test.sort),
+					PaginationOptions: &pagination.Options{
+						First: ptr.Int32(2),
+						After: after,
+					},
+				})
+				require.Nil(t, err)
+
+				for _, event := range result.VCSEvents {
+					actualVCSEventIDs = append(actualVCSEventIDs, event.Metadata.ID)
+				}
+
+				if !result.PageInfo.HasNextPage {
+					break
+				}
+
+				after, err = result.PageInfo.Cursor(&result.VCSEvents[len(result.VCSEvents)-1])
+				require.Nil(t, err)
+			}
+
+			assert.Equal(t, test.expectVCSEventIDs, actualVCSEventIDs)
+		})
+	}
+}
+
 func TestCreateEvent(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jackc/pgx/v4"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
@@ -22,8 +23,11 @@ type VCSProviders interface {
 	GetProviderByID(ctx context.Context, id string) (*models.VCSProvider, error)
 	GetProviderByOAuthState(ctx context.Context, state string) (*models.VCSProvider, error)
 	GetProviders(ctx context.Context, input *GetVCSProvidersInput) (*VCSProvidersResult, error)
+	GetProviderCount(ctx context.Context, filter *VCSProviderFilter) (int32, error)
 	CreateProvider(ctx context.Context, provider *models.VCSProvider) (*models.VCSProvider, error)
 	UpdateProvider(ctx context.Context, provider *models.VCSProvider) (*models.VCSProvider, error)
+	ReassignCreatedBy(ctx context.Context, fromSubject, toSubject string, namespacePath *string) (int, error)
+	GetCreatedBySubjects(ctx context.Context, namespacePath string) ([]string, error)
 	DeleteProvider(ctx context.Context, provider *models.VCSProvider) error
 }
 
@@ -32,6 +36,8 @@ type VCSProviderSortableField string
 
 // VCSProviderSortableField constants
 const (
+	VCSProviderSortableFieldNameAsc        VCSProviderSortableField = "NAME_ASC"
+	VCSProviderSortableFieldNameDesc       VCSProviderSortableField = "NAME_DESC"
 	VCSProviderSortableFieldCreatedAtAsc   VCSProviderSortableField = "CREATED_AT_ASC"
 	VCSProviderSortableFieldCreatedAtDesc  VCSProviderSortableField = "CREATED_AT_DESC"
 	VCSProviderSortableFieldUpdatedAtAsc   VCSProviderSortableField = "UPDATED_AT_ASC"
@@ -42,6 +48,8 @@ const (
 
 func (sf VCSProviderSortableField) getFieldDescriptor() *pagination.FieldDescriptor {
 	switch sf {
+	case VCSProviderSortableFieldNameAsc, VCSProviderSortableFieldNameDesc:
+		return &pagination.FieldDescriptor{Key: "name", Table: "vcs_providers", Col: "name"}
 	case VCSProviderSortableFieldCreatedAtAsc, VCSProviderSortableFieldCreatedAtDesc:
 		return &pagination.FieldDescriptor{Key: "created_at", Table: "vcs_providers", Col: "created_at"}
 	case VCSProviderSortableFieldUpdatedAtAsc, VCSProviderSortableFieldUpdatedAtDesc:
@@ -74,6 +82,8 @@ func (sf VCSProviderSortableField) getTransformFunc() pagination.SortTransformFu
 // VCSProviderFilter contains the supported fields for filtering VCSProvider resources.
 type VCSProviderFilter struct {
 	Search         *string
+	GroupID        *string
+	Name           *string
 	VCSProviderIDs []string
 	NamespacePaths []string
 }
@@ -141,59 +151,13 @@ func (vp *vcsProviders) GetProviders(ctx context.Context, input *GetVCSProviders
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
-	ex := goqu.And()
-
-	if input.Filter != nil {
-		if input.Filter.VCSProviderIDs != nil {
-			ex = ex.Append(goqu.I("vcs_providers.id").In(input.Filter.VCSProviderIDs))
-		}
-
-		if input.Filter.NamespacePaths != nil {
-			ex = ex.Append(goqu.I("namespaces.path").In(input.Filter.NamespacePaths))
-		}
-
-		if input.Filter.Search != nil {
-			search := *input.Filter.Search
-
-			lastDelimiterIndex := strings.LastIndex(search, "/")
-
-			if lastDelimiterIndex != -1 {
-				namespacePath := search[:lastDelimiterIndex]
-				vcsProviderName := search[lastDelimiterIndex+1:]
-
-				if vcsProviderName != "" {
-					// An OR condition is used here since the last component of the search path could be part of
-					// the namespace or it can be a VCS provider name prefix
-					ex = ex.Append(
-						goqu.Or(
-							goqu.And(
-								goqu.I("namespaces.path").Eq(namespacePath),
-								goqu.I("vcs_providers.name").ILike(vcsProviderName+"%"),
-							),
-							goqu.Or(
-								goqu.I("namespaces.path").ILike(search+"%"),
-								goqu.I("vcs_providers.name").ILike(vcsProviderName+"%"),
-							),
-						),
-					)
-				} else {
-					// We know the search is a namespace path since it ends with a "/"
-					ex = ex.Append(goqu.I("namespaces.path").ILike(namespacePath + "%"))
-				}
-			} else {
-				// We don't know if the search is for a namespace path or VCS provider name; therefore, use
-				// an OR condition to search both
-				ex = ex.Append(
-					goqu.Or(
-						goqu.I("namespaces.path").ILike(search+"%"),
-						goqu.I("vcs_providers.name").ILike(search+"%"),
-					),
-				)
-			}
-		}
-
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
 	}
 
+	ex := vcsProviderFilterExpression(input.Filter)
+
 	query := dialect.From("vcs_providers").
 		Select(vp.getSelectFields()...).
 		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"vcs_providers.group_id": goqu.I("namespaces.group_id")})).
@@ -253,6 +217,24 @@ func (vp *vcsProviders) GetProviders(ctx context.Context, input *GetVCSProviders
 	return &result, nil
 }
 
+func (vp *vcsProviders) GetProviderCount(ctx context.Context, filter *VCSProviderFilter) (int32, error) {
+	ctx, span := tracer.Start(ctx, "db.GetProviderCount")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	query := dialect.From("vcs_providers").
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"vcs_providers.group_id": goqu.I("namespaces.group_id")})).
+		Where(vcsProviderFilterExpression(filter))
+
+	count, err := pagination.Count(ctx, vp.dbClient.getConnection(ctx), query)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to count VCS providers")
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (vp *vcsProviders) CreateProvider(ctx context.Context, provider *models.VCSProvider) (*models.VCSProvider, error) {
 	ctx, span := tracer.Start(ctx, "db.CreateProvider")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -404,6 +386,34 @@ func (vp *vcsProviders) UpdateProvider(ctx context.Context, provider *models.VCS
 	return updatedProvider, nil
 }
 
+func (vp *vcsProviders) ReassignCreatedBy(ctx context.Context, fromSubject, toSubject string, namespacePath *string) (int, error) {
+	ctx, span := tracer.Start(ctx, "db.ReassignCreatedBy")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	count, err := reassignCreatedBy(ctx, vp.dbClient.getConnection(ctx), "vcs_providers", "group_id", fromSubject, toSubject, namespacePath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to reassign created by for VCS providers")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (vp *vcsProviders) GetCreatedBySubjects(ctx context.Context, namespacePath string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "db.GetCreatedBySubjects")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	subjects, err := getCreatedBySubjects(ctx, vp.dbClient.getConnection(ctx), "vcs_providers", "group_id", namespacePath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get created by subjects for VCS providers")
+		return nil, err
+	}
+
+	return subjects, nil
+}
+
 func (vp *vcsProviders) DeleteProvider(ctx context.Context, provider *models.VCSProvider) error {
 	ctx, span := tracer.Start(ctx, "db.DeleteProvider")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -534,3 +544,70 @@ func scanVCSProvider(row scanner, withResourcePath bool) (*models.VCSProvider, e
 
 	return vp, nil
 }
+
+// vcsProviderFilterExpression builds the where expression for a VCS provider filter
+func vcsProviderFilterExpression(filter *VCSProviderFilter) exp.ExpressionList {
+	ex := goqu.And()
+
+	if filter == nil {
+		return ex
+	}
+
+	if filter.VCSProviderIDs != nil {
+		ex = ex.Append(goqu.I("vcs_providers.id").In(filter.VCSProviderIDs))
+	}
+
+	if filter.GroupID != nil {
+		ex = ex.Append(goqu.I("vcs_providers.group_id").Eq(*filter.GroupID))
+	}
+
+	if filter.Name != nil {
+		ex = ex.Append(goqu.I("vcs_providers.name").Eq(*filter.Name))
+	}
+
+	if filter.NamespacePaths != nil {
+		ex = ex.Append(goqu.I("namespaces.path").In(filter.NamespacePaths))
+	}
+
+	if filter.Search != nil {
+		search := *filter.Search
+
+		lastDelimiterIndex := strings.LastIndex(search, "/")
+
+		if lastDelimiterIndex != -1 {
+			namespacePath := search[:lastDelimiterIndex]
+			vcsProviderName := search[lastDelimiterIndex+1:]
+
+			if vcsProviderName != "" {
+				// An OR condition is used here since the last component of the search path could be part of
+				// the namespace or it can be a VCS provider name prefix
+				ex = ex.Append(
+					goqu.Or(
+						goqu.And(
+							goqu.I("namespaces.path").Eq(namespacePath),
+							goqu.I("vcs_providers.name").ILike(vcsProviderName+"%"),
+						),
+						goqu.Or(
+							goqu.I("namespaces.path").ILike(search+"%"),
+							goqu.I("vcs_providers.name").ILike(vcsProviderName+"%"),
+						),
+					),
+				)
+			} else {
+				// We know the search is a namespace path since it ends with a "/"
+				ex = ex.Append(goqu.I("namespaces.path").ILike(namespacePath + "%"))
+			}
+		} else {
+			// We don't know if the search is for a namespace path or VCS provider name; therefore, use
+			// an OR condition to search both
+			ex = ex.Append(
+				goqu.Or(
+					goqu.I("namespaces.path").ILike(search+"%"),
+					goqu.I("vcs_providers.name").ILike(search+"%"),
+				),
+			)
+		}
+	}
+
+	return ex
+}
@@ -208,6 +208,7 @@ func TestVCSProviders_GetProviders(t *testing.T) {
 	// Sort by names.
 	sort.Sort(vcsProviderInfoNameSlice(allVCSProviderInfos))
 	allVCSProviderIDsByName := vcsProviderIDsFromVCSProviderInfos(allVCSProviderInfos)
+	reverseVCSProviderIDsByName := reverseStringSlice(allVCSProviderIDsByName)
 
 	dummyCursorFunc := func(cp pagination.CursorPaginatable) (*string, error) { return ptr.String("dummy-cursor-value"), nil }
 
@@ -285,6 +286,28 @@ func TestVCSProviders_GetProviders(t *testing.T) {
 			expectHasEndCursor:   true,
 		},
 
+		{
+			name: "sort in ascending order of name",
+			input: &GetVCSProvidersInput{
+				Sort: ptrVCSProviderSortableField(VCSProviderSortableFieldNameAsc),
+			},
+			expectVCSProviderIDs: allVCSProviderIDsByName,
+			expectPageInfo:       pagination.PageInfo{TotalCount: int32(len(allVCSProviderIDs)), Cursor: dummyCursorFunc},
+			expectHasStartCursor: true,
+			expectHasEndCursor:   true,
+		},
+
+		{
+			name: "sort in descending order of name",
+			input: &GetVCSProvidersInput{
+				Sort: ptrVCSProviderSortableField(VCSProviderSortableFieldNameDesc),
+			},
+			expectVCSProviderIDs: reverseVCSProviderIDsByName,
+			expectPageInfo:       pagination.PageInfo{TotalCount: int32(len(allVCSProviderIDs)), Cursor: dummyCursorFunc},
+			expectHasStartCursor: true,
+			expectHasEndCursor:   true,
+		},
+
 		{
 			name: "populated pagination, sort in ascending order of last update time, nil filter",
 			input: &GetVCSProvidersInput{
@@ -696,6 +719,66 @@ func TestVCSProviders_GetProviders(t *testing.T) {
 	}
 }
 
+func TestVCSProviders_GetProviderCount(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	warmupItems, err := createWarmupVCSProviders(ctx, testClient,
+		warmupVCSProviders{
+			standardWarmupGroupsForVCSProviders,
+			standardWarmupVCSProviders,
+		})
+	require.Nil(t, err)
+
+	type testCase struct {
+		filter *VCSProviderFilter
+		name   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "no filter",
+		},
+		{
+			name:   "filter by namespace path",
+			filter: &VCSProviderFilter{NamespacePaths: []string{warmupItems.groups[0].FullPath}},
+		},
+		{
+			name:   "filter by search",
+			filter: &VCSProviderFilter{Search: ptr.String("1")},
+		},
+		{
+			name:   "filter by provider IDs",
+			filter: &VCSProviderFilter{VCSProviderIDs: []string{warmupItems.providers[0].Metadata.ID}},
+		},
+		{
+			name: "filter by group ID and name",
+			filter: &VCSProviderFilter{
+				GroupID: &warmupItems.providers[0].GroupID,
+				Name:    &warmupItems.providers[0].Name,
+			},
+		},
+		{
+			name:   "filter matches nothing",
+			filter: &VCSProviderFilter{NamespacePaths: []string{"this-path-does-not-exist"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.VCSProviders.GetProviders(ctx, &GetVCSProvidersInput{Filter: test.filter})
+			require.Nil(t, err)
+
+			count, err := testClient.client.VCSProviders.GetProviderCount(ctx, test.filter)
+			require.Nil(t, err)
+
+			assert.Equal(t, result.PageInfo.TotalCount, count)
+			assert.Equal(t, int32(len(result.VCSProviders)), count)
+		})
+	}
+}
+
 func TestVCSProviders_CreateProvider(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -0,0 +1,174 @@
+package db
+
+//go:generate mockery --name WorkspaceRunLocks --inpackage --case underscore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jackc/pgx/v4"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
+)
+
+// WorkspaceRunLocks encapsulates the logic to access workspace run locks from the database
+type WorkspaceRunLocks interface {
+	GetWorkspaceRunLock(ctx context.Context, workspaceID string) (*models.WorkspaceRunLock, error)
+	CreateWorkspaceRunLock(ctx context.Context, lock *models.WorkspaceRunLock) (*models.WorkspaceRunLock, error)
+	DeleteWorkspaceRunLock(ctx context.Context, lock *models.WorkspaceRunLock) error
+}
+
+type workspaceRunLocks struct {
+	dbClient *Client
+}
+
+var workspaceRunLocksFieldList = append(metadataFieldList, "created_by", "workspace_id", "run_id")
+
+// NewWorkspaceRunLocks returns an instance of the WorkspaceRunLocks interface.
+func NewWorkspaceRunLocks(dbClient *Client) WorkspaceRunLocks {
+	return &workspaceRunLocks{dbClient: dbClient}
+}
+
+func (w *workspaceRunLocks) GetWorkspaceRunLock(ctx context.Context, workspaceID string) (*models.WorkspaceRunLock, error) {
+	ctx, span := tracer.Start(ctx, "db.GetWorkspaceRunLock")
+	defer span.End()
+
+	sql, args, err := dialect.From(goqu.T("workspace_run_locks")).
+		Prepared(true).
+		Select(w.getSelectFields()...).
+		Where(goqu.Ex{"workspace_run_locks.workspace_id": workspaceID}).
+		ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return nil, err
+	}
+
+	lock, err := scanWorkspaceRunLock(w.dbClient.getConnection(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+
+		if pgErr := asPgError(err); pgErr != nil {
+			if isInvalidIDViolation(pgErr) {
+				return nil, errors.Wrap(pgErr, "invalid ID; %s", pgErr.Message, errors.WithSpan(span), errors.WithErrorCode(errors.EInvalid))
+			}
+		}
+
+		tracing.RecordError(span, err, "failed to execute query")
+		return nil, err
+	}
+
+	return lock, nil
+}
+
+func (w *workspaceRunLocks) CreateWorkspaceRunLock(ctx context.Context, lock *models.WorkspaceRunLock) (*models.WorkspaceRunLock, error) {
+	ctx, span := tracer.Start(ctx, "db.CreateWorkspaceRunLock")
+	defer span.End()
+
+	timestamp := currentTime()
+
+	sql, args, err := dialect.Insert("workspace_run_locks").
+		Prepared(true).
+		Rows(goqu.Record{
+			"id":           newResourceID(),
+			"version":      initialResourceVersion,
+			"created_at":   timestamp,
+			"updated_at":   timestamp,
+			"created_by":   lock.CreatedBy,
+			"workspace_id": lock.WorkspaceID,
+			"run_id":       lock.RunID,
+		}).
+		Returning(workspaceRunLocksFieldList...).ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return nil, err
+	}
+
+	createdLock, err := scanWorkspaceRunLock(w.dbClient.getConnection(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if pgErr := asPgError(err); pgErr != nil {
+			if isUniqueViolation(pgErr) {
+				tracing.RecordError(span, nil, "workspace is already locked by a run")
+				return nil, errors.New("workspace is already locked by a run", errors.WithErrorCode(errors.EConflict))
+			}
+
+			if isForeignKeyViolation(pgErr) {
+				switch pgErr.ConstraintName {
+				case "fk_workspace_id":
+					tracing.RecordError(span, nil, "workspace does not exist")
+					return nil, errors.New("workspace does not exist", errors.WithErrorCode(errors.ENotFound))
+				case "fk_run_id":
+					tracing.RecordError(span, nil, "run does not exist")
+					return nil, errors.New("run does not exist", errors.WithErrorCode(errors.ENotFound))
+				}
+			}
+		}
+		tracing.RecordError(span, err, "failed to execute query")
+		return nil, err
+	}
+
+	return createdLock, nil
+}
+
+func (w *workspaceRunLocks) DeleteWorkspaceRunLock(ctx context.Context, lock *models.WorkspaceRunLock) error {
+	ctx, span := tracer.Start(ctx, "db.DeleteWorkspaceRunLock")
+	defer span.End()
+
+	sql, args, err := dialect.Delete("workspace_run_locks").
+		Prepared(true).
+		Where(
+			goqu.Ex{
+				"id":      lock.Metadata.ID,
+				"version": lock.Metadata.Version,
+			},
+		).Returning(workspaceRunLocksFieldList...).ToSQL()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate SQL")
+		return err
+	}
+
+	if _, err = scanWorkspaceRunLock(w.dbClient.getConnection(ctx).QueryRow(ctx, sql, args...)); err != nil {
+		if err == pgx.ErrNoRows {
+			tracing.RecordError(span, err, "optimistic lock error")
+			return ErrOptimisticLockError
+		}
+
+		tracing.RecordError(span, err, "failed to execute query")
+		return err
+	}
+
+	return nil
+}
+
+func (w *workspaceRunLocks) getSelectFields() []interface{} {
+	selectFields := []interface{}{}
+	for _, field := range workspaceRunLocksFieldList {
+		selectFields = append(selectFields, fmt.Sprintf("workspace_run_locks.%s", field))
+	}
+
+	return selectFields
+}
+
+func scanWorkspaceRunLock(row scanner) (*models.WorkspaceRunLock, error) {
+	lock := &models.WorkspaceRunLock{}
+
+	fields := []interface{}{
+		&lock.Metadata.ID,
+		&lock.Metadata.CreationTimestamp,
+		&lock.Metadata.LastUpdatedTimestamp,
+		&lock.Metadata.Version,
+		&lock.CreatedBy,
+		&lock.WorkspaceID,
+		&lock.RunID,
+	}
+
+	err := row.Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+
+	return lock, nil
+}
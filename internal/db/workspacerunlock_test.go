@@ -0,0 +1,262 @@
+//go:build integration
+
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
+)
+
+// createWorkspaceRunLockPrerequisites creates a group, workspace and two runs for the workspace run lock tests.
+func createWorkspaceRunLockPrerequisites(ctx context.Context, t *testing.T, testClient *testClient) (*models.Workspace, []models.Run) {
+	group, err := testClient.client.Groups.CreateGroup(ctx, &models.Group{
+		Description: "top level group for testing workspace run locks",
+		FullPath:    "top-level-group-for-workspace-run-locks",
+		CreatedBy:   "someone-g0",
+	})
+	require.Nil(t, err)
+
+	maxJobDuration := int32((time.Hour * 12).Minutes())
+	workspace, err := testClient.client.Workspaces.CreateWorkspace(ctx, &models.Workspace{
+		Description:    "workspace for testing workspace run locks",
+		FullPath:       "top-level-group-for-workspace-run-locks/workspace-for-run-locks",
+		GroupID:        group.Metadata.ID,
+		CreatedBy:      "someone-w0",
+		MaxJobDuration: &maxJobDuration,
+	})
+	require.Nil(t, err)
+
+	runs := []models.Run{}
+	for i := 0; i < 2; i++ {
+		run, err := testClient.client.Runs.CreateRun(ctx, &models.Run{
+			WorkspaceID: workspace.Metadata.ID,
+			CreatedBy:   "someone-r0",
+		})
+		require.Nil(t, err)
+
+		runs = append(runs, *run)
+	}
+
+	return workspace, runs
+}
+
+func TestGetWorkspaceRunLock(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	workspace, runs := createWorkspaceRunLockPrerequisites(ctx, t, testClient)
+
+	lock, err := testClient.client.WorkspaceRunLocks.CreateWorkspaceRunLock(ctx, &models.WorkspaceRunLock{
+		WorkspaceID: workspace.Metadata.ID,
+		RunID:       runs[0].Metadata.ID,
+		CreatedBy:   "someone-l0",
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		expectErrorCode errors.CodeType
+		name            string
+		workspaceID     string
+		expectLock      bool
+	}
+
+	testCases := []testCase{
+		{
+			name:        "get resource by workspace id",
+			workspaceID: workspace.Metadata.ID,
+			expectLock:  true,
+		},
+		{
+			name:        "resource with workspace id not found",
+			workspaceID: nonExistentID,
+		},
+		{
+			name:            "get resource with invalid workspace id will return an error",
+			workspaceID:     invalidID,
+			expectErrorCode: errors.EInvalid,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			actualLock, err := testClient.client.WorkspaceRunLocks.GetWorkspaceRunLock(ctx, test.workspaceID)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			require.Nil(t, err)
+
+			if test.expectLock {
+				require.NotNil(t, actualLock)
+				assert.Equal(t, lock, actualLock)
+			} else {
+				assert.Nil(t, actualLock)
+			}
+		})
+	}
+}
+
+func TestGetWorkspaceLockedByRunID(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	workspace, runs := createWorkspaceRunLockPrerequisites(ctx, t, testClient)
+
+	// Workspace is unlocked until a run lock is created.
+	unlockedWorkspace, err := testClient.client.Workspaces.GetWorkspaceByID(ctx, workspace.Metadata.ID)
+	require.Nil(t, err)
+	require.NotNil(t, unlockedWorkspace)
+	assert.Nil(t, unlockedWorkspace.LockedByRunID)
+	assert.False(t, unlockedWorkspace.IsLocked())
+
+	_, err = testClient.client.WorkspaceRunLocks.CreateWorkspaceRunLock(ctx, &models.WorkspaceRunLock{
+		WorkspaceID: workspace.Metadata.ID,
+		RunID:       runs[0].Metadata.ID,
+		CreatedBy:   "someone-l0",
+	})
+	require.Nil(t, err)
+
+	lockedWorkspace, err := testClient.client.Workspaces.GetWorkspaceByID(ctx, workspace.Metadata.ID)
+	require.Nil(t, err)
+	require.NotNil(t, lockedWorkspace)
+	require.NotNil(t, lockedWorkspace.LockedByRunID)
+	assert.Equal(t, runs[0].Metadata.ID, *lockedWorkspace.LockedByRunID)
+	assert.True(t, lockedWorkspace.IsLocked())
+
+	workspacesResult, err := testClient.client.Workspaces.GetWorkspaces(ctx, &GetWorkspacesInput{
+		Filter: &WorkspaceFilter{
+			WorkspaceIDs: []string{workspace.Metadata.ID},
+		},
+	})
+	require.Nil(t, err)
+	require.Len(t, workspacesResult.Workspaces, 1)
+	require.NotNil(t, workspacesResult.Workspaces[0].LockedByRunID)
+	assert.Equal(t, runs[0].Metadata.ID, *workspacesResult.Workspaces[0].LockedByRunID)
+}
+
+func TestCreateWorkspaceRunLock(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	workspace, runs := createWorkspaceRunLockPrerequisites(ctx, t, testClient)
+
+	type testCase struct {
+		expectErrorCode errors.CodeType
+		name            string
+		workspaceID     string
+		runID           string
+	}
+
+	testCases := []testCase{
+		{
+			name:        "successfully create resource",
+			workspaceID: workspace.Metadata.ID,
+			runID:       runs[0].Metadata.ID,
+		},
+		{
+			name:            "create will fail because the workspace is already locked",
+			workspaceID:     workspace.Metadata.ID,
+			runID:           runs[1].Metadata.ID,
+			expectErrorCode: errors.EConflict,
+		},
+		{
+			name:            "create will fail because the workspace does not exist",
+			workspaceID:     nonExistentID,
+			runID:           runs[1].Metadata.ID,
+			expectErrorCode: errors.ENotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			lock, err := testClient.client.WorkspaceRunLocks.CreateWorkspaceRunLock(ctx, &models.WorkspaceRunLock{
+				WorkspaceID: test.workspaceID,
+				RunID:       test.runID,
+				CreatedBy:   "someone-l0",
+			})
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			require.Nil(t, err)
+			require.NotNil(t, lock)
+			assert.Equal(t, test.workspaceID, lock.WorkspaceID)
+			assert.Equal(t, test.runID, lock.RunID)
+		})
+	}
+}
+
+func TestDeleteWorkspaceRunLock(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	workspace, runs := createWorkspaceRunLockPrerequisites(ctx, t, testClient)
+
+	lock, err := testClient.client.WorkspaceRunLocks.CreateWorkspaceRunLock(ctx, &models.WorkspaceRunLock{
+		WorkspaceID: workspace.Metadata.ID,
+		RunID:       runs[0].Metadata.ID,
+		CreatedBy:   "someone-l0",
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		name            string
+		expectErrorCode errors.CodeType
+		id              string
+		version         int
+	}
+
+	testCases := []testCase{
+		{
+			name:            "delete will fail because resource version doesn't match",
+			id:              lock.Metadata.ID,
+			expectErrorCode: errors.EOptimisticLock,
+			version:         -1,
+		},
+		{
+			name:    "successfully delete resource",
+			id:      lock.Metadata.ID,
+			version: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := testClient.client.WorkspaceRunLocks.DeleteWorkspaceRunLock(ctx, &models.WorkspaceRunLock{
+				Metadata: models.ResourceMetadata{
+					ID:      test.id,
+					Version: test.version,
+				},
+			})
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			require.Nil(t, err)
+
+			// A new lock can be acquired once the previous one is released.
+			_, err = testClient.client.WorkspaceRunLocks.CreateWorkspaceRunLock(ctx, &models.WorkspaceRunLock{
+				WorkspaceID: workspace.Metadata.ID,
+				RunID:       runs[1].Metadata.ID,
+				CreatedBy:   "someone-l1",
+			})
+			require.Nil(t, err)
+		})
+	}
+}
@@ -5,6 +5,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -23,11 +24,14 @@ type Workspaces interface {
 	GetWorkspaceByFullPath(ctx context.Context, path string) (*models.Workspace, error)
 	GetWorkspaceByID(ctx context.Context, id string) (*models.Workspace, error)
 	GetWorkspaces(ctx context.Context, input *GetWorkspacesInput) (*WorkspacesResult, error)
+	GetWorkspaceCount(ctx context.Context, filter *WorkspaceFilter) (int32, error)
 	UpdateWorkspace(ctx context.Context, workspace *models.Workspace) (*models.Workspace, error)
 	CreateWorkspace(ctx context.Context, workspace *models.Workspace) (*models.Workspace, error)
 	DeleteWorkspace(ctx context.Context, workspace *models.Workspace) error
 	GetWorkspacesForManagedIdentity(ctx context.Context, managedIdentityID string) ([]models.Workspace, error)
 	MigrateWorkspace(ctx context.Context, workspace *models.Workspace, newParentGroup *models.Group) (*models.Workspace, error)
+	// GetCreatedBySubjects returns the distinct subjects that created the workspaces in or under a namespace path
+	GetCreatedBySubjects(ctx context.Context, namespacePath string) ([]string, error)
 }
 
 // WorkspaceSortableField represents the fields that a workspace can be sorted by
@@ -39,6 +43,8 @@ const (
 	WorkspaceSortableFieldFullPathDesc  WorkspaceSortableField = "FULL_PATH_DESC"
 	WorkspaceSortableFieldUpdatedAtAsc  WorkspaceSortableField = "UPDATED_AT_ASC"
 	WorkspaceSortableFieldUpdatedAtDesc WorkspaceSortableField = "UPDATED_AT_DESC"
+	WorkspaceSortableFieldCreatedAtAsc  WorkspaceSortableField = "CREATED_AT_ASC"
+	WorkspaceSortableFieldCreatedAtDesc WorkspaceSortableField = "CREATED_AT_DESC"
 )
 
 func (gs WorkspaceSortableField) getFieldDescriptor() *pagination.FieldDescriptor {
@@ -47,6 +53,8 @@ func (gs WorkspaceSortableField) getFieldDescriptor() *pagination.FieldDescripto
 		return &pagination.FieldDescriptor{Key: "full_path", Table: "namespaces", Col: "path"}
 	case WorkspaceSortableFieldUpdatedAtAsc, WorkspaceSortableFieldUpdatedAtDesc:
 		return &pagination.FieldDescriptor{Key: "updated_at", Table: "workspaces", Col: "updated_at"}
+	case WorkspaceSortableFieldCreatedAtAsc, WorkspaceSortableFieldCreatedAtDesc:
+		return &pagination.FieldDescriptor{Key: "created_at", Table: "workspaces", Col: "created_at"}
 	default:
 		return nil
 	}
@@ -62,11 +70,18 @@ func (gs WorkspaceSortableField) getSortDirection() pagination.SortDirection {
 // WorkspaceFilter contains the supported fields for filtering Workspace resources
 type WorkspaceFilter struct {
 	GroupID                   *string
+	PathPrefix                *string
 	UserMemberID              *string
 	ServiceAccountMemberID    *string
 	Search                    *string
 	AssignedManagedIdentityID *string
+	Environment               *string
 	WorkspaceIDs              []string
+	// UsesManagedIdentityType matches workspaces with at least one assigned managed identity of this type
+	UsesManagedIdentityType *models.ManagedIdentityType
+	// HasManagedIdentity matches workspaces with at least one assigned managed identity when true
+	// and workspaces with no assigned managed identities when false
+	HasManagedIdentity *bool
 }
 
 // GetWorkspacesInput is the input for listing workspaces
@@ -102,6 +117,9 @@ var workspaceFieldList = append(
 	"created_by",
 	"terraform_version",
 	"prevent_destroy_plan",
+	"environment",
+	"auto_apply",
+	"allowed_managed_identity_types",
 )
 
 // NewWorkspaces returns an instance of the Workspaces interface
@@ -130,46 +148,19 @@ func (w *workspaces) GetWorkspaces(ctx context.Context, input *GetWorkspacesInpu
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
-	ex := goqu.And()
-
-	if input.Filter != nil {
-		if input.Filter.WorkspaceIDs != nil {
-			// This check avoids an SQL syntax error if an empty slice is provided.
-			if len(input.Filter.WorkspaceIDs) > 0 {
-				ex = ex.Append(goqu.I("workspaces.id").In(input.Filter.WorkspaceIDs))
-			}
-		}
-
-		if input.Filter.GroupID != nil {
-			ex = ex.Append(goqu.I("workspaces.group_id").Eq(*input.Filter.GroupID))
-		}
-
-		if input.Filter.UserMemberID != nil {
-			ex = ex.Append(namespaceMembershipFilterQuery("namespace_memberships.user_id", *input.Filter.UserMemberID))
-		}
-
-		if input.Filter.ServiceAccountMemberID != nil {
-			ex = ex.Append(namespaceMembershipFilterQuery("namespace_memberships.service_account_id", *input.Filter.ServiceAccountMemberID))
-		}
-
-		if input.Filter.Search != nil && *input.Filter.Search != "" {
-			ex = ex.Append(goqu.I("namespaces.path").ILike("%" + *input.Filter.Search + "%"))
-		}
+	if err := input.PaginationOptions.Validate(); err != nil {
+		tracing.RecordError(span, err, "invalid pagination options")
+		return nil, err
 	}
 
+	ex := workspaceFilterExpression(input.Filter)
+
 	query := dialect.From(goqu.T("workspaces")).
 		Select(w.getSelectFields()...).
-		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"workspaces.id": goqu.I("namespaces.workspace_id")}))
-
-	// Since managed identities is a many to many relationship only join them when we are looking for exactly one.
-	// Otherwise duplicates will result.
-	if input.Filter != nil && input.Filter.AssignedManagedIdentityID != nil {
-		query = query.InnerJoin(goqu.T("workspace_managed_identity_relation"), goqu.On(goqu.Ex{"workspaces.id": goqu.I("workspace_managed_identity_relation.workspace_id")}))
-
-		ex = ex.Append(goqu.Ex{"workspace_managed_identity_relation.managed_identity_id": input.Filter.AssignedManagedIdentityID})
-	}
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"workspaces.id": goqu.I("namespaces.workspace_id")})).
+		LeftJoin(goqu.T("workspace_run_locks"), goqu.On(goqu.Ex{"workspaces.id": goqu.I("workspace_run_locks.workspace_id")}))
 
-	query = query.Where(ex)
+	query = joinWorkspaceFilterTables(query, input.Filter).Where(ex)
 
 	sortDirection := pagination.AscSort
 
@@ -222,27 +213,55 @@ func (w *workspaces) GetWorkspaces(ctx context.Context, input *GetWorkspacesInpu
 	return &result, nil
 }
 
+func (w *workspaces) GetWorkspaceCount(ctx context.Context, filter *WorkspaceFilter) (int32, error) {
+	ctx, span := tracer.Start(ctx, "db.GetWorkspaceCount")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	query := dialect.From(goqu.T("workspaces")).
+		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"workspaces.id": goqu.I("namespaces.workspace_id")}))
+
+	query = joinWorkspaceFilterTables(query, filter).Where(workspaceFilterExpression(filter))
+
+	count, err := pagination.Count(ctx, w.dbClient.getConnection(ctx), query)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to count workspaces")
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (w *workspaces) UpdateWorkspace(ctx context.Context, workspace *models.Workspace) (*models.Workspace, error) {
 	ctx, span := tracer.Start(ctx, "db.UpdateWorkspace")
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	allowedManagedIdentityTypes, err := json.Marshal(workspaceAllowedManagedIdentityTypes(workspace.AllowedManagedIdentityTypes))
+	if err != nil {
+		tracing.RecordError(span, err, "failed to marshal allowed managed identity types")
+		return nil, err
+	}
+
 	timestamp := currentTime()
 
 	sql, args, err := dialect.Update("workspaces").
 		Prepared(true).
 		Set(
 			goqu.Record{
-				"version":                  goqu.L("? + ?", goqu.C("version"), 1),
-				"updated_at":               timestamp,
-				"description":              nullableString(workspace.Description),
-				"current_job_id":           nullableString(workspace.CurrentJobID),
-				"current_state_version_id": nullableString(workspace.CurrentStateVersionID),
-				"dirty_state":              workspace.DirtyState,
-				"locked":                   workspace.Locked,
-				"max_job_duration":         workspace.MaxJobDuration,
-				"terraform_version":        workspace.TerraformVersion,
-				"prevent_destroy_plan":     workspace.PreventDestroyPlan,
+				"version":                        goqu.L("? + ?", goqu.C("version"), 1),
+				"updated_at":                     timestamp,
+				"description":                    nullableString(workspace.Description),
+				"current_job_id":                 nullableString(workspace.CurrentJobID),
+				"current_state_version_id":       nullableString(workspace.CurrentStateVersionID),
+				"dirty_state":                    workspace.DirtyState,
+				"locked":                         workspace.Locked,
+				"max_job_duration":               workspace.MaxJobDuration,
+				"terraform_version":              workspace.TerraformVersion,
+				"prevent_destroy_plan":           workspace.PreventDestroyPlan,
+				"environment":                    nullableString(workspace.Environment),
+				"auto_apply":                     workspace.AutoApply,
+				"allowed_managed_identity_types": allowedManagedIdentityTypes,
 			},
 		).Where(goqu.Ex{"id": workspace.Metadata.ID, "version": workspace.Metadata.Version}).Returning(workspaceFieldList...).ToSQL()
 	if err != nil {
@@ -291,26 +310,35 @@ func (w *workspaces) CreateWorkspace(ctx context.Context, workspace *models.Work
 		}
 	}()
 
+	allowedManagedIdentityTypes, err := json.Marshal(workspaceAllowedManagedIdentityTypes(workspace.AllowedManagedIdentityTypes))
+	if err != nil {
+		tracing.RecordError(span, err, "failed to marshal allowed managed identity types")
+		return nil, err
+	}
+
 	timestamp := currentTime()
 
 	sql, args, err := dialect.Insert("workspaces").
 		Prepared(true).
 		Rows(goqu.Record{
-			"id":                       newResourceID(),
-			"version":                  initialResourceVersion,
-			"created_at":               timestamp,
-			"updated_at":               timestamp,
-			"name":                     workspace.Name,
-			"group_id":                 workspace.GroupID,
-			"description":              nullableString(workspace.Description),
-			"current_job_id":           nullableString(workspace.CurrentJobID),
-			"current_state_version_id": nullableString(workspace.CurrentStateVersionID),
-			"dirty_state":              workspace.DirtyState,
-			"locked":                   workspace.Locked,
-			"max_job_duration":         workspace.MaxJobDuration,
-			"created_by":               workspace.CreatedBy,
-			"terraform_version":        workspace.TerraformVersion,
-			"prevent_destroy_plan":     workspace.PreventDestroyPlan,
+			"id":                             newResourceID(),
+			"version":                        initialResourceVersion,
+			"created_at":                     timestamp,
+			"updated_at":                     timestamp,
+			"name":                           workspace.Name,
+			"group_id":                       workspace.GroupID,
+			"description":                    nullableString(workspace.Description),
+			"current_job_id":                 nullableString(workspace.CurrentJobID),
+			"current_state_version_id":       nullableString(workspace.CurrentStateVersionID),
+			"dirty_state":                    workspace.DirtyState,
+			"locked":                         workspace.Locked,
+			"max_job_duration":               workspace.MaxJobDuration,
+			"created_by":                     workspace.CreatedBy,
+			"terraform_version":              workspace.TerraformVersion,
+			"prevent_destroy_plan":           workspace.PreventDestroyPlan,
+			"environment":                    nullableString(workspace.Environment),
+			"auto_apply":                     workspace.AutoApply,
+			"allowed_managed_identity_types": allowedManagedIdentityTypes,
 		}).
 		Returning(workspaceFieldList...).ToSQL()
 	if err != nil {
@@ -403,6 +431,7 @@ func (w *workspaces) GetWorkspacesForManagedIdentity(ctx context.Context, manage
 		Select(w.getSelectFields()...).
 		InnerJoin(goqu.T("workspace_managed_identity_relation"), goqu.On(goqu.Ex{"workspaces.id": goqu.I("workspace_managed_identity_relation.workspace_id")})).
 		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"workspaces.id": goqu.I("namespaces.workspace_id")})).
+		LeftJoin(goqu.T("workspace_run_locks"), goqu.On(goqu.Ex{"workspaces.id": goqu.I("workspace_run_locks.workspace_id")})).
 		Where(goqu.Ex{"workspace_managed_identity_relation.managed_identity_id": managedIdentityID}).ToSQL()
 	if err != nil {
 		tracing.RecordError(span, err, "failed to generate SQL")
@@ -585,11 +614,26 @@ func (w *workspaces) MigrateWorkspace(ctx context.Context, workspace *models.Wor
 	return migratedWorkspace, nil
 }
 
+func (w *workspaces) GetCreatedBySubjects(ctx context.Context, namespacePath string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "db.GetCreatedBySubjects")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	subjects, err := getCreatedBySubjects(ctx, w.dbClient.getConnection(ctx), "workspaces", "group_id", namespacePath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get created by subjects for workspaces")
+		return nil, err
+	}
+
+	return subjects, nil
+}
+
 func (w *workspaces) getWorkspace(ctx context.Context, exp goqu.Ex) (*models.Workspace, error) {
 	query := dialect.From(goqu.T("workspaces")).
 		Prepared(true).
 		Select(w.getSelectFields()...).
 		InnerJoin(goqu.T("namespaces"), goqu.On(goqu.Ex{"workspaces.id": goqu.I("namespaces.workspace_id")})).
+		LeftJoin(goqu.T("workspace_run_locks"), goqu.On(goqu.Ex{"workspaces.id": goqu.I("workspace_run_locks.workspace_id")})).
 		Where(exp)
 
 	sql, args, err := query.ToSQL()
@@ -646,15 +690,19 @@ func (w *workspaces) getSelectFields() []interface{} {
 		selectFields = append(selectFields, fmt.Sprintf("workspaces.%s", field))
 	}
 
-	selectFields = append(selectFields, "namespaces.path")
+	selectFields = append(selectFields, "namespaces.path", "workspace_run_locks.run_id")
 
 	return selectFields
 }
 
+// scanWorkspace scans a workspace row; withFullPath must be true when the row contains the additional
+// fields that are returned by getSelectFields.
 func scanWorkspace(row scanner, withFullPath bool) (*models.Workspace, error) {
 	var description sql.NullString
 	var currentJobID sql.NullString
 	var currentStateVersionID sql.NullString
+	var lockedByRunID sql.NullString
+	var environment sql.NullString
 
 	ws := &models.Workspace{}
 
@@ -674,10 +722,13 @@ func scanWorkspace(row scanner, withFullPath bool) (*models.Workspace, error) {
 		&ws.CreatedBy,
 		&ws.TerraformVersion,
 		&ws.PreventDestroyPlan,
+		&environment,
+		&ws.AutoApply,
+		&ws.AllowedManagedIdentityTypes,
 	}
 
 	if withFullPath {
-		fields = append(fields, &ws.FullPath)
+		fields = append(fields, &ws.FullPath, &lockedByRunID)
 	}
 
 	err := row.Scan(fields...)
@@ -697,5 +748,106 @@ func scanWorkspace(row scanner, withFullPath bool) (*models.Workspace, error) {
 		ws.CurrentStateVersionID = currentStateVersionID.String
 	}
 
+	if lockedByRunID.Valid {
+		ws.LockedByRunID = &lockedByRunID.String
+	}
+
+	if environment.Valid {
+		ws.Environment = environment.String
+	}
+
 	return ws, nil
 }
+
+// workspaceAllowedManagedIdentityTypes returns an empty list instead of nil so the allowed types are never stored as JSON null
+func workspaceAllowedManagedIdentityTypes(types []models.ManagedIdentityType) []models.ManagedIdentityType {
+	if types == nil {
+		return []models.ManagedIdentityType{}
+	}
+	return types
+}
+
+// workspaceFilterExpression builds the where expression for a workspace filter
+func workspaceFilterExpression(filter *WorkspaceFilter) exp.ExpressionList {
+	ex := goqu.And()
+
+	if filter == nil {
+		return ex
+	}
+
+	if filter.WorkspaceIDs != nil {
+		// This check avoids an SQL syntax error if an empty slice is provided.
+		if len(filter.WorkspaceIDs) > 0 {
+			ex = ex.Append(goqu.I("workspaces.id").In(filter.WorkspaceIDs))
+		}
+	}
+
+	if filter.GroupID != nil {
+		ex = ex.Append(goqu.I("workspaces.group_id").Eq(*filter.GroupID))
+	}
+
+	if filter.PathPrefix != nil {
+		// The trailing slash ensures only descendants match, i.e. "a/b" won't match "a/bc".
+		ex = ex.Append(goqu.I("namespaces.path").Like(escapeLikePattern(strings.TrimSuffix(*filter.PathPrefix, "/")) + "/%"))
+	}
+
+	if filter.UserMemberID != nil {
+		ex = ex.Append(namespaceMembershipFilterQuery("namespace_memberships.user_id", *filter.UserMemberID))
+	}
+
+	if filter.ServiceAccountMemberID != nil {
+		ex = ex.Append(namespaceMembershipFilterQuery("namespace_memberships.service_account_id", *filter.ServiceAccountMemberID))
+	}
+
+	if filter.UsesManagedIdentityType != nil {
+		// A subquery is used since joining the assignments directly would return duplicates.
+		// An alias uses the type of its alias source.
+		ex = ex.Append(goqu.I("workspaces.id").In(
+			dialect.From(goqu.T("workspace_managed_identity_relation")).
+				Select("workspace_managed_identity_relation.workspace_id").
+				InnerJoin(goqu.T("managed_identities").As("identities"),
+					goqu.On(goqu.Ex{"workspace_managed_identity_relation.managed_identity_id": goqu.I("identities.id")})).
+				LeftJoin(goqu.T("managed_identities").As("alias_sources"),
+					goqu.On(goqu.Ex{"identities.alias_source_id": goqu.I("alias_sources.id")})).
+				Where(goqu.COALESCE(goqu.I("alias_sources.type"), goqu.I("identities.type")).
+					Eq(string(*filter.UsesManagedIdentityType))),
+		))
+	}
+
+	if filter.HasManagedIdentity != nil {
+		assignments := dialect.From(goqu.T("workspace_managed_identity_relation")).
+			Select(goqu.L("1")).
+			Where(goqu.Ex{"workspace_managed_identity_relation.workspace_id": goqu.I("workspaces.id")})
+
+		if *filter.HasManagedIdentity {
+			ex = ex.Append(goqu.L("EXISTS ?", assignments))
+		} else {
+			ex = ex.Append(goqu.L("NOT EXISTS ?", assignments))
+		}
+	}
+
+	if filter.Environment != nil {
+		ex = ex.Append(goqu.I("workspaces.environment").Eq(*filter.Environment))
+	}
+
+	if filter.Search != nil && *filter.Search != "" {
+		ex = ex.Append(goqu.I("namespaces.path").ILike("%" + *filter.Search + "%"))
+	}
+
+	if filter.AssignedManagedIdentityID != nil {
+		ex = ex.Append(goqu.Ex{"workspace_managed_identity_relation.managed_identity_id": filter.AssignedManagedIdentityID})
+	}
+
+	return ex
+}
+
+// joinWorkspaceFilterTables adds the joins needed by the workspace filter expression
+func joinWorkspaceFilterTables(query *goqu.SelectDataset, filter *WorkspaceFilter) *goqu.SelectDataset {
+	// Since managed identities is a many to many relationship only join them when we are looking for exactly one.
+	// Otherwise duplicates will result.
+	if filter != nil && filter.AssignedManagedIdentityID != nil {
+		query = query.InnerJoin(goqu.T("workspace_managed_identity_relation"), goqu.On(goqu.Ex{"workspaces.id": goqu.I("workspace_managed_identity_relation.workspace_id")}))
+	}
+
+	return query
+}
@@ -1232,6 +1232,56 @@ func TestGetWorkspaces(t *testing.T) {
 	}
 }
 
+func TestGetWorkspaceCount(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	createdWarmupGroups, createdWarmupWorkspaces, err := createWarmupWorkspaces(ctx, testClient,
+		standardWarmupGroupsForWorkspaces, standardWarmupWorkspaces)
+	require.Nil(t, err)
+
+	type testCase struct {
+		filter *WorkspaceFilter
+		name   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "no filter",
+		},
+		{
+			name:   "filter by group ID",
+			filter: &WorkspaceFilter{GroupID: &createdWarmupGroups[0].Metadata.ID},
+		},
+		{
+			name:   "filter by workspace IDs",
+			filter: &WorkspaceFilter{WorkspaceIDs: []string{createdWarmupWorkspaces[0].Metadata.ID}},
+		},
+		{
+			name:   "filter by search",
+			filter: &WorkspaceFilter{Search: ptr.String("1")},
+		},
+		{
+			name:   "filter matches nothing",
+			filter: &WorkspaceFilter{Search: ptr.String("this-path-does-not-exist")},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.Workspaces.GetWorkspaces(ctx, &GetWorkspacesInput{Filter: test.filter})
+			require.Nil(t, err)
+
+			count, err := testClient.client.Workspaces.GetWorkspaceCount(ctx, test.filter)
+			require.Nil(t, err)
+
+			assert.Equal(t, result.PageInfo.TotalCount, count)
+			assert.Equal(t, int32(len(result.Workspaces)), count)
+		})
+	}
+}
+
 func TestUpdateWorkspace(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -1412,6 +1462,7 @@ func TestCreateWorkspace(t *testing.T) {
 				DirtyState:     true,
 				MaxJobDuration: ptr.Int32(954),
 				CreatedBy:      "full-workspace-creator",
+				Environment:    "production",
 			},
 			expectCreated: &models.Workspace{
 				Metadata: models.ResourceMetadata{
@@ -1427,6 +1478,7 @@ func TestCreateWorkspace(t *testing.T) {
 				DirtyState:            true,
 				MaxJobDuration:        ptr.Int32(954),
 				CreatedBy:             "full-workspace-creator",
+				Environment:           "production",
 			},
 		},
 
@@ -1497,6 +1549,262 @@ func TestCreateWorkspace(t *testing.T) {
 	}
 }
 
+func TestGetWorkspacesWithEnvironmentFilter(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	createdWarmupGroups, _, err := createWarmupWorkspaces(ctx, testClient,
+		standardWarmupGroupsForWorkspaces[:1], []models.Workspace{})
+	require.Nil(t, err)
+
+	groupID := createdWarmupGroups[0].Metadata.ID
+
+	for _, toCreate := range []models.Workspace{
+		{Name: "production-workspace", Environment: "production"},
+		{Name: "staging-workspace", Environment: "staging"},
+		{Name: "untagged-workspace"},
+	} {
+		toCreate.GroupID = groupID
+		toCreate.MaxJobDuration = ptr.Int32(60)
+		_, err = testClient.client.Workspaces.CreateWorkspace(ctx, &toCreate)
+		require.Nil(t, err)
+	}
+
+	// Move the untagged workspace into the staging environment.
+	untagged, err := testClient.client.Workspaces.GetWorkspaceByFullPath(ctx, createdWarmupGroups[0].FullPath+"/untagged-workspace")
+	require.Nil(t, err)
+	require.NotNil(t, untagged)
+
+	untagged.Environment = "staging"
+	updated, err := testClient.client.Workspaces.UpdateWorkspace(ctx, untagged)
+	require.Nil(t, err)
+	assert.Equal(t, "staging", updated.Environment)
+
+	type testCase struct {
+		name            string
+		environment     string
+		expectWorkspace []string
+	}
+
+	testCases := []testCase{
+		{
+			name:            "production workspaces",
+			environment:     "production",
+			expectWorkspace: []string{"production-workspace"},
+		},
+		{
+			name:            "staging workspaces",
+			environment:     "staging",
+			expectWorkspace: []string{"staging-workspace", "untagged-workspace"},
+		},
+		{
+			name:            "no workspaces in environment",
+			environment:     "development",
+			expectWorkspace: []string{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.Workspaces.GetWorkspaces(ctx, &GetWorkspacesInput{
+				Filter: &WorkspaceFilter{
+					Environment: &test.environment,
+				},
+			})
+			require.Nil(t, err)
+
+			actualNames := []string{}
+			for _, ws := range result.Workspaces {
+				assert.Equal(t, test.environment, ws.Environment)
+				actualNames = append(actualNames, ws.Name)
+			}
+
+			sort.Strings(actualNames)
+			assert.Equal(t, test.expectWorkspace, actualNames)
+		})
+	}
+}
+
+func TestGetWorkspacesWithManagedIdentityTypeFilter(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	createdWarmupGroups, _, err := createWarmupWorkspaces(ctx, testClient,
+		standardWarmupGroupsForWorkspaces[:1], []models.Workspace{})
+	require.Nil(t, err)
+
+	groupID := createdWarmupGroups[0].Metadata.ID
+
+	workspaceIDs := map[string]string{}
+	for _, name := range []string{"aws-workspace", "mixed-workspace", "alias-workspace", "unassigned-workspace"} {
+		ws, cErr := testClient.client.Workspaces.CreateWorkspace(ctx, &models.Workspace{
+			Name:           name,
+			GroupID:        groupID,
+			MaxJobDuration: ptr.Int32(60),
+		})
+		require.Nil(t, cErr)
+		workspaceIDs[name] = ws.Metadata.ID
+	}
+
+	createIdentity := func(identity *models.ManagedIdentity) *models.ManagedIdentity {
+		identity.GroupID = groupID
+		created, cErr := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, identity)
+		require.Nil(t, cErr)
+		return created
+	}
+
+	awsIdentity1 := createIdentity(&models.ManagedIdentity{Name: "aws-1", Type: models.ManagedIdentityAWSFederated, Data: []byte("data")})
+	awsIdentity2 := createIdentity(&models.ManagedIdentity{Name: "aws-2", Type: models.ManagedIdentityAWSFederated, Data: []byte("data")})
+	azureIdentity := createIdentity(&models.ManagedIdentity{Name: "azure", Type: models.ManagedIdentityAzureFederated, Data: []byte("data")})
+	awsAlias := createIdentity(&models.ManagedIdentity{Name: "aws-alias", AliasSourceID: &awsIdentity1.Metadata.ID})
+
+	for _, assignment := range []struct {
+		identityID    string
+		workspaceName string
+	}{
+		// Two identities of the same type must not return the workspace twice.
+		{identityID: awsIdentity1.Metadata.ID, workspaceName: "aws-workspace"},
+		{identityID: awsIdentity2.Metadata.ID, workspaceName: "aws-workspace"},
+		{identityID: awsIdentity2.Metadata.ID, workspaceName: "mixed-workspace"},
+		{identityID: azureIdentity.Metadata.ID, workspaceName: "mixed-workspace"},
+		{identityID: awsAlias.Metadata.ID, workspaceName: "alias-workspace"},
+	} {
+		require.Nil(t, testClient.client.ManagedIdentities.AddManagedIdentityToWorkspace(ctx,
+			assignment.identityID, workspaceIDs[assignment.workspaceName]))
+	}
+
+	type testCase struct {
+		name            string
+		identityType    models.ManagedIdentityType
+		expectWorkspace []string
+	}
+
+	testCases := []testCase{
+		{
+			name:            "workspaces using aws identities including aliases",
+			identityType:    models.ManagedIdentityAWSFederated,
+			expectWorkspace: []string{"alias-workspace", "aws-workspace", "mixed-workspace"},
+		},
+		{
+			name:            "workspaces using azure identities",
+			identityType:    models.ManagedIdentityAzureFederated,
+			expectWorkspace: []string{"mixed-workspace"},
+		},
+		{
+			name:            "no workspaces use tharsis identities",
+			identityType:    models.ManagedIdentityTharsisFederated,
+			expectWorkspace: []string{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.Workspaces.GetWorkspaces(ctx, &GetWorkspacesInput{
+				Filter: &WorkspaceFilter{
+					UsesManagedIdentityType: &test.identityType,
+				},
+			})
+			require.Nil(t, err)
+
+			actualNames := []string{}
+			for _, ws := range result.Workspaces {
+				actualNames = append(actualNames, ws.Name)
+			}
+
+			sort.Strings(actualNames)
+			assert.Equal(t, test.expectWorkspace, actualNames)
+		})
+	}
+}
+
+func TestGetWorkspacesWithHasManagedIdentityFilter(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	createdWarmupGroups, _, err := createWarmupWorkspaces(ctx, testClient,
+		standardWarmupGroupsForWorkspaces[:1], []models.Workspace{})
+	require.Nil(t, err)
+
+	groupID := createdWarmupGroups[0].Metadata.ID
+
+	workspaceIDs := map[string]string{}
+	for _, name := range []string{"single-identity-workspace", "multi-identity-workspace", "unassigned-workspace-1", "unassigned-workspace-2"} {
+		ws, cErr := testClient.client.Workspaces.CreateWorkspace(ctx, &models.Workspace{
+			Name:           name,
+			GroupID:        groupID,
+			MaxJobDuration: ptr.Int32(60),
+		})
+		require.Nil(t, cErr)
+		workspaceIDs[name] = ws.Metadata.ID
+	}
+
+	identityIDs := []string{}
+	for _, name := range []string{"identity-1", "identity-2"} {
+		identity, cErr := testClient.client.ManagedIdentities.CreateManagedIdentity(ctx, &models.ManagedIdentity{
+			Name:    name,
+			GroupID: groupID,
+			Type:    models.ManagedIdentityAWSFederated,
+			Data:    []byte("data"),
+		})
+		require.Nil(t, cErr)
+		identityIDs = append(identityIDs, identity.Metadata.ID)
+	}
+
+	for _, assignment := range []struct {
+		identityID    string
+		workspaceName string
+	}{
+		{identityID: identityIDs[0], workspaceName: "single-identity-workspace"},
+		// Two assignments must not return the workspace twice.
+		{identityID: identityIDs[0], workspaceName: "multi-identity-workspace"},
+		{identityID: identityIDs[1], workspaceName: "multi-identity-workspace"},
+	} {
+		require.Nil(t, testClient.client.ManagedIdentities.AddManagedIdentityToWorkspace(ctx,
+			assignment.identityID, workspaceIDs[assignment.workspaceName]))
+	}
+
+	type testCase struct {
+		name               string
+		hasManagedIdentity bool
+		expectWorkspace    []string
+	}
+
+	testCases := []testCase{
+		{
+			name:               "workspaces with assigned managed identities",
+			hasManagedIdentity: true,
+			expectWorkspace:    []string{"multi-identity-workspace", "single-identity-workspace"},
+		},
+		{
+			name:               "workspaces without assigned managed identities",
+			hasManagedIdentity: false,
+			expectWorkspace:    []string{"unassigned-workspace-1", "unassigned-workspace-2"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testClient.client.Workspaces.GetWorkspaces(ctx, &GetWorkspacesInput{
+				Filter: &WorkspaceFilter{
+					HasManagedIdentity: &test.hasManagedIdentity,
+				},
+			})
+			require.Nil(t, err)
+
+			actualNames := []string{}
+			for _, ws := range result.Workspaces {
+				actualNames = append(actualNames, ws.Name)
+			}
+
+			sort.Strings(actualNames)
+			assert.Equal(t, test.expectWorkspace, actualNames)
+		})
+	}
+}
+
 func TestDeleteWorkspace(t *testing.T) {
 	ctx := context.Background()
 	testClient := newTestClient(ctx, t)
@@ -1962,6 +2270,61 @@ func TestMigrateWorkspace(t *testing.T) {
 	}
 }
 
+func TestWorkspacesGetCreatedBySubjects(t *testing.T) {
+	ctx := context.Background()
+	testClient := newTestClient(ctx, t)
+	defer testClient.close(ctx)
+
+	_, _, err := createWarmupWorkspaces(ctx, testClient, []models.Group{
+		{FullPath: "top-level-group-a", CreatedBy: "group-creator"},
+		{FullPath: "top-level-group-a/nested-group", CreatedBy: "group-creator"},
+		{FullPath: "top-level-group-b", CreatedBy: "group-creator"},
+	}, []models.Workspace{
+		{FullPath: "top-level-group-a/workspace-1", CreatedBy: "user-1"},
+		{FullPath: "top-level-group-a/nested-group/workspace-2", CreatedBy: "user-2"},
+		{FullPath: "top-level-group-a/nested-group/workspace-3", CreatedBy: "user-1"},
+		{FullPath: "top-level-group-b/workspace-4", CreatedBy: "service-account-1"},
+	})
+	require.Nil(t, err)
+
+	type testCase struct {
+		name           string
+		namespacePath  string
+		expectSubjects []string
+	}
+
+	testCases := []testCase{
+		{
+			name:           "top-level group includes creators of workspaces in nested groups",
+			namespacePath:  "top-level-group-a",
+			expectSubjects: []string{"user-1", "user-2"},
+		},
+		{
+			name:           "nested group",
+			namespacePath:  "top-level-group-a/nested-group",
+			expectSubjects: []string{"user-1", "user-2"},
+		},
+		{
+			name:           "other top-level group",
+			namespacePath:  "top-level-group-b",
+			expectSubjects: []string{"service-account-1"},
+		},
+		{
+			name:           "namespace path with no workspaces",
+			namespacePath:  "top-level-group-c",
+			expectSubjects: []string{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			subjects, err := testClient.client.Workspaces.GetCreatedBySubjects(ctx, test.namespacePath)
+			require.Nil(t, err)
+			assert.Equal(t, test.expectSubjects, subjects)
+		})
+	}
+}
+
 //////////////////////////////////////////////////////////////////////////////
 
 // Common utility structures and functions:
@@ -2167,6 +2530,8 @@ func compareWorkspaces(t *testing.T, expected, actual *models.Workspace, checkID
 	assert.Equal(t, expected.MaxJobDuration, actual.MaxJobDuration)
 	assert.Equal(t, expected.CreatedBy, actual.CreatedBy)
 	assert.Equal(t, expected.PreventDestroyPlan, actual.PreventDestroyPlan)
+	assert.Equal(t, expected.Environment, actual.Environment)
+	assert.ElementsMatch(t, expected.AllowedManagedIdentityTypes, actual.AllowedManagedIdentityTypes)
 }
 
 func createAndAssignManagedIdentitiesToAllButFirstWorkspace(t *testing.T, ctx context.Context, testClient *testClient,
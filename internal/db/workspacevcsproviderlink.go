@@ -43,6 +43,8 @@ var workspaceVCSProviderLinksFieldList = append(
 	"tag_regex",
 	"glob_patterns",
 	"webhook_disabled",
+	"run_debounce_window_seconds",
+	"webhook_secret",
 )
 
 // NewWorkspaceVCSProviderLinks returns an instance of the VCSProviderLinks interface.
@@ -121,22 +123,24 @@ func (wpl *workspaceVCSProviderLinks) CreateLink(ctx context.Context, link *mode
 	sql, args, err := dialect.Insert("workspace_vcs_provider_links").
 		Prepared(true).
 		Rows(goqu.Record{
-			"id":                    newResourceID(),
-			"version":               initialResourceVersion,
-			"created_at":            timestamp,
-			"updated_at":            timestamp,
-			"created_by":            link.CreatedBy,
-			"workspace_id":          link.WorkspaceID,
-			"provider_id":           link.ProviderID,
-			"token_nonce":           link.TokenNonce,
-			"repository_path":       link.RepositoryPath,
-			"auto_speculative_plan": link.AutoSpeculativePlan,
-			"webhook_id":            nullableString(link.WebhookID),
-			"module_directory":      link.ModuleDirectory,
-			"branch":                link.Branch,
-			"tag_regex":             link.TagRegex,
-			"glob_patterns":         globPatternsJSON,
-			"webhook_disabled":      link.WebhookDisabled,
+			"id":                          newResourceID(),
+			"version":                     initialResourceVersion,
+			"created_at":                  timestamp,
+			"updated_at":                  timestamp,
+			"created_by":                  link.CreatedBy,
+			"workspace_id":                link.WorkspaceID,
+			"provider_id":                 link.ProviderID,
+			"token_nonce":                 link.TokenNonce,
+			"repository_path":             link.RepositoryPath,
+			"auto_speculative_plan":       link.AutoSpeculativePlan,
+			"webhook_id":                  nullableString(link.WebhookID),
+			"module_directory":            link.ModuleDirectory,
+			"branch":                      link.Branch,
+			"tag_regex":                   link.TagRegex,
+			"glob_patterns":               globPatternsJSON,
+			"webhook_disabled":            link.WebhookDisabled,
+			"run_debounce_window_seconds": link.RunDebounceWindowSeconds,
+			"webhook_secret":              link.WebhookSecret,
 		}).
 		Returning(workspaceVCSProviderLinksFieldList...).ToSQL()
 	if err != nil {
@@ -188,15 +192,19 @@ func (wpl *workspaceVCSProviderLinks) UpdateLink(ctx context.Context, link *mode
 		Prepared(true).
 		Set(
 			goqu.Record{
-				"version":               goqu.L("? + ?", goqu.C("version"), 1),
-				"updated_at":            timestamp,
-				"auto_speculative_plan": link.AutoSpeculativePlan,
-				"module_directory":      link.ModuleDirectory,
-				"webhook_id":            nullableString(link.WebhookID),
-				"branch":                link.Branch,
-				"tag_regex":             link.TagRegex,
-				"glob_patterns":         globPatternsJSON,
-				"webhook_disabled":      link.WebhookDisabled,
+				"version":                     goqu.L("? + ?", goqu.C("version"), 1),
+				"updated_at":                  timestamp,
+				"provider_id":                 link.ProviderID,
+				"token_nonce":                 link.TokenNonce,
+				"auto_speculative_plan":       link.AutoSpeculativePlan,
+				"module_directory":            link.ModuleDirectory,
+				"webhook_id":                  nullableString(link.WebhookID),
+				"branch":                      link.Branch,
+				"tag_regex":                   link.TagRegex,
+				"glob_patterns":               globPatternsJSON,
+				"webhook_disabled":            link.WebhookDisabled,
+				"run_debounce_window_seconds": link.RunDebounceWindowSeconds,
+				"webhook_secret":              link.WebhookSecret,
 			},
 		).Where(goqu.Ex{"id": link.Metadata.ID, "version": link.Metadata.Version}).
 		Returning(workspaceVCSProviderLinksFieldList...).ToSQL()
@@ -305,6 +313,8 @@ func scanLink(row scanner) (*models.WorkspaceVCSProviderLink, error) {
 		&wpl.TagRegex,
 		&wpl.GlobPatterns,
 		&wpl.WebhookDisabled,
+		&wpl.RunDebounceWindowSeconds,
+		&wpl.WebhookSecret,
 	}
 
 	err := row.Scan(fields...)
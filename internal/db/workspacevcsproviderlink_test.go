@@ -434,11 +434,13 @@ func TestUpdateLink(t *testing.T) {
 					ID:      positiveLink.Metadata.ID,
 					Version: positiveLink.Metadata.Version,
 				},
-				WorkspaceID:         warmupWorkspace.Metadata.ID,
-				ProviderID:          warmupProvider.Metadata.ID,
-				RepositoryPath:      "owner/repository",
-				Branch:              "updated/branch",
-				AutoSpeculativePlan: false,
+				WorkspaceID:              warmupWorkspace.Metadata.ID,
+				ProviderID:               warmupProvider.Metadata.ID,
+				TokenNonce:               positiveLink.TokenNonce,
+				RepositoryPath:           "owner/repository",
+				Branch:                   "updated/branch",
+				AutoSpeculativePlan:      false,
+				RunDebounceWindowSeconds: 30,
 			},
 			expectLink: &models.WorkspaceVCSProviderLink{
 				Metadata: models.ResourceMetadata{
@@ -447,13 +449,14 @@ func TestUpdateLink(t *testing.T) {
 					CreationTimestamp:    positiveLink.Metadata.CreationTimestamp,
 					LastUpdatedTimestamp: &now,
 				},
-				WorkspaceID:         warmupWorkspace.Metadata.ID,
-				ProviderID:          warmupProvider.Metadata.ID,
-				RepositoryPath:      "owner/repository",
-				Branch:              "updated/branch",
-				TokenNonce:          positiveLink.TokenNonce,
-				AutoSpeculativePlan: false,
-				CreatedBy:           positiveLink.CreatedBy,
+				WorkspaceID:              warmupWorkspace.Metadata.ID,
+				ProviderID:               warmupProvider.Metadata.ID,
+				RepositoryPath:           "owner/repository",
+				Branch:                   "updated/branch",
+				TokenNonce:               positiveLink.TokenNonce,
+				AutoSpeculativePlan:      false,
+				CreatedBy:                positiveLink.CreatedBy,
+				RunDebounceWindowSeconds: 30,
 			},
 		},
 		{
@@ -463,6 +466,8 @@ func TestUpdateLink(t *testing.T) {
 					ID:      nonExistentID,
 					Version: positiveLink.Metadata.Version,
 				},
+				ProviderID: warmupProvider.Metadata.ID,
+				TokenNonce: positiveLink.TokenNonce,
 			},
 			expectMsg: resourceVersionMismatch,
 		},
@@ -473,6 +478,8 @@ func TestUpdateLink(t *testing.T) {
 					ID:      invalidID,
 					Version: positiveLink.Metadata.Version,
 				},
+				ProviderID: warmupProvider.Metadata.ID,
+				TokenNonce: positiveLink.TokenNonce,
 			},
 			expectMsg: invalidUUIDMsg1,
 		},
@@ -741,6 +748,7 @@ func compareWorkspaceVCSProviderLinks(t *testing.T, expected, actual *models.Wor
 	assert.Equal(t, expected.WebhookID, actual.WebhookID)
 	assert.Equal(t, expected.CreatedBy, actual.CreatedBy)
 	assert.Equal(t, expected.WebhookDisabled, actual.WebhookDisabled)
+	assert.Equal(t, expected.RunDebounceWindowSeconds, actual.RunDebounceWindowSeconds)
 
 	if checkID {
 		assert.Equal(t, expected.Metadata.ID, actual.Metadata.ID)
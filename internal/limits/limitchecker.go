@@ -5,6 +5,7 @@ package limits
 
 import (
 	"context"
+	goerrors "errors"
 	"time"
 
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/db"
@@ -33,6 +34,7 @@ const (
 	ResourceLimitManagedIdentityAliasesPerManagedIdentity       ResourceLimitName = "ResourceLimitManagedIdentityAliasesPerManagedIdentity"
 	ResourceLimitAssignedManagedIdentitiesPerWorkspace          ResourceLimitName = "ResourceLimitAssignedManagedIdentitiesPerWorkspace"
 	ResourceLimitManagedIdentityAccessRulesPerManagedIdentity   ResourceLimitName = "ResourceLimitManagedIdentityAccessRulesPerManagedIdentity"
+	ResourceLimitAllowedPrincipalsPerManagedIdentityAccessRule  ResourceLimitName = "ResourceLimitAllowedPrincipalsPerManagedIdentityAccessRule"
 	ResourceLimitTerraformModulesPerGroup                       ResourceLimitName = "ResourceLimitTerraformModulesPerGroup"
 	ResourceLimitVersionsPerTerraformModulePerTimePeriod        ResourceLimitName = "ResourceLimitVersionsPerTerraformModulePerTimePeriod"
 	ResourceLimitAttestationsPerTerraformModulePerTimePeriod    ResourceLimitName = "ResourceLimitAttestationsPerTerraformModulePerTimePeriod"
@@ -47,9 +49,75 @@ const (
 	ResourceLimitStateVersionsPerWorkspacePerTimePeriod         ResourceLimitName = "ResourceLimitStateVersionsPerWorkspacePerTimePeriod"
 )
 
+// LimitExceededError is returned when a value exceeds a resource limit. It unwraps to an EInvalid
+// error so it's handled the same as any other validation error unless a caller needs the details.
+type LimitExceededError struct {
+	err       error
+	LimitName ResourceLimitName
+	Value     int32
+	Limit     int
+}
+
+// NewLimitExceededError returns a new LimitExceededError
+func NewLimitExceededError(name ResourceLimitName, value int32, limit int) *LimitExceededError {
+	return &LimitExceededError{
+		err:       errors.New("for limit %s: value %d exceeds limit of %d", name, value, limit, errors.WithErrorCode(errors.EInvalid)),
+		LimitName: name,
+		Value:     value,
+		Limit:     limit,
+	}
+}
+
+// Error implements the error interface
+func (e *LimitExceededError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying EInvalid error
+func (e *LimitExceededError) Unwrap() error {
+	return e.err
+}
+
+// AsLimitExceededError returns the LimitExceededError in the error chain, if there is one.
+func AsLimitExceededError(err error) (*LimitExceededError, bool) {
+	var limitErr *LimitExceededError
+	if goerrors.As(err, &limitErr) {
+		return limitErr, true
+	}
+	return nil, false
+}
+
+// groupOverridableLimits contains the limits that are checked with WithGroupPath, only these limits
+// honor group-level overrides
+var groupOverridableLimits = map[ResourceLimitName]struct{}{
+	ResourceLimitManagedIdentitiesPerGroup: {},
+}
+
+// IsGroupOverridable returns true if a group-level override is applied when checking the limit
+func (n ResourceLimitName) IsGroupOverridable() bool {
+	_, ok := groupOverridableLimits[n]
+	return ok
+}
+
+// checkLimitOptions contains the optional behavior for CheckLimit
+type checkLimitOptions struct {
+	groupPath *string
+}
+
+// CheckLimitOption is a functional option for CheckLimit
+type CheckLimitOption func(*checkLimitOptions)
+
+// WithGroupPath applies the nearest group-level override found by walking up from the group
+// before falling back to the system-wide limit.
+func WithGroupPath(groupPath string) CheckLimitOption {
+	return func(o *checkLimitOptions) {
+		o.groupPath = &groupPath
+	}
+}
+
 // LimitChecker implements functionality related to resource limits.
 type LimitChecker interface {
-	CheckLimit(ctx context.Context, name ResourceLimitName, toCheck int32) error
+	CheckLimit(ctx context.Context, name ResourceLimitName, toCheck int32, opts ...CheckLimitOption) error
 }
 
 type limitChecker struct {
@@ -68,7 +136,26 @@ func NewLimitChecker(
 // CheckLimit returns an error or nil based on a limit check.
 // The returned error is already wrapped if appropriate.
 // The toCheck argument is int32 rather than int, because most calls come from something.PageInfo.TotalCount.
-func (c *limitChecker) CheckLimit(ctx context.Context, name ResourceLimitName, toCheck int32) error {
+func (c *limitChecker) CheckLimit(ctx context.Context, name ResourceLimitName, toCheck int32, opts ...CheckLimitOption) error {
+	options := checkLimitOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.groupPath != nil {
+		override, err := c.dbClient.ResourceLimits.GetNearestGroupResourceLimit(ctx, *options.groupPath, string(name))
+		if err != nil {
+			return err
+		}
+
+		if override != nil {
+			if int(toCheck) > override.Value {
+				return NewLimitExceededError(name, toCheck, override.Value)
+			}
+			return nil
+		}
+	}
+
 	limit, err := c.dbClient.ResourceLimits.GetResourceLimit(ctx, string(name))
 	if err != nil {
 		return err
@@ -78,7 +165,7 @@ func (c *limitChecker) CheckLimit(ctx context.Context, name ResourceLimitName, t
 	}
 
 	if int(toCheck) > limit.Value {
-		return errors.New("for limit %s: value %d exceeds limit of %d", name, toCheck, limit.Value, errors.WithErrorCode(errors.EInvalid))
+		return NewLimitExceededError(name, toCheck, limit.Value)
 	}
 
 	// A valid limit value was found, and there is no violation.
@@ -0,0 +1,152 @@
+package limits
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/db"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
+)
+
+func TestCheckLimit(t *testing.T) {
+	testCases := []struct {
+		name            string
+		limit           *models.ResourceLimit
+		toCheck         int32
+		expectLimitErr  *LimitExceededError
+		expectErrorCode errors.CodeType
+		expectError     string
+	}{
+		{
+			name:    "value is within the limit",
+			limit:   &models.ResourceLimit{Name: string(ResourceLimitManagedIdentitiesPerGroup), Value: 5},
+			toCheck: 5,
+		},
+		{
+			name:            "value exceeds the limit",
+			limit:           &models.ResourceLimit{Name: string(ResourceLimitManagedIdentitiesPerGroup), Value: 5},
+			toCheck:         6,
+			expectLimitErr:  &LimitExceededError{LimitName: ResourceLimitManagedIdentitiesPerGroup, Value: 6, Limit: 5},
+			expectErrorCode: errors.EInvalid,
+			expectError:     "for limit ResourceLimitManagedIdentitiesPerGroup: value 6 exceeds limit of 5",
+		},
+		{
+			name:            "limit does not exist",
+			toCheck:         1,
+			expectErrorCode: errors.EInvalid,
+			expectError:     "invalid resource limit name: ResourceLimitManagedIdentitiesPerGroup",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			mockResourceLimits := db.NewMockResourceLimits(t)
+
+			mockResourceLimits.On("GetResourceLimit", mock.Anything, string(ResourceLimitManagedIdentitiesPerGroup)).Return(test.limit, nil)
+
+			checker := NewLimitChecker(&db.Client{ResourceLimits: mockResourceLimits})
+
+			err := checker.CheckLimit(context.Background(), ResourceLimitManagedIdentitiesPerGroup, test.toCheck)
+
+			if test.expectErrorCode == "" {
+				assert.Nil(t, err)
+				return
+			}
+
+			assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+			assert.Equal(t, test.expectError, errors.ErrorMessage(err))
+			assert.Equal(t, test.expectError, err.Error())
+
+			limitErr, ok := AsLimitExceededError(err)
+			if test.expectLimitErr == nil {
+				assert.False(t, ok)
+				return
+			}
+
+			if assert.True(t, ok) {
+				assert.Equal(t, test.expectLimitErr.LimitName, limitErr.LimitName)
+				assert.Equal(t, test.expectLimitErr.Value, limitErr.Value)
+				assert.Equal(t, test.expectLimitErr.Limit, limitErr.Limit)
+			}
+		})
+	}
+}
+
+func TestAsLimitExceededError(t *testing.T) {
+	limitErr := NewLimitExceededError(ResourceLimitVCSProvidersPerGroup, 3, 2)
+
+	found, ok := AsLimitExceededError(errors.Wrap(limitErr, "failed to create VCS provider"))
+	assert.True(t, ok)
+	assert.Equal(t, limitErr, found)
+
+	_, ok = AsLimitExceededError(errors.New("some other error", errors.WithErrorCode(errors.EInvalid)))
+	assert.False(t, ok)
+}
+
+func TestIsGroupOverridable(t *testing.T) {
+	assert.True(t, ResourceLimitManagedIdentitiesPerGroup.IsGroupOverridable())
+	assert.False(t, ResourceLimitWorkspacesPerGroup.IsGroupOverridable())
+}
+
+func TestCheckLimitWithGroupPath(t *testing.T) {
+	systemLimit := &models.ResourceLimit{Name: string(ResourceLimitManagedIdentitiesPerGroup), Value: 5}
+
+	testCases := []struct {
+		name           string
+		override       *models.GroupResourceLimit
+		toCheck        int32
+		expectLimitErr *LimitExceededError
+	}{
+		{
+			name:     "override inherited from an ancestor allows more than the system limit",
+			override: &models.GroupResourceLimit{GroupID: "root-group-id", Name: string(ResourceLimitManagedIdentitiesPerGroup), Value: 10},
+			toCheck:  8,
+		},
+		{
+			name:           "override inherited from an ancestor is enforced",
+			override:       &models.GroupResourceLimit{GroupID: "root-group-id", Name: string(ResourceLimitManagedIdentitiesPerGroup), Value: 10},
+			toCheck:        11,
+			expectLimitErr: &LimitExceededError{LimitName: ResourceLimitManagedIdentitiesPerGroup, Value: 11, Limit: 10},
+		},
+		{
+			name:    "no override falls back to the system limit",
+			toCheck: 5,
+		},
+		{
+			name:           "no override falls back to the system limit and is enforced",
+			toCheck:        6,
+			expectLimitErr: &LimitExceededError{LimitName: ResourceLimitManagedIdentitiesPerGroup, Value: 6, Limit: 5},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			mockResourceLimits := db.NewMockResourceLimits(t)
+
+			mockResourceLimits.On("GetNearestGroupResourceLimit", mock.Anything, "root/child", string(ResourceLimitManagedIdentitiesPerGroup)).
+				Return(test.override, nil)
+
+			if test.override == nil {
+				mockResourceLimits.On("GetResourceLimit", mock.Anything, string(ResourceLimitManagedIdentitiesPerGroup)).Return(systemLimit, nil)
+			}
+
+			checker := NewLimitChecker(&db.Client{ResourceLimits: mockResourceLimits})
+
+			err := checker.CheckLimit(context.Background(), ResourceLimitManagedIdentitiesPerGroup, test.toCheck, WithGroupPath("root/child"))
+
+			if test.expectLimitErr == nil {
+				assert.Nil(t, err)
+				return
+			}
+
+			limitErr, ok := AsLimitExceededError(err)
+			if assert.True(t, ok) {
+				assert.Equal(t, test.expectLimitErr.Value, limitErr.Value)
+				assert.Equal(t, test.expectLimitErr.Limit, limitErr.Limit)
+			}
+		})
+	}
+}
@@ -13,13 +13,20 @@ type MockLimitChecker struct {
 	mock.Mock
 }
 
-// CheckLimit provides a mock function with given fields: ctx, name, toCheck
-func (_m *MockLimitChecker) CheckLimit(ctx context.Context, name ResourceLimitName, toCheck int32) error {
-	ret := _m.Called(ctx, name, toCheck)
+// CheckLimit provides a mock function with given fields: ctx, name, toCheck, opts
+func (_m *MockLimitChecker) CheckLimit(ctx context.Context, name ResourceLimitName, toCheck int32, opts ...CheckLimitOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name, toCheck)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, ResourceLimitName, int32) error); ok {
-		r0 = rf(ctx, name, toCheck)
+	if rf, ok := ret.Get(0).(func(context.Context, ResourceLimitName, int32, ...CheckLimitOption) error); ok {
+		r0 = rf(ctx, name, toCheck, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
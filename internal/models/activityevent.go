@@ -6,6 +6,7 @@ type ActivityEventAction string
 
 // ActivityEventAction Types
 const (
+	ActionAccessRuleDenied    ActivityEventAction = "ACCESS_RULE_DENIED"
 	ActionAdd                 ActivityEventAction = "ADD"
 	ActionAddMember           ActivityEventAction = "ADD_MEMBER"
 	ActionCreateMembership    ActivityEventAction = "CREATE_MEMBERSHIP"
@@ -13,6 +14,7 @@ const (
 	ActionCancel              ActivityEventAction = "CANCEL"
 	ActionCreate              ActivityEventAction = "CREATE"
 	ActionDeleteChildResource ActivityEventAction = "DELETE_CHILD_RESOURCE"
+	ActionLimitExceeded       ActivityEventAction = "LIMIT_EXCEEDED"
 	ActionLock                ActivityEventAction = "LOCK"
 	ActionMigrate             ActivityEventAction = "MIGRATE"
 	ActionRemove              ActivityEventAction = "REMOVE"
@@ -115,6 +117,59 @@ type ActivityEventMoveManagedIdentityPayload struct {
 	PreviousGroupPath string `json:"previousGroupPath"`
 }
 
+// ActivityEventForceUnlockWorkspacePayload is the custom payload for force unlocking a workspace.
+type ActivityEventForceUnlockWorkspacePayload struct {
+	// RunID is the ID of the run that held the workspace lock
+	RunID string `json:"runId"`
+}
+
+// ActivityEventUpdateServiceAccountTrustPoliciesPayload is the custom payload for replacing
+// the OIDC trust policies of a service account.
+type ActivityEventUpdateServiceAccountTrustPoliciesPayload struct {
+	// AddedIssuers are the issuers that didn't have a trust policy before the update
+	AddedIssuers []string `json:"addedIssuers"`
+	// RemovedIssuers are the issuers that no longer have a trust policy after the update
+	RemovedIssuers []string `json:"removedIssuers"`
+}
+
+// ActivityEventReplaceManagedIdentityAccessRulesPayload is the custom payload for replacing
+// all the access rules of a managed identity.
+type ActivityEventReplaceManagedIdentityAccessRulesPayload struct {
+	// RemovedRuleCount is the number of access rules the managed identity had before the replacement
+	RemovedRuleCount int32 `json:"removedRuleCount"`
+	// AddedRuleCount is the number of access rules the managed identity has after the replacement
+	AddedRuleCount int32 `json:"addedRuleCount"`
+}
+
+// ActivityEventLimitExceededPayload is the custom payload for a request that was rejected because it
+// would have exceeded a resource limit.
+type ActivityEventLimitExceededPayload struct {
+	// LimitName is the name of the resource limit that would have been exceeded
+	LimitName string `json:"limitName"`
+	// Value is the value that was rejected by the limit check
+	Value int32 `json:"value"`
+}
+
+// ActivityEventAccessRuleDeniedPayload is the custom payload for a run that was rejected because
+// a managed identity access rule wasn't satisfied.
+type ActivityEventAccessRuleDeniedPayload struct {
+	// Evaluations are the access rule evaluations that failed
+	Evaluations []ManagedIdentityAccessRuleEvaluation `json:"evaluations"`
+}
+
+// ActivityEventUpdateWorkspaceAutoApplyPayload is the custom payload for changing whether
+// VCS-triggered runs in a workspace are applied automatically.
+type ActivityEventUpdateWorkspaceAutoApplyPayload struct {
+	// AutoApply is the new value of the workspace setting
+	AutoApply bool `json:"autoApply"`
+}
+
+// ActivityEventCancelWorkspaceRunsPayload is the custom payload for canceling all the active runs in a workspace.
+type ActivityEventCancelWorkspaceRunsPayload struct {
+	// CanceledRunCount is the number of runs that were canceled
+	CanceledRunCount int32 `json:"canceledRunCount"`
+}
+
 // ActivityEvent resource
 type ActivityEvent struct {
 	UserID           *string
@@ -125,6 +180,7 @@ type ActivityEvent struct {
 	Action           ActivityEventAction
 	TargetType       ActivityEventTargetType
 	Metadata         ResourceMetadata
+	Backfilled       bool
 }
 
 // ResolveMetadata resolves the metadata fields for cursor-based pagination
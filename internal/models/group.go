@@ -9,6 +9,7 @@ type Group struct {
 	ParentID    string
 	FullPath    string
 	CreatedBy   string
+	RunnerTags  []string
 	Metadata    ResourceMetadata
 }
 
@@ -46,12 +47,20 @@ func (g *Group) GetRootGroupPath() string {
 	return strings.Split(g.FullPath, "/")[0]
 }
 
-// GetParentPath returns the path for the group's immediate parent.
+// GetParentPath returns the path for the group's immediate parent or an empty string for a top-level group.
 func (g *Group) GetParentPath() string {
-	if g.ParentID == "" {
-		return ""
+	parentPath, _ := g.ParentPath()
+	return parentPath
+}
+
+// ParentPath returns the path for the group's immediate parent and whether the group has a parent.
+// It's based only on the full path, so it doesn't depend on the parent ID being set.
+func (g *Group) ParentPath() (string, bool) {
+	index := strings.LastIndex(g.FullPath, "/")
+	if index <= 0 {
+		return "", false
 	}
-	return GetGroupParentPath(g.FullPath)
+	return g.FullPath[:index], true
 }
 
 // ExpandPath returns the expanded path list for the group. The expanded path
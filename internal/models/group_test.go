@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupParentPath(t *testing.T) {
+	type testCase struct {
+		name             string
+		group            Group
+		expectParentPath string
+		expectHasParent  bool
+	}
+
+	testCases := []testCase{
+		{
+			name:  "top-level group",
+			group: Group{FullPath: "top-level"},
+		},
+		{
+			name:             "nested group",
+			group:            Group{ParentID: "parent-id", FullPath: "top-level/parent/nested"},
+			expectParentPath: "top-level/parent",
+			expectHasParent:  true,
+		},
+		{
+			name:             "nested group without a parent ID",
+			group:            Group{FullPath: "top-level/nested"},
+			expectParentPath: "top-level",
+			expectHasParent:  true,
+		},
+		{
+			name:  "group without a full path",
+			group: Group{ParentID: "parent-id"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			parentPath, hasParent := test.group.ParentPath()
+
+			assert.Equal(t, test.expectParentPath, parentPath)
+			assert.Equal(t, test.expectHasParent, hasParent)
+			assert.Equal(t, test.expectParentPath, test.group.GetParentPath())
+		})
+	}
+}
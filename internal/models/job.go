@@ -49,3 +49,8 @@ type Job struct {
 func (j *Job) ResolveMetadata(key string) (string, error) {
 	return j.Metadata.resolveFieldValue(key)
 }
+
+// GetMaxJobDuration returns the maximum amount of time the job is allowed to run for
+func (j *Job) GetMaxJobDuration() time.Duration {
+	return time.Duration(j.MaxJobDuration) * time.Minute
+}
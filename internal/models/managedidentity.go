@@ -2,6 +2,7 @@ package models
 
 import (
 	"strings"
+	"time"
 
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
@@ -47,6 +48,17 @@ type ManagedIdentityAccessRule struct {
 	VerifyStateLineage        bool
 }
 
+// ManagedIdentityAccessRuleEvaluation is the outcome of evaluating a managed identity access rule for a run
+type ManagedIdentityAccessRuleEvaluation struct {
+	ManagedIdentityID string                        `json:"managedIdentityId"`
+	RuleID            string                        `json:"ruleId"`
+	RuleType          ManagedIdentityAccessRuleType `json:"ruleType"`
+	RunStage          JobType                       `json:"runStage"`
+	// Diagnostic explains why the rule wasn't satisfied
+	Diagnostic string `json:"diagnostic,omitempty"`
+	Passed     bool   `json:"passed"`
+}
+
 // ResolveMetadata resolves the metadata fields for cursor-based pagination
 func (m *ManagedIdentityAccessRule) ResolveMetadata(key string) (string, error) {
 	return m.Metadata.resolveFieldValue(key)
@@ -92,8 +104,14 @@ type ManagedIdentity struct {
 	GroupID       string
 	CreatedBy     string
 	AliasSourceID *string
-	Metadata      ResourceMetadata
-	Data          []byte
+	// AliasSource is only populated when explicitly requested for an alias
+	AliasSource *ManagedIdentity
+	// LastUsedAt is the last time credentials were issued for this managed identity
+	LastUsedAt *time.Time
+	Metadata   ResourceMetadata
+	Data       []byte
+	// DeletionProtected prevents the managed identity from being deleted until protection is removed
+	DeletionProtected bool
 }
 
 // ResolveMetadata resolves the metadata fields for cursor-based pagination
@@ -103,6 +121,13 @@ func (m *ManagedIdentity) ResolveMetadata(key string) (string, error) {
 		switch key {
 		case "group_path":
 			val = m.GetGroupPath()
+		case "last_used_at":
+			// Identities that have never been used resolve to the zero time.
+			lastUsedAt := time.Time{}
+			if m.LastUsedAt != nil {
+				lastUsedAt = *m.LastUsedAt
+			}
+			val = lastUsedAt.Format(time.RFC3339Nano)
 		default:
 			return "", err
 		}
@@ -115,11 +140,15 @@ func (m *ManagedIdentity) ResolveMetadata(key string) (string, error) {
 func (m *ManagedIdentity) Validate() error {
 	// Verify name satisfies constraints
 	if err := verifyValidName(m.Name); err != nil {
-		return err
+		return errors.Wrap(err, "", errors.WithErrorDetails(map[string]string{"name": errors.ErrorMessage(err)}))
 	}
 
 	// Verify description satisfies constraints
-	return verifyValidDescription(m.Description)
+	if err := verifyValidDescription(m.Description); err != nil {
+		return errors.Wrap(err, "", errors.WithErrorDetails(map[string]string{"description": errors.ErrorMessage(err)}))
+	}
+
+	return nil
 }
 
 // GetGroupPath returns the group path
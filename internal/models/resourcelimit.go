@@ -6,3 +6,11 @@ type ResourceLimit struct {
 	Metadata ResourceMetadata
 	Value    int
 }
+
+// GroupResourceLimit represents a resource limit override for a group and its descendants
+type GroupResourceLimit struct {
+	Name     string
+	GroupID  string
+	Metadata ResourceMetadata
+	Value    int
+}
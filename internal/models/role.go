@@ -104,6 +104,48 @@ func (d DefaultRoleID) IsDefaultRole() bool {
 	return false
 }
 
+// EffectiveRole is the highest level of access a subject has within a namespace.
+type EffectiveRole string
+
+// EffectiveRole constants.
+const (
+	EffectiveRoleOwner    EffectiveRole = "owner"
+	EffectiveRoleDeployer EffectiveRole = "deployer"
+	EffectiveRoleViewer   EffectiveRole = "viewer"
+	EffectiveRoleNone     EffectiveRole = "none"
+)
+
+// IsHigherThan returns true if this role grants more access than the other role.
+func (e EffectiveRole) IsHigherThan(other EffectiveRole) bool {
+	return e.rank() > other.rank()
+}
+
+func (e EffectiveRole) rank() int {
+	switch e {
+	case EffectiveRoleOwner:
+		return 3
+	case EffectiveRoleDeployer:
+		return 2
+	case EffectiveRoleViewer:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// EffectiveRoleForRoleID returns the effective role granted by a membership with the specified role.
+func EffectiveRoleForRoleID(roleID string) EffectiveRole {
+	switch DefaultRoleID(roleID) {
+	case OwnerRoleID:
+		return EffectiveRoleOwner
+	case DeployerRoleID:
+		return EffectiveRoleDeployer
+	default:
+		// Any membership, including one with a custom role, grants view access to the namespace.
+		return EffectiveRoleViewer
+	}
+}
+
 // Permissions returns the Permission set for a default Tharsis role.
 func (d DefaultRoleID) Permissions() ([]permissions.Permission, bool) {
 	perms, ok := defaultRolePermissions[d]
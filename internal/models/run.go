@@ -29,6 +29,7 @@ type Run struct {
 	ModuleVersion          *string
 	ModuleSource           *string
 	TargetAddresses        []string
+	AccessRuleEvaluations  []ManagedIdentityAccessRuleEvaluation
 	ModuleDigest           []byte // This is only set for modules stored in the Tharsis module registry
 	CreatedBy              string
 	PlanID                 string
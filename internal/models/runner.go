@@ -28,6 +28,7 @@ type Runner struct {
 	GroupID      *string
 	ResourcePath string
 	CreatedBy    string
+	Tags         []string
 	Metadata     ResourceMetadata
 	Disabled     bool
 }
@@ -73,6 +74,23 @@ func (r *Runner) Validate() error {
 	return nil
 }
 
+// HasTags returns true if the runner has all of the specified tags
+func (r *Runner) HasTags(tags []string) bool {
+	for _, tag := range tags {
+		found := false
+		for _, runnerTag := range r.Tags {
+			if runnerTag == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 // GetGroupPath returns the group path
 func (r *Runner) GetGroupPath() string {
 	if r.Type == SharedRunnerType {
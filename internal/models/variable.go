@@ -18,6 +18,8 @@ type Variable struct {
 	Key           string
 	Metadata      ResourceMetadata
 	Hcl           bool
+	// NonOverridable is true when runs are not allowed to override the variable's value
+	NonOverridable bool
 }
 
 // ResolveMetadata resolves the metadata fields for cursor-based pagination
@@ -39,6 +39,8 @@ func (v *VCSProvider) ResolveMetadata(key string) (string, error) {
 	val, err := v.Metadata.resolveFieldValue(key)
 	if err != nil {
 		switch key {
+		case "name":
+			val = v.Name
 		case "group_path":
 			val = v.GetGroupPath()
 		default:
@@ -1,22 +1,30 @@
 package models
 
-import "strings"
+import (
+	"strings"
+
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
+)
 
 // Workspace represents a terraform workspace
 type Workspace struct {
-	MaxJobDuration        *int32
-	Name                  string
-	FullPath              string
-	GroupID               string
-	Description           string
-	CurrentJobID          string
-	CurrentStateVersionID string
-	CreatedBy             string
-	TerraformVersion      string
-	Metadata              ResourceMetadata
-	DirtyState            bool
-	Locked                bool
-	PreventDestroyPlan    bool
+	MaxJobDuration              *int32
+	LockedByRunID               *string
+	AllowedManagedIdentityTypes []ManagedIdentityType
+	Name                        string
+	FullPath                    string
+	GroupID                     string
+	Description                 string
+	CurrentJobID                string
+	CurrentStateVersionID       string
+	CreatedBy                   string
+	TerraformVersion            string
+	Environment                 string
+	Metadata                    ResourceMetadata
+	DirtyState                  bool
+	Locked                      bool
+	PreventDestroyPlan          bool
+	AutoApply                   bool
 }
 
 // ResolveMetadata resolves the metadata fields for cursor-based pagination
@@ -42,7 +50,39 @@ func (w *Workspace) Validate() error {
 	}
 
 	// Verify description satisfies constraints
-	return verifyValidDescription(w.Description)
+	if err := verifyValidDescription(w.Description); err != nil {
+		return err
+	}
+
+	for _, identityType := range w.AllowedManagedIdentityTypes {
+		switch identityType {
+		case ManagedIdentityAWSFederated, ManagedIdentityAzureFederated, ManagedIdentityTharsisFederated:
+		default:
+			return errors.New("invalid managed identity type %s in allowed managed identity types", identityType, errors.WithErrorCode(errors.EInvalid))
+		}
+	}
+
+	return nil
+}
+
+// IsManagedIdentityTypeAllowed returns true if managed identities of the specified type can be assigned to the workspace
+func (w *Workspace) IsManagedIdentityTypeAllowed(identityType ManagedIdentityType) bool {
+	if len(w.AllowedManagedIdentityTypes) == 0 {
+		return true
+	}
+
+	for _, allowedType := range w.AllowedManagedIdentityTypes {
+		if allowedType == identityType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsLocked returns true if the workspace is locked or a run is holding a run lock on it
+func (w *Workspace) IsLocked() bool {
+	return w.Locked || w.LockedByRunID != nil
 }
 
 // GetGroupPath returns the group path
@@ -0,0 +1,9 @@
+package models
+
+// WorkspaceRunLock represents a lock held by a run on a workspace while the run is in the apply stage
+type WorkspaceRunLock struct {
+	WorkspaceID string
+	RunID       string
+	CreatedBy   string
+	Metadata    ResourceMetadata
+}
@@ -44,6 +44,10 @@ type WorkspaceVCSProviderLink struct {
 	Metadata            ResourceMetadata
 	AutoSpeculativePlan bool // Whether to create speculative plans automatically for PRs.
 	WebhookDisabled     bool
+	// RunDebounceWindowSeconds is the window in which a new VCS-triggered run supersedes a still-queued one; 0 disables it.
+	RunDebounceWindowSeconds int32
+	// WebhookSecret is used to verify webhook payloads; nil for links whose webhook was registered before payloads were verified.
+	WebhookSecret *string
 }
 
 // Validate verifies a VCS Provider link struct.
@@ -59,6 +63,10 @@ func (wpl *WorkspaceVCSProviderLink) Validate() error {
 		}
 	}
 
+	if wpl.RunDebounceWindowSeconds < 0 {
+		return errors.New("Run debounce window must not be negative", errors.WithErrorCode(errors.EInvalid))
+	}
+
 	// Verify tag regex.
 	if wpl.TagRegex != nil {
 		if len(*wpl.TagRegex) > maxPatternLength {
@@ -28,6 +28,7 @@ const (
 	DeleteThenCreate Action = "DELETE_THEN_CREATE"
 	CreateThenDelete Action = "CREATE_THEN_DELETE"
 	Delete           Action = "DELETE"
+	Move             Action = "MOVE"
 )
 
 // IsReplace returns true if the action is one of the two actions that
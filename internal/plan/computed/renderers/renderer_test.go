@@ -252,6 +252,57 @@ func TestRenderers(t *testing.T) {
 				false,
 			),
 		},
+		"primitive_json_string_nested_key_update": {
+			diff: computed.Diff{
+				Renderer: Primitive("\n  {\"outer\": {\"key_one\": \"value_one\"}}\n", "\n  {\"outer\": {\"key_one\": \"value_two\"}}\n", cty.String),
+				Action:   action.Update,
+			},
+			expected: node.NewJSONStringDiff(
+				action.Update,
+				false,
+				nil,
+				node.NewJSONObjectDiff(action.Update, false, nil, []*node.KeyValueDiff{
+					node.NewKeyValueDiff(
+						action.Update,
+						nil,
+						"outer",
+						node.NewJSONObjectDiff(action.Update, false, nil, []*node.KeyValueDiff{
+							node.NewKeyValueDiff(
+								action.Update,
+								nil,
+								"key_one",
+								node.NewPrimitiveDiff(
+									action.Update,
+									false,
+									nil,
+									node.NewStringValueDiff("value_one", action.Update, false, false),
+									node.NewStringValueDiff("value_two", action.Update, false, false),
+								),
+								false,
+								7,
+							),
+						}),
+						false,
+						5,
+					),
+				}),
+				false,
+			),
+		},
+		"primitive_fake_json_multiline_string_update": {
+			diff: computed.Diff{
+				// Leading whitespace followed by invalid JSON should fall back to a string diff.
+				Renderer: Primitive("\n{\"key_one\": \"value_one\"\n", "\n{\"key_one\": \"value_two\"\n", cty.String),
+				Action:   action.Update,
+			},
+			expected: node.NewPrimitiveDiff(
+				action.Update,
+				false,
+				nil,
+				node.NewStringValueDiff("{\"key_one\": \"value_one\"", action.Update, false, true),
+				node.NewStringValueDiff("{\"key_one\": \"value_two\"", action.Update, false, true),
+			),
+		},
 		"primitive_fake_json_string_update": {
 			diff: computed.Diff{
 				// This isn't valid JSON, our renderer should be okay with it.
@@ -31,7 +31,10 @@ func evaluatePrimitiveString(value interface{}) evaluatedString {
 
 	str := value.(string)
 
-	if strings.HasPrefix(str, "{") || strings.HasPrefix(str, "[") {
+	// JSON documents supplied via heredocs or templates usually have leading whitespace, so
+	// trim it before checking for a JSON prefix; json.Unmarshal already ignores it.
+	trimmed := strings.TrimSpace(str)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
 		var jv interface{}
 		if err := json.Unmarshal([]byte(str), &jv); err == nil {
 			return evaluatedString{
@@ -3,6 +3,7 @@ package plan
 
 import (
 	"fmt"
+	"strings"
 
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/plan/action"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/plan/structured/attributepath"
@@ -109,21 +110,25 @@ type rawPlanDiffs struct {
 	outputs map[string]rawOutputDiff
 }
 
+// sensitiveOutputChangeWarning is added to an output diff when the output changed
+// but all of its changes are hidden by sensitive values.
+const sensitiveOutputChangeWarning = "The output value will change but the change is hidden because it contains sensitive values"
+
 type rawOutputDiff struct {
 	key  string
 	diff computed.Diff
 }
 
-func (r rawOutputDiff) decode() (*OutputDiff, error) {
+func (r rawOutputDiff) decode(options []visitor.Option) (*OutputDiff, error) {
 	renderedDiff, err := r.diff.Render()
 	if err != nil {
 		return nil, err
 	}
 
-	beforeVisitor := visitor.NewBeforeVisitor(1)
+	beforeVisitor := visitor.NewBeforeVisitor(1, options...)
 	renderedDiff.Accept(beforeVisitor)
 
-	afterVisitor := visitor.NewAfterVisitor(1)
+	afterVisitor := visitor.NewAfterVisitor(1, options...)
 	renderedDiff.Accept(afterVisitor)
 
 	warnings := []*ChangeWarning{}
@@ -146,6 +151,16 @@ func (r rawOutputDiff) decode() (*OutputDiff, error) {
 		afterHCL = fmt.Sprintf("output %q {\n   value = %s\n}", r.key, afterVisitor.String())
 	}
 
+	if r.diff.Action == action.Update && beforeHCL == afterHCL {
+		// Every change in this output is nested within a sensitive value so the rendered
+		// before and after sources are identical; add a warning to indicate the value changed.
+		warnings = append(warnings, &ChangeWarning{
+			Line:       outputValueLine(afterHCL),
+			ChangeType: "after",
+			Message:    sensitiveOutputChangeWarning,
+		})
+	}
+
 	edits := myers.ComputeEdits(span.URIFromPath("before"), beforeHCL, afterHCL)
 	unifiedDiff := gotextdiff.ToUnified("before", "after", beforeHCL, edits)
 
@@ -160,6 +175,16 @@ func (r rawOutputDiff) decode() (*OutputDiff, error) {
 	}, nil
 }
 
+// outputValueLine returns the line number of the value attribute in the rendered source of an output
+func outputValueLine(source string) int32 {
+	for i, line := range strings.Split(source, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "value =") {
+			return int32(i + 1)
+		}
+	}
+	return 0
+}
+
 type rawResourceDiff struct {
 	change  tjson.ResourceChange
 	diff    computed.Diff
@@ -178,7 +203,7 @@ func (r rawResourceDiff) Action() (action.Action, error) {
 	return action.UnmarshalActions(r.change.Change.Actions)
 }
 
-func (r rawResourceDiff) decode() (*ResourceDiff, error) {
+func (r rawResourceDiff) decode(options []visitor.Option) (*ResourceDiff, error) {
 	block := "resource"
 	if r.change.Mode == tjson.DataResourceMode {
 		block = "data"
@@ -190,10 +215,10 @@ func (r rawResourceDiff) decode() (*ResourceDiff, error) {
 	}
 
 	// Create a visitor to render the diff
-	beforeVisitor := visitor.NewBeforeVisitor(0)
+	beforeVisitor := visitor.NewBeforeVisitor(0, options...)
 	renderedNode.Accept(beforeVisitor)
 
-	afterVisitor := visitor.NewAfterVisitor(0)
+	afterVisitor := visitor.NewAfterVisitor(0, options...)
 	renderedNode.Accept(afterVisitor)
 
 	warnings := []*ChangeWarning{}
@@ -210,6 +235,16 @@ func (r rawResourceDiff) decode() (*ResourceDiff, error) {
 		return nil, err
 	}
 
+	var previousAddress string
+	if r.Moved() {
+		previousAddress = r.change.PreviousAddress
+
+		// A resource that was only moved is reported as a move instead of a no-op
+		if actionType == action.NoOp {
+			actionType = action.Move
+		}
+	}
+
 	var beforeHCL, afterHCL string
 	switch actionType {
 	case action.Create:
@@ -225,18 +260,19 @@ func (r rawResourceDiff) decode() (*ResourceDiff, error) {
 	unifiedDiff := gotextdiff.ToUnified("before", "after", beforeHCL, edits)
 
 	return &ResourceDiff{
-		Action:         actionType,
-		Mode:           string(r.change.Mode),
-		Address:        r.change.Address,
-		ResourceType:   r.change.Type,
-		ResourceName:   r.change.Name,
-		ProviderName:   r.change.ProviderName,
-		ModuleAddress:  r.change.ModuleAddress,
-		UnifiedDiff:    fmt.Sprint(unifiedDiff),
-		OriginalSource: beforeHCL,
-		Imported:       r.Importing(),
-		Moved:          r.Moved(),
-		Drifted:        r.drifted,
-		Warnings:       warnings,
+		Action:          actionType,
+		Mode:            string(r.change.Mode),
+		Address:         r.change.Address,
+		ResourceType:    r.change.Type,
+		ResourceName:    r.change.Name,
+		ProviderName:    r.change.ProviderName,
+		ModuleAddress:   r.change.ModuleAddress,
+		PreviousAddress: previousAddress,
+		UnifiedDiff:     fmt.Sprint(unifiedDiff),
+		OriginalSource:  beforeHCL,
+		Imported:        r.Importing(),
+		Moved:           r.Moved(),
+		Drifted:         r.drifted,
+		Warnings:        warnings,
 	}, nil
 }
@@ -2981,6 +2981,127 @@ func TestSpecificCases(t *testing.T) {
 				}, action.Update, false),
 			}, nil, nil, nil, nil, action.Update, false),
 		},
+
+		// Set elements that only differ by a nested attribute are paired by
+		// their identity so they render as a single in-place update.
+		"set/element_nested_attribute_update": {
+			input: structured.Change{
+				Before: map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{
+							"port":     80.0,
+							"protocol": "tcp",
+							"tags": map[string]interface{}{
+								"name": "http",
+							},
+						},
+						map[string]interface{}{
+							"port":     443.0,
+							"protocol": "tcp",
+							"tags": map[string]interface{}{
+								"name": "https",
+							},
+						},
+					},
+				},
+				After: map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{
+							"port":     443.0,
+							"protocol": "tcp",
+							"tags": map[string]interface{}{
+								"name": "https",
+							},
+						},
+						map[string]interface{}{
+							"port":     80.0,
+							"protocol": "tcp",
+							"tags": map[string]interface{}{
+								"name": "web",
+							},
+						},
+					},
+				},
+				ReplacePaths:       attributepath.Empty(false),
+				RelevantAttributes: attributepath.AlwaysMatcher(),
+			},
+			block: &tjson.SchemaBlock{
+				Attributes: map[string]*tjson.SchemaAttribute{
+					"rules": {
+						AttributeType: cty.Set(cty.Object(map[string]cty.Type{
+							"port":     cty.Number,
+							"protocol": cty.String,
+							"tags":     cty.Map(cty.String),
+						})),
+					},
+				},
+			},
+			validate: renderers.ValidateBlock(map[string]renderers.ValidateDiffFunction{
+				"rules": renderers.ValidateSet([]renderers.ValidateDiffFunction{
+					renderers.ValidateObject(map[string]renderers.ValidateDiffFunction{
+						"port":     renderers.ValidatePrimitive(80.0, 80.0, action.NoOp, false),
+						"protocol": renderers.ValidatePrimitive("tcp", "tcp", action.NoOp, false),
+						"tags": renderers.ValidateMap(map[string]renderers.ValidateDiffFunction{
+							"name": renderers.ValidatePrimitive("http", "web", action.Update, false),
+						}, action.Update, false),
+					}, action.Update, false),
+					renderers.ValidateObject(map[string]renderers.ValidateDiffFunction{
+						"port":     renderers.ValidatePrimitive(443.0, 443.0, action.NoOp, false),
+						"protocol": renderers.ValidatePrimitive("tcp", "tcp", action.NoOp, false),
+						"tags": renderers.ValidateMap(map[string]renderers.ValidateDiffFunction{
+							"name": renderers.ValidatePrimitive("https", "https", action.NoOp, false),
+						}, action.NoOp, false),
+					}, action.NoOp, false),
+				}, action.Update, false),
+			}, nil, nil, nil, nil, action.Update, false),
+		},
+
+		// Set elements that have too little in common are still rendered as a
+		// delete and a create.
+		"set/element_mostly_changed": {
+			input: structured.Change{
+				Before: map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{
+							"port":     80.0,
+							"protocol": "tcp",
+						},
+					},
+				},
+				After: map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{
+							"port":     53.0,
+							"protocol": "udp",
+						},
+					},
+				},
+				ReplacePaths:       attributepath.Empty(false),
+				RelevantAttributes: attributepath.AlwaysMatcher(),
+			},
+			block: &tjson.SchemaBlock{
+				Attributes: map[string]*tjson.SchemaAttribute{
+					"rules": {
+						AttributeType: cty.Set(cty.Object(map[string]cty.Type{
+							"port":     cty.Number,
+							"protocol": cty.String,
+						})),
+					},
+				},
+			},
+			validate: renderers.ValidateBlock(map[string]renderers.ValidateDiffFunction{
+				"rules": renderers.ValidateSet([]renderers.ValidateDiffFunction{
+					renderers.ValidateObject(map[string]renderers.ValidateDiffFunction{
+						"port":     renderers.ValidatePrimitive(80.0, nil, action.Delete, false),
+						"protocol": renderers.ValidatePrimitive("tcp", nil, action.Delete, false),
+					}, action.Delete, false),
+					renderers.ValidateObject(map[string]renderers.ValidateDiffFunction{
+						"port":     renderers.ValidatePrimitive(nil, 53.0, action.Create, false),
+						"protocol": renderers.ValidatePrimitive(nil, "udp", action.Create, false),
+					}, action.Create, false),
+				}, action.Update, false),
+			}, nil, nil, nil, nil, action.Update, false),
+		},
 	}
 	for name, tc := range tcs {
 		t.Run(name, func(t *testing.T) {
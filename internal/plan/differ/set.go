@@ -9,6 +9,7 @@
 package differ
 
 import (
+	"encoding/json"
 	"reflect"
 
 	"github.com/zclconf/go-cty/cty"
@@ -106,6 +107,13 @@ func processSet(change structured.Change, process func(value structured.Change)
 		}
 	}
 
+	// Pair the remaining object elements whose identities mostly overlap so an
+	// in-place change to an element is rendered as a single update rather than
+	// a delete and a create.
+	if err := pairSetElementsByIdentity(sliceValue, foundInBefore, foundInAfter); err != nil {
+		return err
+	}
+
 	clearRelevantStatus := func(change structured.Change) structured.Change {
 		// It's actually really difficult to render the diffs when some indices
 		// within a slice are relevant and others aren't. To make this simpler
@@ -175,3 +183,100 @@ func processSet(change structured.Change, process func(value structured.Change)
 
 	return nil
 }
+
+// pairSetElementsByIdentity pairs the unmatched before and after object elements
+// of a set. Each element's identity is the set of stable hashes of its attributes,
+// and a before element is paired with the unmatched after element that shares the
+// most attribute hashes with it, as long as more than half of the attributes of
+// both elements are shared. Sensitive and unknown elements are never paired.
+func pairSetElementsByIdentity(sliceValue structured.ChangeSlice, foundInBefore, foundInAfter map[int]int) error {
+	afterIdentities := make(map[int]map[string]bool)
+	for jx := 0; jx < len(sliceValue.After); jx++ {
+		if _, ok := foundInAfter[jx]; ok {
+			continue
+		}
+		if identity := setElementIdentity(sliceValue.After[jx]); identity != nil {
+			afterIdentities[jx] = identity
+		}
+	}
+
+	if len(afterIdentities) == 0 {
+		return nil
+	}
+
+	for ix := 0; ix < len(sliceValue.Before); ix++ {
+		if foundInBefore[ix] >= 0 {
+			continue
+		}
+
+		beforeIdentity := setElementIdentity(sliceValue.Before[ix])
+		if beforeIdentity == nil {
+			continue
+		}
+
+		bestMatch, bestShared := -1, 0
+		for jx := 0; jx < len(sliceValue.After); jx++ {
+			afterIdentity, ok := afterIdentities[jx]
+			if !ok {
+				continue
+			}
+			if _, ok := foundInAfter[jx]; ok {
+				continue
+			}
+
+			shared := 0
+			for hash := range beforeIdentity {
+				if afterIdentity[hash] {
+					shared++
+				}
+			}
+
+			if shared*2 <= len(beforeIdentity) || shared*2 <= len(afterIdentity) {
+				// Not enough in common to consider these the same element.
+				continue
+			}
+
+			if shared > bestShared {
+				bestMatch, bestShared = jx, shared
+			}
+		}
+
+		if bestMatch < 0 {
+			continue
+		}
+
+		child, err := sliceValue.GetChild(ix, bestMatch)
+		if err != nil {
+			return err
+		}
+		if child.IsBeforeSensitive() || child.IsAfterSensitive() || child.IsUnknown() {
+			continue
+		}
+
+		foundInBefore[ix] = bestMatch
+		foundInAfter[bestMatch] = ix
+	}
+
+	return nil
+}
+
+// setElementIdentity returns the stable hashes of the attributes of an object
+// set element, or nil if the element isn't an object.
+func setElementIdentity(element interface{}) map[string]bool {
+	attributes, ok := element.(map[string]interface{})
+	if !ok || len(attributes) == 0 {
+		return nil
+	}
+
+	identity := make(map[string]bool, len(attributes))
+	for name, value := range attributes {
+		// JSON encoding sorts map keys so the hash is stable for nested objects.
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil
+		}
+		identity[name+"="+string(encoded)] = true
+	}
+
+	return identity
+}
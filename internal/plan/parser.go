@@ -7,6 +7,7 @@ import (
 	"sort"
 
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/plan/action"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/plan/visitor"
 
 	tjson "github.com/hashicorp/terraform-json"
 )
@@ -35,19 +36,20 @@ type OutputDiff struct {
 
 // ResourceDiff is a model for a resource diff
 type ResourceDiff struct {
-	Mode           string           `json:"mode"`
-	Address        string           `json:"address"`
-	ResourceType   string           `json:"resource_type"`
-	ResourceName   string           `json:"resource_name"`
-	ProviderName   string           `json:"provider_name"`
-	ModuleAddress  string           `json:"module_address"`
-	Action         action.Action    `json:"action"`
-	UnifiedDiff    string           `json:"unified_diff"`
-	OriginalSource string           `json:"original_source"`
-	Warnings       []*ChangeWarning `json:"warnings"`
-	Imported       bool             `json:"imported"`
-	Drifted        bool             `json:"drifted"`
-	Moved          bool             `json:"moved"`
+	Mode            string           `json:"mode"`
+	Address         string           `json:"address"`
+	ResourceType    string           `json:"resource_type"`
+	ResourceName    string           `json:"resource_name"`
+	ProviderName    string           `json:"provider_name"`
+	ModuleAddress   string           `json:"module_address"`
+	PreviousAddress string           `json:"previous_address"`
+	Action          action.Action    `json:"action"`
+	UnifiedDiff     string           `json:"unified_diff"`
+	OriginalSource  string           `json:"original_source"`
+	Warnings        []*ChangeWarning `json:"warnings"`
+	Imported        bool             `json:"imported"`
+	Drifted         bool             `json:"drifted"`
+	Moved           bool             `json:"moved"`
 }
 
 // Parser is used to extract a normalized diff from a terraform plan
@@ -55,11 +57,31 @@ type Parser interface {
 	Parse(plan *tjson.Plan, schemas *tjson.ProviderSchemas) (*Diff, error)
 }
 
-type parser struct{}
+// ParserOption is a functional option for configuring the parser
+type ParserOption func(*parser)
+
+// WithMaxDepth limits the depth of nested values rendered in each diff, values nested
+// deeper than the limit are collapsed to a summary of their changes. A depth of zero
+// means no limit.
+func WithMaxDepth(depth int) ParserOption {
+	return func(p *parser) {
+		p.maxDepth = depth
+	}
+}
+
+type parser struct {
+	maxDepth int
+}
 
 // NewParser creates a new parser for the given plan and provider schemas
-func NewParser() Parser {
-	return &parser{}
+func NewParser(options ...ParserOption) Parser {
+	p := &parser{}
+
+	for _, o := range options {
+		o(p)
+	}
+
+	return p
 }
 
 // Parse parses the plan and returns the normalized diff
@@ -87,7 +109,7 @@ func (p *parser) Parse(plan *tjson.Plan, schemas *tjson.ProviderSchemas) (*Diff,
 	sort.Strings(keys)
 
 	for _, key := range keys {
-		outputDiff, err := rawDiffs.outputs[key].decode()
+		outputDiff, err := rawDiffs.outputs[key].decode(p.visitorOptions())
 		if err != nil {
 			return nil, err
 		}
@@ -100,13 +122,13 @@ func (p *parser) Parse(plan *tjson.Plan, schemas *tjson.ProviderSchemas) (*Diff,
 	}
 
 	for _, change := range rawDiffs.changes {
-		resourceDiff, err := change.decode()
+		resourceDiff, err := change.decode(p.visitorOptions())
 		if err != nil {
 			return nil, err
 		}
 
-		if resourceDiff.Action == action.NoOp && !resourceDiff.Moved && !resourceDiff.Imported {
-			// Don't show anything for NoOp changes unless they are moved or imported
+		if resourceDiff.Action == action.NoOp && !resourceDiff.Imported {
+			// Don't show anything for NoOp changes unless they are imported
 			continue
 		}
 
@@ -118,3 +140,7 @@ func (p *parser) Parse(plan *tjson.Plan, schemas *tjson.ProviderSchemas) (*Diff,
 		Outputs:   outputDiffs,
 	}, nil
 }
+
+func (p *parser) visitorOptions() []visitor.Option {
+	return []visitor.Option{visitor.WithMaxDepth(p.maxDepth)}
+}
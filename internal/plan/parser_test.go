@@ -97,6 +97,67 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "parse plan with moved resource",
+			tfPlan: &tfjson.Plan{
+				FormatVersion: "0.1",
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address:         "test_resource.bar",
+						PreviousAddress: "test_resource.foo",
+						Mode:            "managed",
+						Type:            "test_resource",
+						Name:            "bar",
+						ProviderName:    "test",
+						Change: &tfjson.Change{
+							Actions: tfjson.Actions{tfjson.ActionNoop},
+							Before: map[string]interface{}{
+								"normal_attribute": "some value",
+							},
+							After: map[string]interface{}{
+								"normal_attribute": "some value",
+							},
+						},
+					},
+				},
+			},
+			tfProviderSchemas: &tfjson.ProviderSchemas{
+				FormatVersion: "0.1",
+				Schemas: map[string]*tfjson.ProviderSchema{
+					"test": {
+						ResourceSchemas: map[string]*tfjson.Schema{
+							"test_resource": {
+								Block: &tfjson.SchemaBlock{
+									Attributes: map[string]*tfjson.SchemaAttribute{
+										"normal_attribute": {
+											AttributeType: cty.String,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectDiff: &Diff{
+				Outputs: []*OutputDiff{},
+				Resources: []*ResourceDiff{
+					{
+						Address:         "test_resource.bar",
+						PreviousAddress: "test_resource.foo",
+						Mode:            "managed",
+						ResourceType:    "test_resource",
+						ResourceName:    "bar",
+						ProviderName:    "test",
+						Action:          action.Move,
+						Moved:           true,
+						Warnings:        []*ChangeWarning{},
+						OriginalSource:  "resource \"test_resource\" \"bar\" {\n    normal_attribute = \"some value\"\n}",
+						UnifiedDiff:     "",
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -115,3 +176,206 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseWithMaxDepth(t *testing.T) {
+	tfPlan := &tfjson.Plan{
+		FormatVersion: "0.1",
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address:      "test_resource.foo",
+				Mode:         "managed",
+				Type:         "test_resource",
+				Name:         "foo",
+				ProviderName: "test",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionUpdate},
+					Before: map[string]interface{}{
+						"name": "before",
+						"config": map[string]interface{}{
+							"level": "one",
+							"nested": map[string]interface{}{
+								"first":     "a",
+								"second":    "b",
+								"unchanged": "c",
+								"deeper": map[string]interface{}{
+									"third": "d",
+								},
+							},
+						},
+					},
+					After: map[string]interface{}{
+						"name": "after",
+						"config": map[string]interface{}{
+							"level": "two",
+							"nested": map[string]interface{}{
+								"first":     "x",
+								"second":    "y",
+								"unchanged": "c",
+								"deeper": map[string]interface{}{
+									"third": "z",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tfProviderSchemas := &tfjson.ProviderSchemas{
+		FormatVersion: "0.1",
+		Schemas: map[string]*tfjson.ProviderSchema{
+			"test": {
+				ResourceSchemas: map[string]*tfjson.Schema{
+					"test_resource": {
+						Block: &tfjson.SchemaBlock{
+							Attributes: map[string]*tfjson.SchemaAttribute{
+								"name": {
+									AttributeType: cty.String,
+								},
+								"config": {
+									AttributeType: cty.DynamicPseudoType,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	type testCase struct {
+		name             string
+		maxDepth         int
+		expectContains   []string
+		expectNotContain []string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "no max depth renders all nested values",
+			maxDepth: 0,
+			expectContains: []string{
+				`"third" = "d"`,
+				`"third" = "z"`,
+			},
+			expectNotContain: []string{
+				"nested changes",
+			},
+		},
+		{
+			name:     "values beyond the max depth are collapsed",
+			maxDepth: 2,
+			expectContains: []string{
+				`name   = "before"`,
+				`name   = "after"`,
+				`"level"  = "one"`,
+				`"level"  = "two"`,
+				`"nested" = { ... } (3 nested changes)`,
+			},
+			expectNotContain: []string{
+				`"first"`,
+				`"third"`,
+			},
+		},
+		{
+			name:     "collapsed branch counts include deeper changes",
+			maxDepth: 3,
+			expectContains: []string{
+				`"first"     = "a"`,
+				`"first"     = "x"`,
+				`"deeper"    = { ... } (1 nested changes)`,
+			},
+			expectNotContain: []string{
+				`"third"`,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			diff, err := NewParser(WithMaxDepth(test.maxDepth)).Parse(tfPlan, tfProviderSchemas)
+			require.Nil(t, err)
+			require.Len(t, diff.Resources, 1)
+
+			resource := diff.Resources[0]
+			assert.Equal(t, action.Update, resource.Action)
+
+			for _, expected := range test.expectContains {
+				assert.Contains(t, resource.UnifiedDiff, expected)
+			}
+			for _, unexpected := range test.expectNotContain {
+				assert.NotContains(t, resource.UnifiedDiff, unexpected)
+			}
+		})
+	}
+}
+
+func TestParseSensitiveOutputs(t *testing.T) {
+	tfPlan := &tfjson.Plan{
+		FormatVersion: "0.1",
+		OutputChanges: map[string]*tfjson.Change{
+			"secret": {
+				Actions:         tfjson.Actions{tfjson.ActionUpdate},
+				Before:          "old",
+				After:           "new",
+				BeforeSensitive: true,
+				AfterSensitive:  true,
+			},
+			"obj": {
+				Actions:         tfjson.Actions{tfjson.ActionUpdate},
+				Before:          map[string]interface{}{"username": "admin", "password": "old"},
+				After:           map[string]interface{}{"username": "root", "password": "new"},
+				BeforeSensitive: map[string]interface{}{"password": true},
+				AfterSensitive:  map[string]interface{}{"password": true},
+			},
+			"keys": {
+				Actions:         tfjson.Actions{tfjson.ActionUpdate},
+				Before:          []interface{}{"key1"},
+				After:           []interface{}{"key2"},
+				BeforeSensitive: []interface{}{true},
+				AfterSensitive:  []interface{}{true},
+			},
+		},
+	}
+
+	parser := &parser{}
+	actualDiff, err := parser.Parse(tfPlan, &tfjson.ProviderSchemas{FormatVersion: "0.1"})
+	require.NoError(t, err)
+
+	expectOutputs := []*OutputDiff{
+		{
+			OutputName:     "keys",
+			Action:         action.Update,
+			UnifiedDiff:    "",
+			OriginalSource: "output \"keys\" {\n   value = [\n        (sensitive value),\n    ]\n}",
+			Warnings: []*ChangeWarning{
+				{Line: 2, ChangeType: "after", Message: sensitiveOutputChangeWarning},
+			},
+		},
+		{
+			OutputName:     "obj",
+			Action:         action.Update,
+			UnifiedDiff:    "--- before\n+++ after\n@@ -1,6 +1,6 @@\n output \"obj\" {\n    value = {\n-        \"password\" = (old sensitive value)\n-        \"username\" = \"admin\"\n+        \"password\" = (new sensitive value)\n+        \"username\" = \"root\"\n     }\n }\n\\ No newline at end of file\n",
+			OriginalSource: "output \"obj\" {\n   value = {\n        \"password\" = (old sensitive value)\n        \"username\" = \"admin\"\n    }\n}",
+			Warnings:       []*ChangeWarning{},
+		},
+		{
+			OutputName:     "secret",
+			Action:         action.Update,
+			UnifiedDiff:    "--- before\n+++ after\n@@ -1,3 +1,3 @@\n output \"secret\" {\n-   value = (old sensitive value)\n+   value = (new sensitive value)\n }\n\\ No newline at end of file\n",
+			OriginalSource: "output \"secret\" {\n   value = (old sensitive value)\n}",
+			Warnings:       []*ChangeWarning{},
+		},
+	}
+
+	assert.Equal(t, expectOutputs, actualDiff.Outputs)
+
+	// Sensitive values must never be rendered
+	for _, output := range actualDiff.Outputs {
+		for _, value := range []string{"old", "new", "key1", "key2"} {
+			assert.NotContains(t, output.UnifiedDiff, "\""+value+"\"")
+			assert.NotContains(t, output.OriginalSource, "\""+value+"\"")
+		}
+	}
+}
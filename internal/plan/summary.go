@@ -0,0 +1,52 @@
+package plan
+
+import (
+	"strings"
+
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/plan/action"
+)
+
+// ChangeCounts contains the number of resources that will be added, changed and destroyed
+type ChangeCounts struct {
+	Additions    int32 `json:"additions"`
+	Changes      int32 `json:"changes"`
+	Destructions int32 `json:"destructions"`
+}
+
+// SummarizeByProvider groups the resource changes in a diff by the Terraform provider that manages
+// each resource and returns the number of additions, changes and destructions for each provider.
+// The provider is derived from the prefix of the resource type (i.e. aws_instance belongs to aws).
+func SummarizeByProvider(diff *Diff) map[string]ChangeCounts {
+	summary := map[string]ChangeCounts{}
+
+	for _, resource := range diff.Resources {
+		provider := providerFromResourceType(resource.ResourceType)
+		counts := summary[provider]
+
+		switch resource.Action {
+		case action.Create:
+			counts.Additions++
+		case action.Update:
+			counts.Changes++
+		case action.Delete:
+			counts.Destructions++
+		case action.CreateThenDelete, action.DeleteThenCreate:
+			counts.Additions++
+			counts.Destructions++
+		default:
+			// Moved, imported and read-only resources don't add, change or destroy anything
+			continue
+		}
+
+		summary[provider] = counts
+	}
+
+	return summary
+}
+
+// providerFromResourceType returns the provider prefix of a resource type, which is
+// everything before the first underscore.
+func providerFromResourceType(resourceType string) string {
+	provider, _, _ := strings.Cut(resourceType, "_")
+	return provider
+}
@@ -0,0 +1,82 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/plan/action"
+)
+
+func TestSummarizeByProvider(t *testing.T) {
+	type testCase struct {
+		name          string
+		diff          *Diff
+		expectSummary map[string]ChangeCounts
+	}
+
+	testCases := []testCase{
+		{
+			name: "resources from two providers",
+			diff: &Diff{
+				Resources: []*ResourceDiff{
+					{ResourceType: "aws_instance", Action: action.Create},
+					{ResourceType: "aws_instance", Action: action.Update},
+					{ResourceType: "aws_s3_bucket", Action: action.Delete},
+					{ResourceType: "aws_security_group", Action: action.DeleteThenCreate},
+					{ResourceType: "azurerm_resource_group", Action: action.Create},
+					{ResourceType: "azurerm_storage_account", Action: action.CreateThenDelete},
+				},
+			},
+			expectSummary: map[string]ChangeCounts{
+				"aws": {
+					Additions:    2,
+					Changes:      1,
+					Destructions: 2,
+				},
+				"azurerm": {
+					Additions:    2,
+					Destructions: 1,
+				},
+			},
+		},
+		{
+			name: "resources that don't add, change or destroy anything are excluded",
+			diff: &Diff{
+				Resources: []*ResourceDiff{
+					{ResourceType: "aws_instance", Action: action.Create},
+					{ResourceType: "aws_instance", Action: action.Move, Moved: true},
+					{ResourceType: "random_id", Action: action.NoOp, Imported: true},
+				},
+			},
+			expectSummary: map[string]ChangeCounts{
+				"aws": {
+					Additions: 1,
+				},
+			},
+		},
+		{
+			name: "resource type without an underscore",
+			diff: &Diff{
+				Resources: []*ResourceDiff{
+					{ResourceType: "custom", Action: action.Update},
+				},
+			},
+			expectSummary: map[string]ChangeCounts{
+				"custom": {
+					Changes: 1,
+				},
+			},
+		},
+		{
+			name:          "empty diff",
+			diff:          &Diff{},
+			expectSummary: map[string]ChangeCounts{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectSummary, SummarizeByProvider(test.diff))
+		})
+	}
+}
@@ -13,11 +13,9 @@ type AfterVisitor struct {
 }
 
 // NewAfterVisitor creates a new AfterVisitor
-func NewAfterVisitor(initialIndent int) *AfterVisitor {
+func NewAfterVisitor(initialIndent int, options ...Option) *AfterVisitor {
 	return &AfterVisitor{
-		common: common{
-			indentLevel: initialIndent,
-		},
+		common: newCommon(initialIndent, options),
 	}
 }
 
@@ -29,7 +27,12 @@ func (v *AfterVisitor) VisitBlockDiff(diff *node.BlockDiff) {
 			return
 		}
 
+		if v.collapse(diff, "{", "}", diff.Replace) {
+			return
+		}
+
 		v.builder.WriteString(fmt.Sprintf("{%s\n", forcesReplacement(diff.Replace)))
+		v.incDepth()
 
 		for _, attr := range diff.Attributes {
 			if attr.Action == action.Delete {
@@ -66,6 +69,7 @@ func (v *AfterVisitor) VisitBlockDiff(diff *node.BlockDiff) {
 			}
 		}
 
+		v.decDepth()
 		v.indent()
 		v.builder.WriteString("}")
 	}
@@ -93,7 +97,12 @@ func (v *AfterVisitor) VisitJSONObjectDiff(diff *node.JSONObjectDiff) {
 			return
 		}
 
+		if v.collapse(diff, "{", "}", diff.Replace) {
+			return
+		}
+
 		v.builder.WriteString(fmt.Sprintf("{%s\n", forcesReplacement(diff.Replace)))
+		v.incDepth()
 
 		for _, attr := range diff.Attributes {
 			if attr.Action == action.Delete {
@@ -108,6 +117,7 @@ func (v *AfterVisitor) VisitJSONObjectDiff(diff *node.JSONObjectDiff) {
 			v.builder.WriteString("\n")
 		}
 
+		v.decDepth()
 		v.indent()
 		v.builder.WriteString("}")
 	}
@@ -121,7 +131,12 @@ func (v *AfterVisitor) VisitJSONArray(diff *node.JSONArray) {
 			return
 		}
 
+		if v.collapse(diff, "[", "]", diff.Replace) {
+			return
+		}
+
 		v.builder.WriteString(fmt.Sprintf("[%s\n", forcesReplacement(diff.Replace)))
+		v.incDepth()
 
 		for _, attr := range diff.Elements {
 			if attr.GetAction() == action.Delete {
@@ -136,6 +151,7 @@ func (v *AfterVisitor) VisitJSONArray(diff *node.JSONArray) {
 			v.builder.WriteString(",\n")
 		}
 
+		v.decDepth()
 		v.indent()
 		v.builder.WriteString("]")
 	}
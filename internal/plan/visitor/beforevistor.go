@@ -13,11 +13,9 @@ type BeforeVisitor struct {
 }
 
 // NewBeforeVisitor creates a new BeforeVisitor
-func NewBeforeVisitor(initialIndent int) *BeforeVisitor {
+func NewBeforeVisitor(initialIndent int, options ...Option) *BeforeVisitor {
 	return &BeforeVisitor{
-		common: common{
-			indentLevel: initialIndent,
-		},
+		common: newCommon(initialIndent, options),
 	}
 }
 
@@ -29,7 +27,12 @@ func (v *BeforeVisitor) VisitBlockDiff(diff *node.BlockDiff) {
 			return
 		}
 
+		if v.collapse(diff, "{", "}", diff.Replace) {
+			return
+		}
+
 		v.builder.WriteString(fmt.Sprintf("{%s\n", forcesReplacement(diff.Replace)))
+		v.incDepth()
 
 		for _, attr := range diff.Attributes {
 			if attr.Action == action.Create {
@@ -67,6 +70,7 @@ func (v *BeforeVisitor) VisitBlockDiff(diff *node.BlockDiff) {
 			}
 		}
 
+		v.decDepth()
 		v.indent()
 		v.builder.WriteString("}")
 	}
@@ -94,7 +98,12 @@ func (v *BeforeVisitor) VisitJSONObjectDiff(diff *node.JSONObjectDiff) {
 			return
 		}
 
+		if v.collapse(diff, "{", "}", diff.Replace) {
+			return
+		}
+
 		v.builder.WriteString(fmt.Sprintf("{%s\n", forcesReplacement(diff.Replace)))
+		v.incDepth()
 
 		for _, attr := range diff.Attributes {
 			if attr.Action == action.Create {
@@ -109,6 +118,7 @@ func (v *BeforeVisitor) VisitJSONObjectDiff(diff *node.JSONObjectDiff) {
 			v.builder.WriteString("\n")
 		}
 
+		v.decDepth()
 		v.indent()
 		v.builder.WriteString("}")
 	}
@@ -122,7 +132,12 @@ func (v *BeforeVisitor) VisitJSONArray(diff *node.JSONArray) {
 			return
 		}
 
+		if v.collapse(diff, "[", "]", diff.Replace) {
+			return
+		}
+
 		v.builder.WriteString(fmt.Sprintf("[%s\n", forcesReplacement(diff.Replace)))
+		v.incDepth()
 
 		for _, attr := range diff.Elements {
 			if attr.GetAction() == action.Create {
@@ -138,6 +153,7 @@ func (v *BeforeVisitor) VisitJSONArray(diff *node.JSONArray) {
 			v.builder.WriteString(",\n")
 		}
 
+		v.decDepth()
 		v.indent()
 		v.builder.WriteString("]")
 	}
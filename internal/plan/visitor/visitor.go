@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strings"
 
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/plan/action"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/plan/computed/node"
 )
 
@@ -22,12 +23,38 @@ func forcesReplacement(replace bool) string {
 	return ""
 }
 
+// Option is a functional option for configuring a visitor
+type Option func(*common)
+
+// WithMaxDepth limits how deeply nested objects, blocks and lists are rendered;
+// anything nested beyond the depth is collapsed to a summary of its changes.
+// A depth of zero means no limit.
+func WithMaxDepth(depth int) Option {
+	return func(c *common) {
+		c.maxDepth = depth
+	}
+}
+
 type common struct {
 	builder     strings.Builder
 	indentLevel int
+	depth       int
+	maxDepth    int
 	warnings    []Warning
 }
 
+func newCommon(initialIndent int, options []Option) common {
+	c := common{
+		indentLevel: initialIndent,
+	}
+
+	for _, o := range options {
+		o(&c)
+	}
+
+	return c
+}
+
 // String returns the rendered string
 func (c *common) String() string {
 	return c.builder.String()
@@ -58,6 +85,27 @@ func (c *common) decIndent() {
 	}
 }
 
+func (c *common) incDepth() {
+	c.depth++
+}
+
+func (c *common) decDepth() {
+	if c.depth > 0 {
+		c.depth--
+	}
+}
+
+// collapse renders a summary in place of the diff when the max depth has been reached
+// and returns true if the diff was collapsed
+func (c *common) collapse(diff node.Diff, open string, close string, replace bool) bool {
+	if c.maxDepth <= 0 || c.depth < c.maxDepth {
+		return false
+	}
+
+	c.builder.WriteString(fmt.Sprintf("%s ... %s (%d nested changes)%s", open, close, countNestedChanges(diff), forcesReplacement(replace)))
+	return true
+}
+
 func (c *common) indent() {
 	if c.indentLevel == 0 {
 		return
@@ -144,3 +192,48 @@ func (c *common) renderNull(diff *node.NullValueDiff) {
 	c.builder.WriteString("null")
 	c.builder.WriteString(forcesReplacement(diff.Replace))
 }
+
+// countNestedChanges returns the number of changed values within the diff
+func countNestedChanges(diff node.Diff) int {
+	if diff == nil {
+		return 0
+	}
+
+	count := 0
+	switch d := diff.(type) {
+	case *node.BlockDiff:
+		for _, attr := range d.Attributes {
+			count += countNestedChanges(attr)
+		}
+		for _, block := range d.Blocks {
+			count += countNestedChanges(block)
+		}
+	case *node.NestedBlockDiff:
+		count = countNestedChanges(d.Block)
+	case *node.JSONObjectDiff:
+		for _, attr := range d.Attributes {
+			count += countNestedChanges(attr)
+		}
+	case *node.JSONArray:
+		for _, element := range d.Elements {
+			count += countNestedChanges(element)
+		}
+	case *node.KeyValueDiff:
+		count = countNestedChanges(d.Value)
+	case *node.JSONStringDiff:
+		count = countNestedChanges(d.JSONValue)
+	default:
+		if diff.GetAction() != action.NoOp {
+			return 1
+		}
+		return 0
+	}
+
+	// A container that changed without any changed children (e.g. an empty object
+	// being created) is still counted as a single change
+	if count == 0 && diff.GetAction() != action.NoOp {
+		return 1
+	}
+
+	return count
+}
@@ -0,0 +1,24 @@
+// Package reservednames package
+package reservednames
+
+import "strings"
+
+// Names is a case-insensitive set of names that can't be used for resources
+type Names map[string]struct{}
+
+// New returns the set of reserved names, surrounding whitespace is trimmed and empty names are skipped
+func New(names []string) Names {
+	reserved := Names{}
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			reserved[strings.ToLower(name)] = struct{}{}
+		}
+	}
+	return reserved
+}
+
+// IsReserved returns true if the name is one of the reserved names
+func (n Names) IsReserved(name string) bool {
+	_, ok := n[strings.ToLower(name)]
+	return ok
+}
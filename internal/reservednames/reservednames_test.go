@@ -0,0 +1,34 @@
+package reservednames
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsReserved(t *testing.T) {
+	reserved := New([]string{"admin", " System ", ""})
+
+	testCases := []struct {
+		name         string
+		input        string
+		expectResult bool
+	}{
+		{name: "exact match", input: "admin", expectResult: true},
+		{name: "match ignores case", input: "ADMIN", expectResult: true},
+		{name: "surrounding whitespace is trimmed from reserved names", input: "system", expectResult: true},
+		{name: "empty names aren't reserved", input: "", expectResult: false},
+		{name: "name isn't reserved", input: "admins", expectResult: false},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectResult, reserved.IsReserved(test.input))
+		})
+	}
+}
+
+func TestIsReservedWithNilNames(t *testing.T) {
+	var reserved Names
+	assert.False(t, reserved.IsReserved("admin"))
+}
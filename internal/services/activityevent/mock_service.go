@@ -7,7 +7,6 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	db "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/db"
-
 	models "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 )
 
@@ -16,6 +15,32 @@ type MockService struct {
 	mock.Mock
 }
 
+// BackfillActivityEvent provides a mock function with given fields: ctx, input
+func (_m *MockService) BackfillActivityEvent(ctx context.Context, input *CreateActivityEventInput) (*models.ActivityEvent, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *models.ActivityEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *CreateActivityEventInput) (*models.ActivityEvent, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *CreateActivityEventInput) *models.ActivityEvent); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ActivityEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *CreateActivityEventInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateActivityEvent provides a mock function with given fields: ctx, input
 func (_m *MockService) CreateActivityEvent(ctx context.Context, input *CreateActivityEventInput) (*models.ActivityEvent, error) {
 	ret := _m.Called(ctx, input)
@@ -42,6 +67,32 @@ func (_m *MockService) CreateActivityEvent(ctx context.Context, input *CreateAct
 	return r0, r1
 }
 
+// GetActivityEventCountsByDay provides a mock function with given fields: ctx, input
+func (_m *MockService) GetActivityEventCountsByDay(ctx context.Context, input *GetActivityEventCountsByDayInput) (map[string]int, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *GetActivityEventCountsByDayInput) (map[string]int, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *GetActivityEventCountsByDayInput) map[string]int); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *GetActivityEventCountsByDayInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetActivityEvents provides a mock function with given fields: ctx, input
 func (_m *MockService) GetActivityEvents(ctx context.Context, input *GetActivityEventsInput) (*db.ActivityEventsResult, error) {
 	ret := _m.Called(ctx, input)
@@ -31,6 +31,18 @@ type GetActivityEventsInput struct {
 	IncludeNested     bool
 }
 
+// GetActivityEventCountsByDayInput is the input for counting activity events per day
+type GetActivityEventCountsByDayInput struct {
+	UserID           *string
+	ServiceAccountID *string
+	NamespacePath    *string
+	From             time.Time
+	To               time.Time
+	Actions          []models.ActivityEventAction
+	TargetTypes      []models.ActivityEventTargetType
+	IncludeNested    bool
+}
+
 // CreateActivityEventInput specifies the inputs for creating an activity event
 // The method will assign the user or service account caller.
 type CreateActivityEventInput struct {
@@ -44,7 +56,9 @@ type CreateActivityEventInput struct {
 // Service implements all activity event related functionality
 type Service interface {
 	GetActivityEvents(ctx context.Context, input *GetActivityEventsInput) (*db.ActivityEventsResult, error)
+	GetActivityEventCountsByDay(ctx context.Context, input *GetActivityEventCountsByDayInput) (map[string]int, error)
 	CreateActivityEvent(ctx context.Context, input *CreateActivityEventInput) (*models.ActivityEvent, error)
+	BackfillActivityEvent(ctx context.Context, input *CreateActivityEventInput) (*models.ActivityEvent, error)
 }
 
 type service struct {
@@ -70,26 +84,12 @@ func (s *service) GetActivityEvents(ctx context.Context,
 		return nil, err
 	}
 
-	accessPolicy, err := caller.GetNamespaceAccessPolicy(ctx)
+	membershipRequirement, err := getNamespaceMembershipRequirement(ctx, caller)
 	if err != nil {
-		tracing.RecordError(span, err, "failed to get namespace access policy")
+		tracing.RecordError(span, err, "failed to get namespace membership requirement")
 		return nil, err
 	}
 
-	var membershipRequirement *db.ActivityEventNamespaceMembershipRequirement
-
-	if !accessPolicy.AllowAll {
-		switch c := caller.(type) {
-		case *auth.UserCaller:
-			membershipRequirement = &db.ActivityEventNamespaceMembershipRequirement{UserID: &c.User.Metadata.ID}
-		case *auth.ServiceAccountCaller:
-			membershipRequirement = &db.ActivityEventNamespaceMembershipRequirement{ServiceAccountID: &c.ServiceAccountID}
-		default:
-			tracing.RecordError(span, nil, "invalid caller type: %T", caller)
-			return nil, errors.New("invalid caller type", errors.WithErrorCode(errors.EUnauthorized))
-		}
-	}
-
 	dbInput := db.GetActivityEventsInput{
 		Sort:              input.Sort,
 		PaginationOptions: input.PaginationOptions,
@@ -117,6 +117,50 @@ func (s *service) GetActivityEvents(ctx context.Context,
 	return activityEventsResult, nil
 }
 
+func (s *service) GetActivityEventCountsByDay(ctx context.Context,
+	input *GetActivityEventCountsByDayInput,
+) (map[string]int, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetActivityEventCountsByDay")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	if input.To.Before(input.From) {
+		tracing.RecordError(span, nil, "time range end is before start")
+		return nil, errors.New("time range end must not be before time range start", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	membershipRequirement, err := getNamespaceMembershipRequirement(ctx, caller)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get namespace membership requirement")
+		return nil, err
+	}
+
+	filter := &db.ActivityEventFilter{
+		UserID:           input.UserID,
+		ServiceAccountID: input.ServiceAccountID,
+		NamespacePath:    input.NamespacePath,
+		IncludeNested:    input.IncludeNested,
+		Actions:          input.Actions,
+		TargetTypes:      input.TargetTypes,
+		// Only count events from namespaces the caller is a member of, same as when listing events
+		NamespaceMembershipRequirement: membershipRequirement,
+	}
+
+	counts, err := s.dbClient.ActivityEvents.GetActivityEventCountsByDay(ctx, filter, input.From, input.To)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get activity event counts by day")
+		return nil, err
+	}
+
+	return counts, nil
+}
+
 func (s *service) CreateActivityEvent(ctx context.Context, input *CreateActivityEventInput) (*models.ActivityEvent, error) {
 	ctx, span := tracer.Start(ctx, "svc.CreateActivityEvent")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -139,16 +183,77 @@ func (s *service) CreateActivityEvent(ctx context.Context, input *CreateActivity
 		return nil, nil
 	}
 
+	toCreate, err := newActivityEvent(input, userID, serviceAccountID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to marshal payload")
+		return nil, err
+	}
+
+	activityEvent, err := s.dbClient.ActivityEvents.CreateActivityEvent(ctx, toCreate)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to create activity event")
+		return nil, err
+	}
+
+	return activityEvent, nil
+}
+
+// BackfillActivityEvent creates an activity event that's missing because its creation failed after the
+// mutation it records had already succeeded. The event is marked as backfilled and attributed to the
+// admin that created it. Only admins can backfill activity events.
+func (s *service) BackfillActivityEvent(ctx context.Context, input *CreateActivityEventInput) (*models.ActivityEvent, error) {
+	ctx, span := tracer.Start(ctx, "svc.BackfillActivityEvent")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to authorize caller")
+		return nil, err
+	}
+
+	userCaller, ok := caller.(*auth.UserCaller)
+	if !ok || !userCaller.User.Admin {
+		tracing.RecordError(span, nil, "Only system admins can backfill activity events")
+		return nil, errors.New("Only system admins can backfill activity events", errors.WithErrorCode(errors.EForbidden))
+	}
+
+	toCreate, err := newActivityEvent(input, &userCaller.User.Metadata.ID, nil)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to marshal payload")
+		return nil, err
+	}
+
+	toCreate.Backfilled = true
+
+	activityEvent, err := s.dbClient.ActivityEvents.CreateActivityEvent(ctx, toCreate)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to create activity event")
+		return nil, err
+	}
+
+	s.logger.Infow("Backfilled an activity event.",
+		"caller", caller.GetSubject(),
+		"action", input.Action,
+		"targetType", input.TargetType,
+		"targetId", input.TargetID,
+	)
+
+	return activityEvent, nil
+}
+
+// newActivityEvent builds the activity event model for the input with the payload serialized.
+func newActivityEvent(input *CreateActivityEventInput, userID, serviceAccountID *string) (*models.ActivityEvent, error) {
 	var payloadBuffer []byte
 	if input.Payload != nil {
+		var err error
 		payloadBuffer, err = json.Marshal(input.Payload)
 		if err != nil {
-			tracing.RecordError(span, err, "failed to marshal payload")
 			return nil, err
 		}
 	}
 
-	toCreate := models.ActivityEvent{
+	return &models.ActivityEvent{
 		UserID:           userID,
 		ServiceAccountID: serviceAccountID,
 		NamespacePath:    input.NamespacePath,
@@ -156,15 +261,29 @@ func (s *service) CreateActivityEvent(ctx context.Context, input *CreateActivity
 		TargetType:       input.TargetType,
 		TargetID:         input.TargetID,
 		Payload:          payloadBuffer,
-	}
+	}, nil
+}
 
-	activityEvent, err := s.dbClient.ActivityEvents.CreateActivityEvent(ctx, &toCreate)
+// getNamespaceMembershipRequirement returns the namespace membership requirement used to restrict
+// activity events to the namespaces the caller is a member of, or nil if the caller can access all namespaces.
+func getNamespaceMembershipRequirement(ctx context.Context, caller auth.Caller) (*db.ActivityEventNamespaceMembershipRequirement, error) {
+	accessPolicy, err := caller.GetNamespaceAccessPolicy(ctx)
 	if err != nil {
-		tracing.RecordError(span, err, "failed to create activity event")
 		return nil, err
 	}
 
-	return activityEvent, nil
+	if accessPolicy.AllowAll {
+		return nil, nil
+	}
+
+	switch c := caller.(type) {
+	case *auth.UserCaller:
+		return &db.ActivityEventNamespaceMembershipRequirement{UserID: &c.User.Metadata.ID}, nil
+	case *auth.ServiceAccountCaller:
+		return &db.ActivityEventNamespaceMembershipRequirement{ServiceAccountID: &c.ServiceAccountID}, nil
+	default:
+		return nil, errors.New("invalid caller type", errors.WithErrorCode(errors.EUnauthorized))
+	}
 }
 
 // The End.
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/smithy-go/ptr"
 	"github.com/stretchr/testify/assert"
@@ -13,6 +14,7 @@ import (
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/auth"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/db"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/logger"
 )
 
@@ -128,6 +130,113 @@ func TestGetActivityEvents(t *testing.T) {
 	}
 }
 
+func TestGetActivityEventCountsByDay(t *testing.T) {
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+
+	counts := map[string]int{
+		"2024-03-01": 2,
+		"2024-03-02": 1,
+		"2024-03-04": 3,
+	}
+
+	type testCase struct {
+		name                    string
+		caller                  string
+		from                    time.Time
+		to                      time.Time
+		allowAllNamespacePolicy bool
+		expectErrorCode         errors.CodeType
+	}
+
+	testCases := []testCase{
+		{
+			name:                    "admin user can count events in all namespaces",
+			caller:                  "user",
+			from:                    from,
+			to:                      to,
+			allowAllNamespacePolicy: true,
+		},
+		{
+			name:   "service account counts are restricted to namespace memberships",
+			caller: "serviceAccount",
+			from:   from,
+			to:     to,
+		},
+		{
+			name:            "time range end is before start",
+			caller:          "user",
+			from:            to,
+			to:              from,
+			expectErrorCode: errors.EInvalid,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			dbClient := buildDBClientWithMocks(t)
+
+			mockAuthorizer := auth.MockAuthorizer{}
+			mockAuthorizer.Test(t)
+
+			mockAuthorizer.On("GetRootNamespaces", mock.Anything).Return([]models.MembershipNamespace{}, nil).Maybe()
+
+			var testCaller auth.Caller
+			switch test.caller {
+			case "user":
+				testCaller = auth.NewUserCaller(
+					&models.User{
+						Metadata: models.ResourceMetadata{
+							ID: "123",
+						},
+						Admin:    test.allowAllNamespacePolicy,
+						Username: "user1",
+					},
+					&mockAuthorizer,
+					dbClient.Client,
+					nil,
+				)
+			case "serviceAccount":
+				testCaller = auth.NewServiceAccountCaller(
+					"sa1",
+					"groupA/sa1",
+					&mockAuthorizer,
+					nil,
+					nil,
+				)
+			}
+
+			dbClient.MockActivityEvents.On("GetActivityEventCountsByDay", mock.Anything,
+				mock.MatchedBy(func(filter *db.ActivityEventFilter) bool {
+					// Counts must be restricted in the same way as the listing
+					return test.allowAllNamespacePolicy == (filter.NamespaceMembershipRequirement == nil)
+				}), test.from, test.to).Return(counts, nil).Maybe()
+
+			logger, _ := logger.NewForTest()
+			service := NewService(dbClient.Client, logger)
+
+			actualCounts, err := service.GetActivityEventCountsByDay(auth.WithCaller(ctx, testCaller), &GetActivityEventCountsByDayInput{
+				From: test.from,
+				To:   test.to,
+			})
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, counts, actualCounts)
+		})
+	}
+}
+
 func TestCreateActivityEvent(t *testing.T) {
 
 	positiveActivityEventU := models.ActivityEvent{
@@ -368,6 +477,101 @@ func TestCreateActivityEvent(t *testing.T) {
 	}
 }
 
+func TestBackfillActivityEvent(t *testing.T) {
+	adminUser := &models.User{
+		Metadata: models.ResourceMetadata{ID: "admin-user-id"},
+		Username: "admin",
+		Admin:    true,
+	}
+
+	nonAdminUser := &models.User{
+		Metadata: models.ResourceMetadata{ID: "non-admin-user-id"},
+		Username: "non-admin",
+	}
+
+	input := &CreateActivityEventInput{
+		NamespacePath: ptr.String("group-1/workspace-1"),
+		Action:        models.ActionCreate,
+		TargetType:    models.TargetVariable,
+		TargetID:      "variable-id-1",
+		Payload:       map[string]string{"key": "value"},
+	}
+
+	type testCase struct {
+		name                 string
+		callerUser           *models.User
+		callerServiceAccount bool
+		expectErrorCode      errors.CodeType
+	}
+
+	testCases := []testCase{
+		{
+			name:       "admin backfills an activity event",
+			callerUser: adminUser,
+		},
+		{
+			name:            "non-admin user cannot backfill an activity event",
+			callerUser:      nonAdminUser,
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name:                 "service account cannot backfill an activity event",
+			callerServiceAccount: true,
+			expectErrorCode:      errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			dbClient := buildDBClientWithMocks(t)
+
+			var testCaller auth.Caller
+			if test.callerServiceAccount {
+				testCaller = auth.NewServiceAccountCaller("service-account-id", "group-1/service-account", nil, nil, nil)
+			} else {
+				testCaller = auth.NewUserCaller(test.callerUser, nil, dbClient.Client, nil)
+			}
+
+			if test.expectErrorCode == "" {
+				dbClient.MockActivityEvents.On("CreateActivityEvent", mock.Anything, &models.ActivityEvent{
+					UserID:        &test.callerUser.Metadata.ID,
+					NamespacePath: input.NamespacePath,
+					Action:        input.Action,
+					TargetType:    input.TargetType,
+					TargetID:      input.TargetID,
+					Payload:       fillPayload(input.Payload),
+					Backfilled:    true,
+				}).Return(func(_ context.Context, event *models.ActivityEvent) (*models.ActivityEvent, error) {
+					created := *event
+					created.Metadata.ID = "activity-event-id"
+					return &created, nil
+				})
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(dbClient.Client, logger)
+
+			activityEvent, err := service.BackfillActivityEvent(auth.WithCaller(ctx, testCaller), input)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.True(t, activityEvent.Backfilled)
+			assert.Equal(t, "activity-event-id", activityEvent.Metadata.ID)
+			assert.Equal(t, adminUser.Metadata.ID, *activityEvent.UserID)
+		})
+	}
+}
+
 //////////////////////////////////////////////////////////////////////////////
 
 // fillExpectedPayload returns a byte slice with double quotation marks around a base64-encoded
@@ -3,6 +3,8 @@ package group
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/aws/smithy-go/ptr"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/auth"
@@ -10,6 +12,7 @@ import (
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/db"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/limits"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/reservednames"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/activityevent"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/namespacemembership"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
@@ -31,6 +34,28 @@ type GetGroupsInput struct {
 	Search *string
 	// Set RootOnly true to get only root groups returned by the query.
 	RootOnly bool
+	// IncludeCallerRole annotates the result with the caller's effective role in each returned group
+	IncludeCallerRole bool
+	// MigrationTargetsFor returns only the groups this group can be migrated to, which
+	// excludes the group itself and all of its descendants
+	MigrationTargetsFor *models.Group
+	// HasRunnerTags filters the groups by whether they define their own runner tags
+	HasRunnerTags *bool
+	// DirectMembershipsOnly excludes groups the caller can only access through a membership
+	// in an ancestor group; it only applies when the groups are filtered by the caller's memberships
+	DirectMembershipsOnly bool
+}
+
+// maxRecentResourcesLimit is the maximum number of resources GetRecentResources can return
+const maxRecentResourcesLimit = 100
+
+// ResourceSummary is a condensed view of a resource returned by GetRecentResources
+type ResourceSummary struct {
+	CreationTimestamp *time.Time
+	Type              models.ActivityEventTargetType
+	ID                string
+	Name              string
+	Path              string
 }
 
 // DeleteGroupInput is the input for deleting a group
@@ -47,16 +72,25 @@ type Service interface {
 	GetGroupByFullPath(ctx context.Context, path string) (*models.Group, error)
 	// GetGroupByIDs returns a list of groups by IDs
 	GetGroupsByIDs(ctx context.Context, idList []string) ([]models.Group, error)
+	// GetGroupsByIDsMap returns a map of group ID to group, excluding any groups the caller isn't allowed to view
+	GetGroupsByIDsMap(ctx context.Context, idList []string) (map[string]models.Group, error)
 	// GetGroups returns a list of groups
 	GetGroups(ctx context.Context, input *GetGroupsInput) (*db.GroupsResult, error)
 	// DeleteGroup deletes a group by name
 	DeleteGroup(ctx context.Context, input *DeleteGroupInput) error
+	// PreviewGroupDeletion returns the number of resources that would be removed by deleting a group
+	PreviewGroupDeletion(ctx context.Context, groupID string) (*db.GroupDeletionPreview, error)
 	// CreateGroup creates a new group
 	CreateGroup(ctx context.Context, group *models.Group) (*models.Group, error)
 	// UpdateGroup updates an existing group
 	UpdateGroup(ctx context.Context, group *models.Group) (*models.Group, error)
 	// MigrateGroup migrates an existing group to a new parent (or to root)
 	MigrateGroup(ctx context.Context, groupID string, newParentID *string) (*models.Group, error)
+	// RenameGroup changes the name of an existing group along with the paths of everything under it
+	RenameGroup(ctx context.Context, groupID string, newName string) (*models.Group, error)
+	// GetRecentResources returns the most recently created groups, workspaces, managed identities
+	// and VCS providers under a namespace, newest first
+	GetRecentResources(ctx context.Context, namespacePath string, limit int) ([]ResourceSummary, error)
 }
 
 type service struct {
@@ -65,6 +99,8 @@ type service struct {
 	limitChecker               limits.LimitChecker
 	namespaceMembershipService namespacemembership.Service
 	activityService            activityevent.Service
+	// reservedNames are the names that can't be used for groups
+	reservedNames reservednames.Names
 }
 
 // NewService creates an instance of Service
@@ -74,6 +110,7 @@ func NewService(
 	limitChecker limits.LimitChecker,
 	namespaceMembershipService namespacemembership.Service,
 	activityService activityevent.Service,
+	reservedNames reservednames.Names,
 ) Service {
 	return &service{
 		logger:                     logger,
@@ -81,6 +118,7 @@ func NewService(
 		limitChecker:               limitChecker,
 		namespaceMembershipService: namespaceMembershipService,
 		activityService:            activityService,
+		reservedNames:              reservedNames,
 	}
 }
 
@@ -118,6 +156,40 @@ func (s *service) GetGroupsByIDs(ctx context.Context, idList []string) ([]models
 	return resp.Groups, nil
 }
 
+func (s *service) GetGroupsByIDsMap(ctx context.Context, idList []string) (map[string]models.Group, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetGroupsByIDsMap")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	resp, err := s.dbClient.Groups.GetGroups(ctx, &db.GetGroupsInput{Filter: &db.GroupFilter{GroupIDs: idList}})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get groups")
+		return nil, err
+	}
+
+	groups := make(map[string]models.Group, len(resp.Groups))
+	for _, g := range resp.Groups {
+		// Each group is checked individually so an inaccessible group doesn't fail the entire batch
+		if err = caller.RequirePermission(ctx, permissions.ViewGroupPermission, auth.WithNamespacePath(g.FullPath)); err != nil {
+			if errors.ErrorCode(err) == errors.EForbidden {
+				continue
+			}
+			tracing.RecordError(span, err, "permission check failed")
+			return nil, err
+		}
+
+		groups[g.Metadata.ID] = g
+	}
+
+	return groups, nil
+}
+
 func (s *service) GetGroups(ctx context.Context, input *GetGroupsInput) (*db.GroupsResult, error) {
 	ctx, span := tracer.Start(ctx, "svc.GetGroups")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -137,10 +209,16 @@ func (s *service) GetGroups(ctx context.Context, input *GetGroupsInput) (*db.Gro
 		Sort:              input.Sort,
 		PaginationOptions: input.PaginationOptions,
 		Filter: &db.GroupFilter{
-			Search: input.Search,
+			Search:        input.Search,
+			HasRunnerTags: input.HasRunnerTags,
 		},
 	}
 
+	if input.MigrationTargetsFor != nil {
+		// A group can't be migrated into itself or any of its descendants.
+		dbInput.Filter.ExcludePathPrefix = &input.MigrationTargetsFor.FullPath
+	}
+
 	if input.ParentGroup != nil {
 		// Since parent group is specified we will authorize access based on the parent group
 		err = caller.RequirePermission(ctx, permissions.ViewGroupPermission, auth.WithNamespacePath(input.ParentGroup.FullPath))
@@ -170,10 +248,12 @@ func (s *service) GetGroups(ctx context.Context, input *GetGroupsInput) (*db.Gro
 					ctx,
 					func(_ context.Context, c *auth.UserCaller) error {
 						dbInput.Filter.UserMemberID = &c.User.Metadata.ID
+						dbInput.Filter.DirectMembershipsOnly = input.DirectMembershipsOnly
 						return nil
 					},
 					func(_ context.Context, c *auth.ServiceAccountCaller) error {
 						dbInput.Filter.ServiceAccountMemberID = &c.ServiceAccountID
+						dbInput.Filter.DirectMembershipsOnly = input.DirectMembershipsOnly
 						return nil
 					},
 				); err != nil {
@@ -183,7 +263,97 @@ func (s *service) GetGroups(ctx context.Context, input *GetGroupsInput) (*db.Gro
 		}
 	}
 
-	return s.dbClient.Groups.GetGroups(ctx, &dbInput)
+	result, err := s.dbClient.Groups.GetGroups(ctx, &dbInput)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get groups")
+		return nil, err
+	}
+
+	if input.IncludeCallerRole {
+		result.CallerRoles, err = s.getCallerRoles(ctx, caller, result.Groups)
+		if err != nil {
+			tracing.RecordError(span, err, "failed to get caller roles")
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// getCallerRoles returns a map of group IDs to the caller's effective role in each group, which is
+// the highest role granted by a membership in the group itself or in any of its ancestor groups.
+func (s *service) getCallerRoles(ctx context.Context, caller auth.Caller, groups []models.Group) (map[string]models.EffectiveRole, error) {
+	roles := make(map[string]models.EffectiveRole, len(groups))
+
+	filter := &db.NamespaceMembershipFilter{}
+	switch c := caller.(type) {
+	case *auth.UserCaller:
+		if c.User.Admin {
+			for _, group := range groups {
+				roles[group.Metadata.ID] = models.EffectiveRoleOwner
+			}
+			return roles, nil
+		}
+		filter.UserID = &c.User.Metadata.ID
+	case *auth.ServiceAccountCaller:
+		filter.ServiceAccountID = &c.ServiceAccountID
+	case *auth.SystemCaller:
+		for _, group := range groups {
+			roles[group.Metadata.ID] = models.EffectiveRoleOwner
+		}
+		return roles, nil
+	default:
+		// Other callers don't have namespace memberships
+		for _, group := range groups {
+			roles[group.Metadata.ID] = models.EffectiveRoleNone
+		}
+		return roles, nil
+	}
+
+	if len(groups) == 0 {
+		return roles, nil
+	}
+
+	// Gather the paths of every group and its ancestors so all memberships can be fetched with a single query
+	pathSet := map[string]struct{}{}
+	for _, group := range groups {
+		for _, path := range group.ExpandPath() {
+			pathSet[path] = struct{}{}
+		}
+	}
+
+	filter.NamespacePaths = make([]string, 0, len(pathSet))
+	for path := range pathSet {
+		filter.NamespacePaths = append(filter.NamespacePaths, path)
+	}
+
+	membershipsResult, err := s.dbClient.NamespaceMemberships.GetNamespaceMemberships(ctx, &db.GetNamespaceMembershipsInput{
+		Filter: filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A subject can have multiple memberships for the same namespace (e.g. through teams) so keep the highest role
+	rolesByPath := map[string]models.EffectiveRole{}
+	for _, membership := range membershipsResult.NamespaceMemberships {
+		role := models.EffectiveRoleForRoleID(membership.RoleID)
+		if current, ok := rolesByPath[membership.Namespace.Path]; !ok || role.IsHigherThan(current) {
+			rolesByPath[membership.Namespace.Path] = role
+		}
+	}
+
+	for _, group := range groups {
+		effectiveRole := models.EffectiveRoleNone
+		for _, path := range group.ExpandPath() {
+			if role, ok := rolesByPath[path]; ok && role.IsHigherThan(effectiveRole) {
+				effectiveRole = role
+			}
+		}
+		roles[group.Metadata.ID] = effectiveRole
+	}
+
+	return roles, nil
 }
 
 func (s *service) GetGroupByID(ctx context.Context, id string) (*models.Group, error) {
@@ -252,6 +422,185 @@ func (s *service) GetGroupByFullPath(ctx context.Context, path string) (*models.
 	return group, nil
 }
 
+func (s *service) GetRecentResources(ctx context.Context, namespacePath string, limit int) ([]ResourceSummary, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetRecentResources")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	if limit < 1 || limit > maxRecentResourcesLimit {
+		tracing.RecordError(span, nil, "invalid limit")
+		return nil, errors.New(
+			"limit must be between 1 and %d", maxRecentResourcesLimit,
+			errors.WithErrorCode(errors.EInvalid))
+	}
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	err = caller.RequirePermission(ctx, permissions.ViewGroupPermission, auth.WithNamespacePath(namespacePath))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	group, err := s.dbClient.Groups.GetGroupByFullPath(ctx, namespacePath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get group by full path")
+		return nil, err
+	}
+
+	if group == nil {
+		tracing.RecordError(span, nil, "Group with path %s not found", namespacePath)
+		return nil, errors.New(
+			"Group with path %s not found", namespacePath,
+			errors.WithErrorCode(errors.ENotFound))
+	}
+
+	groupSort := db.GroupSortableFieldCreatedAtDesc
+	// All descendants are needed here since their paths are used to scope the queries below.
+	groupsResult, err := s.dbClient.Groups.GetGroups(ctx, &db.GetGroupsInput{
+		Sort:   &groupSort,
+		Filter: &db.GroupFilter{PathPrefix: &group.FullPath},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get descendant groups")
+		return nil, err
+	}
+
+	// Resources can live in the namespace itself or any of its descendants.
+	namespacePaths := []string{group.FullPath}
+	summaries := []ResourceSummary{}
+	for i, g := range groupsResult.Groups {
+		namespacePaths = append(namespacePaths, g.FullPath)
+		if i >= limit {
+			continue
+		}
+		summaries = append(summaries, ResourceSummary{
+			CreationTimestamp: g.Metadata.CreationTimestamp,
+			Type:              models.TargetGroup,
+			ID:                g.Metadata.ID,
+			Name:              g.Name,
+			Path:              g.FullPath,
+		})
+	}
+
+	workspaceSort := db.WorkspaceSortableFieldCreatedAtDesc
+	workspacesResult, err := s.dbClient.Workspaces.GetWorkspaces(ctx, &db.GetWorkspacesInput{
+		Sort:              &workspaceSort,
+		PaginationOptions: &pagination.Options{First: ptr.Int32(int32(limit))},
+		Filter:            &db.WorkspaceFilter{PathPrefix: &group.FullPath},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get workspaces")
+		return nil, err
+	}
+
+	for _, w := range workspacesResult.Workspaces {
+		summaries = append(summaries, ResourceSummary{
+			CreationTimestamp: w.Metadata.CreationTimestamp,
+			Type:              models.TargetWorkspace,
+			ID:                w.Metadata.ID,
+			Name:              w.Name,
+			Path:              w.FullPath,
+		})
+	}
+
+	identitySort := db.ManagedIdentitySortableFieldCreatedAtDesc
+	identitiesResult, err := s.dbClient.ManagedIdentities.GetManagedIdentities(ctx, &db.GetManagedIdentitiesInput{
+		Sort:              &identitySort,
+		PaginationOptions: &pagination.Options{First: ptr.Int32(int32(limit))},
+		Filter:            &db.ManagedIdentityFilter{NamespacePaths: namespacePaths},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identities")
+		return nil, err
+	}
+
+	for _, mi := range identitiesResult.ManagedIdentities {
+		summaries = append(summaries, ResourceSummary{
+			CreationTimestamp: mi.Metadata.CreationTimestamp,
+			Type:              models.TargetManagedIdentity,
+			ID:                mi.Metadata.ID,
+			Name:              mi.Name,
+			Path:              mi.ResourcePath,
+		})
+	}
+
+	providerSort := db.VCSProviderSortableFieldCreatedAtDesc
+	providersResult, err := s.dbClient.VCSProviders.GetProviders(ctx, &db.GetVCSProvidersInput{
+		Sort:              &providerSort,
+		PaginationOptions: &pagination.Options{First: ptr.Int32(int32(limit))},
+		Filter:            &db.VCSProviderFilter{NamespacePaths: namespacePaths},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get vcs providers")
+		return nil, err
+	}
+
+	for _, vp := range providersResult.VCSProviders {
+		summaries = append(summaries, ResourceSummary{
+			CreationTimestamp: vp.Metadata.CreationTimestamp,
+			Type:              models.TargetVCSProvider,
+			ID:                vp.Metadata.ID,
+			Name:              vp.Name,
+			Path:              vp.ResourcePath,
+		})
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].CreationTimestamp.After(*summaries[j].CreationTimestamp)
+	})
+
+	if len(summaries) > limit {
+		summaries = summaries[:limit]
+	}
+
+	return summaries, nil
+}
+
+func (s *service) PreviewGroupDeletion(ctx context.Context, groupID string) (*db.GroupDeletionPreview, error) {
+	ctx, span := tracer.Start(ctx, "svc.PreviewGroupDeletion")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	// Only owners of the group are allowed to preview what its deletion would remove.
+	err = caller.RequirePermission(ctx, permissions.UpdateNamespaceMembershipPermission, auth.WithGroupID(groupID))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	group, err := s.dbClient.Groups.GetGroupByID(ctx, groupID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get group by ID")
+		return nil, err
+	}
+
+	if group == nil {
+		tracing.RecordError(span, nil, "group with id %s not found", groupID)
+		return nil, errors.New(
+			"group with id %s not found", groupID,
+			errors.WithErrorCode(errors.ENotFound))
+	}
+
+	preview, err := s.dbClient.Groups.GetGroupDeletionPreview(ctx, group)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get group deletion preview")
+		return nil, err
+	}
+
+	return preview, nil
+}
+
 func (s *service) DeleteGroup(ctx context.Context, input *DeleteGroupInput) error {
 	ctx, span := tracer.Start(ctx, "svc.DeleteGroup")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -335,8 +684,7 @@ func (s *service) DeleteGroup(ctx context.Context, input *DeleteGroupInput) erro
 	}
 
 	// If this group is nested, create an activity event for removal of this group from its parent.
-	if input.Group.ParentID != "" {
-		parentPath := input.Group.GetParentPath()
+	if parentPath, ok := input.Group.ParentPath(); ok {
 		if _, err = s.activityService.CreateActivityEvent(txContext,
 			&activityevent.CreateActivityEventInput{
 				NamespacePath: &parentPath,
@@ -393,6 +741,11 @@ func (s *service) CreateGroup(ctx context.Context, input *models.Group) (*models
 		return nil, err
 	}
 
+	if err = s.validateNameNotReserved(input.Name); err != nil {
+		tracing.RecordError(span, err, "group name is reserved")
+		return nil, err
+	}
+
 	input.CreatedBy = caller.GetSubject()
 
 	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
@@ -537,6 +890,124 @@ func (s *service) UpdateGroup(ctx context.Context, group *models.Group) (*models
 	return updatedGroup, nil
 }
 
+func (s *service) RenameGroup(ctx context.Context, groupID string, newName string) (*models.Group, error) {
+	ctx, span := tracer.Start(ctx, "svc.RenameGroup")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	group, err := s.dbClient.Groups.GetGroupByID(ctx, groupID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get a group by ID")
+		return nil, err
+	}
+	if group == nil {
+		tracing.RecordError(span, nil, "group with id %s not found", groupID)
+		return nil, errors.New(
+			"group with id %s not found", groupID,
+			errors.WithErrorCode(errors.ENotFound))
+	}
+
+	// Renaming changes the path of the group and everything under it, so it requires the same permission as moving a group.
+	err = caller.RequirePermission(ctx, permissions.DeleteGroupPermission, auth.WithNamespacePath(group.FullPath))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	// Only admins can create top-level groups, so the same applies to renaming them.
+	parentPath, hasParent := group.ParentPath()
+	if !hasParent {
+		userCaller, ok := caller.(*auth.UserCaller)
+		if !ok || !userCaller.User.Admin {
+			tracing.RecordError(span, nil, "Only system admins can rename top-level groups")
+			return nil, errors.New("Only system admins can rename top-level groups", errors.WithErrorCode(errors.EForbidden))
+		}
+	}
+
+	if group.Name == newName {
+		tracing.RecordError(span, nil, "group already has the specified name")
+		return nil, errors.New("group already has the specified name", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	// Validate the new name using the model constraints.
+	renamed := *group
+	renamed.Name = newName
+	if err = renamed.Validate(); err != nil {
+		tracing.RecordError(span, err, "failed to validate a group model")
+		return nil, err
+	}
+
+	if err = s.validateNameNotReserved(newName); err != nil {
+		tracing.RecordError(span, err, "group name is reserved")
+		return nil, err
+	}
+
+	newPath := newName
+	if hasParent {
+		newPath = parentPath + "/" + newName
+	}
+
+	// Check for a sibling group with the new name to return a friendlier error than the namespace conflict.
+	sibling, err := s.dbClient.Groups.GetGroupByFullPath(ctx, newPath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get a group by full path")
+		return nil, err
+	}
+	if sibling != nil {
+		tracing.RecordError(span, nil, "group %s already exists", newPath)
+		return nil, errors.New("group %s already exists", newPath, errors.WithErrorCode(errors.EConflict))
+	}
+
+	s.logger.Infow("Requested a group rename.",
+		"caller", caller.GetSubject(),
+		"fullPath", group.FullPath, // This is the full path of the group prior to the rename.
+		"groupID", group.Metadata.ID,
+		"newName", newName,
+	)
+
+	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to begin a DB transaction")
+		return nil, err
+	}
+
+	defer func() {
+		if txErr := s.dbClient.Transactions.RollbackTx(txContext); txErr != nil {
+			s.logger.Errorf("failed to rollback tx for service layer RenameGroup: %v", txErr)
+		}
+	}()
+
+	renamedGroup, err := s.dbClient.Groups.RenameGroup(txContext, group, newName)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to rename a group")
+		return nil, err
+	}
+
+	if _, err = s.activityService.CreateActivityEvent(txContext,
+		&activityevent.CreateActivityEventInput{
+			NamespacePath: &renamedGroup.FullPath,
+			Action:        models.ActionUpdate,
+			TargetType:    models.TargetGroup,
+			TargetID:      renamedGroup.Metadata.ID,
+		}); err != nil {
+		tracing.RecordError(span, err, "failed to create an activity event")
+		return nil, err
+	}
+
+	if err := s.dbClient.Transactions.CommitTx(txContext); err != nil {
+		tracing.RecordError(span, err, "failed to commit a DB transaction")
+		return nil, err
+	}
+
+	return renamedGroup, nil
+}
+
 func (s *service) MigrateGroup(ctx context.Context, groupID string, newParentID *string) (*models.Group, error) {
 	ctx, span := tracer.Start(ctx, "svc.MigrateGroup")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -616,7 +1087,7 @@ func (s *service) MigrateGroup(ctx context.Context, groupID string, newParentID
 	} else {
 
 		// Return BadRequest if the user tries to move a root group to root.
-		if group.ParentID == "" {
+		if _, ok := group.ParentPath(); !ok {
 			// Return BadRequest.
 			tracing.RecordError(span, nil, "group is already a top-level group")
 			return nil, errors.New("group is already a top-level group", errors.WithErrorCode(errors.EInvalid))
@@ -738,3 +1209,11 @@ func (s *service) checkParentSubgroupLimit(ctx context.Context, span trace.Span,
 
 	return nil
 }
+
+// validateNameNotReserved returns an EInvalid error if the name is one of the configured reserved names.
+func (s *service) validateNameNotReserved(name string) error {
+	if s.reservedNames.IsReserved(name) {
+		return errors.New("group name %s is reserved", name, errors.WithErrorCode(errors.EInvalid))
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package group
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -12,6 +13,7 @@ import (
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/limits"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/maintenance"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/reservednames"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/activityevent"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/namespacemembership"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
@@ -115,7 +117,7 @@ func TestCreateTopLevelGroup(t *testing.T) {
 			limiter := limits.NewLimitChecker(dbClient)
 
 			logger, _ := logger.NewForTest()
-			service := NewService(logger, dbClient, limiter, mockNamespaceMemberships, mockActivityEvents)
+			service := NewService(logger, dbClient, limiter, mockNamespaceMemberships, mockActivityEvents, nil)
 
 			group, err := service.CreateGroup(auth.WithCaller(ctx, test.caller), &test.input)
 			if test.expectErrorCode != "" {
@@ -250,7 +252,7 @@ func TestCreateNestedGroup(t *testing.T) {
 			}
 
 			logger, _ := logger.NewForTest()
-			service := NewService(logger, &dbClient, limiter, nil, mockActivityEvents)
+			service := NewService(logger, &dbClient, limiter, nil, mockActivityEvents, nil)
 
 			group, err := service.CreateGroup(auth.WithCaller(ctx, mockCaller), &test.input)
 			if test.expectErrorCode != "" {
@@ -264,6 +266,83 @@ func TestCreateNestedGroup(t *testing.T) {
 	}
 }
 
+func TestCreateGroupWithReservedName(t *testing.T) {
+	reservedNames := reservednames.New([]string{"admin", "System"})
+
+	tests := []struct {
+		name            string
+		groupName       string
+		expectErrorCode errors.CodeType
+	}{
+		{
+			name:            "reserved name is rejected",
+			groupName:       "admin",
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:            "reserved names are matched case insensitively",
+			groupName:       "system",
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:      "name containing a reserved name is allowed",
+			groupName: "admin-tools",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+			mockGroups := db.NewMockGroups(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockResourceLimits := db.NewMockResourceLimits(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+
+			input := &models.Group{
+				Name:     test.groupName,
+				ParentID: "group0",
+				FullPath: "group0/" + test.groupName,
+			}
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateGroupPermission, mock.Anything).Return(nil)
+
+			if test.expectErrorCode == "" {
+				mockCaller.On("GetSubject").Return("testsubject")
+				mockGroups.On("CreateGroup", mock.Anything, input).Return(input, nil)
+				mockGroups.On("GetGroups", mock.Anything, mock.Anything).Return(&db.GroupsResult{
+					PageInfo: &pagination.PageInfo{TotalCount: 1},
+				}, nil)
+				mockResourceLimits.On("GetResourceLimit", mock.Anything, mock.Anything).Return(&models.ResourceLimit{Value: 5}, nil)
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, mock.Anything).Return(&models.ActivityEvent{}, nil)
+			}
+
+			dbClient := &db.Client{
+				Groups:         mockGroups,
+				Transactions:   mockTransactions,
+				ResourceLimits: mockResourceLimits,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, limits.NewLimitChecker(dbClient), nil, mockActivityEvents, reservedNames)
+
+			group, err := service.CreateGroup(auth.WithCaller(ctx, mockCaller), input)
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, input, group)
+		})
+	}
+}
+
 // TestGetGroups verifies that the auth filters are correctly passed to the DB layer for various conditions.
 // This test currently mainly exercises the search feature.
 func TestGetGroups(t *testing.T) {
@@ -279,6 +358,7 @@ func TestGetGroups(t *testing.T) {
 	userMemberID := "this-is-a-fake-user-member-ID"
 	serviceAccountMemberID := "this is a fake-service-account-member-ID"
 	serviceAccountPath := "this/is/a/fake/service/account/path"
+	groupToMovePath := "top-level/group-to-move"
 
 	// Because this test focuses only on the filters passed to the DB layer, don't worry about end-to-end errors and such.
 	type testCase struct {
@@ -418,6 +498,32 @@ func TestGetGroups(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "user member caller, direct memberships only",
+			callerType: "user",
+			svcInput: &GetGroupsInput{
+				DirectMembershipsOnly: true,
+			},
+			dbInput: &db.GetGroupsInput{
+				Filter: &db.GroupFilter{
+					UserMemberID:          &userMemberID,
+					DirectMembershipsOnly: true,
+				},
+			},
+		},
+		{
+			name:       "user member caller, migration targets exclude the group's subtree",
+			callerType: "user",
+			svcInput: &GetGroupsInput{
+				MigrationTargetsFor: &models.Group{FullPath: groupToMovePath},
+			},
+			dbInput: &db.GetGroupsInput{
+				Filter: &db.GroupFilter{
+					ExcludePathPrefix: &groupToMovePath,
+					UserMemberID:      &userMemberID,
+				},
+			},
+		},
 		{
 			name:       "user member caller, no parent group, search absent/nil, with root-only",
 			callerType: "user",
@@ -539,6 +645,19 @@ func TestGetGroups(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "service account member caller, direct memberships only",
+			callerType: "service-account",
+			svcInput: &GetGroupsInput{
+				DirectMembershipsOnly: true,
+			},
+			dbInput: &db.GetGroupsInput{
+				Filter: &db.GroupFilter{
+					ServiceAccountMemberID: &serviceAccountMemberID,
+					DirectMembershipsOnly:  true,
+				},
+			},
+		},
 		{
 			name:       "service account member caller, no parent group, search absent/nil, with root-only",
 			callerType: "service-account",
@@ -726,7 +845,7 @@ func TestGetGroups(t *testing.T) {
 			logger, _ := logger.NewForTest()
 			activityService := activityevent.NewService(dbClient.Client, logger)
 			namespaceMembershipService := namespacemembership.NewService(logger, dbClient.Client, activityService)
-			service := NewService(logger, dbClient.Client, limiter, namespaceMembershipService, activityService)
+			service := NewService(logger, dbClient.Client, limiter, namespaceMembershipService, activityService, nil)
 
 			// Call the service function.
 			actualOutput, actualError := service.GetGroups(auth.WithCaller(ctx, testCaller), test.svcInput)
@@ -741,6 +860,167 @@ func TestGetGroups(t *testing.T) {
 	}
 }
 
+func TestGetGroupsByIDsMap(t *testing.T) {
+	accessibleGroup := models.Group{
+		Metadata: models.ResourceMetadata{ID: "group-1"},
+		FullPath: "accessible-group",
+	}
+	inaccessibleGroup := models.Group{
+		Metadata: models.ResourceMetadata{ID: "group-2"},
+		FullPath: "inaccessible-group",
+	}
+
+	forbiddenError := errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden))
+
+	type testCase struct {
+		authErrors      []error
+		expectGroups    map[string]models.Group
+		name            string
+		groups          []models.Group
+		expectErrorCode errors.CodeType
+	}
+
+	testCases := []testCase{
+		{
+			name:         "caller has access to all groups",
+			groups:       []models.Group{accessibleGroup, inaccessibleGroup},
+			authErrors:   []error{nil, nil},
+			expectGroups: map[string]models.Group{"group-1": accessibleGroup, "group-2": inaccessibleGroup},
+		},
+		{
+			name:         "groups the caller can't view are excluded",
+			groups:       []models.Group{accessibleGroup, inaccessibleGroup},
+			authErrors:   []error{nil, forbiddenError},
+			expectGroups: map[string]models.Group{"group-1": accessibleGroup},
+		},
+		{
+			name:         "caller doesn't have access to any groups",
+			groups:       []models.Group{accessibleGroup, inaccessibleGroup},
+			authErrors:   []error{forbiddenError, forbiddenError},
+			expectGroups: map[string]models.Group{},
+		},
+		{
+			name:            "unexpected permission check error fails the batch",
+			groups:          []models.Group{accessibleGroup, inaccessibleGroup},
+			authErrors:      []error{errors.New("internal error")},
+			expectErrorCode: errors.EInternal,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ids := []string{}
+			for _, g := range test.groups {
+				ids = append(ids, g.Metadata.ID)
+			}
+
+			mockCaller := auth.NewMockCaller(t)
+			for _, authError := range test.authErrors {
+				mockCaller.On("RequirePermission", mock.Anything, permissions.ViewGroupPermission, mock.Anything).Return(authError).Once()
+			}
+
+			dbClient := buildDBClientWithMocks(t)
+			dbClient.MockGroups.On("GetGroups", mock.Anything, &db.GetGroupsInput{Filter: &db.GroupFilter{GroupIDs: ids}}).
+				Return(&db.GroupsResult{Groups: test.groups}, nil)
+
+			service := NewService(nil, dbClient.Client, nil, nil, nil, nil)
+
+			groups, err := service.GetGroupsByIDsMap(auth.WithCaller(ctx, mockCaller), ids)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectGroups, groups)
+		})
+	}
+}
+
+func TestPreviewGroupDeletion(t *testing.T) {
+	groupID := "group-1"
+	group := &models.Group{
+		Metadata: models.ResourceMetadata{ID: groupID},
+		FullPath: "group-1",
+	}
+
+	preview := &db.GroupDeletionPreview{
+		DescendantGroupCount:     2,
+		WorkspaceCount:           3,
+		ManagedIdentityCount:     1,
+		TerraformProviderCount:   1,
+		NamespaceMembershipCount: 4,
+		ExternalAliasCount:       1,
+	}
+
+	type testCase struct {
+		authError       error
+		group           *models.Group
+		expectPreview   *db.GroupDeletionPreview
+		name            string
+		expectErrorCode errors.CodeType
+	}
+
+	testCases := []testCase{
+		{
+			name:          "owner gets a preview of the group deletion",
+			group:         group,
+			expectPreview: preview,
+		},
+		{
+			name:            "caller is not an owner of the group",
+			group:           group,
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name:            "group does not exist",
+			expectErrorCode: errors.ENotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateNamespaceMembershipPermission, mock.Anything).Return(test.authError)
+
+			dbClient := buildDBClientWithMocks(t)
+			if test.authError == nil {
+				dbClient.MockGroups.On("GetGroupByID", mock.Anything, groupID).Return(test.group, nil)
+			}
+
+			if test.expectPreview != nil {
+				dbClient.MockGroups.On("GetGroupDeletionPreview", mock.Anything, test.group).Return(test.expectPreview, nil)
+			}
+
+			service := NewService(nil, dbClient.Client, nil, nil, nil, nil)
+
+			actualPreview, err := service.PreviewGroupDeletion(auth.WithCaller(ctx, mockCaller), groupID)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectPreview, actualPreview)
+		})
+	}
+}
+
 func TestMigrateGroup(t *testing.T) {
 	testGroupID := "test-group-id"
 	testGroupName := "test-group-name"
@@ -855,6 +1135,19 @@ func TestMigrateGroup(t *testing.T) {
 			injectChildDepth:         -1,
 			expectErrorCode:          errors.EForbidden,
 		},
+		{
+			name: "group is already a top-level group",
+			inputGroup: models.Group{
+				Metadata: models.ResourceMetadata{ID: "top-level-group-id"},
+				Name:     "top-level-group-name",
+				FullPath: "top-level-group-name",
+			},
+			newParentID:      nil,
+			isUserAdmin:      true,
+			isGroupOwner:     true,
+			injectChildDepth: -1,
+			expectErrorCode:  errors.EInvalid,
+		},
 		{
 			name:             "caller is not admin but tried to move group to root",
 			inputGroup:       testGroup,
@@ -1023,7 +1316,7 @@ func TestMigrateGroup(t *testing.T) {
 			)
 
 			logger, _ := logger.NewForTest()
-			service := NewService(logger, &dbClient, limiter, nil, &mockActivityEvents)
+			service := NewService(logger, &dbClient, limiter, nil, &mockActivityEvents, nil)
 
 			migrated, err := service.MigrateGroup(auth.WithCaller(ctx, testCaller),
 				test.inputGroup.Metadata.ID, test.newParentID)
@@ -1037,3 +1330,448 @@ func TestMigrateGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestRenameGroup(t *testing.T) {
+	testGroup := models.Group{
+		Metadata: models.ResourceMetadata{ID: "test-group-id"},
+		Name:     "old-name",
+		ParentID: "parent-id",
+		FullPath: "parent/old-name",
+	}
+
+	rootGroup := models.Group{
+		Metadata: models.ResourceMetadata{ID: "root-group-id"},
+		Name:     "old-root",
+		FullPath: "old-root",
+	}
+
+	// Test cases
+	tests := []struct {
+		renameErr       error
+		existingSibling *models.Group
+		expectGroup     *models.Group
+		name            string
+		newName         string
+		expectErrorCode errors.CodeType
+		inputGroup      models.Group
+		isUserAdmin     bool
+		isGroupOwner    bool
+	}{
+		{
+			name:         "successfully rename a nested group",
+			inputGroup:   testGroup,
+			newName:      "new-name",
+			isGroupOwner: true,
+			expectGroup: &models.Group{
+				Metadata: models.ResourceMetadata{ID: "test-group-id"},
+				Name:     "new-name",
+				ParentID: "parent-id",
+				FullPath: "parent/new-name",
+			},
+		},
+		{
+			name:         "successfully rename a top-level group as an admin",
+			inputGroup:   rootGroup,
+			newName:      "new-root",
+			isGroupOwner: true,
+			isUserAdmin:  true,
+			expectGroup: &models.Group{
+				Metadata: models.ResourceMetadata{ID: "root-group-id"},
+				Name:     "new-root",
+				FullPath: "new-root",
+			},
+		},
+		{
+			name:         "sibling group already has the new name",
+			inputGroup:   testGroup,
+			newName:      "new-name",
+			isGroupOwner: true,
+			existingSibling: &models.Group{
+				Metadata: models.ResourceMetadata{ID: "sibling-id"},
+				Name:     "new-name",
+				FullPath: "parent/new-name",
+			},
+			expectErrorCode: errors.EConflict,
+		},
+		{
+			name:            "sibling workspace already has the new name",
+			inputGroup:      testGroup,
+			newName:         "new-name",
+			isGroupOwner:    true,
+			renameErr:       errors.New("namespace parent/new-name already exists", errors.WithErrorCode(errors.EConflict)),
+			expectErrorCode: errors.EConflict,
+		},
+		{
+			name:            "new name is invalid",
+			inputGroup:      testGroup,
+			newName:         "Invalid Name",
+			isGroupOwner:    true,
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:            "new name is the same as the current name",
+			inputGroup:      testGroup,
+			newName:         "old-name",
+			isGroupOwner:    true,
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:            "caller is not owner of group",
+			inputGroup:      testGroup,
+			newName:         "new-name",
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name:            "only admins can rename top-level groups",
+			inputGroup:      rootGroup,
+			newName:         "new-root",
+			isGroupOwner:    true,
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var groupAccessError error
+			if !test.isGroupOwner {
+				groupAccessError = errors.New("test user is not owner of group being renamed", errors.WithErrorCode(errors.EForbidden))
+			}
+
+			mockAuthorizer := auth.NewMockAuthorizer(t)
+			mockAuthorizer.On("RequireAccess", mock.Anything, []permissions.Permission{permissions.DeleteGroupPermission}, mock.Anything).Return(groupAccessError).Maybe()
+
+			mockGroups := db.NewMockGroups(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+
+			mockGroups.On("GetGroupByID", mock.Anything, test.inputGroup.Metadata.ID).Return(&test.inputGroup, nil)
+			mockGroups.On("GetGroupByFullPath", mock.Anything, mock.Anything).Return(test.existingSibling, nil).Maybe()
+
+			if test.expectGroup != nil || test.renameErr != nil {
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+
+				mockGroups.On("RenameGroup", mock.Anything, &test.inputGroup, test.newName).Return(test.expectGroup, test.renameErr)
+			}
+
+			if test.expectGroup != nil {
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, &activityevent.CreateActivityEventInput{
+					NamespacePath: &test.expectGroup.FullPath,
+					Action:        models.ActionUpdate,
+					TargetType:    models.TargetGroup,
+					TargetID:      test.expectGroup.Metadata.ID,
+				}).Return(&models.ActivityEvent{}, nil)
+			}
+
+			mockMaintenanceMonitor := maintenance.NewMockMonitor(t)
+			mockMaintenanceMonitor.On("InMaintenanceMode", mock.Anything).Return(false, nil).Maybe()
+
+			dbClient := db.Client{
+				Groups:       mockGroups,
+				Transactions: mockTransactions,
+			}
+
+			testCaller := auth.NewUserCaller(
+				&models.User{
+					Metadata: models.ResourceMetadata{
+						ID: "123",
+					},
+					Admin:    test.isUserAdmin,
+					Username: "user1",
+				},
+				mockAuthorizer,
+				&dbClient,
+				mockMaintenanceMonitor,
+			)
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, &dbClient, nil, nil, mockActivityEvents, nil)
+
+			renamed, err := service.RenameGroup(auth.WithCaller(ctx, testCaller), test.inputGroup.Metadata.ID, test.newName)
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectGroup, renamed)
+		})
+	}
+}
+
+func TestGetRecentResources(t *testing.T) {
+	namespacePath := "top"
+	group := &models.Group{
+		Metadata: models.ResourceMetadata{ID: "group-top"},
+		Name:     "top",
+		FullPath: namespacePath,
+	}
+
+	now := time.Now().UTC()
+	timeAgo := func(minutes int) *time.Time {
+		ts := now.Add(-time.Duration(minutes) * time.Minute)
+		return &ts
+	}
+
+	descendantGroups := []models.Group{
+		{Metadata: models.ResourceMetadata{ID: "group-1", CreationTimestamp: timeAgo(1)}, Name: "sub", FullPath: "top/sub"},
+		{Metadata: models.ResourceMetadata{ID: "group-2", CreationTimestamp: timeAgo(6)}, Name: "old", FullPath: "top/old"},
+	}
+	workspaces := []models.Workspace{
+		{Metadata: models.ResourceMetadata{ID: "ws-1", CreationTimestamp: timeAgo(2)}, Name: "ws", FullPath: "top/sub/ws"},
+	}
+	identities := []models.ManagedIdentity{
+		{Metadata: models.ResourceMetadata{ID: "mi-1", CreationTimestamp: timeAgo(0)}, Name: "mi", ResourcePath: "top/old/mi"},
+	}
+	providers := []models.VCSProvider{
+		{Metadata: models.ResourceMetadata{ID: "vp-1", CreationTimestamp: timeAgo(4)}, Name: "vp", ResourcePath: "top/vp"},
+	}
+
+	type testCase struct {
+		authError       error
+		name            string
+		expectErrorCode errors.CodeType
+		expectIDs       []string
+		limit           int
+	}
+
+	testCases := []testCase{
+		{
+			name:      "resources are merged and sorted newest first",
+			limit:     10,
+			expectIDs: []string{"mi-1", "group-1", "ws-1", "vp-1", "group-2"},
+		},
+		{
+			name:      "results are trimmed to the limit",
+			limit:     3,
+			expectIDs: []string{"mi-1", "group-1", "ws-1"},
+		},
+		{
+			name:            "limit is out of range",
+			limit:           0,
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:            "caller does not have view permission",
+			limit:           10,
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+			mockGroups := db.NewMockGroups(t)
+			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockVCSProviders := db.NewMockVCSProviders(t)
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.ViewGroupPermission, mock.Anything).Return(test.authError).Maybe()
+
+			if test.expectIDs != nil {
+				mockGroups.On("GetGroupByFullPath", mock.Anything, namespacePath).Return(group, nil)
+				mockGroups.On("GetGroups", mock.Anything, mock.Anything).Return(&db.GroupsResult{Groups: descendantGroups}, nil)
+				mockWorkspaces.On("GetWorkspaces", mock.Anything, mock.Anything).Return(&db.WorkspacesResult{Workspaces: workspaces}, nil)
+				mockManagedIdentities.On("GetManagedIdentities", mock.Anything, mock.MatchedBy(func(input *db.GetManagedIdentitiesInput) bool {
+					return len(input.Filter.NamespacePaths) == 3
+				})).Return(&db.ManagedIdentitiesResult{ManagedIdentities: identities}, nil)
+				mockVCSProviders.On("GetProviders", mock.Anything, mock.Anything).Return(&db.VCSProvidersResult{VCSProviders: providers}, nil)
+			}
+
+			dbClient := &db.Client{
+				Groups:            mockGroups,
+				Workspaces:        mockWorkspaces,
+				ManagedIdentities: mockManagedIdentities,
+				VCSProviders:      mockVCSProviders,
+			}
+
+			service := NewService(nil, dbClient, nil, nil, nil, nil)
+
+			summaries, err := service.GetRecentResources(auth.WithCaller(ctx, mockCaller), namespacePath, test.limit)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actualIDs := []string{}
+			for _, summary := range summaries {
+				actualIDs = append(actualIDs, summary.ID)
+			}
+			assert.Equal(t, test.expectIDs, actualIDs)
+		})
+	}
+}
+
+func TestGetGroupsWithCallerRole(t *testing.T) {
+	userID := "user-1"
+	serviceAccountID := "service-account-1"
+	customRoleID := "custom-role-1"
+
+	parentGroup := &models.Group{
+		Metadata: models.ResourceMetadata{ID: "group-parent"},
+		FullPath: "parent",
+	}
+
+	groups := []models.Group{
+		{Metadata: models.ResourceMetadata{ID: "group-a"}, FullPath: "parent/a"},
+		{Metadata: models.ResourceMetadata{ID: "group-b"}, FullPath: "parent/b"},
+		{Metadata: models.ResourceMetadata{ID: "group-c"}, FullPath: "parent/c"},
+		{Metadata: models.ResourceMetadata{ID: "group-d"}, FullPath: "parent/d"},
+	}
+
+	type testCase struct {
+		name        string
+		callerType  string // "admin", "user", "service-account"
+		memberships []models.NamespaceMembership
+		expectRoles map[string]models.EffectiveRole
+	}
+
+	testCases := []testCase{
+		{
+			name:       "admin caller is owner of every group",
+			callerType: "admin",
+			expectRoles: map[string]models.EffectiveRole{
+				"group-a": models.EffectiveRoleOwner,
+				"group-b": models.EffectiveRoleOwner,
+				"group-c": models.EffectiveRoleOwner,
+				"group-d": models.EffectiveRoleOwner,
+			},
+		},
+		{
+			name:       "user caller with inherited and direct memberships",
+			callerType: "user",
+			memberships: []models.NamespaceMembership{
+				// Inherited by every group
+				{Namespace: models.MembershipNamespace{Path: "parent"}, RoleID: models.ViewerRoleID.String()},
+				// Direct membership with a higher role
+				{Namespace: models.MembershipNamespace{Path: "parent/a"}, RoleID: models.OwnerRoleID.String()},
+				// Multiple memberships in the same group, the highest one wins
+				{Namespace: models.MembershipNamespace{Path: "parent/b"}, RoleID: models.DeployerRoleID.String()},
+				{Namespace: models.MembershipNamespace{Path: "parent/b"}, RoleID: models.ViewerRoleID.String()},
+				// Custom roles grant viewer access
+				{Namespace: models.MembershipNamespace{Path: "parent/c"}, RoleID: customRoleID},
+			},
+			expectRoles: map[string]models.EffectiveRole{
+				"group-a": models.EffectiveRoleOwner,
+				"group-b": models.EffectiveRoleDeployer,
+				"group-c": models.EffectiveRoleViewer,
+				"group-d": models.EffectiveRoleViewer,
+			},
+		},
+		{
+			name:       "user caller with a deployer role inherited from the parent group",
+			callerType: "user",
+			memberships: []models.NamespaceMembership{
+				{Namespace: models.MembershipNamespace{Path: "parent"}, RoleID: models.DeployerRoleID.String()},
+				{Namespace: models.MembershipNamespace{Path: "parent/a"}, RoleID: models.ViewerRoleID.String()},
+			},
+			expectRoles: map[string]models.EffectiveRole{
+				"group-a": models.EffectiveRoleDeployer,
+				"group-b": models.EffectiveRoleDeployer,
+				"group-c": models.EffectiveRoleDeployer,
+				"group-d": models.EffectiveRoleDeployer,
+			},
+		},
+		{
+			name:       "service account caller only has access to some groups",
+			callerType: "service-account",
+			memberships: []models.NamespaceMembership{
+				{Namespace: models.MembershipNamespace{Path: "parent/d"}, RoleID: models.DeployerRoleID.String()},
+			},
+			expectRoles: map[string]models.EffectiveRole{
+				"group-a": models.EffectiveRoleNone,
+				"group-b": models.EffectiveRoleNone,
+				"group-c": models.EffectiveRoleNone,
+				"group-d": models.EffectiveRoleDeployer,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockGroups := db.NewMockGroups(t)
+			mockNamespaceMemberships := db.NewMockNamespaceMemberships(t)
+			mockAuthorizer := auth.NewMockAuthorizer(t)
+			mockMaintenanceMonitor := maintenance.NewMockMonitor(t)
+
+			mockMaintenanceMonitor.On("InMaintenanceMode", mock.Anything).Return(false, nil).Maybe()
+
+			mockAuthorizer.On("RequireAccess", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			mockGroups.On("GetGroups", mock.Anything, mock.Anything).Return(&db.GroupsResult{Groups: groups}, nil)
+
+			if test.callerType != "admin" {
+				mockNamespaceMemberships.On("GetNamespaceMemberships", mock.Anything, mock.MatchedBy(func(input *db.GetNamespaceMembershipsInput) bool {
+					if test.callerType == "user" && (input.Filter.UserID == nil || *input.Filter.UserID != userID) {
+						return false
+					}
+					if test.callerType == "service-account" && (input.Filter.ServiceAccountID == nil || *input.Filter.ServiceAccountID != serviceAccountID) {
+						return false
+					}
+					// The parent path and the path of each group
+					return len(input.Filter.NamespacePaths) == 5
+				})).Return(&db.NamespaceMembershipResult{NamespaceMemberships: test.memberships}, nil)
+			}
+
+			dbClient := &db.Client{
+				Groups:               mockGroups,
+				NamespaceMemberships: mockNamespaceMemberships,
+			}
+
+			var testCaller auth.Caller
+			switch test.callerType {
+			case "admin", "user":
+				testCaller = auth.NewUserCaller(
+					&models.User{
+						Metadata: models.ResourceMetadata{ID: userID},
+						Admin:    test.callerType == "admin",
+						Username: "user1",
+					},
+					mockAuthorizer,
+					dbClient,
+					mockMaintenanceMonitor,
+				)
+			case "service-account":
+				testCaller = auth.NewServiceAccountCaller(
+					serviceAccountID,
+					"parent/sa1",
+					mockAuthorizer,
+					dbClient,
+					mockMaintenanceMonitor,
+				)
+			}
+
+			service := NewService(nil, dbClient, nil, nil, nil, nil)
+
+			result, err := service.GetGroups(auth.WithCaller(ctx, testCaller), &GetGroupsInput{
+				ParentGroup:       parentGroup,
+				IncludeCallerRole: true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, groups, result.Groups)
+			assert.Equal(t, test.expectRoles, result.CallerRoles)
+		})
+	}
+}
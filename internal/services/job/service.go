@@ -543,8 +543,7 @@ func (s *service) ClaimJob(ctx context.Context, runnerPath string) (*ClaimJobRes
 		}
 
 		if job != nil {
-			maxJobDuration := time.Duration(job.MaxJobDuration) * time.Minute
-			expiration := time.Now().Add(maxJobDuration + time.Hour)
+			expiration := time.Now().Add(job.GetMaxJobDuration() + time.Hour)
 			token, err := s.idp.GenerateToken(ctx, &auth.TokenInput{
 				// Expiration is job timeout plus 1 hour to give the job time to gracefully exit
 				Expiration: &expiration,
@@ -829,6 +828,16 @@ func (s *service) getNextAvailableJob(ctx context.Context, runnerID string) (*mo
 		}
 
 		if !ws.Locked {
+			tags, err := rnr.GetEffectiveRunnerTags(ctx, s.dbClient, ws.GroupID)
+			if err != nil {
+				return nil, err
+			}
+
+			// The runner must have all of the workspace's runner tags to claim this job
+			if !runner.HasTags(tags) {
+				continue
+			}
+
 			// Check if this runner has priority to claim this job
 			if runner.Type == models.SharedRunnerType {
 				// Verify that there are no enabled group runners available for this workspace since
@@ -842,7 +851,7 @@ func (s *service) getNextAvailableJob(ctx context.Context, runnerID string) (*mo
 				if err != nil {
 					return nil, err
 				}
-				if len(groupRunners.Runners) != 0 {
+				if len(filterRunnersByTags(groupRunners.Runners, tags)) != 0 {
 					continue
 				}
 			} else {
@@ -865,7 +874,7 @@ func (s *service) getNextAvailableJob(ctx context.Context, runnerID string) (*mo
 					}
 
 					runnerHasPrecedence := true
-					for _, r := range groupRunners.Runners {
+					for _, r := range filterRunnersByTags(groupRunners.Runners, tags) {
 						if len(r.GetGroupPath()) > len(runnerGroupPath) {
 							// There is a runner lower in the hieararchy which as precedence
 							runnerHasPrecedence = false
@@ -890,3 +899,15 @@ func (s *service) getNextAvailableJob(ctx context.Context, runnerID string) (*mo
 	}
 	return nil, nil
 }
+
+// filterRunnersByTags returns the runners that have all of the specified tags since
+// runners without them can't claim the job and therefore don't take precedence
+func filterRunnersByTags(runners []models.Runner, tags []string) []models.Runner {
+	filtered := []models.Runner{}
+	for _, r := range runners {
+		if r.HasTags(tags) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
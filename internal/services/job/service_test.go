@@ -23,6 +23,7 @@ func TestGetNextAvailableJob(t *testing.T) {
 	tests := []struct {
 		runner                *models.Runner
 		workspaceMap          map[string]models.Workspace
+		groupMap              map[string]models.Group
 		expectJobID           string
 		name                  string
 		runners               []models.Runner
@@ -177,6 +178,108 @@ func TestGetNextAvailableJob(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "shared runner should get next job because it has the workspace's runner tags",
+			runner: &models.Runner{
+				Type: models.SharedRunnerType,
+				Tags: []string{"tag1", "tag2"},
+			},
+			queuedJobs: []models.Job{
+				{Metadata: models.ResourceMetadata{ID: "job1"}, WorkspaceID: "ws1"},
+			},
+			runners: []models.Runner{},
+			workspaceMap: map[string]models.Workspace{
+				"ws1": {
+					Locked:   false,
+					FullPath: "group1/group2/ws1",
+					GroupID:  "group2",
+				},
+			},
+			groupMap: map[string]models.Group{
+				// The closest group that defines runner tags is used
+				"group2": {ParentID: "group1"},
+				"group1": {RunnerTags: []string{"tag1"}},
+			},
+			expectJobID: "job1",
+		},
+		{
+			name: "shared runner should not get next job because it's missing the workspace's runner tags",
+			runner: &models.Runner{
+				Type: models.SharedRunnerType,
+				Tags: []string{"tag1"},
+			},
+			queuedJobs: []models.Job{
+				{Metadata: models.ResourceMetadata{ID: "job1"}, WorkspaceID: "ws1"},
+			},
+			runners: []models.Runner{},
+			workspaceMap: map[string]models.Workspace{
+				"ws1": {
+					Locked:   false,
+					FullPath: "group1/ws1",
+					GroupID:  "group1",
+				},
+			},
+			groupMap: map[string]models.Group{
+				"group1": {RunnerTags: []string{"tag1", "tag2"}},
+			},
+		},
+		{
+			name: "shared runner should get next job because the group runner is missing the workspace's runner tags",
+			runner: &models.Runner{
+				Type: models.SharedRunnerType,
+				Tags: []string{"tag1"},
+			},
+			queuedJobs: []models.Job{
+				{Metadata: models.ResourceMetadata{ID: "job1"}, WorkspaceID: "ws1"},
+			},
+			runners: []models.Runner{
+				{ResourcePath: "group1/runner1"},
+			},
+			workspaceMap: map[string]models.Workspace{
+				"ws1": {
+					Locked:   false,
+					FullPath: "group1/ws1",
+					GroupID:  "group1",
+				},
+			},
+			groupMap: map[string]models.Group{
+				"group1": {RunnerTags: []string{"tag1"}},
+			},
+			expectJobID: "job1",
+		},
+		{
+			name: "group runner should get next job because the child group runner is missing the workspace's runner tags",
+			runner: &models.Runner{
+				Type:         models.GroupRunnerType,
+				ResourcePath: "group1/runner1",
+				Tags:         []string{"tag1"},
+			},
+			queuedJobs: []models.Job{
+				{Metadata: models.ResourceMetadata{ID: "job1"}, WorkspaceID: "ws1"},
+			},
+			runners: []models.Runner{
+				{
+					Type:         models.GroupRunnerType,
+					ResourcePath: "group1/runner1",
+					Tags:         []string{"tag1"},
+				},
+				{
+					Type:         models.GroupRunnerType,
+					ResourcePath: "group1/group2/runner1",
+				},
+			},
+			workspaceMap: map[string]models.Workspace{
+				"ws1": {
+					Locked:   false,
+					FullPath: "group1/group2/ws1",
+					GroupID:  "group2",
+				},
+			},
+			groupMap: map[string]models.Group{
+				"group2": {RunnerTags: []string{"tag1"}},
+			},
+			expectJobID: "job1",
+		},
 	}
 
 	for _, test := range tests {
@@ -187,6 +290,12 @@ func TestGetNextAvailableJob(t *testing.T) {
 			mockJobs := db.NewMockJobs(t)
 			mockWorkspace := db.NewMockWorkspaces(t)
 			mockRunners := db.NewMockRunners(t)
+			mockGroups := db.NewMockGroups(t)
+
+			for id, g := range test.groupMap {
+				group := g
+				mockGroups.On("GetGroupByID", ctx, id).Return(&group, nil).Maybe()
+			}
 
 			mockRunners.On("GetRunnerByID", mock.Anything, mock.Anything).Return(test.runner, nil)
 
@@ -215,6 +324,7 @@ func TestGetNextAvailableJob(t *testing.T) {
 					Jobs:       mockJobs,
 					Workspaces: mockWorkspace,
 					Runners:    mockRunners,
+					Groups:     mockGroups,
 				},
 			}
 
@@ -12,6 +12,7 @@ import (
 
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/gid"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/managedidentity/preconditions"
 	te "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/jws"
 )
@@ -29,6 +30,7 @@ type Data struct {
 
 // Delegate for the AWS OIDC Federated managed identity type
 type Delegate struct {
+	preconditions.AlwaysAllowed
 	jwsProvider jws.Provider
 	issuerURL   string
 }
@@ -52,8 +54,7 @@ func (d *Delegate) CreateCredentials(ctx context.Context, identity *models.Manag
 
 	token := jwt.New()
 
-	maxJobDuration := time.Duration(job.MaxJobDuration) * time.Minute
-	if err = token.Set(jwt.ExpirationKey, time.Now().Add(maxJobDuration).Unix()); err != nil {
+	if err = token.Set(jwt.ExpirationKey, time.Now().Add(job.GetMaxJobDuration()).Unix()); err != nil {
 		return nil, err
 	}
 	if err = token.Set(jwt.NotBeforeKey, currentTimestamp); err != nil {
@@ -124,6 +125,17 @@ func (d *Delegate) SetManagedIdentityData(_ context.Context, managedIdentity *mo
 	return nil
 }
 
+// GetInputData returns the managed identity data fields that were supplied by the user,
+// leaving out the ones set by Tharsis such as the subject
+func (d *Delegate) GetInputData(managedIdentity *models.ManagedIdentity) ([]byte, error) {
+	federatedData, err := decodeData(managedIdentity.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&InputData{Role: federatedData.Role})
+}
+
 func decodeData(data []byte) (*Data, error) {
 	decodedData, err := base64.StdEncoding.DecodeString(string(data))
 	if err != nil {
@@ -15,10 +15,29 @@ import (
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/managedidentity/tharsisfederated"
 )
 
+// supportedManagedIdentityTypes is the list of managed identity types that must have a delegate
+var supportedManagedIdentityTypes = []models.ManagedIdentityType{
+	models.ManagedIdentityAzureFederated,
+	models.ManagedIdentityAWSFederated,
+	models.ManagedIdentityTharsisFederated,
+}
+
 // Delegate handles the logic for a specific type of managed identity
 type Delegate interface {
+	// CanCreateCredentials checks any external preconditions for creating credentials, it returns an
+	// EForbidden or EInvalid error when credentials must not be created for the job
+	CanCreateCredentials(ctx context.Context, identity *models.ManagedIdentity, job *models.Job) error
 	CreateCredentials(ctx context.Context, identity *models.ManagedIdentity, job *models.Job) ([]byte, error)
 	SetManagedIdentityData(ctx context.Context, managedIdentity *models.ManagedIdentity, input []byte) error
+	// GetInputData returns the user supplied data fields, which excludes fields that differ for each
+	// managed identity such as the subject
+	GetInputData(managedIdentity *models.ManagedIdentity) ([]byte, error)
+}
+
+// HealthChecker can optionally be implemented by a delegate to report whether the
+// external systems it depends on are available
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
 }
 
 // NewManagedIdentityDelegateMap creates a map containing a delegate for each managed identity type
@@ -36,9 +55,26 @@ func NewManagedIdentityDelegateMap(ctx context.Context, cfg *config.Config, plug
 		return nil, fmt.Errorf("failed to initialize %s managed identity handler %v", models.ManagedIdentityTharsisFederated, err)
 	}
 
-	return map[models.ManagedIdentityType]Delegate{
+	delegateMap := map[models.ManagedIdentityType]Delegate{
 		models.ManagedIdentityAzureFederated:   azureHandler,
 		models.ManagedIdentityAWSFederated:     awsHandler,
 		models.ManagedIdentityTharsisFederated: tharsisHandler,
-	}, nil
+	}
+
+	// The managed identity service assumes every supported type has a delegate
+	if err = validateDelegateMap(delegateMap); err != nil {
+		return nil, fmt.Errorf("invalid managed identity delegate map %v", err)
+	}
+
+	return delegateMap, nil
+}
+
+// validateDelegateMap returns an error if any supported managed identity type is missing a delegate
+func validateDelegateMap(delegateMap map[models.ManagedIdentityType]Delegate) error {
+	for _, identityType := range supportedManagedIdentityTypes {
+		if delegate, ok := delegateMap[identityType]; !ok || delegate == nil {
+			return fmt.Errorf("managed identity delegate is missing for type %s", identityType)
+		}
+	}
+	return nil
 }
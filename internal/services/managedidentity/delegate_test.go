@@ -0,0 +1,59 @@
+package managedidentity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+)
+
+func TestValidateDelegateMap(t *testing.T) {
+	type testCase struct {
+		name        string
+		delegateMap map[models.ManagedIdentityType]Delegate
+		expectError bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "all supported types have a delegate",
+			delegateMap: map[models.ManagedIdentityType]Delegate{
+				models.ManagedIdentityAzureFederated:   NewMockDelegate(t),
+				models.ManagedIdentityAWSFederated:     NewMockDelegate(t),
+				models.ManagedIdentityTharsisFederated: NewMockDelegate(t),
+			},
+		},
+		{
+			name: "a supported type is missing a delegate",
+			delegateMap: map[models.ManagedIdentityType]Delegate{
+				models.ManagedIdentityAzureFederated: NewMockDelegate(t),
+				models.ManagedIdentityAWSFederated:   NewMockDelegate(t),
+			},
+			expectError: true,
+		},
+		{
+			name: "a supported type has a nil delegate",
+			delegateMap: map[models.ManagedIdentityType]Delegate{
+				models.ManagedIdentityAzureFederated:   NewMockDelegate(t),
+				models.ManagedIdentityAWSFederated:     NewMockDelegate(t),
+				models.ManagedIdentityTharsisFederated: nil,
+			},
+			expectError: true,
+		},
+		{
+			name:        "delegate map is nil",
+			expectError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateDelegateMap(test.delegateMap)
+			if test.expectError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -14,6 +14,20 @@ type MockDelegate struct {
 	mock.Mock
 }
 
+// CanCreateCredentials provides a mock function with given fields: ctx, identity, job
+func (_m *MockDelegate) CanCreateCredentials(ctx context.Context, identity *models.ManagedIdentity, job *models.Job) error {
+	ret := _m.Called(ctx, identity, job)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ManagedIdentity, *models.Job) error); ok {
+		r0 = rf(ctx, identity, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateCredentials provides a mock function with given fields: ctx, identity, job
 func (_m *MockDelegate) CreateCredentials(ctx context.Context, identity *models.ManagedIdentity, job *models.Job) ([]byte, error) {
 	ret := _m.Called(ctx, identity, job)
@@ -40,6 +54,32 @@ func (_m *MockDelegate) CreateCredentials(ctx context.Context, identity *models.
 	return r0, r1
 }
 
+// GetInputData provides a mock function with given fields: managedIdentity
+func (_m *MockDelegate) GetInputData(managedIdentity *models.ManagedIdentity) ([]byte, error) {
+	ret := _m.Called(managedIdentity)
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.ManagedIdentity) ([]byte, error)); ok {
+		return rf(managedIdentity)
+	}
+	if rf, ok := ret.Get(0).(func(*models.ManagedIdentity) []byte); ok {
+		r0 = rf(managedIdentity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.ManagedIdentity) error); ok {
+		r1 = rf(managedIdentity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetManagedIdentityData provides a mock function with given fields: ctx, managedIdentity, input
 func (_m *MockDelegate) SetManagedIdentityData(ctx context.Context, managedIdentity *models.ManagedIdentity, input []byte) error {
 	ret := _m.Called(ctx, managedIdentity, input)
@@ -0,0 +1,17 @@
+// Package preconditions package
+package preconditions
+
+import (
+	"context"
+
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+)
+
+// AlwaysAllowed can be embedded in a managed identity delegate for a type
+// that has no external preconditions for creating credentials
+type AlwaysAllowed struct{}
+
+// CanCreateCredentials always allows credentials to be created
+func (AlwaysAllowed) CanCreateCredentials(_ context.Context, _ *models.ManagedIdentity, _ *models.Job) error {
+	return nil
+}
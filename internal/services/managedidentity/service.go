@@ -2,7 +2,9 @@ package managedidentity
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/smithy-go/ptr"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/auth"
@@ -10,8 +12,10 @@ import (
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/db"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/limits"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/reservednames"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/activityevent"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/job"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/run/rules"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/workspace"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
@@ -19,6 +23,13 @@ import (
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/pagination"
 )
 
+// maxManagedIdentityDataSize is the max size in bytes of the data that can be set for each managed identity type
+var maxManagedIdentityDataSize = map[models.ManagedIdentityType]int{
+	models.ManagedIdentityAWSFederated:     4 * 1024,
+	models.ManagedIdentityAzureFederated:   4 * 1024,
+	models.ManagedIdentityTharsisFederated: 4 * 1024,
+}
+
 // GetManagedIdentitiesInput is the input for listing managed identities
 type GetManagedIdentitiesInput struct {
 	// Sort specifies the field to sort on and direction
@@ -26,6 +37,7 @@ type GetManagedIdentitiesInput struct {
 	// PaginationOptions supports cursor based pagination
 	PaginationOptions *pagination.Options
 	// Search returns only the managed identities with a name or resource path that starts with the value of search
+	// or a description that contains the value of search
 	Search *string
 	// AliasSourceID is used to return aliases for a given managed identity
 	AliasSourceID *string
@@ -33,6 +45,71 @@ type GetManagedIdentitiesInput struct {
 	NamespacePath string
 	// IncludeInherited includes inherited managed identities in the result
 	IncludeInherited bool
+	// IncludeAssignedWorkspaceCount includes the number of workspaces each managed identity is assigned to
+	IncludeAssignedWorkspaceCount bool
+	// UnusedSince returns only the managed identities that have never been used or were last used before this time
+	UnusedSince *time.Time
+	// AssignableToWorkspaceID returns only the managed identities that the caller can assign to this workspace
+	AssignableToWorkspaceID *string
+	// ResolveAliasSource populates the AliasSource field of each alias in the result
+	ResolveAliasSource bool
+	// AliasesOnly returns only aliases when true and only source managed identities when false
+	AliasesOnly *bool
+}
+
+// GetPaginatedManagedIdentitiesByIDsInput is the input for querying a paginated list of managed identities by ID
+type GetPaginatedManagedIdentitiesByIDsInput struct {
+	// Sort specifies the field to sort on and direction
+	Sort *db.ManagedIdentitySortableField
+	// PaginationOptions supports cursor based pagination
+	PaginationOptions *pagination.Options
+	// IDs is the list of managed identity IDs to return
+	IDs []string
+	// SkipUnauthorized filters out the managed identities the caller isn't allowed to view
+	// instead of failing the whole request
+	SkipUnauthorized bool
+}
+
+// GetPaginatedManagedIdentitiesForWorkspaceInput is the input for querying a paginated list of the managed identities assigned to a workspace
+type GetPaginatedManagedIdentitiesForWorkspaceInput struct {
+	// Sort specifies the field to sort on and direction
+	Sort *db.ManagedIdentitySortableField
+	// PaginationOptions supports cursor based pagination
+	PaginationOptions *pagination.Options
+	// WorkspaceID is the workspace the managed identities are assigned to
+	WorkspaceID string
+}
+
+// getManagedIdentityByIDOptions contains the optional behavior for GetManagedIdentityByID
+type getManagedIdentityByIDOptions struct {
+	notFoundWhenForbidden bool
+}
+
+// GetManagedIdentityByIDOption is used to configure the behavior of GetManagedIdentityByID
+type GetManagedIdentityByIDOption func(*getManagedIdentityByIDOptions)
+
+// WithNotFoundWhenForbidden returns a not found error instead of a forbidden error when the caller
+// doesn't have access to the managed identity, so the existence of the identity isn't disclosed.
+func WithNotFoundWhenForbidden() GetManagedIdentityByIDOption {
+	return func(o *getManagedIdentityByIDOptions) {
+		o.notFoundWhenForbidden = true
+	}
+}
+
+// ManagedIdentityWithRules is a managed identity along with its access rules
+type ManagedIdentityWithRules struct {
+	ManagedIdentity *models.ManagedIdentity
+	AccessRules     []models.ManagedIdentityAccessRule
+}
+
+// ManagedIdentityAccessRuleWithPrincipalNames is an access rule along with the display names
+// of its allowed principals. Each name slice is parallel to the matching ID slice on the rule,
+// an empty string is used for a principal that no longer exists.
+type ManagedIdentityAccessRuleWithPrincipalNames struct {
+	AccessRule                 *models.ManagedIdentityAccessRule
+	AllowedUserNames           []string
+	AllowedServiceAccountPaths []string
+	AllowedTeamNames           []string
 }
 
 // DeleteManagedIdentityInput is the input for deleting a managed identity or alias.
@@ -57,6 +134,8 @@ type CreateManagedIdentityInput struct {
 		AllowedTeamIDs            []string
 		VerifyStateLineage        bool
 	}
+	// RequireAccessRulesForAllRunStages returns an error if the access rules don't include a rule for every run stage
+	RequireAccessRulesForAllRunStages bool
 }
 
 // UpdateManagedIdentityInput contains the fields for updating a managed identity
@@ -71,6 +150,15 @@ type CreateManagedIdentityAliasInput struct {
 	Group         *models.Group
 	Name          string
 	AliasSourceID string
+	// CopyAccessRules is not supported: an alias never has access rules of its own,
+	// they are always resolved from the alias source, so setting this returns an error.
+	CopyAccessRules bool
+}
+
+// SetDeletionProtectionInput is the input for enabling or disabling deletion protection on a managed identity.
+type SetDeletionProtectionInput struct {
+	ManagedIdentityID string
+	Enabled           bool
 }
 
 // MoveManagedIdentityInput is the input for moving a managed identity to a new group.
@@ -79,28 +167,70 @@ type MoveManagedIdentityInput struct {
 	NewGroupID        string
 }
 
+// MovePreview describes what would prevent a managed identity from being moved to a new group.
+type MovePreview struct {
+	// InvalidAliases are the aliases that would be in, above or below the new group after the move.
+	InvalidAliases []models.ManagedIdentity
+	// AssignmentsOutsideGroup are the workspaces the managed identity is assigned to that are outside the new group.
+	AssignmentsOutsideGroup []models.Workspace
+}
+
+// CloneManagedIdentityInput is the input for cloning a managed identity into another group.
+type CloneManagedIdentityInput struct {
+	ManagedIdentityID string
+	TargetGroupID     string
+	// Name is optional; the clone keeps the source managed identity's name when empty.
+	Name string
+}
+
+// GetRunsForManagedIdentityInput is the input for listing the runs that used a managed identity.
+type GetRunsForManagedIdentityInput struct {
+	// Sort specifies the field to sort on and direction
+	Sort *db.RunSortableField
+	// PaginationOptions supports cursor based pagination
+	PaginationOptions *pagination.Options
+	// ManagedIdentityID is the managed identity the runs were issued credentials for
+	ManagedIdentityID string
+}
+
 // Service implements managed identity functionality
 type Service interface {
-	GetManagedIdentityByID(ctx context.Context, id string) (*models.ManagedIdentity, error)
+	GetManagedIdentityByID(ctx context.Context, id string, opts ...GetManagedIdentityByIDOption) (*models.ManagedIdentity, error)
+	GetManagedIdentityByIDWithRules(ctx context.Context, id string) (*ManagedIdentityWithRules, error)
 	GetManagedIdentityByPath(ctx context.Context, path string) (*models.ManagedIdentity, error)
 	GetManagedIdentities(ctx context.Context, input *GetManagedIdentitiesInput) (*db.ManagedIdentitiesResult, error)
+	GetManagedIdentityTypesInNamespace(ctx context.Context, namespacePath string) ([]models.ManagedIdentityType, error)
+	FindDuplicateManagedIdentityData(ctx context.Context, groupID string) ([][]string, error)
 	GetManagedIdentitiesByIDs(ctx context.Context, ids []string) ([]models.ManagedIdentity, error)
+	GetPaginatedManagedIdentitiesByIDs(ctx context.Context, input *GetPaginatedManagedIdentitiesByIDsInput) (*db.ManagedIdentitiesResult, error)
 	CreateManagedIdentity(ctx context.Context, input *CreateManagedIdentityInput) (*models.ManagedIdentity, error)
 	UpdateManagedIdentity(ctx context.Context, input *UpdateManagedIdentityInput) (*models.ManagedIdentity, error)
+	SetDeletionProtection(ctx context.Context, input *SetDeletionProtectionInput) (*models.ManagedIdentity, error)
 	DeleteManagedIdentity(ctx context.Context, input *DeleteManagedIdentityInput) error
 	CreateCredentials(ctx context.Context, identity *models.ManagedIdentity) ([]byte, error)
 	GetManagedIdentitiesForWorkspace(ctx context.Context, workspaceID string) ([]models.ManagedIdentity, error)
+	GetPaginatedManagedIdentitiesForWorkspace(ctx context.Context, input *GetPaginatedManagedIdentitiesForWorkspaceInput) (*db.ManagedIdentitiesResult, error)
+	GetManagedIdentitiesForServiceAccount(ctx context.Context, serviceAccountID string) ([]models.ManagedIdentity, error)
+	GetOrphanedManagedIdentityAliases(ctx context.Context) ([]models.ManagedIdentity, error)
 	AddManagedIdentityToWorkspace(ctx context.Context, managedIdentityID string, workspaceID string) error
 	RemoveManagedIdentityFromWorkspace(ctx context.Context, managedIdentityID string, workspaceID string) error
 	GetManagedIdentityAccessRules(ctx context.Context, managedIdentity *models.ManagedIdentity) ([]models.ManagedIdentityAccessRule, error)
 	GetManagedIdentityAccessRulesByIDs(ctx context.Context, ids []string) ([]models.ManagedIdentityAccessRule, error)
 	GetManagedIdentityAccessRule(ctx context.Context, ruleID string) (*models.ManagedIdentityAccessRule, error)
+	GetManagedIdentityAccessRuleWithPrincipalNames(ctx context.Context, ruleID string) (*ManagedIdentityAccessRuleWithPrincipalNames, error)
 	CreateManagedIdentityAccessRule(ctx context.Context, input *models.ManagedIdentityAccessRule) (*models.ManagedIdentityAccessRule, error)
 	UpdateManagedIdentityAccessRule(ctx context.Context, input *models.ManagedIdentityAccessRule) (*models.ManagedIdentityAccessRule, error)
 	DeleteManagedIdentityAccessRule(ctx context.Context, rule *models.ManagedIdentityAccessRule) error
+	ReplaceManagedIdentityAccessRules(ctx context.Context, identityID string, rules []*models.ManagedIdentityAccessRule) error
 	CreateManagedIdentityAlias(ctx context.Context, input *CreateManagedIdentityAliasInput) (*models.ManagedIdentity, error)
 	DeleteManagedIdentityAlias(ctx context.Context, input *DeleteManagedIdentityInput) error
 	MoveManagedIdentity(ctx context.Context, input *MoveManagedIdentityInput) (*models.ManagedIdentity, error)
+	PreviewMoveManagedIdentity(ctx context.Context, input *MoveManagedIdentityInput) (*MovePreview, error)
+	CloneManagedIdentity(ctx context.Context, input *CloneManagedIdentityInput) (*models.ManagedIdentity, error)
+	GetRunsForManagedIdentity(ctx context.Context, input *GetRunsForManagedIdentityInput) (*db.RunsResult, error)
+	GetAssignableGroupsForManagedIdentity(ctx context.Context, identityID string) ([]models.Group, error)
+	CanAssumeManagedIdentity(ctx context.Context, identityID, workspaceID string, stage models.JobType, principal auth.Caller) (bool, string, error)
+	DelegatesHealth(ctx context.Context) (map[models.ManagedIdentityType]error, error)
 }
 
 type service struct {
@@ -111,6 +241,22 @@ type service struct {
 	workspaceService workspace.Service
 	jobService       job.Service
 	activityService  activityevent.Service
+	// limitActivityEventsEnabled creates an activity event when a request is rejected by a resource limit
+	limitActivityEventsEnabled bool
+	// reservedNames are the names that can't be used for managed identities
+	reservedNames reservednames.Names
+	// maxPageSize caps the number of managed identities returned in a single page; zero means no cap
+	maxPageSize int32
+}
+
+// ServiceOptions contains the optional settings for the managed identity service
+type ServiceOptions struct {
+	// ReservedNames are the names that can't be used for managed identities
+	ReservedNames reservednames.Names
+	// MaxPageSize is the maximum number of managed identities returned in a page, 0 means no maximum
+	MaxPageSize int32
+	// LimitActivityEventsEnabled creates an activity event whenever a resource limit is exceeded
+	LimitActivityEventsEnabled bool
 }
 
 // NewService creates an instance of Service
@@ -122,15 +268,19 @@ func NewService(
 	workspaceService workspace.Service,
 	jobService job.Service,
 	activityService activityevent.Service,
+	options ServiceOptions,
 ) Service {
 	return &service{
-		logger:           logger,
-		dbClient:         dbClient,
-		limitChecker:     limitChecker,
-		delegateMap:      managedIdentityDelegateMap,
-		workspaceService: workspaceService,
-		jobService:       jobService,
-		activityService:  activityService,
+		logger:                     logger,
+		dbClient:                   dbClient,
+		limitChecker:               limitChecker,
+		delegateMap:                managedIdentityDelegateMap,
+		workspaceService:           workspaceService,
+		jobService:                 jobService,
+		activityService:            activityService,
+		limitActivityEventsEnabled: options.LimitActivityEventsEnabled,
+		reservedNames:              options.ReservedNames,
+		maxPageSize:                options.MaxPageSize,
 	}
 }
 
@@ -145,12 +295,27 @@ func (s *service) GetManagedIdentities(ctx context.Context, input *GetManagedIde
 		return nil, err
 	}
 
+	var assignableToWorkspace *models.Workspace
+	if input.AssignableToWorkspaceID != nil {
+		// Same permission that's required to assign a managed identity to the workspace.
+		if err = caller.RequirePermission(ctx, permissions.UpdateWorkspacePermission, auth.WithWorkspaceID(*input.AssignableToWorkspaceID)); err != nil {
+			tracing.RecordError(span, err, "permission check failed")
+			return nil, err
+		}
+
+		assignableToWorkspace, err = s.workspaceService.GetWorkspaceByID(ctx, *input.AssignableToWorkspaceID)
+		if err != nil {
+			tracing.RecordError(span, err, "failed to get workspace by ID")
+			return nil, err
+		}
+	}
+
 	if input.NamespacePath != "" {
 		if err = caller.RequirePermission(ctx, permissions.ViewManagedIdentityPermission, auth.WithNamespacePath(input.NamespacePath)); err != nil {
 			tracing.RecordError(span, err, "permission check failed")
 			return nil, err
 		}
-	} else if input.AliasSourceID != nil {
+	} else if input.AliasSourceID != nil && assignableToWorkspace == nil {
 		sourceIdentity, gErr := s.getManagedIdentityByID(ctx, *input.AliasSourceID)
 		if gErr != nil {
 			tracing.RecordError(span, gErr, "failed to get managed identity by ID")
@@ -161,45 +326,180 @@ func (s *service) GetManagedIdentities(ctx context.Context, input *GetManagedIde
 			tracing.RecordError(span, err, "permission check failed")
 			return nil, err
 		}
-	} else {
+	} else if assignableToWorkspace == nil {
 		return nil, errors.New("Either NamespacePath or AliasSourceID must be defined", errors.WithErrorCode(errors.EInvalid))
 	}
 
 	filter := &db.ManagedIdentityFilter{
 		Search:        input.Search,
 		AliasSourceID: input.AliasSourceID,
+		UnusedSince:   input.UnusedSince,
+		AliasesOnly:   input.AliasesOnly,
 	}
 
 	if input.IncludeInherited {
-		pathParts := strings.Split(input.NamespacePath, "/")
-
-		paths := []string{}
-		for len(pathParts) > 0 {
-			paths = append(paths, strings.Join(pathParts, "/"))
-			// Remove last element
-			pathParts = pathParts[:len(pathParts)-1]
-		}
-
-		filter.NamespacePaths = paths
+		filter.NamespacePaths = models.ExpandGroupPath(input.NamespacePath)
 	} else if input.NamespacePath != "" {
 		// This will return an empty result for workspace namespaces because workspaces
 		// don't have managed identities directly associated (i.e. only group namespaces do)
 		filter.NamespacePaths = []string{input.NamespacePath}
 	}
 
+	if assignableToWorkspace != nil {
+		// Only managed identities in the workspace's group hierarchy can be assigned to it.
+		assignablePaths := models.ExpandGroupPath(assignableToWorkspace.GetGroupPath())
+		if input.NamespacePath != "" {
+			assignablePaths = intersectPaths(assignablePaths, filter.NamespacePaths)
+		}
+		filter.NamespacePaths = assignablePaths
+		// Identities that are already assigned to the workspace can't be assigned again.
+		filter.NotAssignedToWorkspaceID = &assignableToWorkspace.Metadata.ID
+	}
+
 	result, err := s.dbClient.ManagedIdentities.GetManagedIdentities(ctx, &db.GetManagedIdentitiesInput{
-		Sort:              input.Sort,
-		PaginationOptions: input.PaginationOptions,
-		Filter:            filter,
+		Sort:                          input.Sort,
+		PaginationOptions:             s.capPageSize(input.PaginationOptions),
+		Filter:                        filter,
+		IncludeAssignedWorkspaceCount: input.IncludeAssignedWorkspaceCount,
 	})
 	if err != nil {
 		tracing.RecordError(span, err, "failed to get managed identities")
 		return nil, err
 	}
 
+	if input.ResolveAliasSource {
+		if err = s.resolveAliasSources(ctx, result.ManagedIdentities); err != nil {
+			tracing.RecordError(span, err, "failed to resolve alias sources")
+			return nil, err
+		}
+	}
+
 	return result, nil
 }
 
+// GetManagedIdentityTypesInNamespace returns the distinct types of the managed identities available
+// in a namespace, including the ones inherited from its ancestor groups.
+func (s *service) GetManagedIdentityTypesInNamespace(ctx context.Context, namespacePath string) ([]models.ManagedIdentityType, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetManagedIdentityTypesInNamespace")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	if err = caller.RequirePermission(ctx, permissions.ViewManagedIdentityPermission, auth.WithNamespacePath(namespacePath)); err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	types, err := s.dbClient.ManagedIdentities.GetManagedIdentityTypes(ctx, &db.ManagedIdentityFilter{
+		NamespacePaths: models.ExpandGroupPath(namespacePath),
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity types")
+		return nil, err
+	}
+
+	return types, nil
+}
+
+// FindDuplicateManagedIdentityData returns groups of IDs of the managed identities in a group that have the
+// same type and user supplied data, so they can be consolidated.
+func (s *service) FindDuplicateManagedIdentityData(ctx context.Context, groupID string) ([][]string, error) {
+	ctx, span := tracer.Start(ctx, "svc.FindDuplicateManagedIdentityData")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	if err = caller.RequirePermission(ctx, permissions.ViewManagedIdentityPermission, auth.WithGroupID(groupID)); err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	// Aliases are excluded since they don't have data of their own.
+	result, err := s.dbClient.ManagedIdentities.GetManagedIdentities(ctx, &db.GetManagedIdentitiesInput{
+		Filter: &db.ManagedIdentityFilter{
+			GroupID:     &groupID,
+			AliasesOnly: ptr.Bool(false),
+		},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identities")
+		return nil, err
+	}
+
+	// The stored data includes fields that differ for each managed identity, such as the subject,
+	// so only the data supplied by the user is compared.
+	duplicates := [][]string{}
+	duplicateIndexes := map[string]int{}
+	for _, identity := range result.ManagedIdentities {
+		identityCopy := identity
+
+		delegate, dErr := s.getDelegate(identity.Type)
+		if dErr != nil {
+			tracing.RecordError(span, dErr, "failed to get managed identity delegate")
+			return nil, dErr
+		}
+
+		inputData, dErr := delegate.GetInputData(&identityCopy)
+		if dErr != nil {
+			tracing.RecordError(span, dErr, "failed to get managed identity input data")
+			return nil, dErr
+		}
+
+		key := fmt.Sprintf("%s:%s", identity.Type, inputData)
+		if index, ok := duplicateIndexes[key]; ok {
+			duplicates[index] = append(duplicates[index], identity.Metadata.ID)
+			continue
+		}
+
+		duplicateIndexes[key] = len(duplicates)
+		duplicates = append(duplicates, []string{identity.Metadata.ID})
+	}
+
+	results := [][]string{}
+	for _, ids := range duplicates {
+		if len(ids) > 1 {
+			results = append(results, ids)
+		}
+	}
+
+	return results, nil
+}
+
+// GetOrphanedManagedIdentityAliases returns aliases whose source managed identity no longer exists so they can be cleaned up.
+func (s *service) GetOrphanedManagedIdentityAliases(ctx context.Context) ([]models.ManagedIdentity, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetOrphanedManagedIdentityAliases")
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	if _, ok := caller.(*auth.SystemCaller); !ok {
+		tracing.RecordError(span, nil, "only the system caller can get orphaned managed identity aliases")
+		return nil, errors.New("Only the system caller can get orphaned managed identity aliases", errors.WithErrorCode(errors.EForbidden))
+	}
+
+	aliases, err := s.dbClient.ManagedIdentities.GetOrphanedManagedIdentityAliases(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get orphaned managed identity aliases")
+		return nil, err
+	}
+
+	return aliases, nil
+}
+
 func (s *service) DeleteManagedIdentity(ctx context.Context, input *DeleteManagedIdentityInput) error {
 	ctx, span := tracer.Start(ctx, "svc.DeleteManagedIdentity")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -222,6 +522,12 @@ func (s *service) DeleteManagedIdentity(ctx context.Context, input *DeleteManage
 		return err
 	}
 
+	// Protection must be removed explicitly first, force does not bypass it.
+	if input.ManagedIdentity.DeletionProtected {
+		tracing.RecordError(span, nil, "deletion protection enabled")
+		return errors.New("deletion protection enabled", errors.WithErrorCode(errors.EConflict))
+	}
+
 	s.logger.Infow("Requested to delete a managed identity.",
 		"caller", caller.GetSubject(),
 		"groupID", input.ManagedIdentity.GroupID,
@@ -309,6 +615,91 @@ func (s *service) GetManagedIdentitiesForWorkspace(ctx context.Context, workspac
 	return identities, nil
 }
 
+func (s *service) GetPaginatedManagedIdentitiesForWorkspace(ctx context.Context, input *GetPaginatedManagedIdentitiesForWorkspaceInput) (*db.ManagedIdentitiesResult, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetPaginatedManagedIdentitiesForWorkspace")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	err = caller.RequirePermission(ctx, permissions.ViewManagedIdentityPermission, auth.WithWorkspaceID(input.WorkspaceID))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	// Keyset pagination with the managed identity ID as the tiebreak keeps pages stable
+	// when identities are assigned to the workspace between requests.
+	results, err := s.dbClient.ManagedIdentities.GetManagedIdentities(ctx, &db.GetManagedIdentitiesInput{
+		Sort:              input.Sort,
+		PaginationOptions: s.capPageSize(input.PaginationOptions),
+		Filter: &db.ManagedIdentityFilter{
+			WorkspaceID: &input.WorkspaceID,
+		},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identities for workspace")
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (s *service) GetManagedIdentitiesForServiceAccount(ctx context.Context, serviceAccountID string) ([]models.ManagedIdentity, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetManagedIdentitiesForServiceAccount")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	sa, err := s.dbClient.ServiceAccounts.GetServiceAccountByID(ctx, serviceAccountID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get service account by ID")
+		return nil, err
+	}
+
+	if sa == nil {
+		tracing.RecordError(span, nil, "service account not found")
+		return nil, errors.New("service account with ID %s not found", serviceAccountID, errors.WithErrorCode(errors.ENotFound))
+	}
+
+	err = caller.RequirePermission(ctx, permissions.ViewServiceAccountPermission, auth.WithGroupID(sa.GroupID))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	identities, err := s.dbClient.ManagedIdentities.GetManagedIdentitiesForServiceAccount(ctx, serviceAccountID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identities for service account")
+		return nil, err
+	}
+
+	// Only return the identities the caller is allowed to view.
+	accessibleIdentities := []models.ManagedIdentity{}
+	for _, identity := range identities {
+		if err = caller.RequireAccessToInheritableResource(ctx, permissions.ManagedIdentityResourceType, auth.WithGroupID(identity.GroupID)); err != nil {
+			if errors.ErrorCode(err) == errors.EForbidden {
+				continue
+			}
+			tracing.RecordError(span, err, "inheritable resource access check failed")
+			return nil, err
+		}
+
+		accessibleIdentities = append(accessibleIdentities, identity)
+	}
+
+	return accessibleIdentities, nil
+}
+
 func (s *service) AddManagedIdentityToWorkspace(ctx context.Context, managedIdentityID string, workspaceID string) error {
 	ctx, span := tracer.Start(ctx, "svc.AddManagedIdentityToWorkspace")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -348,6 +739,11 @@ func (s *service) AddManagedIdentityToWorkspace(ctx context.Context, managedIden
 		return errors.New("managed identity %s is not available to workspace %s", managedIdentityID, workspaceID, errors.WithErrorCode(errors.EInvalid))
 	}
 
+	// Aliases have the same type as their source identity
+	if !workspace.IsManagedIdentityTypeAllowed(identity.Type) {
+		return errors.New("managed identity type %s is not allowed in workspace %s", identity.Type, workspaceID, errors.WithErrorCode(errors.EInvalid))
+	}
+
 	identitiesInWorkspace, err := s.GetManagedIdentitiesForWorkspace(ctx, workspaceID)
 	if err != nil {
 		tracing.RecordError(span, err, "failed to get managed identities for workspace")
@@ -390,6 +786,7 @@ func (s *service) AddManagedIdentityToWorkspace(ctx context.Context, managedIden
 	if err = s.limitChecker.CheckLimit(txContext,
 		limits.ResourceLimitAssignedManagedIdentitiesPerWorkspace, int32(len(newManagedIdentities))); err != nil {
 		tracing.RecordError(span, err, "limit check failed")
+		s.createLimitExceededActivityEvent(ctx, err, workspace.FullPath, models.TargetManagedIdentity, identity.Metadata.ID)
 		return err
 	}
 
@@ -501,11 +898,16 @@ func (s *service) RemoveManagedIdentityFromWorkspace(ctx context.Context, manage
 	return nil
 }
 
-func (s *service) GetManagedIdentityByID(ctx context.Context, id string) (*models.ManagedIdentity, error) {
+func (s *service) GetManagedIdentityByID(ctx context.Context, id string, opts ...GetManagedIdentityByIDOption) (*models.ManagedIdentity, error) {
 	ctx, span := tracer.Start(ctx, "svc.GetManagedIdentityByID")
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	options := &getManagedIdentityByIDOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	caller, err := auth.AuthorizeCaller(ctx)
 	if err != nil {
 		tracing.RecordError(span, err, "caller authorization failed")
@@ -522,12 +924,44 @@ func (s *service) GetManagedIdentityByID(ctx context.Context, id string) (*model
 	err = caller.RequireAccessToInheritableResource(ctx, permissions.ManagedIdentityResourceType, auth.WithGroupID(identity.GroupID))
 	if err != nil {
 		tracing.RecordError(span, err, "inheritable resource access check failed")
+		if options.notFoundWhenForbidden && errors.ErrorCode(err) == errors.EForbidden {
+			// Return the same error as a non-existent identity to avoid disclosing that it exists.
+			return nil, errors.New("managed identity with ID %s not found", id, errors.WithErrorCode(errors.ENotFound))
+		}
 		return nil, err
 	}
 
 	return identity, nil
 }
 
+func (s *service) GetManagedIdentityByIDWithRules(ctx context.Context, id string) (*ManagedIdentityWithRules, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetManagedIdentityByIDWithRules")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	// Authorization is performed once here and covers the access rules as well.
+	identity, err := s.GetManagedIdentityByID(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity by ID")
+		return nil, err
+	}
+
+	resp, err := s.dbClient.ManagedIdentities.GetManagedIdentityAccessRules(ctx, &db.GetManagedIdentityAccessRulesInput{
+		Filter: &db.ManagedIdentityAccessRuleFilter{
+			ManagedIdentityID: &identity.Metadata.ID,
+		},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity access rules")
+		return nil, err
+	}
+
+	return &ManagedIdentityWithRules{
+		ManagedIdentity: identity,
+		AccessRules:     resp.ManagedIdentityAccessRules,
+	}, nil
+}
+
 func (s *service) GetManagedIdentityByPath(ctx context.Context, path string) (*models.ManagedIdentity, error) {
 	ctx, span := tracer.Start(ctx, "svc.GetManagedIdentityByPath")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -574,6 +1008,15 @@ func (s *service) CreateManagedIdentityAlias(ctx context.Context, input *CreateM
 		return nil, err
 	}
 
+	// Access rules always resolve to the alias source, so they can't be copied onto an alias.
+	if input.CopyAccessRules {
+		tracing.RecordError(span, nil, "access rules cannot be copied to an alias")
+		return nil, errors.New(
+			"Access rules cannot be copied to an alias managed identity; an alias always uses the access rules of its source managed identity",
+			errors.WithErrorCode(errors.EInvalid),
+		)
+	}
+
 	// Require permissions for target group (group being shared to).
 	err = caller.RequirePermission(ctx, permissions.CreateManagedIdentityPermission, auth.WithGroupID(input.Group.Metadata.ID))
 	if err != nil {
@@ -648,40 +1091,33 @@ func (s *service) CreateManagedIdentityAlias(ctx context.Context, input *CreateM
 	groupPath := createdAlias.GetGroupPath()
 
 	// Get the number of managed identities in the group to check whether we just violated the limit.
-	newManagedIdentities, err := s.dbClient.ManagedIdentities.GetManagedIdentities(txContext, &db.GetManagedIdentitiesInput{
-		Filter: &db.ManagedIdentityFilter{
-			NamespacePaths: []string{groupPath},
-		},
-		PaginationOptions: &pagination.Options{
-			First: ptr.Int32(0),
-		},
+	managedIdentityCount, err := s.dbClient.ManagedIdentities.GetManagedIdentityCount(txContext, &db.ManagedIdentityFilter{
+		NamespacePaths: []string{groupPath},
 	})
 	if err != nil {
 		tracing.RecordError(span, err, "failed to get group's managed identities")
 		return nil, err
 	}
 	if err = s.limitChecker.CheckLimit(txContext,
-		limits.ResourceLimitManagedIdentitiesPerGroup, newManagedIdentities.PageInfo.TotalCount); err != nil {
+		limits.ResourceLimitManagedIdentitiesPerGroup, managedIdentityCount, limits.WithGroupPath(groupPath)); err != nil {
 		tracing.RecordError(span, err, "limit check failed")
+		s.createLimitExceededActivityEvent(ctx, err, groupPath, models.TargetGroup, createdAlias.GroupID)
 		return nil, err
 	}
 
 	// Get the number of aliases for the source managed identity to check whether we just violated the limit.
-	newAliases, err := s.dbClient.ManagedIdentities.GetManagedIdentities(txContext, &db.GetManagedIdentitiesInput{
-		Filter: &db.ManagedIdentityFilter{
-			AliasSourceID: createdAlias.AliasSourceID,
-		},
-		PaginationOptions: &pagination.Options{
-			First: ptr.Int32(0),
-		},
+	// Aliases are counted across all groups, so this limit also caps the total number of aliases system-wide.
+	aliasCount, err := s.dbClient.ManagedIdentities.GetManagedIdentityCount(txContext, &db.ManagedIdentityFilter{
+		AliasSourceID: createdAlias.AliasSourceID,
 	})
 	if err != nil {
 		tracing.RecordError(span, err, "failed to get managed identity's aliases")
 		return nil, err
 	}
 	if err = s.limitChecker.CheckLimit(txContext,
-		limits.ResourceLimitManagedIdentityAliasesPerManagedIdentity, newAliases.PageInfo.TotalCount); err != nil {
+		limits.ResourceLimitManagedIdentityAliasesPerManagedIdentity, aliasCount); err != nil {
 		tracing.RecordError(span, err, "limit check failed")
+		s.createLimitExceededActivityEvent(ctx, err, aliasSourceIdentity.GetGroupPath(), models.TargetManagedIdentity, aliasSourceIdentity.Metadata.ID)
 		return nil, err
 	}
 
@@ -842,6 +1278,28 @@ func (s *service) CreateManagedIdentity(ctx context.Context, input *CreateManage
 		return nil, err
 	}
 
+	if s.reservedNames.IsReserved(managedIdentity.Name) {
+		tracing.RecordError(span, nil, "managed identity name is reserved")
+		return nil, errors.New("managed identity name %s is reserved", managedIdentity.Name, errors.WithErrorCode(errors.EInvalid))
+	}
+
+	if err = validateManagedIdentityDataSize(input.Type, input.Data); err != nil {
+		tracing.RecordError(span, err, "managed identity data is too large")
+		return nil, err
+	}
+
+	if input.RequireAccessRulesForAllRunStages {
+		runStages := []models.JobType{}
+		for _, rule := range input.AccessRules {
+			runStages = append(runStages, rule.RunStage)
+		}
+
+		if err = validateAccessRuleRunStages(runStages); err != nil {
+			tracing.RecordError(span, err, "access rules don't cover all run stages")
+			return nil, err
+		}
+	}
+
 	s.logger.Infow("Requested to create a new managed identity.",
 		"caller", caller.GetSubject(),
 		"groupID", input.GroupID,
@@ -869,7 +1327,8 @@ func (s *service) CreateManagedIdentity(ctx context.Context, input *CreateManage
 
 	if err = delegate.SetManagedIdentityData(txContext, managedIdentity, input.Data); err != nil {
 		tracing.RecordError(span, err, "failed to set managed identity data")
-		return nil, errors.Wrap(err, "failed to set managed identity data", errors.WithErrorCode(errors.EInvalid))
+		return nil, errors.Wrap(err, "failed to set managed identity data", errors.WithErrorCode(errors.EInvalid),
+			errors.WithErrorDetails(map[string]string{"data": errors.ErrorMessage(err)}))
 	}
 
 	managedIdentity, err = s.dbClient.ManagedIdentities.UpdateManagedIdentity(txContext, managedIdentity)
@@ -881,21 +1340,17 @@ func (s *service) CreateManagedIdentity(ctx context.Context, input *CreateManage
 	groupPath := managedIdentity.GetGroupPath()
 
 	// Get the number of managed identities in the group to check whether we just violated the limit.
-	newManagedIdentities, err := s.dbClient.ManagedIdentities.GetManagedIdentities(txContext, &db.GetManagedIdentitiesInput{
-		Filter: &db.ManagedIdentityFilter{
-			NamespacePaths: []string{groupPath},
-		},
-		PaginationOptions: &pagination.Options{
-			First: ptr.Int32(0),
-		},
+	managedIdentityCount, err := s.dbClient.ManagedIdentities.GetManagedIdentityCount(txContext, &db.ManagedIdentityFilter{
+		NamespacePaths: []string{groupPath},
 	})
 	if err != nil {
 		tracing.RecordError(span, err, "failed to get group's managed identities")
 		return nil, err
 	}
 	if err = s.limitChecker.CheckLimit(txContext,
-		limits.ResourceLimitManagedIdentitiesPerGroup, newManagedIdentities.PageInfo.TotalCount); err != nil {
+		limits.ResourceLimitManagedIdentitiesPerGroup, managedIdentityCount, limits.WithGroupPath(groupPath)); err != nil {
 		tracing.RecordError(span, err, "limit check failed")
+		s.createLimitExceededActivityEvent(ctx, err, groupPath, models.TargetGroup, managedIdentity.GroupID)
 		return nil, err
 	}
 
@@ -912,21 +1367,41 @@ func (s *service) CreateManagedIdentity(ctx context.Context, input *CreateManage
 
 	// Store access rules
 	if input.AccessRules != nil {
+		// Verify the principals referenced by all rules up front so they can be fetched in a single query.
+		serviceAccountIDs := []string{}
+		teamIDs := []string{}
+		userIDs := []string{}
 		for _, rule := range input.AccessRules {
-			if err = s.verifyServiceAccountAccessForGroup(ctx, rule.AllowedServiceAccountIDs, managedIdentity.GetGroupPath()); err != nil {
-				tracing.RecordError(span, err, "failed to verify service access for group")
-				return nil, err
-			}
+			serviceAccountIDs = append(serviceAccountIDs, rule.AllowedServiceAccountIDs...)
+			teamIDs = append(teamIDs, rule.AllowedTeamIDs...)
+			userIDs = append(userIDs, rule.AllowedUserIDs...)
+		}
 
-			ruleToCreate := models.ManagedIdentityAccessRule{
-				Type:                      rule.Type,
-				ManagedIdentityID:         managedIdentity.Metadata.ID,
-				RunStage:                  rule.RunStage,
-				ModuleAttestationPolicies: rule.ModuleAttestationPolicies,
-				AllowedUserIDs:            rule.AllowedUserIDs,
-				AllowedServiceAccountIDs:  rule.AllowedServiceAccountIDs,
-				AllowedTeamIDs:            rule.AllowedTeamIDs,
-				VerifyStateLineage:        rule.VerifyStateLineage,
+		if err = s.verifyServiceAccountAccessForGroup(ctx, serviceAccountIDs, groupPath); err != nil {
+			tracing.RecordError(span, err, "failed to verify service access for group")
+			return nil, err
+		}
+
+		if err = s.verifyAllowedTeamsExist(ctx, teamIDs); err != nil {
+			tracing.RecordError(span, err, "allowed team check failed")
+			return nil, err
+		}
+
+		if err = s.verifyAllowedUsersExist(ctx, userIDs); err != nil {
+			tracing.RecordError(span, err, "allowed user check failed")
+			return nil, err
+		}
+
+		for _, rule := range input.AccessRules {
+			ruleToCreate := models.ManagedIdentityAccessRule{
+				Type:                      rule.Type,
+				ManagedIdentityID:         managedIdentity.Metadata.ID,
+				RunStage:                  rule.RunStage,
+				ModuleAttestationPolicies: rule.ModuleAttestationPolicies,
+				AllowedUserIDs:            rule.AllowedUserIDs,
+				AllowedServiceAccountIDs:  rule.AllowedServiceAccountIDs,
+				AllowedTeamIDs:            rule.AllowedTeamIDs,
+				VerifyStateLineage:        rule.VerifyStateLineage,
 			}
 
 			if err = ruleToCreate.Validate(); err != nil {
@@ -955,37 +1430,89 @@ func (s *service) GetManagedIdentitiesByIDs(ctx context.Context, ids []string) (
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
+	// Every identity is returned, so the page size isn't capped.
+	results, err := s.getManagedIdentitiesByIDs(ctx, &GetPaginatedManagedIdentitiesByIDsInput{IDs: ids}, nil)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identities")
+		return nil, err
+	}
+
+	return results.ManagedIdentities, nil
+}
+
+func (s *service) GetPaginatedManagedIdentitiesByIDs(ctx context.Context, input *GetPaginatedManagedIdentitiesByIDsInput) (*db.ManagedIdentitiesResult, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetPaginatedManagedIdentitiesByIDs")
+	defer span.End()
+
+	results, err := s.getManagedIdentitiesByIDs(ctx, input, s.capPageSize(input.PaginationOptions))
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identities")
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// getManagedIdentitiesByIDs returns the managed identities with the IDs using the pagination options as is.
+func (s *service) getManagedIdentitiesByIDs(ctx context.Context, input *GetPaginatedManagedIdentitiesByIDsInput,
+	paginationOptions *pagination.Options,
+) (*db.ManagedIdentitiesResult, error) {
 	caller, err := auth.AuthorizeCaller(ctx)
 	if err != nil {
-		tracing.RecordError(span, err, "caller authorization failed")
 		return nil, err
 	}
 
+	filter := &db.ManagedIdentityFilter{
+		ManagedIdentityIDs: input.IDs,
+	}
+
+	if input.SkipUnauthorized {
+		// Filter before paginating so the page, total count and cursors only include accessible identities.
+		authorized, aErr := s.getAuthorizedManagedIdentities(ctx, caller, input.IDs)
+		if aErr != nil {
+			return nil, aErr
+		}
+
+		if len(authorized) == 0 {
+			return &db.ManagedIdentitiesResult{
+				PageInfo:          &pagination.PageInfo{},
+				ManagedIdentities: []models.ManagedIdentity{},
+			}, nil
+		}
+
+		authorizedIDs := []string{}
+		for _, identity := range authorized {
+			authorizedIDs = append(authorizedIDs, identity.Metadata.ID)
+		}
+
+		filter.ManagedIdentityIDs = authorizedIDs
+	}
+
 	// Get identity from DB
 	results, err := s.dbClient.ManagedIdentities.GetManagedIdentities(ctx, &db.GetManagedIdentitiesInput{
-		Filter: &db.ManagedIdentityFilter{
-			ManagedIdentityIDs: ids,
-		},
+		Sort:              input.Sort,
+		PaginationOptions: paginationOptions,
+		Filter:            filter,
 	})
 	if err != nil {
-		tracing.RecordError(span, err, "failed to get managed identities")
 		return nil, err
 	}
 
-	namespacePaths := []string{}
-	for _, identity := range results.ManagedIdentities {
-		namespacePaths = append(namespacePaths, identity.GetGroupPath())
+	if input.SkipUnauthorized {
+		return results, nil
 	}
 
-	if len(namespacePaths) > 0 {
-		err = caller.RequireAccessToInheritableResource(ctx, permissions.ManagedIdentityResourceType, auth.WithNamespacePaths(namespacePaths))
-		if err != nil {
-			tracing.RecordError(span, err, "inheritable resource access check failed")
-			return nil, err
-		}
+	namespacePaths := getGroupPaths(results.ManagedIdentities)
+	if len(namespacePaths) == 0 {
+		return results, nil
 	}
 
-	return results.ManagedIdentities, nil
+	err = caller.RequireAccessToInheritableResource(ctx, permissions.ManagedIdentityResourceType, auth.WithNamespacePaths(namespacePaths))
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
 }
 
 func (s *service) UpdateManagedIdentity(ctx context.Context, input *UpdateManagedIdentityInput) (*models.ManagedIdentity, error) {
@@ -1030,9 +1557,15 @@ func (s *service) UpdateManagedIdentity(ctx context.Context, input *UpdateManage
 		return nil, vErr
 	}
 
+	if vErr := validateManagedIdentityDataSize(managedIdentity.Type, input.Data); vErr != nil {
+		tracing.RecordError(span, vErr, "managed identity data is too large")
+		return nil, vErr
+	}
+
 	if sErr := delegate.SetManagedIdentityData(ctx, managedIdentity, input.Data); sErr != nil {
 		tracing.RecordError(span, sErr, "failed to set managed identity date")
-		return nil, errors.Wrap(sErr, "failed to set managed identity data", errors.WithErrorCode(errors.EInvalid))
+		return nil, errors.Wrap(sErr, "failed to set managed identity data", errors.WithErrorCode(errors.EInvalid),
+			errors.WithErrorDetails(map[string]string{"data": errors.ErrorMessage(sErr)}))
 	}
 
 	s.logger.Infow("Updated a managed identity.",
@@ -1081,6 +1614,87 @@ func (s *service) UpdateManagedIdentity(ctx context.Context, input *UpdateManage
 	return updatedManagedIdentity, nil
 }
 
+func (s *service) SetDeletionProtection(ctx context.Context, input *SetDeletionProtectionInput) (*models.ManagedIdentity, error) {
+	ctx, span := tracer.Start(ctx, "svc.SetDeletionProtection")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	managedIdentity, err := s.getManagedIdentityByID(ctx, input.ManagedIdentityID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity by ID")
+		return nil, err
+	}
+
+	// Aliases are deleted separately and can't be protected.
+	if managedIdentity.IsAlias() {
+		return nil, errors.New("Only a source managed identity can be deletion protected, not an alias", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	// Removing protection makes the identity deletable, so require delete permission for either change.
+	err = caller.RequirePermission(ctx, permissions.DeleteManagedIdentityPermission, auth.WithGroupID(managedIdentity.GroupID))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	if managedIdentity.DeletionProtected == input.Enabled {
+		return managedIdentity, nil
+	}
+
+	managedIdentity.DeletionProtected = input.Enabled
+
+	s.logger.Infow("Setting deletion protection for a managed identity.",
+		"caller", caller.GetSubject(),
+		"groupID", managedIdentity.GroupID,
+		"managedIdentityID", managedIdentity.Metadata.ID,
+		"enabled", input.Enabled,
+	)
+
+	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to begin DB transaction")
+		return nil, err
+	}
+
+	defer func() {
+		if txErr := s.dbClient.Transactions.RollbackTx(txContext); txErr != nil {
+			s.logger.Errorf("failed to rollback tx for service layer SetDeletionProtection: %v", txErr)
+		}
+	}()
+
+	updatedManagedIdentity, err := s.dbClient.ManagedIdentities.UpdateManagedIdentity(txContext, managedIdentity)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to update managed identity")
+		return nil, err
+	}
+
+	groupPath := updatedManagedIdentity.GetGroupPath()
+
+	if _, err = s.activityService.CreateActivityEvent(txContext,
+		&activityevent.CreateActivityEventInput{
+			NamespacePath: &groupPath,
+			Action:        models.ActionUpdate,
+			TargetType:    models.TargetManagedIdentity,
+			TargetID:      updatedManagedIdentity.Metadata.ID,
+		}); err != nil {
+		tracing.RecordError(span, err, "failed to create activity event")
+		return nil, err
+	}
+
+	if err := s.dbClient.Transactions.CommitTx(txContext); err != nil {
+		tracing.RecordError(span, err, "failed to commit DB transaction")
+		return nil, err
+	}
+
+	return updatedManagedIdentity, nil
+}
+
 func (s *service) GetManagedIdentityAccessRules(ctx context.Context, managedIdentity *models.ManagedIdentity) ([]models.ManagedIdentityAccessRule, error) {
 	ctx, span := tracer.Start(ctx, "svc.GetManagedIdentityAccessRules")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -1191,6 +1805,84 @@ func (s *service) GetManagedIdentityAccessRule(ctx context.Context, ruleID strin
 	return rule, nil
 }
 
+func (s *service) GetManagedIdentityAccessRuleWithPrincipalNames(ctx context.Context, ruleID string) (*ManagedIdentityAccessRuleWithPrincipalNames, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetManagedIdentityAccessRuleWithPrincipalNames")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	// Authorization is performed once here and covers the principal names as well.
+	rule, err := s.GetManagedIdentityAccessRule(ctx, ruleID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity access rule")
+		return nil, err
+	}
+
+	response := &ManagedIdentityAccessRuleWithPrincipalNames{
+		AccessRule:                 rule,
+		AllowedUserNames:           make([]string, len(rule.AllowedUserIDs)),
+		AllowedServiceAccountPaths: make([]string, len(rule.AllowedServiceAccountIDs)),
+		AllowedTeamNames:           make([]string, len(rule.AllowedTeamIDs)),
+	}
+
+	if len(rule.AllowedUserIDs) > 0 {
+		usersResult, uErr := s.dbClient.Users.GetUsers(ctx, &db.GetUsersInput{
+			Filter: &db.UserFilter{
+				UserIDs: rule.AllowedUserIDs,
+			},
+		})
+		if uErr != nil {
+			tracing.RecordError(span, uErr, "failed to get users")
+			return nil, uErr
+		}
+
+		usernames := make(map[string]string, len(usersResult.Users))
+		for _, user := range usersResult.Users {
+			usernames[user.Metadata.ID] = user.Username
+		}
+
+		for i, id := range rule.AllowedUserIDs {
+			response.AllowedUserNames[i] = usernames[id]
+		}
+	}
+
+	if len(rule.AllowedServiceAccountIDs) > 0 {
+		serviceAccountMap, saErr := s.dbClient.ServiceAccounts.GetServiceAccountsByIDs(ctx, rule.AllowedServiceAccountIDs)
+		if saErr != nil {
+			tracing.RecordError(span, saErr, "failed to get service accounts")
+			return nil, saErr
+		}
+
+		for i, id := range rule.AllowedServiceAccountIDs {
+			if sa, ok := serviceAccountMap[id]; ok {
+				response.AllowedServiceAccountPaths[i] = sa.ResourcePath
+			}
+		}
+	}
+
+	if len(rule.AllowedTeamIDs) > 0 {
+		teamsResult, tErr := s.dbClient.Teams.GetTeams(ctx, &db.GetTeamsInput{
+			Filter: &db.TeamFilter{
+				TeamIDs: rule.AllowedTeamIDs,
+			},
+		})
+		if tErr != nil {
+			tracing.RecordError(span, tErr, "failed to get teams")
+			return nil, tErr
+		}
+
+		teamNames := make(map[string]string, len(teamsResult.Teams))
+		for _, team := range teamsResult.Teams {
+			teamNames[team.Metadata.ID] = team.Name
+		}
+
+		for i, id := range rule.AllowedTeamIDs {
+			response.AllowedTeamNames[i] = teamNames[id]
+		}
+	}
+
+	return response, nil
+}
+
 func (s *service) CreateManagedIdentityAccessRule(ctx context.Context, input *models.ManagedIdentityAccessRule) (*models.ManagedIdentityAccessRule, error) {
 	ctx, span := tracer.Start(ctx, "svc.CreateManagedIdentityAccessRule")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -1224,11 +1916,27 @@ func (s *service) CreateManagedIdentityAccessRule(ctx context.Context, input *mo
 		return nil, err
 	}
 
+	if err = s.checkAllowedPrincipalsLimit(ctx, input); err != nil {
+		tracing.RecordError(span, err, "limit check failed")
+		s.createLimitExceededActivityEvent(ctx, err, managedIdentity.GetGroupPath(), models.TargetManagedIdentity, managedIdentity.Metadata.ID)
+		return nil, err
+	}
+
 	if err = s.verifyServiceAccountAccessForGroup(ctx, input.AllowedServiceAccountIDs, managedIdentity.GetGroupPath()); err != nil {
 		tracing.RecordError(span, err, "group service account access check failed")
 		return nil, err
 	}
 
+	if err = s.verifyAllowedTeamsExist(ctx, input.AllowedTeamIDs); err != nil {
+		tracing.RecordError(span, err, "allowed team check failed")
+		return nil, err
+	}
+
+	if err = s.verifyAllowedUsersExist(ctx, input.AllowedUserIDs); err != nil {
+		tracing.RecordError(span, err, "allowed user check failed")
+		return nil, err
+	}
+
 	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
 	if err != nil {
 		tracing.RecordError(span, err, "failed to begin DB transaction")
@@ -1264,6 +1972,7 @@ func (s *service) CreateManagedIdentityAccessRule(ctx context.Context, input *mo
 	if err = s.limitChecker.CheckLimit(txContext,
 		limits.ResourceLimitManagedIdentityAccessRulesPerManagedIdentity, newAccessRules.PageInfo.TotalCount); err != nil {
 		tracing.RecordError(span, err, "limit check failed")
+		s.createLimitExceededActivityEvent(ctx, err, managedIdentity.GetGroupPath(), models.TargetManagedIdentity, managedIdentity.Metadata.ID)
 		return nil, err
 	}
 
@@ -1323,11 +2032,27 @@ func (s *service) UpdateManagedIdentityAccessRule(ctx context.Context, input *mo
 		return nil, err
 	}
 
+	if err = s.checkAllowedPrincipalsLimit(ctx, input); err != nil {
+		tracing.RecordError(span, err, "limit check failed")
+		s.createLimitExceededActivityEvent(ctx, err, managedIdentity.GetGroupPath(), models.TargetManagedIdentity, managedIdentity.Metadata.ID)
+		return nil, err
+	}
+
 	if err = s.verifyServiceAccountAccessForGroup(ctx, input.AllowedServiceAccountIDs, managedIdentity.GetGroupPath()); err != nil {
 		tracing.RecordError(span, err, "group service account access check failed")
 		return nil, err
 	}
 
+	if err = s.verifyAllowedTeamsExist(ctx, input.AllowedTeamIDs); err != nil {
+		tracing.RecordError(span, err, "allowed team check failed")
+		return nil, err
+	}
+
+	if err = s.verifyAllowedUsersExist(ctx, input.AllowedUserIDs); err != nil {
+		tracing.RecordError(span, err, "allowed user check failed")
+		return nil, err
+	}
+
 	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
 	if err != nil {
 		tracing.RecordError(span, err, "failed to begin DB transaction")
@@ -1435,81 +2160,487 @@ func (s *service) DeleteManagedIdentityAccessRule(ctx context.Context, rule *mod
 	return s.dbClient.Transactions.CommitTx(txContext)
 }
 
-func (s *service) CreateCredentials(ctx context.Context, identity *models.ManagedIdentity) ([]byte, error) {
-	ctx, span := tracer.Start(ctx, "svc.CreateCredentials")
+func (s *service) ReplaceManagedIdentityAccessRules(ctx context.Context, identityID string, rules []*models.ManagedIdentityAccessRule) error {
+	ctx, span := tracer.Start(ctx, "svc.ReplaceManagedIdentityAccessRules")
 	// TODO: Consider setting trace/span attributes for the input.
 	defer span.End()
 
 	caller, err := auth.AuthorizeCaller(ctx)
 	if err != nil {
 		tracing.RecordError(span, err, "caller authorization failed")
-		return nil, err
+		return err
 	}
 
-	jobCaller, ok := caller.(*auth.JobCaller)
-	if !ok {
-		return nil, errors.New("Only job callers can create managed identity credentials", errors.WithErrorCode(errors.EForbidden))
+	managedIdentity, err := s.getManagedIdentityByID(ctx, identityID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity by ID")
+		return err
 	}
 
-	// Get Job
-	job, err := s.jobService.GetJob(ctx, jobCaller.JobID)
-	if err != nil {
-		tracing.RecordError(span, err, "failed to get job")
-		return nil, err
+	// Don't allow replacing access rules for an aliased identity.
+	if managedIdentity.IsAlias() {
+		return errors.New("Access rules can be replaced only for source managed identities, not for aliases", errors.WithErrorCode(errors.EInvalid))
 	}
 
-	// Verify job is in a workspace that has access to this managed identity
-	identitiesInWorkspace, err := s.GetManagedIdentitiesForWorkspace(ctx, job.WorkspaceID)
+	err = caller.RequirePermission(ctx, permissions.UpdateManagedIdentityPermission, auth.WithGroupID(managedIdentity.GroupID))
 	if err != nil {
-		tracing.RecordError(span, err, "failed to get managed identities for workspace")
-		return nil, err
+		tracing.RecordError(span, err, "permission check failed")
+		return err
 	}
 
-	found := false
-	for _, mi := range identitiesInWorkspace {
-		if identity.Metadata.ID == mi.Metadata.ID {
-			found = true
-			break
+	// Validate every rule before making any changes so an invalid rule doesn't leave a partial set behind.
+	rulesToCreate := make([]*models.ManagedIdentityAccessRule, len(rules))
+	serviceAccountIDs := []string{}
+	teamIDs := []string{}
+	userIDs := []string{}
+	for i, rule := range rules {
+		if rule == nil {
+			return errors.New("Access rule at index %d must not be empty", i, errors.WithErrorCode(errors.EInvalid))
 		}
-	}
 
-	if !found {
-		return nil, errors.New("managed identity %s is not assigned to workspace %s", identity.Metadata.ID, job.WorkspaceID, errors.WithErrorCode(errors.EUnauthorized))
-	}
+		if rule.ManagedIdentityID != "" && rule.ManagedIdentityID != managedIdentity.Metadata.ID {
+			return errors.New("Access rule at index %d belongs to a different managed identity", i, errors.WithErrorCode(errors.EInvalid))
+		}
 
-	delegate, err := s.getDelegate(identity.Type)
-	if err != nil {
-		tracing.RecordError(span, err, "failed to get delegate")
-		return nil, err
-	}
+		ruleToCreate := &models.ManagedIdentityAccessRule{
+			Type:                      rule.Type,
+			ManagedIdentityID:         managedIdentity.Metadata.ID,
+			RunStage:                  rule.RunStage,
+			ModuleAttestationPolicies: rule.ModuleAttestationPolicies,
+			AllowedUserIDs:            rule.AllowedUserIDs,
+			AllowedServiceAccountIDs:  rule.AllowedServiceAccountIDs,
+			AllowedTeamIDs:            rule.AllowedTeamIDs,
+			VerifyStateLineage:        rule.VerifyStateLineage,
+		}
 
-	s.logger.Infow("Created credentials for a managed identity.",
-		"caller", caller.GetSubject(),
-		"groupID", identity.GroupID,
-		"managedIdentityID", identity.Metadata.ID,
-	)
+		if err = ruleToCreate.Validate(); err != nil {
+			tracing.RecordError(span, err, "failed to validate managed identity access rule model")
+			return errors.Wrap(err, "access rule at index %d is not valid", i)
+		}
 
-	return delegate.CreateCredentials(ctx, identity, job)
-}
+		if err = s.checkAllowedPrincipalsLimit(ctx, ruleToCreate); err != nil {
+			tracing.RecordError(span, err, "limit check failed")
+			s.createLimitExceededActivityEvent(ctx, err, managedIdentity.GetGroupPath(), models.TargetManagedIdentity, managedIdentity.Metadata.ID)
+			return errors.Wrap(err, "access rule at index %d is not valid", i)
+		}
 
-func (s *service) MoveManagedIdentity(ctx context.Context, input *MoveManagedIdentityInput) (*models.ManagedIdentity, error) {
-	ctx, span := tracer.Start(ctx, "svc.MoveManagedIdentity")
-	defer span.End()
+		rulesToCreate[i] = ruleToCreate
+		serviceAccountIDs = append(serviceAccountIDs, ruleToCreate.AllowedServiceAccountIDs...)
+		teamIDs = append(teamIDs, ruleToCreate.AllowedTeamIDs...)
+		userIDs = append(userIDs, ruleToCreate.AllowedUserIDs...)
+	}
 
-	caller, err := auth.AuthorizeCaller(ctx)
-	if err != nil {
-		tracing.RecordError(span, err, "caller authorization failed")
-		return nil, err
+	if err = s.verifyServiceAccountAccessForGroup(ctx, serviceAccountIDs, managedIdentity.GetGroupPath()); err != nil {
+		tracing.RecordError(span, err, "group service account access check failed")
+		return err
 	}
 
-	managedIdentity, err := s.getManagedIdentityByID(ctx, input.ManagedIdentityID)
-	if err != nil {
-		tracing.RecordError(span, err, "failed to get managed identity")
-		return nil, err
+	if err = s.verifyAllowedTeamsExist(ctx, teamIDs); err != nil {
+		tracing.RecordError(span, err, "allowed team check failed")
+		return err
 	}
 
-	// Caller must be an owner of both the old group and the new group.
-	err = caller.RequirePermission(ctx, permissions.DeleteManagedIdentityPermission,
+	if err = s.verifyAllowedUsersExist(ctx, userIDs); err != nil {
+		tracing.RecordError(span, err, "allowed user check failed")
+		return err
+	}
+
+	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to begin DB transaction")
+		return err
+	}
+
+	defer func() {
+		if txErr := s.dbClient.Transactions.RollbackTx(txContext); txErr != nil {
+			s.logger.Errorf("failed to rollback tx for service layer ReplaceManagedIdentityAccessRules: %v", txErr)
+		}
+	}()
+
+	existingRules, err := s.dbClient.ManagedIdentities.GetManagedIdentityAccessRules(txContext,
+		&db.GetManagedIdentityAccessRulesInput{
+			Filter: &db.ManagedIdentityAccessRuleFilter{
+				ManagedIdentityID: &managedIdentity.Metadata.ID,
+			},
+		})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity access rules")
+		return err
+	}
+
+	for i := range existingRules.ManagedIdentityAccessRules {
+		if err = s.dbClient.ManagedIdentities.DeleteManagedIdentityAccessRule(txContext, &existingRules.ManagedIdentityAccessRules[i]); err != nil {
+			tracing.RecordError(span, err, "failed to delete managed identity access rule")
+			return err
+		}
+	}
+
+	for _, rule := range rulesToCreate {
+		if _, err = s.dbClient.ManagedIdentities.CreateManagedIdentityAccessRule(txContext, rule); err != nil {
+			tracing.RecordError(span, err, "failed to create managed identity access rule")
+			return err
+		}
+	}
+
+	if err = s.limitChecker.CheckLimit(txContext,
+		limits.ResourceLimitManagedIdentityAccessRulesPerManagedIdentity, int32(len(rulesToCreate))); err != nil {
+		tracing.RecordError(span, err, "limit check failed")
+		s.createLimitExceededActivityEvent(ctx, err, managedIdentity.GetGroupPath(), models.TargetManagedIdentity, managedIdentity.Metadata.ID)
+		return err
+	}
+
+	groupPath := managedIdentity.GetGroupPath()
+
+	if _, err = s.activityService.CreateActivityEvent(txContext,
+		&activityevent.CreateActivityEventInput{
+			NamespacePath: &groupPath,
+			Action:        models.ActionUpdate,
+			TargetType:    models.TargetManagedIdentity,
+			TargetID:      managedIdentity.Metadata.ID,
+			Payload: &models.ActivityEventReplaceManagedIdentityAccessRulesPayload{
+				RemovedRuleCount: int32(len(existingRules.ManagedIdentityAccessRules)),
+				AddedRuleCount:   int32(len(rulesToCreate)),
+			},
+		}); err != nil {
+		tracing.RecordError(span, err, "failed to create activity event")
+		return err
+	}
+
+	if err := s.dbClient.Transactions.CommitTx(txContext); err != nil {
+		tracing.RecordError(span, err, "failed to commit DB transaction")
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) CreateCredentials(ctx context.Context, identity *models.ManagedIdentity) ([]byte, error) {
+	ctx, span := tracer.Start(ctx, "svc.CreateCredentials")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	jobCaller, ok := caller.(*auth.JobCaller)
+	if !ok {
+		return nil, errors.New("Only job callers can create managed identity credentials", errors.WithErrorCode(errors.EForbidden))
+	}
+
+	// Get Job
+	job, err := s.jobService.GetJob(ctx, jobCaller.JobID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get job")
+		return nil, err
+	}
+
+	// Verify job is in a workspace that has access to this managed identity
+	identitiesInWorkspace, err := s.GetManagedIdentitiesForWorkspace(ctx, job.WorkspaceID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identities for workspace")
+		return nil, err
+	}
+
+	found := false
+	for _, mi := range identitiesInWorkspace {
+		if identity.Metadata.ID == mi.Metadata.ID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, errors.New("managed identity %s is not assigned to workspace %s", identity.Metadata.ID, job.WorkspaceID, errors.WithErrorCode(errors.EUnauthorized))
+	}
+
+	delegate, err := s.getDelegate(identity.Type)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get delegate")
+		return nil, err
+	}
+
+	if err = delegate.CanCreateCredentials(ctx, identity, job); err != nil {
+		tracing.RecordError(span, err, "credentials precondition check failed")
+		return nil, errors.Wrap(err, "preconditions for creating credentials for managed identity %s are not met", identity.Metadata.ID)
+	}
+
+	credentials, err := delegate.CreateCredentials(ctx, identity, job)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to create credentials")
+		return nil, err
+	}
+
+	s.logger.Infow("Created credentials for a managed identity.",
+		"caller", caller.GetSubject(),
+		"groupID", identity.GroupID,
+		"managedIdentityID", identity.Metadata.ID,
+	)
+
+	// Recording the last used time is best-effort, so a failure here doesn't prevent the credentials from being returned.
+	if err = s.dbClient.ManagedIdentities.UpdateManagedIdentityLastUsedAt(ctx, identity.Metadata.ID, time.Now().UTC()); err != nil {
+		s.logger.Errorf("failed to update last used timestamp for managed identity %s: %v", identity.Metadata.ID, err)
+	}
+
+	// The issuance record is also best-effort; it's used to find the runs that used the managed identity.
+	if err = s.dbClient.ManagedIdentities.CreateCredentialIssuance(ctx, identity.Metadata.ID, job.RunID, job.Metadata.ID); err != nil {
+		s.logger.Errorf("failed to record credential issuance for managed identity %s: %v", identity.Metadata.ID, err)
+	}
+
+	return credentials, nil
+}
+
+func (s *service) GetRunsForManagedIdentity(ctx context.Context, input *GetRunsForManagedIdentityInput) (*db.RunsResult, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetRunsForManagedIdentity")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	// Enforces view access on the managed identity.
+	identity, err := s.GetManagedIdentityByID(ctx, input.ManagedIdentityID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity")
+		return nil, err
+	}
+
+	result, err := s.dbClient.Runs.GetRuns(ctx, &db.GetRunsInput{
+		Sort:              input.Sort,
+		PaginationOptions: input.PaginationOptions,
+		Filter: &db.RunFilter{
+			ManagedIdentityID: &identity.Metadata.ID,
+		},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get runs")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *service) CloneManagedIdentity(ctx context.Context, input *CloneManagedIdentityInput) (*models.ManagedIdentity, error) {
+	ctx, span := tracer.Start(ctx, "svc.CloneManagedIdentity")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	sourceIdentity, err := s.getManagedIdentityByID(ctx, input.ManagedIdentityID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity")
+		return nil, err
+	}
+
+	// The clone copies the source's data, so the caller must be able to update the source
+	// and create managed identities in the target group.
+	err = caller.RequirePermission(ctx, permissions.UpdateManagedIdentityPermission,
+		auth.WithGroupID(sourceIdentity.GroupID))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+	err = caller.RequirePermission(ctx, permissions.CreateManagedIdentityPermission,
+		auth.WithGroupID(input.TargetGroupID))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	// An alias has no data or access rules of its own to copy.
+	if sourceIdentity.IsAlias() {
+		return nil, errors.New("Only a source managed identity can be cloned, not an alias", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	targetGroup, err := s.dbClient.Groups.GetGroupByID(ctx, input.TargetGroupID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get target group")
+		return nil, err
+	}
+
+	if targetGroup == nil {
+		return nil, errors.New("group with id %s not found", input.TargetGroupID, errors.WithErrorCode(errors.ENotFound))
+	}
+
+	name := input.Name
+	if name == "" {
+		name = sourceIdentity.Name
+	}
+
+	if s.reservedNames.IsReserved(name) {
+		tracing.RecordError(span, nil, "managed identity name is reserved")
+		return nil, errors.New("managed identity name %s is reserved", name, errors.WithErrorCode(errors.EInvalid))
+	}
+
+	existing, err := s.dbClient.ManagedIdentities.GetManagedIdentityByPath(ctx, fmt.Sprintf("%s/%s", targetGroup.FullPath, name))
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity by path")
+		return nil, err
+	}
+
+	if existing != nil {
+		tracing.RecordError(span, nil, "managed identity name already in use in target group")
+		return nil, errors.New(
+			"managed identity with name %s already exists in group %s", name, targetGroup.FullPath,
+			errors.WithErrorCode(errors.EConflict),
+		)
+	}
+
+	delegate, err := s.getDelegate(sourceIdentity.Type)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get delegate")
+		return nil, err
+	}
+
+	rulesResp, err := s.dbClient.ManagedIdentities.GetManagedIdentityAccessRules(ctx, &db.GetManagedIdentityAccessRulesInput{
+		Filter: &db.ManagedIdentityAccessRuleFilter{
+			ManagedIdentityID: &sourceIdentity.Metadata.ID,
+		},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity access rules")
+		return nil, err
+	}
+
+	// Service accounts referenced by the rules must also be usable from the target group.
+	serviceAccountIDs := []string{}
+	for _, rule := range rulesResp.ManagedIdentityAccessRules {
+		serviceAccountIDs = append(serviceAccountIDs, rule.AllowedServiceAccountIDs...)
+	}
+
+	if err = s.verifyServiceAccountAccessForGroup(ctx, serviceAccountIDs, targetGroup.FullPath); err != nil {
+		tracing.RecordError(span, err, "failed to verify service access for group")
+		return nil, err
+	}
+
+	toCreate := &models.ManagedIdentity{
+		Type:        sourceIdentity.Type,
+		Name:        name,
+		Description: sourceIdentity.Description,
+		GroupID:     targetGroup.Metadata.ID,
+		CreatedBy:   caller.GetSubject(),
+		Data:        []byte{}, // Required or identity will fail to create.
+	}
+
+	if err = toCreate.Validate(); err != nil {
+		tracing.RecordError(span, err, "failed to validate managed identity model")
+		return nil, err
+	}
+
+	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to begin DB transaction")
+		return nil, err
+	}
+
+	defer func() {
+		if txErr := s.dbClient.Transactions.RollbackTx(txContext); txErr != nil {
+			s.logger.Errorf("failed to rollback tx for CloneManagedIdentity: %v", txErr)
+		}
+	}()
+
+	clonedIdentity, err := s.dbClient.ManagedIdentities.CreateManagedIdentity(txContext, toCreate)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to create managed identity")
+		return nil, err
+	}
+
+	// The stored data is a superset of the input data, so passing it through the delegate
+	// copies the user supplied fields while generating fields like the subject for the clone.
+	if err = delegate.SetManagedIdentityData(txContext, clonedIdentity, sourceIdentity.Data); err != nil {
+		tracing.RecordError(span, err, "failed to set managed identity data")
+		return nil, errors.Wrap(err, "failed to set managed identity data", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	clonedIdentity, err = s.dbClient.ManagedIdentities.UpdateManagedIdentity(txContext, clonedIdentity)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to update managed identity")
+		return nil, err
+	}
+
+	groupPath := clonedIdentity.GetGroupPath()
+
+	// Get the number of managed identities in the group to check whether we just violated the limit.
+	managedIdentityCount, err := s.dbClient.ManagedIdentities.GetManagedIdentityCount(txContext, &db.ManagedIdentityFilter{
+		NamespacePaths: []string{groupPath},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get group's managed identities")
+		return nil, err
+	}
+	if err = s.limitChecker.CheckLimit(txContext,
+		limits.ResourceLimitManagedIdentitiesPerGroup, managedIdentityCount, limits.WithGroupPath(groupPath)); err != nil {
+		tracing.RecordError(span, err, "limit check failed")
+		s.createLimitExceededActivityEvent(ctx, err, groupPath, models.TargetGroup, clonedIdentity.GroupID)
+		return nil, err
+	}
+
+	for _, rule := range rulesResp.ManagedIdentityAccessRules {
+		ruleToCreate := models.ManagedIdentityAccessRule{
+			Type:                      rule.Type,
+			ManagedIdentityID:         clonedIdentity.Metadata.ID,
+			RunStage:                  rule.RunStage,
+			ModuleAttestationPolicies: rule.ModuleAttestationPolicies,
+			AllowedUserIDs:            rule.AllowedUserIDs,
+			AllowedServiceAccountIDs:  rule.AllowedServiceAccountIDs,
+			AllowedTeamIDs:            rule.AllowedTeamIDs,
+			VerifyStateLineage:        rule.VerifyStateLineage,
+		}
+
+		if _, err = s.dbClient.ManagedIdentities.CreateManagedIdentityAccessRule(txContext, &ruleToCreate); err != nil {
+			tracing.RecordError(span, err, "failed to create managed identity access rule")
+			return nil, err
+		}
+	}
+
+	if _, err = s.activityService.CreateActivityEvent(txContext,
+		&activityevent.CreateActivityEventInput{
+			NamespacePath: &groupPath,
+			Action:        models.ActionCreate,
+			TargetType:    models.TargetManagedIdentity,
+			TargetID:      clonedIdentity.Metadata.ID,
+		}); err != nil {
+		tracing.RecordError(span, err, "failed to create activity event")
+		return nil, err
+	}
+
+	if err = s.dbClient.Transactions.CommitTx(txContext); err != nil {
+		tracing.RecordError(span, err, "failed to commit DB transaction")
+		return nil, err
+	}
+
+	s.logger.Infow("Cloned a managed identity.",
+		"caller", caller.GetSubject(),
+		"sourceID", sourceIdentity.Metadata.ID,
+		"cloneID", clonedIdentity.Metadata.ID,
+		"targetGroupID", targetGroup.Metadata.ID,
+	)
+
+	return clonedIdentity, nil
+}
+
+func (s *service) MoveManagedIdentity(ctx context.Context, input *MoveManagedIdentityInput) (*models.ManagedIdentity, error) {
+	ctx, span := tracer.Start(ctx, "svc.MoveManagedIdentity")
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	managedIdentity, err := s.getManagedIdentityByID(ctx, input.ManagedIdentityID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity")
+		return nil, err
+	}
+
+	// Caller must be an owner of both the old group and the new group.
+	err = caller.RequirePermission(ctx, permissions.DeleteManagedIdentityPermission,
 		auth.WithGroupID(managedIdentity.GroupID))
 	if err != nil {
 		tracing.RecordError(span, err, "permission check failed")
@@ -1548,78 +2679,303 @@ func (s *service) MoveManagedIdentity(ctx context.Context, input *MoveManagedIde
 	// If there are any, list the workspaces in the error message.
 	err = s.checkWorkspaceAssignments(ctx, managedIdentity, newGroup)
 	if err != nil {
-		return nil, err
+		return nil, err
+	}
+
+	s.logger.Infow("Requested to move a managed identity.",
+		"caller", caller.GetSubject(),
+		"managedIdentityName", managedIdentity.Name,
+		"groupPath", newGroup.FullPath,
+	)
+
+	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to begin DB transaction")
+		return nil, err
+	}
+
+	defer func() {
+		if txErr := s.dbClient.Transactions.RollbackTx(txContext); txErr != nil {
+			s.logger.Errorf("failed to rollback tx for MoveManagedIdentity: %v", txErr)
+		}
+	}()
+
+	oldGroupPath := managedIdentity.GetGroupPath()
+
+	// Record the move in the DB.
+	managedIdentity.GroupID = input.NewGroupID
+	managedIdentity, err = s.dbClient.ManagedIdentities.UpdateManagedIdentity(txContext, managedIdentity)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to move managed identity")
+		return nil, err
+	}
+
+	// Get the number of managed identities now in the new group to check whether we just violated the limit.
+	managedIdentityCount, err := s.dbClient.ManagedIdentities.GetManagedIdentityCount(txContext, &db.ManagedIdentityFilter{
+		NamespacePaths: []string{newGroup.FullPath},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get group's managed identities")
+		return nil, err
+	}
+
+	// Check the resource limit.
+	if err = s.limitChecker.CheckLimit(txContext,
+		limits.ResourceLimitManagedIdentitiesPerGroup, managedIdentityCount, limits.WithGroupPath(newGroup.FullPath)); err != nil {
+		tracing.RecordError(span, err, "limit check failed")
+		s.createLimitExceededActivityEvent(ctx, err, newGroup.FullPath, models.TargetManagedIdentity, managedIdentity.Metadata.ID)
+		return nil, err
+	}
+
+	if _, err = s.activityService.CreateActivityEvent(txContext,
+		&activityevent.CreateActivityEventInput{
+			NamespacePath: &newGroup.FullPath,
+			Action:        models.ActionMigrate,
+			TargetType:    models.TargetManagedIdentity,
+			TargetID:      managedIdentity.Metadata.ID,
+			Payload: &models.ActivityEventMoveManagedIdentityPayload{
+				PreviousGroupPath: oldGroupPath,
+			},
+		}); err != nil {
+		tracing.RecordError(span, err, "failed to create activity event")
+		return nil, err
+	}
+
+	if err := s.dbClient.Transactions.CommitTx(txContext); err != nil {
+		tracing.RecordError(span, err, "failed to commit DB transaction")
+		return nil, err
+	}
+
+	return managedIdentity, nil
+}
+
+// PreviewMoveManagedIdentity returns the aliases that would become invalid and the workspace assignments
+// that would fall outside the new group if the managed identity were moved, without performing the move.
+func (s *service) PreviewMoveManagedIdentity(ctx context.Context, input *MoveManagedIdentityInput) (*MovePreview, error) {
+	ctx, span := tracer.Start(ctx, "svc.PreviewMoveManagedIdentity")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	managedIdentity, err := s.getManagedIdentityByID(ctx, input.ManagedIdentityID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity")
+		return nil, err
+	}
+
+	// Same permissions as the move itself.
+	err = caller.RequirePermission(ctx, permissions.DeleteManagedIdentityPermission,
+		auth.WithGroupID(managedIdentity.GroupID))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+	err = caller.RequirePermission(ctx, permissions.CreateManagedIdentityPermission,
+		auth.WithGroupID(input.NewGroupID))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	// Only non-aliases are allowed to be moved.
+	if managedIdentity.IsAlias() {
+		tracing.RecordError(span, nil, "an alias cannot be moved")
+		return nil, errors.New("Only a source managed identity can be moved, not an alias", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	newGroup, err := s.dbClient.Groups.GetGroupByID(ctx, input.NewGroupID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get new group")
+		return nil, err
+	}
+
+	if newGroup == nil {
+		tracing.RecordError(span, nil, "group not found")
+		return nil, errors.New("group with id %s not found", input.NewGroupID, errors.WithErrorCode(errors.ENotFound))
+	}
+
+	invalidAliases, err := s.getDisallowedAliases(ctx, managedIdentity, newGroup)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get disallowed aliases")
+		return nil, err
+	}
+
+	workspaces, err := s.getWorkspacesOutsideGroup(ctx, managedIdentity, newGroup)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get workspace assignments")
+		return nil, err
+	}
+
+	return &MovePreview{
+		InvalidAliases:          invalidAliases,
+		AssignmentsOutsideGroup: workspaces,
+	}, nil
+}
+
+// GetAssignableGroupsForManagedIdentity returns the groups within the managed identity's root group
+// that it could be moved to, i.e. the groups where the caller is allowed to create managed identities.
+// The identity's current group is excluded.
+func (s *service) GetAssignableGroupsForManagedIdentity(ctx context.Context, identityID string) ([]models.Group, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetAssignableGroupsForManagedIdentity")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	managedIdentity, err := s.getManagedIdentityByID(ctx, identityID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity")
+		return nil, err
+	}
+
+	// Moving requires the caller to be able to remove the identity from its current group.
+	err = caller.RequirePermission(ctx, permissions.DeleteManagedIdentityPermission, auth.WithGroupID(managedIdentity.GroupID))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	// Only non-aliases are allowed to be moved.
+	if managedIdentity.IsAlias() {
+		tracing.RecordError(span, nil, "an alias cannot be moved")
+		return nil, errors.New("Only a source managed identity can be moved, not an alias", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	rootGroupPath := strings.Split(managedIdentity.GetGroupPath(), "/")[0]
+
+	rootGroup, err := s.dbClient.Groups.GetGroupByFullPath(ctx, rootGroupPath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get root group")
+		return nil, err
+	}
+
+	// Shouldn't happen.
+	if rootGroup == nil {
+		tracing.RecordError(span, nil, "root group not found")
+		return nil, errors.New("root group with path %s not found", rootGroupPath)
+	}
+
+	sortBy := db.GroupSortableFieldFullPathAsc
+	descendants, err := s.dbClient.Groups.GetGroups(ctx, &db.GetGroupsInput{
+		Sort: &sortBy,
+		Filter: &db.GroupFilter{
+			PathPrefix: &rootGroup.FullPath,
+		},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get descendant groups")
+		return nil, err
+	}
+
+	assignableGroups := []models.Group{}
+	for _, group := range append([]models.Group{*rootGroup}, descendants.Groups...) {
+		if group.Metadata.ID == managedIdentity.GroupID {
+			continue
+		}
+
+		if err = caller.RequirePermission(ctx, permissions.CreateManagedIdentityPermission, auth.WithGroupID(group.Metadata.ID)); err != nil {
+			// Groups the caller isn't a member of are skipped along with the ones they lack permission in.
+			if isAccessDeniedError(err) {
+				continue
+			}
+			tracing.RecordError(span, err, "permission check failed")
+			return nil, err
+		}
+
+		assignableGroups = append(assignableGroups, group)
+	}
+
+	return assignableGroups, nil
+}
+
+// CanAssumeManagedIdentity returns true if the managed identity is assigned to the workspace and the principal
+// satisfies the eligible principals rules for the run stage, otherwise it returns false along with the reason.
+func (s *service) CanAssumeManagedIdentity(ctx context.Context, identityID, workspaceID string, stage models.JobType, principal auth.Caller) (bool, string, error) {
+	ctx, span := tracer.Start(ctx, "svc.CanAssumeManagedIdentity")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return false, "", err
+	}
+
+	managedIdentity, err := s.getManagedIdentityByID(ctx, identityID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity")
+		return false, "", err
 	}
 
-	s.logger.Infow("Requested to move a managed identity.",
-		"caller", caller.GetSubject(),
-		"managedIdentityName", managedIdentity.Name,
-		"groupPath", newGroup.FullPath,
-	)
+	err = caller.RequirePermission(ctx, permissions.ViewWorkspacePermission, auth.WithWorkspaceID(workspaceID))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return false, "", err
+	}
 
-	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
+	assignedIdentities, err := s.dbClient.ManagedIdentities.GetManagedIdentitiesForWorkspace(ctx, workspaceID)
 	if err != nil {
-		tracing.RecordError(span, err, "failed to begin DB transaction")
-		return nil, err
+		tracing.RecordError(span, err, "failed to get managed identities for workspace")
+		return false, "", err
 	}
 
-	defer func() {
-		if txErr := s.dbClient.Transactions.RollbackTx(txContext); txErr != nil {
-			s.logger.Errorf("failed to rollback tx for MoveManagedIdentity: %v", txErr)
+	assigned := false
+	for _, identity := range assignedIdentities {
+		if identity.Metadata.ID == managedIdentity.Metadata.ID {
+			assigned = true
+			break
 		}
-	}()
-
-	oldGroupPath := managedIdentity.GetGroupPath()
+	}
 
-	// Record the move in the DB.
-	managedIdentity.GroupID = input.NewGroupID
-	managedIdentity, err = s.dbClient.ManagedIdentities.UpdateManagedIdentity(txContext, managedIdentity)
-	if err != nil {
-		tracing.RecordError(span, err, "failed to move managed identity")
-		return nil, err
+	if !assigned {
+		return false, fmt.Sprintf("managed identity %s is not assigned to the workspace", managedIdentity.ResourcePath), nil
 	}
 
-	// Get the number of managed identities now in the new group to check whether we just violated the limit.
-	newManagedIdentities, err := s.dbClient.ManagedIdentities.GetManagedIdentities(txContext, &db.GetManagedIdentitiesInput{
-		Filter: &db.ManagedIdentityFilter{
-			NamespacePaths: []string{newGroup.FullPath},
-		},
-		PaginationOptions: &pagination.Options{
-			First: ptr.Int32(0),
+	rulesResult, err := s.dbClient.ManagedIdentities.GetManagedIdentityAccessRules(ctx, &db.GetManagedIdentityAccessRulesInput{
+		Filter: &db.ManagedIdentityAccessRuleFilter{
+			ManagedIdentityID: &managedIdentity.Metadata.ID,
 		},
 	})
 	if err != nil {
-		tracing.RecordError(span, err, "failed to get group's managed identities")
-		return nil, err
+		tracing.RecordError(span, err, "failed to get managed identity access rules")
+		return false, "", err
 	}
 
-	// Check the resource limit.
-	if err = s.limitChecker.CheckLimit(txContext,
-		limits.ResourceLimitManagedIdentitiesPerGroup, newManagedIdentities.PageInfo.TotalCount); err != nil {
-		tracing.RecordError(span, err, "limit check failed")
-		return nil, err
-	}
+	// Eligible principals rules for the same stage use an OR condition, no rules means any principal is eligible.
+	reasons := []string{}
+	for i := range rulesResult.ManagedIdentityAccessRules {
+		rule := &rulesResult.ManagedIdentityAccessRules[i]
+		if rule.Type != models.ManagedIdentityAccessRuleEligiblePrincipals || rule.RunStage != stage {
+			continue
+		}
 
-	if _, err = s.activityService.CreateActivityEvent(txContext,
-		&activityevent.CreateActivityEventInput{
-			NamespacePath: &newGroup.FullPath,
-			Action:        models.ActionMigrate,
-			TargetType:    models.TargetManagedIdentity,
-			TargetID:      managedIdentity.Metadata.ID,
-			Payload: &models.ActivityEventMoveManagedIdentityPayload{
-				PreviousGroupPath: oldGroupPath,
-			},
-		}); err != nil {
-		tracing.RecordError(span, err, "failed to create activity event")
-		return nil, err
+		reason, rErr := rules.IsEligiblePrincipal(ctx, rule, principal)
+		if rErr != nil {
+			tracing.RecordError(span, rErr, "failed to check eligible principals rule")
+			return false, "", rErr
+		}
+
+		if reason == "" {
+			return true, "", nil
+		}
+
+		reasons = append(reasons, reason)
 	}
 
-	if err := s.dbClient.Transactions.CommitTx(txContext); err != nil {
-		tracing.RecordError(span, err, "failed to commit DB transaction")
-		return nil, err
+	if len(reasons) > 0 {
+		return false, strings.Join(reasons, ": "), nil
 	}
 
-	return managedIdentity, nil
+	return true, "", nil
 }
 
 // Check to ensure there are no aliases of the managed identity in the new group or certain related groups.
@@ -1628,54 +2984,73 @@ func (s *service) MoveManagedIdentity(ctx context.Context, input *MoveManagedIde
 func (s *service) checkDisallowedAliases(ctx context.Context,
 	managedIdentity *models.ManagedIdentity, targetGroup *models.Group) error {
 
+	aliases, err := s.getDisallowedAliases(ctx, managedIdentity, targetGroup)
+	if err != nil {
+		return err
+	}
+
+	// If nothing was found, then we're good.
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	alias := aliases[0]
+	return errors.New("managed identity %s is an alias of managed identity %s, which is in %s %s",
+		alias.ResourcePath, managedIdentity.ResourcePath, disallowedAliasLocation(&alias, targetGroup), targetGroup.FullPath,
+		errors.WithErrorCode(errors.EInvalid))
+}
+
+// getDisallowedAliases returns the aliases of the managed identity that are in the target group,
+// a descendant of the target group or an ancestor of the target group.
+func (s *service) getDisallowedAliases(ctx context.Context,
+	managedIdentity *models.ManagedIdentity, targetGroup *models.Group) ([]models.ManagedIdentity, error) {
+
 	aliases, err := s.dbClient.ManagedIdentities.GetManagedIdentities(ctx, &db.GetManagedIdentitiesInput{
 		Filter: &db.ManagedIdentityFilter{
 			AliasSourceID: &managedIdentity.Metadata.ID,
 		},
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	disallowed := []models.ManagedIdentity{}
 	for _, alias := range aliases.ManagedIdentities {
-
-		// If the alias is in the target group, then it's a problem.
-		if alias.GroupID == targetGroup.Metadata.ID {
-			return errors.New("managed identity %s is an alias of managed identity %s, which is in the target group %s",
-				alias.ResourcePath, managedIdentity.ResourcePath, targetGroup.FullPath, errors.WithErrorCode(errors.EInvalid))
+		aliasCopy := alias
+		if disallowedAliasLocation(&aliasCopy, targetGroup) != "" {
+			disallowed = append(disallowed, alias)
 		}
+	}
 
-		// If the alias is in a descendant of the target group, then it's a problem.
-		if models.IsDescendantOfPath(alias.GetGroupPath(), targetGroup.FullPath) {
-			return errors.New("managed identity %s is an alias of managed identity %s, which is in a descendant group of the target group %s",
-				alias.ResourcePath, managedIdentity.ResourcePath, targetGroup.FullPath, errors.WithErrorCode(errors.EInvalid))
-		}
+	return disallowed, nil
+}
 
-		// If the alias is in an ancestor of the target group, then it's a problem.
-		if targetGroup.IsDescendantOfGroup(alias.GetGroupPath()) {
-			return errors.New("managed identity %s is an alias of managed identity %s, which is in an ancestor group of the target group %s",
-				alias.ResourcePath, managedIdentity.ResourcePath, targetGroup.FullPath, errors.WithErrorCode(errors.EInvalid))
-		}
+// disallowedAliasLocation describes where the alias is relative to the target group
+// or returns an empty string if the alias is allowed to stay where it is.
+func disallowedAliasLocation(alias *models.ManagedIdentity, targetGroup *models.Group) string {
+	switch {
+	case alias.GroupID == targetGroup.Metadata.ID:
+		return "the target group"
+	case models.IsDescendantOfPath(alias.GetGroupPath(), targetGroup.FullPath):
+		return "a descendant group of the target group"
+	case targetGroup.IsDescendantOfGroup(alias.GetGroupPath()):
+		return "an ancestor group of the target group"
+	default:
+		return ""
 	}
-
-	// If nothing was found, then we're good.
-	return nil
 }
 
 func (s *service) checkWorkspaceAssignments(ctx context.Context,
 	managedIdentity *models.ManagedIdentity, newGroup *models.Group) error {
 
-	workspaces, err := s.dbClient.Workspaces.GetWorkspacesForManagedIdentity(ctx, managedIdentity.Metadata.ID)
+	workspaces, err := s.getWorkspacesOutsideGroup(ctx, managedIdentity, newGroup)
 	if err != nil {
 		return err
 	}
 
 	badPaths := []string{}
-
 	for _, workspace := range workspaces {
-		if !workspace.IsDescendantOfGroup(newGroup.FullPath) {
-			badPaths = append(badPaths, workspace.FullPath)
-		}
+		badPaths = append(badPaths, workspace.FullPath)
 	}
 
 	if len(badPaths) > 0 {
@@ -1686,6 +3061,167 @@ func (s *service) checkWorkspaceAssignments(ctx context.Context,
 	return nil
 }
 
+// getWorkspacesOutsideGroup returns the workspaces the managed identity is assigned to that are not within the new group.
+func (s *service) getWorkspacesOutsideGroup(ctx context.Context,
+	managedIdentity *models.ManagedIdentity, newGroup *models.Group) ([]models.Workspace, error) {
+
+	workspaces, err := s.dbClient.Workspaces.GetWorkspacesForManagedIdentity(ctx, managedIdentity.Metadata.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	outside := []models.Workspace{}
+	for _, workspace := range workspaces {
+		if !workspace.IsDescendantOfGroup(newGroup.FullPath) {
+			outside = append(outside, workspace)
+		}
+	}
+
+	return outside, nil
+}
+
+// capPageSize returns a copy of the pagination options with first and last clamped to the maximum page size,
+// first defaults to the maximum page size when neither is set. The paginated query still sets HasNextPage or
+// HasPreviousPage when more results are available.
+func (s *service) capPageSize(options *pagination.Options) *pagination.Options {
+	if s.maxPageSize <= 0 {
+		return options
+	}
+
+	capped := pagination.Options{}
+	if options != nil {
+		capped = *options
+	}
+
+	if capped.First == nil && capped.Last == nil {
+		capped.First = &s.maxPageSize
+	}
+	if capped.First != nil && *capped.First > s.maxPageSize {
+		capped.First = &s.maxPageSize
+	}
+	if capped.Last != nil && *capped.Last > s.maxPageSize {
+		capped.Last = &s.maxPageSize
+	}
+
+	return &capped
+}
+
+// resolveAliasSources fetches the source identities of any aliases in a single batch and sets them on the aliases.
+func (s *service) resolveAliasSources(ctx context.Context, managedIdentities []models.ManagedIdentity) error {
+	sourceIDs := []string{}
+	seen := map[string]struct{}{}
+	for _, identity := range managedIdentities {
+		if !identity.IsAlias() {
+			continue
+		}
+		if _, ok := seen[*identity.AliasSourceID]; !ok {
+			seen[*identity.AliasSourceID] = struct{}{}
+			sourceIDs = append(sourceIDs, *identity.AliasSourceID)
+		}
+	}
+
+	if len(sourceIDs) == 0 {
+		return nil
+	}
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Sources the caller isn't allowed to view are left unresolved rather than failing the whole page.
+	sources, err := s.getAuthorizedManagedIdentities(ctx, caller, sourceIDs)
+	if err != nil {
+		return err
+	}
+
+	sourceMap := make(map[string]*models.ManagedIdentity, len(sources))
+	for i := range sources {
+		sourceMap[sources[i].Metadata.ID] = &sources[i]
+	}
+
+	for i := range managedIdentities {
+		if managedIdentities[i].IsAlias() {
+			managedIdentities[i].AliasSource = sourceMap[*managedIdentities[i].AliasSourceID]
+		}
+	}
+
+	return nil
+}
+
+// isAccessDeniedError returns true if the error is from an access check the caller didn't pass;
+// callers without any membership in a namespace get ENotFound rather than EForbidden.
+// getAuthorizedManagedIdentities returns the managed identities with the IDs that the caller is allowed to view.
+func (s *service) getAuthorizedManagedIdentities(ctx context.Context, caller auth.Caller, ids []string) ([]models.ManagedIdentity, error) {
+	if len(ids) == 0 {
+		return []models.ManagedIdentity{}, nil
+	}
+
+	results, err := s.dbClient.ManagedIdentities.GetManagedIdentities(ctx, &db.GetManagedIdentitiesInput{
+		Filter: &db.ManagedIdentityFilter{
+			ManagedIdentityIDs: ids,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	namespacePaths := getGroupPaths(results.ManagedIdentities)
+	if len(namespacePaths) == 0 {
+		return []models.ManagedIdentity{}, nil
+	}
+
+	// Authorize all the groups at once, and only authorize each group separately
+	// when the caller can't access all of them.
+	unauthorizedPaths := map[string]struct{}{}
+	err = caller.RequireAccessToInheritableResource(ctx, permissions.ManagedIdentityResourceType, auth.WithNamespacePaths(namespacePaths))
+	if err != nil {
+		if !isAccessDeniedError(err) {
+			return nil, err
+		}
+
+		for _, groupPath := range namespacePaths {
+			err = caller.RequireAccessToInheritableResource(ctx, permissions.ManagedIdentityResourceType, auth.WithNamespacePaths([]string{groupPath}))
+			if err != nil {
+				if isAccessDeniedError(err) {
+					unauthorizedPaths[groupPath] = struct{}{}
+					continue
+				}
+				return nil, err
+			}
+		}
+	}
+
+	authorized := []models.ManagedIdentity{}
+	for _, identity := range results.ManagedIdentities {
+		if _, ok := unauthorizedPaths[identity.GetGroupPath()]; !ok {
+			authorized = append(authorized, identity)
+		}
+	}
+
+	return authorized, nil
+}
+
+// getGroupPaths returns the distinct group paths of the managed identities.
+func getGroupPaths(managedIdentities []models.ManagedIdentity) []string {
+	groupPaths := []string{}
+	seen := map[string]struct{}{}
+	for _, identity := range managedIdentities {
+		groupPath := identity.GetGroupPath()
+		if _, ok := seen[groupPath]; !ok {
+			seen[groupPath] = struct{}{}
+			groupPaths = append(groupPaths, groupPath)
+		}
+	}
+
+	return groupPaths
+}
+
+func isAccessDeniedError(err error) bool {
+	code := errors.ErrorCode(err)
+	return code == errors.EForbidden || code == errors.ENotFound
+}
+
 func (s *service) getDelegate(delegateType models.ManagedIdentityType) (Delegate, error) {
 	delegate, ok := s.delegateMap[delegateType]
 	if !ok {
@@ -1694,14 +3230,27 @@ func (s *service) getDelegate(delegateType models.ManagedIdentityType) (Delegate
 	return delegate, nil
 }
 
+// checkAllowedPrincipalsLimit verifies that the combined number of users, service accounts and teams
+// allowed by an access rule doesn't exceed the limit.
+func (s *service) checkAllowedPrincipalsLimit(ctx context.Context, rule *models.ManagedIdentityAccessRule) error {
+	principalCount := len(rule.AllowedUserIDs) + len(rule.AllowedServiceAccountIDs) + len(rule.AllowedTeamIDs)
+	return s.limitChecker.CheckLimit(ctx, limits.ResourceLimitAllowedPrincipalsPerManagedIdentityAccessRule, int32(principalCount))
+}
+
 func (s *service) verifyServiceAccountAccessForGroup(ctx context.Context, serviceAccountIDs []string, groupPath string) error {
-	for _, id := range serviceAccountIDs {
-		sa, err := s.dbClient.ServiceAccounts.GetServiceAccountByID(ctx, id)
-		if err != nil {
-			return err
-		}
+	if len(serviceAccountIDs) == 0 {
+		return nil
+	}
+
+	serviceAccountMap, err := s.dbClient.ServiceAccounts.GetServiceAccountsByIDs(ctx, serviceAccountIDs)
+	if err != nil {
+		return err
+	}
 
-		if sa == nil {
+	// Check in the order the IDs were given so the first offending service account is reported.
+	for _, id := range serviceAccountIDs {
+		sa, ok := serviceAccountMap[id]
+		if !ok {
 			return errors.New("service account with ID %s not found", id, errors.WithErrorCode(errors.ENotFound))
 		}
 
@@ -1714,6 +3263,131 @@ func (s *service) verifyServiceAccountAccessForGroup(ctx context.Context, servic
 	return nil
 }
 
+// verifyAllowedTeamsExist returns a not found error for the first team ID that doesn't exist.
+func (s *service) verifyAllowedTeamsExist(ctx context.Context, teamIDs []string) error {
+	if len(teamIDs) == 0 {
+		return nil
+	}
+
+	result, err := s.dbClient.Teams.GetTeams(ctx, &db.GetTeamsInput{
+		Filter: &db.TeamFilter{
+			TeamIDs: teamIDs,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]struct{}, len(result.Teams))
+	for _, team := range result.Teams {
+		found[team.Metadata.ID] = struct{}{}
+	}
+
+	for _, id := range teamIDs {
+		if _, ok := found[id]; !ok {
+			return errors.New("team with ID %s not found", id, errors.WithErrorCode(errors.ENotFound))
+		}
+	}
+	return nil
+}
+
+// verifyAllowedUsersExist returns a not found error for the first user ID that doesn't exist.
+func (s *service) verifyAllowedUsersExist(ctx context.Context, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	result, err := s.dbClient.Users.GetUsers(ctx, &db.GetUsersInput{
+		Filter: &db.UserFilter{
+			UserIDs: userIDs,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]struct{}, len(result.Users))
+	for _, user := range result.Users {
+		found[user.Metadata.ID] = struct{}{}
+	}
+
+	for _, id := range userIDs {
+		if _, ok := found[id]; !ok {
+			return errors.New("user with ID %s not found", id, errors.WithErrorCode(errors.ENotFound))
+		}
+	}
+	return nil
+}
+
+// validateAccessRuleRunStages returns an error if the run stages of a set of access rules don't include
+// both the plan and apply stages, since a stage without any rules allows anyone to use the managed identity.
+func validateAccessRuleRunStages(runStages []models.JobType) error {
+	covered := map[models.JobType]bool{}
+	for _, runStage := range runStages {
+		covered[runStage] = true
+	}
+
+	missing := []string{}
+	for _, runStage := range []models.JobType{models.JobPlanType, models.JobApplyType} {
+		if !covered[runStage] {
+			missing = append(missing, string(runStage))
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.New(
+			"access rules must be provided for every run stage but there are no rules for the %s stage(s)",
+			strings.Join(missing, ", "),
+			errors.WithErrorCode(errors.EInvalid),
+		)
+	}
+
+	return nil
+}
+
+// validateManagedIdentityDataSize returns an error if the data exceeds the max size for the managed identity type
+func validateManagedIdentityDataSize(identityType models.ManagedIdentityType, data []byte) error {
+	maxSize, ok := maxManagedIdentityDataSize[identityType]
+	if !ok {
+		return nil
+	}
+
+	if len(data) > maxSize {
+		return errors.New(
+			"managed identity data for type %s must not exceed %d bytes, got %d bytes", identityType, maxSize, len(data),
+			errors.WithErrorCode(errors.EInvalid),
+		)
+	}
+
+	return nil
+}
+
+// createLimitExceededActivityEvent records that a managed identity request was rejected by a resource limit when
+// err is a limit exceeded error. The context must not be the transaction context since that transaction is rolled back.
+func (s *service) createLimitExceededActivityEvent(ctx context.Context, err error, namespacePath string,
+	targetType models.ActivityEventTargetType, targetID string,
+) {
+	limitErr, ok := limits.AsLimitExceededError(err)
+	if !ok || !s.limitActivityEventsEnabled {
+		return
+	}
+
+	if _, aErr := s.activityService.CreateActivityEvent(ctx,
+		&activityevent.CreateActivityEventInput{
+			NamespacePath: &namespacePath,
+			Action:        models.ActionLimitExceeded,
+			TargetType:    targetType,
+			TargetID:      targetID,
+			Payload: &models.ActivityEventLimitExceededPayload{
+				LimitName: string(limitErr.LimitName),
+				Value:     limitErr.Value,
+			},
+		}); aErr != nil {
+		// The limit error is still returned to the caller so only log the failure here
+		s.logger.Errorf("failed to create limit exceeded activity event for %s %s: %v", targetType, targetID, aErr)
+	}
+}
+
 func (s *service) getManagedIdentityByID(ctx context.Context, id string) (*models.ManagedIdentity, error) {
 	// Get identity from DB
 	identity, err := s.dbClient.ManagedIdentities.GetManagedIdentityByID(ctx, id)
@@ -1734,3 +3408,49 @@ func isResourcePathInvalid(path string) bool {
 		strings.HasPrefix(path, "/") ||
 		strings.HasSuffix(path, "/")
 }
+
+// intersectPaths returns the paths in a that are also in b, keeping the order of a
+func intersectPaths(a, b []string) []string {
+	inB := map[string]struct{}{}
+	for _, path := range b {
+		inB[path] = struct{}{}
+	}
+
+	paths := []string{}
+	for _, path := range a {
+		if _, ok := inB[path]; ok {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// DelegatesHealth returns the health of the delegate for each managed identity type. A nil error means
+// the delegate is healthy; delegates that don't implement HealthChecker are always reported as healthy.
+func (s *service) DelegatesHealth(ctx context.Context) (map[models.ManagedIdentityType]error, error) {
+	ctx, span := tracer.Start(ctx, "svc.DelegatesHealth")
+	defer span.End()
+
+	if _, err := auth.AuthorizeCaller(ctx); err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	health := make(map[models.ManagedIdentityType]error, len(s.delegateMap))
+	for identityType, delegate := range s.delegateMap {
+		checker, ok := delegate.(HealthChecker)
+		if !ok {
+			health[identityType] = nil
+			continue
+		}
+
+		err := checker.HealthCheck(ctx)
+		if err != nil {
+			s.logger.Errorf("health check failed for %s managed identity delegate: %v", identityType, err)
+		}
+		health[identityType] = err
+	}
+
+	return health, nil
+}
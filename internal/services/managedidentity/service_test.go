@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/smithy-go/ptr"
 	"github.com/stretchr/testify/assert"
@@ -13,6 +14,7 @@ import (
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/db"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/limits"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/reservednames"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/activityevent"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/job"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/workspace"
@@ -24,6 +26,7 @@ import (
 func TestGetManagedIdentities(t *testing.T) {
 	nonExistentID := "does_not_exist"
 	idOfManagedIdentity := "101"
+	unusedSince := time.Now().UTC().Add(-90 * 24 * time.Hour)
 	sampleManagedIdentity := models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{ID: idOfManagedIdentity},
 		Name:     "a-sample-managed-identity",
@@ -95,6 +98,20 @@ func TestGetManagedIdentities(t *testing.T) {
 			},
 			expectResult: sampleResult,
 		},
+		{
+			name: "positive: input with unused since cutoff",
+			input: &GetManagedIdentitiesInput{
+				NamespacePath: "a-namespace",
+				UnusedSince:   &unusedSince,
+			},
+			dbInput: &db.GetManagedIdentitiesInput{
+				Filter: &db.ManagedIdentityFilter{
+					NamespacePaths: []string{"a-namespace"},
+					UnusedSince:    &unusedSince,
+				},
+			},
+			expectResult: sampleResult,
+		},
 		{
 			name: "negative: subject does not have viewer access to namespace",
 			input: &GetManagedIdentitiesInput{
@@ -168,7 +185,7 @@ func TestGetManagedIdentities(t *testing.T) {
 				ManagedIdentities: mockManagedIdentities,
 			}
 
-			service := NewService(nil, dbClient, nil, nil, nil, nil, nil)
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
 
 			result, err := service.GetManagedIdentities(auth.WithCaller(ctx, mockCaller), test.input)
 
@@ -186,78 +203,71 @@ func TestGetManagedIdentities(t *testing.T) {
 	}
 }
 
-func TestDeleteManagedIdentity(t *testing.T) {
-	sampleManagedIdentity := &models.ManagedIdentity{
-		Metadata: models.ResourceMetadata{
-			ID: "some-id",
-		},
-		Name:         "a-managed-identity-to-delete",
-		ResourcePath: "some/resource/path",
-		GroupID:      "some-group-id",
-	}
-
-	activityEventInput := &activityevent.CreateActivityEventInput{
-		NamespacePath: ptr.String("some/resource"),
-		Action:        models.ActionDeleteChildResource,
-		TargetType:    models.TargetGroup,
-		TargetID:      sampleManagedIdentity.GroupID,
-		Payload: &models.ActivityEventDeleteChildResourcePayload{
-			Name: sampleManagedIdentity.Name,
-			ID:   sampleManagedIdentity.Metadata.ID,
-			Type: string(models.TargetManagedIdentity),
-		},
-	}
+func TestGetManagedIdentitiesMaxPageSize(t *testing.T) {
+	maxPageSize := int32(10)
 
 	type testCase struct {
-		input                     *DeleteManagedIdentityInput
-		authError                 error
-		expectErrorCode           errors.CodeType
-		name                      string
-		managedIdentityWorkspaces []models.Workspace
+		name                string
+		maxPageSize         int32
+		paginationOptions   *pagination.Options
+		expectDBPagination  *pagination.Options
+		injectHasNextPage   bool
+		expectHasNextPage   bool
+		injectHasPrevPage   bool
+		expectHasPrevPage   bool
+		injectIdentityCount int
 	}
 
 	testCases := []testCase{
 		{
-			name: "positive: successfully delete a managed identity",
-			input: &DeleteManagedIdentityInput{
-				ManagedIdentity: sampleManagedIdentity,
-			},
+			name:                "first larger than the cap is clamped",
+			maxPageSize:         maxPageSize,
+			paginationOptions:   &pagination.Options{First: ptr.Int32(500)},
+			expectDBPagination:  &pagination.Options{First: ptr.Int32(maxPageSize)},
+			injectHasNextPage:   true,
+			expectHasNextPage:   true,
+			injectIdentityCount: int(maxPageSize),
 		},
 		{
-			name: "positive: successfully delete a managed identity with force option",
-			input: &DeleteManagedIdentityInput{
-				ManagedIdentity: sampleManagedIdentity,
-				Force:           true,
-			},
+			name:                "last larger than the cap is clamped",
+			maxPageSize:         maxPageSize,
+			paginationOptions:   &pagination.Options{Last: ptr.Int32(500), Before: ptr.String("some-cursor")},
+			expectDBPagination:  &pagination.Options{Last: ptr.Int32(maxPageSize), Before: ptr.String("some-cursor")},
+			injectHasPrevPage:   true,
+			expectHasPrevPage:   true,
+			injectIdentityCount: int(maxPageSize),
 		},
 		{
-			name: "negative: no force option and managed identity is assigned to a workspace",
-			input: &DeleteManagedIdentityInput{
-				ManagedIdentity: sampleManagedIdentity,
-			},
-			managedIdentityWorkspaces: []models.Workspace{
-				{
-					FullPath: "some/associated/workspace",
-				},
-			},
-			expectErrorCode: errors.EConflict,
+			name:                "first within the cap is unchanged",
+			maxPageSize:         maxPageSize,
+			paginationOptions:   &pagination.Options{First: ptr.Int32(5)},
+			expectDBPagination:  &pagination.Options{First: ptr.Int32(5)},
+			injectIdentityCount: 5,
 		},
 		{
-			name: "negative: attempting to delete a managed identity alias",
-			input: &DeleteManagedIdentityInput{
-				ManagedIdentity: &models.ManagedIdentity{
-					AliasSourceID: &sampleManagedIdentity.Metadata.ID,
-				},
-			},
-			expectErrorCode: errors.EInvalid,
+			name:                "no pagination options defaults to the cap",
+			maxPageSize:         maxPageSize,
+			expectDBPagination:  &pagination.Options{First: ptr.Int32(maxPageSize)},
+			injectHasNextPage:   true,
+			expectHasNextPage:   true,
+			injectIdentityCount: int(maxPageSize),
 		},
 		{
-			name:      "negative: subject does not have permissions in group",
-			authError: errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			input: &DeleteManagedIdentityInput{
-				ManagedIdentity: sampleManagedIdentity,
-			},
-			expectErrorCode: errors.EForbidden,
+			name:                "pagination options without first or last default to the cap",
+			maxPageSize:         maxPageSize,
+			paginationOptions:   &pagination.Options{After: ptr.String("some-cursor")},
+			expectDBPagination:  &pagination.Options{First: ptr.Int32(maxPageSize), After: ptr.String("some-cursor")},
+			injectIdentityCount: 5,
+		},
+		{
+			name:                "no pagination options without a cap",
+			injectIdentityCount: 20,
+		},
+		{
+			name:                "no cap configured",
+			paginationOptions:   &pagination.Options{First: ptr.Int32(500)},
+			expectDBPagination:  &pagination.Options{First: ptr.Int32(500)},
+			injectIdentityCount: 20,
 		},
 	}
 
@@ -266,40 +276,156 @@ func TestDeleteManagedIdentity(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			mockManagedIdentities := db.NewMockManagedIdentities(t)
-			mockWorkspaces := db.NewMockWorkspaces(t)
-			mockActivityEvents := activityevent.NewMockService(t)
-			mockTransactions := db.NewMockTransactions(t)
 			mockCaller := auth.NewMockCaller(t)
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
 
-			if !test.input.ManagedIdentity.IsAlias() {
-				mockCaller.On("RequirePermission", mock.Anything, permissions.DeleteManagedIdentityPermission, mock.Anything).Return(test.authError)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.ViewManagedIdentityPermission, mock.Anything).Return(nil)
+
+			identities := make([]models.ManagedIdentity, test.injectIdentityCount)
+
+			mockManagedIdentities.On("GetManagedIdentities", mock.Anything, &db.GetManagedIdentitiesInput{
+				PaginationOptions: test.expectDBPagination,
+				Filter: &db.ManagedIdentityFilter{
+					NamespacePaths: []string{"a-namespace"},
+				},
+			}).Return(&db.ManagedIdentitiesResult{
+				PageInfo: &pagination.PageInfo{
+					HasNextPage:     test.injectHasNextPage,
+					HasPreviousPage: test.injectHasPrevPage,
+				},
+				ManagedIdentities: identities,
+			}, nil)
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
 			}
 
-			mockCaller.On("GetSubject").Return("mockSubject").Maybe()
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{MaxPageSize: test.maxPageSize})
 
-			mockWorkspaces.On("GetWorkspacesForManagedIdentity", mock.Anything, sampleManagedIdentity.Metadata.ID).Return(test.managedIdentityWorkspaces, nil).Maybe()
+			var requestedFirst *int32
+			if test.paginationOptions != nil && test.paginationOptions.First != nil {
+				requestedFirst = ptr.Int32(*test.paginationOptions.First)
+			}
 
-			if test.expectErrorCode == "" {
-				mockManagedIdentities.On("DeleteManagedIdentity", mock.Anything, test.input.ManagedIdentity).Return(nil)
+			result, err := service.GetManagedIdentities(auth.WithCaller(ctx, mockCaller), &GetManagedIdentitiesInput{
+				NamespacePath:     "a-namespace",
+				PaginationOptions: test.paginationOptions,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
 
-				mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
+			assert.Len(t, result.ManagedIdentities, test.injectIdentityCount)
+			assert.Equal(t, test.expectHasNextPage, result.PageInfo.HasNextPage)
+			assert.Equal(t, test.expectHasPrevPage, result.PageInfo.HasPreviousPage)
 
-				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
-				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
-				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+			// The caller's pagination options must not be modified.
+			if requestedFirst != nil {
+				assert.Equal(t, *requestedFirst, *test.paginationOptions.First)
 			}
+		})
+	}
+}
+
+func TestGetManagedIdentitiesAssignableToWorkspace(t *testing.T) {
+	workspaceID := "workspace-1"
+
+	sampleWorkspace := &models.Workspace{
+		Metadata: models.ResourceMetadata{ID: workspaceID},
+		FullPath: "top-group/sub-group/a-workspace",
+	}
+
+	// Identities in the workspace's group hierarchy and ones outside of it.
+	allIdentities := []models.ManagedIdentity{
+		{Metadata: models.ResourceMetadata{ID: "in-parent"}, ResourcePath: "top-group/sub-group/in-parent"},
+		{Metadata: models.ResourceMetadata{ID: "in-root"}, ResourcePath: "top-group/in-root"},
+		{Metadata: models.ResourceMetadata{ID: "in-sibling"}, ResourcePath: "top-group/other-group/in-sibling"},
+		{Metadata: models.ResourceMetadata{ID: "in-other-root"}, ResourcePath: "other-top-group/in-other-root"},
+		{Metadata: models.ResourceMetadata{ID: "already-assigned"}, ResourcePath: "top-group/already-assigned"},
+	}
+
+	// Identities that are already assigned to the workspace.
+	assignedIDs := map[string]bool{"already-assigned": true}
+
+	testCases := []struct {
+		authError        error
+		name             string
+		namespacePath    string
+		includeInherited bool
+		expectErrorCode  errors.CodeType
+		expectIDs        []string
+	}{
+		{
+			name:      "returns only unassigned identities in the workspace's group hierarchy",
+			expectIDs: []string{"in-parent", "in-root"},
+		},
+		{
+			name:          "namespace path limits the result to that group",
+			namespacePath: "top-group",
+			expectIDs:     []string{"in-root"},
+		},
+		{
+			name:             "namespace path with inherited identities",
+			namespacePath:    "top-group/sub-group",
+			includeInherited: true,
+			expectIDs:        []string{"in-parent", "in-root"},
+		},
+		{
+			name:          "namespace path outside of the workspace's group hierarchy",
+			namespacePath: "top-group/other-group",
+			expectIDs:     []string{},
+		},
+		{
+			name:            "caller cannot assign identities to the workspace",
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockWorkspaces := workspace.NewMockService(t)
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateWorkspacePermission, mock.Anything).Return(test.authError)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.ViewManagedIdentityPermission, mock.Anything).Return(nil).Maybe()
+
+			mockWorkspaces.On("GetWorkspaceByID", mock.Anything, workspaceID).Return(sampleWorkspace, nil).Maybe()
+
+			mockManagedIdentities.On("GetManagedIdentities", mock.Anything, mock.Anything).
+				Return(func(_ context.Context, input *db.GetManagedIdentitiesInput) (*db.ManagedIdentitiesResult, error) {
+					assert.Equal(t, workspaceID, *input.Filter.NotAssignedToWorkspaceID)
+
+					// Mimic the namespace path and assignment filters of the DB layer.
+					result := &db.ManagedIdentitiesResult{ManagedIdentities: []models.ManagedIdentity{}}
+					for _, identity := range allIdentities {
+						if assignedIDs[identity.Metadata.ID] {
+							continue
+						}
+						for _, path := range input.Filter.NamespacePaths {
+							if identity.GetGroupPath() == path {
+								result.ManagedIdentities = append(result.ManagedIdentities, identity)
+							}
+						}
+					}
+					return result, nil
+				}).Maybe()
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
-				Workspaces:        mockWorkspaces,
-				Transactions:      mockTransactions,
 			}
 
-			logger, _ := logger.NewForTest()
-			service := NewService(logger, dbClient, nil, nil, nil, nil, mockActivityEvents)
+			service := NewService(nil, dbClient, nil, nil, mockWorkspaces, nil, nil, ServiceOptions{})
 
-			err := service.DeleteManagedIdentity(auth.WithCaller(ctx, mockCaller), test.input)
+			result, err := service.GetManagedIdentities(auth.WithCaller(ctx, mockCaller), &GetManagedIdentitiesInput{
+				AssignableToWorkspaceID: &workspaceID,
+				NamespacePath:           test.namespacePath,
+				IncludeInherited:        test.includeInherited,
+			})
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -309,39 +435,180 @@ func TestDeleteManagedIdentity(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+
+			actualIDs := []string{}
+			for _, identity := range result.ManagedIdentities {
+				actualIDs = append(actualIDs, identity.Metadata.ID)
+			}
+
+			assert.ElementsMatch(t, test.expectIDs, actualIDs)
 		})
 	}
 }
 
-func TestGetManagedIdentitiesForWorkspace(t *testing.T) {
-	sampleManagedIdentity := models.ManagedIdentity{
-		Metadata: models.ResourceMetadata{
-			ID: "some-id",
+func TestGetManagedIdentitiesResolveAliasSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source1 := models.ManagedIdentity{
+		Metadata:     models.ResourceMetadata{ID: "source-1"},
+		Name:         "source-1",
+		Type:         models.ManagedIdentityAWSFederated,
+		ResourcePath: "other-group/source-1",
+	}
+	source2 := models.ManagedIdentity{
+		Metadata:     models.ResourceMetadata{ID: "source-2"},
+		Name:         "source-2",
+		Type:         models.ManagedIdentityAzureFederated,
+		ResourcePath: "some-group/source-2",
+	}
+	alias1 := models.ManagedIdentity{
+		Metadata:      models.ResourceMetadata{ID: "alias-1"},
+		Name:          "alias-1",
+		ResourcePath:  "some-group/alias-1",
+		AliasSourceID: &source1.Metadata.ID,
+	}
+	alias2 := models.ManagedIdentity{
+		Metadata:      models.ResourceMetadata{ID: "alias-2"},
+		Name:          "alias-2",
+		ResourcePath:  "some-group/alias-2",
+		AliasSourceID: &source1.Metadata.ID,
+	}
+
+	mockCaller := auth.NewMockCaller(t)
+	mockManagedIdentities := db.NewMockManagedIdentities(t)
+
+	mockCaller.On("RequirePermission", mock.Anything, permissions.ViewManagedIdentityPermission, mock.Anything).Return(nil)
+	mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(nil)
+
+	mockManagedIdentities.On("GetManagedIdentities", mock.Anything, &db.GetManagedIdentitiesInput{
+		Filter: &db.ManagedIdentityFilter{
+			NamespacePaths: []string{"some-group"},
 		},
-		Name:         "a-managed-identity",
-		ResourcePath: "some/resource/path",
-		GroupID:      "some-group-id",
+	}).Return(&db.ManagedIdentitiesResult{
+		ManagedIdentities: []models.ManagedIdentity{alias1, source2, alias2},
+	}, nil)
+
+	// Both aliases share a source so it's only requested once
+	mockManagedIdentities.On("GetManagedIdentities", mock.Anything, &db.GetManagedIdentitiesInput{
+		Filter: &db.ManagedIdentityFilter{
+			ManagedIdentityIDs: []string{source1.Metadata.ID},
+		},
+	}).Return(&db.ManagedIdentitiesResult{
+		ManagedIdentities: []models.ManagedIdentity{source1},
+	}, nil).Once()
+
+	dbClient := &db.Client{
+		ManagedIdentities: mockManagedIdentities,
 	}
 
-	type testCase struct {
-		name            string
-		workspaceID     string
-		expectErrorCode errors.CodeType
-		authError       error
-		expectResult    []models.ManagedIdentity
+	service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
+
+	result, err := service.GetManagedIdentities(auth.WithCaller(ctx, mockCaller), &GetManagedIdentitiesInput{
+		NamespacePath:      "some-group",
+		ResolveAliasSource: true,
+	})
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	testCases := []testCase{
+	if len(result.ManagedIdentities) != 3 {
+		t.Fatalf("expected 3 managed identities, got %d", len(result.ManagedIdentities))
+	}
+
+	for _, identity := range result.ManagedIdentities {
+		if !identity.IsAlias() {
+			assert.Nil(t, identity.AliasSource)
+			continue
+		}
+
+		if assert.NotNil(t, identity.AliasSource) {
+			assert.Equal(t, source1.Name, identity.AliasSource.Name)
+			assert.Equal(t, source1.Type, identity.AliasSource.Type)
+		}
+	}
+}
+
+func TestGetManagedIdentitiesResolveAliasSourceWithoutAccess(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source := models.ManagedIdentity{
+		Metadata:     models.ResourceMetadata{ID: "source-1"},
+		Name:         "source-1",
+		ResourcePath: "other-group/source-1",
+	}
+	alias := models.ManagedIdentity{
+		Metadata:      models.ResourceMetadata{ID: "alias-1"},
+		Name:          "alias-1",
+		ResourcePath:  "some-group/alias-1",
+		AliasSourceID: &source.Metadata.ID,
+	}
+
+	mockCaller := auth.NewMockCaller(t)
+	mockManagedIdentities := db.NewMockManagedIdentities(t)
+
+	mockCaller.On("RequirePermission", mock.Anything, permissions.ViewManagedIdentityPermission, mock.Anything).Return(nil)
+
+	// The caller isn't a member of the source's group.
+	mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).
+		Return(errors.New("Not found", errors.WithErrorCode(errors.ENotFound)))
+
+	mockManagedIdentities.On("GetManagedIdentities", mock.Anything, &db.GetManagedIdentitiesInput{
+		Filter: &db.ManagedIdentityFilter{
+			NamespacePaths: []string{"some-group"},
+		},
+	}).Return(&db.ManagedIdentitiesResult{
+		ManagedIdentities: []models.ManagedIdentity{alias},
+	}, nil)
+
+	mockManagedIdentities.On("GetManagedIdentities", mock.Anything, &db.GetManagedIdentitiesInput{
+		Filter: &db.ManagedIdentityFilter{
+			ManagedIdentityIDs: []string{source.Metadata.ID},
+		},
+	}).Return(&db.ManagedIdentitiesResult{
+		ManagedIdentities: []models.ManagedIdentity{source},
+	}, nil)
+
+	dbClient := &db.Client{
+		ManagedIdentities: mockManagedIdentities,
+	}
+
+	service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
+
+	result, err := service.GetManagedIdentities(auth.WithCaller(ctx, mockCaller), &GetManagedIdentitiesInput{
+		NamespacePath:      "some-group",
+		ResolveAliasSource: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The alias is still returned, only its source is left unresolved.
+	if assert.Len(t, result.ManagedIdentities, 1) {
+		assert.Equal(t, alias.Metadata.ID, result.ManagedIdentities[0].Metadata.ID)
+		assert.Nil(t, result.ManagedIdentities[0].AliasSource)
+	}
+}
+
+func TestGetManagedIdentityTypesInNamespace(t *testing.T) {
+	namespacePath := "top-group/sub-group"
+
+	testCases := []struct {
+		authError       error
+		name            string
+		expectErrorCode errors.CodeType
+		expectTypes     []models.ManagedIdentityType
+	}{
 		{
-			name:        "positive: successfully returns managed identities for a workspace",
-			workspaceID: "some-workspace-id",
-			expectResult: []models.ManagedIdentity{
-				sampleManagedIdentity,
+			name: "returns the distinct types in the namespace and its ancestors",
+			expectTypes: []models.ManagedIdentityType{
+				models.ManagedIdentityAWSFederated,
+				models.ManagedIdentityAzureFederated,
 			},
 		},
 		{
-			name:            "negative: subject does not have viewer access to workspace",
-			workspaceID:     "some-workspace-id",
+			name:            "caller cannot view managed identities in the namespace",
 			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
 			expectErrorCode: errors.EForbidden,
 		},
@@ -352,22 +619,22 @@ func TestGetManagedIdentitiesForWorkspace(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			mockManagedIdentities := db.NewMockManagedIdentities(t)
 			mockCaller := auth.NewMockCaller(t)
-
-			if test.expectErrorCode == "" {
-				mockManagedIdentities.On("GetManagedIdentitiesForWorkspace", mock.Anything, test.workspaceID).Return(test.expectResult, nil)
-			}
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
 
 			mockCaller.On("RequirePermission", mock.Anything, permissions.ViewManagedIdentityPermission, mock.Anything).Return(test.authError)
 
+			mockManagedIdentities.On("GetManagedIdentityTypes", mock.Anything, &db.ManagedIdentityFilter{
+				NamespacePaths: []string{"top-group/sub-group", "top-group"},
+			}).Return(test.expectTypes, nil).Maybe()
+
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
 			}
 
-			service := NewService(nil, dbClient, nil, nil, nil, nil, nil)
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
 
-			result, err := service.GetManagedIdentitiesForWorkspace(auth.WithCaller(ctx, mockCaller), test.workspaceID)
+			types, err := service.GetManagedIdentityTypesInNamespace(auth.WithCaller(ctx, mockCaller), namespacePath)
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -378,131 +645,45 @@ func TestGetManagedIdentitiesForWorkspace(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			assert.Equal(t, test.expectResult, result)
+			assert.Equal(t, test.expectTypes, types)
 		})
 	}
 }
 
-func TestAddManagedIdentityToWorkspace(t *testing.T) {
-	awsManagedIdentity := &models.ManagedIdentity{
-		Metadata: models.ResourceMetadata{
-			ID: "some-managed-identity-id",
-		},
-		Name:         "a-managed-identity",
-		ResourcePath: "some/resource/path",
-		GroupID:      "some-group-id",
-		Type:         models.ManagedIdentityAWSFederated,
-	}
+func TestFindDuplicateManagedIdentityData(t *testing.T) {
+	groupID := "group-1"
 
-	sampleWorkspace := &models.Workspace{
-		FullPath: "some/resource/path",
+	// Identities with the same type and input data are duplicates even though their subjects differ.
+	identities := []models.ManagedIdentity{
+		{Metadata: models.ResourceMetadata{ID: "identity-1"}, Type: models.ManagedIdentityAWSFederated, Data: []byte("subject-1")},
+		{Metadata: models.ResourceMetadata{ID: "identity-2"}, Type: models.ManagedIdentityAWSFederated, Data: []byte("subject-2")},
+		{Metadata: models.ResourceMetadata{ID: "identity-3"}, Type: models.ManagedIdentityAWSFederated, Data: []byte("subject-3")},
+		{Metadata: models.ResourceMetadata{ID: "identity-4"}, Type: models.ManagedIdentityAzureFederated, Data: []byte("subject-4")},
 	}
 
-	activityEventInput := &activityevent.CreateActivityEventInput{
-		NamespacePath: &sampleWorkspace.FullPath,
-		Action:        models.ActionAdd,
-		TargetType:    models.TargetManagedIdentity,
-		TargetID:      awsManagedIdentity.Metadata.ID,
+	inputData := map[string]string{
+		"identity-1": `{"role":"role-a"}`,
+		"identity-2": `{"role":"role-a"}`,
+		"identity-3": `{"role":"role-b"}`,
+		"identity-4": `{"role":"role-a"}`,
 	}
 
 	type testCase struct {
-		authError                           error
-		existingManagedIdentity             *models.ManagedIdentity
-		existingWorkspace                   *models.Workspace
-		name                                string
-		managedIdentityID                   string
-		workspaceID                         string
-		expectErrorCode                     errors.CodeType
-		identitiesInWorkspace               []models.ManagedIdentity
-		limit                               int
-		injectManagedIdentitiesPerWorkspace int32
-		exceedsLimit                        bool
-	}
+		authError        error
+		name             string
+		expectErrorCode  errors.CodeType
+		expectDuplicates [][]string
+	}
 
 	testCases := []testCase{
 		{
-			name:                                "positive: successfully add managed identity to workspace",
-			existingManagedIdentity:             awsManagedIdentity,
-			existingWorkspace:                   sampleWorkspace,
-			identitiesInWorkspace:               []models.ManagedIdentity{},
-			managedIdentityID:                   "some-managed-identity-id",
-			workspaceID:                         "some-workspace-id",
-			limit:                               5,
-			injectManagedIdentitiesPerWorkspace: 5,
-		},
-		{
-			name:              "negative: managed identity being added doesn't exist",
-			managedIdentityID: "some-managed-identity-id",
-			workspaceID:       "some-workspace-id",
-			expectErrorCode:   errors.ENotFound,
-		},
-		{
-			name:                    "negative: managed identity is not under the same group hierarchy",
-			existingManagedIdentity: awsManagedIdentity,
-			existingWorkspace: &models.Workspace{
-				FullPath: "another/path",
-			},
-			managedIdentityID: "some-managed-identity-id",
-			workspaceID:       "some-workspace-id",
-			expectErrorCode:   errors.EInvalid,
-		},
-		{
-			name:                    "can assign more than one aws managed identity",
-			existingManagedIdentity: awsManagedIdentity,
-			existingWorkspace:       sampleWorkspace,
-			identitiesInWorkspace: []models.ManagedIdentity{
-				*awsManagedIdentity,
-			},
-			managedIdentityID:                   "some-managed-identity-id",
-			workspaceID:                         "some-workspace-id",
-			limit:                               5,
-			injectManagedIdentitiesPerWorkspace: 5,
-		},
-		{
-			name: "cannot assign more than one non-aws managed identity",
-			existingManagedIdentity: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "some-managed-identity-id",
-				},
-				Name:         "a-managed-identity",
-				ResourcePath: "some/resource/path",
-				GroupID:      "some-group-id",
-				Type:         models.ManagedIdentityAzureFederated,
-			},
-			existingWorkspace: sampleWorkspace,
-			identitiesInWorkspace: []models.ManagedIdentity{
-				{
-					Metadata: models.ResourceMetadata{
-						ID: "some-managed-identity-id-1",
-					},
-					Name:         "a-managed-identity",
-					ResourcePath: "some/resource/path",
-					GroupID:      "some-group-id",
-					Type:         models.ManagedIdentityAzureFederated,
-				},
-			},
-			managedIdentityID: "some-managed-identity-id",
-			workspaceID:       "some-workspace-id",
-			expectErrorCode:   errors.EInvalid,
-		},
-		{
-			name:              "negative: subject does not have permissions for workspace",
-			managedIdentityID: "some-managed-identity-id",
-			workspaceID:       "some-workspace-id",
-			authError:         errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode:   errors.EForbidden,
+			name:             "identities with the same type and input data are returned",
+			expectDuplicates: [][]string{{"identity-1", "identity-2"}},
 		},
 		{
-			name:                                "exceeds limit",
-			existingManagedIdentity:             awsManagedIdentity,
-			existingWorkspace:                   sampleWorkspace,
-			identitiesInWorkspace:               []models.ManagedIdentity{},
-			managedIdentityID:                   "some-managed-identity-id",
-			workspaceID:                         "some-workspace-id",
-			limit:                               5,
-			injectManagedIdentitiesPerWorkspace: 6,
-			exceedsLimit:                        true,
-			expectErrorCode:                     errors.EInvalid,
+			name:            "caller can't view managed identities in the group",
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
 		},
 	}
 
@@ -511,77 +692,35 @@ func TestAddManagedIdentityToWorkspace(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			mockManagedIdentities := db.NewMockManagedIdentities(t)
-			mockWorkspaces := workspace.NewMockService(t)
-			mockActivityEvents := activityevent.NewMockService(t)
-			mockTransactions := db.NewMockTransactions(t)
 			mockCaller := auth.NewMockCaller(t)
-			mockResourceLimits := db.NewMockResourceLimits(t)
-
-			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateWorkspacePermission, mock.Anything).Return(test.authError)
-
-			if test.identitiesInWorkspace != nil {
-				mockCaller.On("RequirePermission", mock.Anything, permissions.ViewManagedIdentityPermission, mock.Anything).Return(test.authError)
-
-				// This mock On is hit by both the initial check before doing the assignment and for the later check afterward.
-				// To get past the initial check but still allow a non-trivial limit value,
-				// the ones it returns are all of a type different from the one being assigned in this test.
-				// Also, please note that this is not a paginated return, so ...PageInfo.TotalCount is not an option.
-				mockManagedIdentities.On("GetManagedIdentitiesForWorkspace", mock.Anything, test.workspaceID).
-					Return(
-
-						func(ctx context.Context, workspaceID string) []models.ManagedIdentity {
-							_ = ctx
-							_ = workspaceID
-
-							// For the 'already assigned' test case, use the slice supplied by the test case.
-							if len(test.identitiesInWorkspace) > 0 {
-								return test.identitiesInWorkspace
-							}
-
-							result := []models.ManagedIdentity{}
-							for len(result) < int(test.injectManagedIdentitiesPerWorkspace) {
-								result = append(result, models.ManagedIdentity{Type: models.ManagedIdentityAzureFederated})
-							}
-
-							return result
-						}, nil)
-			}
-
-			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.managedIdentityID).Return(test.existingManagedIdentity, nil).Maybe()
-
-			mockWorkspaces.On("GetWorkspaceByID", mock.Anything, test.workspaceID).Return(test.existingWorkspace, nil).Maybe()
-
-			if (test.expectErrorCode == "") || test.exceedsLimit {
-				mockManagedIdentities.On("AddManagedIdentityToWorkspace", mock.Anything, test.managedIdentityID, test.workspaceID).Return(nil)
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockDelegate := NewMockDelegate(t)
 
-				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
-				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
-				if !test.exceedsLimit {
-					mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
-					mockTransactions.On("CommitTx", mock.Anything).Return(nil)
-					mockCaller.On("GetSubject").Return("mockSubject")
-				}
-			}
+			mockCaller.On("RequirePermission", mock.Anything, permissions.ViewManagedIdentityPermission, mock.Anything).Return(test.authError)
 
-			// Called inside transaction to check resource limits.
-			if test.limit > 0 {
-				// The mock On of GetManagedIdentitiesForWorkspace is done above.
+			mockManagedIdentities.On("GetManagedIdentities", mock.Anything, &db.GetManagedIdentitiesInput{
+				Filter: &db.ManagedIdentityFilter{
+					GroupID:     &groupID,
+					AliasesOnly: ptr.Bool(false),
+				},
+			}).Return(&db.ManagedIdentitiesResult{ManagedIdentities: identities}, nil).Maybe()
 
-				mockResourceLimits.On("GetResourceLimit", mock.Anything, mock.Anything).
-					Return(&models.ResourceLimit{Value: test.limit}, nil)
-			}
+			mockDelegate.On("GetInputData", mock.Anything).Return(func(identity *models.ManagedIdentity) ([]byte, error) {
+				return []byte(inputData[identity.Metadata.ID]), nil
+			}).Maybe()
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
-				Transactions:      mockTransactions,
-				ResourceLimits:    mockResourceLimits,
 			}
 
-			logger, _ := logger.NewForTest()
-			service := NewService(logger, dbClient, limits.NewLimitChecker(dbClient), nil, mockWorkspaces, nil, mockActivityEvents)
+			delegateMap := map[models.ManagedIdentityType]Delegate{
+				models.ManagedIdentityAWSFederated:   mockDelegate,
+				models.ManagedIdentityAzureFederated: mockDelegate,
+			}
 
-			err := service.AddManagedIdentityToWorkspace(auth.WithCaller(ctx, mockCaller), test.managedIdentityID, test.workspaceID)
+			service := NewService(nil, dbClient, nil, delegateMap, nil, nil, nil, ServiceOptions{})
+
+			duplicates, err := service.FindDuplicateManagedIdentityData(auth.WithCaller(ctx, mockCaller), groupID)
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -591,60 +730,35 @@ func TestAddManagedIdentityToWorkspace(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+
+			assert.Equal(t, test.expectDuplicates, duplicates)
 		})
 	}
 }
 
-func TestRemoveManagedIdentityFromWorkspace(t *testing.T) {
-	sampleManagedIdentity := &models.ManagedIdentity{
-		Metadata: models.ResourceMetadata{
-			ID: "some-managed-identity-id",
-		},
-		Name:         "a-managed-identity",
-		ResourcePath: "some/resource/path",
-		GroupID:      "some-group-id",
-		Type:         models.ManagedIdentityAWSFederated,
-	}
-
-	sampleWorkspace := &models.Workspace{
-		FullPath: "some/resource/path",
-	}
-
-	activityEventInput := &activityevent.CreateActivityEventInput{
-		NamespacePath: &sampleWorkspace.FullPath,
-		Action:        models.ActionRemove,
-		TargetType:    models.TargetManagedIdentity,
-		TargetID:      sampleManagedIdentity.Metadata.ID,
+func TestGetOrphanedManagedIdentityAliases(t *testing.T) {
+	orphanedAlias := models.ManagedIdentity{
+		Metadata:      models.ResourceMetadata{ID: "alias-1"},
+		AliasSourceID: ptr.String("deleted-source-id"),
 	}
 
 	type testCase struct {
-		authError               error
-		existingManagedIdentity *models.ManagedIdentity
-		name                    string
-		managedIdentityID       string
-		workspaceID             string
-		expectErrorCode         errors.CodeType
+		caller          auth.Caller
+		name            string
+		expectErrorCode errors.CodeType
+		expectAliases   []models.ManagedIdentity
 	}
 
 	testCases := []testCase{
 		{
-			name:                    "positive: successfully remove managed identity from workspace",
-			existingManagedIdentity: sampleManagedIdentity,
-			managedIdentityID:       "some-managed-identity-id",
-			workspaceID:             "some-workspace-id",
-		},
-		{
-			name:              "negative: managed identity being removed doesn't exist",
-			managedIdentityID: "some-managed-identity-id",
-			workspaceID:       "some-workspace-id",
-			expectErrorCode:   errors.ENotFound,
+			name:          "system caller gets orphaned aliases",
+			caller:        &auth.SystemCaller{},
+			expectAliases: []models.ManagedIdentity{orphanedAlias},
 		},
 		{
-			name:              "negative: subject does not have permissions for workspace",
-			managedIdentityID: "some-managed-identity-id",
-			workspaceID:       "some-workspace-id",
-			authError:         errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode:   errors.EForbidden,
+			name:            "non-system caller is forbidden",
+			caller:          auth.NewMockCaller(t),
+			expectErrorCode: errors.EForbidden,
 		},
 	}
 
@@ -654,40 +768,16 @@ func TestRemoveManagedIdentityFromWorkspace(t *testing.T) {
 			defer cancel()
 
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
-			mockWorkspaces := workspace.NewMockService(t)
-			mockActivityEvents := activityevent.NewMockService(t)
-			mockTransactions := db.NewMockTransactions(t)
-			mockCaller := auth.NewMockCaller(t)
-
-			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateWorkspacePermission, mock.Anything).Return(test.authError)
-
-			if test.authError == nil {
-				mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.managedIdentityID).Return(test.existingManagedIdentity, nil)
-			}
-
-			if test.expectErrorCode == "" {
-				mockManagedIdentities.On("RemoveManagedIdentityFromWorkspace", mock.Anything, test.managedIdentityID, test.workspaceID).Return(nil)
-
-				mockWorkspaces.On("GetWorkspaceByID", mock.Anything, test.workspaceID).Return(sampleWorkspace, nil)
-
-				mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
 
-				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
-				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
-				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
-
-				mockCaller.On("GetSubject").Return("mockSubject")
-			}
+			mockManagedIdentities.On("GetOrphanedManagedIdentityAliases", mock.Anything).Return(test.expectAliases, nil).Maybe()
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
-				Transactions:      mockTransactions,
 			}
 
-			logger, _ := logger.NewForTest()
-			service := NewService(logger, dbClient, nil, nil, mockWorkspaces, nil, mockActivityEvents)
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
 
-			err := service.RemoveManagedIdentityFromWorkspace(auth.WithCaller(ctx, mockCaller), test.managedIdentityID, test.workspaceID)
+			aliases, err := service.GetOrphanedManagedIdentityAliases(auth.WithCaller(ctx, test.caller))
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -697,46 +787,98 @@ func TestRemoveManagedIdentityFromWorkspace(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+
+			assert.Equal(t, test.expectAliases, aliases)
 		})
 	}
 }
 
-func TestGetManagedIdentityByID(t *testing.T) {
+func TestDeleteManagedIdentity(t *testing.T) {
 	sampleManagedIdentity := &models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
-			ID: "some-managed-identity-id",
+			ID: "some-id",
 		},
-		Name:         "a-managed-identity",
+		Name:         "a-managed-identity-to-delete",
 		ResourcePath: "some/resource/path",
 		GroupID:      "some-group-id",
-		Type:         models.ManagedIdentityAWSFederated,
+	}
+
+	activityEventInput := &activityevent.CreateActivityEventInput{
+		NamespacePath: ptr.String("some/resource"),
+		Action:        models.ActionDeleteChildResource,
+		TargetType:    models.TargetGroup,
+		TargetID:      sampleManagedIdentity.GroupID,
+		Payload: &models.ActivityEventDeleteChildResourcePayload{
+			Name: sampleManagedIdentity.Name,
+			ID:   sampleManagedIdentity.Metadata.ID,
+			Type: string(models.TargetManagedIdentity),
+		},
 	}
 
 	type testCase struct {
-		authError             error
-		expectManagedIdentity *models.ManagedIdentity
-		name                  string
-		searchID              string
-		expectErrorCode       errors.CodeType
+		input                     *DeleteManagedIdentityInput
+		authError                 error
+		expectErrorCode           errors.CodeType
+		name                      string
+		managedIdentityWorkspaces []models.Workspace
 	}
 
 	testCases := []testCase{
 		{
-			name:                  "positive: successfully return a managed identity",
-			expectManagedIdentity: sampleManagedIdentity,
-			searchID:              "some-managed-identity-id",
+			name: "positive: successfully delete a managed identity",
+			input: &DeleteManagedIdentityInput{
+				ManagedIdentity: sampleManagedIdentity,
+			},
 		},
 		{
-			name:            "negative: managed identity doesn't exist",
-			searchID:        "some-managed-identity-id",
-			expectErrorCode: errors.ENotFound,
+			name: "positive: successfully delete a managed identity with force option",
+			input: &DeleteManagedIdentityInput{
+				ManagedIdentity: sampleManagedIdentity,
+				Force:           true,
+			},
 		},
 		{
-			name:                  "negative: subject does not have access to resource",
-			searchID:              "some-managed-identity-id",
-			expectManagedIdentity: sampleManagedIdentity,
-			authError:             errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode:       errors.EForbidden,
+			name: "negative: no force option and managed identity is assigned to a workspace",
+			input: &DeleteManagedIdentityInput{
+				ManagedIdentity: sampleManagedIdentity,
+			},
+			managedIdentityWorkspaces: []models.Workspace{
+				{
+					FullPath: "some/associated/workspace",
+				},
+			},
+			expectErrorCode: errors.EConflict,
+		},
+		{
+			name: "negative: managed identity is deletion protected even with force option",
+			input: &DeleteManagedIdentityInput{
+				ManagedIdentity: &models.ManagedIdentity{
+					Metadata:          sampleManagedIdentity.Metadata,
+					Name:              sampleManagedIdentity.Name,
+					ResourcePath:      sampleManagedIdentity.ResourcePath,
+					GroupID:           sampleManagedIdentity.GroupID,
+					DeletionProtected: true,
+				},
+				Force: true,
+			},
+			expectErrorCode: errors.EConflict,
+		},
+		{
+			name: "negative: attempting to delete a managed identity alias",
+			input: &DeleteManagedIdentityInput{
+				ManagedIdentity: &models.ManagedIdentity{
+					AliasSourceID: &sampleManagedIdentity.Metadata.ID,
+				},
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:      "negative: subject does not have permissions in group",
+			authError: errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			input: &DeleteManagedIdentityInput{
+				ManagedIdentity: sampleManagedIdentity,
+			},
+			expectErrorCode: errors.EForbidden,
 		},
 	}
 
@@ -746,19 +888,39 @@ func TestGetManagedIdentityByID(t *testing.T) {
 			defer cancel()
 
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+			mockTransactions := db.NewMockTransactions(t)
 			mockCaller := auth.NewMockCaller(t)
 
-			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.searchID).Return(test.expectManagedIdentity, nil)
+			if !test.input.ManagedIdentity.IsAlias() {
+				mockCaller.On("RequirePermission", mock.Anything, permissions.DeleteManagedIdentityPermission, mock.Anything).Return(test.authError)
+			}
 
-			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError).Maybe()
+			mockCaller.On("GetSubject").Return("mockSubject").Maybe()
+
+			mockWorkspaces.On("GetWorkspacesForManagedIdentity", mock.Anything, sampleManagedIdentity.Metadata.ID).Return(test.managedIdentityWorkspaces, nil).Maybe()
+
+			if test.expectErrorCode == "" {
+				mockManagedIdentities.On("DeleteManagedIdentity", mock.Anything, test.input.ManagedIdentity).Return(nil)
+
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
+
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+			}
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
+				Workspaces:        mockWorkspaces,
+				Transactions:      mockTransactions,
 			}
 
-			service := NewService(nil, dbClient, nil, nil, nil, nil, nil)
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, nil, nil, nil, nil, mockActivityEvents, ServiceOptions{})
 
-			identity, err := service.GetManagedIdentityByID(auth.WithCaller(ctx, mockCaller), test.searchID)
+			err := service.DeleteManagedIdentity(auth.WithCaller(ctx, mockCaller), test.input)
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -768,48 +930,110 @@ func TestGetManagedIdentityByID(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-
-			assert.Equal(t, test.expectManagedIdentity, identity)
 		})
 	}
 }
 
-func TestGetManagedIdentityByPath(t *testing.T) {
-	sampleManagedIdentity := &models.ManagedIdentity{
+func TestGetManagedIdentitiesForWorkspace(t *testing.T) {
+	sampleManagedIdentity := models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
-			ID: "some-managed-identity-id",
+			ID: "some-id",
 		},
 		Name:         "a-managed-identity",
 		ResourcePath: "some/resource/path",
 		GroupID:      "some-group-id",
-		Type:         models.ManagedIdentityAWSFederated,
 	}
 
 	type testCase struct {
-		authError             error
-		expectManagedIdentity *models.ManagedIdentity
-		name                  string
-		searchPath            string
-		expectErrorCode       errors.CodeType
+		name            string
+		workspaceID     string
+		expectErrorCode errors.CodeType
+		authError       error
+		expectResult    []models.ManagedIdentity
 	}
 
 	testCases := []testCase{
 		{
-			name:                  "positive: successfully returns a managed identity",
-			expectManagedIdentity: sampleManagedIdentity,
-			searchPath:            "some/resource/path",
+			name:        "positive: successfully returns managed identities for a workspace",
+			workspaceID: "some-workspace-id",
+			expectResult: []models.ManagedIdentity{
+				sampleManagedIdentity,
+			},
 		},
 		{
-			name:            "negative: path is invalid",
-			searchPath:      "/invalid/path/",
-			expectErrorCode: errors.EInvalid,
+			name:            "negative: subject does not have viewer access to workspace",
+			workspaceID:     "some-workspace-id",
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockCaller := auth.NewMockCaller(t)
+
+			if test.expectErrorCode == "" {
+				mockManagedIdentities.On("GetManagedIdentitiesForWorkspace", mock.Anything, test.workspaceID).Return(test.expectResult, nil)
+			}
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.ViewManagedIdentityPermission, mock.Anything).Return(test.authError)
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+			}
+
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
+
+			result, err := service.GetManagedIdentitiesForWorkspace(auth.WithCaller(ctx, mockCaller), test.workspaceID)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectResult, result)
+		})
+	}
+}
+
+func TestGetPaginatedManagedIdentitiesForWorkspace(t *testing.T) {
+	workspaceID := "some-workspace-id"
+
+	sampleResult := &db.ManagedIdentitiesResult{
+		ManagedIdentities: []models.ManagedIdentity{
+			{
+				Metadata:     models.ResourceMetadata{ID: "some-id"},
+				Name:         "a-managed-identity",
+				ResourcePath: "some/resource/path",
+				GroupID:      "some-group-id",
+			},
+		},
+	}
+
+	type testCase struct {
+		name            string
+		expectErrorCode errors.CodeType
+		authError       error
+		expectResult    *db.ManagedIdentitiesResult
+	}
+
+	testCases := []testCase{
+		{
+			name:         "positive: successfully returns a page of managed identities for a workspace",
+			expectResult: sampleResult,
 		},
 		{
-			name:                  "negative: subject does not have access to group resource",
-			searchPath:            "some/resource/path",
-			expectManagedIdentity: sampleManagedIdentity,
-			authError:             errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode:       errors.EForbidden,
+			name:            "negative: subject does not have viewer access to workspace",
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
 		},
 	}
 
@@ -821,17 +1045,29 @@ func TestGetManagedIdentityByPath(t *testing.T) {
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
 			mockCaller := auth.NewMockCaller(t)
 
-			mockManagedIdentities.On("GetManagedIdentityByPath", mock.Anything, test.searchPath).Return(test.expectManagedIdentity, nil).Maybe()
+			paginationOptions := &pagination.Options{First: ptr.Int32(1)}
 
-			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError).Maybe()
+			if test.expectErrorCode == "" {
+				mockManagedIdentities.On("GetManagedIdentities", mock.Anything, &db.GetManagedIdentitiesInput{
+					PaginationOptions: paginationOptions,
+					Filter: &db.ManagedIdentityFilter{
+						WorkspaceID: &workspaceID,
+					},
+				}).Return(test.expectResult, nil)
+			}
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.ViewManagedIdentityPermission, mock.Anything).Return(test.authError)
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
 			}
 
-			service := NewService(nil, dbClient, nil, nil, nil, nil, nil)
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
 
-			identity, err := service.GetManagedIdentityByPath(auth.WithCaller(ctx, mockCaller), test.searchPath)
+			result, err := service.GetPaginatedManagedIdentitiesForWorkspace(auth.WithCaller(ctx, mockCaller), &GetPaginatedManagedIdentitiesForWorkspaceInput{
+				PaginationOptions: paginationOptions,
+				WorkspaceID:       workspaceID,
+			})
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -842,250 +1078,77 @@ func TestGetManagedIdentityByPath(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			assert.Equal(t, test.expectManagedIdentity, identity)
+			assert.Equal(t, test.expectResult, result)
 		})
 	}
 }
 
-func TestCreateManagedIdentityAlias(t *testing.T) {
-	mockSubject := "mockSubject"
+func TestGetManagedIdentitiesForServiceAccount(t *testing.T) {
+	serviceAccountID := "some-service-account-id"
 
-	sampleManagedIdentity := &models.ManagedIdentity{
+	sampleServiceAccount := &models.ServiceAccount{
 		Metadata: models.ResourceMetadata{
-			ID: "some-managed-identity-id",
+			ID: serviceAccountID,
 		},
-		Name:         "a-managed-identity",
-		Description:  "this is a managed identity being created",
-		ResourcePath: "some/resource/path",
-		GroupID:      "some-group-id",
-		Data:         []byte("some-data"),
-		CreatedBy:    mockSubject,
-		Type:         models.ManagedIdentityAWSFederated,
+		GroupID: "some-group-id",
 	}
 
-	sampleGroup := &models.Group{
+	accessibleIdentity := models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
-			ID: "some-group-id",
+			ID: "accessible-id",
 		},
-		FullPath: "some/resource",
+		Name:         "accessible-identity",
+		ResourcePath: "some/resource/accessible-identity",
+		GroupID:      "some-group-id",
 	}
 
-	sampleAliasGroup := &models.Group{
+	inaccessibleIdentity := models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
-			ID: "some-other-group-id",
+			ID: "inaccessible-id",
 		},
-		FullPath: "some/sibling",
-	}
-
-	activityEventInput := &activityevent.CreateActivityEventInput{
-		NamespacePath: &sampleAliasGroup.FullPath,
-		Action:        models.ActionCreate,
-		TargetType:    models.TargetManagedIdentity,
-		TargetID:      "some-new-alias-id",
+		Name:         "inaccessible-identity",
+		ResourcePath: "other/resource/inaccessible-identity",
+		GroupID:      "other-group-id",
 	}
 
-	sampleAliasName := "some-managed-identity-alias"
+	forbiddenError := errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden))
 
 	type testCase struct {
-		authError               error
-		existingManagedIdentity *models.ManagedIdentity
-		existingGroup           *models.Group
-		expectCreatedAlias      *models.ManagedIdentity
-		createInput             *models.ManagedIdentity
-		input                   *CreateManagedIdentityAliasInput
-		name                    string
-		expectErrorCode         errors.CodeType
-		limit                   int
-		injectAliasesPerGroup   int32
-		injectAliasesPerMI      int32
-		exceedsLimit            bool
-		exceedsGroupLimit       bool
+		serviceAccount    *models.ServiceAccount
+		authError         error
+		name              string
+		expectErrorCode   errors.CodeType
+		identityAuthError map[string]error
+		identities        []models.ManagedIdentity
+		expectResult      []models.ManagedIdentity
 	}
 
 	testCases := []testCase{
 		{
-			name: "positive: successfully create a managed identity alias in a sibling group",
-			input: &CreateManagedIdentityAliasInput{
-				Group:         sampleAliasGroup,
-				AliasSourceID: sampleManagedIdentity.Metadata.ID,
-				Name:          sampleAliasName,
-			},
-			createInput: &models.ManagedIdentity{
-				GroupID:       sampleAliasGroup.Metadata.ID,
-				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
-				Name:          sampleAliasName,
-				CreatedBy:     mockSubject,
+			name:           "positive: successfully returns managed identities for a service account",
+			serviceAccount: sampleServiceAccount,
+			identities:     []models.ManagedIdentity{accessibleIdentity},
+			expectResult:   []models.ManagedIdentity{accessibleIdentity},
+		},
+		{
+			name:           "positive: managed identities the caller can't view are excluded",
+			serviceAccount: sampleServiceAccount,
+			identities:     []models.ManagedIdentity{accessibleIdentity, inaccessibleIdentity},
+			identityAuthError: map[string]error{
+				inaccessibleIdentity.GroupID: forbiddenError,
 			},
-			existingManagedIdentity: sampleManagedIdentity,
-			existingGroup:           sampleGroup,
-			expectCreatedAlias: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "some-new-alias-id",
-				},
-				Type:          sampleManagedIdentity.Type,
-				ResourcePath:  sampleAliasGroup.FullPath + "/some-managed-identity-alias",
-				Name:          sampleAliasName,
-				Description:   sampleManagedIdentity.Description,
-				GroupID:       sampleAliasGroup.Metadata.ID,
-				CreatedBy:     mockSubject,
-				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
-				Data:          sampleManagedIdentity.Data,
-			},
-			limit:                 5,
-			injectAliasesPerGroup: 5,
-			injectAliasesPerMI:    5,
+			expectResult: []models.ManagedIdentity{accessibleIdentity},
 		},
 		{
-			name: "negative: source managed identity doesn't exist",
-			input: &CreateManagedIdentityAliasInput{
-				Group:         sampleAliasGroup,
-				AliasSourceID: sampleManagedIdentity.Metadata.ID,
-				Name:          sampleAliasName,
-			},
+			name:            "negative: service account doesn't exist",
 			expectErrorCode: errors.ENotFound,
 		},
 		{
-			// Shouldn't happen.
-			name: "negative: group associated with source managed identity doesn't exist",
-			input: &CreateManagedIdentityAliasInput{
-				Group:         sampleAliasGroup,
-				AliasSourceID: sampleManagedIdentity.Metadata.ID,
-				Name:          sampleAliasName,
-			},
-			existingManagedIdentity: sampleManagedIdentity,
-			expectErrorCode:         errors.EInternal,
-		},
-		{
-			name: "negative: source managed identity is already available for namespace",
-			input: &CreateManagedIdentityAliasInput{
-				Group:         sampleGroup, // Using the same group here.
-				AliasSourceID: sampleManagedIdentity.Metadata.ID,
-				Name:          sampleAliasName,
-			},
-			existingManagedIdentity: sampleManagedIdentity,
-			existingGroup:           sampleGroup,
-			expectErrorCode:         errors.EInvalid,
-		},
-		{
-			name: "negative: attempting to alias another alias",
-			input: &CreateManagedIdentityAliasInput{
-				Group:         sampleAliasGroup,
-				AliasSourceID: "some-alias-id",
-				Name:          sampleAliasName,
-			},
-			existingManagedIdentity: &models.ManagedIdentity{
-				AliasSourceID: &sampleManagedIdentity.Metadata.ID, // Only populated for aliases.
-			},
-			expectErrorCode: errors.EInvalid,
-		},
-		{
-			name: "negative: invalid name",
-			input: &CreateManagedIdentityAliasInput{
-				Group:         sampleGroup, // Using the same group here.
-				AliasSourceID: sampleManagedIdentity.Metadata.ID,
-				Name:          "some/invalid/name",
-			},
-			createInput: &models.ManagedIdentity{
-				GroupID:       sampleAliasGroup.Metadata.ID,
-				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
-				Name:          "some/invalid/name",
-				CreatedBy:     mockSubject,
-			},
-			existingManagedIdentity: sampleManagedIdentity,
-			existingGroup:           sampleGroup,
-			expectErrorCode:         errors.EInvalid,
-		},
-		{
-			name: "negative: subject does not have owner role in target group",
-			input: &CreateManagedIdentityAliasInput{
-				Group:         sampleGroup, // Using the same group here.
-				AliasSourceID: sampleManagedIdentity.Metadata.ID,
-				Name:          sampleAliasName,
-			},
-			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			name:            "negative: subject does not have access to the service account",
+			serviceAccount:  sampleServiceAccount,
+			authError:       forbiddenError,
 			expectErrorCode: errors.EForbidden,
 		},
-		{
-			name: "negative: subject does not have owner role in source group",
-			input: &CreateManagedIdentityAliasInput{
-				Group:         sampleGroup, // Using the same group here.
-				AliasSourceID: sampleManagedIdentity.Metadata.ID,
-				Name:          sampleAliasName,
-			},
-			existingManagedIdentity: sampleManagedIdentity,
-			existingGroup:           sampleGroup,
-			authError:               errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode:         errors.EForbidden,
-		},
-		{
-			name: "exceeds limit for aliases in group",
-			input: &CreateManagedIdentityAliasInput{
-				Group:         sampleAliasGroup,
-				AliasSourceID: sampleManagedIdentity.Metadata.ID,
-				Name:          sampleAliasName,
-			},
-			createInput: &models.ManagedIdentity{
-				GroupID:       sampleAliasGroup.Metadata.ID,
-				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
-				Name:          sampleAliasName,
-				CreatedBy:     mockSubject,
-			},
-			existingManagedIdentity: sampleManagedIdentity,
-			existingGroup:           sampleGroup,
-			expectCreatedAlias: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "some-new-alias-id",
-				},
-				Type:          sampleManagedIdentity.Type,
-				ResourcePath:  sampleAliasGroup.FullPath + "/some-managed-identity-alias",
-				Name:          sampleAliasName,
-				Description:   sampleManagedIdentity.Description,
-				GroupID:       sampleAliasGroup.Metadata.ID,
-				CreatedBy:     mockSubject,
-				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
-				Data:          sampleManagedIdentity.Data,
-			},
-			expectErrorCode:       errors.EInvalid,
-			limit:                 5,
-			injectAliasesPerGroup: 6,
-			injectAliasesPerMI:    5,
-			exceedsLimit:          true,
-			exceedsGroupLimit:     true,
-		},
-		{
-			name: "exceeds limit for aliases per source MI",
-			input: &CreateManagedIdentityAliasInput{
-				Group:         sampleAliasGroup,
-				AliasSourceID: sampleManagedIdentity.Metadata.ID,
-				Name:          sampleAliasName,
-			},
-			createInput: &models.ManagedIdentity{
-				GroupID:       sampleAliasGroup.Metadata.ID,
-				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
-				Name:          sampleAliasName,
-				CreatedBy:     mockSubject,
-			},
-			existingManagedIdentity: sampleManagedIdentity,
-			existingGroup:           sampleGroup,
-			expectCreatedAlias: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "some-new-alias-id",
-				},
-				Type:          sampleManagedIdentity.Type,
-				ResourcePath:  sampleAliasGroup.FullPath + "/some-managed-identity-alias",
-				Name:          sampleAliasName,
-				Description:   sampleManagedIdentity.Description,
-				GroupID:       sampleAliasGroup.Metadata.ID,
-				CreatedBy:     mockSubject,
-				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
-				Data:          sampleManagedIdentity.Data,
-			},
-			expectErrorCode:       errors.EInvalid,
-			limit:                 5,
-			injectAliasesPerGroup: 5,
-			injectAliasesPerMI:    6,
-			exceedsLimit:          true,
-		},
 	}
 
 	for _, test := range testCases {
@@ -1094,92 +1157,32 @@ func TestCreateManagedIdentityAlias(t *testing.T) {
 			defer cancel()
 
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
-			mockGroups := db.NewMockGroups(t)
-			mockActivityEvents := activityevent.NewMockService(t)
-			mockTransactions := db.NewMockTransactions(t)
+			mockServiceAccounts := db.NewMockServiceAccounts(t)
 			mockCaller := auth.NewMockCaller(t)
-			mockResourceLimits := db.NewMockResourceLimits(t)
-
-			if test.authError == nil {
-				mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, mock.Anything).Return(test.existingManagedIdentity, nil)
-			}
-
-			mockGroups.On("GetGroupByID", mock.Anything, mock.Anything).Return(test.existingGroup, nil).Maybe()
-
-			if (test.expectErrorCode == "") || test.exceedsLimit {
-				mockCaller.On("GetSubject").Return("mockSubject")
-
-				mockManagedIdentities.On("CreateManagedIdentity", mock.Anything, test.createInput).Return(test.expectCreatedAlias, nil)
 
-				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
-				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
-				if !test.exceedsLimit {
-					mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
-					mockTransactions.On("CommitTx", mock.Anything).Return(nil)
-				}
-			}
+			mockServiceAccounts.On("GetServiceAccountByID", mock.Anything, serviceAccountID).Return(test.serviceAccount, nil)
 
-			if test.existingGroup != nil {
-				mockCaller.On("RequirePermission", mock.Anything, permissions.CreateManagedIdentityPermission, mock.Anything).Return(test.authError)
+			if test.serviceAccount != nil {
+				mockCaller.On("RequirePermission", mock.Anything, permissions.ViewServiceAccountPermission, mock.Anything).Return(test.authError)
 			}
 
-			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateManagedIdentityPermission, mock.Anything).Return(test.authError)
-
-			// Called inside transaction to check resource limits.
-			if test.limit > 0 {
-				mockManagedIdentities.On("GetManagedIdentities", mock.Anything, &db.GetManagedIdentitiesInput{
-					Filter: &db.ManagedIdentityFilter{
-						NamespacePaths: []string{"some/sibling"},
-					},
-					PaginationOptions: &pagination.Options{
-						First: ptr.Int32(0),
-					},
-				}).Return(func(ctx context.Context, input *db.GetManagedIdentitiesInput) *db.ManagedIdentitiesResult {
-					_ = ctx
-					_ = input
-
-					return &db.ManagedIdentitiesResult{
-						PageInfo: &pagination.PageInfo{
-							TotalCount: test.injectAliasesPerGroup,
-						},
-					}
-				}, nil)
+			if test.expectErrorCode == "" {
+				mockManagedIdentities.On("GetManagedIdentitiesForServiceAccount", mock.Anything, serviceAccountID).Return(test.identities, nil)
 
-				if !test.exceedsGroupLimit {
-					mockManagedIdentities.On("GetManagedIdentities", mock.Anything, &db.GetManagedIdentitiesInput{
-						Filter: &db.ManagedIdentityFilter{
-							AliasSourceID: &test.existingManagedIdentity.Metadata.ID,
-						},
-						PaginationOptions: &pagination.Options{
-							First: ptr.Int32(0),
-						},
-					}).Return(func(ctx context.Context, input *db.GetManagedIdentitiesInput) *db.ManagedIdentitiesResult {
-						_ = ctx
-						_ = input
-
-						return &db.ManagedIdentitiesResult{
-							PageInfo: &pagination.PageInfo{
-								TotalCount: test.injectAliasesPerMI,
-							},
-						}
-					}, nil)
+				for _, identity := range test.identities {
+					mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).
+						Return(test.identityAuthError[identity.GroupID]).Once()
 				}
-
-				mockResourceLimits.On("GetResourceLimit", mock.Anything, mock.Anything).
-					Return(&models.ResourceLimit{Value: test.limit}, nil)
 			}
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
-				Transactions:      mockTransactions,
-				Groups:            mockGroups,
-				ResourceLimits:    mockResourceLimits,
+				ServiceAccounts:   mockServiceAccounts,
 			}
 
-			logger, _ := logger.NewForTest()
-			service := NewService(logger, dbClient, limits.NewLimitChecker(dbClient), nil, nil, nil, mockActivityEvents)
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
 
-			alias, err := service.CreateManagedIdentityAlias(auth.WithCaller(ctx, mockCaller), test.input)
+			result, err := service.GetManagedIdentitiesForServiceAccount(auth.WithCaller(ctx, mockCaller), serviceAccountID)
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -1190,94 +1193,172 @@ func TestCreateManagedIdentityAlias(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			assert.Equal(t, test.expectCreatedAlias, alias)
+			assert.Equal(t, test.expectResult, result)
 		})
 	}
 }
 
-func TestDeleteManagedIdentityAlias(t *testing.T) {
-	sampleManagedIdentityAlias := &models.ManagedIdentity{
+func TestAddManagedIdentityToWorkspace(t *testing.T) {
+	awsManagedIdentity := &models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
-			ID: "some-alias-id",
+			ID: "some-managed-identity-id",
 		},
-		Name:          "a-managed-identity-alias-to-delete",
-		ResourcePath:  "some/resource/path",
-		GroupID:       "some-group-id",
-		AliasSourceID: ptr.String("some-source-managed-identity-id"),
+		Name:         "a-managed-identity",
+		ResourcePath: "some/resource/path",
+		GroupID:      "some-group-id",
+		Type:         models.ManagedIdentityAWSFederated,
 	}
 
-	sampleSourceIdentity := &models.ManagedIdentity{
-		Metadata: models.ResourceMetadata{
-			ID: "some-source-managed-identity-id",
-		},
-		GroupID: "some-group-id",
+	sampleWorkspace := &models.Workspace{
+		FullPath: "some/resource/path",
 	}
 
 	activityEventInput := &activityevent.CreateActivityEventInput{
-		NamespacePath: ptr.String("some/resource"),
-		Action:        models.ActionDeleteChildResource,
-		TargetType:    models.TargetGroup,
-		TargetID:      sampleManagedIdentityAlias.GroupID,
-		Payload: &models.ActivityEventDeleteChildResourcePayload{
-			Name: sampleManagedIdentityAlias.Name,
-			ID:   sampleManagedIdentityAlias.Metadata.ID,
-			Type: string(models.TargetManagedIdentity),
-		},
+		NamespacePath: &sampleWorkspace.FullPath,
+		Action:        models.ActionAdd,
+		TargetType:    models.TargetManagedIdentity,
+		TargetID:      awsManagedIdentity.Metadata.ID,
 	}
 
 	type testCase struct {
-		authError                 error
-		input                     *DeleteManagedIdentityInput
-		sourceIdentity            *models.ManagedIdentity
-		expectErrorCode           errors.CodeType
-		name                      string
-		managedIdentityWorkspaces []models.Workspace
-	}
-
+		authError                           error
+		existingManagedIdentity             *models.ManagedIdentity
+		existingWorkspace                   *models.Workspace
+		name                                string
+		managedIdentityID                   string
+		workspaceID                         string
+		expectErrorCode                     errors.CodeType
+		identitiesInWorkspace               []models.ManagedIdentity
+		limit                               int
+		injectManagedIdentitiesPerWorkspace int32
+		exceedsLimit                        bool
+		limitActivityEventsEnabled          bool
+	}
+
 	testCases := []testCase{
 		{
-			name: "positive: successfully delete a managed identity alias",
-			input: &DeleteManagedIdentityInput{
-				ManagedIdentity: sampleManagedIdentityAlias,
-			},
+			name:                                "positive: successfully add managed identity to workspace",
+			existingManagedIdentity:             awsManagedIdentity,
+			existingWorkspace:                   sampleWorkspace,
+			identitiesInWorkspace:               []models.ManagedIdentity{},
+			managedIdentityID:                   "some-managed-identity-id",
+			workspaceID:                         "some-workspace-id",
+			limit:                               5,
+			injectManagedIdentitiesPerWorkspace: 5,
 		},
 		{
-			name: "positive: successfully delete a managed identity alias with force option",
-			input: &DeleteManagedIdentityInput{
-				ManagedIdentity: sampleManagedIdentityAlias,
-				Force:           true,
+			name:              "negative: managed identity being added doesn't exist",
+			managedIdentityID: "some-managed-identity-id",
+			workspaceID:       "some-workspace-id",
+			expectErrorCode:   errors.ENotFound,
+		},
+		{
+			name:                    "negative: managed identity is not under the same group hierarchy",
+			existingManagedIdentity: awsManagedIdentity,
+			existingWorkspace: &models.Workspace{
+				FullPath: "another/path",
 			},
+			managedIdentityID: "some-managed-identity-id",
+			workspaceID:       "some-workspace-id",
+			expectErrorCode:   errors.EInvalid,
 		},
 		{
-			name: "negative: no force option and managed identity alias is assigned to a workspace",
-			input: &DeleteManagedIdentityInput{
-				ManagedIdentity: sampleManagedIdentityAlias,
+			name:                    "positive: managed identity type is allowed by the workspace",
+			existingManagedIdentity: awsManagedIdentity,
+			existingWorkspace: &models.Workspace{
+				FullPath: sampleWorkspace.FullPath,
+				AllowedManagedIdentityTypes: []models.ManagedIdentityType{
+					models.ManagedIdentityAzureFederated,
+					models.ManagedIdentityAWSFederated,
+				},
 			},
-			managedIdentityWorkspaces: []models.Workspace{{}},
-			expectErrorCode:           errors.EConflict,
+			identitiesInWorkspace:               []models.ManagedIdentity{},
+			managedIdentityID:                   "some-managed-identity-id",
+			workspaceID:                         "some-workspace-id",
+			limit:                               5,
+			injectManagedIdentitiesPerWorkspace: 5,
 		},
 		{
-			name: "negative: attempting to delete a source managed identity",
-			input: &DeleteManagedIdentityInput{
-				ManagedIdentity: sampleSourceIdentity,
+			name:                    "negative: managed identity type is not allowed by the workspace",
+			existingManagedIdentity: awsManagedIdentity,
+			existingWorkspace: &models.Workspace{
+				FullPath:                    sampleWorkspace.FullPath,
+				AllowedManagedIdentityTypes: []models.ManagedIdentityType{models.ManagedIdentityAzureFederated},
 			},
-			expectErrorCode: errors.EInvalid,
+			managedIdentityID: "some-managed-identity-id",
+			workspaceID:       "some-workspace-id",
+			expectErrorCode:   errors.EInvalid,
 		},
 		{
-			name: "negative: subject does not have owner role in alias' group",
-			input: &DeleteManagedIdentityInput{
-				ManagedIdentity: sampleManagedIdentityAlias,
+			name:                    "can assign more than one aws managed identity",
+			existingManagedIdentity: awsManagedIdentity,
+			existingWorkspace:       sampleWorkspace,
+			identitiesInWorkspace: []models.ManagedIdentity{
+				*awsManagedIdentity,
 			},
-			sourceIdentity: sampleSourceIdentity,
+			managedIdentityID:                   "some-managed-identity-id",
+			workspaceID:                         "some-workspace-id",
+			limit:                               5,
+			injectManagedIdentitiesPerWorkspace: 5,
 		},
 		{
-			name: "negative: subject does not have owner role in source group",
-			input: &DeleteManagedIdentityInput{
-				ManagedIdentity: sampleManagedIdentityAlias,
+			name: "cannot assign more than one non-aws managed identity",
+			existingManagedIdentity: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID: "some-managed-identity-id",
+				},
+				Name:         "a-managed-identity",
+				ResourcePath: "some/resource/path",
+				GroupID:      "some-group-id",
+				Type:         models.ManagedIdentityAzureFederated,
 			},
-			sourceIdentity:  sampleSourceIdentity,
-			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode: errors.EForbidden,
+			existingWorkspace: sampleWorkspace,
+			identitiesInWorkspace: []models.ManagedIdentity{
+				{
+					Metadata: models.ResourceMetadata{
+						ID: "some-managed-identity-id-1",
+					},
+					Name:         "a-managed-identity",
+					ResourcePath: "some/resource/path",
+					GroupID:      "some-group-id",
+					Type:         models.ManagedIdentityAzureFederated,
+				},
+			},
+			managedIdentityID: "some-managed-identity-id",
+			workspaceID:       "some-workspace-id",
+			expectErrorCode:   errors.EInvalid,
+		},
+		{
+			name:              "negative: subject does not have permissions for workspace",
+			managedIdentityID: "some-managed-identity-id",
+			workspaceID:       "some-workspace-id",
+			authError:         errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:   errors.EForbidden,
+		},
+		{
+			name:                                "exceeds limit",
+			existingManagedIdentity:             awsManagedIdentity,
+			existingWorkspace:                   sampleWorkspace,
+			identitiesInWorkspace:               []models.ManagedIdentity{},
+			managedIdentityID:                   "some-managed-identity-id",
+			workspaceID:                         "some-workspace-id",
+			limit:                               5,
+			injectManagedIdentitiesPerWorkspace: 6,
+			exceedsLimit:                        true,
+			expectErrorCode:                     errors.EInvalid,
+		},
+		{
+			name:                                "exceeds limit and creates an activity event when enabled",
+			existingManagedIdentity:             awsManagedIdentity,
+			existingWorkspace:                   sampleWorkspace,
+			identitiesInWorkspace:               []models.ManagedIdentity{},
+			managedIdentityID:                   "some-managed-identity-id",
+			workspaceID:                         "some-workspace-id",
+			limit:                               5,
+			injectManagedIdentitiesPerWorkspace: 6,
+			exceedsLimit:                        true,
+			limitActivityEventsEnabled:          true,
+			expectErrorCode:                     errors.EInvalid,
 		},
 	}
 
@@ -1287,45 +1368,98 @@ func TestDeleteManagedIdentityAlias(t *testing.T) {
 			defer cancel()
 
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
-			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockWorkspaces := workspace.NewMockService(t)
 			mockActivityEvents := activityevent.NewMockService(t)
 			mockTransactions := db.NewMockTransactions(t)
 			mockCaller := auth.NewMockCaller(t)
+			mockResourceLimits := db.NewMockResourceLimits(t)
 
-			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, sampleSourceIdentity.Metadata.ID).Return(test.sourceIdentity, nil).Maybe()
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateWorkspacePermission, mock.Anything).Return(test.authError)
 
-			mockCaller.On("GetSubject").Return("mockSubject").Maybe()
+			if test.identitiesInWorkspace != nil {
+				mockCaller.On("RequirePermission", mock.Anything, permissions.ViewManagedIdentityPermission, mock.Anything).Return(test.authError)
 
-			if test.expectErrorCode == "" {
-				mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
+				// This mock On is hit by both the initial check before doing the assignment and for the later check afterward.
+				// To get past the initial check but still allow a non-trivial limit value,
+				// the ones it returns are all of a type different from the one being assigned in this test.
+				// Also, please note that this is not a paginated return, so ...PageInfo.TotalCount is not an option.
+				mockManagedIdentities.On("GetManagedIdentitiesForWorkspace", mock.Anything, test.workspaceID).
+					Return(
 
-				mockManagedIdentities.On("DeleteManagedIdentity", mock.Anything, test.input.ManagedIdentity).Return(nil)
+						func(ctx context.Context, workspaceID string) []models.ManagedIdentity {
+							_ = ctx
+							_ = workspaceID
+
+							// For the 'already assigned' test case, use the slice supplied by the test case.
+							if len(test.identitiesInWorkspace) > 0 {
+								return test.identitiesInWorkspace
+							}
+
+							result := []models.ManagedIdentity{}
+							for len(result) < int(test.injectManagedIdentitiesPerWorkspace) {
+								result = append(result, models.ManagedIdentity{Type: models.ManagedIdentityAzureFederated})
+							}
+
+							return result
+						}, nil)
+			}
+
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.managedIdentityID).Return(test.existingManagedIdentity, nil).Maybe()
+
+			mockWorkspaces.On("GetWorkspaceByID", mock.Anything, test.workspaceID).Return(test.existingWorkspace, nil).Maybe()
+
+			if (test.expectErrorCode == "") || test.exceedsLimit {
+				mockManagedIdentities.On("AddManagedIdentityToWorkspace", mock.Anything, test.managedIdentityID, test.workspaceID).Return(nil)
 
 				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
 				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
-				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+				if !test.exceedsLimit {
+					mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
+					mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+					mockCaller.On("GetSubject").Return("mockSubject")
+				}
 			}
 
-			if !test.input.Force {
-				mockWorkspaces.On("GetWorkspacesForManagedIdentity", mock.Anything, sampleManagedIdentityAlias.Metadata.ID).Return(test.managedIdentityWorkspaces, nil).Maybe()
+			if test.exceedsLimit && test.limitActivityEventsEnabled {
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, &activityevent.CreateActivityEventInput{
+					NamespacePath: &sampleWorkspace.FullPath,
+					Action:        models.ActionLimitExceeded,
+					TargetType:    models.TargetManagedIdentity,
+					TargetID:      awsManagedIdentity.Metadata.ID,
+					Payload: &models.ActivityEventLimitExceededPayload{
+						LimitName: string(limits.ResourceLimitAssignedManagedIdentitiesPerWorkspace),
+						Value:     test.injectManagedIdentitiesPerWorkspace,
+					},
+				}).Return(&models.ActivityEvent{}, nil)
 			}
 
-			if test.sourceIdentity != nil {
-				mockCaller.On("RequirePermission", mock.Anything, permissions.DeleteManagedIdentityPermission, mock.Anything).Return(test.authError).Maybe()
-			}
+			// Called inside transaction to check resource limits.
+			if test.limit > 0 {
+				// The mock On of GetManagedIdentitiesForWorkspace is done above.
 
-			mockCaller.On("RequirePermission", mock.Anything, permissions.DeleteManagedIdentityPermission, mock.Anything).Return(test.authError).Maybe()
+				mockResourceLimits.On("GetResourceLimit", mock.Anything, mock.Anything).
+					Return(&models.ResourceLimit{Value: test.limit}, nil)
+			}
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
-				Workspaces:        mockWorkspaces,
 				Transactions:      mockTransactions,
+				ResourceLimits:    mockResourceLimits,
 			}
 
 			logger, _ := logger.NewForTest()
-			service := NewService(logger, dbClient, nil, nil, nil, nil, mockActivityEvents)
+			service := NewService(logger, dbClient, limits.NewLimitChecker(dbClient), nil, mockWorkspaces, nil, mockActivityEvents, ServiceOptions{LimitActivityEventsEnabled: test.limitActivityEventsEnabled})
 
-			err := service.DeleteManagedIdentityAlias(auth.WithCaller(ctx, mockCaller), test.input)
+			err := service.AddManagedIdentityToWorkspace(auth.WithCaller(ctx, mockCaller), test.managedIdentityID, test.workspaceID)
+
+			if test.exceedsLimit {
+				limitErr, ok := limits.AsLimitExceededError(err)
+				if assert.True(t, ok) {
+					assert.Equal(t, limits.ResourceLimitAssignedManagedIdentitiesPerWorkspace, limitErr.LimitName)
+					assert.Equal(t, test.injectManagedIdentitiesPerWorkspace, limitErr.Value)
+					assert.Equal(t, test.limit, limitErr.Limit)
+				}
+			}
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -1339,238 +1473,56 @@ func TestDeleteManagedIdentityAlias(t *testing.T) {
 	}
 }
 
-func TestCreateManagedIdentity(t *testing.T) {
-	mockSubject := "mockSubject"
-
+func TestRemoveManagedIdentityFromWorkspace(t *testing.T) {
 	sampleManagedIdentity := &models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
 			ID: "some-managed-identity-id",
 		},
 		Name:         "a-managed-identity",
-		Description:  "this is a managed identity being created",
 		ResourcePath: "some/resource/path",
 		GroupID:      "some-group-id",
-		Data:         []byte("some-data"),
-		CreatedBy:    mockSubject,
 		Type:         models.ManagedIdentityAWSFederated,
 	}
 
-	sampleServiceAccount := &models.ServiceAccount{
-		ResourcePath: "some/resource/service-account",
+	sampleWorkspace := &models.Workspace{
+		FullPath: "some/resource/path",
 	}
 
 	activityEventInput := &activityevent.CreateActivityEventInput{
-		NamespacePath: ptr.String(sampleManagedIdentity.GetGroupPath()),
-		Action:        models.ActionCreate,
+		NamespacePath: &sampleWorkspace.FullPath,
+		Action:        models.ActionRemove,
 		TargetType:    models.TargetManagedIdentity,
 		TargetID:      sampleManagedIdentity.Metadata.ID,
 	}
 
-	createIdentityInput := &models.ManagedIdentity{
-		Name:        "a-managed-identity",
-		Description: "this is a managed identity being created",
-		GroupID:     "some-group-id",
-		CreatedBy:   mockSubject,
-		Type:        models.ManagedIdentityAWSFederated,
-		Data:        []byte{},
-	}
-
-	createAccessRuleInput := &models.ManagedIdentityAccessRule{
-		ManagedIdentityID:        sampleManagedIdentity.Metadata.ID,
-		Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
-		RunStage:                 models.JobPlanType,
-		AllowedUserIDs:           []string{"user-1-id", "user-2-id"},
-		AllowedServiceAccountIDs: []string{"service-account-1-id"},
-		AllowedTeamIDs:           []string{"team-1-id"},
-	}
-
 	type testCase struct {
-		authError                   error
-		input                       *CreateManagedIdentityInput
-		existingServiceAccount      *models.ServiceAccount
-		name                        string
-		expectErrorCode             errors.CodeType
-		expectError                 string
-		limit                       int
-		injectMIPerGroup            int32
-		exceedsLimit                bool
-		setManagedIdentityDataError error
+		authError               error
+		existingManagedIdentity *models.ManagedIdentity
+		name                    string
+		managedIdentityID       string
+		workspaceID             string
+		expectErrorCode         errors.CodeType
 	}
 
 	testCases := []testCase{
 		{
-			name: "positive: successfully create a managed identity",
-			input: &CreateManagedIdentityInput{
-				Type:        models.ManagedIdentityAWSFederated,
-				Name:        "a-managed-identity",
-				Description: "this is a managed identity being created",
-				GroupID:     "some-group-id",
-				Data:        []byte("some-data"),
-				AccessRules: []struct {
-					Type                      models.ManagedIdentityAccessRuleType
-					RunStage                  models.JobType
-					ModuleAttestationPolicies []models.ManagedIdentityAccessRuleModuleAttestationPolicy
-					AllowedUserIDs            []string
-					AllowedServiceAccountIDs  []string
-					AllowedTeamIDs            []string
-					VerifyStateLineage        bool
-				}{
-					{
-						Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
-						RunStage:                 models.JobPlanType,
-						AllowedUserIDs:           []string{"user-1-id", "user-2-id"},
-						AllowedServiceAccountIDs: []string{"service-account-1-id"},
-						AllowedTeamIDs:           []string{"team-1-id"},
-					},
-				},
-			},
-			existingServiceAccount: sampleServiceAccount,
-			limit:                  5,
-			injectMIPerGroup:       5,
-		},
-		{
-			name: "negative: service account in access policy does not exist",
-			input: &CreateManagedIdentityInput{
-				Type:        models.ManagedIdentityAWSFederated,
-				Name:        "a-managed-identity",
-				Description: "this is a managed identity being created",
-				GroupID:     "some-group-id",
-				Data:        []byte("some-data"),
-				AccessRules: []struct {
-					Type                      models.ManagedIdentityAccessRuleType
-					RunStage                  models.JobType
-					ModuleAttestationPolicies []models.ManagedIdentityAccessRuleModuleAttestationPolicy
-					AllowedUserIDs            []string
-					AllowedServiceAccountIDs  []string
-					AllowedTeamIDs            []string
-					VerifyStateLineage        bool
-				}{
-					{
-						Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
-						RunStage:                 models.JobPlanType,
-						AllowedUserIDs:           []string{"user-1-id", "user-2-id"},
-						AllowedServiceAccountIDs: []string{"non-existent-service-account"},
-						AllowedTeamIDs:           []string{"team-1-id"},
-					},
-				},
-			},
-			expectErrorCode:  errors.ENotFound,
-			expectError:      "service account with ID non-existent-service-account not found",
-			limit:            5, // enables mock On calls
-			injectMIPerGroup: 5,
-		},
-		{
-			name: "negative: service account in access policy is outside group scope",
-			input: &CreateManagedIdentityInput{
-				Type:        models.ManagedIdentityAWSFederated,
-				Name:        "a-managed-identity",
-				Description: "this is a managed identity being created",
-				GroupID:     "some-group-id",
-				Data:        []byte("some-data"),
-				AccessRules: []struct {
-					Type                      models.ManagedIdentityAccessRuleType
-					RunStage                  models.JobType
-					ModuleAttestationPolicies []models.ManagedIdentityAccessRuleModuleAttestationPolicy
-					AllowedUserIDs            []string
-					AllowedServiceAccountIDs  []string
-					AllowedTeamIDs            []string
-					VerifyStateLineage        bool
-				}{
-					{
-						Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
-						RunStage:                 models.JobPlanType,
-						AllowedUserIDs:           []string{"user-1-id", "user-2-id"},
-						AllowedServiceAccountIDs: []string{"outside-scope-1"},
-						AllowedTeamIDs:           []string{"team-1-id"},
-					},
-				},
-			},
-			existingServiceAccount: &models.ServiceAccount{
-				ResourcePath: "outside/scope/service-account",
-			},
-			expectErrorCode:  errors.EInvalid,
-			expectError:      "service account outside/scope/service-account is outside the scope of group some/resource",
-			limit:            5, // enables mock On calls
-			injectMIPerGroup: 5,
-		},
-		{
-			name: "negative: unsupported managed identity type",
-			input: &CreateManagedIdentityInput{
-				Type:    "unknown-type",
-				GroupID: "some-group-id",
-			},
-			expectErrorCode: errors.EInvalid,
-			expectError:     "managed identity with type unknown-type is not supported",
-		},
-		{
-			name: "negative: managed identity has an invalid name",
-			input: &CreateManagedIdentityInput{
-				Type:    models.ManagedIdentityAWSFederated,
-				Name:    "-invalid-name-",
-				GroupID: "some-group-id",
-			},
-			expectErrorCode: errors.EInvalid,
-			expectError:     "Invalid name, name can only include lowercase letters and numbers with - and _ supported in non leading or trailing positions. Max length is 64 characters.",
-		},
-		{
-			name: "negative: managed identity has an invalid host",
-			input: &CreateManagedIdentityInput{
-				Type:        models.ManagedIdentityAWSFederated,
-				Name:        "a-managed-identity",
-				Description: "this is a managed identity being created",
-				GroupID:     "some-group-id",
-				Data:        []byte("some-data"),
-			},
-			existingServiceAccount: sampleServiceAccount,
-			//limit:                       5,
-			injectMIPerGroup:            5,
-			setManagedIdentityDataError: errors.New("host invalid", errors.WithErrorCode(errors.EInvalid)),
-			expectErrorCode:             errors.EInvalid,
-			expectError:                 "failed to set managed identity data: host invalid",
+			name:                    "positive: successfully remove managed identity from workspace",
+			existingManagedIdentity: sampleManagedIdentity,
+			managedIdentityID:       "some-managed-identity-id",
+			workspaceID:             "some-workspace-id",
 		},
 		{
-			name: "negative: subject does not have perms for group",
-			input: &CreateManagedIdentityInput{
-				Type:    models.ManagedIdentityAWSFederated,
-				Name:    "a-managed-identity",
-				GroupID: "some-group-id",
-			},
-			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode: errors.EForbidden,
-			expectError:     "Forbidden",
+			name:              "negative: managed identity being removed doesn't exist",
+			managedIdentityID: "some-managed-identity-id",
+			workspaceID:       "some-workspace-id",
+			expectErrorCode:   errors.ENotFound,
 		},
 		{
-			name: "exceeds limit",
-			input: &CreateManagedIdentityInput{
-				Type:        models.ManagedIdentityAWSFederated,
-				Name:        "a-managed-identity",
-				Description: "this is a managed identity being created",
-				GroupID:     "some-group-id",
-				Data:        []byte("some-data"),
-				AccessRules: []struct {
-					Type                      models.ManagedIdentityAccessRuleType
-					RunStage                  models.JobType
-					ModuleAttestationPolicies []models.ManagedIdentityAccessRuleModuleAttestationPolicy
-					AllowedUserIDs            []string
-					AllowedServiceAccountIDs  []string
-					AllowedTeamIDs            []string
-					VerifyStateLineage        bool
-				}{
-					{
-						Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
-						RunStage:                 models.JobPlanType,
-						AllowedUserIDs:           []string{"user-1-id", "user-2-id"},
-						AllowedServiceAccountIDs: []string{"service-account-1-id"},
-						AllowedTeamIDs:           []string{"team-1-id"},
-					},
-				},
-			},
-			existingServiceAccount: sampleServiceAccount,
-			limit:                  5,
-			injectMIPerGroup:       6,
-			exceedsLimit:           true,
-			expectErrorCode:        errors.EInvalid,
-			expectError:            "for limit ResourceLimitManagedIdentitiesPerGroup: value 6 exceeds limit of 5",
+			name:              "negative: subject does not have permissions for workspace",
+			managedIdentityID: "some-managed-identity-id",
+			workspaceID:       "some-workspace-id",
+			authError:         errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:   errors.EForbidden,
 		},
 	}
 
@@ -1580,136 +1532,104 @@ func TestCreateManagedIdentity(t *testing.T) {
 			defer cancel()
 
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
-			mockServiceAccounts := db.NewMockServiceAccounts(t)
+			mockWorkspaces := workspace.NewMockService(t)
 			mockActivityEvents := activityevent.NewMockService(t)
 			mockTransactions := db.NewMockTransactions(t)
-			mockDelegate := NewMockDelegate(t)
 			mockCaller := auth.NewMockCaller(t)
-			mockResourceLimits := db.NewMockResourceLimits(t)
-
-			mockManagedIdentities.On("CreateManagedIdentity", mock.Anything, createIdentityInput).Return(sampleManagedIdentity, nil).Maybe()
-			mockManagedIdentities.On("UpdateManagedIdentity", mock.Anything, sampleManagedIdentity).Return(sampleManagedIdentity, nil).Maybe()
-			mockManagedIdentities.On("CreateManagedIdentityAccessRule", mock.Anything, createAccessRuleInput).Return(&models.ManagedIdentityAccessRule{}, nil).Maybe()
-
-			mockServiceAccounts.On("GetServiceAccountByID", mock.Anything, mock.Anything).Return(test.existingServiceAccount, nil).Maybe()
-
-			mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil).Maybe()
 
-			mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil).Maybe()
-			mockTransactions.On("RollbackTx", mock.Anything).Return(nil).Maybe()
-			mockTransactions.On("CommitTx", mock.Anything).Return(nil).Maybe()
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateWorkspacePermission, mock.Anything).Return(test.authError)
 
-			mockDelegate.On("SetManagedIdentityData", mock.Anything, sampleManagedIdentity, sampleManagedIdentity.Data).Return(test.setManagedIdentityDataError).Maybe()
+			if test.authError == nil {
+				mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.managedIdentityID).Return(test.existingManagedIdentity, nil)
+			}
 
-			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateManagedIdentityPermission, mock.Anything).Return(test.authError)
+			if test.expectErrorCode == "" {
+				mockManagedIdentities.On("RemoveManagedIdentityFromWorkspace", mock.Anything, test.managedIdentityID, test.workspaceID).Return(nil)
 
-			mockCaller.On("GetSubject").Return("mockSubject").Maybe()
+				mockWorkspaces.On("GetWorkspaceByID", mock.Anything, test.workspaceID).Return(sampleWorkspace, nil)
 
-			// Called inside transaction to check resource limits.
-			if test.limit > 0 {
-				mockManagedIdentities.On("GetManagedIdentities", mock.Anything, &db.GetManagedIdentitiesInput{
-					Filter: &db.ManagedIdentityFilter{
-						NamespacePaths: []string{"some/resource"},
-					},
-					PaginationOptions: &pagination.Options{
-						First: ptr.Int32(0),
-					},
-				}).Return(func(ctx context.Context, input *db.GetManagedIdentitiesInput) *db.ManagedIdentitiesResult {
-					_ = ctx
-					_ = input
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
 
-					return &db.ManagedIdentitiesResult{
-						PageInfo: &pagination.PageInfo{
-							TotalCount: test.injectMIPerGroup,
-						},
-					}
-				}, nil)
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
 
-				mockResourceLimits.On("GetResourceLimit", mock.Anything, mock.Anything).
-					Return(&models.ResourceLimit{Value: test.limit}, nil)
+				mockCaller.On("GetSubject").Return("mockSubject")
 			}
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
-				ServiceAccounts:   mockServiceAccounts,
 				Transactions:      mockTransactions,
-				ResourceLimits:    mockResourceLimits,
-			}
-
-			delegateMap := map[models.ManagedIdentityType]Delegate{
-				models.ManagedIdentityAWSFederated:     mockDelegate,
-				models.ManagedIdentityAzureFederated:   mockDelegate,
-				models.ManagedIdentityTharsisFederated: mockDelegate,
 			}
 
 			logger, _ := logger.NewForTest()
-			service := NewService(logger, dbClient, limits.NewLimitChecker(dbClient), delegateMap, nil, nil, mockActivityEvents)
+			service := NewService(logger, dbClient, nil, nil, mockWorkspaces, nil, mockActivityEvents, ServiceOptions{})
 
-			identity, err := service.CreateManagedIdentity(auth.WithCaller(ctx, mockCaller), test.input)
+			err := service.RemoveManagedIdentityFromWorkspace(auth.WithCaller(ctx, mockCaller), test.managedIdentityID, test.workspaceID)
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
-				assert.Equal(t, test.expectError, errors.ErrorMessage(err))
 				return
 			}
 
 			if err != nil {
 				t.Fatal(err)
 			}
-
-			assert.Equal(t, sampleManagedIdentity, identity)
 		})
 	}
 }
 
-func TestGetManagedIdentitiesByIDs(t *testing.T) {
-	sampleManagedIdentity := models.ManagedIdentity{
+func TestGetManagedIdentityByID(t *testing.T) {
+	sampleManagedIdentity := &models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
 			ID: "some-managed-identity-id",
 		},
+		Name:         "a-managed-identity",
+		ResourcePath: "some/resource/path",
 		GroupID:      "some-group-id",
-		ResourcePath: "some-group/some-identity",
-	}
-
-	inputList := []string{
-		"some-managed-identity-id",
+		Type:         models.ManagedIdentityAWSFederated,
 	}
 
 	type testCase struct {
-		authError       error
-		dbInput         *db.GetManagedIdentitiesInput
-		expectResult    *db.ManagedIdentitiesResult
-		name            string
-		expectErrorCode errors.CodeType
-		inputIDList     []string
+		authError             error
+		expectManagedIdentity *models.ManagedIdentity
+		name                  string
+		searchID              string
+		expectErrorCode       errors.CodeType
+		notFoundWhenForbidden bool
 	}
 
 	testCases := []testCase{
 		{
-			name:        "positive: successfully return a list of managed identities",
-			inputIDList: inputList,
-			dbInput: &db.GetManagedIdentitiesInput{
-				Filter: &db.ManagedIdentityFilter{
-					ManagedIdentityIDs: inputList,
-				},
-			},
-			expectResult: &db.ManagedIdentitiesResult{
-				ManagedIdentities: []models.ManagedIdentity{sampleManagedIdentity},
-			},
+			name:                  "positive: successfully return a managed identity",
+			expectManagedIdentity: sampleManagedIdentity,
+			searchID:              "some-managed-identity-id",
 		},
 		{
-			name:        "negative: subject does not have access to group resource",
-			inputIDList: inputList,
-			dbInput: &db.GetManagedIdentitiesInput{
-				Filter: &db.ManagedIdentityFilter{
-					ManagedIdentityIDs: inputList,
-				},
-			},
-			expectResult: &db.ManagedIdentitiesResult{
-				ManagedIdentities: []models.ManagedIdentity{sampleManagedIdentity},
-			},
-			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode: errors.EForbidden,
+			name:            "negative: managed identity doesn't exist",
+			searchID:        "some-managed-identity-id",
+			expectErrorCode: errors.ENotFound,
+		},
+		{
+			name:                  "negative: subject does not have access to resource",
+			searchID:              "some-managed-identity-id",
+			expectManagedIdentity: sampleManagedIdentity,
+			authError:             errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:       errors.EForbidden,
+		},
+		{
+			name:                  "negative: subject does not have access to resource and not found is returned instead",
+			searchID:              "some-managed-identity-id",
+			expectManagedIdentity: sampleManagedIdentity,
+			authError:             errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			notFoundWhenForbidden: true,
+			expectErrorCode:       errors.ENotFound,
+		},
+		{
+			name:                  "positive: not found when forbidden option still returns an accessible managed identity",
+			expectManagedIdentity: sampleManagedIdentity,
+			searchID:              "some-managed-identity-id",
+			notFoundWhenForbidden: true,
 		},
 	}
 
@@ -1721,17 +1641,22 @@ func TestGetManagedIdentitiesByIDs(t *testing.T) {
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
 			mockCaller := auth.NewMockCaller(t)
 
-			mockManagedIdentities.On("GetManagedIdentities", mock.Anything, test.dbInput).Return(test.expectResult, nil)
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.searchID).Return(test.expectManagedIdentity, nil)
 
-			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError)
+			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError).Maybe()
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
 			}
 
-			service := NewService(nil, dbClient, nil, nil, nil, nil, nil)
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
 
-			result, err := service.GetManagedIdentitiesByIDs(auth.WithCaller(ctx, mockCaller), test.inputIDList)
+			opts := []GetManagedIdentityByIDOption{}
+			if test.notFoundWhenForbidden {
+				opts = append(opts, WithNotFoundWhenForbidden())
+			}
+
+			identity, err := service.GetManagedIdentityByID(auth.WithCaller(ctx, mockCaller), test.searchID, opts...)
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -1742,120 +1667,62 @@ func TestGetManagedIdentitiesByIDs(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			assert.Equal(t, test.expectResult.ManagedIdentities, result)
+			assert.Equal(t, test.expectManagedIdentity, identity)
 		})
 	}
 }
 
-func TestUpdateManagedIdentity(t *testing.T) {
+func TestGetManagedIdentityByIDWithRules(t *testing.T) {
+	managedIdentityID := "some-managed-identity-id"
+
 	sampleManagedIdentity := &models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
-			ID: "some-managed-identity-id",
+			ID: managedIdentityID,
 		},
 		Name:         "a-managed-identity",
 		ResourcePath: "some/resource/path",
-		Description:  "old-description",
 		GroupID:      "some-group-id",
-		Data:         []byte("this is old data"),
 		Type:         models.ManagedIdentityAWSFederated,
 	}
 
-	activityEventInput := &activityevent.CreateActivityEventInput{
-		NamespacePath: ptr.String(sampleManagedIdentity.GetGroupPath()),
-		Action:        models.ActionUpdate,
-		TargetType:    models.TargetManagedIdentity,
-		TargetID:      sampleManagedIdentity.Metadata.ID,
+	sampleRules := []models.ManagedIdentityAccessRule{
+		{
+			Metadata:          models.ResourceMetadata{ID: "rule-1"},
+			ManagedIdentityID: managedIdentityID,
+			Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+			RunStage:          models.JobPlanType,
+		},
+		{
+			Metadata:          models.ResourceMetadata{ID: "rule-2"},
+			ManagedIdentityID: managedIdentityID,
+			Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+			RunStage:          models.JobApplyType,
+		},
 	}
 
 	type testCase struct {
-		authError                   error
-		setManagedIdentityDataError error
-		existingManagedIdentity     *models.ManagedIdentity
-		expectManagedIdentity       *models.ManagedIdentity
-		input                       *UpdateManagedIdentityInput
-		name                        string
-		expectErrorCode             errors.CodeType
-		expectError                 string
+		authError             error
+		existingIdentity      *models.ManagedIdentity
+		name                  string
+		expectErrorCode       errors.CodeType
+		expectAccessRuleCount int
 	}
 
 	testCases := []testCase{
 		{
-			name: "positive: successfully update a managed identity",
-			input: &UpdateManagedIdentityInput{
-				ID:          "some-managed-identity-id",
-				Description: "This is an updated description",
-				Data:        []byte("this is new data"),
-			},
-			existingManagedIdentity: sampleManagedIdentity,
-			expectManagedIdentity: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "some-managed-identity-id",
-				},
-				Name:         "a-managed-identity",
-				ResourcePath: "some/resource/path",
-				Description:  "This is an updated description",
-				GroupID:      "some-group-id",
-				Data:         []byte("this is new data"),
-				Type:         models.ManagedIdentityAWSFederated,
-			},
-		},
-		{
-			name: "negative: set managed identity data fails validation",
-			input: &UpdateManagedIdentityInput{
-				ID:          "some-managed-identity-id",
-				Description: "This is an updated description",
-				Data:        []byte("this is new data"),
-			},
-			setManagedIdentityDataError: errors.New("host invalid", errors.WithErrorCode(errors.EInvalid)),
-			expectErrorCode:             errors.EInvalid,
-			expectError:                 "failed to set managed identity data: host invalid",
-			existingManagedIdentity:     sampleManagedIdentity,
-		},
-		{
-			name: "negative: updated description is too long",
-			input: &UpdateManagedIdentityInput{
-				ID:          "some-managed-identity-id",
-				Description: strings.Repeat("really long description", 20),
-				Data:        []byte("this is new data"),
-			},
-			expectErrorCode:         errors.EInvalid,
-			expectError:             "invalid description, cannot be greater than 100 characters",
-			existingManagedIdentity: sampleManagedIdentity,
+			name:                  "positive: returns the managed identity and its access rules",
+			existingIdentity:      sampleManagedIdentity,
+			expectAccessRuleCount: 2,
 		},
 		{
-			name: "negative: managed identity being updated doesn't exist",
-			input: &UpdateManagedIdentityInput{
-				ID:          "non-existent-id",
-				Description: "This is an updated description",
-				Data:        []byte("this is new data"),
-			},
+			name:            "negative: managed identity doesn't exist",
 			expectErrorCode: errors.ENotFound,
-			expectError:     "managed identity with ID non-existent-id not found",
-		},
-		{
-			name: "negative: attempting to update a managed identity alias",
-			input: &UpdateManagedIdentityInput{
-				ID:          "some-managed-identity-id",
-				Description: "This is an updated description",
-				Data:        []byte("this is new data"),
-			},
-			existingManagedIdentity: &models.ManagedIdentity{
-				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
-			},
-			expectErrorCode: errors.EInvalid,
-			expectError:     "Only a source managed identity can be updated, not an alias",
 		},
 		{
-			name: "negative: subject does not have access to group",
-			input: &UpdateManagedIdentityInput{
-				ID:          "some-managed-identity-id",
-				Description: "This is an updated description",
-				Data:        []byte("this is new data"),
-			},
-			existingManagedIdentity: sampleManagedIdentity,
-			authError:               errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode:         errors.EForbidden,
-			expectError:             "Forbidden",
+			name:             "negative: subject does not have access to managed identity",
+			existingIdentity: sampleManagedIdentity,
+			authError:        errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:  errors.EForbidden,
 		},
 	}
 
@@ -1865,52 +1732,34 @@ func TestUpdateManagedIdentity(t *testing.T) {
 			defer cancel()
 
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
-			mockActivityEvents := activityevent.NewMockService(t)
-			mockTransactions := db.NewMockTransactions(t)
-			mockDelegate := NewMockDelegate(t)
 			mockCaller := auth.NewMockCaller(t)
 
-			if test.expectErrorCode == "" || test.setManagedIdentityDataError != nil {
-				mockDelegate.On("SetManagedIdentityData", mock.Anything, test.existingManagedIdentity, test.input.Data).Return(test.setManagedIdentityDataError)
-			}
-
-			if test.expectErrorCode == "" {
-				mockManagedIdentities.On("UpdateManagedIdentity", mock.Anything, test.existingManagedIdentity).Return(test.expectManagedIdentity, nil)
-
-				mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
-
-				mockCaller.On("GetSubject").Return("mockSubject")
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, managedIdentityID).Return(test.existingIdentity, nil)
 
-				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
-				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
-				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+			if test.existingIdentity != nil {
+				// Authorization must only be enforced once for both the identity and its rules.
+				mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).
+					Return(test.authError).Once()
 			}
 
-			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.input.ID).Return(test.existingManagedIdentity, nil)
-
-			if test.existingManagedIdentity != nil && !test.existingManagedIdentity.IsAlias() {
-				mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateManagedIdentityPermission, mock.Anything).Return(test.authError)
+			if test.expectErrorCode == "" {
+				mockManagedIdentities.On("GetManagedIdentityAccessRules", mock.Anything, &db.GetManagedIdentityAccessRulesInput{
+					Filter: &db.ManagedIdentityAccessRuleFilter{
+						ManagedIdentityID: &managedIdentityID,
+					},
+				}).Return(&db.ManagedIdentityAccessRulesResult{ManagedIdentityAccessRules: sampleRules}, nil).Once()
 			}
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
-				Transactions:      mockTransactions,
-			}
-
-			delegateMap := map[models.ManagedIdentityType]Delegate{
-				models.ManagedIdentityAWSFederated:     mockDelegate,
-				models.ManagedIdentityAzureFederated:   mockDelegate,
-				models.ManagedIdentityTharsisFederated: mockDelegate,
 			}
 
-			logger, _ := logger.NewForTest()
-			service := NewService(logger, dbClient, nil, delegateMap, nil, nil, mockActivityEvents)
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
 
-			identity, err := service.UpdateManagedIdentity(auth.WithCaller(ctx, mockCaller), test.input)
+			result, err := service.GetManagedIdentityByIDWithRules(auth.WithCaller(ctx, mockCaller), managedIdentityID)
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
-				assert.Equal(t, test.expectError, errors.ErrorMessage(err))
 				return
 			}
 
@@ -1918,75 +1767,49 @@ func TestUpdateManagedIdentity(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			assert.Equal(t, test.expectManagedIdentity, identity)
+			assert.Equal(t, test.existingIdentity, result.ManagedIdentity)
+			assert.Equal(t, test.expectAccessRuleCount, len(result.AccessRules))
+			mockCaller.AssertNumberOfCalls(t, "RequireAccessToInheritableResource", 1)
 		})
 	}
 }
 
-func TestGetManagedIdentityAccessRules(t *testing.T) {
+func TestGetManagedIdentityByPath(t *testing.T) {
 	sampleManagedIdentity := &models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
 			ID: "some-managed-identity-id",
 		},
 		Name:         "a-managed-identity",
 		ResourcePath: "some/resource/path",
-		Description:  "old-description",
 		GroupID:      "some-group-id",
-		Data:         []byte("this is old data"),
 		Type:         models.ManagedIdentityAWSFederated,
 	}
 
-	sampleAccessRules := []models.ManagedIdentityAccessRule{
-		{
-			Metadata: models.ResourceMetadata{
-				ID: "some-access-rule",
-			},
-			Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
-			RunStage:                 models.JobPlanType,
-			ManagedIdentityID:        sampleManagedIdentity.Metadata.ID,
-			AllowedUserIDs:           []string{"user-id-1"},
-			AllowedServiceAccountIDs: []string{"service-account-id-1"},
-			AllowedTeamIDs:           []string{"team-id-1"},
-		},
-	}
-
 	type testCase struct {
-		authError         error
-		input             *models.ManagedIdentity
-		dbInput           *db.GetManagedIdentityAccessRulesInput
-		dbResult          *db.ManagedIdentityAccessRulesResult
-		name              string
-		expectErrorCode   errors.CodeType
-		expectAccessRules []models.ManagedIdentityAccessRule
+		authError             error
+		expectManagedIdentity *models.ManagedIdentity
+		name                  string
+		searchPath            string
+		expectErrorCode       errors.CodeType
 	}
 
 	testCases := []testCase{
 		{
-			name:  "positive: successfully return managed identity access rules",
-			input: sampleManagedIdentity,
-			dbInput: &db.GetManagedIdentityAccessRulesInput{
-				Filter: &db.ManagedIdentityAccessRuleFilter{
-					ManagedIdentityID: &sampleManagedIdentity.Metadata.ID,
-				},
-			},
-			expectAccessRules: sampleAccessRules,
-			dbResult: &db.ManagedIdentityAccessRulesResult{
-				ManagedIdentityAccessRules: sampleAccessRules,
-			},
+			name:                  "positive: successfully returns a managed identity",
+			expectManagedIdentity: sampleManagedIdentity,
+			searchPath:            "some/resource/path",
 		},
 		{
-			name:  "negative: subject does have access to group resource",
-			input: sampleManagedIdentity,
-			dbInput: &db.GetManagedIdentityAccessRulesInput{
-				Filter: &db.ManagedIdentityAccessRuleFilter{
-					ManagedIdentityID: &sampleManagedIdentity.Metadata.ID,
-				},
-			},
-			authError: errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			dbResult: &db.ManagedIdentityAccessRulesResult{
-				ManagedIdentityAccessRules: sampleAccessRules,
-			},
-			expectErrorCode: errors.EForbidden,
+			name:            "negative: path is invalid",
+			searchPath:      "/invalid/path/",
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:                  "negative: subject does not have access to group resource",
+			searchPath:            "some/resource/path",
+			expectManagedIdentity: sampleManagedIdentity,
+			authError:             errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:       errors.EForbidden,
 		},
 	}
 
@@ -1998,17 +1821,17 @@ func TestGetManagedIdentityAccessRules(t *testing.T) {
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
 			mockCaller := auth.NewMockCaller(t)
 
-			mockManagedIdentities.On("GetManagedIdentityAccessRules", mock.Anything, test.dbInput).Return(test.dbResult, nil).Maybe()
+			mockManagedIdentities.On("GetManagedIdentityByPath", mock.Anything, test.searchPath).Return(test.expectManagedIdentity, nil).Maybe()
 
-			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError)
+			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError).Maybe()
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
 			}
 
-			service := NewService(nil, dbClient, nil, nil, nil, nil, nil)
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
 
-			rules, err := service.GetManagedIdentityAccessRules(auth.WithCaller(ctx, mockCaller), test.input)
+			identity, err := service.GetManagedIdentityByPath(auth.WithCaller(ctx, mockCaller), test.searchPath)
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -2019,86 +1842,2861 @@ func TestGetManagedIdentityAccessRules(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			assert.Equal(t, test.expectAccessRules, rules)
+			assert.Equal(t, test.expectManagedIdentity, identity)
 		})
 	}
 }
 
-func TestGetManagedIdentityAccessRulesByIDs(t *testing.T) {
+func TestCreateManagedIdentityAlias(t *testing.T) {
+	mockSubject := "mockSubject"
+
+	sampleManagedIdentity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-id",
+		},
+		Name:         "a-managed-identity",
+		Description:  "this is a managed identity being created",
+		ResourcePath: "some/resource/path",
+		GroupID:      "some-group-id",
+		Data:         []byte("some-data"),
+		CreatedBy:    mockSubject,
+		Type:         models.ManagedIdentityAWSFederated,
+	}
+
+	sampleGroup := &models.Group{
+		Metadata: models.ResourceMetadata{
+			ID: "some-group-id",
+		},
+		FullPath: "some/resource",
+	}
+
+	sampleAliasGroup := &models.Group{
+		Metadata: models.ResourceMetadata{
+			ID: "some-other-group-id",
+		},
+		FullPath: "some/sibling",
+	}
+
+	activityEventInput := &activityevent.CreateActivityEventInput{
+		NamespacePath: &sampleAliasGroup.FullPath,
+		Action:        models.ActionCreate,
+		TargetType:    models.TargetManagedIdentity,
+		TargetID:      "some-new-alias-id",
+	}
+
+	sampleAliasName := "some-managed-identity-alias"
+
+	type testCase struct {
+		authError               error
+		existingManagedIdentity *models.ManagedIdentity
+		existingGroup           *models.Group
+		expectCreatedAlias      *models.ManagedIdentity
+		createInput             *models.ManagedIdentity
+		input                   *CreateManagedIdentityAliasInput
+		name                    string
+		expectErrorCode         errors.CodeType
+		limit                   int
+		injectAliasesPerGroup   int32
+		injectAliasesPerMI      int32
+		exceedsLimit            bool
+		exceedsGroupLimit       bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "positive: successfully create a managed identity alias in a sibling group",
+			input: &CreateManagedIdentityAliasInput{
+				Group:         sampleAliasGroup,
+				AliasSourceID: sampleManagedIdentity.Metadata.ID,
+				Name:          sampleAliasName,
+			},
+			createInput: &models.ManagedIdentity{
+				GroupID:       sampleAliasGroup.Metadata.ID,
+				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
+				Name:          sampleAliasName,
+				CreatedBy:     mockSubject,
+			},
+			existingManagedIdentity: sampleManagedIdentity,
+			existingGroup:           sampleGroup,
+			expectCreatedAlias: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID: "some-new-alias-id",
+				},
+				Type:          sampleManagedIdentity.Type,
+				ResourcePath:  sampleAliasGroup.FullPath + "/some-managed-identity-alias",
+				Name:          sampleAliasName,
+				Description:   sampleManagedIdentity.Description,
+				GroupID:       sampleAliasGroup.Metadata.ID,
+				CreatedBy:     mockSubject,
+				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
+				Data:          sampleManagedIdentity.Data,
+			},
+			limit:                 5,
+			injectAliasesPerGroup: 5,
+			injectAliasesPerMI:    5,
+		},
+		{
+			name: "negative: source managed identity doesn't exist",
+			input: &CreateManagedIdentityAliasInput{
+				Group:         sampleAliasGroup,
+				AliasSourceID: sampleManagedIdentity.Metadata.ID,
+				Name:          sampleAliasName,
+			},
+			expectErrorCode: errors.ENotFound,
+		},
+		{
+			// Shouldn't happen.
+			name: "negative: group associated with source managed identity doesn't exist",
+			input: &CreateManagedIdentityAliasInput{
+				Group:         sampleAliasGroup,
+				AliasSourceID: sampleManagedIdentity.Metadata.ID,
+				Name:          sampleAliasName,
+			},
+			existingManagedIdentity: sampleManagedIdentity,
+			expectErrorCode:         errors.EInternal,
+		},
+		{
+			name: "negative: source managed identity is already available for namespace",
+			input: &CreateManagedIdentityAliasInput{
+				Group:         sampleGroup, // Using the same group here.
+				AliasSourceID: sampleManagedIdentity.Metadata.ID,
+				Name:          sampleAliasName,
+			},
+			existingManagedIdentity: sampleManagedIdentity,
+			existingGroup:           sampleGroup,
+			expectErrorCode:         errors.EInvalid,
+		},
+		{
+			name: "negative: attempting to alias another alias",
+			input: &CreateManagedIdentityAliasInput{
+				Group:         sampleAliasGroup,
+				AliasSourceID: "some-alias-id",
+				Name:          sampleAliasName,
+			},
+			existingManagedIdentity: &models.ManagedIdentity{
+				AliasSourceID: &sampleManagedIdentity.Metadata.ID, // Only populated for aliases.
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name: "negative: invalid name",
+			input: &CreateManagedIdentityAliasInput{
+				Group:         sampleGroup, // Using the same group here.
+				AliasSourceID: sampleManagedIdentity.Metadata.ID,
+				Name:          "some/invalid/name",
+			},
+			createInput: &models.ManagedIdentity{
+				GroupID:       sampleAliasGroup.Metadata.ID,
+				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
+				Name:          "some/invalid/name",
+				CreatedBy:     mockSubject,
+			},
+			existingManagedIdentity: sampleManagedIdentity,
+			existingGroup:           sampleGroup,
+			expectErrorCode:         errors.EInvalid,
+		},
+		{
+			name: "negative: subject does not have owner role in target group",
+			input: &CreateManagedIdentityAliasInput{
+				Group:         sampleGroup, // Using the same group here.
+				AliasSourceID: sampleManagedIdentity.Metadata.ID,
+				Name:          sampleAliasName,
+			},
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name: "negative: subject does not have owner role in source group",
+			input: &CreateManagedIdentityAliasInput{
+				Group:         sampleGroup, // Using the same group here.
+				AliasSourceID: sampleManagedIdentity.Metadata.ID,
+				Name:          sampleAliasName,
+			},
+			existingManagedIdentity: sampleManagedIdentity,
+			existingGroup:           sampleGroup,
+			authError:               errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:         errors.EForbidden,
+		},
+		{
+			name: "exceeds limit for aliases in group",
+			input: &CreateManagedIdentityAliasInput{
+				Group:         sampleAliasGroup,
+				AliasSourceID: sampleManagedIdentity.Metadata.ID,
+				Name:          sampleAliasName,
+			},
+			createInput: &models.ManagedIdentity{
+				GroupID:       sampleAliasGroup.Metadata.ID,
+				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
+				Name:          sampleAliasName,
+				CreatedBy:     mockSubject,
+			},
+			existingManagedIdentity: sampleManagedIdentity,
+			existingGroup:           sampleGroup,
+			expectCreatedAlias: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID: "some-new-alias-id",
+				},
+				Type:          sampleManagedIdentity.Type,
+				ResourcePath:  sampleAliasGroup.FullPath + "/some-managed-identity-alias",
+				Name:          sampleAliasName,
+				Description:   sampleManagedIdentity.Description,
+				GroupID:       sampleAliasGroup.Metadata.ID,
+				CreatedBy:     mockSubject,
+				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
+				Data:          sampleManagedIdentity.Data,
+			},
+			expectErrorCode:       errors.EInvalid,
+			limit:                 5,
+			injectAliasesPerGroup: 6,
+			injectAliasesPerMI:    5,
+			exceedsLimit:          true,
+			exceedsGroupLimit:     true,
+		},
+		{
+			name: "exceeds limit for aliases per source MI",
+			input: &CreateManagedIdentityAliasInput{
+				Group:         sampleAliasGroup,
+				AliasSourceID: sampleManagedIdentity.Metadata.ID,
+				Name:          sampleAliasName,
+			},
+			createInput: &models.ManagedIdentity{
+				GroupID:       sampleAliasGroup.Metadata.ID,
+				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
+				Name:          sampleAliasName,
+				CreatedBy:     mockSubject,
+			},
+			existingManagedIdentity: sampleManagedIdentity,
+			existingGroup:           sampleGroup,
+			expectCreatedAlias: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID: "some-new-alias-id",
+				},
+				Type:          sampleManagedIdentity.Type,
+				ResourcePath:  sampleAliasGroup.FullPath + "/some-managed-identity-alias",
+				Name:          sampleAliasName,
+				Description:   sampleManagedIdentity.Description,
+				GroupID:       sampleAliasGroup.Metadata.ID,
+				CreatedBy:     mockSubject,
+				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
+				Data:          sampleManagedIdentity.Data,
+			},
+			expectErrorCode:       errors.EInvalid,
+			limit:                 5,
+			injectAliasesPerGroup: 5,
+			injectAliasesPerMI:    6,
+			exceedsLimit:          true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockGroups := db.NewMockGroups(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockCaller := auth.NewMockCaller(t)
+			mockResourceLimits := db.NewMockResourceLimits(t)
+
+			if test.authError == nil {
+				mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, mock.Anything).Return(test.existingManagedIdentity, nil)
+			}
+
+			mockGroups.On("GetGroupByID", mock.Anything, mock.Anything).Return(test.existingGroup, nil).Maybe()
+
+			if (test.expectErrorCode == "") || test.exceedsLimit {
+				mockCaller.On("GetSubject").Return("mockSubject")
+
+				mockManagedIdentities.On("CreateManagedIdentity", mock.Anything, test.createInput).Return(test.expectCreatedAlias, nil)
+
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				if !test.exceedsLimit {
+					mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
+					mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+				}
+			}
+
+			if test.existingGroup != nil {
+				mockCaller.On("RequirePermission", mock.Anything, permissions.CreateManagedIdentityPermission, mock.Anything).Return(test.authError)
+			}
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateManagedIdentityPermission, mock.Anything).Return(test.authError)
+
+			// Called inside transaction to check resource limits.
+			if test.limit > 0 {
+				mockManagedIdentities.On("GetManagedIdentityCount", mock.Anything, &db.ManagedIdentityFilter{
+					NamespacePaths: []string{"some/sibling"},
+				}).Return(test.injectAliasesPerGroup, nil)
+
+				if !test.exceedsGroupLimit {
+					mockManagedIdentities.On("GetManagedIdentityCount", mock.Anything, &db.ManagedIdentityFilter{
+						AliasSourceID: &test.existingManagedIdentity.Metadata.ID,
+					}).Return(test.injectAliasesPerMI, nil)
+				}
+
+				mockResourceLimits.On("GetResourceLimit", mock.Anything, mock.Anything).
+					Return(&models.ResourceLimit{Value: test.limit}, nil)
+				mockResourceLimits.On("GetNearestGroupResourceLimit", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, nil).Maybe()
+			}
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+				Transactions:      mockTransactions,
+				Groups:            mockGroups,
+				ResourceLimits:    mockResourceLimits,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, limits.NewLimitChecker(dbClient), nil, nil, nil, mockActivityEvents, ServiceOptions{})
+
+			alias, err := service.CreateManagedIdentityAlias(auth.WithCaller(ctx, mockCaller), test.input)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectCreatedAlias, alias)
+		})
+	}
+}
+
+func TestCreateManagedIdentityAliasWithCopyAccessRules(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Aliases never own access rules, they always resolve to the source managed identity's
+	// rules, so the request must be rejected before any permission checks or DB calls.
+	mockCaller := auth.NewMockCaller(t)
+	mockManagedIdentities := db.NewMockManagedIdentities(t)
+
+	dbClient := &db.Client{
+		ManagedIdentities: mockManagedIdentities,
+	}
+
+	logger, _ := logger.NewForTest()
+	service := NewService(logger, dbClient, limits.NewLimitChecker(dbClient), nil, nil, nil, nil, ServiceOptions{})
+
+	alias, err := service.CreateManagedIdentityAlias(auth.WithCaller(ctx, mockCaller), &CreateManagedIdentityAliasInput{
+		Group: &models.Group{
+			Metadata: models.ResourceMetadata{ID: "some-other-group-id"},
+			FullPath: "some/sibling",
+		},
+		AliasSourceID:   "some-managed-identity-id",
+		Name:            "some-managed-identity-alias",
+		CopyAccessRules: true,
+	})
+
+	assert.Nil(t, alias)
+	assert.Equal(t, errors.EInvalid, errors.ErrorCode(err))
+}
+
+func TestDeleteManagedIdentityAlias(t *testing.T) {
+	sampleManagedIdentityAlias := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-alias-id",
+		},
+		Name:          "a-managed-identity-alias-to-delete",
+		ResourcePath:  "some/resource/path",
+		GroupID:       "some-group-id",
+		AliasSourceID: ptr.String("some-source-managed-identity-id"),
+	}
+
+	sampleSourceIdentity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-source-managed-identity-id",
+		},
+		GroupID: "some-group-id",
+	}
+
+	activityEventInput := &activityevent.CreateActivityEventInput{
+		NamespacePath: ptr.String("some/resource"),
+		Action:        models.ActionDeleteChildResource,
+		TargetType:    models.TargetGroup,
+		TargetID:      sampleManagedIdentityAlias.GroupID,
+		Payload: &models.ActivityEventDeleteChildResourcePayload{
+			Name: sampleManagedIdentityAlias.Name,
+			ID:   sampleManagedIdentityAlias.Metadata.ID,
+			Type: string(models.TargetManagedIdentity),
+		},
+	}
+
+	type testCase struct {
+		authError                 error
+		input                     *DeleteManagedIdentityInput
+		sourceIdentity            *models.ManagedIdentity
+		expectErrorCode           errors.CodeType
+		name                      string
+		managedIdentityWorkspaces []models.Workspace
+	}
+
+	testCases := []testCase{
+		{
+			name: "positive: successfully delete a managed identity alias",
+			input: &DeleteManagedIdentityInput{
+				ManagedIdentity: sampleManagedIdentityAlias,
+			},
+		},
+		{
+			name: "positive: successfully delete a managed identity alias with force option",
+			input: &DeleteManagedIdentityInput{
+				ManagedIdentity: sampleManagedIdentityAlias,
+				Force:           true,
+			},
+		},
+		{
+			name: "negative: no force option and managed identity alias is assigned to a workspace",
+			input: &DeleteManagedIdentityInput{
+				ManagedIdentity: sampleManagedIdentityAlias,
+			},
+			managedIdentityWorkspaces: []models.Workspace{{}},
+			expectErrorCode:           errors.EConflict,
+		},
+		{
+			name: "negative: attempting to delete a source managed identity",
+			input: &DeleteManagedIdentityInput{
+				ManagedIdentity: sampleSourceIdentity,
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name: "negative: subject does not have owner role in alias' group",
+			input: &DeleteManagedIdentityInput{
+				ManagedIdentity: sampleManagedIdentityAlias,
+			},
+			sourceIdentity: sampleSourceIdentity,
+		},
+		{
+			name: "negative: subject does not have owner role in source group",
+			input: &DeleteManagedIdentityInput{
+				ManagedIdentity: sampleManagedIdentityAlias,
+			},
+			sourceIdentity:  sampleSourceIdentity,
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockCaller := auth.NewMockCaller(t)
+
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, sampleSourceIdentity.Metadata.ID).Return(test.sourceIdentity, nil).Maybe()
+
+			mockCaller.On("GetSubject").Return("mockSubject").Maybe()
+
+			if test.expectErrorCode == "" {
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
+
+				mockManagedIdentities.On("DeleteManagedIdentity", mock.Anything, test.input.ManagedIdentity).Return(nil)
+
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+			}
+
+			if !test.input.Force {
+				mockWorkspaces.On("GetWorkspacesForManagedIdentity", mock.Anything, sampleManagedIdentityAlias.Metadata.ID).Return(test.managedIdentityWorkspaces, nil).Maybe()
+			}
+
+			if test.sourceIdentity != nil {
+				mockCaller.On("RequirePermission", mock.Anything, permissions.DeleteManagedIdentityPermission, mock.Anything).Return(test.authError).Maybe()
+			}
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.DeleteManagedIdentityPermission, mock.Anything).Return(test.authError).Maybe()
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+				Workspaces:        mockWorkspaces,
+				Transactions:      mockTransactions,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, nil, nil, nil, nil, mockActivityEvents, ServiceOptions{})
+
+			err := service.DeleteManagedIdentityAlias(auth.WithCaller(ctx, mockCaller), test.input)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestValidateManagedIdentityDataSize(t *testing.T) {
+	maxSize := maxManagedIdentityDataSize[models.ManagedIdentityAWSFederated]
+
+	type testCase struct {
+		name            string
+		identityType    models.ManagedIdentityType
+		data            []byte
+		expectErrorCode errors.CodeType
+	}
+
+	testCases := []testCase{
+		{
+			name:         "empty data",
+			identityType: models.ManagedIdentityAWSFederated,
+			data:         []byte{},
+		},
+		{
+			name:         "data at max size",
+			identityType: models.ManagedIdentityAWSFederated,
+			data:         make([]byte, maxSize),
+		},
+		{
+			name:            "data one byte over max size",
+			identityType:    models.ManagedIdentityAWSFederated,
+			data:            make([]byte, maxSize+1),
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:         "type without a max size",
+			identityType: "unknown-type",
+			data:         make([]byte, maxSize+1),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateManagedIdentityDataSize(test.identityType, test.data)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestCreateManagedIdentity(t *testing.T) {
+	mockSubject := "mockSubject"
+
+	sampleManagedIdentity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-id",
+		},
+		Name:         "a-managed-identity",
+		Description:  "this is a managed identity being created",
+		ResourcePath: "some/resource/path",
+		GroupID:      "some-group-id",
+		Data:         []byte("some-data"),
+		CreatedBy:    mockSubject,
+		Type:         models.ManagedIdentityAWSFederated,
+	}
+
+	sampleServiceAccount := models.ServiceAccount{
+		Metadata: models.ResourceMetadata{
+			ID: "service-account-1-id",
+		},
+		ResourcePath: "some/resource/service-account",
+	}
+
+	activityEventInput := &activityevent.CreateActivityEventInput{
+		NamespacePath: ptr.String(sampleManagedIdentity.GetGroupPath()),
+		Action:        models.ActionCreate,
+		TargetType:    models.TargetManagedIdentity,
+		TargetID:      sampleManagedIdentity.Metadata.ID,
+	}
+
+	createIdentityInput := &models.ManagedIdentity{
+		Name:        "a-managed-identity",
+		Description: "this is a managed identity being created",
+		GroupID:     "some-group-id",
+		CreatedBy:   mockSubject,
+		Type:        models.ManagedIdentityAWSFederated,
+		Data:        []byte{},
+	}
+
+	createAccessRuleInput := &models.ManagedIdentityAccessRule{
+		ManagedIdentityID:        sampleManagedIdentity.Metadata.ID,
+		Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:                 models.JobPlanType,
+		AllowedUserIDs:           []string{"user-1-id", "user-2-id"},
+		AllowedServiceAccountIDs: []string{"service-account-1-id"},
+		AllowedTeamIDs:           []string{"team-1-id"},
+	}
+
+	createApplyAccessRuleInput := &models.ManagedIdentityAccessRule{
+		ManagedIdentityID: sampleManagedIdentity.Metadata.ID,
+		Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:          models.JobApplyType,
+		AllowedUserIDs:    []string{"user-1-id"},
+	}
+
+	type testCase struct {
+		authError                   error
+		input                       *CreateManagedIdentityInput
+		existingServiceAccounts     []models.ServiceAccount
+		name                        string
+		expectErrorDetails          map[string]string
+		expectErrorCode             errors.CodeType
+		expectError                 string
+		limit                       int
+		injectMIPerGroup            int32
+		exceedsLimit                bool
+		setManagedIdentityDataError error
+	}
+
+	testCases := []testCase{
+		{
+			name: "positive: successfully create a managed identity",
+			input: &CreateManagedIdentityInput{
+				Type:        models.ManagedIdentityAWSFederated,
+				Name:        "a-managed-identity",
+				Description: "this is a managed identity being created",
+				GroupID:     "some-group-id",
+				Data:        []byte("some-data"),
+				AccessRules: []struct {
+					Type                      models.ManagedIdentityAccessRuleType
+					RunStage                  models.JobType
+					ModuleAttestationPolicies []models.ManagedIdentityAccessRuleModuleAttestationPolicy
+					AllowedUserIDs            []string
+					AllowedServiceAccountIDs  []string
+					AllowedTeamIDs            []string
+					VerifyStateLineage        bool
+				}{
+					{
+						Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+						RunStage:                 models.JobPlanType,
+						AllowedUserIDs:           []string{"user-1-id", "user-2-id"},
+						AllowedServiceAccountIDs: []string{"service-account-1-id"},
+						AllowedTeamIDs:           []string{"team-1-id"},
+					},
+				},
+			},
+			existingServiceAccounts: []models.ServiceAccount{sampleServiceAccount},
+			limit:                   5,
+			injectMIPerGroup:        5,
+		},
+		{
+			name: "positive: access rules cover all run stages when required",
+			input: &CreateManagedIdentityInput{
+				Type:        models.ManagedIdentityAWSFederated,
+				Name:        "a-managed-identity",
+				Description: "this is a managed identity being created",
+				GroupID:     "some-group-id",
+				Data:        []byte("some-data"),
+				AccessRules: []struct {
+					Type                      models.ManagedIdentityAccessRuleType
+					RunStage                  models.JobType
+					ModuleAttestationPolicies []models.ManagedIdentityAccessRuleModuleAttestationPolicy
+					AllowedUserIDs            []string
+					AllowedServiceAccountIDs  []string
+					AllowedTeamIDs            []string
+					VerifyStateLineage        bool
+				}{
+					{
+						Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+						RunStage:                 models.JobPlanType,
+						AllowedUserIDs:           []string{"user-1-id", "user-2-id"},
+						AllowedServiceAccountIDs: []string{"service-account-1-id"},
+						AllowedTeamIDs:           []string{"team-1-id"},
+					},
+					{
+						Type:           models.ManagedIdentityAccessRuleEligiblePrincipals,
+						RunStage:       models.JobApplyType,
+						AllowedUserIDs: []string{"user-1-id"},
+					},
+				},
+				RequireAccessRulesForAllRunStages: true,
+			},
+			existingServiceAccounts: []models.ServiceAccount{sampleServiceAccount},
+			limit:                   5,
+			injectMIPerGroup:        5,
+		},
+		{
+			name: "negative: access rules don't cover the apply stage when required",
+			input: &CreateManagedIdentityInput{
+				Type:        models.ManagedIdentityAWSFederated,
+				Name:        "a-managed-identity",
+				Description: "this is a managed identity being created",
+				GroupID:     "some-group-id",
+				Data:        []byte("some-data"),
+				AccessRules: []struct {
+					Type                      models.ManagedIdentityAccessRuleType
+					RunStage                  models.JobType
+					ModuleAttestationPolicies []models.ManagedIdentityAccessRuleModuleAttestationPolicy
+					AllowedUserIDs            []string
+					AllowedServiceAccountIDs  []string
+					AllowedTeamIDs            []string
+					VerifyStateLineage        bool
+				}{
+					{
+						Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+						RunStage:                 models.JobPlanType,
+						AllowedUserIDs:           []string{"user-1-id", "user-2-id"},
+						AllowedServiceAccountIDs: []string{"service-account-1-id"},
+						AllowedTeamIDs:           []string{"team-1-id"},
+					},
+				},
+				RequireAccessRulesForAllRunStages: true,
+			},
+			expectErrorCode: errors.EInvalid,
+			expectError:     "access rules must be provided for every run stage but there are no rules for the apply stage(s)",
+		},
+		{
+			name: "negative: service account in access policy does not exist",
+			input: &CreateManagedIdentityInput{
+				Type:        models.ManagedIdentityAWSFederated,
+				Name:        "a-managed-identity",
+				Description: "this is a managed identity being created",
+				GroupID:     "some-group-id",
+				Data:        []byte("some-data"),
+				AccessRules: []struct {
+					Type                      models.ManagedIdentityAccessRuleType
+					RunStage                  models.JobType
+					ModuleAttestationPolicies []models.ManagedIdentityAccessRuleModuleAttestationPolicy
+					AllowedUserIDs            []string
+					AllowedServiceAccountIDs  []string
+					AllowedTeamIDs            []string
+					VerifyStateLineage        bool
+				}{
+					{
+						Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+						RunStage:                 models.JobPlanType,
+						AllowedUserIDs:           []string{"user-1-id", "user-2-id"},
+						AllowedServiceAccountIDs: []string{"non-existent-service-account"},
+						AllowedTeamIDs:           []string{"team-1-id"},
+					},
+				},
+			},
+			expectErrorCode:  errors.ENotFound,
+			expectError:      "service account with ID non-existent-service-account not found",
+			limit:            5, // enables mock On calls
+			injectMIPerGroup: 5,
+		},
+		{
+			name: "negative: service account in access policy is outside group scope",
+			input: &CreateManagedIdentityInput{
+				Type:        models.ManagedIdentityAWSFederated,
+				Name:        "a-managed-identity",
+				Description: "this is a managed identity being created",
+				GroupID:     "some-group-id",
+				Data:        []byte("some-data"),
+				AccessRules: []struct {
+					Type                      models.ManagedIdentityAccessRuleType
+					RunStage                  models.JobType
+					ModuleAttestationPolicies []models.ManagedIdentityAccessRuleModuleAttestationPolicy
+					AllowedUserIDs            []string
+					AllowedServiceAccountIDs  []string
+					AllowedTeamIDs            []string
+					VerifyStateLineage        bool
+				}{
+					{
+						Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+						RunStage:                 models.JobPlanType,
+						AllowedUserIDs:           []string{"user-1-id", "user-2-id"},
+						AllowedServiceAccountIDs: []string{"outside-scope-1"},
+						AllowedTeamIDs:           []string{"team-1-id"},
+					},
+				},
+			},
+			existingServiceAccounts: []models.ServiceAccount{
+				{
+					Metadata:     models.ResourceMetadata{ID: "outside-scope-1"},
+					ResourcePath: "outside/scope/service-account",
+				},
+			},
+			expectErrorCode:  errors.EInvalid,
+			expectError:      "service account outside/scope/service-account is outside the scope of group some/resource",
+			limit:            5, // enables mock On calls
+			injectMIPerGroup: 5,
+		},
+		{
+			name: "negative: one of several service accounts across access rules is outside group scope",
+			input: &CreateManagedIdentityInput{
+				Type:        models.ManagedIdentityAWSFederated,
+				Name:        "a-managed-identity",
+				Description: "this is a managed identity being created",
+				GroupID:     "some-group-id",
+				Data:        []byte("some-data"),
+				AccessRules: []struct {
+					Type                      models.ManagedIdentityAccessRuleType
+					RunStage                  models.JobType
+					ModuleAttestationPolicies []models.ManagedIdentityAccessRuleModuleAttestationPolicy
+					AllowedUserIDs            []string
+					AllowedServiceAccountIDs  []string
+					AllowedTeamIDs            []string
+					VerifyStateLineage        bool
+				}{
+					{
+						Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+						RunStage:                 models.JobPlanType,
+						AllowedServiceAccountIDs: []string{"service-account-1-id", "service-account-2-id"},
+					},
+					{
+						Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+						RunStage:                 models.JobApplyType,
+						AllowedServiceAccountIDs: []string{"service-account-3-id", "outside-scope-1"},
+					},
+				},
+			},
+			existingServiceAccounts: []models.ServiceAccount{
+				sampleServiceAccount,
+				{
+					Metadata:     models.ResourceMetadata{ID: "service-account-2-id"},
+					ResourcePath: "some/service-account-2",
+				},
+				{
+					Metadata:     models.ResourceMetadata{ID: "outside-scope-1"},
+					ResourcePath: "outside/scope/service-account",
+				},
+				{
+					Metadata:     models.ResourceMetadata{ID: "service-account-3-id"},
+					ResourcePath: "some/resource/service-account-3",
+				},
+			},
+			expectErrorCode:  errors.EInvalid,
+			expectError:      "service account outside/scope/service-account is outside the scope of group some/resource",
+			limit:            5, // enables mock On calls
+			injectMIPerGroup: 5,
+		},
+		{
+			name: "negative: managed identity data exceeds max size",
+			input: &CreateManagedIdentityInput{
+				Type:        models.ManagedIdentityAWSFederated,
+				Name:        "a-managed-identity",
+				Description: "this is a managed identity being created",
+				GroupID:     "some-group-id",
+				Data:        make([]byte, maxManagedIdentityDataSize[models.ManagedIdentityAWSFederated]+1),
+			},
+			expectErrorCode: errors.EInvalid,
+			expectError:     "managed identity data for type aws_federated must not exceed 4096 bytes, got 4097 bytes",
+		},
+		{
+			name: "negative: unsupported managed identity type",
+			input: &CreateManagedIdentityInput{
+				Type:    "unknown-type",
+				GroupID: "some-group-id",
+			},
+			expectErrorCode: errors.EInvalid,
+			expectError:     "managed identity with type unknown-type is not supported",
+		},
+		{
+			name: "negative: managed identity has an invalid name",
+			input: &CreateManagedIdentityInput{
+				Type:    models.ManagedIdentityAWSFederated,
+				Name:    "-invalid-name-",
+				GroupID: "some-group-id",
+			},
+			expectErrorCode: errors.EInvalid,
+			expectError:     "Invalid name, name can only include lowercase letters and numbers with - and _ supported in non leading or trailing positions. Max length is 64 characters.",
+			expectErrorDetails: map[string]string{
+				"name": "Invalid name, name can only include lowercase letters and numbers with - and _ supported in non leading or trailing positions. Max length is 64 characters.",
+			},
+		},
+		{
+			name: "negative: managed identity has an invalid host",
+			input: &CreateManagedIdentityInput{
+				Type:        models.ManagedIdentityAWSFederated,
+				Name:        "a-managed-identity",
+				Description: "this is a managed identity being created",
+				GroupID:     "some-group-id",
+				Data:        []byte("some-data"),
+			},
+			existingServiceAccounts: []models.ServiceAccount{sampleServiceAccount},
+			//limit:                       5,
+			injectMIPerGroup:            5,
+			setManagedIdentityDataError: errors.New("host invalid", errors.WithErrorCode(errors.EInvalid)),
+			expectErrorCode:             errors.EInvalid,
+			expectError:                 "failed to set managed identity data: host invalid",
+			expectErrorDetails:          map[string]string{"data": "host invalid"},
+		},
+		{
+			name: "negative: subject does not have perms for group",
+			input: &CreateManagedIdentityInput{
+				Type:    models.ManagedIdentityAWSFederated,
+				Name:    "a-managed-identity",
+				GroupID: "some-group-id",
+			},
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+			expectError:     "Forbidden",
+		},
+		{
+			name: "exceeds limit",
+			input: &CreateManagedIdentityInput{
+				Type:        models.ManagedIdentityAWSFederated,
+				Name:        "a-managed-identity",
+				Description: "this is a managed identity being created",
+				GroupID:     "some-group-id",
+				Data:        []byte("some-data"),
+				AccessRules: []struct {
+					Type                      models.ManagedIdentityAccessRuleType
+					RunStage                  models.JobType
+					ModuleAttestationPolicies []models.ManagedIdentityAccessRuleModuleAttestationPolicy
+					AllowedUserIDs            []string
+					AllowedServiceAccountIDs  []string
+					AllowedTeamIDs            []string
+					VerifyStateLineage        bool
+				}{
+					{
+						Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+						RunStage:                 models.JobPlanType,
+						AllowedUserIDs:           []string{"user-1-id", "user-2-id"},
+						AllowedServiceAccountIDs: []string{"service-account-1-id"},
+						AllowedTeamIDs:           []string{"team-1-id"},
+					},
+				},
+			},
+			existingServiceAccounts: []models.ServiceAccount{sampleServiceAccount},
+			limit:                   5,
+			injectMIPerGroup:        6,
+			exceedsLimit:            true,
+			expectErrorCode:         errors.EInvalid,
+			expectError:             "for limit ResourceLimitManagedIdentitiesPerGroup: value 6 exceeds limit of 5",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockServiceAccounts := db.NewMockServiceAccounts(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockDelegate := NewMockDelegate(t)
+			mockCaller := auth.NewMockCaller(t)
+			mockResourceLimits := db.NewMockResourceLimits(t)
+
+			mockManagedIdentities.On("CreateManagedIdentity", mock.Anything, createIdentityInput).Return(sampleManagedIdentity, nil).Maybe()
+			mockManagedIdentities.On("UpdateManagedIdentity", mock.Anything, sampleManagedIdentity).Return(sampleManagedIdentity, nil).Maybe()
+			mockManagedIdentities.On("CreateManagedIdentityAccessRule", mock.Anything, createAccessRuleInput).Return(&models.ManagedIdentityAccessRule{}, nil).Maybe()
+			mockManagedIdentities.On("CreateManagedIdentityAccessRule", mock.Anything, createApplyAccessRuleInput).Return(&models.ManagedIdentityAccessRule{}, nil).Maybe()
+
+			mockServiceAccounts.On("GetServiceAccountsByIDs", mock.Anything, mock.Anything).
+				Return(buildServiceAccountMap(test.existingServiceAccounts), nil).Maybe()
+
+			mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil).Maybe()
+
+			if test.exceedsLimit {
+				// The managed identity is rolled back so the event targets its group instead.
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, &activityevent.CreateActivityEventInput{
+					NamespacePath: ptr.String(sampleManagedIdentity.GetGroupPath()),
+					Action:        models.ActionLimitExceeded,
+					TargetType:    models.TargetGroup,
+					TargetID:      sampleManagedIdentity.GroupID,
+					Payload: &models.ActivityEventLimitExceededPayload{
+						LimitName: string(limits.ResourceLimitManagedIdentitiesPerGroup),
+						Value:     test.injectMIPerGroup,
+					},
+				}).Return(&models.ActivityEvent{}, nil)
+			}
+
+			mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil).Maybe()
+			mockTransactions.On("RollbackTx", mock.Anything).Return(nil).Maybe()
+			mockTransactions.On("CommitTx", mock.Anything).Return(nil).Maybe()
+
+			mockDelegate.On("SetManagedIdentityData", mock.Anything, sampleManagedIdentity, sampleManagedIdentity.Data).Return(test.setManagedIdentityDataError).Maybe()
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateManagedIdentityPermission, mock.Anything).Return(test.authError)
+
+			mockCaller.On("GetSubject").Return("mockSubject").Maybe()
+
+			// Called inside transaction to check resource limits.
+			if test.limit > 0 {
+				mockManagedIdentities.On("GetManagedIdentityCount", mock.Anything, &db.ManagedIdentityFilter{
+					NamespacePaths: []string{"some/resource"},
+				}).Return(test.injectMIPerGroup, nil)
+
+				mockResourceLimits.On("GetResourceLimit", mock.Anything, mock.Anything).
+					Return(&models.ResourceLimit{Value: test.limit}, nil)
+				mockResourceLimits.On("GetNearestGroupResourceLimit", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, nil).Maybe()
+			}
+
+			mockTeams, mockUsers := buildMockTeamsAndUsers(t, []string{"team-1-id"}, []string{"user-1-id", "user-2-id"})
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+				ServiceAccounts:   mockServiceAccounts,
+				Transactions:      mockTransactions,
+				ResourceLimits:    mockResourceLimits,
+				Teams:             mockTeams,
+				Users:             mockUsers,
+			}
+
+			delegateMap := map[models.ManagedIdentityType]Delegate{
+				models.ManagedIdentityAWSFederated:     mockDelegate,
+				models.ManagedIdentityAzureFederated:   mockDelegate,
+				models.ManagedIdentityTharsisFederated: mockDelegate,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, limits.NewLimitChecker(dbClient), delegateMap, nil, nil, mockActivityEvents, ServiceOptions{LimitActivityEventsEnabled: true})
+
+			identity, err := service.CreateManagedIdentity(auth.WithCaller(ctx, mockCaller), test.input)
+
+			if test.exceedsLimit {
+				limitErr, ok := limits.AsLimitExceededError(err)
+				if assert.True(t, ok) {
+					assert.Equal(t, limits.ResourceLimitManagedIdentitiesPerGroup, limitErr.LimitName)
+					assert.Equal(t, test.injectMIPerGroup, limitErr.Value)
+					assert.Equal(t, test.limit, limitErr.Limit)
+				}
+			}
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				assert.Equal(t, test.expectError, errors.ErrorMessage(err))
+				assert.Equal(t, test.expectErrorDetails, errors.ErrorDetails(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, sampleManagedIdentity, identity)
+		})
+	}
+}
+
+func TestValidateAccessRuleRunStages(t *testing.T) {
+	type testCase struct {
+		name          string
+		runStages     []models.JobType
+		expectMissing string
+	}
+
+	testCases := []testCase{
+		{
+			name:      "plan and apply stages are covered",
+			runStages: []models.JobType{models.JobPlanType, models.JobApplyType},
+		},
+		{
+			name:      "stages are covered by multiple rules",
+			runStages: []models.JobType{models.JobApplyType, models.JobPlanType, models.JobApplyType},
+		},
+		{
+			name:          "apply stage is missing",
+			runStages:     []models.JobType{models.JobPlanType, models.JobPlanType},
+			expectMissing: "apply",
+		},
+		{
+			name:          "plan stage is missing",
+			runStages:     []models.JobType{models.JobApplyType},
+			expectMissing: "plan",
+		},
+		{
+			name:          "no rules",
+			runStages:     []models.JobType{},
+			expectMissing: "plan, apply",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateAccessRuleRunStages(test.runStages)
+
+			if test.expectMissing == "" {
+				assert.Nil(t, err)
+				return
+			}
+
+			assert.Equal(t, errors.EInvalid, errors.ErrorCode(err))
+			assert.Contains(t, errors.ErrorMessage(err), "no rules for the "+test.expectMissing+" stage(s)")
+		})
+	}
+}
+
+func TestCreateManagedIdentityWithReservedName(t *testing.T) {
+	for _, name := range []string{"admin", "ADMIN", "System"} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateManagedIdentityPermission, mock.Anything).Return(nil)
+			mockCaller.On("GetSubject").Return("mockSubject")
+
+			delegateMap := map[models.ManagedIdentityType]Delegate{
+				models.ManagedIdentityAWSFederated: NewMockDelegate(t),
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, &db.Client{}, nil, delegateMap, nil, nil, nil, ServiceOptions{ReservedNames: reservednames.New([]string{"admin", "system"})})
+
+			identity, err := service.CreateManagedIdentity(auth.WithCaller(ctx, mockCaller), &CreateManagedIdentityInput{
+				Type:    models.ManagedIdentityAWSFederated,
+				Name:    name,
+				GroupID: "some-group-id",
+				Data:    []byte("some-data"),
+			})
+
+			assert.Nil(t, identity)
+			assert.Equal(t, errors.EInvalid, errors.ErrorCode(err))
+		})
+	}
+}
+
+func TestGetManagedIdentitiesByIDs(t *testing.T) {
+	sampleManagedIdentity := models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-id",
+		},
+		GroupID:      "some-group-id",
+		ResourcePath: "some-group/some-identity",
+	}
+
+	inputList := []string{
+		"some-managed-identity-id",
+	}
+
+	type testCase struct {
+		authError       error
+		dbInput         *db.GetManagedIdentitiesInput
+		expectResult    *db.ManagedIdentitiesResult
+		name            string
+		expectErrorCode errors.CodeType
+		inputIDList     []string
+	}
+
+	testCases := []testCase{
+		{
+			name:        "positive: successfully return a list of managed identities",
+			inputIDList: inputList,
+			dbInput: &db.GetManagedIdentitiesInput{
+				Filter: &db.ManagedIdentityFilter{
+					ManagedIdentityIDs: inputList,
+				},
+			},
+			expectResult: &db.ManagedIdentitiesResult{
+				ManagedIdentities: []models.ManagedIdentity{sampleManagedIdentity},
+			},
+		},
+		{
+			name:        "negative: subject does not have access to group resource",
+			inputIDList: inputList,
+			dbInput: &db.GetManagedIdentitiesInput{
+				Filter: &db.ManagedIdentityFilter{
+					ManagedIdentityIDs: inputList,
+				},
+			},
+			expectResult: &db.ManagedIdentitiesResult{
+				ManagedIdentities: []models.ManagedIdentity{sampleManagedIdentity},
+			},
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockCaller := auth.NewMockCaller(t)
+
+			mockManagedIdentities.On("GetManagedIdentities", mock.Anything, test.dbInput).Return(test.expectResult, nil)
+
+			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError)
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+			}
+
+			// Every identity must be returned even when the page size is capped.
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{MaxPageSize: 1})
+
+			result, err := service.GetManagedIdentitiesByIDs(auth.WithCaller(ctx, mockCaller), test.inputIDList)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectResult.ManagedIdentities, result)
+		})
+	}
+}
+
+func TestGetPaginatedManagedIdentitiesByIDs(t *testing.T) {
+	// More IDs than fit in a single page
+	idList := []string{"identity-1", "identity-2", "identity-3"}
+
+	firstPage := []models.ManagedIdentity{}
+	for _, id := range idList[:2] {
+		firstPage = append(firstPage, models.ManagedIdentity{
+			Metadata: models.ResourceMetadata{
+				ID: id,
+			},
+			GroupID:      "some-group-id",
+			ResourcePath: "some-group/" + id,
+		})
+	}
+
+	// Identities spread across groups the caller has mixed access to
+	mixedGroupIdentities := []models.ManagedIdentity{
+		{
+			Metadata:     models.ResourceMetadata{ID: "identity-1"},
+			ResourcePath: "allowed-group/identity-1",
+		},
+		{
+			Metadata:     models.ResourceMetadata{ID: "identity-2"},
+			ResourcePath: "forbidden-group/identity-2",
+		},
+		{
+			Metadata:     models.ResourceMetadata{ID: "identity-3"},
+			ResourcePath: "allowed-group/identity-3",
+		},
+	}
+
+	sort := db.ManagedIdentitySortableFieldUpdatedAtAsc
+
+	type testCase struct {
+		authError error
+		// groupAuthErrors are returned in order when each group is authorized separately
+		groupAuthErrors []error
+		input           *GetPaginatedManagedIdentitiesByIDsInput
+		// lookupResult is returned when the identities are looked up to be authorized before paginating
+		lookupResult      *db.ManagedIdentitiesResult
+		dbInput           *db.GetManagedIdentitiesInput
+		dbResult          *db.ManagedIdentitiesResult
+		expectIdentityIDs []string
+		name              string
+		expectErrorCode   errors.CodeType
+		expectTotalCount  int32
+	}
+
+	testCases := []testCase{
+		{
+			name: "return the first page of managed identities",
+			input: &GetPaginatedManagedIdentitiesByIDsInput{
+				Sort:              &sort,
+				PaginationOptions: &pagination.Options{First: ptr.Int32(2)},
+				IDs:               idList,
+			},
+			dbInput: &db.GetManagedIdentitiesInput{
+				Sort:              &sort,
+				PaginationOptions: &pagination.Options{First: ptr.Int32(2)},
+				Filter: &db.ManagedIdentityFilter{
+					ManagedIdentityIDs: idList,
+				},
+			},
+			dbResult: &db.ManagedIdentitiesResult{
+				PageInfo: &pagination.PageInfo{
+					TotalCount:  3,
+					HasNextPage: true,
+				},
+				ManagedIdentities: firstPage,
+			},
+		},
+		{
+			name: "no managed identities match the IDs",
+			input: &GetPaginatedManagedIdentitiesByIDsInput{
+				PaginationOptions: &pagination.Options{First: ptr.Int32(2)},
+				IDs:               idList,
+			},
+			dbInput: &db.GetManagedIdentitiesInput{
+				PaginationOptions: &pagination.Options{First: ptr.Int32(2)},
+				Filter: &db.ManagedIdentityFilter{
+					ManagedIdentityIDs: idList,
+				},
+			},
+			dbResult: &db.ManagedIdentitiesResult{
+				PageInfo:          &pagination.PageInfo{},
+				ManagedIdentities: []models.ManagedIdentity{},
+			},
+		},
+		{
+			name: "subject does not have access to group resource",
+			input: &GetPaginatedManagedIdentitiesByIDsInput{
+				PaginationOptions: &pagination.Options{First: ptr.Int32(2)},
+				IDs:               idList,
+			},
+			dbInput: &db.GetManagedIdentitiesInput{
+				PaginationOptions: &pagination.Options{First: ptr.Int32(2)},
+				Filter: &db.ManagedIdentityFilter{
+					ManagedIdentityIDs: idList,
+				},
+			},
+			dbResult: &db.ManagedIdentitiesResult{
+				PageInfo: &pagination.PageInfo{
+					TotalCount:  3,
+					HasNextPage: true,
+				},
+				ManagedIdentities: firstPage,
+			},
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name: "mixed access fails without skipping unauthorized identities",
+			input: &GetPaginatedManagedIdentitiesByIDsInput{
+				IDs: idList,
+			},
+			dbInput: &db.GetManagedIdentitiesInput{
+				Filter: &db.ManagedIdentityFilter{
+					ManagedIdentityIDs: idList,
+				},
+			},
+			dbResult: &db.ManagedIdentitiesResult{
+				PageInfo:          &pagination.PageInfo{TotalCount: 3},
+				ManagedIdentities: mixedGroupIdentities,
+			},
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name: "mixed access filters out identities in groups the caller cannot access",
+			input: &GetPaginatedManagedIdentitiesByIDsInput{
+				PaginationOptions: &pagination.Options{First: ptr.Int32(1)},
+				IDs:               idList,
+				SkipUnauthorized:  true,
+			},
+			lookupResult: &db.ManagedIdentitiesResult{
+				PageInfo:          &pagination.PageInfo{TotalCount: 3},
+				ManagedIdentities: mixedGroupIdentities,
+			},
+			// The groups are authorized together first, then one check per distinct group in the order they first appear.
+			groupAuthErrors: []error{
+				errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+				nil,
+				errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			},
+			dbInput: &db.GetManagedIdentitiesInput{
+				PaginationOptions: &pagination.Options{First: ptr.Int32(1)},
+				Filter: &db.ManagedIdentityFilter{
+					ManagedIdentityIDs: []string{"identity-1", "identity-3"},
+				},
+			},
+			dbResult: &db.ManagedIdentitiesResult{
+				PageInfo: &pagination.PageInfo{
+					TotalCount:  2,
+					HasNextPage: true,
+				},
+				ManagedIdentities: mixedGroupIdentities[:1],
+			},
+			expectIdentityIDs: []string{"identity-1"},
+			expectTotalCount:  2,
+		},
+		{
+			name: "identities in groups the caller isn't a member of are skipped when skipping unauthorized identities",
+			input: &GetPaginatedManagedIdentitiesByIDsInput{
+				IDs:              idList,
+				SkipUnauthorized: true,
+			},
+			lookupResult: &db.ManagedIdentitiesResult{
+				PageInfo:          &pagination.PageInfo{TotalCount: 3},
+				ManagedIdentities: mixedGroupIdentities,
+			},
+			// Callers without any membership in a group get not found instead of forbidden.
+			groupAuthErrors: []error{
+				errors.New("Not found", errors.WithErrorCode(errors.ENotFound)),
+				nil,
+				errors.New("Not found", errors.WithErrorCode(errors.ENotFound)),
+			},
+			dbInput: &db.GetManagedIdentitiesInput{
+				Filter: &db.ManagedIdentityFilter{
+					ManagedIdentityIDs: []string{"identity-1", "identity-3"},
+				},
+			},
+			dbResult: &db.ManagedIdentitiesResult{
+				PageInfo:          &pagination.PageInfo{TotalCount: 2},
+				ManagedIdentities: []models.ManagedIdentity{mixedGroupIdentities[0], mixedGroupIdentities[2]},
+			},
+			expectIdentityIDs: []string{"identity-1", "identity-3"},
+			expectTotalCount:  2,
+		},
+		{
+			name: "access to every group only needs a single check when skipping unauthorized identities",
+			input: &GetPaginatedManagedIdentitiesByIDsInput{
+				PaginationOptions: &pagination.Options{First: ptr.Int32(2)},
+				IDs:               idList,
+				SkipUnauthorized:  true,
+			},
+			lookupResult: &db.ManagedIdentitiesResult{
+				PageInfo:          &pagination.PageInfo{TotalCount: 3},
+				ManagedIdentities: mixedGroupIdentities,
+			},
+			groupAuthErrors: []error{nil},
+			dbInput: &db.GetManagedIdentitiesInput{
+				PaginationOptions: &pagination.Options{First: ptr.Int32(2)},
+				Filter: &db.ManagedIdentityFilter{
+					ManagedIdentityIDs: idList,
+				},
+			},
+			dbResult: &db.ManagedIdentitiesResult{
+				PageInfo: &pagination.PageInfo{
+					TotalCount:  3,
+					HasNextPage: true,
+				},
+				ManagedIdentities: mixedGroupIdentities[:2],
+			},
+			expectIdentityIDs: []string{"identity-1", "identity-2"},
+			expectTotalCount:  3,
+		},
+		{
+			name: "no access to any group returns no identities when skipping unauthorized identities",
+			input: &GetPaginatedManagedIdentitiesByIDsInput{
+				IDs:              idList,
+				SkipUnauthorized: true,
+			},
+			lookupResult: &db.ManagedIdentitiesResult{
+				PageInfo:          &pagination.PageInfo{TotalCount: 3},
+				ManagedIdentities: mixedGroupIdentities,
+			},
+			groupAuthErrors: []error{
+				errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+				errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+				errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			},
+			expectIdentityIDs: []string{},
+			expectTotalCount:  0,
+		},
+		{
+			name: "errors other than forbidden are returned when skipping unauthorized identities",
+			input: &GetPaginatedManagedIdentitiesByIDsInput{
+				IDs:              idList,
+				SkipUnauthorized: true,
+			},
+			lookupResult: &db.ManagedIdentitiesResult{
+				PageInfo:          &pagination.PageInfo{TotalCount: 3},
+				ManagedIdentities: mixedGroupIdentities,
+			},
+			groupAuthErrors: []error{
+				errors.New("Internal error", errors.WithErrorCode(errors.EInternal)),
+			},
+			expectErrorCode: errors.EInternal,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockCaller := auth.NewMockCaller(t)
+
+			if test.lookupResult != nil {
+				mockManagedIdentities.On("GetManagedIdentities", mock.Anything, &db.GetManagedIdentitiesInput{
+					Filter: &db.ManagedIdentityFilter{
+						ManagedIdentityIDs: test.input.IDs,
+					},
+				}).Return(test.lookupResult, nil)
+			}
+
+			if test.dbInput != nil {
+				mockManagedIdentities.On("GetManagedIdentities", mock.Anything, test.dbInput).Return(test.dbResult, nil)
+			}
+
+			if test.groupAuthErrors != nil {
+				for _, authErr := range test.groupAuthErrors {
+					mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(authErr).Once()
+				}
+			} else {
+				mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError).Maybe()
+			}
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+			}
+
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
+
+			result, err := service.GetPaginatedManagedIdentitiesByIDs(auth.WithCaller(ctx, mockCaller), test.input)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectIdentityIDs != nil {
+				actualIDs := []string{}
+				for _, identity := range result.ManagedIdentities {
+					actualIDs = append(actualIDs, identity.Metadata.ID)
+				}
+				assert.Equal(t, test.expectIdentityIDs, actualIDs)
+				assert.Equal(t, test.expectTotalCount, result.PageInfo.TotalCount)
+				return
+			}
+
+			assert.Equal(t, test.dbResult, result)
+		})
+	}
+}
+
+func TestUpdateManagedIdentity(t *testing.T) {
+	sampleManagedIdentity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-id",
+		},
+		Name:         "a-managed-identity",
+		ResourcePath: "some/resource/path",
+		Description:  "old-description",
+		GroupID:      "some-group-id",
+		Data:         []byte("this is old data"),
+		Type:         models.ManagedIdentityAWSFederated,
+	}
+
+	activityEventInput := &activityevent.CreateActivityEventInput{
+		NamespacePath: ptr.String(sampleManagedIdentity.GetGroupPath()),
+		Action:        models.ActionUpdate,
+		TargetType:    models.TargetManagedIdentity,
+		TargetID:      sampleManagedIdentity.Metadata.ID,
+	}
+
+	type testCase struct {
+		authError                   error
+		setManagedIdentityDataError error
+		existingManagedIdentity     *models.ManagedIdentity
+		expectManagedIdentity       *models.ManagedIdentity
+		input                       *UpdateManagedIdentityInput
+		name                        string
+		expectErrorDetails          map[string]string
+		expectErrorCode             errors.CodeType
+		expectError                 string
+	}
+
+	testCases := []testCase{
+		{
+			name: "positive: successfully update a managed identity",
+			input: &UpdateManagedIdentityInput{
+				ID:          "some-managed-identity-id",
+				Description: "This is an updated description",
+				Data:        []byte("this is new data"),
+			},
+			existingManagedIdentity: sampleManagedIdentity,
+			expectManagedIdentity: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID: "some-managed-identity-id",
+				},
+				Name:         "a-managed-identity",
+				ResourcePath: "some/resource/path",
+				Description:  "This is an updated description",
+				GroupID:      "some-group-id",
+				Data:         []byte("this is new data"),
+				Type:         models.ManagedIdentityAWSFederated,
+			},
+		},
+		{
+			name: "negative: set managed identity data fails validation",
+			input: &UpdateManagedIdentityInput{
+				ID:          "some-managed-identity-id",
+				Description: "This is an updated description",
+				Data:        []byte("this is new data"),
+			},
+			setManagedIdentityDataError: errors.New("host invalid", errors.WithErrorCode(errors.EInvalid)),
+			expectErrorCode:             errors.EInvalid,
+			expectError:                 "failed to set managed identity data: host invalid",
+			expectErrorDetails:          map[string]string{"data": "host invalid"},
+			existingManagedIdentity:     sampleManagedIdentity,
+		},
+		{
+			name: "negative: updated description is too long",
+			input: &UpdateManagedIdentityInput{
+				ID:          "some-managed-identity-id",
+				Description: strings.Repeat("really long description", 20),
+				Data:        []byte("this is new data"),
+			},
+			expectErrorCode:         errors.EInvalid,
+			expectError:             "invalid description, cannot be greater than 100 characters",
+			expectErrorDetails:      map[string]string{"description": "invalid description, cannot be greater than 100 characters"},
+			existingManagedIdentity: sampleManagedIdentity,
+		},
+		{
+			name: "negative: managed identity being updated doesn't exist",
+			input: &UpdateManagedIdentityInput{
+				ID:          "non-existent-id",
+				Description: "This is an updated description",
+				Data:        []byte("this is new data"),
+			},
+			expectErrorCode: errors.ENotFound,
+			expectError:     "managed identity with ID non-existent-id not found",
+		},
+		{
+			name: "negative: attempting to update a managed identity alias",
+			input: &UpdateManagedIdentityInput{
+				ID:          "some-managed-identity-id",
+				Description: "This is an updated description",
+				Data:        []byte("this is new data"),
+			},
+			existingManagedIdentity: &models.ManagedIdentity{
+				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
+			},
+			expectErrorCode: errors.EInvalid,
+			expectError:     "Only a source managed identity can be updated, not an alias",
+		},
+		{
+			name: "negative: subject does not have access to group",
+			input: &UpdateManagedIdentityInput{
+				ID:          "some-managed-identity-id",
+				Description: "This is an updated description",
+				Data:        []byte("this is new data"),
+			},
+			existingManagedIdentity: sampleManagedIdentity,
+			authError:               errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:         errors.EForbidden,
+			expectError:             "Forbidden",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockDelegate := NewMockDelegate(t)
+			mockCaller := auth.NewMockCaller(t)
+
+			if test.expectErrorCode == "" || test.setManagedIdentityDataError != nil {
+				mockDelegate.On("SetManagedIdentityData", mock.Anything, test.existingManagedIdentity, test.input.Data).Return(test.setManagedIdentityDataError)
+			}
+
+			if test.expectErrorCode == "" {
+				mockManagedIdentities.On("UpdateManagedIdentity", mock.Anything, test.existingManagedIdentity).Return(test.expectManagedIdentity, nil)
+
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
+
+				mockCaller.On("GetSubject").Return("mockSubject")
+
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+			}
+
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.input.ID).Return(test.existingManagedIdentity, nil)
+
+			if test.existingManagedIdentity != nil && !test.existingManagedIdentity.IsAlias() {
+				mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateManagedIdentityPermission, mock.Anything).Return(test.authError)
+			}
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+				Transactions:      mockTransactions,
+			}
+
+			delegateMap := map[models.ManagedIdentityType]Delegate{
+				models.ManagedIdentityAWSFederated:     mockDelegate,
+				models.ManagedIdentityAzureFederated:   mockDelegate,
+				models.ManagedIdentityTharsisFederated: mockDelegate,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, nil, delegateMap, nil, nil, mockActivityEvents, ServiceOptions{})
+
+			identity, err := service.UpdateManagedIdentity(auth.WithCaller(ctx, mockCaller), test.input)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				assert.Equal(t, test.expectError, errors.ErrorMessage(err))
+				assert.Equal(t, test.expectErrorDetails, errors.ErrorDetails(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectManagedIdentity, identity)
+		})
+	}
+}
+
+func TestGetManagedIdentityAccessRules(t *testing.T) {
+	sampleManagedIdentity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-id",
+		},
+		Name:         "a-managed-identity",
+		ResourcePath: "some/resource/path",
+		Description:  "old-description",
+		GroupID:      "some-group-id",
+		Data:         []byte("this is old data"),
+		Type:         models.ManagedIdentityAWSFederated,
+	}
+
+	sampleAccessRules := []models.ManagedIdentityAccessRule{
+		{
+			Metadata: models.ResourceMetadata{
+				ID: "some-access-rule",
+			},
+			Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+			RunStage:                 models.JobPlanType,
+			ManagedIdentityID:        sampleManagedIdentity.Metadata.ID,
+			AllowedUserIDs:           []string{"user-id-1"},
+			AllowedServiceAccountIDs: []string{"service-account-id-1"},
+			AllowedTeamIDs:           []string{"team-id-1"},
+		},
+	}
+
+	type testCase struct {
+		authError         error
+		input             *models.ManagedIdentity
+		dbInput           *db.GetManagedIdentityAccessRulesInput
+		dbResult          *db.ManagedIdentityAccessRulesResult
+		name              string
+		expectErrorCode   errors.CodeType
+		expectAccessRules []models.ManagedIdentityAccessRule
+	}
+
+	testCases := []testCase{
+		{
+			name:  "positive: successfully return managed identity access rules",
+			input: sampleManagedIdentity,
+			dbInput: &db.GetManagedIdentityAccessRulesInput{
+				Filter: &db.ManagedIdentityAccessRuleFilter{
+					ManagedIdentityID: &sampleManagedIdentity.Metadata.ID,
+				},
+			},
+			expectAccessRules: sampleAccessRules,
+			dbResult: &db.ManagedIdentityAccessRulesResult{
+				ManagedIdentityAccessRules: sampleAccessRules,
+			},
+		},
+		{
+			name:  "negative: subject does have access to group resource",
+			input: sampleManagedIdentity,
+			dbInput: &db.GetManagedIdentityAccessRulesInput{
+				Filter: &db.ManagedIdentityAccessRuleFilter{
+					ManagedIdentityID: &sampleManagedIdentity.Metadata.ID,
+				},
+			},
+			authError: errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			dbResult: &db.ManagedIdentityAccessRulesResult{
+				ManagedIdentityAccessRules: sampleAccessRules,
+			},
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockCaller := auth.NewMockCaller(t)
+
+			mockManagedIdentities.On("GetManagedIdentityAccessRules", mock.Anything, test.dbInput).Return(test.dbResult, nil).Maybe()
+
+			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError)
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+			}
+
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
+
+			rules, err := service.GetManagedIdentityAccessRules(auth.WithCaller(ctx, mockCaller), test.input)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectAccessRules, rules)
+		})
+	}
+}
+
+func TestGetManagedIdentityAccessRulesByIDs(t *testing.T) {
 	sampleAccessRules := []models.ManagedIdentityAccessRule{
 		{
-			Metadata: models.ResourceMetadata{
-				ID: "an-access-rule-1",
+			Metadata: models.ResourceMetadata{
+				ID: "an-access-rule-1",
+			},
+			Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+			RunStage:          models.JobApplyType,
+			ManagedIdentityID: "some-managed-identity-id",
+			AllowedUserIDs:    []string{"some-user-1"},
+		},
+	}
+
+	sampleIdentitiesResult := &db.ManagedIdentitiesResult{
+		ManagedIdentities: []models.ManagedIdentity{
+			{
+				Metadata: models.ResourceMetadata{
+					ID: "some-managed-identity-id",
+				},
+				GroupID:      "some-group-id",
+				ResourcePath: "some-group/some-identity",
+			},
+		},
+	}
+
+	idList := []string{"access-rule-id-1", "access-rule-id-2"}
+
+	type testCase struct {
+		authError         error
+		name              string
+		expectErrorCode   errors.CodeType
+		inputIDList       []string
+		ruleDBInput       *db.GetManagedIdentityAccessRulesInput
+		identityDBInput   *db.GetManagedIdentitiesInput
+		dbResult          *db.ManagedIdentityAccessRulesResult
+		expectAccessRules []models.ManagedIdentityAccessRule
+	}
+
+	testCases := []testCase{
+		{
+			name:        "positive: successfully return managed identity access rules",
+			inputIDList: idList,
+			ruleDBInput: &db.GetManagedIdentityAccessRulesInput{
+				Filter: &db.ManagedIdentityAccessRuleFilter{
+					ManagedIdentityAccessRuleIDs: idList,
+				},
+			},
+			identityDBInput: &db.GetManagedIdentitiesInput{
+				Filter: &db.ManagedIdentityFilter{
+					ManagedIdentityIDs: []string{sampleAccessRules[0].ManagedIdentityID},
+				},
+			},
+			dbResult: &db.ManagedIdentityAccessRulesResult{
+				ManagedIdentityAccessRules: sampleAccessRules,
+			},
+			expectAccessRules: sampleAccessRules,
+		},
+		{
+			name:        "negative: subject does not have access to group resource",
+			inputIDList: idList,
+			ruleDBInput: &db.GetManagedIdentityAccessRulesInput{
+				Filter: &db.ManagedIdentityAccessRuleFilter{
+					ManagedIdentityAccessRuleIDs: idList,
+				},
+			},
+			identityDBInput: &db.GetManagedIdentitiesInput{
+				Filter: &db.ManagedIdentityFilter{
+					ManagedIdentityIDs: []string{sampleAccessRules[0].ManagedIdentityID},
+				},
+			},
+			dbResult: &db.ManagedIdentityAccessRulesResult{
+				ManagedIdentityAccessRules: sampleAccessRules,
+			},
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockCaller := auth.NewMockCaller(t)
+
+			mockManagedIdentities.On("GetManagedIdentityAccessRules", mock.Anything, test.ruleDBInput).Return(test.dbResult, nil)
+			mockManagedIdentities.On("GetManagedIdentities", mock.Anything, test.identityDBInput).Return(sampleIdentitiesResult, nil)
+
+			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError)
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+			}
+
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
+
+			rules, err := service.GetManagedIdentityAccessRulesByIDs(auth.WithCaller(ctx, mockCaller), test.inputIDList)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectAccessRules, rules)
+		})
+	}
+}
+
+func TestGetManagedIdentityAccessRule(t *testing.T) {
+	sampleManagedIdentity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-id",
+		},
+		GroupID: "some-group-id",
+		Type:    models.ManagedIdentityAWSFederated,
+	}
+
+	sampleAccessRule := &models.ManagedIdentityAccessRule{
+		Metadata: models.ResourceMetadata{
+			ID: "some-access-rule",
+		},
+		Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:          models.JobPlanType,
+		ManagedIdentityID: sampleManagedIdentity.Metadata.ID,
+		AllowedUserIDs:    []string{"user-id-1"},
+	}
+
+	type testCase struct {
+		authError        error
+		expectAccessRule *models.ManagedIdentityAccessRule
+		searchID         string
+		name             string
+		expectErrorCode  errors.CodeType
+	}
+
+	testCases := []testCase{
+		{
+			name:             "positive: successfully return a managed identity access rule",
+			expectAccessRule: sampleAccessRule,
+			searchID:         sampleAccessRule.Metadata.ID,
+		},
+		{
+			name:            "negative: access rule doesn't exist",
+			expectErrorCode: errors.ENotFound,
+			searchID:        "unknown-access-rule-id",
+		},
+		{
+			name:             "negative: subject does not have access to group resource",
+			searchID:         sampleAccessRule.Metadata.ID,
+			expectAccessRule: sampleAccessRule,
+			authError:        errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:  errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockCaller := auth.NewMockCaller(t)
+
+			mockManagedIdentities.On("GetManagedIdentityAccessRule", mock.Anything, test.searchID).Return(test.expectAccessRule, nil)
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, sampleManagedIdentity.Metadata.ID).Return(sampleManagedIdentity, nil).Maybe()
+
+			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError).Maybe()
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+			}
+
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
+
+			rule, err := service.GetManagedIdentityAccessRule(auth.WithCaller(ctx, mockCaller), test.searchID)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectAccessRule, rule)
+		})
+	}
+}
+
+func TestGetManagedIdentityAccessRuleWithPrincipalNames(t *testing.T) {
+	sampleManagedIdentity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-id",
+		},
+		GroupID: "some-group-id",
+		Type:    models.ManagedIdentityAWSFederated,
+	}
+
+	sampleAccessRule := &models.ManagedIdentityAccessRule{
+		Metadata: models.ResourceMetadata{
+			ID: "some-access-rule",
+		},
+		Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:                 models.JobPlanType,
+		ManagedIdentityID:        sampleManagedIdentity.Metadata.ID,
+		AllowedUserIDs:           []string{"user-id-1", "deleted-user-id", "user-id-2"},
+		AllowedServiceAccountIDs: []string{"sa-id-1"},
+		AllowedTeamIDs:           []string{"team-id-1"},
+	}
+
+	type testCase struct {
+		authError       error
+		name            string
+		expectErrorCode errors.CodeType
+		expectResponse  *ManagedIdentityAccessRuleWithPrincipalNames
+	}
+
+	testCases := []testCase{
+		{
+			name: "principal names are resolved in the same order as the IDs",
+			expectResponse: &ManagedIdentityAccessRuleWithPrincipalNames{
+				AccessRule:                 sampleAccessRule,
+				AllowedUserNames:           []string{"user1", "", "user2"},
+				AllowedServiceAccountPaths: []string{"some-group/sa1"},
+				AllowedTeamNames:           []string{"team1"},
+			},
+		},
+		{
+			name:            "subject does not have access to group resource",
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockUsers := db.NewMockUsers(t)
+			mockServiceAccounts := db.NewMockServiceAccounts(t)
+			mockTeams := db.NewMockTeams(t)
+			mockCaller := auth.NewMockCaller(t)
+
+			mockManagedIdentities.On("GetManagedIdentityAccessRule", mock.Anything, sampleAccessRule.Metadata.ID).Return(sampleAccessRule, nil)
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, sampleManagedIdentity.Metadata.ID).Return(sampleManagedIdentity, nil)
+
+			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError)
+
+			if test.authError == nil {
+				mockUsers.On("GetUsers", mock.Anything, &db.GetUsersInput{
+					Filter: &db.UserFilter{UserIDs: sampleAccessRule.AllowedUserIDs},
+				}).Return(&db.UsersResult{
+					Users: []models.User{
+						{Metadata: models.ResourceMetadata{ID: "user-id-2"}, Username: "user2"},
+						{Metadata: models.ResourceMetadata{ID: "user-id-1"}, Username: "user1"},
+					},
+				}, nil)
+
+				mockServiceAccounts.On("GetServiceAccountsByIDs", mock.Anything, sampleAccessRule.AllowedServiceAccountIDs).Return(map[string]*models.ServiceAccount{
+					"sa-id-1": {Metadata: models.ResourceMetadata{ID: "sa-id-1"}, ResourcePath: "some-group/sa1"},
+				}, nil)
+
+				mockTeams.On("GetTeams", mock.Anything, &db.GetTeamsInput{
+					Filter: &db.TeamFilter{TeamIDs: sampleAccessRule.AllowedTeamIDs},
+				}).Return(&db.TeamsResult{
+					Teams: []models.Team{
+						{Metadata: models.ResourceMetadata{ID: "team-id-1"}, Name: "team1"},
+					},
+				}, nil)
+			}
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+				Users:             mockUsers,
+				ServiceAccounts:   mockServiceAccounts,
+				Teams:             mockTeams,
+			}
+
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
+
+			response, err := service.GetManagedIdentityAccessRuleWithPrincipalNames(auth.WithCaller(ctx, mockCaller), sampleAccessRule.Metadata.ID)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectResponse, response)
+		})
+	}
+}
+
+func TestCreateManagedIdentityAccessRule(t *testing.T) {
+	sampleManagedIdentity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-id",
+		},
+		ResourcePath: "some/resource/path",
+		GroupID:      "some-group-id",
+		Type:         models.ManagedIdentityAWSFederated,
+	}
+
+	sampleAccessRule := &models.ManagedIdentityAccessRule{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-access-rule-id",
+		},
+		Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:                 models.JobApplyType,
+		ManagedIdentityID:        sampleManagedIdentity.Metadata.ID,
+		AllowedUserIDs:           []string{"user-id-1"},
+		AllowedServiceAccountIDs: []string{"service-account-id-1"},
+		AllowedTeamIDs:           []string{"team-id-1"},
+	}
+
+	sampleServiceAccount := &models.ServiceAccount{
+		Metadata: models.ResourceMetadata{
+			ID: "service-account-id-1",
+		},
+		ResourcePath: "some/resource/service-account",
+	}
+
+	activityEventInput := &activityevent.CreateActivityEventInput{
+		NamespacePath: ptr.String(sampleManagedIdentity.GetGroupPath()),
+		Action:        models.ActionCreate,
+		TargetType:    models.TargetManagedIdentityAccessRule,
+		TargetID:      sampleAccessRule.Metadata.ID,
+	}
+
+	type testCase struct {
+		authError               error
+		expectAccessRule        *models.ManagedIdentityAccessRule
+		existingServiceAccount  *models.ServiceAccount
+		existingManagedIdentity *models.ManagedIdentity
+		input                   *models.ManagedIdentityAccessRule
+		name                    string
+		expectErrorCode         errors.CodeType
+		limit                   int
+		injectRulesPerMI        int32
+		exceedsLimit            bool
+		missingTeam             bool
+		missingUser             bool
+	}
+
+	testCases := []testCase{
+		{
+			name:                    "positive: successfully create a managed identity access rule",
+			existingManagedIdentity: sampleManagedIdentity,
+			existingServiceAccount:  sampleServiceAccount,
+			expectAccessRule:        sampleAccessRule,
+			input:                   sampleAccessRule,
+			limit:                   5,
+			injectRulesPerMI:        5,
+		},
+		{
+			name:                    "negative: allowed service account doesn't exist",
+			existingManagedIdentity: sampleManagedIdentity,
+			input:                   sampleAccessRule,
+			expectErrorCode:         errors.ENotFound,
+		},
+		{
+			name:                    "negative: allowed team doesn't exist",
+			existingManagedIdentity: sampleManagedIdentity,
+			existingServiceAccount:  sampleServiceAccount,
+			input:                   sampleAccessRule,
+			missingTeam:             true,
+			expectErrorCode:         errors.ENotFound,
+		},
+		{
+			name:                    "negative: allowed user doesn't exist",
+			existingManagedIdentity: sampleManagedIdentity,
+			existingServiceAccount:  sampleServiceAccount,
+			input:                   sampleAccessRule,
+			missingUser:             true,
+			expectErrorCode:         errors.ENotFound,
+		},
+		{
+			name:            "negative: managed identity associated with rules doesn't exist",
+			input:           sampleAccessRule,
+			expectErrorCode: errors.ENotFound,
+		},
+		{
+			name:                    "negative: service account is out of group scope",
+			input:                   sampleAccessRule,
+			existingManagedIdentity: sampleManagedIdentity,
+			existingServiceAccount: &models.ServiceAccount{
+				Metadata: models.ResourceMetadata{
+					ID: "service-account-id-1",
+				},
+				ResourcePath: "out/of/scope/service-account",
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:  "negative: attempting to create access rule for a managed identity alias",
+			input: sampleAccessRule,
+			existingManagedIdentity: &models.ManagedIdentity{
+				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:                    "negative: subject does not have owner role for group",
+			input:                   sampleAccessRule,
+			existingManagedIdentity: sampleManagedIdentity,
+			authError:               errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:         errors.EForbidden,
+		},
+		{
+			name:                    "exceeds limit",
+			existingManagedIdentity: sampleManagedIdentity,
+			existingServiceAccount:  sampleServiceAccount,
+			expectAccessRule:        sampleAccessRule,
+			input:                   sampleAccessRule,
+			limit:                   5,
+			injectRulesPerMI:        6,
+			exceedsLimit:            true,
+			expectErrorCode:         errors.EInvalid,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockServiceAccounts := db.NewMockServiceAccounts(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockCaller := auth.NewMockCaller(t)
+			mockResourceLimits := db.NewMockResourceLimits(t)
+			mockResourceLimits.On("GetResourceLimit", mock.Anything, string(limits.ResourceLimitAllowedPrincipalsPerManagedIdentityAccessRule)).
+				Return(&models.ResourceLimit{Value: 100}, nil).Maybe()
+
+			if (test.expectErrorCode == "") || test.exceedsLimit {
+				mockManagedIdentities.On("CreateManagedIdentityAccessRule", mock.Anything, test.input).Return(test.expectAccessRule, nil)
+
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				if !test.exceedsLimit {
+					mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+					mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
+				}
+			}
+
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.input.ManagedIdentityID).Return(test.existingManagedIdentity, nil)
+
+			serviceAccountsResult := &db.ServiceAccountsResult{ServiceAccounts: []models.ServiceAccount{}}
+			if test.existingServiceAccount != nil {
+				serviceAccountsResult.ServiceAccounts = append(serviceAccountsResult.ServiceAccounts, *test.existingServiceAccount)
+			}
+
+			mockServiceAccounts.On("GetServiceAccountsByIDs", mock.Anything, sampleAccessRule.AllowedServiceAccountIDs).
+				Return(buildServiceAccountMap(serviceAccountsResult.ServiceAccounts), nil).Maybe()
+
+			if test.existingManagedIdentity != nil && !test.existingManagedIdentity.IsAlias() {
+				mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateManagedIdentityPermission, mock.Anything).Return(test.authError)
+			}
+
+			// Called inside transaction to check resource limits.
+			if test.limit > 0 {
+				mockManagedIdentities.On("GetManagedIdentityAccessRules", mock.Anything, &db.GetManagedIdentityAccessRulesInput{
+					Filter: &db.ManagedIdentityAccessRuleFilter{
+						ManagedIdentityID: &sampleManagedIdentity.Metadata.ID,
+					},
+					PaginationOptions: &pagination.Options{
+						First: ptr.Int32(0),
+					},
+				}).Return(func(ctx context.Context, input *db.GetManagedIdentityAccessRulesInput) *db.ManagedIdentityAccessRulesResult {
+					_ = ctx
+					_ = input
+
+					return &db.ManagedIdentityAccessRulesResult{
+						PageInfo: &pagination.PageInfo{
+							TotalCount: test.injectRulesPerMI,
+						},
+					}
+				}, nil)
+
+				mockResourceLimits.On("GetResourceLimit", mock.Anything, string(limits.ResourceLimitManagedIdentityAccessRulesPerManagedIdentity)).
+					Return(&models.ResourceLimit{Value: test.limit}, nil)
+			}
+
+			existingTeamIDs := sampleAccessRule.AllowedTeamIDs
+			if test.missingTeam {
+				existingTeamIDs = nil
+			}
+
+			existingUserIDs := sampleAccessRule.AllowedUserIDs
+			if test.missingUser {
+				existingUserIDs = nil
+			}
+
+			mockTeams, mockUsers := buildMockTeamsAndUsers(t, existingTeamIDs, existingUserIDs)
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+				ServiceAccounts:   mockServiceAccounts,
+				Transactions:      mockTransactions,
+				ResourceLimits:    mockResourceLimits,
+				Teams:             mockTeams,
+				Users:             mockUsers,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, limits.NewLimitChecker(dbClient), nil, nil, nil, mockActivityEvents, ServiceOptions{})
+
+			accessRule, err := service.CreateManagedIdentityAccessRule(auth.WithCaller(ctx, mockCaller), test.input)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				if test.missingTeam {
+					assert.Equal(t, "team with ID team-id-1 not found", errors.ErrorMessage(err))
+				}
+				if test.missingUser {
+					assert.Equal(t, "user with ID user-id-1 not found", errors.ErrorMessage(err))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectAccessRule, accessRule)
+		})
+	}
+}
+
+func TestReplaceManagedIdentityAccessRules(t *testing.T) {
+	sampleManagedIdentity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-id",
+		},
+		ResourcePath: "some/resource/path",
+		GroupID:      "some-group-id",
+		Type:         models.ManagedIdentityAWSFederated,
+	}
+
+	existingRules := []models.ManagedIdentityAccessRule{
+		{
+			Metadata:          models.ResourceMetadata{ID: "existing-rule-1"},
+			Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+			RunStage:          models.JobPlanType,
+			ManagedIdentityID: sampleManagedIdentity.Metadata.ID,
+		},
+		{
+			Metadata:          models.ResourceMetadata{ID: "existing-rule-2"},
+			Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+			RunStage:          models.JobApplyType,
+			ManagedIdentityID: sampleManagedIdentity.Metadata.ID,
+		},
+	}
+
+	validRules := []*models.ManagedIdentityAccessRule{
+		{
+			Type:           models.ManagedIdentityAccessRuleEligiblePrincipals,
+			RunStage:       models.JobPlanType,
+			AllowedUserIDs: []string{"user-id-1"},
+		},
+		{
+			Type:           models.ManagedIdentityAccessRuleEligiblePrincipals,
+			RunStage:       models.JobApplyType,
+			AllowedTeamIDs: []string{"team-id-1"},
+		},
+	}
+
+	type testCase struct {
+		authError               error
+		existingManagedIdentity *models.ManagedIdentity
+		name                    string
+		expectErrorCode         errors.CodeType
+		input                   []*models.ManagedIdentityAccessRule
+		limit                   int
+		expectReplaced          bool
+	}
+
+	testCases := []testCase{
+		{
+			name:                    "successfully replace all access rules",
+			existingManagedIdentity: sampleManagedIdentity,
+			input:                   validRules,
+			limit:                   5,
+			expectReplaced:          true,
+		},
+		{
+			name:                    "successfully remove all access rules",
+			existingManagedIdentity: sampleManagedIdentity,
+			input:                   []*models.ManagedIdentityAccessRule{},
+			limit:                   5,
+			expectReplaced:          true,
+		},
+		{
+			name:                    "invalid rule prevents any changes",
+			existingManagedIdentity: sampleManagedIdentity,
+			input: []*models.ManagedIdentityAccessRule{
+				validRules[0],
+				{
+					Type:     models.ManagedIdentityAccessRuleModuleAttestation,
+					RunStage: models.JobApplyType,
+				},
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:                    "rule for a different managed identity prevents any changes",
+			existingManagedIdentity: sampleManagedIdentity,
+			input: []*models.ManagedIdentityAccessRule{
+				{
+					Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+					RunStage:          models.JobApplyType,
+					ManagedIdentityID: "another-managed-identity-id",
+				},
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:                    "exceeding the limit rolls back the transaction",
+			existingManagedIdentity: sampleManagedIdentity,
+			input:                   validRules,
+			limit:                   1,
+			expectErrorCode:         errors.EInvalid,
+		},
+		{
+			name:  "cannot replace access rules for an alias",
+			input: validRules,
+			existingManagedIdentity: &models.ManagedIdentity{
+				Metadata:      models.ResourceMetadata{ID: sampleManagedIdentity.Metadata.ID},
+				AliasSourceID: ptr.String("source-managed-identity-id"),
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:                    "managed identity not found",
+			input:                   validRules,
+			existingManagedIdentity: nil,
+			expectErrorCode:         errors.ENotFound,
+		},
+		{
+			name:                    "subject does not have permission",
+			existingManagedIdentity: sampleManagedIdentity,
+			input:                   validRules,
+			authError:               errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:         errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockCaller := auth.NewMockCaller(t)
+			mockResourceLimits := db.NewMockResourceLimits(t)
+			mockResourceLimits.On("GetResourceLimit", mock.Anything, string(limits.ResourceLimitAllowedPrincipalsPerManagedIdentityAccessRule)).
+				Return(&models.ResourceLimit{Value: 100}, nil).Maybe()
+
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, sampleManagedIdentity.Metadata.ID).Return(test.existingManagedIdentity, nil)
+
+			if test.existingManagedIdentity != nil && !test.existingManagedIdentity.IsAlias() {
+				mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateManagedIdentityPermission, mock.Anything).Return(test.authError)
+			}
+
+			if test.limit > 0 {
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+
+				mockManagedIdentities.On("GetManagedIdentityAccessRules", mock.Anything, &db.GetManagedIdentityAccessRulesInput{
+					Filter: &db.ManagedIdentityAccessRuleFilter{
+						ManagedIdentityID: &sampleManagedIdentity.Metadata.ID,
+					},
+				}).Return(&db.ManagedIdentityAccessRulesResult{ManagedIdentityAccessRules: existingRules}, nil)
+
+				for i := range existingRules {
+					mockManagedIdentities.On("DeleteManagedIdentityAccessRule", mock.Anything, &existingRules[i]).Return(nil).Once()
+				}
+
+				for _, rule := range test.input {
+					runStage := rule.RunStage
+					mockManagedIdentities.On("CreateManagedIdentityAccessRule", mock.Anything, mock.MatchedBy(func(r *models.ManagedIdentityAccessRule) bool {
+						return r.ManagedIdentityID == sampleManagedIdentity.Metadata.ID && r.RunStage == runStage
+					})).Return(&models.ManagedIdentityAccessRule{}, nil).Once()
+				}
+
+				mockResourceLimits.On("GetResourceLimit", mock.Anything, string(limits.ResourceLimitManagedIdentityAccessRulesPerManagedIdentity)).
+					Return(&models.ResourceLimit{Value: test.limit}, nil)
+			}
+
+			if test.expectReplaced {
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, &activityevent.CreateActivityEventInput{
+					NamespacePath: ptr.String(sampleManagedIdentity.GetGroupPath()),
+					Action:        models.ActionUpdate,
+					TargetType:    models.TargetManagedIdentity,
+					TargetID:      sampleManagedIdentity.Metadata.ID,
+					Payload: &models.ActivityEventReplaceManagedIdentityAccessRulesPayload{
+						RemovedRuleCount: int32(len(existingRules)),
+						AddedRuleCount:   int32(len(test.input)),
+					},
+				}).Return(&models.ActivityEvent{}, nil)
+
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+			}
+
+			mockTeams, mockUsers := buildMockTeamsAndUsers(t, []string{"team-id-1"}, []string{"user-id-1"})
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+				Transactions:      mockTransactions,
+				ResourceLimits:    mockResourceLimits,
+				Teams:             mockTeams,
+				Users:             mockUsers,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, limits.NewLimitChecker(dbClient), nil, nil, nil, mockActivityEvents, ServiceOptions{})
+
+			err := service.ReplaceManagedIdentityAccessRules(auth.WithCaller(ctx, mockCaller), sampleManagedIdentity.Metadata.ID, test.input)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestUpdateManagedIdentityAccessRule(t *testing.T) {
+	sampleManagedIdentity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-id",
+		},
+		ResourcePath: "some/resource/path",
+		GroupID:      "some-group-id",
+		Type:         models.ManagedIdentityAWSFederated,
+	}
+
+	sampleAccessRule := &models.ManagedIdentityAccessRule{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-access-rule-id",
+		},
+		Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:                 models.JobApplyType,
+		ManagedIdentityID:        sampleManagedIdentity.Metadata.ID,
+		AllowedUserIDs:           []string{"user-id-1"},
+		AllowedServiceAccountIDs: []string{"service-account-id-1"},
+		AllowedTeamIDs:           []string{"team-id-1"},
+	}
+
+	sampleServiceAccount := &models.ServiceAccount{
+		Metadata: models.ResourceMetadata{
+			ID: "service-account-id-1",
+		},
+		ResourcePath: "some/resource/service-account",
+	}
+
+	activityEventInput := &activityevent.CreateActivityEventInput{
+		NamespacePath: ptr.String(sampleManagedIdentity.GetGroupPath()),
+		Action:        models.ActionUpdate,
+		TargetType:    models.TargetManagedIdentityAccessRule,
+		TargetID:      sampleAccessRule.Metadata.ID,
+	}
+
+	type testCase struct {
+		authError               error
+		expectAccessRule        *models.ManagedIdentityAccessRule
+		existingServiceAccount  *models.ServiceAccount
+		existingManagedIdentity *models.ManagedIdentity
+		input                   *models.ManagedIdentityAccessRule
+		name                    string
+		expectErrorCode         errors.CodeType
+	}
+
+	testCases := []testCase{
+		{
+			name:                    "positive: successfully update a managed identity access rule",
+			existingManagedIdentity: sampleManagedIdentity,
+			existingServiceAccount:  sampleServiceAccount,
+			expectAccessRule:        sampleAccessRule,
+			input:                   sampleAccessRule,
+		},
+		{
+			name:                    "negative: allowed service account doesn't exist",
+			existingManagedIdentity: sampleManagedIdentity,
+			input:                   sampleAccessRule,
+			expectErrorCode:         errors.ENotFound,
+		},
+		{
+			name:                    "negative: service account is out of group scope",
+			input:                   sampleAccessRule,
+			existingManagedIdentity: sampleManagedIdentity,
+			existingServiceAccount: &models.ServiceAccount{
+				Metadata: models.ResourceMetadata{
+					ID: "service-account-id-1",
+				},
+				ResourcePath: "out/of/scope/service-account",
 			},
-			Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
-			RunStage:          models.JobApplyType,
-			ManagedIdentityID: "some-managed-identity-id",
-			AllowedUserIDs:    []string{"some-user-1"},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:  "negative: attempting to update access rules for a managed identity alias",
+			input: sampleAccessRule,
+			existingManagedIdentity: &models.ManagedIdentity{
+				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:                    "negative: subject does not have owner role for group",
+			input:                   sampleAccessRule,
+			existingManagedIdentity: sampleManagedIdentity,
+			authError:               errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:         errors.EForbidden,
 		},
 	}
 
-	sampleIdentitiesResult := &db.ManagedIdentitiesResult{
-		ManagedIdentities: []models.ManagedIdentity{
-			{
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockServiceAccounts := db.NewMockServiceAccounts(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockCaller := auth.NewMockCaller(t)
+			mockLimitChecker := limits.NewMockLimitChecker(t)
+
+			if test.expectErrorCode == "" {
+				mockManagedIdentities.On("UpdateManagedIdentityAccessRule", mock.Anything, test.input).Return(test.expectAccessRule, nil)
+
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
+
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+			}
+
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.input.ManagedIdentityID).Return(test.existingManagedIdentity, nil)
+
+			serviceAccountsResult := &db.ServiceAccountsResult{ServiceAccounts: []models.ServiceAccount{}}
+			if test.existingServiceAccount != nil {
+				serviceAccountsResult.ServiceAccounts = append(serviceAccountsResult.ServiceAccounts, *test.existingServiceAccount)
+			}
+
+			mockServiceAccounts.On("GetServiceAccountsByIDs", mock.Anything, sampleAccessRule.AllowedServiceAccountIDs).
+				Return(buildServiceAccountMap(serviceAccountsResult.ServiceAccounts), nil).Maybe()
+
+			if test.existingManagedIdentity != nil && !test.existingManagedIdentity.IsAlias() {
+				mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateManagedIdentityPermission, mock.Anything).Return(test.authError)
+			}
+
+			mockLimitChecker.On("CheckLimit", mock.Anything, limits.ResourceLimitAllowedPrincipalsPerManagedIdentityAccessRule, mock.Anything).
+				Return(nil).Maybe()
+
+			mockTeams, mockUsers := buildMockTeamsAndUsers(t, sampleAccessRule.AllowedTeamIDs, sampleAccessRule.AllowedUserIDs)
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+				ServiceAccounts:   mockServiceAccounts,
+				Transactions:      mockTransactions,
+				Teams:             mockTeams,
+				Users:             mockUsers,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, mockLimitChecker, nil, nil, nil, mockActivityEvents, ServiceOptions{})
+
+			accessRule, err := service.UpdateManagedIdentityAccessRule(auth.WithCaller(ctx, mockCaller), test.input)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectAccessRule, accessRule)
+		})
+	}
+}
+
+func TestUpdateManagedIdentityAccessRuleAllowedPrincipalsLimit(t *testing.T) {
+	sampleManagedIdentity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-id",
+		},
+		ResourcePath: "some/resource/path",
+		GroupID:      "some-group-id",
+		Type:         models.ManagedIdentityAWSFederated,
+	}
+
+	principalsLimit := 3
+
+	testCases := []struct {
+		name            string
+		userIDs         []string
+		teamIDs         []string
+		expectErrorCode errors.CodeType
+	}{
+		{
+			name:    "number of allowed principals is equal to the limit",
+			userIDs: []string{"user-id-1", "user-id-2"},
+			teamIDs: []string{"team-id-1"},
+		},
+		{
+			name:            "number of allowed principals exceeds the limit by one",
+			userIDs:         []string{"user-id-1", "user-id-2", "user-id-3"},
+			teamIDs:         []string{"team-id-1"},
+			expectErrorCode: errors.EInvalid,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			input := &models.ManagedIdentityAccessRule{
 				Metadata: models.ResourceMetadata{
-					ID: "some-managed-identity-id",
+					ID: "some-managed-identity-access-rule-id",
 				},
-				GroupID:      "some-group-id",
-				ResourcePath: "some-group/some-identity",
-			},
+				Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+				RunStage:          models.JobApplyType,
+				ManagedIdentityID: sampleManagedIdentity.Metadata.ID,
+				AllowedUserIDs:    test.userIDs,
+				AllowedTeamIDs:    test.teamIDs,
+			}
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockResourceLimits := db.NewMockResourceLimits(t)
+			mockCaller := auth.NewMockCaller(t)
+
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, sampleManagedIdentity.Metadata.ID).Return(sampleManagedIdentity, nil)
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateManagedIdentityPermission, mock.Anything).Return(nil)
+
+			mockResourceLimits.On("GetResourceLimit", mock.Anything, string(limits.ResourceLimitAllowedPrincipalsPerManagedIdentityAccessRule)).
+				Return(&models.ResourceLimit{Value: principalsLimit}, nil)
+
+			if test.expectErrorCode == "" {
+				mockManagedIdentities.On("UpdateManagedIdentityAccessRule", mock.Anything, input).Return(input, nil)
+
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, mock.Anything).Return(&models.ActivityEvent{}, nil)
+
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+			}
+
+			mockTeams, mockUsers := buildMockTeamsAndUsers(t, test.teamIDs, test.userIDs)
+
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+				Transactions:      mockTransactions,
+				ResourceLimits:    mockResourceLimits,
+				Teams:             mockTeams,
+				Users:             mockUsers,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, limits.NewLimitChecker(dbClient), nil, nil, nil, mockActivityEvents, ServiceOptions{})
+
+			_, err := service.UpdateManagedIdentityAccessRule(auth.WithCaller(ctx, mockCaller), input)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestDeleteManagedIdentityAccessRule(t *testing.T) {
+	sampleManagedIdentity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-id",
 		},
+		ResourcePath: "some/resource/path",
+		GroupID:      "some-group-id",
+		Type:         models.ManagedIdentityAWSFederated,
 	}
 
-	idList := []string{"access-rule-id-1", "access-rule-id-2"}
+	sampleAccessRule := &models.ManagedIdentityAccessRule{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-access-rule-id",
+		},
+		Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:                 models.JobApplyType,
+		ManagedIdentityID:        sampleManagedIdentity.Metadata.ID,
+		AllowedUserIDs:           []string{"user-id-1"},
+		AllowedServiceAccountIDs: []string{"service-account-id-1"},
+		AllowedTeamIDs:           []string{"team-id-1"},
+	}
+
+	activityEventInput := &activityevent.CreateActivityEventInput{
+		NamespacePath: ptr.String(sampleManagedIdentity.GetGroupPath()),
+		Action:        models.ActionDeleteChildResource,
+		TargetType:    models.TargetManagedIdentity,
+		TargetID:      sampleManagedIdentity.Metadata.ID,
+		Payload: &models.ActivityEventDeleteChildResourcePayload{
+			ID:   sampleAccessRule.Metadata.ID,
+			Name: string(sampleAccessRule.RunStage),
+			Type: string(models.TargetManagedIdentityAccessRule),
+		},
+	}
 
 	type testCase struct {
-		authError         error
-		name              string
-		expectErrorCode   errors.CodeType
-		inputIDList       []string
-		ruleDBInput       *db.GetManagedIdentityAccessRulesInput
-		identityDBInput   *db.GetManagedIdentitiesInput
-		dbResult          *db.ManagedIdentityAccessRulesResult
-		expectAccessRules []models.ManagedIdentityAccessRule
+		authError               error
+		existingManagedIdentity *models.ManagedIdentity
+		input                   *models.ManagedIdentityAccessRule
+		name                    string
+		expectErrorCode         errors.CodeType
 	}
 
 	testCases := []testCase{
 		{
-			name:        "positive: successfully return managed identity access rules",
-			inputIDList: idList,
-			ruleDBInput: &db.GetManagedIdentityAccessRulesInput{
-				Filter: &db.ManagedIdentityAccessRuleFilter{
-					ManagedIdentityAccessRuleIDs: idList,
-				},
-			},
-			identityDBInput: &db.GetManagedIdentitiesInput{
-				Filter: &db.ManagedIdentityFilter{
-					ManagedIdentityIDs: []string{sampleAccessRules[0].ManagedIdentityID},
-				},
-			},
-			dbResult: &db.ManagedIdentityAccessRulesResult{
-				ManagedIdentityAccessRules: sampleAccessRules,
+			name:                    "positive: successfully delete a managed identity access rule",
+			existingManagedIdentity: sampleManagedIdentity,
+			input:                   sampleAccessRule,
+		},
+		{
+			name:            "negative: managed identity associated with access rule doesn't exist",
+			input:           sampleAccessRule,
+			expectErrorCode: errors.ENotFound,
+		},
+		{
+			name:  "negative: attempting to delete access rule for a managed identity alias",
+			input: sampleAccessRule,
+			existingManagedIdentity: &models.ManagedIdentity{
+				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
 			},
-			expectAccessRules: sampleAccessRules,
+			expectErrorCode: errors.EInvalid,
 		},
 		{
-			name:        "negative: subject does not have access to group resource",
-			inputIDList: idList,
-			ruleDBInput: &db.GetManagedIdentityAccessRulesInput{
-				Filter: &db.ManagedIdentityAccessRuleFilter{
-					ManagedIdentityAccessRuleIDs: idList,
-				},
-			},
-			identityDBInput: &db.GetManagedIdentitiesInput{
-				Filter: &db.ManagedIdentityFilter{
-					ManagedIdentityIDs: []string{sampleAccessRules[0].ManagedIdentityID},
-				},
-			},
-			dbResult: &db.ManagedIdentityAccessRulesResult{
-				ManagedIdentityAccessRules: sampleAccessRules,
-			},
-			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode: errors.EForbidden,
+			name:                    "negative: subject does not have owner role for group",
+			input:                   sampleAccessRule,
+			existingManagedIdentity: sampleManagedIdentity,
+			authError:               errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:         errors.EForbidden,
 		},
 	}
 
@@ -2108,20 +4706,35 @@ func TestGetManagedIdentityAccessRulesByIDs(t *testing.T) {
 			defer cancel()
 
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+			mockTransactions := db.NewMockTransactions(t)
 			mockCaller := auth.NewMockCaller(t)
 
-			mockManagedIdentities.On("GetManagedIdentityAccessRules", mock.Anything, test.ruleDBInput).Return(test.dbResult, nil)
-			mockManagedIdentities.On("GetManagedIdentities", mock.Anything, test.identityDBInput).Return(sampleIdentitiesResult, nil)
+			if test.expectErrorCode == "" {
+				mockManagedIdentities.On("DeleteManagedIdentityAccessRule", mock.Anything, test.input).Return(nil)
 
-			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError)
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
+
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+			}
+
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.input.ManagedIdentityID).Return(test.existingManagedIdentity, nil)
+
+			if test.existingManagedIdentity != nil && !test.existingManagedIdentity.IsAlias() {
+				mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateManagedIdentityPermission, mock.Anything).Return(test.authError)
+			}
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
+				Transactions:      mockTransactions,
 			}
 
-			service := NewService(nil, dbClient, nil, nil, nil, nil, nil)
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, nil, nil, nil, nil, mockActivityEvents, ServiceOptions{})
 
-			rules, err := service.GetManagedIdentityAccessRulesByIDs(auth.WithCaller(ctx, mockCaller), test.inputIDList)
+			err := service.DeleteManagedIdentityAccessRule(auth.WithCaller(ctx, mockCaller), test.input)
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -2131,79 +4744,181 @@ func TestGetManagedIdentityAccessRulesByIDs(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-
-			assert.Equal(t, test.expectAccessRules, rules)
 		})
 	}
 }
 
-func TestGetManagedIdentityAccessRule(t *testing.T) {
+func TestCreateCredentials(t *testing.T) {
 	sampleManagedIdentity := &models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
 			ID: "some-managed-identity-id",
 		},
-		GroupID: "some-group-id",
-		Type:    models.ManagedIdentityAWSFederated,
+		ResourcePath: "some/resource/path",
+		GroupID:      "some-group-id",
+		Type:         models.ManagedIdentityAWSFederated,
 	}
 
-	sampleAccessRule := &models.ManagedIdentityAccessRule{
+	sampleJob := &models.Job{
 		Metadata: models.ResourceMetadata{
-			ID: "some-access-rule",
+			ID: "some-job-id",
 		},
-		Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
-		RunStage:          models.JobPlanType,
-		ManagedIdentityID: sampleManagedIdentity.Metadata.ID,
-		AllowedUserIDs:    []string{"user-id-1"},
+		WorkspaceID: "some-workspace-id",
+		RunID:       "some-run-id",
 	}
 
 	type testCase struct {
-		authError        error
-		expectAccessRule *models.ManagedIdentityAccessRule
-		searchID         string
-		name             string
-		expectErrorCode  errors.CodeType
+		caller                    auth.Caller
+		updateLastUsedErr         error
+		delegateErr               error
+		preconditionErr           error
+		input                     *models.ManagedIdentity
+		existingManagedIdentities []models.ManagedIdentity
+		name                      string
+		expectErrorCode           errors.CodeType
+		expectCredentials         []byte
 	}
 
 	testCases := []testCase{
 		{
-			name:             "positive: successfully return a managed identity access rule",
-			expectAccessRule: sampleAccessRule,
-			searchID:         sampleAccessRule.Metadata.ID,
+			name: "positive: successfully create managed identity credentials",
+			caller: &auth.JobCaller{
+				JobID:       sampleJob.Metadata.ID,
+				WorkspaceID: sampleJob.WorkspaceID,
+			},
+			input:                     sampleManagedIdentity,
+			existingManagedIdentities: []models.ManagedIdentity{*sampleManagedIdentity},
+			expectCredentials:         []byte("some-credentials"),
 		},
 		{
-			name:            "negative: access rule doesn't exist",
-			expectErrorCode: errors.ENotFound,
-			searchID:        "unknown-access-rule-id",
+			name: "positive: credentials are returned even if the last used timestamp can't be updated",
+			caller: &auth.JobCaller{
+				JobID:       sampleJob.Metadata.ID,
+				WorkspaceID: sampleJob.WorkspaceID,
+			},
+			input:                     sampleManagedIdentity,
+			existingManagedIdentities: []models.ManagedIdentity{*sampleManagedIdentity},
+			updateLastUsedErr:         errors.New("connection reset"),
+			expectCredentials:         []byte("some-credentials"),
 		},
 		{
-			name:             "negative: subject does not have access to group resource",
-			searchID:         sampleAccessRule.Metadata.ID,
-			expectAccessRule: sampleAccessRule,
-			authError:        errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode:  errors.EForbidden,
+			name: "negative: delegate fails to create credentials so last used timestamp isn't updated",
+			caller: &auth.JobCaller{
+				JobID:       sampleJob.Metadata.ID,
+				WorkspaceID: sampleJob.WorkspaceID,
+			},
+			input:                     sampleManagedIdentity,
+			existingManagedIdentities: []models.ManagedIdentity{*sampleManagedIdentity},
+			delegateErr:               errors.New("failed to sign token", errors.WithErrorCode(errors.EInternal)),
+			expectErrorCode:           errors.EInternal,
+		},
+		{
+			name: "negative: delegate forbids creating credentials",
+			caller: &auth.JobCaller{
+				JobID:       sampleJob.Metadata.ID,
+				WorkspaceID: sampleJob.WorkspaceID,
+			},
+			input:                     sampleManagedIdentity,
+			existingManagedIdentities: []models.ManagedIdentity{*sampleManagedIdentity},
+			preconditionErr:           errors.New("role trust policy does not allow the job", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:           errors.EForbidden,
+		},
+		{
+			name: "negative: delegate rejects the managed identity as invalid",
+			caller: &auth.JobCaller{
+				JobID:       sampleJob.Metadata.ID,
+				WorkspaceID: sampleJob.WorkspaceID,
+			},
+			input:                     sampleManagedIdentity,
+			existingManagedIdentities: []models.ManagedIdentity{*sampleManagedIdentity},
+			preconditionErr:           errors.New("role does not exist", errors.WithErrorCode(errors.EInvalid)),
+			expectErrorCode:           errors.EInvalid,
+		},
+		{
+			name: "negative: delegate fails to check preconditions",
+			caller: &auth.JobCaller{
+				JobID:       sampleJob.Metadata.ID,
+				WorkspaceID: sampleJob.WorkspaceID,
+			},
+			input:                     sampleManagedIdentity,
+			existingManagedIdentities: []models.ManagedIdentity{*sampleManagedIdentity},
+			preconditionErr:           errors.New("connection reset"),
+			expectErrorCode:           errors.EInternal,
+		},
+		{
+			name: "negative: managed identities don't belong to respective workspace",
+			caller: &auth.JobCaller{
+				JobID:       sampleJob.Metadata.ID,
+				WorkspaceID: sampleJob.WorkspaceID,
+			},
+			input:                     sampleManagedIdentity,
+			existingManagedIdentities: []models.ManagedIdentity{},
+			expectErrorCode:           errors.EUnauthorized,
+		},
+		{
+			name:            "negative: not a job caller",
+			caller:          &auth.UserCaller{},
+			input:           sampleManagedIdentity,
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name:            "negative: no caller",
+			input:           sampleManagedIdentity,
+			expectErrorCode: errors.EUnauthorized,
 		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
+			ctx := auth.WithCaller(context.Background(), test.caller)
 
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
-			mockCaller := auth.NewMockCaller(t)
+			mockJobService := job.NewMockService(t)
+			mockDelegate := NewMockDelegate(t)
 
-			mockManagedIdentities.On("GetManagedIdentityAccessRule", mock.Anything, test.searchID).Return(test.expectAccessRule, nil)
-			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, sampleManagedIdentity.Metadata.ID).Return(sampleManagedIdentity, nil).Maybe()
+			if test.existingManagedIdentities != nil {
+				mockManagedIdentities.On("GetManagedIdentitiesForWorkspace", mock.Anything, sampleJob.WorkspaceID).Return(test.existingManagedIdentities, nil)
 
-			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).Return(test.authError).Maybe()
+				mockJobService.On("GetJob", mock.Anything, mock.Anything).Return(sampleJob, nil)
+			}
+
+			issuedAfter := time.Now().UTC()
+
+			if test.expectCredentials != nil || test.delegateErr != nil || test.preconditionErr != nil {
+				mockDelegate.On("CanCreateCredentials", mock.Anything, test.input, sampleJob).Return(test.preconditionErr)
+			}
+
+			if test.expectCredentials != nil {
+				mockDelegate.On("CreateCredentials", mock.Anything, test.input, sampleJob).Return([]byte("some-credentials"), nil)
+
+				// The last used timestamp must advance to the time the credentials were issued.
+				mockManagedIdentities.On("UpdateManagedIdentityLastUsedAt", mock.Anything, test.input.Metadata.ID,
+					mock.MatchedBy(func(lastUsedAt time.Time) bool {
+						return !lastUsedAt.Before(issuedAfter)
+					})).Return(test.updateLastUsedErr)
+
+				// The issuance is recorded against the job's run.
+				mockManagedIdentities.On("CreateCredentialIssuance", mock.Anything, test.input.Metadata.ID,
+					sampleJob.RunID, sampleJob.Metadata.ID).Return(nil)
+			}
+
+			if test.delegateErr != nil {
+				mockDelegate.On("CreateCredentials", mock.Anything, test.input, sampleJob).Return(nil, test.delegateErr)
+			}
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
 			}
 
-			service := NewService(nil, dbClient, nil, nil, nil, nil, nil)
+			delegateMap := map[models.ManagedIdentityType]Delegate{
+				models.ManagedIdentityAWSFederated:     mockDelegate,
+				models.ManagedIdentityAzureFederated:   mockDelegate,
+				models.ManagedIdentityTharsisFederated: mockDelegate,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, nil, delegateMap, nil, mockJobService, nil, ServiceOptions{})
 
-			rule, err := service.GetManagedIdentityAccessRule(auth.WithCaller(ctx, mockCaller), test.searchID)
+			credentials, err := service.CreateCredentials(ctx, test.input)
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -2214,118 +4929,259 @@ func TestGetManagedIdentityAccessRule(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			assert.Equal(t, test.expectAccessRule, rule)
+			assert.Equal(t, test.expectCredentials, credentials)
 		})
 	}
 }
 
-func TestCreateManagedIdentityAccessRule(t *testing.T) {
-	sampleManagedIdentity := &models.ManagedIdentity{
+func TestMoveManagedIdentity(t *testing.T) {
+
+	oldParentGroup := &models.Group{
 		Metadata: models.ResourceMetadata{
-			ID: "some-managed-identity-id",
+			ID: "old-group-id",
 		},
-		ResourcePath: "some/resource/path",
-		GroupID:      "some-group-id",
-		Type:         models.ManagedIdentityAWSFederated,
 	}
 
-	sampleAccessRule := &models.ManagedIdentityAccessRule{
+	candidateParentGroup := &models.Group{
 		Metadata: models.ResourceMetadata{
-			ID: "some-managed-identity-access-rule-id",
+			ID: "target-group-id",
 		},
-		Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
-		RunStage:                 models.JobApplyType,
-		ManagedIdentityID:        sampleManagedIdentity.Metadata.ID,
-		AllowedUserIDs:           []string{"user-id-1"},
-		AllowedServiceAccountIDs: []string{"service-account-id-1"},
-		AllowedTeamIDs:           []string{"team-id-1"},
 	}
 
-	sampleServiceAccount := &models.ServiceAccount{
+	sampleManagedIdentity := &models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
-			ID: "service-account-id-1",
+			ID: "some-managed-identity-id",
 		},
-		ResourcePath: "some/resource/service-account",
-	}
-
-	activityEventInput := &activityevent.CreateActivityEventInput{
-		NamespacePath: ptr.String(sampleManagedIdentity.GetGroupPath()),
-		Action:        models.ActionCreate,
-		TargetType:    models.TargetManagedIdentityAccessRule,
-		TargetID:      sampleAccessRule.Metadata.ID,
-	}
-
-	type testCase struct {
-		authError               error
-		expectAccessRule        *models.ManagedIdentityAccessRule
-		existingServiceAccount  *models.ServiceAccount
-		existingManagedIdentity *models.ManagedIdentity
-		input                   *models.ManagedIdentityAccessRule
-		name                    string
-		expectErrorCode         errors.CodeType
-		limit                   int
-		injectRulesPerMI        int32
-		exceedsLimit            bool
+		Name:         "a-managed-identity",
+		ResourcePath: "some/resource/path",
+		Description:  "some-description",
+		GroupID:      oldParentGroup.Metadata.ID,
+		Data:         []byte("this is some data"),
+		Type:         models.ManagedIdentityAWSFederated,
+	}
+
+	type testCase struct {
+		name                       string
+		authError                  error
+		targetGroupID              string
+		targetGroup                *models.Group
+		mover                      *models.ManagedIdentity
+		injectMoved                *models.ManagedIdentity
+		injectGetManagedIdentities *db.ManagedIdentitiesResult
+		injectWorkspacesForMI      []models.Workspace
+		limitError                 error
+		injectMoveError            error
+		expectErrorCode            errors.CodeType
 	}
 
 	testCases := []testCase{
 		{
-			name:                    "positive: successfully create a managed identity access rule",
-			existingManagedIdentity: sampleManagedIdentity,
-			existingServiceAccount:  sampleServiceAccount,
-			expectAccessRule:        sampleAccessRule,
-			input:                   sampleAccessRule,
-			limit:                   5,
-			injectRulesPerMI:        5,
+			name:          "positive, successfully move a managed identity, no problematic aliases",
+			targetGroupID: "target-group-id",
+			targetGroup: &models.Group{
+				Metadata: models.ResourceMetadata{
+					ID: "target-group-id",
+				},
+				FullPath: "target/group/path",
+			},
+			mover: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID: "mover-id",
+				},
+				GroupID:      "old-group-id",
+				ResourcePath: "old-group-path/mover-name",
+			},
+			injectWorkspacesForMI: []models.Workspace{
+				{
+					Metadata: models.ResourceMetadata{
+						ID: "workspace-id",
+					},
+					FullPath: "target/group/path/workspace/path",
+				},
+			},
+			injectMoved: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID: "moved-id",
+				},
+				ResourcePath: "target-group-id/moved-id",
+			},
+			// For the positive test case, GetManagedIdentities is hit when checking the resource limit and for aliases.
+			injectGetManagedIdentities: &db.ManagedIdentitiesResult{
+				PageInfo: &pagination.PageInfo{
+					TotalCount: 0,
+				},
+			},
 		},
 		{
-			name:                    "negative: allowed service account doesn't exist",
-			existingManagedIdentity: sampleManagedIdentity,
-			input:                   sampleAccessRule,
-			expectErrorCode:         errors.ENotFound,
+			name:          "negative: violates limit in new group",
+			targetGroupID: "target-group-id",
+			targetGroup: &models.Group{
+				Metadata: models.ResourceMetadata{
+					ID: "target-group-id",
+				},
+			},
+			mover: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID: "mover-id",
+				},
+				GroupID:      "old-group-id",
+				ResourcePath: "old-group-path/mover-name",
+			},
+			injectMoved: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID: "moved-id",
+				},
+				ResourcePath: "target-group-id/moved-id",
+			},
+			injectGetManagedIdentities: &db.ManagedIdentitiesResult{
+				PageInfo: &pagination.PageInfo{
+					TotalCount: 0,
+				},
+			},
+			expectErrorCode: errors.EInvalid,
+			limitError:      errors.New("limit exceeded", errors.WithErrorCode(errors.EInvalid)),
 		},
 		{
-			name:            "negative: managed identity associated with rules doesn't exist",
-			input:           sampleAccessRule,
+			name:          "negative: managed identity being moved doesn't exist",
+			targetGroup:   candidateParentGroup,
+			targetGroupID: "target-group-id",
+			mover: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID: "mover-id",
+				},
+				GroupID:      "old-group-id",
+				ResourcePath: "old-group-path/mover-name",
+			},
+			injectGetManagedIdentities: &db.ManagedIdentitiesResult{
+				PageInfo: &pagination.PageInfo{
+					TotalCount: 0,
+				},
+			},
+			injectMoveError: errors.New("Not found", errors.WithErrorCode(errors.ENotFound)),
 			expectErrorCode: errors.ENotFound,
 		},
 		{
-			name:                    "negative: service account is out of group scope",
-			input:                   sampleAccessRule,
-			existingManagedIdentity: sampleManagedIdentity,
-			existingServiceAccount: &models.ServiceAccount{
+			name:        "negative: attempting to move a managed identity alias",
+			targetGroup: candidateParentGroup,
+			mover: &models.ManagedIdentity{
+				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
+				ResourcePath:  "some/resource/path",
+			},
+			injectMoved: &models.ManagedIdentity{
 				Metadata: models.ResourceMetadata{
-					ID: "service-account-id-1",
+					ID: "moved-id",
 				},
-				ResourcePath: "out/of/scope/service-account",
+				ResourcePath: "target-group-id/moved-id",
 			},
 			expectErrorCode: errors.EInvalid,
 		},
 		{
-			name:  "negative: attempting to create access rule for a managed identity alias",
-			input: sampleAccessRule,
-			existingManagedIdentity: &models.ManagedIdentity{
-				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
+			name:            "negative: subject does not have access to the old group",
+			targetGroup:     candidateParentGroup,
+			targetGroupID:   "target-group-id",
+			mover:           sampleManagedIdentity,
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name:            "negative: subject does not have access to the new group",
+			targetGroup:     candidateParentGroup,
+			targetGroupID:   "target-group-id",
+			mover:           sampleManagedIdentity,
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name:          "a problematic alias in a descendant group",
+			targetGroupID: "target-group-id",
+			targetGroup: &models.Group{
+				Metadata: models.ResourceMetadata{
+					ID: "target-group-id",
+				},
+				FullPath: "target-parent-path/target-group-name",
+			},
+			mover: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID: "mover-id",
+				},
+				GroupID:      "old-group-id",
+				ResourcePath: "old-group-path/mover-name",
+			},
+			// For negative test cases, GetManagedIdentities is hit when checking for aliases.
+			injectGetManagedIdentities: &db.ManagedIdentitiesResult{
+				ManagedIdentities: []models.ManagedIdentity{
+					{
+						Metadata: models.ResourceMetadata{
+							ID: "alias in a descendant group",
+						},
+						AliasSourceID: ptr.String("mover-id"),
+						ResourcePath:  "target-parent-path/target-group-name/descendant-name/alias-name",
+						GroupID:       "alias-group-id",
+					},
+				},
 			},
 			expectErrorCode: errors.EInvalid,
 		},
 		{
-			name:                    "negative: subject does not have owner role for group",
-			input:                   sampleAccessRule,
-			existingManagedIdentity: sampleManagedIdentity,
-			authError:               errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode:         errors.EForbidden,
+			name:          "a problematic alias in an ancestor group",
+			targetGroupID: "target-group-id",
+			targetGroup: &models.Group{
+				Metadata: models.ResourceMetadata{
+					ID: "target-group-id",
+				},
+				FullPath: "ancestor-path/target-group-name",
+			},
+			mover: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID: "mover-id",
+				},
+				GroupID:      "old-group-id",
+				ResourcePath: "old-group-path/mover-name",
+			},
+			// For negative test cases, GetManagedIdentities is hit when checking for aliases.
+			injectGetManagedIdentities: &db.ManagedIdentitiesResult{
+				ManagedIdentities: []models.ManagedIdentity{
+					{
+						Metadata: models.ResourceMetadata{
+							ID: "alias in an ancestor group",
+						},
+						AliasSourceID: ptr.String("mover-id"),
+						ResourcePath:  "ancestor-path/alias-name",
+						GroupID:       "alias-group-id",
+					},
+				},
+			},
+			expectErrorCode: errors.EInvalid,
 		},
 		{
-			name:                    "exceeds limit",
-			existingManagedIdentity: sampleManagedIdentity,
-			existingServiceAccount:  sampleServiceAccount,
-			expectAccessRule:        sampleAccessRule,
-			input:                   sampleAccessRule,
-			limit:                   5,
-			injectRulesPerMI:        6,
-			exceedsLimit:            true,
-			expectErrorCode:         errors.EInvalid,
+			name:          "a problematic assignment outside the target group",
+			targetGroupID: "target-group-id",
+			targetGroup: &models.Group{
+				Metadata: models.ResourceMetadata{
+					ID: "target-group-id",
+				},
+				FullPath: "ancestor-path/old-group-name",
+			},
+			mover: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{
+					ID: "mover-id",
+				},
+				GroupID:      "old-group-id",
+				ResourcePath: "old-group-path/mover-name",
+			},
+			injectWorkspacesForMI: []models.Workspace{
+				{
+					Metadata: models.ResourceMetadata{
+						ID: "workspace-id",
+					},
+					FullPath: "workspace/outside/target/group",
+				},
+			},
+			// For negative test cases, GetManagedIdentities is hit when checking for aliases.
+			injectGetManagedIdentities: &db.ManagedIdentitiesResult{
+				ManagedIdentities: []models.ManagedIdentity{},
+			},
+			expectErrorCode: errors.EInvalid,
 		},
 	}
 
@@ -2335,66 +5191,70 @@ func TestCreateManagedIdentityAccessRule(t *testing.T) {
 			defer cancel()
 
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
-			mockServiceAccounts := db.NewMockServiceAccounts(t)
-			mockActivityEvents := activityevent.NewMockService(t)
 			mockTransactions := db.NewMockTransactions(t)
 			mockCaller := auth.NewMockCaller(t)
-			mockResourceLimits := db.NewMockResourceLimits(t)
+			mockLimitChecker := limits.NewMockLimitChecker(t)
+			mockGroups := db.NewMockGroups(t)
+			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockActivityEvents := activityevent.NewMockService(t)
 
-			if (test.expectErrorCode == "") || test.exceedsLimit {
-				mockManagedIdentities.On("CreateManagedIdentityAccessRule", mock.Anything, test.input).Return(test.expectAccessRule, nil)
+			mockGroups.On("GetGroupByID", mock.Anything, test.targetGroupID).Return(test.targetGroup, nil).Maybe()
+
+			mockWorkspaces.On("GetWorkspacesForManagedIdentity", mock.Anything, mock.Anything).
+				Return(test.injectWorkspacesForMI, nil).Maybe()
 
-				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
-				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
-				if !test.exceedsLimit {
-					mockTransactions.On("CommitTx", mock.Anything).Return(nil)
-					mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
-				}
-			}
+			mockCaller.On("GetSubject").Return("mockSubject").Maybe()
+			mockCaller.On("RequirePermission", mock.Anything, permissions.DeleteManagedIdentityPermission, mock.Anything).
+				Return(test.authError)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateManagedIdentityPermission, mock.Anything).
+				Return(test.authError).Maybe()
 
-			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.input.ManagedIdentityID).Return(test.existingManagedIdentity, nil)
+			mockTransactions.On("CommitTx", mock.Anything).Return(nil).Maybe()
+			mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil).Maybe()
+			mockTransactions.On("RollbackTx", mock.Anything).Return(nil).Maybe()
 
-			mockServiceAccounts.On("GetServiceAccountByID", mock.Anything, sampleServiceAccount.Metadata.ID).Return(test.existingServiceAccount, nil).Maybe()
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, mock.Anything).
+				Return(test.mover, nil).Maybe()
 
-			if test.existingManagedIdentity != nil && !test.existingManagedIdentity.IsAlias() {
-				mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateManagedIdentityPermission, mock.Anything).Return(test.authError)
-			}
+			mockManagedIdentities.On("UpdateManagedIdentity", mock.Anything, mock.Anything).
+				Return(
+					func(_ context.Context, managedIdentity *models.ManagedIdentity) (*models.ManagedIdentity, error) {
 
-			// Called inside transaction to check resource limits.
-			if test.limit > 0 {
-				mockManagedIdentities.On("GetManagedIdentityAccessRules", mock.Anything, &db.GetManagedIdentityAccessRulesInput{
-					Filter: &db.ManagedIdentityAccessRuleFilter{
-						ManagedIdentityID: &sampleManagedIdentity.Metadata.ID,
-					},
-					PaginationOptions: &pagination.Options{
-						First: ptr.Int32(0),
+						// Check that the correct group ID is being passed in.
+						if managedIdentity.GroupID != test.targetGroupID {
+							return nil, errors.New("incorrect group id")
+						}
+
+						return test.injectMoved, test.injectMoveError
 					},
-				}).Return(func(ctx context.Context, input *db.GetManagedIdentityAccessRulesInput) *db.ManagedIdentityAccessRulesResult {
-					_ = ctx
-					_ = input
+				).Maybe()
 
-					return &db.ManagedIdentityAccessRulesResult{
-						PageInfo: &pagination.PageInfo{
-							TotalCount: test.injectRulesPerMI,
-						},
-					}
-				}, nil)
+			mockManagedIdentities.On("GetManagedIdentities", mock.Anything, mock.Anything).
+				Return(test.injectGetManagedIdentities, nil).Maybe()
 
-				mockResourceLimits.On("GetResourceLimit", mock.Anything, mock.Anything).
-					Return(&models.ResourceLimit{Value: test.limit}, nil)
-			}
+			mockManagedIdentities.On("GetManagedIdentityCount", mock.Anything, mock.Anything).
+				Return(int32(0), nil).Maybe()
+
+			mockLimitChecker.On("CheckLimit", mock.Anything, limits.ResourceLimitManagedIdentitiesPerGroup, int32(0), mock.Anything).
+				Return(test.limitError).Maybe()
+
+			mockActivityEvents.On("CreateActivityEvent", mock.Anything, mock.Anything).
+				Return(&models.ActivityEvent{}, nil).Maybe()
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
-				ServiceAccounts:   mockServiceAccounts,
+				Groups:            mockGroups,
+				Workspaces:        mockWorkspaces,
 				Transactions:      mockTransactions,
-				ResourceLimits:    mockResourceLimits,
 			}
 
 			logger, _ := logger.NewForTest()
-			service := NewService(logger, dbClient, limits.NewLimitChecker(dbClient), nil, nil, nil, mockActivityEvents)
+			service := NewService(logger, dbClient, mockLimitChecker, nil, nil, nil, mockActivityEvents, ServiceOptions{})
 
-			accessRule, err := service.CreateManagedIdentityAccessRule(auth.WithCaller(ctx, mockCaller), test.input)
+			_, err := service.MoveManagedIdentity(auth.WithCaller(ctx, mockCaller), &MoveManagedIdentityInput{
+				ManagedIdentityID: test.mover.Metadata.ID,
+				NewGroupID:        test.targetGroup.Metadata.ID,
+			})
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -2404,98 +5264,154 @@ func TestCreateManagedIdentityAccessRule(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-
-			assert.Equal(t, test.expectAccessRule, accessRule)
 		})
 	}
 }
 
-func TestUpdateManagedIdentityAccessRule(t *testing.T) {
-	sampleManagedIdentity := &models.ManagedIdentity{
+func TestPreviewMoveManagedIdentity(t *testing.T) {
+	targetGroup := &models.Group{
 		Metadata: models.ResourceMetadata{
-			ID: "some-managed-identity-id",
+			ID: "target-group-id",
 		},
-		ResourcePath: "some/resource/path",
-		GroupID:      "some-group-id",
-		Type:         models.ManagedIdentityAWSFederated,
+		FullPath: "ancestor-path/target-group-name",
 	}
 
-	sampleAccessRule := &models.ManagedIdentityAccessRule{
+	mover := &models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
-			ID: "some-managed-identity-access-rule-id",
+			ID: "mover-id",
 		},
-		Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
-		RunStage:                 models.JobApplyType,
-		ManagedIdentityID:        sampleManagedIdentity.Metadata.ID,
-		AllowedUserIDs:           []string{"user-id-1"},
-		AllowedServiceAccountIDs: []string{"service-account-id-1"},
-		AllowedTeamIDs:           []string{"team-id-1"},
+		GroupID:      "old-group-id",
+		ResourcePath: "old-group-path/mover-name",
 	}
 
-	sampleServiceAccount := &models.ServiceAccount{
+	descendantAlias := models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
-			ID: "service-account-id-1",
+			ID: "alias in a descendant group",
 		},
-		ResourcePath: "some/resource/service-account",
+		AliasSourceID: ptr.String("mover-id"),
+		ResourcePath:  "ancestor-path/target-group-name/descendant-name/alias-name",
+		GroupID:       "descendant-group-id",
 	}
 
-	activityEventInput := &activityevent.CreateActivityEventInput{
-		NamespacePath: ptr.String(sampleManagedIdentity.GetGroupPath()),
-		Action:        models.ActionUpdate,
-		TargetType:    models.TargetManagedIdentityAccessRule,
-		TargetID:      sampleAccessRule.Metadata.ID,
+	ancestorAlias := models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "alias in an ancestor group",
+		},
+		AliasSourceID: ptr.String("mover-id"),
+		ResourcePath:  "ancestor-path/alias-name",
+		GroupID:       "ancestor-group-id",
+	}
+
+	targetGroupAlias := models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "alias in the target group",
+		},
+		AliasSourceID: ptr.String("mover-id"),
+		ResourcePath:  "ancestor-path/target-group-name/alias-name",
+		GroupID:       "target-group-id",
+	}
+
+	unrelatedAlias := models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "alias in an unrelated group",
+		},
+		AliasSourceID: ptr.String("mover-id"),
+		ResourcePath:  "unrelated-path/alias-name",
+		GroupID:       "unrelated-group-id",
+	}
+
+	insideWorkspace := models.Workspace{
+		Metadata: models.ResourceMetadata{
+			ID: "inside-workspace-id",
+		},
+		FullPath: "ancestor-path/target-group-name/workspace-name",
+	}
+
+	outsideWorkspace := models.Workspace{
+		Metadata: models.ResourceMetadata{
+			ID: "outside-workspace-id",
+		},
+		FullPath: "workspace/outside/target/group",
 	}
 
 	type testCase struct {
-		authError               error
-		expectAccessRule        *models.ManagedIdentityAccessRule
-		existingServiceAccount  *models.ServiceAccount
-		existingManagedIdentity *models.ManagedIdentity
-		input                   *models.ManagedIdentityAccessRule
-		name                    string
-		expectErrorCode         errors.CodeType
+		name                     string
+		authError                error
+		mover                    *models.ManagedIdentity
+		targetGroup              *models.Group
+		injectAliases            []models.ManagedIdentity
+		injectWorkspacesForMI    []models.Workspace
+		expectInvalidAliases     []models.ManagedIdentity
+		expectAssignmentsOutside []models.Workspace
+		expectErrorCode          errors.CodeType
 	}
 
 	testCases := []testCase{
 		{
-			name:                    "positive: successfully update a managed identity access rule",
-			existingManagedIdentity: sampleManagedIdentity,
-			existingServiceAccount:  sampleServiceAccount,
-			expectAccessRule:        sampleAccessRule,
-			input:                   sampleAccessRule,
+			name:                     "no problematic aliases or assignments",
+			mover:                    mover,
+			targetGroup:              targetGroup,
+			injectAliases:            []models.ManagedIdentity{unrelatedAlias},
+			injectWorkspacesForMI:    []models.Workspace{insideWorkspace},
+			expectInvalidAliases:     []models.ManagedIdentity{},
+			expectAssignmentsOutside: []models.Workspace{},
 		},
 		{
-			name:                    "negative: allowed service account doesn't exist",
-			existingManagedIdentity: sampleManagedIdentity,
-			input:                   sampleAccessRule,
-			expectErrorCode:         errors.ENotFound,
+			name:                     "a problematic alias in a descendant group",
+			mover:                    mover,
+			targetGroup:              targetGroup,
+			injectAliases:            []models.ManagedIdentity{descendantAlias},
+			expectInvalidAliases:     []models.ManagedIdentity{descendantAlias},
+			expectAssignmentsOutside: []models.Workspace{},
 		},
 		{
-			name:                    "negative: service account is out of group scope",
-			input:                   sampleAccessRule,
-			existingManagedIdentity: sampleManagedIdentity,
-			existingServiceAccount: &models.ServiceAccount{
+			name:                     "a problematic alias in an ancestor group",
+			mover:                    mover,
+			targetGroup:              targetGroup,
+			injectAliases:            []models.ManagedIdentity{ancestorAlias},
+			expectInvalidAliases:     []models.ManagedIdentity{ancestorAlias},
+			expectAssignmentsOutside: []models.Workspace{},
+		},
+		{
+			name:                     "all problematic aliases and assignments are returned",
+			mover:                    mover,
+			targetGroup:              targetGroup,
+			injectAliases:            []models.ManagedIdentity{targetGroupAlias, unrelatedAlias, descendantAlias, ancestorAlias},
+			injectWorkspacesForMI:    []models.Workspace{insideWorkspace, outsideWorkspace},
+			expectInvalidAliases:     []models.ManagedIdentity{targetGroupAlias, descendantAlias, ancestorAlias},
+			expectAssignmentsOutside: []models.Workspace{outsideWorkspace},
+		},
+		{
+			name:                     "a problematic assignment outside the target group",
+			mover:                    mover,
+			targetGroup:              targetGroup,
+			injectWorkspacesForMI:    []models.Workspace{outsideWorkspace},
+			expectInvalidAliases:     []models.ManagedIdentity{},
+			expectAssignmentsOutside: []models.Workspace{outsideWorkspace},
+		},
+		{
+			name: "cannot preview moving a managed identity alias",
+			mover: &models.ManagedIdentity{
 				Metadata: models.ResourceMetadata{
-					ID: "service-account-id-1",
+					ID: "alias-id",
 				},
-				ResourcePath: "out/of/scope/service-account",
+				AliasSourceID: ptr.String("mover-id"),
+				ResourcePath:  "some/resource/path",
 			},
+			targetGroup:     targetGroup,
 			expectErrorCode: errors.EInvalid,
 		},
 		{
-			name:  "negative: attempting to update access rules for a managed identity alias",
-			input: sampleAccessRule,
-			existingManagedIdentity: &models.ManagedIdentity{
-				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
-			},
-			expectErrorCode: errors.EInvalid,
+			name:            "target group does not exist",
+			mover:           mover,
+			expectErrorCode: errors.ENotFound,
 		},
 		{
-			name:                    "negative: subject does not have owner role for group",
-			input:                   sampleAccessRule,
-			existingManagedIdentity: sampleManagedIdentity,
-			authError:               errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode:         errors.EForbidden,
+			name:            "subject does not have permission",
+			mover:           mover,
+			targetGroup:     targetGroup,
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
 		},
 	}
 
@@ -2505,39 +5421,41 @@ func TestUpdateManagedIdentityAccessRule(t *testing.T) {
 			defer cancel()
 
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
-			mockServiceAccounts := db.NewMockServiceAccounts(t)
-			mockActivityEvents := activityevent.NewMockService(t)
-			mockTransactions := db.NewMockTransactions(t)
 			mockCaller := auth.NewMockCaller(t)
+			mockGroups := db.NewMockGroups(t)
+			mockWorkspaces := db.NewMockWorkspaces(t)
 
-			if test.expectErrorCode == "" {
-				mockManagedIdentities.On("UpdateManagedIdentityAccessRule", mock.Anything, test.input).Return(test.expectAccessRule, nil)
-
-				mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.DeleteManagedIdentityPermission, mock.Anything).
+				Return(test.authError)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateManagedIdentityPermission, mock.Anything).
+				Return(test.authError).Maybe()
 
-				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
-				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
-				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
-			}
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.mover.Metadata.ID).Return(test.mover, nil)
 
-			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.input.ManagedIdentityID).Return(test.existingManagedIdentity, nil)
+			mockGroups.On("GetGroupByID", mock.Anything, "target-group-id").Return(test.targetGroup, nil).Maybe()
 
-			mockServiceAccounts.On("GetServiceAccountByID", mock.Anything, sampleServiceAccount.Metadata.ID).Return(test.existingServiceAccount, nil).Maybe()
+			mockManagedIdentities.On("GetManagedIdentities", mock.Anything, &db.GetManagedIdentitiesInput{
+				Filter: &db.ManagedIdentityFilter{
+					AliasSourceID: &test.mover.Metadata.ID,
+				},
+			}).Return(&db.ManagedIdentitiesResult{ManagedIdentities: test.injectAliases}, nil).Maybe()
 
-			if test.existingManagedIdentity != nil && !test.existingManagedIdentity.IsAlias() {
-				mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateManagedIdentityPermission, mock.Anything).Return(test.authError)
-			}
+			mockWorkspaces.On("GetWorkspacesForManagedIdentity", mock.Anything, test.mover.Metadata.ID).
+				Return(test.injectWorkspacesForMI, nil).Maybe()
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
-				ServiceAccounts:   mockServiceAccounts,
-				Transactions:      mockTransactions,
+				Groups:            mockGroups,
+				Workspaces:        mockWorkspaces,
 			}
 
 			logger, _ := logger.NewForTest()
-			service := NewService(logger, dbClient, nil, nil, nil, nil, mockActivityEvents)
+			service := NewService(logger, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
 
-			accessRule, err := service.UpdateManagedIdentityAccessRule(auth.WithCaller(ctx, mockCaller), test.input)
+			preview, err := service.PreviewMoveManagedIdentity(auth.WithCaller(ctx, mockCaller), &MoveManagedIdentityInput{
+				ManagedIdentityID: test.mover.Metadata.ID,
+				NewGroupID:        "target-group-id",
+			})
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -2548,78 +5466,82 @@ func TestUpdateManagedIdentityAccessRule(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			assert.Equal(t, test.expectAccessRule, accessRule)
+			assert.Equal(t, test.expectInvalidAliases, preview.InvalidAliases)
+			assert.Equal(t, test.expectAssignmentsOutside, preview.AssignmentsOutsideGroup)
 		})
 	}
 }
 
-func TestDeleteManagedIdentityAccessRule(t *testing.T) {
+func TestGetAssignableGroupsForManagedIdentity(t *testing.T) {
+	// Hierarchy: root -> root/a -> root/a/b, and root -> root/c
+	rootGroup := models.Group{Metadata: models.ResourceMetadata{ID: "root-id"}, FullPath: "root"}
+	groupA := models.Group{Metadata: models.ResourceMetadata{ID: "a-id"}, FullPath: "root/a"}
+	groupB := models.Group{Metadata: models.ResourceMetadata{ID: "b-id"}, FullPath: "root/a/b"}
+	groupC := models.Group{Metadata: models.ResourceMetadata{ID: "c-id"}, FullPath: "root/c"}
+
 	sampleManagedIdentity := &models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
 			ID: "some-managed-identity-id",
 		},
-		ResourcePath: "some/resource/path",
-		GroupID:      "some-group-id",
+		Name:         "a-managed-identity",
+		ResourcePath: "root/a/a-managed-identity",
+		GroupID:      groupA.Metadata.ID,
 		Type:         models.ManagedIdentityAWSFederated,
 	}
 
-	sampleAccessRule := &models.ManagedIdentityAccessRule{
-		Metadata: models.ResourceMetadata{
-			ID: "some-managed-identity-access-rule-id",
-		},
-		Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
-		RunStage:                 models.JobApplyType,
-		ManagedIdentityID:        sampleManagedIdentity.Metadata.ID,
-		AllowedUserIDs:           []string{"user-id-1"},
-		AllowedServiceAccountIDs: []string{"service-account-id-1"},
-		AllowedTeamIDs:           []string{"team-id-1"},
-	}
-
-	activityEventInput := &activityevent.CreateActivityEventInput{
-		NamespacePath: ptr.String(sampleManagedIdentity.GetGroupPath()),
-		Action:        models.ActionDeleteChildResource,
-		TargetType:    models.TargetManagedIdentity,
-		TargetID:      sampleManagedIdentity.Metadata.ID,
-		Payload: &models.ActivityEventDeleteChildResourcePayload{
-			ID:   sampleAccessRule.Metadata.ID,
-			Name: string(sampleAccessRule.RunStage),
-			Type: string(models.TargetManagedIdentityAccessRule),
-		},
-	}
-
 	type testCase struct {
-		authError               error
-		existingManagedIdentity *models.ManagedIdentity
-		input                   *models.ManagedIdentityAccessRule
 		name                    string
+		existingManagedIdentity *models.ManagedIdentity
+		deleteAuthError         error
+		forbiddenGroupIDs       []string
+		notMemberGroupIDs       []string
+		expectGroups            []models.Group
 		expectErrorCode         errors.CodeType
 	}
 
 	testCases := []testCase{
 		{
-			name:                    "positive: successfully delete a managed identity access rule",
+			name:                    "caller can create managed identities in every group",
 			existingManagedIdentity: sampleManagedIdentity,
-			input:                   sampleAccessRule,
+			expectGroups:            []models.Group{rootGroup, groupB, groupC},
 		},
 		{
-			name:            "negative: managed identity associated with access rule doesn't exist",
-			input:           sampleAccessRule,
-			expectErrorCode: errors.ENotFound,
+			name:                    "groups without create permission are excluded",
+			existingManagedIdentity: sampleManagedIdentity,
+			forbiddenGroupIDs:       []string{rootGroup.Metadata.ID, groupC.Metadata.ID},
+			expectGroups:            []models.Group{groupB},
 		},
 		{
-			name:  "negative: attempting to delete access rule for a managed identity alias",
-			input: sampleAccessRule,
+			name:                    "groups the caller isn't a member of are excluded",
+			existingManagedIdentity: sampleManagedIdentity,
+			notMemberGroupIDs:       []string{rootGroup.Metadata.ID, groupC.Metadata.ID},
+			expectGroups:            []models.Group{groupB},
+		},
+		{
+			name:                    "no assignable groups",
+			existingManagedIdentity: sampleManagedIdentity,
+			forbiddenGroupIDs:       []string{rootGroup.Metadata.ID, groupB.Metadata.ID, groupC.Metadata.ID},
+			expectGroups:            []models.Group{},
+		},
+		{
+			name:                    "caller cannot remove the managed identity from its current group",
+			existingManagedIdentity: sampleManagedIdentity,
+			deleteAuthError:         errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:         errors.EForbidden,
+		},
+		{
+			name: "managed identity is an alias",
 			existingManagedIdentity: &models.ManagedIdentity{
+				Metadata:      models.ResourceMetadata{ID: "some-alias-id"},
+				ResourcePath:  "root/c/an-alias",
+				GroupID:       groupC.Metadata.ID,
 				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
 			},
 			expectErrorCode: errors.EInvalid,
 		},
 		{
-			name:                    "negative: subject does not have owner role for group",
-			input:                   sampleAccessRule,
-			existingManagedIdentity: sampleManagedIdentity,
-			authError:               errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode:         errors.EForbidden,
+			name:            "managed identity does not exist",
+			expectErrorCode: errors.ENotFound,
 		},
 	}
 
@@ -2628,36 +5550,53 @@ func TestDeleteManagedIdentityAccessRule(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			mockManagedIdentities := db.NewMockManagedIdentities(t)
-			mockActivityEvents := activityevent.NewMockService(t)
-			mockTransactions := db.NewMockTransactions(t)
 			mockCaller := auth.NewMockCaller(t)
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockGroups := db.NewMockGroups(t)
 
-			if test.expectErrorCode == "" {
-				mockManagedIdentities.On("DeleteManagedIdentityAccessRule", mock.Anything, test.input).Return(nil)
-
-				mockActivityEvents.On("CreateActivityEvent", mock.Anything, activityEventInput).Return(&models.ActivityEvent{}, nil)
-
-				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
-				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
-				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, "some-managed-identity-id").Return(test.existingManagedIdentity, nil)
+
+			if test.existingManagedIdentity != nil {
+				mockCaller.On("RequirePermission", mock.Anything, permissions.DeleteManagedIdentityPermission, mock.Anything).Return(test.deleteAuthError)
 			}
 
-			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, test.input.ManagedIdentityID).Return(test.existingManagedIdentity, nil)
+			if test.expectErrorCode == "" {
+				sortBy := db.GroupSortableFieldFullPathAsc
+				mockGroups.On("GetGroupByFullPath", mock.Anything, rootGroup.FullPath).Return(&rootGroup, nil)
+				mockGroups.On("GetGroups", mock.Anything, &db.GetGroupsInput{
+					Sort: &sortBy,
+					Filter: &db.GroupFilter{
+						PathPrefix: &rootGroup.FullPath,
+					},
+				}).Return(&db.GroupsResult{Groups: []models.Group{groupA, groupB, groupC}}, nil)
 
-			if test.existingManagedIdentity != nil && !test.existingManagedIdentity.IsAlias() {
-				mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateManagedIdentityPermission, mock.Anything).Return(test.authError)
+				for _, group := range []models.Group{rootGroup, groupB, groupC} {
+					var authErr error
+					for _, forbiddenID := range test.forbiddenGroupIDs {
+						if forbiddenID == group.Metadata.ID {
+							authErr = errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden))
+						}
+					}
+					for _, notMemberID := range test.notMemberGroupIDs {
+						if notMemberID == group.Metadata.ID {
+							authErr = errors.New("Not found", errors.WithErrorCode(errors.ENotFound))
+						}
+					}
+
+					mockCaller.On("RequirePermission", mock.Anything, permissions.CreateManagedIdentityPermission, mock.Anything).
+						Return(authErr).Once()
+				}
 			}
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
-				Transactions:      mockTransactions,
+				Groups:            mockGroups,
 			}
 
 			logger, _ := logger.NewForTest()
-			service := NewService(logger, dbClient, nil, nil, nil, nil, mockActivityEvents)
+			service := NewService(logger, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
 
-			err := service.DeleteManagedIdentityAccessRule(auth.WithCaller(ctx, mockCaller), test.input)
+			groups, err := service.GetAssignableGroupsForManagedIdentity(auth.WithCaller(ctx, mockCaller), "some-managed-identity-id")
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -2667,102 +5606,163 @@ func TestDeleteManagedIdentityAccessRule(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+
+			assert.Equal(t, test.expectGroups, groups)
 		})
 	}
 }
 
-func TestCreateCredentials(t *testing.T) {
+// buildMockTeamsAndUsers returns team and user mocks where only the given team and user IDs exist
+func buildMockTeamsAndUsers(t *testing.T, existingTeamIDs []string, existingUserIDs []string) (*db.MockTeams, *db.MockUsers) {
+	mockTeams := db.NewMockTeams(t)
+	mockUsers := db.NewMockUsers(t)
+
+	teams := []models.Team{}
+	for _, id := range existingTeamIDs {
+		teams = append(teams, models.Team{Metadata: models.ResourceMetadata{ID: id}})
+	}
+
+	users := []models.User{}
+	for _, id := range existingUserIDs {
+		users = append(users, models.User{Metadata: models.ResourceMetadata{ID: id}})
+	}
+
+	mockTeams.On("GetTeams", mock.Anything, mock.Anything).Return(&db.TeamsResult{Teams: teams}, nil).Maybe()
+	mockUsers.On("GetUsers", mock.Anything, mock.Anything).Return(&db.UsersResult{Users: users}, nil).Maybe()
+
+	return mockTeams, mockUsers
+}
+
+// buildServiceAccountMap returns the service accounts keyed by ID
+func buildServiceAccountMap(serviceAccounts []models.ServiceAccount) map[string]*models.ServiceAccount {
+	serviceAccountMap := make(map[string]*models.ServiceAccount, len(serviceAccounts))
+	for ix := range serviceAccounts {
+		serviceAccountMap[serviceAccounts[ix].Metadata.ID] = &serviceAccounts[ix]
+	}
+	return serviceAccountMap
+}
+
+func TestCanAssumeManagedIdentity(t *testing.T) {
+	identityID := "managed-identity-1"
+	workspaceID := "workspace-1"
+
 	sampleManagedIdentity := &models.ManagedIdentity{
-		Metadata: models.ResourceMetadata{
-			ID: "some-managed-identity-id",
-		},
-		ResourcePath: "some/resource/path",
+		Metadata:     models.ResourceMetadata{ID: identityID},
+		ResourcePath: "some-group/a-managed-identity",
 		GroupID:      "some-group-id",
 		Type:         models.ManagedIdentityAWSFederated,
 	}
 
-	sampleJob := &models.Job{
-		Metadata: models.ResourceMetadata{
-			ID: "some-job-id",
+	userPrincipal := auth.NewUserCaller(&models.User{
+		Metadata: models.ResourceMetadata{ID: "user-1"},
+		Username: "user1",
+	}, nil, nil, nil)
+
+	serviceAccountPrincipal := auth.NewServiceAccountCaller("service-account-1", "some-group/sa1", nil, nil, nil)
+
+	planRules := []models.ManagedIdentityAccessRule{
+		{
+			Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+			RunStage:                 models.JobPlanType,
+			ManagedIdentityID:        identityID,
+			AllowedUserIDs:           []string{"user-1"},
+			AllowedServiceAccountIDs: []string{"service-account-2"},
+		},
+		{
+			Type:              models.ManagedIdentityAccessRuleModuleAttestation,
+			RunStage:          models.JobPlanType,
+			ManagedIdentityID: identityID,
 		},
-		WorkspaceID: "some-workspace-id",
 	}
 
 	type testCase struct {
-		caller                    auth.Caller
-		input                     *models.ManagedIdentity
-		existingManagedIdentities []models.ManagedIdentity
-		name                      string
-		expectErrorCode           errors.CodeType
-		expectCredentials         []byte
+		name            string
+		principal       auth.Caller
+		stage           models.JobType
+		assigned        bool
+		rules           []models.ManagedIdentityAccessRule
+		authError       error
+		expectAllowed   bool
+		expectReason    string
+		expectErrorCode errors.CodeType
 	}
 
 	testCases := []testCase{
 		{
-			name: "positive: successfully create managed identity credentials",
-			caller: &auth.JobCaller{
-				JobID:       sampleJob.Metadata.ID,
-				WorkspaceID: sampleJob.WorkspaceID,
-			},
-			input:                     sampleManagedIdentity,
-			existingManagedIdentities: []models.ManagedIdentity{*sampleManagedIdentity},
-			expectCredentials:         []byte("some-credentials"),
+			name:          "assigned and eligible user",
+			principal:     userPrincipal,
+			stage:         models.JobPlanType,
+			assigned:      true,
+			rules:         planRules,
+			expectAllowed: true,
 		},
 		{
-			name: "negative: managed identities don't belong to respective workspace",
-			caller: &auth.JobCaller{
-				JobID:       sampleJob.Metadata.ID,
-				WorkspaceID: sampleJob.WorkspaceID,
-			},
-			input:                     sampleManagedIdentity,
-			existingManagedIdentities: []models.ManagedIdentity{},
-			expectErrorCode:           errors.EUnauthorized,
+			name:         "assigned and ineligible service account",
+			principal:    serviceAccountPrincipal,
+			stage:        models.JobPlanType,
+			assigned:     true,
+			rules:        planRules,
+			expectReason: "service account some-group/sa1 is not an eligible principal",
 		},
 		{
-			name:            "negative: not a job caller",
-			caller:          &auth.UserCaller{},
-			input:           sampleManagedIdentity,
-			expectErrorCode: errors.EForbidden,
+			name:         "unassigned and eligible user",
+			principal:    userPrincipal,
+			stage:        models.JobPlanType,
+			rules:        planRules,
+			expectReason: "managed identity some-group/a-managed-identity is not assigned to the workspace",
 		},
 		{
-			name:            "negative: no caller",
-			input:           sampleManagedIdentity,
-			expectErrorCode: errors.EUnauthorized,
+			name:         "unassigned and ineligible service account",
+			principal:    serviceAccountPrincipal,
+			stage:        models.JobPlanType,
+			rules:        planRules,
+			expectReason: "managed identity some-group/a-managed-identity is not assigned to the workspace",
+		},
+		{
+			name:          "assigned with no eligible principals rule for the stage",
+			principal:     serviceAccountPrincipal,
+			stage:         models.JobApplyType,
+			assigned:      true,
+			rules:         planRules,
+			expectAllowed: true,
+		},
+		{
+			name:            "caller cannot view the workspace",
+			principal:       userPrincipal,
+			stage:           models.JobPlanType,
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
 		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
-			ctx := auth.WithCaller(context.Background(), test.caller)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
 
+			mockCaller := auth.NewMockCaller(t)
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
-			mockJobService := job.NewMockService(t)
-			mockDelegate := NewMockDelegate(t)
 
-			if test.existingManagedIdentities != nil {
-				mockManagedIdentities.On("GetManagedIdentitiesForWorkspace", mock.Anything, sampleJob.WorkspaceID).Return(test.existingManagedIdentities, nil)
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, identityID).Return(sampleManagedIdentity, nil)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.ViewWorkspacePermission, mock.Anything).Return(test.authError)
 
-				mockJobService.On("GetJob", mock.Anything, mock.Anything).Return(sampleJob, nil)
+			assignedIdentities := []models.ManagedIdentity{}
+			if test.assigned {
+				assignedIdentities = append(assignedIdentities, *sampleManagedIdentity)
 			}
 
-			if test.expectCredentials != nil {
-				mockDelegate.On("CreateCredentials", mock.Anything, test.input, sampleJob).Return([]byte("some-credentials"), nil)
-			}
+			mockManagedIdentities.On("GetManagedIdentitiesForWorkspace", mock.Anything, workspaceID).Return(assignedIdentities, nil).Maybe()
+			mockManagedIdentities.On("GetManagedIdentityAccessRules", mock.Anything, mock.Anything).Return(&db.ManagedIdentityAccessRulesResult{
+				ManagedIdentityAccessRules: test.rules,
+			}, nil).Maybe()
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
 			}
 
-			delegateMap := map[models.ManagedIdentityType]Delegate{
-				models.ManagedIdentityAWSFederated:     mockDelegate,
-				models.ManagedIdentityAzureFederated:   mockDelegate,
-				models.ManagedIdentityTharsisFederated: mockDelegate,
-			}
-
-			logger, _ := logger.NewForTest()
-			service := NewService(logger, dbClient, nil, delegateMap, nil, mockJobService, nil)
+			service := NewService(nil, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
 
-			credentials, err := service.CreateCredentials(ctx, test.input)
+			allowed, reason, err := service.CanAssumeManagedIdentity(auth.WithCaller(ctx, mockCaller), identityID, workspaceID, test.stage, test.principal)
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
@@ -2773,259 +5773,151 @@ func TestCreateCredentials(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			assert.Equal(t, test.expectCredentials, credentials)
+			assert.Equal(t, test.expectAllowed, allowed)
+			assert.Equal(t, test.expectReason, reason)
 		})
 	}
 }
 
-func TestMoveManagedIdentity(t *testing.T) {
+func TestSetDeletionProtection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	oldParentGroup := &models.Group{
+	// The stored identity starts out protected and is updated in place by the mocked DB layer.
+	storedIdentity := &models.ManagedIdentity{
 		Metadata: models.ResourceMetadata{
-			ID: "old-group-id",
+			ID: "some-id",
 		},
+		Name:              "a-protected-managed-identity",
+		ResourcePath:      "some/resource/a-protected-managed-identity",
+		GroupID:           "some-group-id",
+		DeletionProtected: true,
 	}
 
-	candidateParentGroup := &models.Group{
-		Metadata: models.ResourceMetadata{
-			ID: "target-group-id",
-		},
-	}
+	mockManagedIdentities := db.NewMockManagedIdentities(t)
+	mockWorkspaces := db.NewMockWorkspaces(t)
+	mockActivityEvents := activityevent.NewMockService(t)
+	mockTransactions := db.NewMockTransactions(t)
+	mockCaller := auth.NewMockCaller(t)
 
-	sampleManagedIdentity := &models.ManagedIdentity{
-		Metadata: models.ResourceMetadata{
-			ID: "some-managed-identity-id",
-		},
-		Name:         "a-managed-identity",
-		ResourcePath: "some/resource/path",
-		Description:  "some-description",
-		GroupID:      oldParentGroup.Metadata.ID,
-		Data:         []byte("this is some data"),
-		Type:         models.ManagedIdentityAWSFederated,
+	mockCaller.On("RequirePermission", mock.Anything, permissions.DeleteManagedIdentityPermission, mock.Anything).Return(nil)
+	mockCaller.On("GetSubject").Return("mockSubject")
+
+	mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, storedIdentity.Metadata.ID).
+		Return(func(_ context.Context, _ string) (*models.ManagedIdentity, error) {
+			identityCopy := *storedIdentity
+			return &identityCopy, nil
+		})
+	mockManagedIdentities.On("UpdateManagedIdentity", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, identity *models.ManagedIdentity) (*models.ManagedIdentity, error) {
+			storedIdentity = identity
+			return identity, nil
+		})
+	mockManagedIdentities.On("DeleteManagedIdentity", mock.Anything, mock.Anything).Return(nil)
+
+	mockWorkspaces.On("GetWorkspacesForManagedIdentity", mock.Anything, storedIdentity.Metadata.ID).Return([]models.Workspace{}, nil)
+
+	mockActivityEvents.On("CreateActivityEvent", mock.Anything, mock.Anything).Return(&models.ActivityEvent{}, nil)
+
+	mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+	mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+	mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+
+	dbClient := &db.Client{
+		ManagedIdentities: mockManagedIdentities,
+		Workspaces:        mockWorkspaces,
+		Transactions:      mockTransactions,
 	}
 
-	type testCase struct {
-		name                       string
-		authError                  error
-		targetGroupID              string
-		targetGroup                *models.Group
-		mover                      *models.ManagedIdentity
-		injectMoved                *models.ManagedIdentity
-		injectGetManagedIdentities *db.ManagedIdentitiesResult
-		injectWorkspacesForMI      []models.Workspace
-		limitError                 error
-		injectMoveError            error
-		expectErrorCode            errors.CodeType
+	logger, _ := logger.NewForTest()
+	service := NewService(logger, dbClient, nil, nil, nil, nil, mockActivityEvents, ServiceOptions{})
+
+	callerCtx := auth.WithCaller(ctx, mockCaller)
+
+	// Deleting while protected is blocked.
+	protectedCopy := *storedIdentity
+	err := service.DeleteManagedIdentity(callerCtx, &DeleteManagedIdentityInput{ManagedIdentity: &protectedCopy, Force: true})
+	assert.Equal(t, errors.EConflict, errors.ErrorCode(err))
+
+	// Remove the protection, then the delete goes through.
+	unprotected, err := service.SetDeletionProtection(callerCtx, &SetDeletionProtectionInput{
+		ManagedIdentityID: storedIdentity.Metadata.ID,
+		Enabled:           false,
+	})
+	if err != nil {
+		t.Fatal(err)
 	}
+	assert.False(t, unprotected.DeletionProtected)
 
-	testCases := []testCase{
-		{
-			name:          "positive, successfully move a managed identity, no problematic aliases",
-			targetGroupID: "target-group-id",
-			targetGroup: &models.Group{
-				Metadata: models.ResourceMetadata{
-					ID: "target-group-id",
-				},
-				FullPath: "target/group/path",
-			},
-			mover: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "mover-id",
-				},
-				GroupID:      "old-group-id",
-				ResourcePath: "old-group-path/mover-name",
-			},
-			injectWorkspacesForMI: []models.Workspace{
-				{
-					Metadata: models.ResourceMetadata{
-						ID: "workspace-id",
-					},
-					FullPath: "target/group/path/workspace/path",
-				},
-			},
-			injectMoved: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "moved-id",
-				},
-				ResourcePath: "target-group-id/moved-id",
-			},
-			// For the positive test case, GetManagedIdentities is hit when checking the resource limit and for aliases.
-			injectGetManagedIdentities: &db.ManagedIdentitiesResult{
-				PageInfo: &pagination.PageInfo{
-					TotalCount: 0,
-				},
-			},
-		},
-		{
-			name:          "negative: violates limit in new group",
-			targetGroupID: "target-group-id",
-			targetGroup: &models.Group{
-				Metadata: models.ResourceMetadata{
-					ID: "target-group-id",
-				},
-			},
-			mover: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "mover-id",
-				},
-				GroupID:      "old-group-id",
-				ResourcePath: "old-group-path/mover-name",
-			},
-			injectMoved: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "moved-id",
-				},
-				ResourcePath: "target-group-id/moved-id",
-			},
-			injectGetManagedIdentities: &db.ManagedIdentitiesResult{
-				PageInfo: &pagination.PageInfo{
-					TotalCount: 0,
-				},
-			},
-			expectErrorCode: errors.EInvalid,
-			limitError:      errors.New("limit exceeded", errors.WithErrorCode(errors.EInvalid)),
-		},
-		{
-			name:          "negative: managed identity being moved doesn't exist",
-			targetGroup:   candidateParentGroup,
-			targetGroupID: "target-group-id",
-			mover: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "mover-id",
-				},
-				GroupID:      "old-group-id",
-				ResourcePath: "old-group-path/mover-name",
-			},
-			injectGetManagedIdentities: &db.ManagedIdentitiesResult{
-				PageInfo: &pagination.PageInfo{
-					TotalCount: 0,
-				},
-			},
-			injectMoveError: errors.New("Not found", errors.WithErrorCode(errors.ENotFound)),
-			expectErrorCode: errors.ENotFound,
-		},
-		{
-			name:        "negative: attempting to move a managed identity alias",
-			targetGroup: candidateParentGroup,
-			mover: &models.ManagedIdentity{
-				AliasSourceID: &sampleManagedIdentity.Metadata.ID,
-				ResourcePath:  "some/resource/path",
-			},
-			injectMoved: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "moved-id",
-				},
-				ResourcePath: "target-group-id/moved-id",
-			},
-			expectErrorCode: errors.EInvalid,
+	unprotectedCopy := *storedIdentity
+	assert.Nil(t, service.DeleteManagedIdentity(callerCtx, &DeleteManagedIdentityInput{ManagedIdentity: &unprotectedCopy}))
+}
+
+func TestCloneManagedIdentity(t *testing.T) {
+	sourceIdentity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "source-id",
 		},
-		{
-			name:            "negative: subject does not have access to the old group",
-			targetGroup:     candidateParentGroup,
-			targetGroupID:   "target-group-id",
-			mover:           sampleManagedIdentity,
-			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode: errors.EForbidden,
+		Type:         models.ManagedIdentityAWSFederated,
+		Name:         "source-identity",
+		Description:  "source description",
+		ResourcePath: "source-group/source-identity",
+		GroupID:      "source-group-id",
+		Data:         []byte("source-data"),
+	}
+
+	targetGroup := &models.Group{
+		Metadata: models.ResourceMetadata{
+			ID: "target-group-id",
 		},
+		FullPath: "target-group",
+	}
+
+	sourceRule := models.ManagedIdentityAccessRule{
+		Metadata:          models.ResourceMetadata{ID: "rule-1"},
+		Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+		ManagedIdentityID: sourceIdentity.Metadata.ID,
+		RunStage:          models.JobPlanType,
+		AllowedUserIDs:    []string{"user-1"},
+	}
+
+	type testCase struct {
+		existingIdentity *models.ManagedIdentity
+		source           *models.ManagedIdentity
+		name             string
+		cloneName        string
+		expectErrorCode  errors.CodeType
+		expectName       string
+	}
+
+	testCases := []testCase{
 		{
-			name:            "negative: subject does not have access to the new group",
-			targetGroup:     candidateParentGroup,
-			targetGroupID:   "target-group-id",
-			mover:           sampleManagedIdentity,
-			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode: errors.EForbidden,
+			name:       "clone keeps the source name in the target group",
+			source:     sourceIdentity,
+			expectName: sourceIdentity.Name,
 		},
 		{
-			name:          "a problematic alias in a descendant group",
-			targetGroupID: "target-group-id",
-			targetGroup: &models.Group{
-				Metadata: models.ResourceMetadata{
-					ID: "target-group-id",
-				},
-				FullPath: "target-parent-path/target-group-name",
-			},
-			mover: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "mover-id",
-				},
-				GroupID:      "old-group-id",
-				ResourcePath: "old-group-path/mover-name",
-			},
-			// For negative test cases, GetManagedIdentities is hit when checking for aliases.
-			injectGetManagedIdentities: &db.ManagedIdentitiesResult{
-				ManagedIdentities: []models.ManagedIdentity{
-					{
-						Metadata: models.ResourceMetadata{
-							ID: "alias in a descendant group",
-						},
-						AliasSourceID: ptr.String("mover-id"),
-						ResourcePath:  "target-parent-path/target-group-name/descendant-name/alias-name",
-						GroupID:       "alias-group-id",
-					},
-				},
-			},
-			expectErrorCode: errors.EInvalid,
+			name:       "clone is given a new name",
+			source:     sourceIdentity,
+			cloneName:  "renamed",
+			expectName: "renamed",
 		},
 		{
-			name:          "a problematic alias in an ancestor group",
-			targetGroupID: "target-group-id",
-			targetGroup: &models.Group{
-				Metadata: models.ResourceMetadata{
-					ID: "target-group-id",
-				},
-				ParentID: "ancestor-group-id",
-				FullPath: "ancestor-path/target-group-name",
-			},
-			mover: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "mover-id",
-				},
-				GroupID:      "old-group-id",
-				ResourcePath: "old-group-path/mover-name",
+			name:   "name collides with an existing managed identity in the target group",
+			source: sourceIdentity,
+			existingIdentity: &models.ManagedIdentity{
+				Metadata: models.ResourceMetadata{ID: "existing-id"},
+				Name:     sourceIdentity.Name,
 			},
-			// For negative test cases, GetManagedIdentities is hit when checking for aliases.
-			injectGetManagedIdentities: &db.ManagedIdentitiesResult{
-				ManagedIdentities: []models.ManagedIdentity{
-					{
-						Metadata: models.ResourceMetadata{
-							ID: "alias in an ancestor group",
-						},
-						AliasSourceID: ptr.String("mover-id"),
-						ResourcePath:  "ancestor-path/alias-name",
-						GroupID:       "alias-group-id",
-					},
-				},
-			},
-			expectErrorCode: errors.EInvalid,
+			expectErrorCode: errors.EConflict,
 		},
 		{
-			name:          "a problematic assignment outside the target group",
-			targetGroupID: "target-group-id",
-			targetGroup: &models.Group{
-				Metadata: models.ResourceMetadata{
-					ID: "target-group-id",
-				},
-				ParentID: "ancestor-group-id",
-				FullPath: "ancestor-path/old-group-name",
-			},
-			mover: &models.ManagedIdentity{
-				Metadata: models.ResourceMetadata{
-					ID: "mover-id",
-				},
-				GroupID:      "old-group-id",
-				ResourcePath: "old-group-path/mover-name",
-			},
-			injectWorkspacesForMI: []models.Workspace{
-				{
-					Metadata: models.ResourceMetadata{
-						ID: "workspace-id",
-					},
-					FullPath: "workspace/outside/target/group",
-				},
-			},
-			// For negative test cases, GetManagedIdentities is hit when checking for aliases.
-			injectGetManagedIdentities: &db.ManagedIdentitiesResult{
-				ManagedIdentities: []models.ManagedIdentity{},
+			name: "an alias cannot be cloned",
+			source: &models.ManagedIdentity{
+				Metadata:      models.ResourceMetadata{ID: "source-id"},
+				Name:          "alias",
+				GroupID:       "source-group-id",
+				AliasSourceID: ptr.String("another-id"),
 			},
 			expectErrorCode: errors.EInvalid,
 		},
@@ -3037,66 +5929,168 @@ func TestMoveManagedIdentity(t *testing.T) {
 			defer cancel()
 
 			mockManagedIdentities := db.NewMockManagedIdentities(t)
-			mockTransactions := db.NewMockTransactions(t)
-			mockCaller := auth.NewMockCaller(t)
-			mockLimitChecker := limits.NewMockLimitChecker(t)
 			mockGroups := db.NewMockGroups(t)
-			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockTransactions := db.NewMockTransactions(t)
 			mockActivityEvents := activityevent.NewMockService(t)
+			mockLimitChecker := limits.NewMockLimitChecker(t)
+			mockDelegate := NewMockDelegate(t)
+			mockCaller := auth.NewMockCaller(t)
 
-			mockGroups.On("GetGroupByID", mock.Anything, test.targetGroupID).Return(test.targetGroup, nil).Maybe()
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateManagedIdentityPermission, mock.Anything).Return(nil)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateManagedIdentityPermission, mock.Anything).Return(nil)
+			mockCaller.On("GetSubject").Return("mockSubject").Maybe()
 
-			mockWorkspaces.On("GetWorkspacesForManagedIdentity", mock.Anything, mock.Anything).
-				Return(test.injectWorkspacesForMI, nil).Maybe()
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, "source-id").Return(test.source, nil)
+			mockGroups.On("GetGroupByID", mock.Anything, targetGroup.Metadata.ID).Return(targetGroup, nil).Maybe()
+			mockManagedIdentities.On("GetManagedIdentityByPath", mock.Anything, mock.Anything).Return(test.existingIdentity, nil).Maybe()
 
-			mockCaller.On("GetSubject").Return("mockSubject").Maybe()
-			mockCaller.On("RequirePermission", mock.Anything, permissions.DeleteManagedIdentityPermission, mock.Anything).
-				Return(test.authError)
-			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateManagedIdentityPermission, mock.Anything).
-				Return(test.authError).Maybe()
+			var createdRule *models.ManagedIdentityAccessRule
+			if test.expectErrorCode == "" {
+				cloned := &models.ManagedIdentity{
+					Metadata:     models.ResourceMetadata{ID: "clone-id"},
+					Type:         sourceIdentity.Type,
+					Name:         test.expectName,
+					Description:  sourceIdentity.Description,
+					ResourcePath: "target-group/" + test.expectName,
+					GroupID:      targetGroup.Metadata.ID,
+					Data:         []byte{},
+				}
 
-			mockTransactions.On("CommitTx", mock.Anything).Return(nil).Maybe()
-			mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil).Maybe()
-			mockTransactions.On("RollbackTx", mock.Anything).Return(nil).Maybe()
+				mockManagedIdentities.On("GetManagedIdentityAccessRules", mock.Anything, mock.Anything).
+					Return(&db.ManagedIdentityAccessRulesResult{ManagedIdentityAccessRules: []models.ManagedIdentityAccessRule{sourceRule}}, nil)
+				mockManagedIdentities.On("CreateManagedIdentity", mock.Anything, mock.MatchedBy(func(identity *models.ManagedIdentity) bool {
+					return identity.Name == test.expectName && identity.GroupID == targetGroup.Metadata.ID && identity.AliasSourceID == nil
+				})).Return(cloned, nil)
+				mockDelegate.On("SetManagedIdentityData", mock.Anything, cloned, sourceIdentity.Data).Return(nil)
+				mockManagedIdentities.On("UpdateManagedIdentity", mock.Anything, cloned).Return(cloned, nil)
+				mockManagedIdentities.On("GetManagedIdentityCount", mock.Anything, mock.Anything).Return(int32(1), nil)
+				mockLimitChecker.On("CheckLimit", mock.Anything, limits.ResourceLimitManagedIdentitiesPerGroup, int32(1), mock.Anything).Return(nil)
+				mockManagedIdentities.On("CreateManagedIdentityAccessRule", mock.Anything, mock.Anything).
+					Return(func(_ context.Context, rule *models.ManagedIdentityAccessRule) (*models.ManagedIdentityAccessRule, error) {
+						createdRule = rule
+						return rule, nil
+					})
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, mock.Anything).Return(&models.ActivityEvent{}, nil)
 
-			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, mock.Anything).
-				Return(test.mover, nil).Maybe()
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+			}
 
-			mockManagedIdentities.On("UpdateManagedIdentity", mock.Anything, mock.Anything).
-				Return(
-					func(_ context.Context, managedIdentity *models.ManagedIdentity) (*models.ManagedIdentity, error) {
+			dbClient := &db.Client{
+				ManagedIdentities: mockManagedIdentities,
+				Groups:            mockGroups,
+				Transactions:      mockTransactions,
+			}
 
-						// Check that the correct group ID is being passed in.
-						if managedIdentity.GroupID != test.targetGroupID {
-							return nil, errors.New("incorrect group id")
-						}
+			delegateMap := map[models.ManagedIdentityType]Delegate{
+				models.ManagedIdentityAWSFederated: mockDelegate,
+			}
 
-						return test.injectMoved, test.injectMoveError
-					},
-				).Maybe()
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, mockLimitChecker, delegateMap, nil, nil, mockActivityEvents, ServiceOptions{})
 
-			mockManagedIdentities.On("GetManagedIdentities", mock.Anything, mock.Anything).
-				Return(test.injectGetManagedIdentities, nil).Maybe()
+			clone, err := service.CloneManagedIdentity(auth.WithCaller(ctx, mockCaller), &CloneManagedIdentityInput{
+				ManagedIdentityID: "source-id",
+				TargetGroupID:     targetGroup.Metadata.ID,
+				Name:              test.cloneName,
+			})
 
-			mockLimitChecker.On("CheckLimit", mock.Anything, limits.ResourceLimitManagedIdentitiesPerGroup, int32(0)).
-				Return(test.limitError).Maybe()
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
 
-			mockActivityEvents.On("CreateActivityEvent", mock.Anything, mock.Anything).
-				Return(&models.ActivityEvent{}, nil).Maybe()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, "clone-id", clone.Metadata.ID)
+			assert.Equal(t, test.expectName, clone.Name)
+			assert.Nil(t, clone.AliasSourceID)
+
+			// The access rule is copied onto the clone.
+			if assert.NotNil(t, createdRule) {
+				assert.Equal(t, "clone-id", createdRule.ManagedIdentityID)
+				assert.Equal(t, sourceRule.AllowedUserIDs, createdRule.AllowedUserIDs)
+				assert.Equal(t, sourceRule.RunStage, createdRule.RunStage)
+			}
+		})
+	}
+}
+
+func TestGetRunsForManagedIdentity(t *testing.T) {
+	identity := &models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "some-managed-identity-id",
+		},
+		ResourcePath: "some/resource/path",
+		GroupID:      "some-group-id",
+	}
+
+	runs := []models.Run{
+		{Metadata: models.ResourceMetadata{ID: "run-1"}, WorkspaceID: "ws-1"},
+		{Metadata: models.ResourceMetadata{ID: "run-2"}, WorkspaceID: "ws-2"},
+	}
+
+	type testCase struct {
+		authError       error
+		identity        *models.ManagedIdentity
+		name            string
+		expectErrorCode errors.CodeType
+		expectRuns      []models.Run
+	}
+
+	testCases := []testCase{
+		{
+			name:       "returns the runs that were issued credentials for the managed identity",
+			identity:   identity,
+			expectRuns: runs,
+		},
+		{
+			name:            "caller does not have access to the managed identity",
+			identity:        identity,
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name:            "managed identity does not exist",
+			expectErrorCode: errors.ENotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockRuns := db.NewMockRuns(t)
+
+			mockCaller.On("RequireAccessToInheritableResource", mock.Anything, permissions.ManagedIdentityResourceType, mock.Anything).
+				Return(test.authError).Maybe()
+
+			mockManagedIdentities.On("GetManagedIdentityByID", mock.Anything, identity.Metadata.ID).Return(test.identity, nil)
+
+			if test.expectRuns != nil {
+				mockRuns.On("GetRuns", mock.Anything, &db.GetRunsInput{
+					Filter: &db.RunFilter{
+						ManagedIdentityID: &identity.Metadata.ID,
+					},
+				}).Return(&db.RunsResult{Runs: test.expectRuns}, nil)
+			}
 
 			dbClient := &db.Client{
 				ManagedIdentities: mockManagedIdentities,
-				Groups:            mockGroups,
-				Workspaces:        mockWorkspaces,
-				Transactions:      mockTransactions,
+				Runs:              mockRuns,
 			}
 
 			logger, _ := logger.NewForTest()
-			service := NewService(logger, dbClient, mockLimitChecker, nil, nil, nil, mockActivityEvents)
+			service := NewService(logger, dbClient, nil, nil, nil, nil, nil, ServiceOptions{})
 
-			_, err := service.MoveManagedIdentity(auth.WithCaller(ctx, mockCaller), &MoveManagedIdentityInput{
-				ManagedIdentityID: test.mover.Metadata.ID,
-				NewGroupID:        test.targetGroup.Metadata.ID,
+			result, err := service.GetRunsForManagedIdentity(auth.WithCaller(ctx, mockCaller), &GetRunsForManagedIdentityInput{
+				ManagedIdentityID: identity.Metadata.ID,
 			})
 
 			if test.expectErrorCode != "" {
@@ -3107,6 +6101,84 @@ func TestMoveManagedIdentity(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+
+			assert.Equal(t, test.expectRuns, result.Runs)
+		})
+	}
+}
+
+// healthCheckingDelegate is a delegate that also implements HealthChecker
+type healthCheckingDelegate struct {
+	*MockDelegate
+	healthErr error
+}
+
+func (d *healthCheckingDelegate) HealthCheck(_ context.Context) error {
+	return d.healthErr
+}
+
+func TestDelegatesHealth(t *testing.T) {
+	healthErr := errors.New("failed to reach identity provider")
+
+	type testCase struct {
+		name         string
+		delegateMap  map[models.ManagedIdentityType]Delegate
+		expectHealth map[models.ManagedIdentityType]error
+	}
+
+	testCases := []testCase{
+		{
+			name: "all delegates are healthy",
+			delegateMap: map[models.ManagedIdentityType]Delegate{
+				models.ManagedIdentityAzureFederated:   &healthCheckingDelegate{MockDelegate: NewMockDelegate(t)},
+				models.ManagedIdentityAWSFederated:     &healthCheckingDelegate{MockDelegate: NewMockDelegate(t)},
+				models.ManagedIdentityTharsisFederated: NewMockDelegate(t),
+			},
+			expectHealth: map[models.ManagedIdentityType]error{
+				models.ManagedIdentityAzureFederated:   nil,
+				models.ManagedIdentityAWSFederated:     nil,
+				models.ManagedIdentityTharsisFederated: nil,
+			},
+		},
+		{
+			name: "one delegate is unhealthy",
+			delegateMap: map[models.ManagedIdentityType]Delegate{
+				models.ManagedIdentityAzureFederated:   &healthCheckingDelegate{MockDelegate: NewMockDelegate(t)},
+				models.ManagedIdentityAWSFederated:     &healthCheckingDelegate{MockDelegate: NewMockDelegate(t), healthErr: healthErr},
+				models.ManagedIdentityTharsisFederated: NewMockDelegate(t),
+			},
+			expectHealth: map[models.ManagedIdentityType]error{
+				models.ManagedIdentityAzureFederated:   nil,
+				models.ManagedIdentityAWSFederated:     healthErr,
+				models.ManagedIdentityTharsisFederated: nil,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, &db.Client{}, nil, test.delegateMap, nil, nil, nil, ServiceOptions{})
+
+			health, err := service.DelegatesHealth(auth.WithCaller(ctx, mockCaller))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectHealth, health)
 		})
 	}
+
+	t.Run("caller must be authenticated", func(t *testing.T) {
+		logger, _ := logger.NewForTest()
+		service := NewService(logger, &db.Client{}, nil, map[models.ManagedIdentityType]Delegate{}, nil, nil, nil, ServiceOptions{})
+
+		_, err := service.DelegatesHealth(context.Background())
+		assert.Equal(t, errors.EUnauthorized, errors.ErrorCode(err))
+	})
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/gid"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/managedidentity/preconditions"
 	te "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/jws"
 )
@@ -28,6 +29,7 @@ type Data struct {
 
 // Delegate for the Tharsis OIDC Federated managed identity type
 type Delegate struct {
+	preconditions.AlwaysAllowed
 	jwsProvider jws.Provider
 	issuerURL   string
 }
@@ -51,8 +53,7 @@ func (d *Delegate) CreateCredentials(ctx context.Context, identity *models.Manag
 
 	token := jwt.New()
 
-	maxJobDuration := time.Duration(job.MaxJobDuration) * time.Minute
-	if err = token.Set(jwt.ExpirationKey, time.Now().Add(maxJobDuration).Unix()); err != nil {
+	if err = token.Set(jwt.ExpirationKey, time.Now().Add(job.GetMaxJobDuration()).Unix()); err != nil {
 		return nil, err
 	}
 	if err = token.Set(jwt.NotBeforeKey, currentTimestamp); err != nil {
@@ -123,6 +124,17 @@ func (d *Delegate) SetManagedIdentityData(_ context.Context, managedIdentity *mo
 	return nil
 }
 
+// GetInputData returns the managed identity data fields that were supplied by the user,
+// leaving out the ones set by Tharsis such as the subject
+func (d *Delegate) GetInputData(managedIdentity *models.ManagedIdentity) ([]byte, error) {
+	federatedData, err := decodeData(managedIdentity.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&InputData{ServiceAccountPath: federatedData.ServiceAccountPath})
+}
+
 func decodeData(data []byte) (*Data, error) {
 	decodedData, err := base64.StdEncoding.DecodeString(string(data))
 	if err != nil {
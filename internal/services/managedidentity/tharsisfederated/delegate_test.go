@@ -162,3 +162,33 @@ func TestCreateCredentials(t *testing.T) {
 	assert.Equal(t, parsedToken.Audience(), []string{"tharsis"})
 	assert.True(t, parsedToken.Expiration().After(time.Now()) && parsedToken.Expiration().Before(time.Now().Add(maxJobDuration)))
 }
+
+func TestCanCreateCredentials(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	delegate, err := New(ctx, &jwsprovider.MockProvider{}, "http://test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// This managed identity type doesn't have any preconditions for creating credentials.
+	assert.Nil(t, delegate.CanCreateCredentials(ctx, &models.ManagedIdentity{}, &models.Job{}))
+}
+
+func TestGetInputData(t *testing.T) {
+	delegate, err := New(context.Background(), &jwsprovider.MockProvider{}, "http://test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputData, err := delegate.GetInputData(&models.ManagedIdentity{
+		Data: []byte(base64.StdEncoding.EncodeToString([]byte(`{"subject":"subject-1","serviceAccountPath":"group/sa"}`))),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The subject is set by Tharsis so it's not part of the input data.
+	assert.JSONEq(t, `{"serviceAccountPath":"group/sa"}`, string(inputData))
+}
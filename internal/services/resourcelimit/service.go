@@ -5,7 +5,9 @@ import (
 	"context"
 
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/auth"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/auth/permissions"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/db"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/limits"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
@@ -19,10 +21,30 @@ type UpdateResourceLimitInput struct {
 	Value           int
 }
 
+// SetGroupResourceLimitInput is the input for setting a group-level override of a resource limit.
+type SetGroupResourceLimitInput struct {
+	GroupPath string
+	Name      string
+	Value     int
+}
+
+// ExceededLimit is a per-group resource limit whose current count is at or over its value.
+type ExceededLimit struct {
+	Name  string
+	Limit int
+	Count int32
+}
+
+// groupLimitCounter returns the current count of the resources governed by a per-group limit.
+type groupLimitCounter func(ctx context.Context, dbClient *db.Client, group *models.Group) (int32, error)
+
 // Service implements all resource limit related functionality
 type Service interface {
 	GetResourceLimits(ctx context.Context) ([]models.ResourceLimit, error)
 	UpdateResourceLimit(ctx context.Context, input *UpdateResourceLimitInput) (*models.ResourceLimit, error)
+	GetExceededResourceLimits(ctx context.Context, namespacePath string) ([]ExceededLimit, error)
+	GetGroupResourceLimit(ctx context.Context, groupPath string, name string) (*models.GroupResourceLimit, error)
+	SetGroupResourceLimit(ctx context.Context, input *SetGroupResourceLimitInput) (*models.GroupResourceLimit, error)
 }
 
 type service struct {
@@ -109,3 +131,268 @@ func (s *service) UpdateResourceLimit(ctx context.Context, input *UpdateResource
 
 	return newLimit, nil
 }
+
+func (s *service) GetExceededResourceLimits(ctx context.Context, namespacePath string) ([]ExceededLimit, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetExceededResourceLimits")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	group, err := s.dbClient.Groups.GetGroupByFullPath(ctx, namespacePath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get group")
+		return nil, err
+	}
+
+	if group == nil {
+		tracing.RecordError(span, nil, "group not found")
+		return nil, errors.New("group with path %s not found", namespacePath, errors.WithErrorCode(errors.ENotFound))
+	}
+
+	// Only owners (and admins) are allowed to manage memberships, so use that to restrict access.
+	err = caller.RequirePermission(ctx, permissions.UpdateNamespaceMembershipPermission, auth.WithNamespacePath(group.FullPath))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	result := []ExceededLimit{}
+	for _, name := range groupLimitNames {
+		limitValue, err := s.getEffectiveGroupLimitValue(ctx, group.FullPath, name)
+		if err != nil {
+			tracing.RecordError(span, err, "failed to get resource limit")
+			return nil, err
+		}
+
+		count, err := groupLimitCounters[name](ctx, s.dbClient, group)
+		if err != nil {
+			tracing.RecordError(span, err, "failed to get resource count")
+			return nil, err
+		}
+
+		if int(count) >= limitValue {
+			result = append(result, ExceededLimit{
+				Name:  string(name),
+				Limit: limitValue,
+				Count: count,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func (s *service) GetGroupResourceLimit(ctx context.Context, groupPath string, name string) (*models.GroupResourceLimit, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetGroupResourceLimit")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	err = caller.RequirePermission(ctx, permissions.ViewGroupPermission, auth.WithNamespacePath(groupPath))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	group, err := s.getGroupByFullPath(ctx, groupPath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get group")
+		return nil, err
+	}
+
+	groupLimit, err := s.dbClient.ResourceLimits.GetGroupResourceLimit(ctx, group.Metadata.ID, name)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get group resource limit")
+		return nil, err
+	}
+
+	if groupLimit == nil {
+		tracing.RecordError(span, nil, "group resource limit not found")
+		return nil, errors.New("resource limit %s is not overridden for group %s", name, groupPath, errors.WithErrorCode(errors.ENotFound))
+	}
+
+	return groupLimit, nil
+}
+
+func (s *service) SetGroupResourceLimit(ctx context.Context, input *SetGroupResourceLimitInput) (*models.GroupResourceLimit, error) {
+	ctx, span := tracer.Start(ctx, "svc.SetGroupResourceLimit")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	userCaller, ok := caller.(*auth.UserCaller)
+	if !ok {
+		tracing.RecordError(span, nil, "Unsupported caller type, only users are allowed to set group resource limits")
+		return nil, errors.New("Unsupported caller type, only users are allowed to set group resource limits", errors.WithErrorCode(errors.EForbidden))
+	}
+	// Only admins are allowed to override resource limits.
+	if !userCaller.User.Admin {
+		tracing.RecordError(span, nil, "Only system admins can set group resource limits")
+		return nil, errors.New("Only system admins can set group resource limits", errors.WithErrorCode(errors.EForbidden))
+	}
+
+	if input.Value < 0 {
+		tracing.RecordError(span, nil, "negative resource limit value")
+		return nil, errors.New("resource limit value cannot be negative", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	// Validate the limit name/key.
+	foundLimit, err := s.dbClient.ResourceLimits.GetResourceLimit(ctx, input.Name)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get resource limit to validate name")
+		return nil, err
+	}
+	if foundLimit == nil {
+		tracing.RecordError(span, nil, "Invalid resource limit name")
+		return nil, errors.New("Invalid resource limit name", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	if !limits.ResourceLimitName(input.Name).IsGroupOverridable() {
+		tracing.RecordError(span, nil, "resource limit cannot be overridden at the group level")
+		return nil, errors.New("Resource limit %s cannot be overridden at the group level", input.Name, errors.WithErrorCode(errors.EInvalid))
+	}
+
+	group, err := s.getGroupByFullPath(ctx, input.GroupPath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get group")
+		return nil, err
+	}
+
+	groupLimit, err := s.dbClient.ResourceLimits.SetGroupResourceLimit(ctx, &models.GroupResourceLimit{
+		GroupID: group.Metadata.ID,
+		Name:    input.Name,
+		Value:   input.Value,
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to set group resource limit")
+		return nil, err
+	}
+
+	s.logger.Infow("Set group resource limit.",
+		"caller", caller.GetSubject(),
+		"groupPath", group.FullPath,
+		"name", input.Name,
+		"value", input.Value,
+	)
+
+	return groupLimit, nil
+}
+
+func (s *service) getGroupByFullPath(ctx context.Context, groupPath string) (*models.Group, error) {
+	group, err := s.dbClient.Groups.GetGroupByFullPath(ctx, groupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if group == nil {
+		return nil, errors.New("group with path %s not found", groupPath, errors.WithErrorCode(errors.ENotFound))
+	}
+
+	return group, nil
+}
+
+// getEffectiveGroupLimitValue returns the nearest group-level override for the limit, or the system-wide value if there is none.
+func (s *service) getEffectiveGroupLimitValue(ctx context.Context, groupPath string, name limits.ResourceLimitName) (int, error) {
+	override, err := s.dbClient.ResourceLimits.GetNearestGroupResourceLimit(ctx, groupPath, string(name))
+	if err != nil {
+		return 0, err
+	}
+
+	if override != nil {
+		return override.Value, nil
+	}
+
+	limit, err := s.dbClient.ResourceLimits.GetResourceLimit(ctx, string(name))
+	if err != nil {
+		return 0, err
+	}
+
+	if limit == nil {
+		return 0, errors.New("resource limit with name %s not found", name)
+	}
+
+	return limit.Value, nil
+}
+
+// groupLimitNames lists the per-group limits in the order they are checked.
+var groupLimitNames = []limits.ResourceLimitName{
+	limits.ResourceLimitSubgroupsPerParent,
+	limits.ResourceLimitWorkspacesPerGroup,
+	limits.ResourceLimitServiceAccountsPerGroup,
+	limits.ResourceLimitRunnerAgentsPerGroup,
+	limits.ResourceLimitGPGKeysPerGroup,
+	limits.ResourceLimitManagedIdentitiesPerGroup,
+	limits.ResourceLimitTerraformModulesPerGroup,
+	limits.ResourceLimitTerraformProvidersPerGroup,
+	limits.ResourceLimitVCSProvidersPerGroup,
+	limits.ResourceLimitTerraformProviderVersionMirrorsPerGroup,
+}
+
+// groupLimitCounters counts resources with the same filters the create paths use when enforcing each limit.
+var groupLimitCounters = map[limits.ResourceLimitName]groupLimitCounter{
+	limits.ResourceLimitSubgroupsPerParent: func(ctx context.Context, dbClient *db.Client, group *models.Group) (int32, error) {
+		return dbClient.Groups.GetGroupCount(ctx, &db.GroupFilter{
+			ParentID: &group.Metadata.ID,
+		})
+	},
+	limits.ResourceLimitWorkspacesPerGroup: func(ctx context.Context, dbClient *db.Client, group *models.Group) (int32, error) {
+		return dbClient.Workspaces.GetWorkspaceCount(ctx, &db.WorkspaceFilter{
+			GroupID: &group.Metadata.ID,
+		})
+	},
+	limits.ResourceLimitServiceAccountsPerGroup: func(ctx context.Context, dbClient *db.Client, group *models.Group) (int32, error) {
+		return dbClient.ServiceAccounts.GetServiceAccountCount(ctx, &db.ServiceAccountFilter{
+			NamespacePaths: []string{group.FullPath},
+		})
+	},
+	limits.ResourceLimitRunnerAgentsPerGroup: func(ctx context.Context, dbClient *db.Client, group *models.Group) (int32, error) {
+		return dbClient.Runners.GetRunnerCount(ctx, &db.RunnerFilter{
+			NamespacePaths: []string{group.FullPath},
+		})
+	},
+	limits.ResourceLimitGPGKeysPerGroup: func(ctx context.Context, dbClient *db.Client, group *models.Group) (int32, error) {
+		return dbClient.GPGKeys.GetGPGKeyCount(ctx, &db.GPGKeyFilter{
+			NamespacePaths: []string{group.FullPath},
+		})
+	},
+	limits.ResourceLimitManagedIdentitiesPerGroup: func(ctx context.Context, dbClient *db.Client, group *models.Group) (int32, error) {
+		return dbClient.ManagedIdentities.GetManagedIdentityCount(ctx, &db.ManagedIdentityFilter{
+			NamespacePaths: []string{group.FullPath},
+		})
+	},
+	limits.ResourceLimitTerraformModulesPerGroup: func(ctx context.Context, dbClient *db.Client, group *models.Group) (int32, error) {
+		return dbClient.TerraformModules.GetModuleCount(ctx, &db.TerraformModuleFilter{
+			GroupID: &group.Metadata.ID,
+		})
+	},
+	limits.ResourceLimitTerraformProvidersPerGroup: func(ctx context.Context, dbClient *db.Client, group *models.Group) (int32, error) {
+		return dbClient.TerraformProviders.GetProviderCount(ctx, &db.TerraformProviderFilter{
+			GroupID: &group.Metadata.ID,
+		})
+	},
+	limits.ResourceLimitVCSProvidersPerGroup: func(ctx context.Context, dbClient *db.Client, group *models.Group) (int32, error) {
+		return dbClient.VCSProviders.GetProviderCount(ctx, &db.VCSProviderFilter{
+			NamespacePaths: []string{group.FullPath},
+		})
+	},
+	limits.ResourceLimitTerraformProviderVersionMirrorsPerGroup: func(ctx context.Context, dbClient *db.Client, group *models.Group) (int32, error) {
+		return dbClient.TerraformProviderVersionMirrors.GetVersionMirrorCount(ctx, &db.TerraformProviderVersionMirrorFilter{
+			GroupID: &group.Metadata.ID,
+		})
+	},
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/auth"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/auth/permissions"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/db"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/limits"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/maintenance"
@@ -190,3 +191,356 @@ func TestUpdateResourceLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExceededResourceLimits(t *testing.T) {
+	group := &models.Group{
+		Metadata: models.ResourceMetadata{ID: "group-1"},
+		FullPath: "top-level/group",
+	}
+
+	// Every limit defaults to 10 unless overridden by a test case.
+	const defaultLimit = 10
+
+	type testCase struct {
+		name            string
+		group           *models.Group
+		limitOverrides  map[limits.ResourceLimitName]int
+		groupOverrides  map[limits.ResourceLimitName]int
+		counts          map[limits.ResourceLimitName]int32
+		authError       error
+		expectExceeded  []ExceededLimit
+		expectErrorCode errors.CodeType
+	}
+
+	testCases := []testCase{
+		{
+			name:           "no limits exceeded when all counts are below their limits",
+			group:          group,
+			counts:         map[limits.ResourceLimitName]int32{limits.ResourceLimitWorkspacesPerGroup: 9},
+			expectExceeded: []ExceededLimit{},
+		},
+		{
+			name:  "limits at or over threshold are returned",
+			group: group,
+			limitOverrides: map[limits.ResourceLimitName]int{
+				limits.ResourceLimitManagedIdentitiesPerGroup: 5,
+			},
+			counts: map[limits.ResourceLimitName]int32{
+				limits.ResourceLimitWorkspacesPerGroup:        10,
+				limits.ResourceLimitServiceAccountsPerGroup:   9,
+				limits.ResourceLimitManagedIdentitiesPerGroup: 7,
+				limits.ResourceLimitVCSProvidersPerGroup:      11,
+			},
+			expectExceeded: []ExceededLimit{
+				{Name: string(limits.ResourceLimitWorkspacesPerGroup), Limit: 10, Count: 10},
+				{Name: string(limits.ResourceLimitManagedIdentitiesPerGroup), Limit: 5, Count: 7},
+				{Name: string(limits.ResourceLimitVCSProvidersPerGroup), Limit: 10, Count: 11},
+			},
+		},
+		{
+			name:  "group-level overrides take precedence over the system limits",
+			group: group,
+			groupOverrides: map[limits.ResourceLimitName]int{
+				limits.ResourceLimitWorkspacesPerGroup:   20,
+				limits.ResourceLimitVCSProvidersPerGroup: 3,
+			},
+			counts: map[limits.ResourceLimitName]int32{
+				limits.ResourceLimitWorkspacesPerGroup:   10,
+				limits.ResourceLimitVCSProvidersPerGroup: 3,
+			},
+			expectExceeded: []ExceededLimit{
+				{Name: string(limits.ResourceLimitVCSProvidersPerGroup), Limit: 3, Count: 3},
+			},
+		},
+		{
+			name:            "group does not exist",
+			expectErrorCode: errors.ENotFound,
+		},
+		{
+			name:            "caller is not an owner of the group",
+			group:           group,
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+			mockGroups := db.NewMockGroups(t)
+			mockResourceLimits := db.NewMockResourceLimits(t)
+			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockServiceAccounts := db.NewMockServiceAccounts(t)
+			mockRunners := db.NewMockRunners(t)
+			mockGPGKeys := db.NewMockGPGKeys(t)
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockModules := db.NewMockTerraformModules(t)
+			mockProviders := db.NewMockTerraformProviders(t)
+			mockVCSProviders := db.NewMockVCSProviders(t)
+			mockMirrors := db.NewMockTerraformProviderVersionMirrors(t)
+
+			mockGroups.On("GetGroupByFullPath", mock.Anything, group.FullPath).Return(test.group, nil)
+
+			if test.group != nil {
+				mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateNamespaceMembershipPermission, mock.Anything).
+					Return(test.authError)
+			}
+
+			if test.group != nil && test.authError == nil {
+				mockResourceLimits.On("GetNearestGroupResourceLimit", mock.Anything, group.FullPath, mock.Anything).
+					Return(func(_ context.Context, _ string, name string) (*models.GroupResourceLimit, error) {
+						value, ok := test.groupOverrides[limits.ResourceLimitName(name)]
+						if !ok {
+							return nil, nil
+						}
+						return &models.GroupResourceLimit{Name: name, Value: value}, nil
+					})
+				mockResourceLimits.On("GetResourceLimit", mock.Anything, mock.Anything).Maybe().
+					Return(func(_ context.Context, name string) (*models.ResourceLimit, error) {
+						value, ok := test.limitOverrides[limits.ResourceLimitName(name)]
+						if !ok {
+							value = defaultLimit
+						}
+						return &models.ResourceLimit{Name: name, Value: value}, nil
+					})
+
+				mockGroups.On("GetGroupCount", mock.Anything, mock.Anything).
+					Return(test.counts[limits.ResourceLimitSubgroupsPerParent], nil)
+				mockWorkspaces.On("GetWorkspaceCount", mock.Anything, mock.Anything).
+					Return(test.counts[limits.ResourceLimitWorkspacesPerGroup], nil)
+				mockServiceAccounts.On("GetServiceAccountCount", mock.Anything, mock.Anything).
+					Return(test.counts[limits.ResourceLimitServiceAccountsPerGroup], nil)
+				mockRunners.On("GetRunnerCount", mock.Anything, mock.Anything).
+					Return(test.counts[limits.ResourceLimitRunnerAgentsPerGroup], nil)
+				mockGPGKeys.On("GetGPGKeyCount", mock.Anything, mock.Anything).
+					Return(test.counts[limits.ResourceLimitGPGKeysPerGroup], nil)
+				mockManagedIdentities.On("GetManagedIdentityCount", mock.Anything, mock.Anything).
+					Return(test.counts[limits.ResourceLimitManagedIdentitiesPerGroup], nil)
+				mockModules.On("GetModuleCount", mock.Anything, mock.Anything).
+					Return(test.counts[limits.ResourceLimitTerraformModulesPerGroup], nil)
+				mockProviders.On("GetProviderCount", mock.Anything, mock.Anything).
+					Return(test.counts[limits.ResourceLimitTerraformProvidersPerGroup], nil)
+				mockVCSProviders.On("GetProviderCount", mock.Anything, mock.Anything).
+					Return(test.counts[limits.ResourceLimitVCSProvidersPerGroup], nil)
+				mockMirrors.On("GetVersionMirrorCount", mock.Anything, mock.Anything).
+					Return(test.counts[limits.ResourceLimitTerraformProviderVersionMirrorsPerGroup], nil)
+			}
+
+			dbClient := &db.Client{
+				Groups:                          mockGroups,
+				ResourceLimits:                  mockResourceLimits,
+				Workspaces:                      mockWorkspaces,
+				ServiceAccounts:                 mockServiceAccounts,
+				Runners:                         mockRunners,
+				GPGKeys:                         mockGPGKeys,
+				ManagedIdentities:               mockManagedIdentities,
+				TerraformModules:                mockModules,
+				TerraformProviders:              mockProviders,
+				VCSProviders:                    mockVCSProviders,
+				TerraformProviderVersionMirrors: mockMirrors,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient)
+
+			exceeded, err := service.GetExceededResourceLimits(auth.WithCaller(ctx, mockCaller), group.FullPath)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectExceeded, exceeded)
+		})
+	}
+}
+
+func TestSetGroupResourceLimit(t *testing.T) {
+	group := &models.Group{
+		Metadata: models.ResourceMetadata{ID: "group-1"},
+		FullPath: "root/child",
+	}
+
+	type testCase struct {
+		name            string
+		isAdmin         bool
+		input           *SetGroupResourceLimitInput
+		existingLimit   *models.ResourceLimit
+		group           *models.Group
+		expectErrorCode errors.CodeType
+	}
+
+	testCases := []testCase{
+		{
+			name:          "admin sets an override for a group",
+			isAdmin:       true,
+			input:         &SetGroupResourceLimitInput{GroupPath: group.FullPath, Name: string(limits.ResourceLimitManagedIdentitiesPerGroup), Value: 50},
+			existingLimit: &models.ResourceLimit{Name: string(limits.ResourceLimitManagedIdentitiesPerGroup), Value: 10},
+			group:         group,
+		},
+		{
+			name:            "non-admin user cannot set an override",
+			input:           &SetGroupResourceLimitInput{GroupPath: group.FullPath, Name: string(limits.ResourceLimitManagedIdentitiesPerGroup), Value: 50},
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name:            "invalid resource limit name",
+			isAdmin:         true,
+			input:           &SetGroupResourceLimitInput{GroupPath: group.FullPath, Name: "not-a-valid-resource-limit-name", Value: 50},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:            "resource limit cannot be overridden at the group level",
+			isAdmin:         true,
+			input:           &SetGroupResourceLimitInput{GroupPath: group.FullPath, Name: string(limits.ResourceLimitWorkspacesPerGroup), Value: 50},
+			existingLimit:   &models.ResourceLimit{Name: string(limits.ResourceLimitWorkspacesPerGroup), Value: 10},
+			group:           group,
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:            "negative value",
+			isAdmin:         true,
+			input:           &SetGroupResourceLimitInput{GroupPath: group.FullPath, Name: string(limits.ResourceLimitManagedIdentitiesPerGroup), Value: -1},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:            "group does not exist",
+			isAdmin:         true,
+			input:           &SetGroupResourceLimitInput{GroupPath: group.FullPath, Name: string(limits.ResourceLimitManagedIdentitiesPerGroup), Value: 50},
+			existingLimit:   &models.ResourceLimit{Name: string(limits.ResourceLimitManagedIdentitiesPerGroup), Value: 10},
+			expectErrorCode: errors.ENotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockGroups := db.NewMockGroups(t)
+			mockResourceLimits := db.NewMockResourceLimits(t)
+
+			mockResourceLimits.On("GetResourceLimit", mock.Anything, test.input.Name).Return(test.existingLimit, nil).Maybe()
+			mockGroups.On("GetGroupByFullPath", mock.Anything, test.input.GroupPath).Return(test.group, nil).Maybe()
+
+			expectLimit := &models.GroupResourceLimit{
+				GroupID: group.Metadata.ID,
+				Name:    test.input.Name,
+				Value:   test.input.Value,
+			}
+
+			if test.expectErrorCode == "" {
+				mockResourceLimits.On("SetGroupResourceLimit", mock.Anything, expectLimit).Return(expectLimit, nil)
+			}
+
+			dbClient := &db.Client{
+				Groups:         mockGroups,
+				ResourceLimits: mockResourceLimits,
+			}
+
+			testCaller := auth.NewUserCaller(&models.User{
+				Metadata: models.ResourceMetadata{ID: "user-1"},
+				Admin:    test.isAdmin,
+				Username: "user1",
+			}, nil, dbClient, nil)
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient)
+
+			groupLimit, err := service.SetGroupResourceLimit(auth.WithCaller(ctx, testCaller), test.input)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, expectLimit, groupLimit)
+		})
+	}
+}
+
+func TestGetGroupResourceLimit(t *testing.T) {
+	group := &models.Group{
+		Metadata: models.ResourceMetadata{ID: "group-1"},
+		FullPath: "root/child",
+	}
+
+	groupLimit := &models.GroupResourceLimit{
+		GroupID: group.Metadata.ID,
+		Name:    string(limits.ResourceLimitManagedIdentitiesPerGroup),
+		Value:   50,
+	}
+
+	type testCase struct {
+		name            string
+		groupLimit      *models.GroupResourceLimit
+		authError       error
+		expectErrorCode errors.CodeType
+	}
+
+	testCases := []testCase{
+		{
+			name:       "override exists for the group",
+			groupLimit: groupLimit,
+		},
+		{
+			name:            "group has no override",
+			expectErrorCode: errors.ENotFound,
+		},
+		{
+			name:            "caller cannot view the group",
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+			mockGroups := db.NewMockGroups(t)
+			mockResourceLimits := db.NewMockResourceLimits(t)
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.ViewGroupPermission, mock.Anything).Return(test.authError)
+
+			if test.authError == nil {
+				mockGroups.On("GetGroupByFullPath", mock.Anything, group.FullPath).Return(group, nil)
+				mockResourceLimits.On("GetGroupResourceLimit", mock.Anything, group.Metadata.ID, groupLimit.Name).Return(test.groupLimit, nil)
+			}
+
+			dbClient := &db.Client{
+				Groups:         mockGroups,
+				ResourceLimits: mockResourceLimits,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient)
+
+			actual, err := service.GetGroupResourceLimit(auth.WithCaller(ctx, mockCaller), group.FullPath, groupLimit.Name)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.groupLimit, actual)
+		})
+	}
+}
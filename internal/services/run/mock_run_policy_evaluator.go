@@ -0,0 +1,61 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package run
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	models "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+)
+
+// MockRunPolicyEvaluator is an autogenerated mock type for the RunPolicyEvaluator type
+type MockRunPolicyEvaluator struct {
+	mock.Mock
+}
+
+// Evaluate provides a mock function with given fields: ctx, run
+func (_m *MockRunPolicyEvaluator) Evaluate(ctx context.Context, run *models.Run) (bool, string, error) {
+	ret := _m.Called(ctx, run)
+
+	var r0 bool
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Run) (bool, string, error)); ok {
+		return rf(ctx, run)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Run) bool); ok {
+		r0 = rf(ctx, run)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Run) string); ok {
+		r1 = rf(ctx, run)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.Run) error); ok {
+		r2 = rf(ctx, run)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewMockRunPolicyEvaluator interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockRunPolicyEvaluator creates a new instance of MockRunPolicyEvaluator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockRunPolicyEvaluator(t mockConstructorTestingTNewMockRunPolicyEvaluator) *MockRunPolicyEvaluator {
+	mock := &MockRunPolicyEvaluator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -74,6 +74,30 @@ func (_m *MockService) CancelRun(ctx context.Context, options *CancelRunInput) (
 	return r0, r1
 }
 
+// CancelWorkspaceRuns provides a mock function with given fields: ctx, workspaceID
+func (_m *MockService) CancelWorkspaceRuns(ctx context.Context, workspaceID string) (int, error) {
+	ret := _m.Called(ctx, workspaceID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, workspaceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, workspaceID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, workspaceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateRun provides a mock function with given fields: ctx, options
 func (_m *MockService) CreateRun(ctx context.Context, options *CreateRunInput) (*models.Run, error) {
 	ret := _m.Called(ctx, options)
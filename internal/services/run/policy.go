@@ -0,0 +1,25 @@
+package run
+
+//go:generate mockery --name RunPolicyEvaluator --inpackage --case underscore
+
+import (
+	"context"
+
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
+)
+
+// RunPolicyEvaluator evaluates external policies (e.g. OPA) against a run before it is created.
+type RunPolicyEvaluator interface {
+	Evaluate(ctx context.Context, run *models.Run) (allow bool, reason string, err error)
+}
+
+type noopRunPolicyEvaluator struct{}
+
+// NewNoopRunPolicyEvaluator returns a RunPolicyEvaluator that allows every run.
+func NewNoopRunPolicyEvaluator() RunPolicyEvaluator {
+	return &noopRunPolicyEvaluator{}
+}
+
+func (e *noopRunPolicyEvaluator) Evaluate(_ context.Context, _ *models.Run) (bool, string, error) {
+	return true, "", nil
+}
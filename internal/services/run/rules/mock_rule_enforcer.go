@@ -15,17 +15,29 @@ type MockRuleEnforcer struct {
 }
 
 // EnforceRules provides a mock function with given fields: ctx, managedIdentity, input
-func (_m *MockRuleEnforcer) EnforceRules(ctx context.Context, managedIdentity *models.ManagedIdentity, input *RunDetails) error {
+func (_m *MockRuleEnforcer) EnforceRules(ctx context.Context, managedIdentity *models.ManagedIdentity, input *RunDetails) ([]models.ManagedIdentityAccessRuleEvaluation, error) {
 	ret := _m.Called(ctx, managedIdentity, input)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.ManagedIdentity, *RunDetails) error); ok {
+	var r0 []models.ManagedIdentityAccessRuleEvaluation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ManagedIdentity, *RunDetails) ([]models.ManagedIdentityAccessRuleEvaluation, error)); ok {
+		return rf(ctx, managedIdentity, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ManagedIdentity, *RunDetails) []models.ManagedIdentityAccessRuleEvaluation); ok {
 		r0 = rf(ctx, managedIdentity, input)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ManagedIdentityAccessRuleEvaluation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.ManagedIdentity, *RunDetails) error); ok {
+		r1 = rf(ctx, managedIdentity, input)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
 type mockConstructorTestingTNewMockRuleEnforcer interface {
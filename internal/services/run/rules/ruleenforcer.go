@@ -26,7 +26,7 @@ import (
 
 // RuleEnforcer is used to enforce managed identity access rules
 type RuleEnforcer interface {
-	EnforceRules(ctx context.Context, managedIdentity *models.ManagedIdentity, input *RunDetails) error
+	EnforceRules(ctx context.Context, managedIdentity *models.ManagedIdentity, input *RunDetails) ([]models.ManagedIdentityAccessRuleEvaluation, error)
 }
 
 type ruleTypeHandler func(ctx context.Context, dbClient *db.Client, rule *models.ManagedIdentityAccessRule, input *RunDetails) (string, error)
@@ -59,8 +59,8 @@ func NewRuleEnforcer(dbClient *db.Client) RuleEnforcer {
 }
 
 // EnforceRules verifies all the managed identity rules are satisfied. An error will be returned if any rules do
-// not pass.
-func (r *ruleEnforcer) EnforceRules(ctx context.Context, managedIdentity *models.ManagedIdentity, input *RunDetails) error {
+// not pass. The outcome of each evaluated rule is returned, including when the rules aren't satisfied.
+func (r *ruleEnforcer) EnforceRules(ctx context.Context, managedIdentity *models.ManagedIdentity, input *RunDetails) ([]models.ManagedIdentityAccessRuleEvaluation, error) {
 	results, err := r.dbClient.ManagedIdentities.GetManagedIdentityAccessRules(ctx,
 		&db.GetManagedIdentityAccessRulesInput{
 			Filter: &db.ManagedIdentityAccessRuleFilter{
@@ -68,7 +68,7 @@ func (r *ruleEnforcer) EnforceRules(ctx context.Context, managedIdentity *models
 			},
 		})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ruleMap := map[models.ManagedIdentityAccessRuleType][]models.ManagedIdentityAccessRule{}
@@ -84,17 +84,25 @@ func (r *ruleEnforcer) EnforceRules(ctx context.Context, managedIdentity *models
 		}
 	}
 
+	evaluations := []models.ManagedIdentityAccessRuleEvaluation{}
+
 	// Rules of different types use an AND condition and must all pass
 	for _, rules := range ruleMap {
-		if err := r.enforceRules(ctx, managedIdentity, input, rules); err != nil {
-			return err
+		if err := r.enforceRules(ctx, managedIdentity, input, rules, &evaluations); err != nil {
+			return evaluations, err
 		}
 	}
 
-	return nil
+	return evaluations, nil
 }
 
-func (r *ruleEnforcer) enforceRules(ctx context.Context, managedIdentity *models.ManagedIdentity, input *RunDetails, rules []models.ManagedIdentityAccessRule) error {
+func (r *ruleEnforcer) enforceRules(
+	ctx context.Context,
+	managedIdentity *models.ManagedIdentity,
+	input *RunDetails,
+	rules []models.ManagedIdentityAccessRule,
+	evaluations *[]models.ManagedIdentityAccessRuleEvaluation,
+) error {
 	// Rules of the same type use an OR condition (i.e. first successful rule will pass)
 	diagnostics := []string{}
 	for i, rule := range rules {
@@ -110,6 +118,15 @@ func (r *ruleEnforcer) enforceRules(ctx context.Context, managedIdentity *models
 			return err
 		}
 
+		*evaluations = append(*evaluations, models.ManagedIdentityAccessRuleEvaluation{
+			ManagedIdentityID: managedIdentity.Metadata.ID,
+			RuleID:            rule.Metadata.ID,
+			RuleType:          rule.Type,
+			RunStage:          rule.RunStage,
+			Diagnostic:        diag,
+			Passed:            diag == "",
+		})
+
 		if diag == "" {
 			// Break out of loop on first rule that passes since rules of the same type use an OR condition
 			break
@@ -134,60 +151,55 @@ func (r *ruleEnforcer) enforceRules(ctx context.Context, managedIdentity *models
 }
 
 func enforceEligiblePrincipalsRuleType(ctx context.Context, _ *db.Client, rule *models.ManagedIdentityAccessRule, _ *RunDetails) (string, error) {
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		return err.Error(), nil
+	}
+
 	// Check if subject is allowed to use this managed identity
-	if err := auth.HandleCaller(
-		ctx,
-		func(ctx context.Context, c *auth.UserCaller) error {
-			found := false
-			for _, userID := range rule.AllowedUserIDs {
-				if c.User.Metadata.ID == userID {
-					found = true
-					break
-				}
+	return IsEligiblePrincipal(ctx, rule, caller)
+}
+
+// IsEligiblePrincipal returns an empty string if the principal is allowed by the eligible principals rule,
+// otherwise the reason it is not.
+func IsEligiblePrincipal(ctx context.Context, rule *models.ManagedIdentityAccessRule, principal auth.Caller) (string, error) {
+	switch c := principal.(type) {
+	case *auth.UserCaller:
+		for _, userID := range rule.AllowedUserIDs {
+			if c.User.Metadata.ID == userID {
+				return "", nil
 			}
+		}
 
+		if len(rule.AllowedTeamIDs) > 0 {
 			// Check whether there is an intersection between the
 			// calling user's teams and this access rule's allowed teams.
-			userCallerTeams, err := c.GetTeams(ctx)
+			teams, err := c.GetTeams(ctx)
 			if err != nil {
-				return err
-			}
-			// The time spent converting from slice to map is expected to be minor.
-			userCallerTeamsMap := map[string]bool{}
-			for _, callerTeamID := range userCallerTeams {
-				userCallerTeamsMap[callerTeamID.Metadata.ID] = true
-			}
-			for _, teamID := range rule.AllowedTeamIDs {
-				if _, ok := userCallerTeamsMap[teamID]; ok {
-					found = true
-					break
-				}
+				return "", err
 			}
 
-			if !found {
-				return fmt.Errorf("user %s is not an eligible principal", c.User.Username)
-			}
-
-			return nil
-		},
-		func(_ context.Context, c *auth.ServiceAccountCaller) error {
-			found := false
-			for _, serviceAccountID := range rule.AllowedServiceAccountIDs {
-				if c.ServiceAccountID == serviceAccountID {
-					found = true
-					break
+			for _, team := range teams {
+				for _, teamID := range rule.AllowedTeamIDs {
+					if team.Metadata.ID == teamID {
+						return "", nil
+					}
 				}
 			}
-			if !found {
-				return fmt.Errorf("service account %s is not an eligible principal", c.ServiceAccountPath)
+		}
+
+		return fmt.Sprintf("user %s is not an eligible principal", c.User.Username), nil
+	case *auth.ServiceAccountCaller:
+		for _, serviceAccountID := range rule.AllowedServiceAccountIDs {
+			if c.ServiceAccountID == serviceAccountID {
+				return "", nil
 			}
+		}
 
-			return nil
-		},
-	); err != nil {
-		return err.Error(), nil
+		return fmt.Sprintf("service account %s is not an eligible principal", c.ServiceAccountPath), nil
+	default:
+		return "principal type is not eligible to assume a managed identity", nil
 	}
-	return "", nil
 }
 
 func enforceModuleAttestationRuleType(ctx context.Context, dbClient *db.Client, rule *models.ManagedIdentityAccessRule, input *RunDetails) (string, error) {
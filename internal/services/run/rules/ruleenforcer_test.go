@@ -841,7 +841,7 @@ func TestEnforceRules(t *testing.T) {
 
 			enforcer := NewRuleEnforcer(&dbClient)
 
-			err := enforcer.EnforceRules(ctx, &managedIdentity, test.runDetails)
+			_, err := enforcer.EnforceRules(ctx, &managedIdentity, test.runDetails)
 
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err), "unexpected error returned %v", err)
@@ -852,3 +852,105 @@ func TestEnforceRules(t *testing.T) {
 		})
 	}
 }
+
+func TestEnforceRulesReturnsEvaluations(t *testing.T) {
+	managedIdentity := models.ManagedIdentity{
+		Metadata: models.ResourceMetadata{
+			ID: "123",
+		},
+		ResourcePath: "test-group/test-managed-identity",
+	}
+
+	failingRule := models.ManagedIdentityAccessRule{
+		Metadata:                 models.ResourceMetadata{ID: "rule-1"},
+		Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:                 models.JobPlanType,
+		ManagedIdentityID:        managedIdentity.Metadata.ID,
+		AllowedServiceAccountIDs: []string{"sa2"},
+	}
+
+	passingRule := models.ManagedIdentityAccessRule{
+		Metadata:                 models.ResourceMetadata{ID: "rule-2"},
+		Type:                     models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:                 models.JobPlanType,
+		ManagedIdentityID:        managedIdentity.Metadata.ID,
+		AllowedServiceAccountIDs: []string{"sa1"},
+	}
+
+	// Rules for other run stages aren't evaluated
+	applyRule := models.ManagedIdentityAccessRule{
+		Metadata:          models.ResourceMetadata{ID: "rule-3"},
+		Type:              models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:          models.JobApplyType,
+		ManagedIdentityID: managedIdentity.Metadata.ID,
+	}
+
+	failedEvaluation := models.ManagedIdentityAccessRuleEvaluation{
+		ManagedIdentityID: managedIdentity.Metadata.ID,
+		RuleID:            failingRule.Metadata.ID,
+		RuleType:          models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:          models.JobPlanType,
+		Diagnostic:        "service account groupA/sa1 is not an eligible principal",
+	}
+
+	// Test cases
+	tests := []struct {
+		name              string
+		expectErrorCode   errors.CodeType
+		rules             []models.ManagedIdentityAccessRule
+		expectEvaluations []models.ManagedIdentityAccessRuleEvaluation
+	}{
+		{
+			name:  "failed rule is recorded before the passing rule",
+			rules: []models.ManagedIdentityAccessRule{failingRule, passingRule, applyRule},
+			expectEvaluations: []models.ManagedIdentityAccessRuleEvaluation{
+				failedEvaluation,
+				{
+					ManagedIdentityID: managedIdentity.Metadata.ID,
+					RuleID:            passingRule.Metadata.ID,
+					RuleType:          models.ManagedIdentityAccessRuleEligiblePrincipals,
+					RunStage:          models.JobPlanType,
+					Passed:            true,
+				},
+			},
+		},
+		{
+			name:              "failed evaluation is returned when no rules are satisfied",
+			rules:             []models.ManagedIdentityAccessRule{failingRule, applyRule},
+			expectErrorCode:   errors.EForbidden,
+			expectEvaluations: []models.ManagedIdentityAccessRuleEvaluation{failedEvaluation},
+		},
+		{
+			name:              "no rules for the run stage",
+			rules:             []models.ManagedIdentityAccessRule{applyRule},
+			expectEvaluations: []models.ManagedIdentityAccessRuleEvaluation{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testCaller := auth.NewServiceAccountCaller("sa1", "groupA/sa1", nil, nil, nil)
+
+			ctx, cancel := context.WithCancel(auth.WithCaller(context.Background(), testCaller))
+			defer cancel()
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+
+			mockManagedIdentities.On("GetManagedIdentityAccessRules", ctx, mock.Anything).Return(&db.ManagedIdentityAccessRulesResult{
+				ManagedIdentityAccessRules: test.rules,
+			}, nil)
+
+			enforcer := NewRuleEnforcer(&db.Client{ManagedIdentities: mockManagedIdentities})
+
+			evaluations, err := enforcer.EnforceRules(ctx, &managedIdentity, &RunDetails{RunStage: models.JobPlanType})
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err), "unexpected error returned %v", err)
+			} else {
+				require.Nil(t, err)
+			}
+
+			assert.Equal(t, test.expectEvaluations, evaluations)
+		})
+	}
+}
@@ -42,8 +42,20 @@ const (
 	forceCancelWait = 1 * time.Minute
 	// Max error message length for plan and apply errors.
 	maxErrorMessageLength = 2048
+	// autoApplyComment is the apply comment for runs that are applied automatically.
+	autoApplyComment = "Automatically applied since auto apply is enabled for the workspace"
 )
 
+// activeRunStatuses are the statuses of runs that haven't reached a terminal state.
+var activeRunStatuses = []models.RunStatus{
+	models.RunPending,
+	models.RunPlanQueued,
+	models.RunPlanning,
+	models.RunPlanned,
+	models.RunApplyQueued,
+	models.RunApplying,
+}
+
 // Variable represents a run variable
 type Variable struct {
 	Value         *string                 `json:"value"`
@@ -75,6 +87,8 @@ type GetRunsInput struct {
 	Workspace *models.Workspace
 	// Group filters the runs by the specified group
 	Group *models.Group
+	// AccessRuleEvaluationFailed filters the runs by whether a managed identity access rule wasn't satisfied
+	AccessRuleEvaluationFailed *bool
 }
 
 // CreateRunInput is the input for creating a new run
@@ -91,6 +105,8 @@ type CreateRunInput struct {
 	IsDestroy              bool
 	Refresh                bool
 	RefreshOnly            bool
+	// VariableOverrides replace namespace variables with the same key and category for this run only
+	VariableOverrides []models.Variable
 }
 
 // Validate attempts to ensure the CreateRunInput structure is in good form and able to be used.
@@ -147,6 +163,7 @@ type Service interface {
 	CreateRun(ctx context.Context, options *CreateRunInput) (*models.Run, error)
 	ApplyRun(ctx context.Context, runID string, comment *string) (*models.Run, error)
 	CancelRun(ctx context.Context, options *CancelRunInput) (*models.Run, error)
+	CancelWorkspaceRuns(ctx context.Context, workspaceID string) (int, error)
 	GetRunVariables(ctx context.Context, runID string) ([]Variable, error)
 	GetPlansByIDs(ctx context.Context, idList []string) ([]models.Plan, error)
 	GetPlan(ctx context.Context, planID string) (*models.Plan, error)
@@ -165,19 +182,21 @@ type Service interface {
 }
 
 type service struct {
-	logger          logger.Logger
-	dbClient        *db.Client
-	artifactStore   workspace.ArtifactStore
-	eventManager    *events.EventManager
-	jobService      job.Service
-	cliService      cli.Service
-	runStateManager *state.RunStateManager
-	activityService activityevent.Service
-	moduleService   moduleregistry.Service
-	moduleResolver  ModuleResolver
-	ruleEnforcer    rules.RuleEnforcer
-	limitChecker    limits.LimitChecker
-	planParser      plan.Parser
+	logger           logger.Logger
+	dbClient         *db.Client
+	artifactStore    workspace.ArtifactStore
+	eventManager     *events.EventManager
+	jobService       job.Service
+	workspaceService workspace.Service
+	cliService       cli.Service
+	runStateManager  *state.RunStateManager
+	activityService  activityevent.Service
+	moduleService    moduleregistry.Service
+	moduleResolver   ModuleResolver
+	ruleEnforcer     rules.RuleEnforcer
+	limitChecker     limits.LimitChecker
+	planParser       plan.Parser
+	policyEvaluator  RunPolicyEvaluator
 }
 
 // NewService creates an instance of Service
@@ -187,12 +206,15 @@ func NewService(
 	artifactStore workspace.ArtifactStore,
 	eventManager *events.EventManager,
 	jobService job.Service,
+	workspaceService workspace.Service,
 	cliService cli.Service,
 	activityService activityevent.Service,
 	moduleService moduleregistry.Service,
 	moduleResolver ModuleResolver,
 	runStateManager *state.RunStateManager,
 	limitChecker limits.LimitChecker,
+	policyEvaluator RunPolicyEvaluator,
+	planDiffMaxDepth int,
 ) Service {
 	return newService(
 		logger,
@@ -200,6 +222,7 @@ func NewService(
 		artifactStore,
 		eventManager,
 		jobService,
+		workspaceService,
 		cliService,
 		activityService,
 		moduleService,
@@ -207,7 +230,8 @@ func NewService(
 		runStateManager,
 		rules.NewRuleEnforcer(dbClient),
 		limitChecker,
-		plan.NewParser(),
+		plan.NewParser(plan.WithMaxDepth(planDiffMaxDepth)),
+		policyEvaluator,
 	)
 }
 
@@ -217,6 +241,7 @@ func newService(
 	artifactStore workspace.ArtifactStore,
 	eventManager *events.EventManager,
 	jobService job.Service,
+	workspaceService workspace.Service,
 	cliService cli.Service,
 	activityService activityevent.Service,
 	moduleService moduleregistry.Service,
@@ -225,13 +250,19 @@ func newService(
 	ruleEnforcer rules.RuleEnforcer,
 	limitChecker limits.LimitChecker,
 	planParser plan.Parser,
+	policyEvaluator RunPolicyEvaluator,
 ) Service {
+	if policyEvaluator == nil {
+		policyEvaluator = NewNoopRunPolicyEvaluator()
+	}
+
 	return &service{
 		logger,
 		dbClient,
 		artifactStore,
 		eventManager,
 		jobService,
+		workspaceService,
 		cliService,
 		runStateManager,
 		activityService,
@@ -240,6 +271,7 @@ func newService(
 		ruleEnforcer,
 		limitChecker,
 		planParser,
+		policyEvaluator,
 	}
 }
 
@@ -366,7 +398,7 @@ func (s *service) CreateRun(ctx context.Context, options *CreateRunInput) (*mode
 	}
 
 	// Build run variables
-	runVariables, err := s.buildRunVariables(ctx, options.WorkspaceID, options.Variables)
+	runVariables, err := s.buildRunVariables(ctx, options.WorkspaceID, options.Variables, options.VariableOverrides)
 	if err != nil {
 		tracing.RecordError(span, err, "failed to build run variables")
 		return nil, errors.Wrap(
@@ -466,6 +498,12 @@ func (s *service) CreateRun(ctx context.Context, options *CreateRunInput) (*mode
 		terraformVersion = options.TerraformVersion
 	}
 
+	// Verify that a runner is able to claim the jobs for this run
+	if err = s.verifyRunnerAvailable(ctx, ws); err != nil {
+		tracing.RecordError(span, err, "failed to verify runner is available")
+		return nil, err
+	}
+
 	// Enforce the workspace's option to prevent a destroy run.
 	if options.IsDestroy && ws.PreventDestroyPlan {
 		return nil, errors.New(
@@ -497,11 +535,29 @@ func (s *service) CreateRun(ctx context.Context, options *CreateRunInput) (*mode
 	}
 
 	// Verify that subject has permission to create a plan for all of the assigned managed identities
-	if err = s.enforceManagedIdentityRules(ctx, managedIdentities, runDetails); err != nil {
+	accessRuleEvaluations, err := s.enforceManagedIdentityRules(ctx, managedIdentities, runDetails)
+	if err != nil {
+		// The run won't be created so the evaluations are recorded in an activity event instead
+		s.createAccessRuleDeniedActivityEvent(ctx, ws, accessRuleEvaluations)
 		tracing.RecordError(span, err, "failed to verify subject can enforce managed identity rules")
 		return nil, err
 	}
 
+	// Runs triggered by a VCS webhook skip the manual apply when the workspace has auto apply enabled.
+	_, isVCSCaller := caller.(*auth.VCSWorkspaceLinkCaller)
+	autoApply := isVCSCaller && ws.AutoApply
+	if autoApply && len(managedIdentities) > 0 {
+		// The apply will be queued without a caller, so the apply stage rules are checked against the webhook now.
+		applyRunDetails := *runDetails
+		applyRunDetails.RunStage = models.JobApplyType
+		applyEvaluations, rErr := s.enforceManagedIdentityRules(ctx, managedIdentities, &applyRunDetails)
+		accessRuleEvaluations = append(accessRuleEvaluations, applyEvaluations...)
+		if rErr != nil {
+			s.logger.Infof("run for workspace %s must be applied manually since managed identity apply rules are not satisfied: %v", ws.FullPath, rErr)
+			autoApply = false
+		}
+	}
+
 	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
 	if err != nil {
 		tracing.RecordError(span, err, "failed to begin DB transaction")
@@ -567,6 +623,7 @@ func (s *service) CreateRun(ctx context.Context, options *CreateRunInput) (*mode
 		PlanID:                 plan.Metadata.ID,
 		TerraformVersion:       terraformVersion,
 		TargetAddresses:        options.TargetAddresses,
+		AccessRuleEvaluations:  accessRuleEvaluations,
 		Refresh:                options.Refresh,
 		RefreshOnly:            options.RefreshOnly,
 	}
@@ -588,6 +645,19 @@ func (s *service) CreateRun(ctx context.Context, options *CreateRunInput) (*mode
 		}
 
 		createRunOptions.ApplyID = apply.Metadata.ID
+		createRunOptions.AutoApply = autoApply
+	}
+
+	// Evaluate any external run policies before the run is created.
+	allow, reason, err := s.policyEvaluator.Evaluate(txContext, &createRunOptions)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to evaluate run policy")
+		return nil, errors.Wrap(err, "failed to evaluate run policy")
+	}
+
+	if !allow {
+		tracing.RecordError(span, nil, "run denied by policy")
+		return nil, errors.New("run denied by policy: %s", reason, errors.WithErrorCode(errors.EForbidden))
 	}
 
 	run, err := s.dbClient.Runs.CreateRun(txContext, &createRunOptions)
@@ -759,88 +829,32 @@ func (s *service) ApplyRun(ctx context.Context, runID string, comment *string) (
 		}
 
 		// Verify that subject has permission to create a plan for all of the assigned managed identities
-		if err = s.enforceManagedIdentityRules(ctx, managedIdentities, runDetails); err != nil {
-			tracing.RecordError(span, err, "failed to verify subject can enforce managed identity rules")
-			return nil, err
+		evaluations, rErr := s.enforceManagedIdentityRules(ctx, managedIdentities, runDetails)
+
+		// The evaluations are recorded even when the rules aren't satisfied so blocked applies can be audited.
+		// They replace the ones from a previous attempt to apply the run so retries don't accumulate.
+		if len(evaluations) > 0 {
+			accessRuleEvaluations := []models.ManagedIdentityAccessRuleEvaluation{}
+			for _, evaluation := range run.AccessRuleEvaluations {
+				if evaluation.RunStage != runDetails.RunStage {
+					accessRuleEvaluations = append(accessRuleEvaluations, evaluation)
+				}
+			}
+			run.AccessRuleEvaluations = append(accessRuleEvaluations, evaluations...)
+			if run, err = s.runStateManager.UpdateRun(ctx, run); err != nil {
+				tracing.RecordError(span, err, "failed to record managed identity access rule evaluations")
+				return nil, err
+			}
 		}
-	}
-
-	// Get apply resource
-	apply, err := s.dbClient.Applies.GetApply(ctx, run.ApplyID)
-	if err != nil {
-		tracing.RecordError(span, err, "failed to get apply")
-		return nil, errors.Wrap(
-			err,
-			"Failed to get apply resource",
-		)
-	}
-
-	apply.Status = models.ApplyQueued
-	apply.TriggeredBy = caller.GetSubject()
-
-	if comment != nil {
-		apply.Comment = *comment
-	}
-
-	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
-	if err != nil {
-		tracing.RecordError(span, err, "failed to begin DB transaction")
-		return nil, err
-	}
 
-	defer func() {
-		if txErr := s.dbClient.Transactions.RollbackTx(txContext); txErr != nil {
-			s.logger.Errorf("failed to rollback tx for ApplyRun: %v", txErr)
+		if rErr != nil {
+			tracing.RecordError(span, rErr, "failed to verify subject can enforce managed identity rules")
+			return nil, rErr
 		}
-	}()
-
-	_, err = s.runStateManager.UpdateApply(txContext, apply)
-	if err != nil {
-		tracing.RecordError(span, err, "failed to update apply")
-		return nil, errors.Wrap(
-			err,
-			"Failed to update apply resource",
-		)
-	}
-
-	now := time.Now()
-
-	// Create job for apply
-	job := models.Job{
-		Status:          models.JobQueued,
-		Type:            models.JobApplyType,
-		WorkspaceID:     run.WorkspaceID,
-		RunID:           run.Metadata.ID,
-		CancelRequested: false,
-		Timestamps: models.JobTimestamps{
-			QueuedTimestamp: &now,
-		},
-		MaxJobDuration: *ws.MaxJobDuration,
-	}
-
-	// Create Job
-	createdJob, err := s.dbClient.Jobs.CreateJob(txContext, &job)
-	if err != nil {
-		tracing.RecordError(span, err, "failed to create job")
-		return nil, errors.Wrap(
-			err,
-			"Failed to create job",
-		)
-	}
-
-	_, err = s.dbClient.LogStreams.CreateLogStream(txContext, &models.LogStream{
-		JobID: &createdJob.Metadata.ID,
-	})
-	if err != nil {
-		tracing.RecordError(span, err, "failed to create log stream for apply job")
-		return nil, errors.Wrap(
-			err,
-			"Failed to create log stream for apply job",
-		)
 	}
 
-	if err := s.dbClient.Transactions.CommitTx(txContext); err != nil {
-		tracing.RecordError(span, err, "failed to commit DB transaction")
+	if err = s.queueApply(ctx, run, ws, caller.GetSubject(), comment); err != nil {
+		tracing.RecordError(span, err, "failed to queue apply")
 		return nil, err
 	}
 
@@ -1025,6 +1039,124 @@ func (s *service) CancelRun(ctx context.Context, options *CancelRunInput) (*mode
 	return updatedRun, nil
 }
 
+func (s *service) CancelWorkspaceRuns(ctx context.Context, workspaceID string) (int, error) {
+	ctx, span := tracer.Start(ctx, "svc.CancelWorkspaceRuns")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return 0, err
+	}
+
+	// Only owners of the workspace are allowed to cancel all of its runs.
+	err = caller.RequirePermission(ctx, permissions.UpdateNamespaceMembershipPermission, auth.WithWorkspaceID(workspaceID))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return 0, err
+	}
+
+	ws, err := s.dbClient.Workspaces.GetWorkspaceByID(ctx, workspaceID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get workspace by ID")
+		return 0, err
+	}
+
+	if ws == nil {
+		tracing.RecordError(span, nil, "workspace not found")
+		return 0, errors.New("workspace with id %s not found", workspaceID, errors.WithErrorCode(errors.ENotFound))
+	}
+
+	runsResult, err := s.dbClient.Runs.GetRuns(ctx, &db.GetRunsInput{
+		Filter: &db.RunFilter{
+			WorkspaceID: &workspaceID,
+			Statuses:    activeRunStatuses,
+		},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get active runs for workspace")
+		return 0, err
+	}
+
+	lock, err := s.dbClient.WorkspaceRunLocks.GetWorkspaceRunLock(ctx, workspaceID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get workspace run lock")
+		return 0, err
+	}
+
+	// Cancel the run holding the workspace run lock last, so a waiting run
+	// can't acquire the lock once the lock holder releases it.
+	toCancel := []models.Run{}
+	var lockHolder *models.Run
+	for _, run := range runsResult.Runs {
+		runCopy := run
+
+		// Runs that already received a cancel request are still being canceled.
+		if runCopy.ForceCancelAvailableAt != nil {
+			continue
+		}
+
+		if lock != nil && lock.RunID == runCopy.Metadata.ID {
+			lockHolder = &runCopy
+			continue
+		}
+
+		toCancel = append(toCancel, runCopy)
+	}
+
+	if lockHolder != nil {
+		toCancel = append(toCancel, *lockHolder)
+	}
+
+	var cancelErr error
+	canceledCount := 0
+	for _, run := range toCancel {
+		if _, err = s.CancelRun(ctx, &CancelRunInput{
+			RunID:   run.Metadata.ID,
+			Comment: ptr.String("Canceled with all other active runs in the workspace"),
+		}); err != nil {
+			// Keep canceling the remaining runs since this is used for emergency stops.
+			s.logger.Errorf("failed to cancel run %s in workspace %s: %v", run.Metadata.ID, ws.FullPath, err)
+			if cancelErr == nil {
+				cancelErr = errors.Wrap(err, "failed to cancel run %s", run.Metadata.ID)
+			}
+			continue
+		}
+
+		canceledCount++
+	}
+
+	if canceledCount > 0 {
+		if _, err = s.activityService.CreateActivityEvent(ctx,
+			&activityevent.CreateActivityEventInput{
+				NamespacePath: &ws.FullPath,
+				Action:        models.ActionCancel,
+				TargetType:    models.TargetWorkspace,
+				TargetID:      ws.Metadata.ID,
+				Payload: &models.ActivityEventCancelWorkspaceRunsPayload{
+					CanceledRunCount: int32(canceledCount),
+				},
+			}); err != nil {
+			tracing.RecordError(span, err, "failed to create activity event")
+			return canceledCount, err
+		}
+	}
+
+	if cancelErr != nil {
+		tracing.RecordError(span, cancelErr, "failed to cancel all active runs")
+		return canceledCount, cancelErr
+	}
+
+	s.logger.Infow("Canceled all active runs in a workspace.",
+		"caller", caller.GetSubject(),
+		"workspaceID", workspaceID,
+		"canceledRunCount", canceledCount,
+	)
+
+	return canceledCount, nil
+}
+
 func (s *service) gracefullyCancelRun(ctx context.Context, run *models.Run) (*models.Run, error) {
 
 	// Update run's ForceCancelAvailableAt.
@@ -1171,7 +1303,9 @@ func (s *service) GetRuns(ctx context.Context, input *GetRunsInput) (*db.RunsRes
 		return nil, err
 	}
 
-	filter := &db.RunFilter{}
+	filter := &db.RunFilter{
+		AccessRuleEvaluationFailed: input.AccessRuleEvaluationFailed,
+	}
 
 	switch {
 	case input.Workspace != nil:
@@ -1348,7 +1482,17 @@ func (s *service) UpdatePlan(ctx context.Context, plan *models.Plan) (*models.Pl
 		return nil, err
 	}
 
-	return s.runStateManager.UpdatePlan(ctx, plan)
+	updatedPlan, err := s.runStateManager.UpdatePlan(ctx, plan)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to update plan")
+		return nil, err
+	}
+
+	if updatedPlan.Status == models.PlanFinished {
+		s.autoApplyRun(ctx, updatedPlan.Metadata.ID)
+	}
+
+	return updatedPlan, nil
 }
 
 func (s *service) DownloadPlan(ctx context.Context, planID string) (io.ReadCloser, error) {
@@ -1559,6 +1703,8 @@ func (s *service) ProcessPlanData(ctx context.Context, planID string, tfPlan *tf
 		case action.CreateThenDelete, action.DeleteThenCreate:
 			planModel.Summary.ResourceAdditions++
 			planModel.Summary.ResourceDestructions++
+		case action.Move:
+			// Moved resources keep their existing object so they aren't additions or destructions
 		}
 
 		if change.Imported {
@@ -1880,7 +2026,9 @@ func (s *service) GetStateVersionsByRunIDs(ctx context.Context, runIDs []string)
 	return []models.StateVersion{}, nil
 }
 
-func (s *service) buildRunVariables(ctx context.Context, workspaceID string, runVariables []Variable) ([]Variable, error) {
+// buildRunVariables computes the effective variables for a run. Run variables have the highest precedence,
+// followed by the variable overrides and then the namespace variables from the closest ancestor.
+func (s *service) buildRunVariables(ctx context.Context, workspaceID string, runVariables []Variable, overrides []models.Variable) ([]Variable, error) {
 	// Get Workspace
 	ws, err := s.dbClient.Workspaces.GetWorkspaceByID(ctx, workspaceID)
 	if err != nil {
@@ -1932,6 +2080,57 @@ func (s *service) buildRunVariables(ctx context.Context, workspaceID string, run
 		}
 	}
 
+	overrideMap := map[string]models.Variable{}
+	for _, v := range overrides {
+		if v.Category == models.EnvironmentVariableCategory && v.Hcl {
+			return nil, errors.New("HCL variables are not supported for the environment category", errors.WithErrorCode(errors.EInvalid))
+		}
+
+		keyAndCategory := buildMapKey(v.Key, string(v.Category))
+		if _, ok := overrideMap[keyAndCategory]; ok {
+			return nil, errors.New("%s variable %s is overridden more than once", v.Category, v.Key, errors.WithErrorCode(errors.EInvalid))
+		}
+
+		if _, ok := variableMap[keyAndCategory]; ok {
+			return nil, errors.New("%s variable %s cannot be both a run variable and an override", v.Category, v.Key, errors.WithErrorCode(errors.EInvalid))
+		}
+
+		overrideMap[keyAndCategory] = v
+	}
+
+	// Both run variables and overrides replace namespace variables, so both are checked against the overridable flag.
+	// The variables are sorted by descending namespace path, so the first match is the one being overridden.
+	checked := map[string]struct{}{}
+	for _, v := range result.Variables {
+		keyAndCategory := buildMapKey(v.Key, string(v.Category))
+		_, isOverride := overrideMap[keyAndCategory]
+		_, isRunVariable := variableMap[keyAndCategory]
+		if !isOverride && !isRunVariable {
+			continue
+		}
+
+		if _, ok := checked[keyAndCategory]; ok {
+			continue
+		}
+		checked[keyAndCategory] = struct{}{}
+
+		if v.NonOverridable {
+			return nil, errors.New(
+				"%s variable %s in namespace %s cannot be overridden", v.Category, v.Key, v.NamespacePath,
+				errors.WithErrorCode(errors.EInvalid),
+			)
+		}
+	}
+
+	for keyAndCategory, v := range overrideMap {
+		variableMap[keyAndCategory] = Variable{
+			Key:      v.Key,
+			Value:    v.Value,
+			Category: v.Category,
+			Hcl:      v.Hcl,
+		}
+	}
+
 	for _, v := range result.Variables {
 		vCopy := v
 
@@ -1968,17 +2167,181 @@ func (s *service) getLatestJobByRunAndType(ctx context.Context, runID string, jo
 	return job, nil
 }
 
-func (s *service) enforceManagedIdentityRules(ctx context.Context, managedIdentities []models.ManagedIdentity, runDetails *rules.RunDetails) error {
+// enforceManagedIdentityRules returns the outcome of each evaluated access rule along with an error if the rules
+// for any of the managed identities aren't satisfied.
+func (s *service) enforceManagedIdentityRules(ctx context.Context, managedIdentities []models.ManagedIdentity, runDetails *rules.RunDetails) ([]models.ManagedIdentityAccessRuleEvaluation, error) {
+	var evaluations []models.ManagedIdentityAccessRuleEvaluation
 	for _, mi := range managedIdentities {
 		miCopy := mi
-		if err := s.ruleEnforcer.EnforceRules(ctx, &miCopy, runDetails); err != nil {
-			return err
+		miEvaluations, err := s.ruleEnforcer.EnforceRules(ctx, &miCopy, runDetails)
+		evaluations = append(evaluations, miEvaluations...)
+		if err != nil {
+			return evaluations, err
 		}
 	}
 
+	return evaluations, nil
+}
+
+// createAccessRuleDeniedActivityEvent records the failed access rule evaluations for a run that was rejected
+// before it could be created.
+func (s *service) createAccessRuleDeniedActivityEvent(ctx context.Context, ws *models.Workspace,
+	evaluations []models.ManagedIdentityAccessRuleEvaluation,
+) {
+	failedEvaluations := []models.ManagedIdentityAccessRuleEvaluation{}
+	for _, evaluation := range evaluations {
+		if !evaluation.Passed {
+			failedEvaluations = append(failedEvaluations, evaluation)
+		}
+	}
+
+	if len(failedEvaluations) == 0 {
+		return
+	}
+
+	if _, err := s.activityService.CreateActivityEvent(ctx,
+		&activityevent.CreateActivityEventInput{
+			NamespacePath: &ws.FullPath,
+			Action:        models.ActionAccessRuleDenied,
+			TargetType:    models.TargetWorkspace,
+			TargetID:      ws.Metadata.ID,
+			Payload: &models.ActivityEventAccessRuleDeniedPayload{
+				Evaluations: failedEvaluations,
+			},
+		}); err != nil {
+		// The rule error is still returned to the caller so only log the failure here
+		s.logger.Errorf("failed to create access rule denied activity event for workspace %s: %v", ws.FullPath, err)
+	}
+}
+
+// acquireWorkspaceRunLock creates the workspace run lock for the specified run or returns
+// a conflict error if the workspace is already locked by another run.
+func (s *service) acquireWorkspaceRunLock(ctx context.Context, run *models.Run, subject string) error {
+	lock, err := s.dbClient.WorkspaceRunLocks.GetWorkspaceRunLock(ctx, run.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	if lock != nil {
+		return errors.New("workspace is locked by run %s", lock.RunID, errors.WithErrorCode(errors.EConflict))
+	}
+
+	if _, err = s.dbClient.WorkspaceRunLocks.CreateWorkspaceRunLock(ctx, &models.WorkspaceRunLock{
+		WorkspaceID: run.WorkspaceID,
+		RunID:       run.Metadata.ID,
+		CreatedBy:   subject,
+	}); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// queueApply queues the apply stage of a run by locking the workspace and creating the apply job.
+func (s *service) queueApply(ctx context.Context, run *models.Run, ws *models.Workspace, triggeredBy string, comment *string) error {
+	apply, err := s.dbClient.Applies.GetApply(ctx, run.ApplyID)
+	if err != nil {
+		return errors.Wrap(
+			err,
+			"Failed to get apply resource",
+		)
+	}
+
+	apply.Status = models.ApplyQueued
+	apply.TriggeredBy = triggeredBy
+
+	if comment != nil {
+		apply.Comment = *comment
+	}
+
+	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if txErr := s.dbClient.Transactions.RollbackTx(txContext); txErr != nil {
+			s.logger.Errorf("failed to rollback tx for queueApply: %v", txErr)
+		}
+	}()
+
+	// Lock the workspace so no other run can enter the apply stage until this run is finished.
+	if err = s.acquireWorkspaceRunLock(txContext, run, triggeredBy); err != nil {
+		return err
+	}
+
+	if _, err = s.runStateManager.UpdateApply(txContext, apply); err != nil {
+		return errors.Wrap(
+			err,
+			"Failed to update apply resource",
+		)
+	}
+
+	now := time.Now()
+
+	// Create job for apply
+	job := models.Job{
+		Status:          models.JobQueued,
+		Type:            models.JobApplyType,
+		WorkspaceID:     run.WorkspaceID,
+		RunID:           run.Metadata.ID,
+		CancelRequested: false,
+		Timestamps: models.JobTimestamps{
+			QueuedTimestamp: &now,
+		},
+		MaxJobDuration: *ws.MaxJobDuration,
+	}
+
+	createdJob, err := s.dbClient.Jobs.CreateJob(txContext, &job)
+	if err != nil {
+		return errors.Wrap(
+			err,
+			"Failed to create job",
+		)
+	}
+
+	if _, err = s.dbClient.LogStreams.CreateLogStream(txContext, &models.LogStream{
+		JobID: &createdJob.Metadata.ID,
+	}); err != nil {
+		return errors.Wrap(
+			err,
+			"Failed to create log stream for apply job",
+		)
+	}
+
+	return s.dbClient.Transactions.CommitTx(txContext)
+}
+
+// autoApplyRun queues the apply for a run that was planned with auto apply enabled. Failures are
+// only logged since the run can still be applied manually.
+func (s *service) autoApplyRun(ctx context.Context, planID string) {
+	run, err := s.dbClient.Runs.GetRunByPlanID(ctx, planID)
+	if err != nil {
+		s.logger.Errorf("failed to get run for plan %s to auto apply: %v", planID, err)
+		return
+	}
+
+	if run == nil || !run.AutoApply || run.Status != models.RunPlanned {
+		return
+	}
+
+	ws, err := s.dbClient.Workspaces.GetWorkspaceByID(ctx, run.WorkspaceID)
+	if err != nil || ws == nil {
+		s.logger.Errorf("failed to get workspace %s to auto apply run %s: %v", run.WorkspaceID, run.Metadata.ID, err)
+		return
+	}
+
+	if err = s.queueApply(ctx, run, ws, run.CreatedBy, ptr.String(autoApplyComment)); err != nil {
+		s.logger.Infof("failed to auto apply run %s for workspace %s, run must be applied manually: %v", run.Metadata.ID, ws.FullPath, err)
+		return
+	}
+
+	s.logger.Infow("Automatically applied a run.",
+		"workspaceID", run.WorkspaceID,
+		"runID", run.Metadata.ID,
+	)
+}
+
 func (s *service) getRun(ctx context.Context, runID string) (*models.Run, error) {
 	run, err := s.dbClient.Runs.GetRun(ctx, runID)
 	if err != nil {
@@ -2006,3 +2369,47 @@ func truncateErrorMessage(errorMessage string) *string {
 	}
 	return &errorMessage
 }
+
+// verifyRunnerAvailable returns an error if the workspace has effective runner tags that don't match any enabled runner
+func (s *service) verifyRunnerAvailable(ctx context.Context, ws *models.Workspace) error {
+	tags, err := s.workspaceService.GetEffectiveRunnerTags(ctx, ws.Metadata.ID)
+	if err != nil {
+		return err
+	}
+
+	// Jobs without tags can be claimed by any runner
+	if len(tags) == 0 {
+		return nil
+	}
+
+	sharedRunnerType := models.SharedRunnerType
+	filters := []*db.RunnerFilter{
+		{
+			RunnerType: &sharedRunnerType,
+			Enabled:    ptr.Bool(true),
+		},
+		{
+			NamespacePaths: models.ExpandGroupPath(ws.GetGroupPath()),
+			Enabled:        ptr.Bool(true),
+		},
+	}
+
+	for _, filter := range filters {
+		runnersResult, err := s.dbClient.Runners.GetRunners(ctx, &db.GetRunnersInput{Filter: filter})
+		if err != nil {
+			return err
+		}
+
+		for _, runner := range runnersResult.Runners {
+			if runner.HasTags(tags) {
+				return nil
+			}
+		}
+	}
+
+	return errors.New(
+		"no runner is available with runner tags %s, update the runner tags for the workspace's group or register a runner with these tags",
+		strings.Join(tags, ", "),
+		errors.WithErrorCode(errors.EInvalid),
+	)
+}
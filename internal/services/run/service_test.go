@@ -24,6 +24,7 @@ import (
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/plan"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/plan/action"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/activityevent"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/job"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/moduleregistry"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/run/rules"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/run/state"
@@ -48,6 +49,8 @@ type mockDBClient struct {
 	MockTeamMembers           *db.MockTeamMembers
 	MockLogStreams            *db.MockLogStreams
 	MockResourceLimits        *db.MockResourceLimits
+	MockWorkspaceRunLocks     *db.MockWorkspaceRunLocks
+	MockRunners               *db.MockRunners
 }
 
 func buildDBClientWithMocks(t *testing.T) *mockDBClient {
@@ -91,6 +94,12 @@ func buildDBClientWithMocks(t *testing.T) *mockDBClient {
 	mockResourceLimits := db.MockResourceLimits{}
 	mockResourceLimits.Test(t)
 
+	mockWorkspaceRunLocks := db.MockWorkspaceRunLocks{}
+	mockWorkspaceRunLocks.Test(t)
+
+	mockRunners := db.MockRunners{}
+	mockRunners.Test(t)
+
 	return &mockDBClient{
 		Client: &db.Client{
 			Transactions:          &mockTransactions,
@@ -106,6 +115,8 @@ func buildDBClientWithMocks(t *testing.T) *mockDBClient {
 			TeamMembers:           &mockTeamMembers,
 			LogStreams:            &mockLogStreams,
 			ResourceLimits:        &mockResourceLimits,
+			WorkspaceRunLocks:     &mockWorkspaceRunLocks,
+			Runners:               &mockRunners,
 		},
 		MockTransactions:          &mockTransactions,
 		MockManagedIdentities:     &mockManagedIdentities,
@@ -120,6 +131,8 @@ func buildDBClientWithMocks(t *testing.T) *mockDBClient {
 		MockTeamMembers:           &mockTeamMembers,
 		MockLogStreams:            &mockLogStreams,
 		MockResourceLimits:        &mockResourceLimits,
+		MockWorkspaceRunLocks:     &mockWorkspaceRunLocks,
+		MockRunners:               &mockRunners,
 	}
 }
 
@@ -152,15 +165,24 @@ func TestCreateRunWithManagedIdentityAccessRules(t *testing.T) {
 		RunID:       run.Metadata.ID,
 	}
 
+	failedEvaluation := models.ManagedIdentityAccessRuleEvaluation{
+		ManagedIdentityID: "1",
+		RuleID:            "rule1",
+		RuleType:          models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:          models.JobPlanType,
+		Diagnostic:        "user is not an eligible principal",
+	}
+
 	// Test cases
 	tests := []struct {
-		name                   string
-		injectJob              *models.Job
-		expectErrorCode        errors.CodeType
-		enforceRulesResponse   error
-		managedIdentities      []models.ManagedIdentity
-		limit                  int
-		injectRunsPerWorkspace int32
+		name                    string
+		injectJob               *models.Job
+		expectErrorCode         errors.CodeType
+		enforceRulesResponse    error
+		managedIdentities       []models.ManagedIdentity
+		enforceRulesEvaluations []models.ManagedIdentityAccessRuleEvaluation
+		limit                   int
+		injectRunsPerWorkspace  int32
 	}{
 		{
 			name:      "run is created because all managed identity rules are satisfied",
@@ -191,8 +213,9 @@ func TestCreateRunWithManagedIdentityAccessRules(t *testing.T) {
 					},
 				},
 			},
-			enforceRulesResponse: errors.New("rule not satisfied", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode:      errors.EForbidden,
+			enforceRulesEvaluations: []models.ManagedIdentityAccessRuleEvaluation{failedEvaluation},
+			enforceRulesResponse:    errors.New("rule not satisfied", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:         errors.EForbidden,
 		},
 		{
 			name:                   "resource limit exceeded",
@@ -285,9 +308,12 @@ func TestCreateRunWithManagedIdentityAccessRules(t *testing.T) {
 
 			for _, mi := range test.managedIdentities {
 				miCopy := mi
-				ruleEnforcer.On("EnforceRules", mock.Anything, &miCopy, mock.Anything).Return(test.enforceRulesResponse)
+				ruleEnforcer.On("EnforceRules", mock.Anything, &miCopy, mock.Anything).Return(test.enforceRulesEvaluations, test.enforceRulesResponse)
 			}
 
+			mockWorkspaceService := workspace.NewMockService(t)
+			mockWorkspaceService.On("GetEffectiveRunnerTags", mock.Anything, mock.Anything).Return([]string{}, nil).Maybe()
+
 			logger, _ := logger.NewForTest()
 			service := newService(
 				logger,
@@ -295,6 +321,7 @@ func TestCreateRunWithManagedIdentityAccessRules(t *testing.T) {
 				&mockArtifactStore,
 				nil,
 				nil,
+				mockWorkspaceService,
 				nil,
 				&mockActivityEvents,
 				mockModuleService,
@@ -303,12 +330,27 @@ func TestCreateRunWithManagedIdentityAccessRules(t *testing.T) {
 				ruleEnforcer,
 				limits.NewLimitChecker(dbClient.Client),
 				nil,
+				nil,
 			)
 
 			_, err := service.CreateRun(auth.WithCaller(ctx, mockCaller), &CreateRunInput{
 				WorkspaceID:            ws.Metadata.ID,
 				ConfigurationVersionID: &configurationVersionID,
 			})
+
+			// The failed evaluations must be recorded in an activity event since the run isn't created
+			if len(test.enforceRulesEvaluations) > 0 {
+				mockActivityEvents.AssertCalled(t, "CreateActivityEvent", mock.Anything, &activityevent.CreateActivityEventInput{
+					NamespacePath: &ws.FullPath,
+					Action:        models.ActionAccessRuleDenied,
+					TargetType:    models.TargetWorkspace,
+					TargetID:      ws.Metadata.ID,
+					Payload: &models.ActivityEventAccessRuleDeniedPayload{
+						Evaluations: test.enforceRulesEvaluations,
+					},
+				})
+			}
+
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
 			} else if err != nil {
@@ -318,6 +360,179 @@ func TestCreateRunWithManagedIdentityAccessRules(t *testing.T) {
 	}
 }
 
+func TestCreateRunWithRunnerTags(t *testing.T) {
+	configurationVersionID := "cv1"
+	currentTime := time.Now().UTC()
+
+	ws := &models.Workspace{
+		Metadata: models.ResourceMetadata{
+			ID: "ws1",
+		},
+		FullPath:       "groupA/ws1",
+		MaxJobDuration: ptr.Int32(60),
+	}
+
+	// Test cases
+	tests := []struct {
+		name            string
+		expectErrorCode errors.CodeType
+		runnerTags      []string
+		sharedRunners   []models.Runner
+		groupRunners    []models.Runner
+	}{
+		{
+			name:       "run is created because workspace has no runner tags",
+			runnerTags: []string{},
+		},
+		{
+			name:       "run is created because a shared runner has all the runner tags",
+			runnerTags: []string{"tag1", "tag2"},
+			sharedRunners: []models.Runner{
+				{Name: "shared-runner", Type: models.SharedRunnerType, Tags: []string{"tag2", "tag1", "tag3"}},
+			},
+		},
+		{
+			name:       "run is created because a group runner has all the runner tags",
+			runnerTags: []string{"tag1"},
+			sharedRunners: []models.Runner{
+				{Name: "shared-runner", Type: models.SharedRunnerType, Tags: []string{}},
+			},
+			groupRunners: []models.Runner{
+				{Name: "group-runner", Type: models.GroupRunnerType, Tags: []string{"tag1"}},
+			},
+		},
+		{
+			name:       "run is not created because no runner has all the runner tags",
+			runnerTags: []string{"tag1", "tag2"},
+			sharedRunners: []models.Runner{
+				{Name: "shared-runner", Type: models.SharedRunnerType, Tags: []string{"tag1"}},
+			},
+			groupRunners: []models.Runner{
+				{Name: "group-runner", Type: models.GroupRunnerType, Tags: []string{"tag2"}},
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:            "run is not created because there are no runners",
+			runnerTags:      []string{"tag1"},
+			expectErrorCode: errors.EInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dbClient := buildDBClientWithMocks(t)
+
+			mockCaller := auth.NewMockCaller(t)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateRunPermission, mock.Anything).Return(nil)
+			mockCaller.On("GetSubject").Return("mock-caller").Maybe()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			dbClient.MockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil).Maybe()
+			dbClient.MockTransactions.On("RollbackTx", mock.Anything).Return(nil).Maybe()
+			dbClient.MockTransactions.On("CommitTx", mock.Anything).Return(nil).Maybe()
+
+			dbClient.MockManagedIdentities.On("GetManagedIdentitiesForWorkspace", mock.Anything, ws.Metadata.ID).Return([]models.ManagedIdentity{}, nil).Maybe()
+
+			dbClient.MockWorkspaces.On("GetWorkspaceByID", mock.Anything, ws.Metadata.ID).Return(ws, nil)
+
+			dbClient.MockVariables.On("GetVariables", mock.Anything, mock.Anything).Return(&db.VariableResult{
+				Variables: []models.Variable{},
+			}, nil)
+
+			sharedRunnerType := models.SharedRunnerType
+			dbClient.MockRunners.On("GetRunners", mock.Anything, &db.GetRunnersInput{
+				Filter: &db.RunnerFilter{
+					RunnerType: &sharedRunnerType,
+					Enabled:    ptr.Bool(true),
+				},
+			}).Return(&db.RunnersResult{Runners: test.sharedRunners}, nil).Maybe()
+			dbClient.MockRunners.On("GetRunners", mock.Anything, &db.GetRunnersInput{
+				Filter: &db.RunnerFilter{
+					NamespacePaths: []string{"groupA"},
+					Enabled:        ptr.Bool(true),
+				},
+			}).Return(&db.RunnersResult{Runners: test.groupRunners}, nil).Maybe()
+
+			dbClient.MockRuns.On("CreateRun", mock.Anything, mock.Anything).
+				Return(func(_ context.Context, run *models.Run) (*models.Run, error) {
+					runWithTimestamp := *run
+					runWithTimestamp.Metadata.ID = "run1"
+					runWithTimestamp.Metadata.CreationTimestamp = &currentTime
+					return &runWithTimestamp, nil
+				}).Maybe()
+			dbClient.MockRuns.On("GetRuns", mock.Anything, mock.Anything).
+				Return(&db.RunsResult{
+					PageInfo: &pagination.PageInfo{
+						TotalCount: 1,
+					},
+				}, nil).Maybe()
+
+			dbClient.MockResourceLimits.On("GetResourceLimit", mock.Anything, mock.Anything).
+				Return(&models.ResourceLimit{Value: 10}, nil).Maybe()
+
+			dbClient.MockConfigurationVersions.On("GetConfigurationVersion", mock.Anything, configurationVersionID).Return(&models.ConfigurationVersion{
+				Speculative: true,
+			}, nil).Maybe()
+
+			dbClient.MockPlans.On("CreatePlan", mock.Anything, mock.Anything).Return(&models.Plan{
+				Metadata: models.ResourceMetadata{
+					ID: "plan1",
+				},
+			}, nil).Maybe()
+
+			dbClient.MockJobs.On("CreateJob", mock.Anything, mock.Anything).Return(&models.Job{
+				Metadata: models.ResourceMetadata{
+					ID: "job1",
+				},
+			}, nil).Maybe()
+
+			dbClient.MockLogStreams.On("CreateLogStream", mock.Anything, mock.Anything).Return(&models.LogStream{}, nil).Maybe()
+
+			mockArtifactStore := workspace.NewMockArtifactStore(t)
+			mockArtifactStore.On("UploadRunVariables", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			mockActivityEvents := activityevent.NewMockService(t)
+			mockActivityEvents.On("CreateActivityEvent", mock.Anything, mock.Anything).Return(&models.ActivityEvent{}, nil).Maybe()
+
+			mockWorkspaceService := workspace.NewMockService(t)
+			mockWorkspaceService.On("GetEffectiveRunnerTags", mock.Anything, ws.Metadata.ID).Return(test.runnerTags, nil)
+
+			logger, _ := logger.NewForTest()
+			service := newService(
+				logger,
+				dbClient.Client,
+				mockArtifactStore,
+				nil,
+				nil,
+				mockWorkspaceService,
+				nil,
+				mockActivityEvents,
+				nil,
+				nil,
+				nil,
+				rules.NewMockRuleEnforcer(t),
+				limits.NewLimitChecker(dbClient.Client),
+				nil,
+				nil,
+			)
+
+			_, err := service.CreateRun(auth.WithCaller(ctx, mockCaller), &CreateRunInput{
+				WorkspaceID:            ws.Metadata.ID,
+				ConfigurationVersionID: &configurationVersionID,
+			})
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
 func TestCreateRunWithPreventDestroy(t *testing.T) {
 	configurationVersionID := "cv1"
 	var duration int32 = 720
@@ -523,6 +738,9 @@ func TestCreateRunWithPreventDestroy(t *testing.T) {
 
 			mockActivityEvents.On("CreateActivityEvent", mock.Anything, mock.Anything).Return(&models.ActivityEvent{}, nil)
 
+			mockWorkspaceService := workspace.NewMockService(t)
+			mockWorkspaceService.On("GetEffectiveRunnerTags", mock.Anything, mock.Anything).Return([]string{}, nil).Maybe()
+
 			logger, _ := logger.NewForTest()
 
 			service := NewService(
@@ -531,12 +749,15 @@ func TestCreateRunWithPreventDestroy(t *testing.T) {
 				&mockArtifactStore,
 				nil,
 				nil,
+				mockWorkspaceService,
 				nil,
 				&mockActivityEvents,
 				nil,
 				nil,
 				nil,
 				limits.NewLimitChecker(dbClient.Client),
+				nil,
+				0,
 			)
 
 			_, err := service.CreateRun(auth.WithCaller(ctx, mockCaller), test.runInput)
@@ -549,6 +770,143 @@ func TestCreateRunWithPreventDestroy(t *testing.T) {
 	}
 }
 
+func TestCreateRunWithPolicyEvaluator(t *testing.T) {
+	configurationVersionID := "cv1"
+	currentTime := time.Now().UTC()
+
+	ws := &models.Workspace{
+		Metadata: models.ResourceMetadata{
+			ID: "ws1",
+		},
+		MaxJobDuration: ptr.Int32(720),
+	}
+
+	// Test cases
+	type testCase struct {
+		evaluateErr     error
+		name            string
+		reason          string
+		expectErrorCode errors.CodeType
+		allow           bool
+	}
+
+	tests := []testCase{
+		{
+			name:  "policy allows run",
+			allow: true,
+		},
+		{
+			name:            "policy denies run",
+			allow:           false,
+			reason:          "destroy runs are not permitted on fridays",
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name:            "policy evaluation fails",
+			evaluateErr:     errors.New("policy engine unavailable", errors.WithErrorCode(errors.EServiceUnavailable)),
+			expectErrorCode: errors.EServiceUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dbClient := buildDBClientWithMocks(t)
+
+			mockCaller := auth.NewMockCaller(t)
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateRunPermission, mock.Anything).Return(nil)
+			mockCaller.On("GetSubject").Return("testsubject")
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			dbClient.MockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+			dbClient.MockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+			dbClient.MockTransactions.On("CommitTx", mock.Anything).Return(nil).Maybe()
+
+			dbClient.MockManagedIdentities.On("GetManagedIdentitiesForWorkspace", mock.Anything, ws.Metadata.ID).Return([]models.ManagedIdentity{}, nil)
+			dbClient.MockWorkspaces.On("GetWorkspaceByID", mock.Anything, ws.Metadata.ID).Return(ws, nil)
+			dbClient.MockVariables.On("GetVariables", mock.Anything, mock.Anything).Return(&db.VariableResult{
+				Variables: []models.Variable{},
+			}, nil)
+
+			dbClient.MockConfigurationVersions.On("GetConfigurationVersion", mock.Anything, configurationVersionID).Return(&models.ConfigurationVersion{}, nil)
+			dbClient.MockPlans.On("CreatePlan", mock.Anything, mock.Anything).Return(&models.Plan{
+				Metadata: models.ResourceMetadata{ID: "plan1"},
+			}, nil)
+			dbClient.MockApplies.On("CreateApply", mock.Anything, mock.Anything).Return(&models.Apply{
+				Metadata: models.ResourceMetadata{ID: "apply1"},
+			}, nil)
+
+			mockPolicyEvaluator := NewMockRunPolicyEvaluator(t)
+			mockPolicyEvaluator.On("Evaluate", mock.Anything, mock.MatchedBy(func(run *models.Run) bool {
+				return run.WorkspaceID == ws.Metadata.ID && run.PlanID == "plan1" && run.CreatedBy == "testsubject"
+			})).Return(test.allow, test.reason, test.evaluateErr)
+
+			mockArtifactStore := workspace.NewMockArtifactStore(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+
+			if test.allow {
+				dbClient.MockRuns.On("CreateRun", mock.Anything, mock.Anything).
+					Return(func(_ context.Context, run *models.Run) (*models.Run, error) {
+						runWithTimestamp := *run
+						runWithTimestamp.Metadata.CreationTimestamp = &currentTime
+						return &runWithTimestamp, nil
+					})
+				dbClient.MockRuns.On("GetRuns", mock.Anything, mock.Anything).Return(&db.RunsResult{
+					PageInfo: &pagination.PageInfo{TotalCount: 1},
+				}, nil)
+				dbClient.MockResourceLimits.On("GetResourceLimit", mock.Anything, mock.Anything).Return(&models.ResourceLimit{Value: 10}, nil)
+				dbClient.MockJobs.On("CreateJob", mock.Anything, mock.Anything).Return(&models.Job{
+					Metadata: models.ResourceMetadata{ID: "job1"},
+				}, nil)
+				dbClient.MockLogStreams.On("CreateLogStream", mock.Anything, mock.Anything).Return(&models.LogStream{}, nil)
+
+				mockArtifactStore.On("UploadRunVariables", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, mock.Anything).Return(&models.ActivityEvent{}, nil)
+			}
+
+			mockWorkspaceService := workspace.NewMockService(t)
+			mockWorkspaceService.On("GetEffectiveRunnerTags", mock.Anything, mock.Anything).Return([]string{}, nil).Maybe()
+
+			logger, _ := logger.NewForTest()
+
+			service := NewService(
+				logger,
+				dbClient.Client,
+				mockArtifactStore,
+				nil,
+				nil,
+				mockWorkspaceService,
+				nil,
+				mockActivityEvents,
+				nil,
+				nil,
+				nil,
+				limits.NewLimitChecker(dbClient.Client),
+				mockPolicyEvaluator,
+				0,
+			)
+
+			run, err := service.CreateRun(auth.WithCaller(ctx, mockCaller), &CreateRunInput{
+				WorkspaceID:            ws.Metadata.ID,
+				ConfigurationVersionID: &configurationVersionID,
+			})
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				if test.reason != "" {
+					assert.Contains(t, err.Error(), test.reason)
+				}
+				return
+			}
+
+			require.Nil(t, err)
+			assert.NotNil(t, run)
+		})
+	}
+}
+
 func TestCreateRunWithSpeculativeOption(t *testing.T) {
 	configurationVersionID := "configuration-version-id-1"
 	moduleSource := "module-source-1"
@@ -860,6 +1218,9 @@ func TestCreateRunWithSpeculativeOption(t *testing.T) {
 			mockModuleResolver.On("ResolveModuleVersion", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(moduleVersion, nil).Maybe()
 
+			mockWorkspaceService := workspace.NewMockService(t)
+			mockWorkspaceService.On("GetEffectiveRunnerTags", mock.Anything, mock.Anything).Return([]string{}, nil).Maybe()
+
 			logger, _ := logger.NewForTest()
 			service := newService(
 				logger,
@@ -867,6 +1228,7 @@ func TestCreateRunWithSpeculativeOption(t *testing.T) {
 				&mockArtifactStore,
 				nil,
 				nil,
+				mockWorkspaceService,
 				nil,
 				&mockActivityEvents,
 				mockModuleService,
@@ -875,6 +1237,7 @@ func TestCreateRunWithSpeculativeOption(t *testing.T) {
 				nil,
 				limits.NewLimitChecker(dbClient.Client),
 				nil,
+				nil,
 			)
 
 			_, err := service.CreateRun(auth.WithCaller(ctx, mockCaller), test.input)
@@ -918,13 +1281,40 @@ func TestApplyRunWithManagedIdentityAccessRules(t *testing.T) {
 		RunID:       run.Metadata.ID,
 	}
 
+	passedEvaluation := models.ManagedIdentityAccessRuleEvaluation{
+		ManagedIdentityID: "1",
+		RuleID:            "rule1",
+		RuleType:          models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:          models.JobApplyType,
+		Passed:            true,
+	}
+
+	failedEvaluation := models.ManagedIdentityAccessRuleEvaluation{
+		ManagedIdentityID: "1",
+		RuleID:            "rule1",
+		RuleType:          models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:          models.JobApplyType,
+		Diagnostic:        "user is not an eligible principal",
+	}
+
+	planEvaluation := models.ManagedIdentityAccessRuleEvaluation{
+		ManagedIdentityID: "1",
+		RuleID:            "rule1",
+		RuleType:          models.ManagedIdentityAccessRuleEligiblePrincipals,
+		RunStage:          models.JobPlanType,
+		Passed:            true,
+	}
+
 	// Test cases
 	tests := []struct {
-		enforceRulesResponse error
-		injectJob            *models.Job
-		name                 string
-		expectErrorCode      errors.CodeType
-		managedIdentities    []models.ManagedIdentity
+		enforceRulesResponse    error
+		injectJob               *models.Job
+		name                    string
+		expectErrorCode         errors.CodeType
+		managedIdentities       []models.ManagedIdentity
+		existingEvaluations     []models.ManagedIdentityAccessRuleEvaluation
+		enforceRulesEvaluations []models.ManagedIdentityAccessRuleEvaluation
+		expectEvaluations       []models.ManagedIdentityAccessRuleEvaluation
 	}{
 		{
 			name: "apply is created because all managed identity rules are satisfied",
@@ -935,7 +1325,8 @@ func TestApplyRunWithManagedIdentityAccessRules(t *testing.T) {
 					},
 				},
 			},
-			injectJob: &injectJob,
+			enforceRulesEvaluations: []models.ManagedIdentityAccessRuleEvaluation{passedEvaluation},
+			injectJob:               &injectJob,
 		},
 		{
 			name:              "apply is created because there are no managed identities",
@@ -951,8 +1342,23 @@ func TestApplyRunWithManagedIdentityAccessRules(t *testing.T) {
 					},
 				},
 			},
-			enforceRulesResponse: errors.New("rule not satisfied", errors.WithErrorCode(errors.EForbidden)),
-			expectErrorCode:      errors.EForbidden,
+			enforceRulesEvaluations: []models.ManagedIdentityAccessRuleEvaluation{failedEvaluation},
+			enforceRulesResponse:    errors.New("rule not satisfied", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode:         errors.EForbidden,
+		},
+		{
+			name: "evaluations from a previous apply attempt are replaced",
+			managedIdentities: []models.ManagedIdentity{
+				{
+					Metadata: models.ResourceMetadata{
+						ID: "1",
+					},
+				},
+			},
+			existingEvaluations:     []models.ManagedIdentityAccessRuleEvaluation{planEvaluation, failedEvaluation},
+			enforceRulesEvaluations: []models.ManagedIdentityAccessRuleEvaluation{passedEvaluation},
+			expectEvaluations:       []models.ManagedIdentityAccessRuleEvaluation{planEvaluation, passedEvaluation},
+			injectJob:               &injectJob,
 		},
 	}
 
@@ -977,14 +1383,18 @@ func TestApplyRunWithManagedIdentityAccessRules(t *testing.T) {
 
 			apply.Status = models.ApplyCreated // to avoid tripping the state transition checks in UpdateApply, etc.
 
-			dbClient.MockRuns.On("GetRun", mock.Anything, run.Metadata.ID).Return(&run, nil)
-			dbClient.MockRuns.On("UpdateRun", mock.Anything, mock.Anything).Return(&run, nil)
+			runCopy := run
+			runCopy.AccessRuleEvaluations = test.existingEvaluations
+			dbClient.MockRuns.On("GetRun", mock.Anything, run.Metadata.ID).Return(&runCopy, nil)
+			dbClient.MockRuns.On("UpdateRun", mock.Anything, mock.Anything).Return(&runCopy, nil)
 
 			dbClient.MockApplies.On("GetApply", mock.Anything, mock.Anything).Return(&apply, nil)
 			dbClient.MockApplies.On("UpdateApply", mock.Anything, mock.Anything).Return(&apply, nil)
 			dbClient.MockJobs.On("CreateJob", mock.Anything, mock.Anything).Return(test.injectJob, nil)
 			dbClient.MockLogStreams.On("CreateLogStream", mock.Anything, mock.Anything).Return(&models.LogStream{}, nil)
 			dbClient.MockWorkspaces.On("GetWorkspaceByID", mock.Anything, run.WorkspaceID).Return(ws, nil)
+			dbClient.MockWorkspaceRunLocks.On("GetWorkspaceRunLock", mock.Anything, ws.Metadata.ID).Return(nil, nil).Maybe()
+			dbClient.MockWorkspaceRunLocks.On("CreateWorkspaceRunLock", mock.Anything, mock.Anything).Return(&models.WorkspaceRunLock{}, nil).Maybe()
 
 			mockActivityEvents := activityevent.MockService{}
 			mockActivityEvents.Test(t)
@@ -997,7 +1407,7 @@ func TestApplyRunWithManagedIdentityAccessRules(t *testing.T) {
 
 			for _, mi := range test.managedIdentities {
 				miCopy := mi
-				ruleEnforcer.On("EnforceRules", mock.Anything, &miCopy, mock.Anything).Return(test.enforceRulesResponse)
+				ruleEnforcer.On("EnforceRules", mock.Anything, &miCopy, mock.Anything).Return(test.enforceRulesEvaluations, test.enforceRulesResponse)
 			}
 
 			logger, _ := logger.NewForTest()
@@ -1008,6 +1418,7 @@ func TestApplyRunWithManagedIdentityAccessRules(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				&mockActivityEvents,
 				mockModuleService,
 				mockModuleResolver,
@@ -1015,9 +1426,22 @@ func TestApplyRunWithManagedIdentityAccessRules(t *testing.T) {
 				ruleEnforcer,
 				limits.NewLimitChecker(dbClient.Client),
 				nil,
+				nil,
 			)
 
 			_, err := service.ApplyRun(ctx, run.Metadata.ID, nil)
+
+			// The evaluations must be recorded on the run whether or not the rules were satisfied
+			if len(test.enforceRulesEvaluations) > 0 {
+				expectEvaluations := test.expectEvaluations
+				if expectEvaluations == nil {
+					expectEvaluations = test.enforceRulesEvaluations
+				}
+				dbClient.MockRuns.AssertCalled(t, "UpdateRun", mock.Anything, mock.MatchedBy(func(r *models.Run) bool {
+					return assert.ObjectsAreEqual(expectEvaluations, r.AccessRuleEvaluations)
+				}))
+			}
+
 			if test.expectErrorCode != "" {
 				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
 			} else if err != nil {
@@ -1027,8 +1451,560 @@ func TestApplyRunWithManagedIdentityAccessRules(t *testing.T) {
 	}
 }
 
-func TestGetStateVersionsByRunIDs(t *testing.T) {
-	workspaceID := "ws1"
+func TestApplyRunWithWorkspaceRunLock(t *testing.T) {
+	var duration int32 = 1
+	ws := &models.Workspace{
+		Metadata: models.ResourceMetadata{
+			ID: "ws1",
+		},
+		FullPath:       "groupA/ws1",
+		MaxJobDuration: &duration,
+	}
+
+	run := models.Run{
+		Metadata: models.ResourceMetadata{
+			ID: "run1",
+		},
+		WorkspaceID: ws.Metadata.ID,
+	}
+
+	apply := models.Apply{
+		Metadata: models.ResourceMetadata{
+			ID: "apply1",
+		},
+	}
+
+	// Test cases
+	tests := []struct {
+		existingLock    *models.WorkspaceRunLock
+		name            string
+		expectErrorCode errors.CodeType
+	}{
+		{
+			name: "lock is acquired because the workspace is not locked",
+		},
+		{
+			name: "apply is rejected because the workspace is locked by another run",
+			existingLock: &models.WorkspaceRunLock{
+				WorkspaceID: ws.Metadata.ID,
+				RunID:       "run2",
+			},
+			expectErrorCode: errors.EConflict,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dbClient := buildDBClientWithMocks(t)
+
+			mockCaller := auth.NewMockCaller(t)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateRunPermission, mock.Anything).Return(nil)
+			mockCaller.On("GetSubject").Return("mock-caller").Maybe()
+
+			ctx, cancel := context.WithCancel(auth.WithCaller(context.Background(), mockCaller))
+			defer cancel()
+
+			dbClient.MockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+			dbClient.MockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+			dbClient.MockTransactions.On("CommitTx", mock.Anything).Return(nil).Maybe()
+
+			dbClient.MockManagedIdentities.On("GetManagedIdentitiesForWorkspace", mock.Anything, ws.Metadata.ID).Return([]models.ManagedIdentity{}, nil)
+			dbClient.MockWorkspaces.On("GetWorkspaceByID", mock.Anything, ws.Metadata.ID).Return(ws, nil)
+
+			apply.Status = models.ApplyCreated
+
+			dbClient.MockRuns.On("GetRun", mock.Anything, run.Metadata.ID).Return(&run, nil)
+			dbClient.MockRuns.On("UpdateRun", mock.Anything, mock.Anything).Return(&run, nil).Maybe()
+			dbClient.MockRuns.On("GetRunByApplyID", mock.Anything, mock.Anything).Return(&run, nil).Maybe()
+
+			dbClient.MockApplies.On("GetApply", mock.Anything, mock.Anything).Return(&apply, nil)
+			dbClient.MockApplies.On("UpdateApply", mock.Anything, mock.Anything).Return(&apply, nil).Maybe()
+			dbClient.MockJobs.On("GetLatestJobByType", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+			dbClient.MockJobs.On("CreateJob", mock.Anything, mock.Anything).Return(&models.Job{}, nil).Maybe()
+			dbClient.MockLogStreams.On("CreateLogStream", mock.Anything, mock.Anything).Return(&models.LogStream{}, nil).Maybe()
+
+			dbClient.MockWorkspaceRunLocks.On("GetWorkspaceRunLock", mock.Anything, ws.Metadata.ID).Return(test.existingLock, nil)
+
+			if test.existingLock == nil {
+				dbClient.MockWorkspaceRunLocks.On("CreateWorkspaceRunLock", mock.Anything, &models.WorkspaceRunLock{
+					WorkspaceID: ws.Metadata.ID,
+					RunID:       run.Metadata.ID,
+					CreatedBy:   "mock-caller",
+				}).Return(&models.WorkspaceRunLock{}, nil)
+			}
+
+			logger, _ := logger.NewForTest()
+			service := newService(
+				logger,
+				dbClient.Client,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				state.NewRunStateManager(dbClient.Client, logger),
+				nil,
+				limits.NewLimitChecker(dbClient.Client),
+				nil,
+				nil,
+			)
+
+			_, err := service.ApplyRun(ctx, run.Metadata.ID, nil)
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				assert.Contains(t, err.Error(), test.existingLock.RunID)
+				return
+			}
+
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestUpdateApplyReleasesWorkspaceRunLock(t *testing.T) {
+	workspaceID := "ws1"
+	runID := "run1"
+
+	// Test cases
+	tests := []struct {
+		existingLock      *models.WorkspaceRunLock
+		name              string
+		newApplyStatus    models.ApplyStatus
+		expectLockRelease bool
+	}{
+		{
+			name:           "lock is released when the apply finishes",
+			newApplyStatus: models.ApplyFinished,
+			existingLock: &models.WorkspaceRunLock{
+				WorkspaceID: workspaceID,
+				RunID:       runID,
+			},
+			expectLockRelease: true,
+		},
+		{
+			name:           "lock is released when the apply is canceled",
+			newApplyStatus: models.ApplyCanceled,
+			existingLock: &models.WorkspaceRunLock{
+				WorkspaceID: workspaceID,
+				RunID:       runID,
+			},
+			expectLockRelease: true,
+		},
+		{
+			name:           "lock is not released because it is held by another run",
+			newApplyStatus: models.ApplyFinished,
+			existingLock: &models.WorkspaceRunLock{
+				WorkspaceID: workspaceID,
+				RunID:       "run2",
+			},
+		},
+		{
+			name:           "nothing to release because the workspace is not locked",
+			newApplyStatus: models.ApplyErrored,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dbClient := buildDBClientWithMocks(t)
+
+			mockCaller := auth.NewMockCaller(t)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateApplyPermission, mock.Anything).Return(nil)
+			mockCaller.On("GetSubject").Return("mock-caller").Maybe()
+
+			ctx, cancel := context.WithCancel(auth.WithCaller(context.Background(), mockCaller))
+			defer cancel()
+
+			dbClient.MockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+			dbClient.MockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+			dbClient.MockTransactions.On("CommitTx", mock.Anything).Return(nil)
+
+			oldApply := &models.Apply{
+				Metadata:    models.ResourceMetadata{ID: "apply1"},
+				WorkspaceID: workspaceID,
+				Status:      models.ApplyRunning,
+			}
+
+			newApply := &models.Apply{
+				Metadata:    models.ResourceMetadata{ID: "apply1"},
+				WorkspaceID: workspaceID,
+				Status:      test.newApplyStatus,
+			}
+
+			if test.newApplyStatus == models.ApplyErrored {
+				newApply.ErrorMessage = ptr.String("failed")
+			}
+
+			dbClient.MockApplies.On("GetApply", mock.Anything, oldApply.Metadata.ID).Return(oldApply, nil)
+			dbClient.MockApplies.On("UpdateApply", mock.Anything, newApply).Return(newApply, nil)
+
+			dbClient.MockRuns.On("GetRunByApplyID", mock.Anything, oldApply.Metadata.ID).Return(func(_ context.Context, _ string) *models.Run {
+				return &models.Run{
+					Metadata:    models.ResourceMetadata{ID: runID},
+					WorkspaceID: workspaceID,
+					Status:      models.RunApplying,
+				}
+			}, nil)
+			dbClient.MockRuns.On("GetRun", mock.Anything, runID).Return(&models.Run{
+				Metadata:    models.ResourceMetadata{ID: runID},
+				WorkspaceID: workspaceID,
+				Status:      models.RunApplying,
+			}, nil)
+			dbClient.MockRuns.On("UpdateRun", mock.Anything, mock.Anything).Return(func(_ context.Context, run *models.Run) *models.Run {
+				return run
+			}, nil)
+
+			dbClient.MockJobs.On("GetLatestJobByType", mock.Anything, runID, models.JobApplyType).Return(nil, nil)
+
+			dbClient.MockWorkspaceRunLocks.On("GetWorkspaceRunLock", mock.Anything, workspaceID).Return(test.existingLock, nil)
+
+			if test.expectLockRelease {
+				dbClient.MockWorkspaceRunLocks.On("DeleteWorkspaceRunLock", mock.Anything, test.existingLock).Return(nil)
+			}
+
+			logger, _ := logger.NewForTest()
+			service := newService(
+				logger,
+				dbClient.Client,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				state.NewRunStateManager(dbClient.Client, logger),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := service.UpdateApply(ctx, newApply)
+			require.Nil(t, err)
+
+			dbClient.MockWorkspaceRunLocks.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdatePlanWithAutoApply(t *testing.T) {
+	var duration int32 = 1
+	ws := &models.Workspace{
+		Metadata: models.ResourceMetadata{
+			ID: "ws1",
+		},
+		FullPath:       "groupA/ws1",
+		MaxJobDuration: &duration,
+		AutoApply:      true,
+	}
+
+	// Test cases
+	tests := []struct {
+		name            string
+		autoApply       bool
+		expectApplyJob  bool
+		expectRunStatus models.RunStatus
+	}{
+		{
+			name:            "apply is queued when the run has auto apply enabled",
+			autoApply:       true,
+			expectApplyJob:  true,
+			expectRunStatus: models.RunApplyQueued,
+		},
+		{
+			name:            "run waits for a manual apply when auto apply is disabled",
+			autoApply:       false,
+			expectRunStatus: models.RunPlanned,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dbClient := buildDBClientWithMocks(t)
+
+			mockCaller := auth.NewMockCaller(t)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdatePlanPermission, mock.Anything).Return(nil)
+			mockCaller.On("GetSubject").Return("mock-caller").Maybe()
+
+			ctx, cancel := context.WithCancel(auth.WithCaller(context.Background(), mockCaller))
+			defer cancel()
+
+			dbClient.MockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+			dbClient.MockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+			dbClient.MockTransactions.On("CommitTx", mock.Anything).Return(nil)
+
+			currentRun := models.Run{
+				Metadata:    models.ResourceMetadata{ID: "run1"},
+				WorkspaceID: ws.Metadata.ID,
+				PlanID:      "plan1",
+				ApplyID:     "apply1",
+				Status:      models.RunPlanning,
+				CreatedBy:   "vcs-caller",
+				AutoApply:   test.autoApply,
+			}
+
+			oldPlan := &models.Plan{
+				Metadata:    models.ResourceMetadata{ID: "plan1"},
+				WorkspaceID: ws.Metadata.ID,
+				Status:      models.PlanRunning,
+			}
+
+			newPlan := &models.Plan{
+				Metadata:    models.ResourceMetadata{ID: "plan1"},
+				WorkspaceID: ws.Metadata.ID,
+				Status:      models.PlanFinished,
+				HasChanges:  true,
+			}
+
+			dbClient.MockPlans.On("GetPlan", mock.Anything, oldPlan.Metadata.ID).Return(oldPlan, nil)
+			dbClient.MockPlans.On("UpdatePlan", mock.Anything, newPlan).Return(newPlan, nil)
+
+			getRun := func(_ context.Context, _ string) *models.Run {
+				runCopy := currentRun
+				return &runCopy
+			}
+
+			dbClient.MockRuns.On("GetRunByPlanID", mock.Anything, oldPlan.Metadata.ID).Return(getRun, nil)
+			dbClient.MockRuns.On("GetRunByApplyID", mock.Anything, "apply1").Return(getRun, nil).Maybe()
+			dbClient.MockRuns.On("GetRun", mock.Anything, "run1").Return(getRun, nil)
+			dbClient.MockRuns.On("UpdateRun", mock.Anything, mock.Anything).Return(func(_ context.Context, run *models.Run) *models.Run {
+				currentRun = *run
+				return run
+			}, nil)
+
+			dbClient.MockJobs.On("GetLatestJobByType", mock.Anything, "run1", mock.Anything).Return(nil, nil)
+
+			if test.expectApplyJob {
+				dbClient.MockWorkspaces.On("GetWorkspaceByID", mock.Anything, ws.Metadata.ID).Return(ws, nil)
+
+				dbClient.MockApplies.On("GetApply", mock.Anything, "apply1").Return(func(_ context.Context, _ string) *models.Apply {
+					return &models.Apply{
+						Metadata:    models.ResourceMetadata{ID: "apply1"},
+						WorkspaceID: ws.Metadata.ID,
+						Status:      models.ApplyCreated,
+					}
+				}, nil)
+				dbClient.MockApplies.On("UpdateApply", mock.Anything, mock.MatchedBy(func(apply *models.Apply) bool {
+					return apply.Status == models.ApplyQueued && apply.TriggeredBy == "vcs-caller"
+				})).Return(func(_ context.Context, apply *models.Apply) *models.Apply {
+					return apply
+				}, nil)
+
+				dbClient.MockWorkspaceRunLocks.On("GetWorkspaceRunLock", mock.Anything, ws.Metadata.ID).Return(nil, nil)
+				dbClient.MockWorkspaceRunLocks.On("CreateWorkspaceRunLock", mock.Anything, &models.WorkspaceRunLock{
+					WorkspaceID: ws.Metadata.ID,
+					RunID:       "run1",
+					CreatedBy:   "vcs-caller",
+				}).Return(&models.WorkspaceRunLock{}, nil)
+
+				dbClient.MockJobs.On("CreateJob", mock.Anything, mock.MatchedBy(func(job *models.Job) bool {
+					return job.Type == models.JobApplyType && job.RunID == "run1"
+				})).Return(&models.Job{Metadata: models.ResourceMetadata{ID: "job1"}}, nil)
+				dbClient.MockLogStreams.On("CreateLogStream", mock.Anything, mock.Anything).Return(&models.LogStream{}, nil)
+			}
+
+			logger, _ := logger.NewForTest()
+			service := newService(
+				logger,
+				dbClient.Client,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				state.NewRunStateManager(dbClient.Client, logger),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := service.UpdatePlan(ctx, newPlan)
+			require.Nil(t, err)
+
+			assert.Equal(t, test.expectRunStatus, currentRun.Status)
+
+			if test.expectApplyJob {
+				dbClient.MockJobs.AssertExpectations(t)
+				dbClient.MockWorkspaceRunLocks.AssertExpectations(t)
+			} else {
+				dbClient.MockJobs.AssertNotCalled(t, "CreateJob", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCancelWorkspaceRuns(t *testing.T) {
+	ws := &models.Workspace{
+		Metadata: models.ResourceMetadata{
+			ID: "ws1",
+		},
+		FullPath: "groupA/ws1",
+	}
+
+	cancelRequestedAt := time.Now().Add(time.Minute)
+
+	activeRuns := []models.Run{
+		{Metadata: models.ResourceMetadata{ID: "run1"}, WorkspaceID: ws.Metadata.ID, Status: models.RunApplying},
+		{Metadata: models.ResourceMetadata{ID: "run2"}, WorkspaceID: ws.Metadata.ID, Status: models.RunPlanning},
+		{Metadata: models.ResourceMetadata{ID: "run3"}, WorkspaceID: ws.Metadata.ID, Status: models.RunPending},
+		{Metadata: models.ResourceMetadata{ID: "run4"}, WorkspaceID: ws.Metadata.ID, Status: models.RunPlanning, ForceCancelAvailableAt: &cancelRequestedAt},
+	}
+
+	// Test cases
+	tests := []struct {
+		authError          error
+		existingLock       *models.WorkspaceRunLock
+		name               string
+		expectErrorCode    errors.CodeType
+		expectCancelOrder  []string
+		expectCanceledRuns int
+	}{
+		{
+			name: "all active runs are canceled with the lock holder last",
+			existingLock: &models.WorkspaceRunLock{
+				WorkspaceID: ws.Metadata.ID,
+				RunID:       "run1",
+			},
+			expectCancelOrder:  []string{"run2", "run3", "run1"},
+			expectCanceledRuns: 3,
+		},
+		{
+			name:               "all active runs are canceled when the workspace is not locked",
+			expectCancelOrder:  []string{"run1", "run2", "run3"},
+			expectCanceledRuns: 3,
+		},
+		{
+			name:            "caller is not an owner of the workspace",
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dbClient := buildDBClientWithMocks(t)
+
+			mockCaller := auth.NewMockCaller(t)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateNamespaceMembershipPermission, mock.Anything).Return(test.authError)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.ViewRunPermission, mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateRunPermission, mock.Anything).Return(nil).Maybe()
+			mockCaller.On("GetSubject").Return("mock-caller").Maybe()
+
+			ctx, cancel := context.WithCancel(auth.WithCaller(context.Background(), mockCaller))
+			defer cancel()
+
+			mockJobService := job.NewMockService(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+
+			canceledOrder := []string{}
+
+			if test.authError == nil {
+				dbClient.MockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				dbClient.MockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				dbClient.MockTransactions.On("CommitTx", mock.Anything).Return(nil)
+
+				dbClient.MockWorkspaces.On("GetWorkspaceByID", mock.Anything, ws.Metadata.ID).Return(ws, nil)
+				dbClient.MockWorkspaceRunLocks.On("GetWorkspaceRunLock", mock.Anything, ws.Metadata.ID).Return(test.existingLock, nil)
+
+				dbClient.MockRuns.On("GetRuns", mock.Anything, &db.GetRunsInput{
+					Filter: &db.RunFilter{
+						WorkspaceID: &ws.Metadata.ID,
+						Statuses:    activeRunStatuses,
+					},
+				}).Return(&db.RunsResult{Runs: activeRuns}, nil)
+
+				for _, r := range activeRuns {
+					runCopy := r
+					dbClient.MockRuns.On("GetRun", mock.Anything, runCopy.Metadata.ID).Return(func(_ context.Context, _ string) *models.Run {
+						run := runCopy
+						return &run
+					}, nil).Maybe()
+				}
+
+				dbClient.MockRuns.On("UpdateRun", mock.Anything, mock.Anything).Return(func(_ context.Context, run *models.Run) *models.Run {
+					canceledOrder = append(canceledOrder, run.Metadata.ID)
+					return run
+				}, nil)
+
+				mockJobService.On("GetLatestJobForRun", mock.Anything, mock.Anything).Return(func(_ context.Context, run *models.Run) *models.Job {
+					return &models.Job{
+						Metadata:    models.ResourceMetadata{ID: "job-" + run.Metadata.ID},
+						WorkspaceID: ws.Metadata.ID,
+						RunID:       run.Metadata.ID,
+						Status:      models.JobRunning,
+					}
+				}, nil)
+
+				dbClient.MockJobs.On("GetJobByID", mock.Anything, mock.Anything).Return(func(_ context.Context, id string) *models.Job {
+					return &models.Job{
+						Metadata:    models.ResourceMetadata{ID: id},
+						WorkspaceID: ws.Metadata.ID,
+						Status:      models.JobRunning,
+					}
+				}, nil)
+				dbClient.MockJobs.On("UpdateJob", mock.Anything, mock.MatchedBy(func(job *models.Job) bool {
+					return job.CancelRequested
+				})).Return(func(_ context.Context, job *models.Job) *models.Job {
+					return job
+				}, nil)
+
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, mock.MatchedBy(func(input *activityevent.CreateActivityEventInput) bool {
+					return input.Action == models.ActionCancel && input.TargetType == models.TargetRun
+				})).Return(&models.ActivityEvent{}, nil).Times(test.expectCanceledRuns)
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, &activityevent.CreateActivityEventInput{
+					NamespacePath: &ws.FullPath,
+					Action:        models.ActionCancel,
+					TargetType:    models.TargetWorkspace,
+					TargetID:      ws.Metadata.ID,
+					Payload: &models.ActivityEventCancelWorkspaceRunsPayload{
+						CanceledRunCount: int32(test.expectCanceledRuns),
+					},
+				}).Return(&models.ActivityEvent{}, nil)
+			}
+
+			logger, _ := logger.NewForTest()
+			service := newService(
+				logger,
+				dbClient.Client,
+				nil,
+				nil,
+				mockJobService,
+				nil,
+				nil,
+				mockActivityEvents,
+				nil,
+				nil,
+				state.NewRunStateManager(dbClient.Client, logger),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			count, err := service.CancelWorkspaceRuns(ctx, ws.Metadata.ID)
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, test.expectCanceledRuns, count)
+			assert.Equal(t, test.expectCancelOrder, canceledOrder)
+		})
+	}
+}
+
+func TestGetStateVersionsByRunIDs(t *testing.T) {
+	workspaceID := "ws1"
 
 	type testCase struct {
 		authError       error
@@ -1477,6 +2453,32 @@ func TestProcessPlanData(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "process plan data with moved resource",
+			tfPlan: &tfjson.Plan{
+				FormatVersion: "0.1",
+			},
+			tfProviderSchemas: &tfjson.ProviderSchemas{
+				FormatVersion: "0.1",
+			},
+			expectedPlan: &models.Plan{
+				Metadata: models.ResourceMetadata{
+					ID: planID,
+				},
+				WorkspaceID:  workspaceID,
+				PlanDiffSize: 305,
+			},
+			expectDiff: &plan.Diff{
+				Resources: []*plan.ResourceDiff{
+					{
+						Address:         "test_resource.bar",
+						PreviousAddress: "test_resource.foo",
+						Action:          action.Move,
+						Moved:           true,
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -1565,3 +2567,120 @@ func TestProcessPlanData(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildRunVariablesWithOverrides(t *testing.T) {
+	workspace := &models.Workspace{
+		Metadata: models.ResourceMetadata{ID: "ws-1"},
+		FullPath: "group/workspace",
+	}
+
+	// Sorted by descending namespace path like the DB query in buildRunVariables.
+	namespaceVariables := []models.Variable{
+		{Key: "region", Value: ptr.String("us-east-1"), Category: models.TerraformVariableCategory, NamespacePath: "group/workspace"},
+		{Key: "locked", Value: ptr.String("locked-value"), Category: models.TerraformVariableCategory, NamespacePath: "group/workspace", NonOverridable: true},
+		{Key: "region", Value: ptr.String("us-west-2"), Category: models.TerraformVariableCategory, NamespacePath: "group", NonOverridable: true},
+		{Key: "TOKEN", Value: ptr.String("group-token"), Category: models.EnvironmentVariableCategory, NamespacePath: "group"},
+	}
+
+	type testCase struct {
+		name            string
+		runVariables    []Variable
+		overrides       []models.Variable
+		expectErrorCode errors.CodeType
+		expectValues    map[string]string
+	}
+
+	testCases := []testCase{
+		{
+			name: "overrides take precedence over namespace variables",
+			overrides: []models.Variable{
+				{Key: "region", Value: ptr.String("eu-west-1"), Category: models.TerraformVariableCategory},
+				{Key: "extra", Value: ptr.String("extra-value"), Category: models.TerraformVariableCategory},
+			},
+			expectValues: map[string]string{
+				"region": "eu-west-1",
+				"locked": "locked-value",
+				"TOKEN":  "group-token",
+				"extra":  "extra-value",
+			},
+		},
+		{
+			name: "run variables take precedence over namespace variables without overrides",
+			runVariables: []Variable{
+				{Key: "TOKEN", Value: ptr.String("run-token"), Category: models.EnvironmentVariableCategory},
+			},
+			expectValues: map[string]string{
+				"region": "us-east-1",
+				"locked": "locked-value",
+				"TOKEN":  "run-token",
+			},
+		},
+		{
+			name: "a non-overridable variable cannot be overridden",
+			overrides: []models.Variable{
+				{Key: "locked", Value: ptr.String("new-value"), Category: models.TerraformVariableCategory},
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name: "a run variable cannot replace a non-overridable variable",
+			runVariables: []Variable{
+				{Key: "locked", Value: ptr.String("new-value"), Category: models.TerraformVariableCategory},
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name: "a variable can't be a run variable and an override",
+			runVariables: []Variable{
+				{Key: "region", Value: ptr.String("ap-south-1"), Category: models.TerraformVariableCategory},
+			},
+			overrides: []models.Variable{
+				{Key: "region", Value: ptr.String("eu-west-1"), Category: models.TerraformVariableCategory},
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name: "a variable can't be overridden more than once",
+			overrides: []models.Variable{
+				{Key: "extra", Value: ptr.String("one"), Category: models.TerraformVariableCategory},
+				{Key: "extra", Value: ptr.String("two"), Category: models.TerraformVariableCategory},
+			},
+			expectErrorCode: errors.EInvalid,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockVariables := db.NewMockVariables(t)
+
+			mockWorkspaces.On("GetWorkspaceByID", mock.Anything, workspace.Metadata.ID).Return(workspace, nil)
+			mockVariables.On("GetVariables", mock.Anything, mock.Anything).Return(&db.VariableResult{Variables: namespaceVariables}, nil)
+
+			service := &service{
+				dbClient: &db.Client{
+					Workspaces: mockWorkspaces,
+					Variables:  mockVariables,
+				},
+			}
+
+			variables, err := service.buildRunVariables(ctx, workspace.Metadata.ID, test.runVariables, test.overrides)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			require.Nil(t, err)
+
+			actualValues := map[string]string{}
+			for _, v := range variables {
+				actualValues[v.Key] = *v.Value
+			}
+			assert.Equal(t, test.expectValues, actualValues)
+		})
+	}
+}
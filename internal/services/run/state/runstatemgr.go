@@ -616,6 +616,23 @@ func (w *workspaceHandlers) handleRunStateChangeEvent(ctx context.Context, oldRu
 		}
 	}
 
+	if oldRun.Status != newRun.Status {
+		switch newRun.Status {
+		case models.RunApplied, models.RunCanceled, models.RunErrored:
+			// Release the workspace run lock if it's held by this run.
+			lock, err := w.manager.dbClient.WorkspaceRunLocks.GetWorkspaceRunLock(ctx, newRun.WorkspaceID)
+			if err != nil {
+				return err
+			}
+
+			if lock != nil && lock.RunID == newRun.Metadata.ID {
+				if err = w.manager.dbClient.WorkspaceRunLocks.DeleteWorkspaceRunLock(ctx, lock); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
 	return nil
 }
 
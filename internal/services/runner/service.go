@@ -59,6 +59,7 @@ type CreateRunnerInput struct {
 	Disabled    *bool
 	Name        string
 	Description string
+	Tags        []string
 }
 
 // CreateRunnerSessionInput is the input for creating a new runner session.
@@ -635,6 +636,7 @@ func (s *service) CreateRunner(ctx context.Context, input *CreateRunnerInput) (*
 		Description: input.Description,
 		GroupID:     &input.GroupID,
 		CreatedBy:   caller.GetSubject(),
+		Tags:        input.Tags,
 	}
 
 	// Validate model
@@ -1245,3 +1247,27 @@ func RequireViewerAccessToRunnerResource(ctx context.Context, runner *models.Run
 
 	return nil
 }
+
+// GetEffectiveRunnerTags returns the runner tags for a group, which are inherited
+// from the closest group in the group's hierarchy that defines runner tags.
+func GetEffectiveRunnerTags(ctx context.Context, dbClient *db.Client, groupID string) ([]string, error) {
+	for groupID != "" {
+		group, err := dbClient.Groups.GetGroupByID(ctx, groupID)
+		if err != nil {
+			return nil, err
+		}
+
+		if group == nil {
+			return nil, errors.New("group with id %s not found", groupID, errors.WithErrorCode(errors.ENotFound))
+		}
+
+		if group.RunnerTags != nil {
+			return group.RunnerTags, nil
+		}
+
+		groupID = group.ParentID
+	}
+
+	// No group in the hierarchy defines runner tags
+	return []string{}, nil
+}
@@ -2236,3 +2236,64 @@ func TestSubscribeToRunnerSessions(t *testing.T) {
 		})
 	}
 }
+
+func TestGetEffectiveRunnerTags(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		groups          map[string]*models.Group
+		name            string
+		expectErrorCode errors.CodeType
+		expectTags      []string
+	}{
+		{
+			name: "tags are inherited from the closest ancestor group",
+			groups: map[string]*models.Group{
+				"group-2": {Metadata: models.ResourceMetadata{ID: "group-2"}, ParentID: "group-1"},
+				"group-1": {Metadata: models.ResourceMetadata{ID: "group-1"}, RunnerTags: []string{"root"}},
+			},
+			expectTags: []string{"root"},
+		},
+		{
+			name: "no group in the hierarchy defines tags",
+			groups: map[string]*models.Group{
+				"group-2": {Metadata: models.ResourceMetadata{ID: "group-2"}, ParentID: "group-1"},
+				"group-1": {Metadata: models.ResourceMetadata{ID: "group-1"}},
+			},
+			expectTags: []string{},
+		},
+		{
+			name: "group in the hierarchy is not found",
+			groups: map[string]*models.Group{
+				"group-2": {Metadata: models.ResourceMetadata{ID: "group-2"}, ParentID: "group-1"},
+				"group-1": nil,
+			},
+			expectErrorCode: errors.ENotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockGroups := db.NewMockGroups(t)
+
+			for id, group := range test.groups {
+				mockGroups.On("GetGroupByID", mock.Anything, id).Return(group, nil)
+			}
+
+			dbClient := &db.Client{
+				Groups: mockGroups,
+			}
+
+			tags, err := GetEffectiveRunnerTags(ctx, dbClient, "group-2")
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectTags, tags)
+		})
+	}
+}
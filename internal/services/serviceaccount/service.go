@@ -4,6 +4,7 @@ package serviceaccount
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -83,6 +84,7 @@ type Service interface {
 	GetServiceAccountsByIDs(ctx context.Context, idList []string) ([]models.ServiceAccount, error)
 	CreateServiceAccount(ctx context.Context, input *models.ServiceAccount) (*models.ServiceAccount, error)
 	UpdateServiceAccount(ctx context.Context, serviceAccount *models.ServiceAccount) (*models.ServiceAccount, error)
+	UpdateServiceAccountTrustPolicies(ctx context.Context, id string, policies []models.OIDCTrustPolicy) error
 	DeleteServiceAccount(ctx context.Context, serviceAccount *models.ServiceAccount) error
 	CreateToken(ctx context.Context, input *CreateTokenInput) (*CreateTokenResponse, error)
 }
@@ -511,6 +513,95 @@ func (s *service) UpdateServiceAccount(ctx context.Context, serviceAccount *mode
 	return updatedServiceAccount, nil
 }
 
+// UpdateServiceAccountTrustPolicies replaces all the OIDC trust policies for a service account
+func (s *service) UpdateServiceAccountTrustPolicies(ctx context.Context, id string, policies []models.OIDCTrustPolicy) error {
+	ctx, span := tracer.Start(ctx, "svc.UpdateServiceAccountTrustPolicies")
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return err
+	}
+
+	serviceAccount, err := s.dbClient.ServiceAccounts.GetServiceAccountByID(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get service account")
+		return err
+	}
+
+	if serviceAccount == nil {
+		tracing.RecordError(span, nil, "service account not found")
+		return errors.New("service account with id %s not found", id, errors.WithErrorCode(errors.ENotFound))
+	}
+
+	err = caller.RequirePermission(ctx, permissions.UpdateServiceAccountPermission, auth.WithGroupID(serviceAccount.GroupID))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return err
+	}
+
+	addedIssuers, removedIssuers := diffTrustPolicyIssuers(serviceAccount.OIDCTrustPolicies, policies)
+
+	serviceAccount.OIDCTrustPolicies = policies
+
+	// Validate model
+	if err = serviceAccount.Validate(); err != nil {
+		tracing.RecordError(span, err, "failed to validate service account model")
+		return err
+	}
+
+	s.logger.Infow("Requested an update to service account trust policies.",
+		"caller", caller.GetSubject(),
+		"groupID", serviceAccount.GroupID,
+		"serviceAccountID", serviceAccount.Metadata.ID,
+		"addedIssuers", addedIssuers,
+		"removedIssuers", removedIssuers,
+	)
+
+	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to begin DB transaction")
+		return err
+	}
+
+	defer func() {
+		if txErr := s.dbClient.Transactions.RollbackTx(txContext); txErr != nil {
+			s.logger.Errorf("failed to rollback tx for service layer UpdateServiceAccountTrustPolicies: %v", txErr)
+		}
+	}()
+
+	updatedServiceAccount, err := s.dbClient.ServiceAccounts.UpdateServiceAccount(txContext, serviceAccount)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to update service account")
+		return err
+	}
+
+	groupPath := updatedServiceAccount.GetGroupPath()
+
+	if _, err = s.activityService.CreateActivityEvent(txContext,
+		&activityevent.CreateActivityEventInput{
+			NamespacePath: &groupPath,
+			Action:        models.ActionUpdate,
+			TargetType:    models.TargetServiceAccount,
+			TargetID:      updatedServiceAccount.Metadata.ID,
+			Payload: &models.ActivityEventUpdateServiceAccountTrustPoliciesPayload{
+				AddedIssuers:   addedIssuers,
+				RemovedIssuers: removedIssuers,
+			},
+		}); err != nil {
+		tracing.RecordError(span, err, "failed to create activity event")
+		return err
+	}
+
+	if err := s.dbClient.Transactions.CommitTx(txContext); err != nil {
+		tracing.RecordError(span, err, "failed to commit DB transaction")
+		return err
+	}
+
+	return nil
+}
+
 func (s *service) CreateToken(ctx context.Context, input *CreateTokenInput) (*CreateTokenResponse, error) {
 	ctx, span := tracer.Start(ctx, "svc.CreateToken")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -692,3 +783,35 @@ func (s *service) verifyOneTrustPolicy(ctx context.Context, inputToken []byte, t
 
 	return nil
 }
+
+// diffTrustPolicyIssuers returns the sorted issuers that were added and removed between two sets of trust policies
+func diffTrustPolicyIssuers(oldPolicies, newPolicies []models.OIDCTrustPolicy) ([]string, []string) {
+	oldIssuers := map[string]struct{}{}
+	for _, policy := range oldPolicies {
+		oldIssuers[policy.Issuer] = struct{}{}
+	}
+
+	newIssuers := map[string]struct{}{}
+	for _, policy := range newPolicies {
+		newIssuers[policy.Issuer] = struct{}{}
+	}
+
+	addedIssuers := []string{}
+	for issuer := range newIssuers {
+		if _, ok := oldIssuers[issuer]; !ok {
+			addedIssuers = append(addedIssuers, issuer)
+		}
+	}
+
+	removedIssuers := []string{}
+	for issuer := range oldIssuers {
+		if _, ok := newIssuers[issuer]; !ok {
+			removedIssuers = append(removedIssuers, issuer)
+		}
+	}
+
+	sort.Strings(addedIssuers)
+	sort.Strings(removedIssuers)
+
+	return addedIssuers, removedIssuers
+}
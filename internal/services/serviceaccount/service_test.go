@@ -229,6 +229,174 @@ func TestCreateServiceAccount(t *testing.T) {
 	}
 }
 
+func TestUpdateServiceAccountTrustPolicies(t *testing.T) {
+	serviceAccountID := "sa-1"
+	groupID := "group-1"
+	oldIssuer := "https://old/identity/issuer"
+	keptIssuer := "https://kept/identity/issuer"
+	newIssuer := "https://new/identity/issuer"
+
+	buildPolicy := func(issuer string) models.OIDCTrustPolicy {
+		return models.OIDCTrustPolicy{
+			Issuer:          issuer,
+			BoundClaimsType: models.BoundClaimsTypeString,
+			BoundClaims:     map[string]string{"sub": "value"},
+		}
+	}
+
+	// Test cases
+	tests := []struct {
+		authError            error
+		existingSA           *models.ServiceAccount
+		name                 string
+		expectErrCode        terrs.CodeType
+		expectAddedIssuers   []string
+		expectRemovedIssuers []string
+		policies             []models.OIDCTrustPolicy
+	}{
+		{
+			name: "replace trust policies with a new issuer",
+			existingSA: &models.ServiceAccount{
+				Metadata:          models.ResourceMetadata{ID: serviceAccountID},
+				Name:              "sa",
+				GroupID:           groupID,
+				ResourcePath:      "group/sa",
+				OIDCTrustPolicies: []models.OIDCTrustPolicy{buildPolicy(oldIssuer), buildPolicy(keptIssuer)},
+			},
+			policies:             []models.OIDCTrustPolicy{buildPolicy(keptIssuer), buildPolicy(newIssuer)},
+			expectAddedIssuers:   []string{newIssuer},
+			expectRemovedIssuers: []string{oldIssuer},
+		},
+		{
+			name: "replace trust policies without changing issuers",
+			existingSA: &models.ServiceAccount{
+				Metadata:          models.ResourceMetadata{ID: serviceAccountID},
+				Name:              "sa",
+				GroupID:           groupID,
+				ResourcePath:      "group/sa",
+				OIDCTrustPolicies: []models.OIDCTrustPolicy{buildPolicy(keptIssuer)},
+			},
+			policies:             []models.OIDCTrustPolicy{buildPolicy(keptIssuer)},
+			expectAddedIssuers:   []string{},
+			expectRemovedIssuers: []string{},
+		},
+		{
+			name: "issuer is not a valid URL",
+			existingSA: &models.ServiceAccount{
+				Metadata:          models.ResourceMetadata{ID: serviceAccountID},
+				Name:              "sa",
+				GroupID:           groupID,
+				ResourcePath:      "group/sa",
+				OIDCTrustPolicies: []models.OIDCTrustPolicy{buildPolicy(keptIssuer)},
+			},
+			policies:      []models.OIDCTrustPolicy{buildPolicy("not a url")},
+			expectErrCode: terrs.EInvalid,
+		},
+		{
+			name: "trust policy is missing bound claims",
+			existingSA: &models.ServiceAccount{
+				Metadata:          models.ResourceMetadata{ID: serviceAccountID},
+				Name:              "sa",
+				GroupID:           groupID,
+				ResourcePath:      "group/sa",
+				OIDCTrustPolicies: []models.OIDCTrustPolicy{buildPolicy(keptIssuer)},
+			},
+			policies: []models.OIDCTrustPolicy{
+				{Issuer: newIssuer, BoundClaimsType: models.BoundClaimsTypeString},
+			},
+			expectErrCode: terrs.EInvalid,
+		},
+		{
+			name: "no trust policies are specified",
+			existingSA: &models.ServiceAccount{
+				Metadata:          models.ResourceMetadata{ID: serviceAccountID},
+				Name:              "sa",
+				GroupID:           groupID,
+				ResourcePath:      "group/sa",
+				OIDCTrustPolicies: []models.OIDCTrustPolicy{buildPolicy(keptIssuer)},
+			},
+			policies:      []models.OIDCTrustPolicy{},
+			expectErrCode: terrs.EInvalid,
+		},
+		{
+			name: "subject does not have permission",
+			existingSA: &models.ServiceAccount{
+				Metadata:          models.ResourceMetadata{ID: serviceAccountID},
+				Name:              "sa",
+				GroupID:           groupID,
+				ResourcePath:      "group/sa",
+				OIDCTrustPolicies: []models.OIDCTrustPolicy{buildPolicy(keptIssuer)},
+			},
+			policies:      []models.OIDCTrustPolicy{buildPolicy(newIssuer)},
+			authError:     terrs.New("Unauthorized", terrs.WithErrorCode(terrs.EForbidden)),
+			expectErrCode: terrs.EForbidden,
+		},
+		{
+			name:          "service account does not exist",
+			policies:      []models.OIDCTrustPolicy{buildPolicy(newIssuer)},
+			expectErrCode: terrs.ENotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateServiceAccountPermission, mock.Anything).Return(test.authError).Maybe()
+			mockCaller.On("GetSubject").Return("mockSubject").Maybe()
+
+			mockTransactions := db.NewMockTransactions(t)
+			mockServiceAccounts := db.NewMockServiceAccounts(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+
+			mockServiceAccounts.On("GetServiceAccountByID", mock.Anything, serviceAccountID).Return(test.existingSA, nil)
+
+			if test.expectErrCode == "" {
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+
+				mockServiceAccounts.On("UpdateServiceAccount", mock.Anything, mock.Anything).
+					Return(func(_ context.Context, sa *models.ServiceAccount) (*models.ServiceAccount, error) {
+						assert.Equal(t, test.policies, sa.OIDCTrustPolicies)
+						return sa, nil
+					})
+
+				groupPath := "group"
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, &activityevent.CreateActivityEventInput{
+					NamespacePath: &groupPath,
+					Action:        models.ActionUpdate,
+					TargetType:    models.TargetServiceAccount,
+					TargetID:      serviceAccountID,
+					Payload: &models.ActivityEventUpdateServiceAccountTrustPoliciesPayload{
+						AddedIssuers:   test.expectAddedIssuers,
+						RemovedIssuers: test.expectRemovedIssuers,
+					},
+				}).Return(&models.ActivityEvent{}, nil)
+			}
+
+			dbClient := db.Client{
+				Transactions:    mockTransactions,
+				ServiceAccounts: mockServiceAccounts,
+			}
+
+			testLogger, _ := logger.NewForTest()
+
+			service := NewService(testLogger, &dbClient, nil, nil, nil, mockActivityEvents)
+
+			err := service.UpdateServiceAccountTrustPolicies(auth.WithCaller(ctx, mockCaller), serviceAccountID, test.policies)
+			if test.expectErrCode != "" {
+				assert.Equal(t, test.expectErrCode, terrs.ErrorCode(err))
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
 func TestCreateToken(t *testing.T) {
 	validKeyPair := createKeyPair(t)
 	invalidKeyPair := createKeyPair(t)
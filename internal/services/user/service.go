@@ -3,8 +3,10 @@ package user
 
 import (
 	"context"
+	"sort"
 
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/auth"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/auth/permissions"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/db"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
@@ -29,6 +31,8 @@ type Service interface {
 	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 	GetUsers(ctx context.Context, input *GetUsersInput) (*db.UsersResult, error)
 	GetUsersByIDs(ctx context.Context, idList []string) ([]models.User, error)
+	ReassignCreatedBy(ctx context.Context, fromSubject, toSubject string, scope *string) (int, error)
+	GetCreatorsInNamespace(ctx context.Context, namespacePath string) ([]string, error)
 }
 
 type service struct {
@@ -149,3 +153,136 @@ func (s *service) GetUsersByIDs(ctx context.Context, idList []string) ([]models.
 
 	return resp.Users, nil
 }
+
+// ReassignCreatedBy changes the creator of the groups, managed identities and VCS providers created by one subject
+// to another subject, optionally only within a namespace path, and returns the number of resources updated.
+func (s *service) ReassignCreatedBy(ctx context.Context, fromSubject, toSubject string, scope *string) (int, error) {
+	ctx, span := tracer.Start(ctx, "svc.ReassignCreatedBy")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return 0, err
+	}
+
+	if !caller.IsAdmin() {
+		tracing.RecordError(span, nil, "only system admins can reassign created by")
+		return 0, errors.New("only system admins can reassign created by", errors.WithErrorCode(errors.EForbidden))
+	}
+
+	if fromSubject == "" || toSubject == "" {
+		tracing.RecordError(span, nil, "subjects must not be empty")
+		return 0, errors.New("from and to subjects must not be empty", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	if fromSubject == toSubject {
+		tracing.RecordError(span, nil, "subjects must be different")
+		return 0, errors.New("from and to subjects must be different", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to begin DB transaction")
+		return 0, err
+	}
+
+	defer func() {
+		if txErr := s.dbClient.Transactions.RollbackTx(txContext); txErr != nil {
+			s.logger.Errorf("failed to rollback tx for service layer ReassignCreatedBy: %v", txErr)
+		}
+	}()
+
+	groupCount, err := s.dbClient.Groups.ReassignCreatedBy(txContext, fromSubject, toSubject, scope)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to reassign created by for groups")
+		return 0, err
+	}
+
+	managedIdentityCount, err := s.dbClient.ManagedIdentities.ReassignCreatedBy(txContext, fromSubject, toSubject, scope)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to reassign created by for managed identities")
+		return 0, err
+	}
+
+	vcsProviderCount, err := s.dbClient.VCSProviders.ReassignCreatedBy(txContext, fromSubject, toSubject, scope)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to reassign created by for VCS providers")
+		return 0, err
+	}
+
+	if err = s.dbClient.Transactions.CommitTx(txContext); err != nil {
+		tracing.RecordError(span, err, "failed to commit DB transaction")
+		return 0, err
+	}
+
+	total := groupCount + managedIdentityCount + vcsProviderCount
+
+	s.logger.Infow("Reassigned created by.",
+		"caller", caller.GetSubject(),
+		"fromSubject", fromSubject,
+		"toSubject", toSubject,
+		"count", total,
+	)
+
+	return total, nil
+}
+
+// GetCreatorsInNamespace returns the distinct subjects that created the groups, workspaces, managed identities and VCS providers
+// in or under a namespace path, which is useful when reviewing who has created resources in the namespace.
+func (s *service) GetCreatorsInNamespace(ctx context.Context, namespacePath string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetCreatorsInNamespace")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return nil, err
+	}
+
+	if err = caller.RequirePermission(ctx, permissions.ViewGroupPermission, auth.WithNamespacePath(namespacePath)); err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return nil, err
+	}
+
+	groupCreators, err := s.dbClient.Groups.GetCreatedBySubjects(ctx, namespacePath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get group creators")
+		return nil, err
+	}
+
+	managedIdentityCreators, err := s.dbClient.ManagedIdentities.GetCreatedBySubjects(ctx, namespacePath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get managed identity creators")
+		return nil, err
+	}
+
+	vcsProviderCreators, err := s.dbClient.VCSProviders.GetCreatedBySubjects(ctx, namespacePath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get VCS provider creators")
+		return nil, err
+	}
+
+	workspaceCreators, err := s.dbClient.Workspaces.GetCreatedBySubjects(ctx, namespacePath)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get workspace creators")
+		return nil, err
+	}
+
+	seen := map[string]struct{}{}
+	creators := []string{}
+	for _, subjects := range [][]string{groupCreators, managedIdentityCreators, vcsProviderCreators, workspaceCreators} {
+		for _, subject := range subjects {
+			if _, ok := seen[subject]; !ok {
+				seen[subject] = struct{}{}
+				creators = append(creators, subject)
+			}
+		}
+	}
+
+	sort.Strings(creators)
+
+	return creators, nil
+}
@@ -0,0 +1,209 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/auth"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/auth/permissions"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/db"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/logger"
+)
+
+func TestReassignCreatedBy(t *testing.T) {
+	fromSubject := "old-user@example.com"
+	toSubject := "new-user@example.com"
+
+	type testCase struct {
+		name                 string
+		fromSubject          string
+		toSubject            string
+		scope                *string
+		isAdmin              bool
+		expectCount          int
+		expectErrorCode      errors.CodeType
+		groupCount           int
+		managedIdentityCount int
+		vcsProviderCount     int
+	}
+
+	testCases := []testCase{
+		{
+			name:                 "reassign across all namespaces",
+			fromSubject:          fromSubject,
+			toSubject:            toSubject,
+			isAdmin:              true,
+			groupCount:           2,
+			managedIdentityCount: 3,
+			vcsProviderCount:     1,
+			expectCount:          6,
+		},
+		{
+			name:                 "reassign within a namespace scope",
+			fromSubject:          fromSubject,
+			toSubject:            toSubject,
+			scope:                ptr.String("top-level/nested"),
+			isAdmin:              true,
+			groupCount:           1,
+			managedIdentityCount: 1,
+			expectCount:          2,
+		},
+		{
+			name:            "caller is not an admin",
+			fromSubject:     fromSubject,
+			toSubject:       toSubject,
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name:            "subjects are the same",
+			fromSubject:     fromSubject,
+			toSubject:       fromSubject,
+			isAdmin:         true,
+			expectErrorCode: errors.EInvalid,
+		},
+		{
+			name:            "subject is empty",
+			fromSubject:     fromSubject,
+			isAdmin:         true,
+			expectErrorCode: errors.EInvalid,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockGroups := db.NewMockGroups(t)
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockVCSProviders := db.NewMockVCSProviders(t)
+
+			mockCaller.On("IsAdmin").Return(test.isAdmin)
+			mockCaller.On("GetSubject").Return("admin").Maybe()
+
+			if test.expectErrorCode == "" {
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+
+				mockGroups.On("ReassignCreatedBy", mock.Anything, test.fromSubject, test.toSubject, test.scope).Return(test.groupCount, nil)
+				mockManagedIdentities.On("ReassignCreatedBy", mock.Anything, test.fromSubject, test.toSubject, test.scope).Return(test.managedIdentityCount, nil)
+				mockVCSProviders.On("ReassignCreatedBy", mock.Anything, test.fromSubject, test.toSubject, test.scope).Return(test.vcsProviderCount, nil)
+			}
+
+			dbClient := &db.Client{
+				Transactions:      mockTransactions,
+				Groups:            mockGroups,
+				ManagedIdentities: mockManagedIdentities,
+				VCSProviders:      mockVCSProviders,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient)
+
+			count, err := service.ReassignCreatedBy(auth.WithCaller(ctx, mockCaller), test.fromSubject, test.toSubject, test.scope)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, test.expectCount, count)
+		})
+	}
+}
+
+func TestGetCreatorsInNamespace(t *testing.T) {
+	namespacePath := "top-level/nested"
+
+	type testCase struct {
+		authError               error
+		name                    string
+		groupCreators           []string
+		managedIdentityCreators []string
+		vcsProviderCreators     []string
+		workspaceCreators       []string
+		expectCreators          []string
+		expectErrorCode         errors.CodeType
+	}
+
+	testCases := []testCase{
+		{
+			name:                    "creators are merged across resources without duplicates",
+			groupCreators:           []string{"user-2@example.com", "user-1@example.com"},
+			managedIdentityCreators: []string{"user-1@example.com", "top-level/nested/service-account"},
+			vcsProviderCreators:     []string{"user-3@example.com"},
+			workspaceCreators:       []string{"user-4@example.com", "user-2@example.com"},
+			expectCreators: []string{
+				"top-level/nested/service-account",
+				"user-1@example.com",
+				"user-2@example.com",
+				"user-3@example.com",
+				"user-4@example.com",
+			},
+		},
+		{
+			name:                    "no resources in the namespace",
+			groupCreators:           []string{},
+			managedIdentityCreators: []string{},
+			vcsProviderCreators:     []string{},
+			workspaceCreators:       []string{},
+			expectCreators:          []string{},
+		},
+		{
+			name:            "caller cannot view the namespace",
+			authError:       errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden)),
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+			mockGroups := db.NewMockGroups(t)
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockVCSProviders := db.NewMockVCSProviders(t)
+			mockWorkspaces := db.NewMockWorkspaces(t)
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.ViewGroupPermission, mock.Anything).Return(test.authError)
+
+			if test.authError == nil {
+				mockGroups.On("GetCreatedBySubjects", mock.Anything, namespacePath).Return(test.groupCreators, nil)
+				mockManagedIdentities.On("GetCreatedBySubjects", mock.Anything, namespacePath).Return(test.managedIdentityCreators, nil)
+				mockVCSProviders.On("GetCreatedBySubjects", mock.Anything, namespacePath).Return(test.vcsProviderCreators, nil)
+				mockWorkspaces.On("GetCreatedBySubjects", mock.Anything, namespacePath).Return(test.workspaceCreators, nil)
+			}
+
+			dbClient := &db.Client{
+				Groups:            mockGroups,
+				ManagedIdentities: mockManagedIdentities,
+				VCSProviders:      mockVCSProviders,
+				Workspaces:        mockWorkspaces,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient)
+
+			creators, err := service.GetCreatorsInNamespace(auth.WithCaller(ctx, mockCaller), namespacePath)
+
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, test.expectCreators, creators)
+		})
+	}
+}
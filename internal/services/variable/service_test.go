@@ -57,6 +57,26 @@ func TestCreateVariable(t *testing.T) {
 			limit:                       5,
 			injectVariablesPerNamespace: 5,
 		},
+		{
+			name: "create non-overridable namespace variable",
+			input: models.Variable{
+				NamespacePath:  namespacePath,
+				Category:       variableCategory,
+				Key:            variableKey,
+				Value:          &variableValue,
+				NonOverridable: true,
+			},
+			expectCreatedVariable: &models.Variable{
+				Metadata:       models.ResourceMetadata{ID: variableID},
+				NamespacePath:  namespacePath,
+				Category:       variableCategory,
+				Key:            variableKey,
+				Value:          &variableValue,
+				NonOverridable: true,
+			},
+			limit:                       5,
+			injectVariablesPerNamespace: 1,
+		},
 		{
 			name: "subject does not have permission",
 			input: models.Variable{
@@ -117,8 +137,9 @@ func TestCreateVariable(t *testing.T) {
 			}
 
 			if (test.expectCreatedVariable != nil) || test.exceedsLimit {
-				mockVariables.On("CreateVariable", mock.Anything, mock.Anything).
-					Return(test.expectCreatedVariable, nil)
+				mockVariables.On("CreateVariable", mock.Anything, mock.MatchedBy(func(v *models.Variable) bool {
+					return v.NonOverridable == test.input.NonOverridable
+				})).Return(test.expectCreatedVariable, nil)
 			}
 
 			dbClient := db.Client{
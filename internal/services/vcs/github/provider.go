@@ -4,6 +4,9 @@ package github
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -26,6 +29,13 @@ const (
 	// pullRequestEvent represents a GitHub pull request event.
 	pullRequestEvent = "pull_request"
 
+	// signatureHeader is the header GitHub uses to send the HMAC-SHA256 signature of a webhook payload.
+	// https://docs.github.com/en/webhooks/using-webhooks/validating-webhook-deliveries
+	signatureHeader = "X-Hub-Signature-256"
+
+	// signaturePrefix prefixes the hex-encoded signature in the signature header.
+	signaturePrefix = "sha256="
+
 	// gitHubReadWriteOAuthScopes represents space-separated OAuth scopes that are requested
 	// from the GitHub VCS provider. Passed in as 'scope' query parameter.
 	// NOTE: GitHub does not seem to support read-only 'repo' scope.
@@ -137,6 +147,29 @@ func (p *Provider) ToVCSEventType(input *types.ToVCSEventTypeInput) models.VCSEv
 	return ""
 }
 
+// VerifyWebhookSignature verifies the HMAC-SHA256 signature GitHub computes
+// over the webhook payload using the webhook secret.
+func (p *Provider) VerifyWebhookSignature(secret string, headers http.Header, body []byte) error {
+	signature := headers.Get(signatureHeader)
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return fmt.Errorf("missing or malformed %s header", signatureHeader)
+	}
+
+	actual, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return fmt.Errorf("failed to decode webhook signature: %v", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	if !hmac.Equal(actual, mac.Sum(nil)) {
+		return fmt.Errorf("webhook signature does not match payload")
+	}
+
+	return nil
+}
+
 // BuildOAuthAuthorizationURL build the authorization code URL which is
 // used to redirect the user to the VCS provider to complete OAuth flow.
 func (p *Provider) BuildOAuthAuthorizationURL(input *types.BuildOAuthAuthorizationURLInput) (string, error) {
@@ -477,7 +510,8 @@ func (p *Provider) CreateWebhook(ctx context.Context, input *types.CreateWebhook
 			// GitHub doesn't seem to support passing in token via 'token' field.
 			"url":          parsedURL.String(),
 			"content_type": "json",
-			"insecure_ssl": 0, // Don't allow webhook to connect with insecure SSL.
+			"insecure_ssl": 0,                   // Don't allow webhook to connect with insecure SSL.
+			"secret":       input.WebhookSecret, // Used by GitHub to sign payloads.
 		},
 	}
 
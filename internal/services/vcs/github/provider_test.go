@@ -3,6 +3,9 @@ package github
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -128,6 +131,64 @@ func TestToVCSEventType(t *testing.T) {
 	}
 }
 
+func TestVerifyWebhookSignature(t *testing.T) {
+	secret := "webhook-secret"
+	body := []byte(`{"ref":"refs/heads/main"}`)
+
+	sign := func(key string, payload []byte) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write(payload)
+		return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	testCases := []struct {
+		headers     http.Header
+		name        string
+		body        []byte
+		expectError bool
+	}{
+		{
+			name:    "positive: signature matches payload; expect no errors",
+			headers: http.Header{signatureHeader: []string{sign(secret, body)}},
+			body:    body,
+		},
+		{
+			name:        "negative: payload was tampered with",
+			headers:     http.Header{signatureHeader: []string{sign(secret, body)}},
+			body:        []byte(`{"ref":"refs/heads/other"}`),
+			expectError: true,
+		},
+		{
+			name:        "negative: payload signed with a different secret",
+			headers:     http.Header{signatureHeader: []string{sign("another-secret", body)}},
+			body:        body,
+			expectError: true,
+		},
+		{
+			name:        "negative: signature is not hex encoded",
+			headers:     http.Header{signatureHeader: []string{signaturePrefix + "not-hex"}},
+			body:        body,
+			expectError: true,
+		},
+		{
+			name:        "negative: signature header is missing",
+			headers:     http.Header{},
+			body:        body,
+			expectError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			provider, err := New(context.TODO(), nil, nil, "")
+			assert.Nil(t, err)
+
+			err = provider.VerifyWebhookSignature(secret, test.headers, test.body)
+			assert.Equal(t, test.expectError, err != nil)
+		})
+	}
+}
+
 func TestBuildOAuthAuthorizationURL(t *testing.T) {
 	// URL should be sample for both test cases since GitHub
 	// doesn't support read-only scopes.
@@ -876,6 +937,7 @@ func TestCreateWebhook(t *testing.T) {
 			"url":          "https://tharsis.domain/v1/vcs/events?token=webhook-auth-token",
 			"content_type": "json",
 			"insecure_ssl": float64(0), // Marshalling will convert to float64.
+			"secret":       "webhook-secret",
 		},
 		Events: eventTypes,
 		Active: true,
@@ -895,6 +957,7 @@ func TestCreateWebhook(t *testing.T) {
 				AccessToken:    "an-access-token",
 				RepositoryPath: "owner/repository",
 				WebhookToken:   []byte("webhook-auth-token"),
+				WebhookSecret:  "webhook-secret",
 			},
 			response: &createWebhookResponse{
 				ID: 50,
@@ -910,6 +973,7 @@ func TestCreateWebhook(t *testing.T) {
 				AccessToken:    "an-access-token",
 				RepositoryPath: "owner/repository",
 				WebhookToken:   []byte("webhook-auth-token"),
+				WebhookSecret:  "webhook-secret",
 			},
 			response: &createWebhookResponse{
 				ID: 50,
@@ -925,6 +989,7 @@ func TestCreateWebhook(t *testing.T) {
 				AccessToken:    "some-token",
 				RepositoryPath: "owner/repo",
 				WebhookToken:   []byte("webhook-auth-token"),
+				WebhookSecret:  "webhook-secret",
 			},
 			expectedError: fmt.Errorf("failed to create webhook. Response status: %s", "401"),
 		},
@@ -3,6 +3,7 @@ package gitlab
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -29,6 +30,10 @@ const (
 	// These base permissions are needed when webhooks aren't being used in order to validate
 	// the access token, download a repository tarball among other API interactions.
 	gitLabReadOnlyOAuthScopes = "read_user read_api"
+
+	// tokenHeader is the header GitLab uses to send the secret token configured on a webhook.
+	// https://docs.gitlab.com/ee/user/project/integrations/webhooks.html#validate-payloads-by-using-a-secret-token
+	tokenHeader = "X-Gitlab-Token"
 )
 
 var (
@@ -135,6 +140,22 @@ func (p *Provider) ToVCSEventType(input *types.ToVCSEventTypeInput) models.VCSEv
 	return supportedGitLabEvents[input.EventHeader]
 }
 
+// VerifyWebhookSignature verifies the webhook secret token. GitLab doesn't
+// sign payloads; instead it sends the secret token configured on the webhook
+// as a header, which must match the secret stored for the link.
+func (p *Provider) VerifyWebhookSignature(secret string, headers http.Header, _ []byte) error {
+	token := headers.Get(tokenHeader)
+	if token == "" {
+		return fmt.Errorf("missing %s header", tokenHeader)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		return fmt.Errorf("webhook token does not match secret")
+	}
+
+	return nil
+}
+
 // BuildOAuthAuthorizationURL build the authorization code URL which is
 // used to redirect the user to the VCS provider to complete OAuth flow.
 func (p *Provider) BuildOAuthAuthorizationURL(input *types.BuildOAuthAuthorizationURLInput) (string, error) {
@@ -498,15 +519,20 @@ func (p *Provider) CreateWebhook(ctx context.Context, input *types.CreateWebhook
 	}
 	parsedURL.Path = types.V1WebhookEndpoint
 
+	// Add the token as a query param since the secret token header is used for the webhook secret.
+	queries := parsedURL.Query()
+	queries.Set("token", string(input.WebhookToken))
+	parsedURL.RawQuery = queries.Encode()
+
 	// Add the webhook event types to body form.
 	form := url.Values{}
 	for _, event := range eventTypes {
 		form.Add(event, "true")
 	}
 
-	// Add the Tharsis URL and token.
+	// Add the Tharsis URL and secret token.
 	form.Add("url", parsedURL.String())
-	form.Add("token", string(input.WebhookToken))
+	form.Add("token", input.WebhookSecret)
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
 	if err != nil {
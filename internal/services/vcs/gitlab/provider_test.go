@@ -110,6 +110,41 @@ func TestToVCSEventType(t *testing.T) {
 	}
 }
 
+func TestVerifyWebhookSignature(t *testing.T) {
+	secret := "webhook-secret"
+
+	testCases := []struct {
+		headers     http.Header
+		name        string
+		expectError bool
+	}{
+		{
+			name:    "positive: token matches secret; expect no errors",
+			headers: http.Header{tokenHeader: []string{secret}},
+		},
+		{
+			name:        "negative: token does not match secret",
+			headers:     http.Header{tokenHeader: []string{"tampered-secret"}},
+			expectError: true,
+		},
+		{
+			name:        "negative: token header is missing",
+			headers:     http.Header{},
+			expectError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			provider, err := New(context.TODO(), nil, nil, "")
+			assert.Nil(t, err)
+
+			err = provider.VerifyWebhookSignature(secret, test.headers, []byte(`{"ref":"refs/heads/main"}`))
+			assert.Equal(t, test.expectError, err != nil)
+		})
+	}
+}
+
 func TestBuildOAuthAuthorizationURL(t *testing.T) {
 	testCases := []struct {
 		input       *types.BuildOAuthAuthorizationURLInput
@@ -904,8 +939,8 @@ func TestCreateWebhook(t *testing.T) {
 	ctx := context.Background()
 
 	sampleRequestBody := url.Values{}
-	sampleRequestBody.Add("url", "https://tharsis.domain/v1/vcs/events")
-	sampleRequestBody.Add("token", "webhook-auth-token")
+	sampleRequestBody.Add("url", "https://tharsis.domain/v1/vcs/events?token=webhook-auth-token")
+	sampleRequestBody.Add("token", "webhook-secret")
 
 	// Add webhook events.
 	for _, event := range eventTypes {
@@ -926,6 +961,7 @@ func TestCreateWebhook(t *testing.T) {
 				AccessToken:    "an-access-token",
 				RepositoryPath: "owner/repository",
 				WebhookToken:   []byte("webhook-auth-token"),
+				WebhookSecret:  "webhook-secret",
 			},
 			response: &createWebhookResponse{
 				ID: 50,
@@ -941,6 +977,7 @@ func TestCreateWebhook(t *testing.T) {
 				AccessToken:    "an-access-token",
 				RepositoryPath: "owner/repository",
 				WebhookToken:   []byte("webhook-auth-token"),
+				WebhookSecret:  "webhook-secret",
 			},
 			response: &createWebhookResponse{
 				ID: 50,
@@ -956,6 +993,7 @@ func TestCreateWebhook(t *testing.T) {
 				AccessToken:    "some-token",
 				RepositoryPath: "owner/repo",
 				WebhookToken:   []byte("webhook-auth-token"),
+				WebhookSecret:  "webhook-secret",
 			},
 			expectedError: fmt.Errorf("failed to create webhook. Response status: %s", "401"),
 		},
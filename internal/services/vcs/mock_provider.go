@@ -5,14 +5,11 @@ package vcs
 import (
 	context "context"
 	http "net/http"
+	url "net/url"
 
 	mock "github.com/stretchr/testify/mock"
-
 	models "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
-
 	types "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/vcs/types"
-
-	url "net/url"
 )
 
 // MockProvider is an autogenerated mock type for the Provider type
@@ -294,6 +291,20 @@ func (_m *MockProvider) ToVCSEventType(input *types.ToVCSEventTypeInput) models.
 	return r0
 }
 
+// VerifyWebhookSignature provides a mock function with given fields: secret, headers, body
+func (_m *MockProvider) VerifyWebhookSignature(secret string, headers http.Header, body []byte) error {
+	ret := _m.Called(secret, headers, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, http.Header, []byte) error); ok {
+		r0 = rf(secret, headers, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewMockProvider interface {
 	mock.TestingT
 	Cleanup(func())
@@ -31,6 +31,7 @@ type Provider interface {
 	CreateAccessToken(ctx context.Context, input *types.CreateAccessTokenInput) (*types.AccessTokenPayload, error)
 	CreateWebhook(ctx context.Context, input *types.CreateWebhookInput) (*types.WebhookPayload, error)
 	DeleteWebhook(ctx context.Context, input *types.DeleteWebhookInput) error
+	VerifyWebhookSignature(secret string, headers http.Header, body []byte) error
 }
 
 // NewVCSProviderMap returns a map containing a handler for each VCS provider type.
@@ -2,6 +2,8 @@ package vcs
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
@@ -121,6 +123,8 @@ type CreateWorkspaceVCSProviderLinkInput struct {
 	GlobPatterns        []string
 	AutoSpeculativePlan bool
 	WebhookDisabled     bool
+	// RunDebounceWindowSeconds cancels a still-queued VCS-triggered run when a newer one arrives within the window.
+	RunDebounceWindowSeconds int32
 }
 
 // UpdateWorkspaceVCSProviderLinkInput is the input for updating a VCS provider link.
@@ -136,9 +140,10 @@ type DeleteWorkspaceVCSProviderLinkInput struct {
 
 // CreateWorkspaceVCSProviderLinkResponse is the response for creating a workspace vcs provider link.
 type CreateWorkspaceVCSProviderLinkResponse struct {
-	WebhookURL   *string
-	Link         *models.WorkspaceVCSProviderLink
-	WebhookToken []byte
+	WebhookURL    *string
+	WebhookSecret *string
+	Link          *models.WorkspaceVCSProviderLink
+	WebhookToken  []byte
 }
 
 // CreateVCSRunInput is the input for creating a run via VCS.
@@ -168,14 +173,16 @@ type CreateVCSProviderResponse struct {
 // ProcessWebhookEventInput is the input for processing a webhook event.
 type ProcessWebhookEventInput struct {
 	EventHeader      string
-	Action           string // Type of action for a MR / PR.
-	SourceRepository string // Repository from which the MR originated.
-	SourceBranch     string // Source branch from which the MR originated.
-	TargetBranch     string // Branch this MR is for.
-	HeadCommitID     string // Head commit for an MR.
-	Before           string // Commit SHA before the change (can be empty).
-	After            string // Commit SHA after the change  (can be empty).
-	Ref              string // Ref name starting with refs/heads or similar.
+	Action           string      // Type of action for a MR / PR.
+	SourceRepository string      // Repository from which the MR originated.
+	SourceBranch     string      // Source branch from which the MR originated.
+	TargetBranch     string      // Branch this MR is for.
+	HeadCommitID     string      // Head commit for an MR.
+	Before           string      // Commit SHA before the change (can be empty).
+	After            string      // Commit SHA after the change  (can be empty).
+	Ref              string      // Ref name starting with refs/heads or similar.
+	Headers          http.Header // Request headers, used to verify the webhook payload.
+	Body             []byte      // Raw request body, used to verify the webhook payload.
 }
 
 // ProcessOAuthInput is the input for processing OAuth callback.
@@ -239,6 +246,7 @@ type Service interface {
 	CreateWorkspaceVCSProviderLink(ctx context.Context, input *CreateWorkspaceVCSProviderLinkInput) (*CreateWorkspaceVCSProviderLinkResponse, error)
 	UpdateWorkspaceVCSProviderLink(ctx context.Context, input *UpdateWorkspaceVCSProviderLinkInput) (*models.WorkspaceVCSProviderLink, error)
 	DeleteWorkspaceVCSProviderLink(ctx context.Context, input *DeleteWorkspaceVCSProviderLinkInput) error
+	RelinkWorkspaces(ctx context.Context, oldProviderID, newProviderID string) error
 	GetVCSEventByID(ctx context.Context, id string) (*models.VCSEvent, error)
 	GetVCSEvents(ctx context.Context, input *GetVCSEventsInput) (*db.VCSEventsResult, error)
 	GetVCSEventsByIDs(ctx context.Context, idList []string) ([]models.VCSEvent, error)
@@ -261,6 +269,7 @@ type service struct {
 	oAuthStateGenerator func() (uuid.UUID, error) // Overriding for unit tests.
 	tharsisURL          string
 	repositorySizeLimit int
+	allowedHosts        []string // Host patterns providers may point at; empty allows any host.
 }
 
 // NewService creates an instance of Service
@@ -277,7 +286,14 @@ func NewService(
 	taskManager asynctask.Manager,
 	tharsisURL string,
 	repositorySizeLimit int,
+	allowedHosts []string,
 ) (Service, error) {
+	for _, pattern := range allowedHosts {
+		if !doublestar.ValidatePattern(pattern) {
+			return nil, fmt.Errorf("invalid VCS provider allowed host pattern %q", pattern)
+		}
+	}
+
 	vcsProviderMap, err := NewVCSProviderMap(ctx, logger, httpClient, tharsisURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize vcs provider map %v", err)
@@ -296,6 +312,7 @@ func NewService(
 		uuid.NewRandom,
 		tharsisURL,
 		repositorySizeLimit,
+		allowedHosts,
 	), nil
 }
 
@@ -312,7 +329,13 @@ func newService(
 	oAuthStateGenerator func() (uuid.UUID, error),
 	tharsisURL string,
 	repositorySizeLimit int,
+	allowedHosts []string,
 ) Service {
+	lowerAllowedHosts := make([]string, len(allowedHosts))
+	for i, pattern := range allowedHosts {
+		lowerAllowedHosts[i] = strings.ToLower(pattern)
+	}
+
 	return &service{
 		logger,
 		dbClient,
@@ -326,6 +349,7 @@ func newService(
 		oAuthStateGenerator,
 		tharsisURL,
 		repositorySizeLimit,
+		lowerAllowedHosts,
 	}
 }
 
@@ -382,16 +406,8 @@ func (s *service) GetVCSProviders(ctx context.Context, input *GetVCSProvidersInp
 	}
 
 	if input.IncludeInherited {
-		pathParts := strings.Split(input.NamespacePath, "/")
-
-		paths := []string{}
-		for len(pathParts) > 0 {
-			paths = append(paths, strings.Join(pathParts, "/"))
-			// Remove last element
-			pathParts = pathParts[:len(pathParts)-1]
-		}
-
-		filter.NamespacePaths = paths
+		// Expand the namespace path with its ancestors so providers from parent groups are included.
+		filter.NamespacePaths = models.ExpandGroupPath(input.NamespacePath)
 	} else {
 		// This will return an empty result for workspace namespaces because workspaces
 		// don't have VCS providers directly associated (i.e. only group namespaces do)
@@ -488,6 +504,11 @@ func (s *service) CreateVCSProvider(ctx context.Context, input *CreateVCSProvide
 		providerURL = *parsedURL
 	}
 
+	if err = s.requireAllowedHost(&providerURL); err != nil {
+		tracing.RecordError(span, err, "provider URL host is not allowed")
+		return nil, err
+	}
+
 	// Use a UUID for the state.
 	oAuthState, err := s.oAuthStateGenerator()
 	if err != nil {
@@ -528,6 +549,20 @@ func (s *service) CreateVCSProvider(ctx context.Context, input *CreateVCSProvide
 		return nil, err
 	}
 
+	// Check for a provider with the same name in the group to return a friendlier error than the constraint violation.
+	existingCount, err := s.dbClient.VCSProviders.GetProviderCount(txContext, &db.VCSProviderFilter{
+		GroupID: &toCreate.GroupID,
+		Name:    &toCreate.Name,
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get VCS provider count")
+		return nil, err
+	}
+	if existingCount > 0 {
+		tracing.RecordError(span, nil, "vcs provider %s already exists", toCreate.Name)
+		return nil, errors.New("vcs provider %s already exists", toCreate.Name, errors.WithErrorCode(errors.EConflict))
+	}
+
 	createdProvider, err := s.dbClient.VCSProviders.CreateProvider(txContext, toCreate)
 	if err != nil {
 		tracing.RecordError(span, err, "failed to create provider")
@@ -537,21 +572,24 @@ func (s *service) CreateVCSProvider(ctx context.Context, input *CreateVCSProvide
 	groupPath := createdProvider.GetGroupPath()
 
 	// Get the number of VCS providers in the group to check whether we just violated the limit.
-	newVCSProviders, err := s.dbClient.VCSProviders.GetProviders(txContext, &db.GetVCSProvidersInput{
-		Filter: &db.VCSProviderFilter{
-			NamespacePaths: []string{groupPath},
-		},
-		PaginationOptions: &pagination.Options{
-			First: ptr.Int32(0),
-		},
+	vcsProviderCount, err := s.dbClient.VCSProviders.GetProviderCount(txContext, &db.VCSProviderFilter{
+		NamespacePaths: []string{groupPath},
 	})
 	if err != nil {
-		tracing.RecordError(span, err, "failed to get VCS providers")
+		tracing.RecordError(span, err, "failed to get VCS provider count")
 		return nil, err
 	}
 	if err = s.limitChecker.CheckLimit(txContext,
-		limits.ResourceLimitVCSProvidersPerGroup, newVCSProviders.PageInfo.TotalCount); err != nil {
+		limits.ResourceLimitVCSProvidersPerGroup, vcsProviderCount); err != nil {
 		tracing.RecordError(span, err, "limit check failed")
+		if limitErr, ok := limits.AsLimitExceededError(err); ok {
+			s.logger.Infow("VCS provider limit exceeded.",
+				"groupPath", groupPath,
+				"limitName", limitErr.LimitName,
+				"value", limitErr.Value,
+				"limit", limitErr.Limit,
+			)
+		}
 		return nil, err
 	}
 
@@ -612,6 +650,11 @@ func (s *service) UpdateVCSProvider(ctx context.Context, input *UpdateVCSProvide
 		return nil, err
 	}
 
+	if err = s.requireAllowedHost(&input.Provider.URL); err != nil {
+		tracing.RecordError(span, err, "provider URL host is not allowed")
+		return nil, err
+	}
+
 	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
 	if err != nil {
 		tracing.RecordError(span, err, "failed to begin DB transaction")
@@ -658,6 +701,22 @@ func (s *service) UpdateVCSProvider(ctx context.Context, input *UpdateVCSProvide
 	return updatedProvider, nil
 }
 
+// requireAllowedHost returns an EInvalid error if the provider URL's host doesn't match any of the allowed host patterns.
+func (s *service) requireAllowedHost(providerURL *url.URL) error {
+	if len(s.allowedHosts) == 0 {
+		return nil
+	}
+
+	host := strings.ToLower(providerURL.Hostname())
+	for _, pattern := range s.allowedHosts {
+		if matched, _ := doublestar.Match(pattern, host); matched {
+			return nil
+		}
+	}
+
+	return errors.New("VCS provider host %s is not allowed", host, errors.WithErrorCode(errors.EInvalid))
+}
+
 func (s *service) DeleteVCSProvider(ctx context.Context, input *DeleteVCSProviderInput) error {
 	ctx, span := tracer.Start(ctx, "svc.DeleteVCSProvider")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -927,17 +986,25 @@ func (s *service) CreateWorkspaceVCSProviderLink(ctx context.Context, input *Cre
 
 	jwtID := uuid.New().String()
 
+	webhookSecret, err := newWebhookSecret()
+	if err != nil {
+		tracing.RecordError(span, err, "failed to generate webhook secret")
+		return nil, err
+	}
+
 	toCreate := &models.WorkspaceVCSProviderLink{
-		CreatedBy:           caller.GetSubject(),
-		WorkspaceID:         input.Workspace.Metadata.ID,
-		ProviderID:          input.ProviderID,
-		TokenNonce:          jwtID,
-		Branch:              branch,
-		RepositoryPath:      input.RepositoryPath,
-		TagRegex:            input.TagRegex,
-		GlobPatterns:        input.GlobPatterns,
-		AutoSpeculativePlan: input.AutoSpeculativePlan,
-		WebhookDisabled:     input.WebhookDisabled,
+		CreatedBy:                caller.GetSubject(),
+		WorkspaceID:              input.Workspace.Metadata.ID,
+		ProviderID:               input.ProviderID,
+		TokenNonce:               jwtID,
+		Branch:                   branch,
+		RepositoryPath:           input.RepositoryPath,
+		TagRegex:                 input.TagRegex,
+		GlobPatterns:             input.GlobPatterns,
+		AutoSpeculativePlan:      input.AutoSpeculativePlan,
+		WebhookDisabled:          input.WebhookDisabled,
+		RunDebounceWindowSeconds: input.RunDebounceWindowSeconds,
+		WebhookSecret:            &webhookSecret,
 	}
 
 	// Clean module directory path. Attempting to clean an
@@ -983,6 +1050,7 @@ func (s *service) CreateWorkspaceVCSProviderLink(ctx context.Context, input *Cre
 			AccessToken:    accessToken,
 			RepositoryPath: createdLink.RepositoryPath,
 			WebhookToken:   token,
+			WebhookSecret:  webhookSecret,
 		})
 		if cErr != nil {
 			tracing.RecordError(span, cErr, "failed to create webhook")
@@ -998,18 +1066,12 @@ func (s *service) CreateWorkspaceVCSProviderLink(ctx context.Context, input *Cre
 			return nil, err
 		}
 	} else {
-		// Get the webhook URL based on the provider type. GitLab supports
-		// passing in a token whereas GitHub does not. It must be added
-		// as a query parameter for the latter.
-		var webhookToken []byte
-		switch vp.Type {
-		case models.GitLabProviderType:
-			response.WebhookToken = token // Only set token field for GitLab.
-		case models.GitHubProviderType:
-			webhookToken = token // For GitHub. include token as a query param.
-		}
+		// The token is passed in as a query parameter for all provider types, since the
+		// webhook secret must be configured as the GitLab secret token or GitHub secret.
+		response.WebhookToken = token
+		response.WebhookSecret = &webhookSecret
 
-		webhookURL, wErr := getTharsisWebhookURL(s.tharsisURL, webhookToken)
+		webhookURL, wErr := getTharsisWebhookURL(s.tharsisURL, token)
 		if wErr != nil {
 			tracing.RecordError(span, wErr, "failed to get webhook URL")
 			return nil, wErr
@@ -1137,6 +1199,262 @@ func (s *service) DeleteWorkspaceVCSProviderLink(ctx context.Context, input *Del
 	return s.dbClient.WorkspaceVCSProviderLinks.DeleteLink(ctx, input.Link)
 }
 
+func (s *service) RelinkWorkspaces(ctx context.Context, oldProviderID, newProviderID string) error {
+	ctx, span := tracer.Start(ctx, "svc.RelinkWorkspaces")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return err
+	}
+
+	if oldProviderID == newProviderID {
+		tracing.RecordError(span, nil, "old and new VCS providers must be different")
+		return errors.New("old and new VCS providers must be different", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	oldVP, err := s.getProviderForRelink(ctx, caller, oldProviderID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get old provider")
+		return err
+	}
+
+	newVP, err := s.getProviderForRelink(ctx, caller, newProviderID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get new provider")
+		return err
+	}
+
+	// Links can only be moved between providers for the same VCS host.
+	if oldVP.Type != newVP.Type || oldVP.URL.String() != newVP.URL.String() {
+		tracing.RecordError(span, nil, "VCS providers %s and %s are not compatible", oldVP.ResourcePath, newVP.ResourcePath)
+		return errors.New(
+			"VCS providers %s and %s are not compatible; both must have the same type and URL",
+			oldVP.ResourcePath, newVP.ResourcePath,
+			errors.WithErrorCode(errors.EInvalid),
+		)
+	}
+
+	// Make sure the token is there, otherwise user forgot to complete
+	// the OAuth flow for the new VCS provider.
+	if newVP.OAuthAccessToken == nil {
+		tracing.RecordError(span, nil, "OAuth flow must be completed before relinking workspaces to a VCS provider")
+		return errors.New(
+			"OAuth flow must be completed before relinking workspaces to a VCS provider",
+			errors.WithErrorCode(errors.EInvalid),
+		)
+	}
+
+	links, err := s.dbClient.WorkspaceVCSProviderLinks.GetLinksByProviderID(ctx, oldProviderID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get links by provider ID")
+		return err
+	}
+
+	if len(links) == 0 {
+		// Nothing to relink.
+		return nil
+	}
+
+	workspaceIDs := []string{}
+	for _, link := range links {
+		workspaceIDs = append(workspaceIDs, link.WorkspaceID)
+	}
+
+	workspacesResult, err := s.dbClient.Workspaces.GetWorkspaces(ctx, &db.GetWorkspacesInput{
+		Filter: &db.WorkspaceFilter{
+			WorkspaceIDs: workspaceIDs,
+		},
+	})
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get workspaces")
+		return err
+	}
+
+	// Verify that the new vcs provider's group is in the same hierarchy as every linked workspace.
+	newGroupPath := newVP.ResourcePath[:strings.LastIndex(newVP.ResourcePath, "/")+1]
+	for _, ws := range workspacesResult.Workspaces {
+		if !strings.HasPrefix(ws.FullPath, newGroupPath) {
+			tracing.RecordError(span, nil,
+				"VCS provider %s is not available to workspace %s", newVP.ResourcePath, ws.FullPath)
+			return errors.New("VCS provider %s is not available to workspace %s", newVP.ResourcePath, ws.FullPath, errors.WithErrorCode(errors.EInvalid))
+		}
+	}
+
+	provider, err := s.getVCSProvider(newVP.Type)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get VCS provider")
+		return err
+	}
+
+	var oldAccessToken, newAccessToken string
+
+	// Old webhooks are only replaced when the new provider creates them, otherwise they
+	// keep working since the link's token and secret are unchanged.
+	if oldVP.AutoCreateWebhooks && newVP.AutoCreateWebhooks {
+		// The old provider's token may no longer be usable, so webhook cleanup is best effort.
+		if oldAccessToken, err = s.refreshOAuthToken(ctx, provider, oldVP, false); err != nil {
+			s.logger.Infof("unable to refresh access token for VCS provider %s, webhooks may have to be deleted manually: %v", oldVP.ResourcePath, err)
+		}
+	}
+
+	if newVP.AutoCreateWebhooks {
+		if newAccessToken, err = s.refreshOAuthToken(ctx, provider, newVP, false); err != nil {
+			tracing.RecordError(span, err, "failed to refresh access token")
+			return fmt.Errorf("failed to refresh access token: %v", err)
+		}
+	}
+
+	// Webhooks created for the new provider are deleted if the links can't be
+	// updated, otherwise they'd be left behind without a link.
+	createdWebhooks := []models.WorkspaceVCSProviderLink{}
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+
+		for _, link := range createdWebhooks {
+			if dErr := provider.DeleteWebhook(ctx, &types.DeleteWebhookInput{
+				ProviderURL:    newVP.URL,
+				AccessToken:    newAccessToken,
+				RepositoryPath: link.RepositoryPath,
+				WebhookID:      link.WebhookID,
+			}); dErr != nil {
+				s.logger.Infof("failed to delete webhook %s for repository %s, it may have to be deleted manually: %v",
+					link.WebhookID, link.RepositoryPath, dErr)
+			}
+		}
+	}()
+
+	// Create all the new webhooks before updating any links, so the old webhooks
+	// are kept until every link has been moved.
+	updatedLinks := []models.WorkspaceVCSProviderLink{}
+	for _, link := range links {
+		linkCopy := link
+		linkCopy.ProviderID = newProviderID
+
+		// Webhooks that aren't created automatically were configured by the user with the link's
+		// token and secret, so they're kept to avoid breaking those webhooks.
+		if newVP.AutoCreateWebhooks {
+			webhookSecret, sErr := newWebhookSecret()
+			if sErr != nil {
+				tracing.RecordError(span, sErr, "failed to generate webhook secret")
+				return sErr
+			}
+
+			// A new nonce invalidates any webhook tokens issued for the old provider.
+			linkCopy.TokenNonce = uuid.New().String()
+			linkCopy.WebhookSecret = &webhookSecret
+
+			token, gErr := s.idp.GenerateToken(ctx, &auth.TokenInput{
+				Subject: newVP.ResourcePath,
+				JwtID:   linkCopy.TokenNonce,
+				Claims: map[string]string{
+					"type":    auth.VCSWorkspaceLinkTokenType,
+					"link_id": gid.ToGlobalID(gid.WorkspaceVCSProviderLinkType, linkCopy.Metadata.ID),
+				},
+			})
+			if gErr != nil {
+				tracing.RecordError(span, gErr, "failed to generate token with a UUID claim")
+				return gErr
+			}
+
+			payload, cErr := provider.CreateWebhook(ctx, &types.CreateWebhookInput{
+				ProviderURL:    newVP.URL,
+				AccessToken:    newAccessToken,
+				RepositoryPath: linkCopy.RepositoryPath,
+				WebhookToken:   token,
+				WebhookSecret:  webhookSecret,
+			})
+			if cErr != nil {
+				tracing.RecordError(span, cErr, "failed to create webhook")
+				return cErr
+			}
+
+			linkCopy.WebhookID = payload.WebhookID
+			createdWebhooks = append(createdWebhooks, linkCopy)
+		}
+
+		updatedLinks = append(updatedLinks, linkCopy)
+	}
+
+	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to begin DB transaction")
+		return err
+	}
+
+	defer func() {
+		if txErr := s.dbClient.Transactions.RollbackTx(txContext); txErr != nil {
+			s.logger.Errorf("failed to rollback tx for service layer RelinkWorkspaces: %v", txErr)
+		}
+	}()
+
+	for _, link := range updatedLinks {
+		linkCopy := link
+		if _, err = s.dbClient.WorkspaceVCSProviderLinks.UpdateLink(txContext, &linkCopy); err != nil {
+			tracing.RecordError(span, err, "failed to update link")
+			return err
+		}
+	}
+
+	if err = s.dbClient.Transactions.CommitTx(txContext); err != nil {
+		tracing.RecordError(span, err, "failed to commit DB transaction")
+		return err
+	}
+
+	committed = true
+
+	// The links no longer reference the old webhooks, so deleting them is best effort.
+	if oldVP.AutoCreateWebhooks && oldAccessToken != "" {
+		for _, link := range links {
+			if link.WebhookID == "" {
+				continue
+			}
+
+			if dErr := provider.DeleteWebhook(ctx, &types.DeleteWebhookInput{
+				ProviderURL:    oldVP.URL,
+				AccessToken:    oldAccessToken,
+				RepositoryPath: link.RepositoryPath,
+				WebhookID:      link.WebhookID,
+			}); dErr != nil {
+				s.logger.Infof("failed to delete webhook %s for repository %s, it may have to be deleted manually: %v",
+					link.WebhookID, link.RepositoryPath, dErr)
+			}
+		}
+	}
+
+	s.logger.Infow("Relinked workspaces to a new VCS provider.",
+		"caller", caller.GetSubject(),
+		"oldProviderID", oldProviderID,
+		"newProviderID", newProviderID,
+		"linkCount", len(links),
+	)
+
+	return nil
+}
+
+// getProviderForRelink returns the VCS provider and verifies the caller can update it.
+func (s *service) getProviderForRelink(ctx context.Context, caller auth.Caller, id string) (*models.VCSProvider, error) {
+	vp, err := s.dbClient.VCSProviders.GetProviderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if vp == nil {
+		return nil, errors.New("vcs provider with id %s not found", id, errors.WithErrorCode(errors.ENotFound))
+	}
+
+	if err = caller.RequirePermission(ctx, permissions.UpdateVCSProviderPermission, auth.WithGroupID(vp.GroupID)); err != nil {
+		return nil, err
+	}
+
+	return vp, nil
+}
+
 func (s *service) GetVCSEventByID(ctx context.Context, id string) (*models.VCSEvent, error) {
 	ctx, span := tracer.Start(ctx, "svc.GetVCSEventByID")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -1391,6 +1709,28 @@ func (s *service) ProcessWebhookEvent(ctx context.Context, input *ProcessWebhook
 		return errors.New("Invalid caller; only version control systems can invoke webhook", errors.WithErrorCode(errors.EInvalid))
 	}
 
+	provider, err := s.getVCSProvider(vcsCaller.Provider.Type)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get VCS provider")
+		return err
+	}
+
+	// Links whose webhook was registered before payloads were verified don't have a secret,
+	// so their payloads can't be verified until the link is created again.
+	if vcsCaller.Link.WebhookSecret == nil {
+		tracing.RecordError(span, nil, "workspace vcs provider link doesn't have a webhook secret")
+		return errors.New(
+			"workspace vcs provider link doesn't have a webhook secret; the link must be deleted and created again to verify webhook payloads",
+			errors.WithErrorCode(errors.EUnauthorized),
+		)
+	}
+
+	// Verify the payload hasn't been tampered with before acting on it.
+	if err = provider.VerifyWebhookSignature(*vcsCaller.Link.WebhookSecret, input.Headers, input.Body); err != nil {
+		tracing.RecordError(span, err, "webhook signature verification failed")
+		return errors.Wrap(err, "webhook signature verification failed", errors.WithErrorCode(errors.EUnauthorized))
+	}
+
 	// Require permission for creating plan runs.
 	err = caller.RequirePermission(ctx, permissions.CreateRunPermission, auth.WithWorkspaceID(vcsCaller.Link.WorkspaceID))
 	if err != nil {
@@ -1417,12 +1757,6 @@ func (s *service) ProcessWebhookEvent(ctx context.Context, input *ProcessWebhook
 		return nil
 	}
 
-	provider, err := s.getVCSProvider(vcsCaller.Provider.Type)
-	if err != nil {
-		tracing.RecordError(span, err, "failed to get VCS provider")
-		return err
-	}
-
 	eventType := provider.ToVCSEventType(&types.ToVCSEventTypeInput{
 		EventHeader: input.EventHeader,
 		Ref:         input.Ref,
@@ -1928,6 +2262,18 @@ func (s *service) handleEvent(ctx context.Context, input *handleEventInput) erro
 		)
 	}
 
+	// Find the still-queued runs this one supersedes before creating it, so it isn't included.
+	supersededRuns, err := s.getSupersededRuns(ctx, input.link, input.vcsEvent)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to get superseded runs for repository %s for workspace %s and workspace vcs provider link ID %s: %v",
+			input.link.RepositoryPath,
+			input.workspace.FullPath,
+			input.link.Metadata.ID,
+			err,
+		)
+	}
+
 	if _, err = s.runService.CreateRun(ctx, &run.CreateRunInput{
 		ConfigurationVersionID: &configurationVersionID,
 		WorkspaceID:            input.link.WorkspaceID,
@@ -1941,9 +2287,83 @@ func (s *service) handleEvent(ctx context.Context, input *handleEventInput) erro
 		)
 	}
 
+	for _, supersededRun := range supersededRuns {
+		if _, err = s.runService.CancelRun(ctx, &run.CancelRunInput{
+			RunID:   supersededRun.Metadata.ID,
+			Comment: ptr.String("Superseded by a newer VCS-triggered run"),
+		}); err != nil {
+			// The new run has already been created, so only log the error.
+			s.logger.Errorf(
+				"failed to cancel superseded run %s for workspace %s and workspace vcs provider link ID %s: %v",
+				supersededRun.Metadata.ID,
+				input.workspace.FullPath,
+				input.link.Metadata.ID,
+				err,
+			)
+		}
+	}
+
 	return nil
 }
 
+// getSupersededRuns returns the still-queued runs created within the link's debounce
+// window by an earlier VCS event of the same type and source reference.
+func (s *service) getSupersededRuns(ctx context.Context,
+	link *models.WorkspaceVCSProviderLink,
+	vcsEvent *models.VCSEvent,
+) ([]models.Run, error) {
+	if link.RunDebounceWindowSeconds <= 0 {
+		return nil, nil
+	}
+
+	windowStart := time.Now().Add(-time.Duration(link.RunDebounceWindowSeconds) * time.Second)
+
+	runsResult, err := s.dbClient.Runs.GetRuns(ctx, &db.GetRunsInput{
+		Filter: &db.RunFilter{
+			WorkspaceID:    &link.WorkspaceID,
+			TimeRangeStart: &windowStart,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	supersededRuns := []models.Run{}
+	for _, r := range runsResult.Runs {
+		if r.Status != models.RunPending && r.Status != models.RunPlanQueued {
+			continue
+		}
+
+		if r.ConfigurationVersionID == nil {
+			continue
+		}
+
+		cv, err := s.dbClient.ConfigurationVersions.GetConfigurationVersion(ctx, *r.ConfigurationVersionID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Runs not triggered by a VCS event are never superseded.
+		if cv == nil || cv.VCSEventID == nil {
+			continue
+		}
+
+		priorEvent, err := s.dbClient.VCSEvents.GetEventByID(ctx, *cv.VCSEventID)
+		if err != nil {
+			return nil, err
+		}
+
+		if priorEvent == nil || priorEvent.Type != vcsEvent.Type ||
+			ptr.ToString(priorEvent.SourceReferenceName) != ptr.ToString(vcsEvent.SourceReferenceName) {
+			continue
+		}
+
+		supersededRuns = append(supersededRuns, r)
+	}
+
+	return supersededRuns, nil
+}
+
 // createUploadConfigurationVersion creates a configuration version, uploads it
 // and waits for the upload to finish. Returns the configuration version ID and
 // any errors encountered.
@@ -2247,8 +2667,18 @@ func trimRefPrefix(ref string) string {
 	return ref
 }
 
+// newWebhookSecret returns a random secret used to verify webhook payloads.
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
 // getTharsisWebhookURL returns the Tharsis webhook URL with an optional
-// token as a query parameter (used for GitHub).
+// token as a query parameter.
 func getTharsisWebhookURL(tharsisURL string, token []byte) (string, error) {
 	endpoint, err := url.Parse(tharsisURL)
 	if err != nil {
@@ -104,7 +104,7 @@ func TestGetVCSProviderByID(t *testing.T) {
 				VCSProviders: &mockVCSProviders,
 			}
 
-			service := newService(nil, dbClient, nil, nil, nil, nil, nil, nil, nil, nil, "", 0)
+			service := newService(nil, dbClient, nil, nil, nil, nil, nil, nil, nil, nil, "", 0, nil)
 
 			provider, err := service.GetVCSProviderByID(ctx, test.inputID)
 			if test.expectedErrorCode != "" {
@@ -169,6 +169,33 @@ func TestGetVCSProviders(t *testing.T) {
 			caller:         &auth.SystemCaller{},
 			expectedResult: &db.VCSProvidersResult{},
 		},
+		{
+			name: "positive: without include inherited; expect only the namespace itself to be queried",
+			input: &GetVCSProvidersInput{
+				NamespacePath: "top-group/sub-group/workspace",
+			},
+			dbInput: &db.GetVCSProvidersInput{
+				Filter: &db.VCSProviderFilter{
+					NamespacePaths: []string{"top-group/sub-group/workspace"},
+				},
+			},
+			caller:         &auth.SystemCaller{},
+			expectedResult: &db.VCSProvidersResult{},
+		},
+		{
+			name: "positive: with include inherited; expect the namespace and its ancestors to be queried",
+			input: &GetVCSProvidersInput{
+				NamespacePath:    "top-group/sub-group/workspace",
+				IncludeInherited: true,
+			},
+			dbInput: &db.GetVCSProvidersInput{
+				Filter: &db.VCSProviderFilter{
+					NamespacePaths: []string{"top-group/sub-group/workspace", "top-group/sub-group", "top-group"},
+				},
+			},
+			caller:         &auth.SystemCaller{},
+			expectedResult: sampleResult,
+		},
 		{
 			name:              "negative: without caller; expect error EUnauthorized",
 			expectedErrorCode: errors.EUnauthorized,
@@ -189,7 +216,7 @@ func TestGetVCSProviders(t *testing.T) {
 				VCSProviders: &mockVCSProviders,
 			}
 
-			service := newService(nil, dbClient, nil, nil, nil, nil, nil, nil, nil, nil, "", 0)
+			service := newService(nil, dbClient, nil, nil, nil, nil, nil, nil, nil, nil, "", 0, nil)
 
 			result, err := service.GetVCSProviders(ctx, test.input)
 			if test.expectedErrorCode != "" {
@@ -262,7 +289,7 @@ func TestGetVCSProvidersByIDs(t *testing.T) {
 				VCSProviders: &mockVCSProviders,
 			}
 
-			service := newService(nil, dbClient, nil, nil, nil, nil, nil, nil, nil, nil, "", 0)
+			service := newService(nil, dbClient, nil, nil, nil, nil, nil, nil, nil, nil, "", 0, nil)
 
 			providerList, err := service.GetVCSProvidersByIDs(ctx, test.inputIDList)
 			if test.expectedErrorCode != "" {
@@ -289,9 +316,11 @@ func TestCreateVCSProvider(t *testing.T) {
 		expectedProvider      *models.VCSProvider
 		name                  string
 		expectedErrorCode     errors.CodeType
+		allowedHosts          []string
 		limit                 int
 		injectProviders       int32
 		exceedsLimit          bool
+		nameExists            bool
 	}{
 		{
 			name:   "positive: GitLab provider with URL, manual; expect provider created with values",
@@ -347,6 +376,7 @@ func TestCreateVCSProvider(t *testing.T) {
 				CreatedBy:          "sample@sample-email",
 				AutoCreateWebhooks: false,
 			},
+			allowedHosts:    []string{"gitlab.com", "EXAMPLE.com"},
 			limit:           5,
 			injectProviders: 5,
 		},
@@ -403,6 +433,7 @@ func TestCreateVCSProvider(t *testing.T) {
 				CreatedBy:          "system",
 				AutoCreateWebhooks: false,
 			},
+			allowedHosts:    []string{"*.com"},
 			limit:           5,
 			injectProviders: 5,
 		},
@@ -429,6 +460,20 @@ func TestCreateVCSProvider(t *testing.T) {
 			},
 			expectedErrorCode: errors.EInvalid,
 		},
+		{
+			name:   "negative: URL host is not in the allowed hosts; expect error EInvalid",
+			caller: &auth.SystemCaller{},
+			input: &CreateVCSProviderInput{
+				Name:              "a-sample-gitlab-provider",
+				GroupID:           "group-id",
+				OAuthClientID:     "a-sample-client-id",
+				OAuthClientSecret: "a-sample-client-secret",
+				Type:              models.GitLabProviderType,
+				URL:               ptr.String("https://gitlab.internal.local"),
+			},
+			allowedHosts:      []string{"gitlab.com", "*.example.com"},
+			expectedErrorCode: errors.EInvalid,
+		},
 		{
 			name:              "negative: without caller; expect error EUnauthorized",
 			input:             &CreateVCSProviderInput{},
@@ -493,6 +538,22 @@ func TestCreateVCSProvider(t *testing.T) {
 			exceedsLimit:      true,
 			expectedErrorCode: errors.EInvalid,
 		},
+		{
+			name:   "negative: provider with the same name already exists in the group; expect error EConflict",
+			caller: &auth.SystemCaller{},
+			input: &CreateVCSProviderInput{
+				Name:               "a-sample-gitlab-provider",
+				Description:        "",
+				GroupID:            "group-id",
+				OAuthClientID:      "a-sample-client-id",
+				OAuthClientSecret:  "a-sample-client-secret",
+				Type:               models.GitLabProviderType,
+				URL:                ptr.String(sampleProviderURL.String()),
+				AutoCreateWebhooks: false,
+			},
+			nameExists:        true,
+			expectedErrorCode: errors.EConflict,
+		},
 	}
 
 	for _, test := range testCases {
@@ -516,6 +577,17 @@ func TestCreateVCSProvider(t *testing.T) {
 			mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
 			mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
 
+			if (test.expectedErrorCode == "") || test.exceedsLimit || test.nameExists {
+				var existingCount int32
+				if test.nameExists {
+					existingCount = 1
+				}
+				mockVCSProviders.On("GetProviderCount", mock.Anything, &db.VCSProviderFilter{
+					GroupID: &test.input.GroupID,
+					Name:    &test.input.Name,
+				}).Return(existingCount, nil)
+			}
+
 			if (test.expectedErrorCode == "") || test.exceedsLimit {
 				mockVCSProviders.On("CreateProvider", mock.Anything, test.toCreate).Return(test.expectedProvider, nil)
 
@@ -526,23 +598,9 @@ func TestCreateVCSProvider(t *testing.T) {
 
 			// Called inside transaction to check resource limits.
 			if test.limit > 0 {
-				mockVCSProviders.On("GetProviders", mock.Anything, mock.Anything).Return(&db.GetVCSProvidersInput{
-					Filter: &db.VCSProviderFilter{
-						// empty
-					},
-					PaginationOptions: &pagination.Options{
-						First: ptr.Int32(0),
-					},
-				}).Return(func(ctx context.Context, input *db.GetVCSProvidersInput) *db.VCSProvidersResult {
-					_ = ctx
-					_ = input
-
-					return &db.VCSProvidersResult{
-						PageInfo: &pagination.PageInfo{
-							TotalCount: test.injectProviders,
-						},
-					}
-				}, nil)
+				mockVCSProviders.On("GetProviderCount", mock.Anything, &db.VCSProviderFilter{
+					NamespacePaths: []string{"a/resource"},
+				}).Return(test.injectProviders, nil)
 
 				mockResourceLimits.On("GetResourceLimit", mock.Anything, mock.Anything).
 					Return(&models.ResourceLimit{Value: test.limit}, nil)
@@ -566,9 +624,19 @@ func TestCreateVCSProvider(t *testing.T) {
 				return sampleOAuthState, nil
 			}
 
-			service := newService(logger, dbClient, limits.NewLimitChecker(dbClient), nil, providerMap, &mockActivityEventService, nil, nil, nil, stateGeneratorFunc, "", 0)
+			service := newService(logger, dbClient, limits.NewLimitChecker(dbClient), nil, providerMap, &mockActivityEventService, nil, nil, nil, stateGeneratorFunc, "", 0, test.allowedHosts)
 
 			response, err := service.CreateVCSProvider(ctx, test.input)
+
+			if test.exceedsLimit {
+				limitErr, ok := limits.AsLimitExceededError(err)
+				if assert.True(t, ok) {
+					assert.Equal(t, limits.ResourceLimitVCSProvidersPerGroup, limitErr.LimitName)
+					assert.Equal(t, test.injectProviders, limitErr.Value)
+					assert.Equal(t, test.limit, limitErr.Limit)
+				}
+			}
+
 			if test.expectedErrorCode != "" {
 				assert.Equal(t, test.expectedErrorCode, errors.ErrorCode(err))
 			} else if err != nil {
@@ -588,6 +656,7 @@ func TestUpdateVCSProvider(t *testing.T) {
 		activityInput     *activityevent.CreateActivityEventInput
 		name              string
 		expectedErrorCode errors.CodeType
+		allowedHosts      []string
 	}{
 		{
 			name:   "positive: update description; expect updated provider",
@@ -616,6 +685,26 @@ func TestUpdateVCSProvider(t *testing.T) {
 				TargetType:    models.TargetVCSProvider,
 				TargetID:      resourceUUID,
 			},
+			allowedHosts: []string{"example.com"},
+		},
+		{
+			name:   "negative: URL host is not in the allowed hosts; expect error EInvalid",
+			caller: &auth.SystemCaller{},
+			input: &UpdateVCSProviderInput{
+				&models.VCSProvider{
+					Metadata: models.ResourceMetadata{
+						ID: resourceUUID,
+					},
+					Name:              "a-sample-github-provider",
+					GroupID:           "group-id",
+					OAuthClientID:     "a-sample-client-id",
+					OAuthClientSecret: "a-sample-client-secret",
+					Type:              models.GitHubProviderType,
+					URL:               sampleProviderURL,
+				},
+			},
+			allowedHosts:      []string{"github.com"},
+			expectedErrorCode: errors.EInvalid,
 		},
 		{
 			name:              "negative: without caller; expect error EUnauthorized",
@@ -651,7 +740,7 @@ func TestUpdateVCSProvider(t *testing.T) {
 
 			logger, _ := logger.NewForTest()
 
-			service := newService(logger, dbClient, nil, nil, nil, &mockActivityEventService, nil, nil, nil, nil, "", 0)
+			service := newService(logger, dbClient, nil, nil, nil, &mockActivityEventService, nil, nil, nil, nil, "", 0, test.allowedHosts)
 
 			provider, err := service.UpdateVCSProvider(ctx, test.input)
 			if test.expectedErrorCode != "" {
@@ -861,7 +950,7 @@ func TestDeleteVCSProvider(t *testing.T) {
 			}
 
 			logger, _ := logger.NewForTest()
-			service := newService(logger, dbClient, nil, nil, providerMap, &mockActivityEventService, nil, nil, nil, stateGeneratorFunc, tharsisURL, 0)
+			service := newService(logger, dbClient, nil, nil, providerMap, &mockActivityEventService, nil, nil, nil, stateGeneratorFunc, tharsisURL, 0, nil)
 
 			err := service.DeleteVCSProvider(ctx, test.input)
 			if test.expectedErrorCode != "" {
@@ -920,7 +1009,7 @@ func TestGetVCSProviderLinkByWorkspaceID(t *testing.T) {
 				WorkspaceVCSProviderLinks: &mockWorkspaceVCSProviderLinks,
 			}
 
-			service := newService(nil, dbClient, nil, nil, nil, nil, nil, nil, nil, nil, "", 0)
+			service := newService(nil, dbClient, nil, nil, nil, nil, nil, nil, nil, nil, "", 0, nil)
 
 			link, err := service.GetWorkspaceVCSProviderLinkByWorkspaceID(ctx, test.workspaceID)
 			if test.expectedErrorCode != "" {
@@ -981,7 +1070,7 @@ func TestGetVCSProviderLinkByID(t *testing.T) {
 				WorkspaceVCSProviderLinks: &mockWorkspaceVCSProviderLinks,
 			}
 
-			service := newService(nil, dbClient, nil, nil, nil, nil, nil, nil, nil, nil, "", 0)
+			service := newService(nil, dbClient, nil, nil, nil, nil, nil, nil, nil, nil, "", 0, nil)
 
 			link, err := service.GetWorkspaceVCSProviderLinkByID(ctx, test.inputID)
 			if test.expectedErrorCode != "" {
@@ -1046,6 +1135,7 @@ func TestCreateWorkspaceVCSProviderLink(t *testing.T) {
 				AccessToken:    "an-access-token",
 				RepositoryPath: "owner/repository",
 				WebhookToken:   []byte("signed-token"),
+				// WebhookSecret is generated, so it's filled in from the created link.
 			},
 			existingProvider: &models.VCSProvider{
 				Metadata: models.ResourceMetadata{
@@ -1162,8 +1252,9 @@ func TestCreateWorkspaceVCSProviderLink(t *testing.T) {
 					TokenNonce:     "some-token-nonce",
 					TagRegex:       &sampleTagRegex,
 				},
-				WebhookURL: ptr.String("https://tharsis.domain/v1/vcs/events?token=signed-token"),
-				// No token for GitHub.
+				WebhookURL:   ptr.String("https://tharsis.domain/v1/vcs/events?token=signed-token"),
+				WebhookToken: []byte("signed-token"),
+				// WebhookSecret is generated, so it's checked separately.
 			},
 		},
 		{
@@ -1273,13 +1364,22 @@ func TestCreateWorkspaceVCSProviderLink(t *testing.T) {
 
 			mockProviders.On("CreateAccessToken", mock.Anything, createAccessTokenInput).Return(createAccessTokenPayload, nil)
 			mockProviders.On("GetProject", mock.Anything, test.getProjectInput).Return(sampleProjectPayload, nil)
-			mockProviders.On("CreateWebhook", mock.Anything, test.createWebhookInput).Return(sampleWebhookPayload, nil)
+
+			// The webhook secret is randomly generated, so capture it when the link is created.
+			var webhookSecret string
+			mockProviders.On("CreateWebhook", mock.Anything, mock.MatchedBy(func(input *types.CreateWebhookInput) bool {
+				expected := *test.createWebhookInput
+				expected.WebhookSecret = webhookSecret
+				return assert.ObjectsAreEqual(&expected, input)
+			})).Return(sampleWebhookPayload, nil)
 
 			mockVCSProviders.On("GetProviderByID", mock.Anything, "provider-id").Return(test.existingProvider, nil)
 
 			mockJWSProvider.On("Sign", mock.Anything, mock.Anything).Return([]byte("signed-token"), nil)
 
-			mockWorkspaceVCSProviderLinks.On("CreateLink", mock.Anything, mock.Anything).Return(test.createdLink, nil)
+			mockWorkspaceVCSProviderLinks.On("CreateLink", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				webhookSecret = *args.Get(1).(*models.WorkspaceVCSProviderLink).WebhookSecret
+			}).Return(test.createdLink, nil)
 			mockWorkspaceVCSProviderLinks.On("UpdateLink", mock.Anything, test.createdLink).Return(test.updatedLink, nil)
 
 			dbClient := &db.Client{
@@ -1301,7 +1401,7 @@ func TestCreateWorkspaceVCSProviderLink(t *testing.T) {
 			}
 
 			logger, _ := logger.NewForTest()
-			service := newService(logger, dbClient, nil, identityProvider, providerMap, nil, nil, nil, nil, stateGeneratorFunc, tharsisURL, 0)
+			service := newService(logger, dbClient, nil, identityProvider, providerMap, nil, nil, nil, nil, stateGeneratorFunc, tharsisURL, 0, nil)
 
 			response, err := service.CreateWorkspaceVCSProviderLink(ctx, test.input)
 			if test.expectedErrorCode != "" {
@@ -1309,7 +1409,15 @@ func TestCreateWorkspaceVCSProviderLink(t *testing.T) {
 			} else if err != nil {
 				t.Fatal(err)
 			} else {
-				assert.Equal(t, test.expectedResponse, response)
+				assert.Len(t, webhookSecret, 64)
+
+				expectedResponse := *test.expectedResponse
+				if expectedResponse.WebhookURL != nil {
+					// The webhook secret must be configured manually when Tharsis doesn't create the webhook.
+					expectedResponse.WebhookSecret = &webhookSecret
+				}
+
+				assert.Equal(t, &expectedResponse, response)
 			}
 		})
 	}
@@ -1387,7 +1495,7 @@ func TestUpdateWorkspaceVCSProviderLink(t *testing.T) {
 			}
 
 			logger, _ := logger.NewForTest()
-			service := newService(logger, dbClient, nil, nil, nil, nil, nil, nil, nil, nil, "", 0)
+			service := newService(logger, dbClient, nil, nil, nil, nil, nil, nil, nil, nil, "", 0, nil)
 
 			link, err := service.UpdateWorkspaceVCSProviderLink(ctx, test.input)
 			if test.expectedErrorCode != "" {
@@ -1513,7 +1621,7 @@ func TestDeleteWorkspaceVCSProviderLink(t *testing.T) {
 			}
 
 			logger, _ := logger.NewForTest()
-			service := newService(logger, dbClient, nil, nil, providerMap, nil, nil, nil, nil, oAuthStateGenerator, "", 0)
+			service := newService(logger, dbClient, nil, nil, providerMap, nil, nil, nil, nil, oAuthStateGenerator, "", 0, nil)
 
 			err := service.DeleteWorkspaceVCSProviderLink(ctx, test.input)
 			if test.expectedErrorCode != "" {
@@ -1527,6 +1635,231 @@ func TestDeleteWorkspaceVCSProviderLink(t *testing.T) {
 	}
 }
 
+func TestRelinkWorkspaces(t *testing.T) {
+	oldProvider := &models.VCSProvider{
+		Metadata:           models.ResourceMetadata{ID: "old-provider-id"},
+		GroupID:            "group-id",
+		ResourcePath:       "a/resource/old-provider",
+		URL:                sampleProviderURL,
+		Type:               models.GitLabProviderType,
+		OAuthAccessToken:   ptr.String("old-access-token"),
+		AutoCreateWebhooks: true,
+	}
+
+	existingLink := models.WorkspaceVCSProviderLink{
+		Metadata:       models.ResourceMetadata{ID: "link-id"},
+		WorkspaceID:    "workspace-id",
+		ProviderID:     "old-provider-id",
+		TokenNonce:     "old-nonce",
+		RepositoryPath: "owner/repository",
+		WebhookID:      "old-webhook-id",
+		WebhookSecret:  ptr.String("old-secret"),
+	}
+
+	compatibleProvider := &models.VCSProvider{
+		Metadata:           models.ResourceMetadata{ID: "new-provider-id"},
+		GroupID:            "group-id",
+		ResourcePath:       "a/resource/new-provider",
+		URL:                sampleProviderURL,
+		Type:               models.GitLabProviderType,
+		OAuthAccessToken:   ptr.String("new-access-token"),
+		AutoCreateWebhooks: true,
+	}
+
+	testCases := []struct {
+		name              string
+		newProvider       *models.VCSProvider
+		workspacePath     string
+		updateLinkError   error
+		expectedErrorCode errors.CodeType
+	}{
+		{
+			name:              "links can't be updated; new webhooks are deleted and old webhooks are kept",
+			newProvider:       compatibleProvider,
+			workspacePath:     "a/resource/workspace",
+			updateLinkError:   errors.New("update failed", errors.WithErrorCode(errors.EInternal)),
+			expectedErrorCode: errors.EInternal,
+		},
+		{
+			name: "compatible provider; links are moved and webhooks recreated",
+			newProvider: &models.VCSProvider{
+				Metadata:           models.ResourceMetadata{ID: "new-provider-id"},
+				GroupID:            "group-id",
+				ResourcePath:       "a/resource/new-provider",
+				URL:                sampleProviderURL,
+				Type:               models.GitLabProviderType,
+				OAuthAccessToken:   ptr.String("new-access-token"),
+				AutoCreateWebhooks: true,
+			},
+			workspacePath: "a/resource/workspace",
+		},
+		{
+			name: "new provider doesn't create webhooks; links keep their token and secret",
+			newProvider: &models.VCSProvider{
+				Metadata:         models.ResourceMetadata{ID: "new-provider-id"},
+				GroupID:          "group-id",
+				ResourcePath:     "a/resource/new-provider",
+				URL:              sampleProviderURL,
+				Type:             models.GitLabProviderType,
+				OAuthAccessToken: ptr.String("new-access-token"),
+			},
+			workspacePath: "a/resource/workspace",
+		},
+		{
+			name: "provider type is different",
+			newProvider: &models.VCSProvider{
+				Metadata:         models.ResourceMetadata{ID: "new-provider-id"},
+				GroupID:          "group-id",
+				ResourcePath:     "a/resource/new-provider",
+				URL:              sampleProviderURL,
+				Type:             models.GitHubProviderType,
+				OAuthAccessToken: ptr.String("new-access-token"),
+			},
+			workspacePath:     "a/resource/workspace",
+			expectedErrorCode: errors.EInvalid,
+		},
+		{
+			name: "provider URL is different",
+			newProvider: &models.VCSProvider{
+				Metadata:         models.ResourceMetadata{ID: "new-provider-id"},
+				GroupID:          "group-id",
+				ResourcePath:     "a/resource/new-provider",
+				URL:              url.URL{Scheme: "https", Host: "other.example.com"},
+				Type:             models.GitLabProviderType,
+				OAuthAccessToken: ptr.String("new-access-token"),
+			},
+			workspacePath:     "a/resource/workspace",
+			expectedErrorCode: errors.EInvalid,
+		},
+		{
+			name: "new provider is not available to the linked workspace",
+			newProvider: &models.VCSProvider{
+				Metadata:         models.ResourceMetadata{ID: "new-provider-id"},
+				GroupID:          "other-group-id",
+				ResourcePath:     "a/other/new-provider",
+				URL:              sampleProviderURL,
+				Type:             models.GitLabProviderType,
+				OAuthAccessToken: ptr.String("new-access-token"),
+			},
+			workspacePath:     "a/resource/workspace",
+			expectedErrorCode: errors.EInvalid,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+			mockProviders := NewMockProvider(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockVCSProviders := db.NewMockVCSProviders(t)
+			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockWorkspaceVCSProviderLinks := db.NewMockWorkspaceVCSProviderLinks(t)
+			mockJWSProvider := jws.NewMockProvider(t)
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateVCSProviderPermission, mock.Anything).Return(nil)
+			mockCaller.On("GetSubject").Return("testsubject").Maybe()
+
+			// Copy the old provider since refreshing the token may modify it.
+			oldProviderCopy := *oldProvider
+			mockVCSProviders.On("GetProviderByID", mock.Anything, "old-provider-id").Return(&oldProviderCopy, nil)
+			mockVCSProviders.On("GetProviderByID", mock.Anything, "new-provider-id").Return(test.newProvider, nil)
+
+			mockWorkspaceVCSProviderLinks.On("GetLinksByProviderID", mock.Anything, "old-provider-id").
+				Return([]models.WorkspaceVCSProviderLink{existingLink}, nil).Maybe()
+
+			mockWorkspaces.On("GetWorkspaces", mock.Anything, &db.GetWorkspacesInput{
+				Filter: &db.WorkspaceFilter{WorkspaceIDs: []string{"workspace-id"}},
+			}).Return(&db.WorkspacesResult{
+				Workspaces: []models.Workspace{{Metadata: models.ResourceMetadata{ID: "workspace-id"}, FullPath: test.workspacePath}},
+			}, nil).Maybe()
+
+			if test.expectedErrorCode == "" && !test.newProvider.AutoCreateWebhooks {
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+
+				// Manually configured webhooks must keep working, so no webhooks are
+				// created or deleted and the link's token and secret aren't rotated.
+				mockWorkspaceVCSProviderLinks.On("UpdateLink", mock.Anything, mock.MatchedBy(func(link *models.WorkspaceVCSProviderLink) bool {
+					return link.Metadata.ID == "link-id" &&
+						link.ProviderID == "new-provider-id" &&
+						link.TokenNonce == "old-nonce" &&
+						link.WebhookSecret != nil && *link.WebhookSecret == "old-secret"
+				})).Return(&models.WorkspaceVCSProviderLink{}, nil)
+			} else if test.expectedErrorCode == "" || test.updateLinkError != nil {
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+
+				if test.updateLinkError == nil {
+					mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+
+					// Old webhooks are only deleted once the links have been moved.
+					mockProviders.On("DeleteWebhook", mock.Anything, &types.DeleteWebhookInput{
+						ProviderURL:    sampleProviderURL,
+						AccessToken:    "old-access-token",
+						RepositoryPath: "owner/repository",
+						WebhookID:      "old-webhook-id",
+					}).Return(nil)
+				} else {
+					// New webhooks must not be left behind when the links can't be moved.
+					mockProviders.On("DeleteWebhook", mock.Anything, &types.DeleteWebhookInput{
+						ProviderURL:    sampleProviderURL,
+						AccessToken:    "new-access-token",
+						RepositoryPath: "owner/repository",
+						WebhookID:      "new-webhook-id",
+					}).Return(nil)
+				}
+
+				mockJWSProvider.On("Sign", mock.Anything, mock.Anything).Return([]byte("signed-token"), nil)
+
+				mockProviders.On("CreateWebhook", mock.Anything, mock.MatchedBy(func(input *types.CreateWebhookInput) bool {
+					return input.ProviderURL == sampleProviderURL &&
+						input.AccessToken == "new-access-token" &&
+						input.RepositoryPath == "owner/repository" &&
+						string(input.WebhookToken) == "signed-token" &&
+						len(input.WebhookSecret) == 64 // Generated for the new webhook.
+				})).Return(&types.WebhookPayload{WebhookID: "new-webhook-id"}, nil)
+
+				mockWorkspaceVCSProviderLinks.On("UpdateLink", mock.Anything, mock.MatchedBy(func(link *models.WorkspaceVCSProviderLink) bool {
+					return link.Metadata.ID == "link-id" &&
+						link.ProviderID == "new-provider-id" &&
+						link.TokenNonce != "old-nonce" &&
+						link.WebhookID == "new-webhook-id" &&
+						link.WebhookSecret != nil
+				})).Return(&models.WorkspaceVCSProviderLink{}, test.updateLinkError)
+			}
+
+			dbClient := &db.Client{
+				Transactions:              mockTransactions,
+				VCSProviders:              mockVCSProviders,
+				Workspaces:                mockWorkspaces,
+				WorkspaceVCSProviderLinks: mockWorkspaceVCSProviderLinks,
+			}
+
+			providerMap := map[models.VCSProviderType]Provider{
+				models.GitLabProviderType: mockProviders,
+				models.GitHubProviderType: mockProviders,
+			}
+
+			identityProvider := auth.NewIdentityProvider(mockJWSProvider, tharsisURL)
+
+			logger, _ := logger.NewForTest()
+			service := newService(logger, dbClient, nil, identityProvider, providerMap, nil, nil, nil, nil, nil, tharsisURL, 0, nil)
+
+			err := service.RelinkWorkspaces(auth.WithCaller(ctx, mockCaller), "old-provider-id", test.newProvider.Metadata.ID)
+			if test.expectedErrorCode != "" {
+				assert.Equal(t, test.expectedErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			require.Nil(t, err)
+		})
+	}
+}
+
 func TestCreateVCSRun(t *testing.T) {
 	sampleOAuthState, err := uuid.NewRandom()
 	assert.Nil(t, err)
@@ -1682,7 +2015,7 @@ func TestCreateVCSRun(t *testing.T) {
 			}
 
 			logger, _ := logger.NewForTest()
-			service := newService(logger, dbClient, nil, nil, providerMap, nil, nil, nil, &mockManager, oAuthStateGenerator, "", 5000)
+			service := newService(logger, dbClient, nil, nil, providerMap, nil, nil, nil, &mockManager, oAuthStateGenerator, "", 5000, nil)
 
 			err := service.CreateVCSRun(ctx, test.input)
 			if test.expectedErrorCode != "" {
@@ -1729,13 +2062,62 @@ func TestProcessWebhookEvent(t *testing.T) {
 		link                *models.WorkspaceVCSProviderLink
 		input               *ProcessWebhookEventInput
 		createEventInput    *models.VCSEvent
+		verifyError         error
 		equivalentEventType models.VCSEventType
 		name                string
 		expectedErrorCode   errors.CodeType
 	}{
+		{
+			name: "negative: webhook signature does not match; expect error code unauthorized",
+			link: &models.WorkspaceVCSProviderLink{
+				WorkspaceID:   "workspace-id",
+				WebhookSecret: ptr.String("webhook-secret"),
+			},
+			input: &ProcessWebhookEventInput{
+				Body: []byte("tampered-body"),
+			},
+			verifyError:       errors.New("webhook signature does not match payload"),
+			expectedErrorCode: errors.EUnauthorized,
+		},
+		{
+			name: "negative: link doesn't have a webhook secret; expect error code unauthorized",
+			link: &models.WorkspaceVCSProviderLink{
+				WorkspaceID: "workspace-id",
+			},
+			input: &ProcessWebhookEventInput{
+				Body: []byte("unsigned-body"),
+			},
+			expectedErrorCode: errors.EUnauthorized,
+		},
+		{
+			name: "positive: webhook signature matches; expect no errors",
+			link: &models.WorkspaceVCSProviderLink{
+				RepositoryPath: "owner/repository",
+				WorkspaceID:    "workspace-id",
+				Branch:         "main",
+				WebhookSecret:  ptr.String("webhook-secret"),
+			},
+			input: &ProcessWebhookEventInput{
+				EventHeader: "push",
+				Before:      plumbing.ZeroHash.String(),
+				After:       sampleAfterCommit,
+				Ref:         "refs/heads/main",
+				Body:        []byte("signed-body"),
+			},
+			equivalentEventType: models.BranchEventType,
+			createEventInput: &models.VCSEvent{
+				SourceReferenceName: &sampleReferenceNames[0],
+				CommitID:            &sampleAfterCommit,
+				WorkspaceID:         sampleWorkspace.Metadata.ID,
+				Type:                models.BranchEventType,
+				Status:              models.VCSEventPending,
+				RepositoryURL:       sampleRepositoryURL,
+			},
+		},
 		{
 			name: "positive: valid branch push event, mostly empty link and provider setup; expect no errors",
 			link: &models.WorkspaceVCSProviderLink{
+				WebhookSecret:       ptr.String("webhook-secret"),
 				RepositoryPath:      "owner/repository",
 				WorkspaceID:         "workspace-id",
 				Branch:              "main", // Only allow events for main branch.
@@ -1760,6 +2142,7 @@ func TestProcessWebhookEvent(t *testing.T) {
 		{
 			name: "positive: valid branch push event, mostly empty link and provider setup; expect no errors",
 			link: &models.WorkspaceVCSProviderLink{
+				WebhookSecret:       ptr.String("webhook-secret"),
 				RepositoryPath:      "owner/repository",
 				WorkspaceID:         "workspace-id",
 				Branch:              "main", // Only allow events for main branch.
@@ -1784,6 +2167,7 @@ func TestProcessWebhookEvent(t *testing.T) {
 		{
 			name: "positive: valid tag event, no tag regex defined on link; expect no errors",
 			link: &models.WorkspaceVCSProviderLink{
+				WebhookSecret:       ptr.String("webhook-secret"),
 				RepositoryPath:      "owner/repository",
 				WorkspaceID:         "workspace-id",
 				Branch:              "main",
@@ -1808,6 +2192,7 @@ func TestProcessWebhookEvent(t *testing.T) {
 		{
 			name: "positive: valid tag event, with tag regex defined on link; expect no errors",
 			link: &models.WorkspaceVCSProviderLink{
+				WebhookSecret:       ptr.String("webhook-secret"),
 				RepositoryPath:      "owner/repository",
 				WorkspaceID:         "workspace-id",
 				Branch:              "main",
@@ -1832,6 +2217,7 @@ func TestProcessWebhookEvent(t *testing.T) {
 		{
 			name: "positive: valid PR event, auto-speculative is false on link; expect no errors",
 			link: &models.WorkspaceVCSProviderLink{
+				WebhookSecret:       ptr.String("webhook-secret"),
 				RepositoryPath:      "owner/repository",
 				WorkspaceID:         "workspace-id",
 				Branch:              "main",
@@ -1859,6 +2245,7 @@ func TestProcessWebhookEvent(t *testing.T) {
 		{
 			name: "positive: valid PR event, auto-speculative is true on link; expect no errors",
 			link: &models.WorkspaceVCSProviderLink{
+				WebhookSecret:       ptr.String("webhook-secret"),
 				RepositoryPath:      "owner/repository",
 				WorkspaceID:         "workspace-id",
 				Branch:              "main",
@@ -1886,6 +2273,7 @@ func TestProcessWebhookEvent(t *testing.T) {
 		{
 			name: "positive: webhook is disabled on the link; expect no errors",
 			link: &models.WorkspaceVCSProviderLink{
+				WebhookSecret:   ptr.String("webhook-secret"),
 				WorkspaceID:     "workspace-id",
 				WebhookDisabled: true, // Webhook is disabled.
 			},
@@ -1894,7 +2282,8 @@ func TestProcessWebhookEvent(t *testing.T) {
 		{
 			name: "negative: invalid webhook event; expect no errors",
 			link: &models.WorkspaceVCSProviderLink{
-				WorkspaceID: "workspace-id",
+				WebhookSecret: ptr.String("webhook-secret"),
+				WorkspaceID:   "workspace-id",
 			},
 			input: &ProcessWebhookEventInput{
 				EventHeader: "unknown", // An event not supported.
@@ -1938,6 +2327,9 @@ func TestProcessWebhookEvent(t *testing.T) {
 				RepositoryPath: "owner/repository",
 			}
 
+			if test.link.WebhookSecret != nil {
+				mockProviders.On("VerifyWebhookSignature", *test.link.WebhookSecret, test.input.Headers, test.input.Body).Return(test.verifyError)
+			}
 			mockProviders.On("ToVCSEventType", toVCSEventInput).Return(test.equivalentEventType)
 			mockProviders.On("MergeRequestActionIsSupported", test.input.Action).Return(test.input.Action == "opened")
 			mockProviders.On("CreateAccessToken", mock.Anything, createAccessTokenInput).Return(createAccessTokenPayload, nil)
@@ -1969,7 +2361,7 @@ func TestProcessWebhookEvent(t *testing.T) {
 			}
 
 			logger, _ := logger.NewForTest()
-			service := newService(logger, dbClient, nil, nil, providerMap, nil, nil, &mockWorkspaceService, &mockManager, oAuthStateGenerator, "", 5000)
+			service := newService(logger, dbClient, nil, nil, providerMap, nil, nil, &mockWorkspaceService, &mockManager, oAuthStateGenerator, "", 5000, nil)
 
 			err := service.ProcessWebhookEvent(auth.WithCaller(context.Background(), caller), test.input)
 			if test.expectedErrorCode != "" {
@@ -2053,7 +2445,7 @@ func TestResetVCSProviderOAuthToken(t *testing.T) {
 			}
 
 			logger, _ := logger.NewForTest()
-			service := newService(logger, dbClient, nil, nil, providerMap, nil, nil, nil, nil, oAuthStateGenerator, "", 5000)
+			service := newService(logger, dbClient, nil, nil, providerMap, nil, nil, nil, nil, oAuthStateGenerator, "", 5000, nil)
 
 			response, err := service.ResetVCSProviderOAuthToken(ctx, test.input)
 			if test.expectedErrorCode != "" {
@@ -2256,7 +2648,7 @@ func TestProcessOAuth(t *testing.T) {
 				models.GitHubProviderType: &mockProviders,
 			}
 
-			service := newService(nil, dbClient, nil, nil, providerMap, nil, nil, nil, nil, nil, tharsisURL, 5000)
+			service := newService(nil, dbClient, nil, nil, providerMap, nil, nil, nil, nil, nil, tharsisURL, 5000, nil)
 
 			err := service.ProcessOAuth(ctx, test.input)
 			if test.expectedErrorCode != "" {
@@ -2642,6 +3034,158 @@ func Test_handleEvent(t *testing.T) {
 	}
 }
 
+func Test_getSupersededRuns(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	newEvent := &models.VCSEvent{
+		Metadata:            models.ResourceMetadata{ID: "new-event-id"},
+		Type:                models.BranchEventType,
+		SourceReferenceName: ptr.String("refs/heads/main"),
+	}
+
+	// Existing runs keyed by ID, each with its own configuration version and VCS event.
+	type existingRun struct {
+		run   models.Run
+		cv    *models.ConfigurationVersion
+		event *models.VCSEvent
+	}
+
+	buildRun := func(id string, createdAgo time.Duration, status models.RunStatus, eventType models.VCSEventType, ref string, fromVCS bool) existingRun {
+		createdAt := now.Add(-createdAgo)
+		cv := &models.ConfigurationVersion{Metadata: models.ResourceMetadata{ID: id + "-cv"}}
+		var event *models.VCSEvent
+		if fromVCS {
+			cv.VCSEventID = ptr.String(id + "-event")
+			event = &models.VCSEvent{
+				Metadata:            models.ResourceMetadata{ID: id + "-event"},
+				Type:                eventType,
+				SourceReferenceName: &ref,
+			}
+		}
+		return existingRun{
+			run: models.Run{
+				Metadata:               models.ResourceMetadata{ID: id, CreationTimestamp: &createdAt},
+				Status:                 status,
+				ConfigurationVersionID: &cv.Metadata.ID,
+			},
+			cv:    cv,
+			event: event,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		windowSeconds int32
+		existingRuns  []existingRun
+		expectRunIDs  []string
+	}{
+		{
+			name:          "queued run within the window is superseded",
+			windowSeconds: 60,
+			existingRuns: []existingRun{
+				buildRun("run-1", 30*time.Second, models.RunPlanQueued, models.BranchEventType, "refs/heads/main", true),
+			},
+			expectRunIDs: []string{"run-1"},
+		},
+		{
+			name:          "queued run outside the window results in separate runs",
+			windowSeconds: 60,
+			existingRuns: []existingRun{
+				buildRun("run-1", 2*time.Minute, models.RunPlanQueued, models.BranchEventType, "refs/heads/main", true),
+			},
+			expectRunIDs: []string{},
+		},
+		{
+			name:          "run that already started planning is not superseded",
+			windowSeconds: 60,
+			existingRuns: []existingRun{
+				buildRun("run-1", 30*time.Second, models.RunPlanning, models.BranchEventType, "refs/heads/main", true),
+			},
+			expectRunIDs: []string{},
+		},
+		{
+			name:          "run for a different branch or event type is not superseded",
+			windowSeconds: 60,
+			existingRuns: []existingRun{
+				buildRun("run-1", 30*time.Second, models.RunPending, models.BranchEventType, "refs/heads/feature", true),
+				buildRun("run-2", 20*time.Second, models.RunPending, models.MergeRequestEventType, "refs/heads/main", true),
+				buildRun("run-3", 10*time.Second, models.RunPending, models.BranchEventType, "refs/heads/main", true),
+			},
+			expectRunIDs: []string{"run-3"},
+		},
+		{
+			name:          "run not triggered by VCS is not superseded",
+			windowSeconds: 60,
+			existingRuns: []existingRun{
+				buildRun("run-1", 30*time.Second, models.RunPlanQueued, "", "", false),
+			},
+			expectRunIDs: []string{},
+		},
+		{
+			name: "debounce window is disabled",
+			existingRuns: []existingRun{
+				buildRun("run-1", 30*time.Second, models.RunPlanQueued, models.BranchEventType, "refs/heads/main", true),
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			mockRuns := db.NewMockRuns(t)
+			mockConfigurationVersions := db.NewMockConfigurationVersions(t)
+			mockVCSEvents := db.NewMockVCSEvents(t)
+
+			// Simulate the DB only returning runs created within the window.
+			mockRuns.On("GetRuns", mock.Anything, mock.Anything).Return(func(_ context.Context, input *db.GetRunsInput) (*db.RunsResult, error) {
+				runs := []models.Run{}
+				for _, r := range test.existingRuns {
+					if !r.run.Metadata.CreationTimestamp.Before(*input.Filter.TimeRangeStart) {
+						runs = append(runs, r.run)
+					}
+				}
+				return &db.RunsResult{Runs: runs}, nil
+			}).Maybe()
+
+			for _, r := range test.existingRuns {
+				mockConfigurationVersions.On("GetConfigurationVersion", mock.Anything, r.cv.Metadata.ID).Return(r.cv, nil).Maybe()
+				if r.event != nil {
+					mockVCSEvents.On("GetEventByID", mock.Anything, r.event.Metadata.ID).Return(r.event, nil).Maybe()
+				}
+			}
+
+			logger, _ := logger.NewForTest()
+			s := service{
+				logger: logger,
+				dbClient: &db.Client{
+					Runs:                  mockRuns,
+					ConfigurationVersions: mockConfigurationVersions,
+					VCSEvents:             mockVCSEvents,
+				},
+			}
+
+			link := &models.WorkspaceVCSProviderLink{
+				WorkspaceID:              "workspace-id",
+				RunDebounceWindowSeconds: test.windowSeconds,
+			}
+
+			supersededRuns, err := s.getSupersededRuns(ctx, link, newEvent)
+			require.Nil(t, err)
+
+			if test.expectRunIDs == nil {
+				assert.Nil(t, supersededRuns)
+				return
+			}
+
+			actualIDs := []string{}
+			for _, r := range supersededRuns {
+				actualIDs = append(actualIDs, r.Metadata.ID)
+			}
+			assert.Equal(t, test.expectRunIDs, actualIDs)
+		})
+	}
+}
+
 // createRepositoryArchive creates a sample tar.gz file which is used
 // as the GetArchive response payload.
 func createRepositoryArchive() (*os.File, error) {
@@ -92,6 +92,7 @@ type CreateWebhookInput struct {
 	AccessToken    string
 	RepositoryPath string
 	WebhookToken   []byte
+	WebhookSecret  string // Used by the provider to sign or authenticate webhook payloads.
 }
 
 // DeleteWebhookInput is the input for deleting a webhook.
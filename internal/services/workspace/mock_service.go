@@ -6,10 +6,8 @@ import (
 	context "context"
 	io "io"
 
-	db "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/db"
-
 	mock "github.com/stretchr/testify/mock"
-
+	db "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/db"
 	models "gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 )
 
@@ -110,6 +108,20 @@ func (_m *MockService) DeleteWorkspace(ctx context.Context, workspace *models.Wo
 	return r0
 }
 
+// ForceUnlockWorkspace provides a mock function with given fields: ctx, workspaceID
+func (_m *MockService) ForceUnlockWorkspace(ctx context.Context, workspaceID string) error {
+	ret := _m.Called(ctx, workspaceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, workspaceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetConfigurationVersion provides a mock function with given fields: ctx, configurationVersionID
 func (_m *MockService) GetConfigurationVersion(ctx context.Context, configurationVersionID string) (*models.ConfigurationVersion, error) {
 	ret := _m.Called(ctx, configurationVersionID)
@@ -214,6 +226,32 @@ func (_m *MockService) GetCurrentStateVersion(ctx context.Context, workspaceID s
 	return r0, r1
 }
 
+// GetEffectiveRunnerTags provides a mock function with given fields: ctx, workspaceID
+func (_m *MockService) GetEffectiveRunnerTags(ctx context.Context, workspaceID string) ([]string, error) {
+	ret := _m.Called(ctx, workspaceID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, workspaceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, workspaceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, workspaceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStateVersion provides a mock function with given fields: ctx, stateVersionID
 func (_m *MockService) GetStateVersion(ctx context.Context, stateVersionID string) (*models.StateVersion, error) {
 	ret := _m.Called(ctx, stateVersionID)
@@ -644,6 +682,20 @@ func (_m *MockService) UploadConfigurationVersion(ctx context.Context, configura
 	return r0
 }
 
+// VerifyStateLineage provides a mock function with given fields: ctx, workspaceID, incoming
+func (_m *MockService) VerifyStateLineage(ctx context.Context, workspaceID string, incoming *StateMeta) error {
+	ret := _m.Called(ctx, workspaceID, incoming)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *StateMeta) error); ok {
+		r0 = rf(ctx, workspaceID, incoming)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewMockService interface {
 	mock.TestingT
 	Cleanup(func())
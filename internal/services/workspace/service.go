@@ -22,6 +22,7 @@ import (
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/models"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/activityevent"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/cli"
+	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/services/runner"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/internal/tracing"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/errors"
 	"gitlab.com/infor-cloud/martian-cloud/tharsis/tharsis-api/pkg/logger"
@@ -52,6 +53,9 @@ var (
 
 	// Error returned when a workspace unlock is attempted but it's locked by a run.
 	ErrWorkspaceLockedByRun = errors.New("cannot unlock workspace locked by run", errors.WithErrorCode(errors.EConflict))
+
+	// Error returned when a workspace force unlock is attempted but it's not locked by a run.
+	ErrWorkspaceNotLockedByRun = errors.New("workspace is not locked by a run", errors.WithErrorCode(errors.EConflict))
 )
 
 // Event represents a workspace event
@@ -81,6 +85,12 @@ type StateVersionDependency struct {
 	StateVersionID string
 }
 
+// StateMeta contains the lineage and serial of a Terraform state
+type StateMeta struct {
+	Lineage string
+	Serial  uint64
+}
+
 // GetWorkspacesInput is the input for querying a list of workspaces
 type GetWorkspacesInput struct {
 	// Sort specifies the field to sort on and direction
@@ -93,6 +103,12 @@ type GetWorkspacesInput struct {
 	AssignedManagedIdentityID *string
 	// Search is used to search for a workspace by name or namespace path
 	Search *string
+	// Environment filters the workspaces by the specified environment
+	Environment *string
+	// UsesManagedIdentityType filters the workspaces to those with at least one assigned managed identity of this type
+	UsesManagedIdentityType *models.ManagedIdentityType
+	// HasManagedIdentity filters the workspaces by whether they have any managed identities assigned
+	HasManagedIdentity *bool
 }
 
 // GetStateVersionsInput is the input for querying a list of state versions
@@ -124,6 +140,8 @@ type Service interface {
 	DeleteWorkspace(ctx context.Context, workspace *models.Workspace, force bool) error
 	LockWorkspace(ctx context.Context, workspace *models.Workspace) (*models.Workspace, error)
 	UnlockWorkspace(ctx context.Context, workspace *models.Workspace) (*models.Workspace, error)
+	ForceUnlockWorkspace(ctx context.Context, workspaceID string) error
+	GetEffectiveRunnerTags(ctx context.Context, workspaceID string) ([]string, error)
 	GetCurrentStateVersion(ctx context.Context, workspaceID string) (*models.StateVersion, error)
 	CreateStateVersion(ctx context.Context, stateVersion *models.StateVersion, data *string) (*models.StateVersion, error)
 	GetStateVersion(ctx context.Context, stateVersionID string) (*models.StateVersion, error)
@@ -138,6 +156,7 @@ type Service interface {
 	GetStateVersionOutputs(context context.Context, stateVersionID string) ([]models.StateVersionOutput, error)
 	GetStateVersionResources(ctx context.Context, stateVersion *models.StateVersion) ([]StateVersionResource, error)
 	GetStateVersionDependencies(ctx context.Context, stateVersion *models.StateVersion) ([]StateVersionDependency, error)
+	VerifyStateLineage(ctx context.Context, workspaceID string, incoming *StateMeta) error
 	MigrateWorkspace(ctx context.Context, workspaceID string, newGroupID string) (*models.Workspace, error)
 }
 
@@ -155,7 +174,9 @@ type service struct {
 	eventManager    *events.EventManager
 	cliService      cli.Service
 	activityService activityevent.Service
-	handleCaller    handleCallerFunc
+	// environments are the values a workspace's environment can be set to
+	environments []string
+	handleCaller handleCallerFunc
 }
 
 // NewService creates an instance of Service
@@ -167,6 +188,7 @@ func NewService(
 	eventManager *events.EventManager,
 	cliService cli.Service,
 	activityService activityevent.Service,
+	environments []string,
 ) Service {
 	return newService(
 		logger,
@@ -176,6 +198,7 @@ func NewService(
 		eventManager,
 		cliService,
 		activityService,
+		environments,
 		auth.HandleCaller,
 	)
 }
@@ -188,6 +211,7 @@ func newService(
 	eventManager *events.EventManager,
 	cliService cli.Service,
 	activityService activityevent.Service,
+	environments []string,
 	handleCaller handleCallerFunc,
 ) Service {
 	return &service{
@@ -198,6 +222,7 @@ func newService(
 		eventManager,
 		cliService,
 		activityService,
+		environments,
 		handleCaller,
 	}
 }
@@ -318,6 +343,9 @@ func (s *service) GetWorkspaces(ctx context.Context, input *GetWorkspacesInput)
 		Filter: &db.WorkspaceFilter{
 			Search:                    input.Search,
 			AssignedManagedIdentityID: input.AssignedManagedIdentityID,
+			Environment:               input.Environment,
+			UsesManagedIdentityType:   input.UsesManagedIdentityType,
+			HasManagedIdentity:        input.HasManagedIdentity,
 		},
 	}
 
@@ -535,12 +563,26 @@ func (s *service) CreateWorkspace(ctx context.Context, workspace *models.Workspa
 		return nil, err
 	}
 
+	// Only owners are allowed to enable auto apply since it skips the manual apply for VCS-triggered runs.
+	if workspace.AutoApply {
+		err = caller.RequirePermission(ctx, permissions.UpdateNamespaceMembershipPermission, auth.WithGroupID(workspace.GroupID))
+		if err != nil {
+			tracing.RecordError(span, err, "permission check failed")
+			return nil, err
+		}
+	}
+
 	// Validate model
 	if wErr := workspace.Validate(); wErr != nil {
 		tracing.RecordError(span, wErr, "failed to commit DB transaction")
 		return nil, wErr
 	}
 
+	if eErr := s.validateEnvironment(workspace.Environment); eErr != nil {
+		tracing.RecordError(span, eErr, "failed to validate environment")
+		return nil, eErr
+	}
+
 	workspace.CreatedBy = caller.GetSubject()
 
 	if d := workspace.MaxJobDuration; d != nil {
@@ -661,6 +703,33 @@ func (s *service) UpdateWorkspace(ctx context.Context, workspace *models.Workspa
 		return nil, vErr
 	}
 
+	if eErr := s.validateEnvironment(workspace.Environment); eErr != nil {
+		tracing.RecordError(span, eErr, "failed to validate environment")
+		return nil, eErr
+	}
+
+	currentWorkspace, err := s.dbClient.Workspaces.GetWorkspaceByID(ctx, workspace.Metadata.ID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to get workspace by ID")
+		return nil, err
+	}
+
+	if currentWorkspace == nil {
+		tracing.RecordError(span, nil, "workspace not found")
+		return nil, errors.New("workspace with id %s not found", workspace.Metadata.ID, errors.WithErrorCode(errors.ENotFound))
+	}
+
+	autoApplyChanged := currentWorkspace.AutoApply != workspace.AutoApply
+
+	// Only owners are allowed to enable auto apply since it skips the manual apply for VCS-triggered runs.
+	if autoApplyChanged && workspace.AutoApply {
+		err = caller.RequirePermission(ctx, permissions.UpdateNamespaceMembershipPermission, auth.WithWorkspaceID(workspace.Metadata.ID))
+		if err != nil {
+			tracing.RecordError(span, err, "permission check failed")
+			return nil, err
+		}
+	}
+
 	// Get a list of all the supported versions.
 	versions, err := s.cliService.GetTerraformCLIVersions(ctx)
 	if err != nil {
@@ -698,13 +767,20 @@ func (s *service) UpdateWorkspace(ctx context.Context, workspace *models.Workspa
 		return nil, err
 	}
 
-	if _, err = s.activityService.CreateActivityEvent(txContext,
-		&activityevent.CreateActivityEventInput{
-			NamespacePath: &updatedWorkspace.FullPath,
-			Action:        models.ActionUpdate,
-			TargetType:    models.TargetWorkspace,
-			TargetID:      updatedWorkspace.Metadata.ID,
-		}); err != nil {
+	activityEventInput := &activityevent.CreateActivityEventInput{
+		NamespacePath: &updatedWorkspace.FullPath,
+		Action:        models.ActionUpdate,
+		TargetType:    models.TargetWorkspace,
+		TargetID:      updatedWorkspace.Metadata.ID,
+	}
+
+	if autoApplyChanged {
+		activityEventInput.Payload = &models.ActivityEventUpdateWorkspaceAutoApplyPayload{
+			AutoApply: updatedWorkspace.AutoApply,
+		}
+	}
+
+	if _, err = s.activityService.CreateActivityEvent(txContext, activityEventInput); err != nil {
 		tracing.RecordError(span, err, "failed to create activity event")
 		return nil, err
 	}
@@ -861,6 +937,113 @@ func (s *service) UnlockWorkspace(ctx context.Context, workspace *models.Workspa
 	return updatedWorkspace, nil
 }
 
+func (s *service) ForceUnlockWorkspace(ctx context.Context, workspaceID string) error {
+	ctx, span := tracer.Start(ctx, "svc.ForceUnlockWorkspace")
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		return errors.Wrap(err, "caller authorization failed", errors.WithSpan(span))
+	}
+
+	// Only owners of the workspace are allowed to force unlock it.
+	err = caller.RequirePermission(ctx, permissions.UpdateNamespaceMembershipPermission, auth.WithWorkspaceID(workspaceID))
+	if err != nil {
+		return errors.Wrap(err, "permission check failed", errors.WithSpan(span))
+	}
+
+	workspace, err := s.dbClient.Workspaces.GetWorkspaceByID(ctx, workspaceID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace by ID", errors.WithSpan(span))
+	}
+
+	if workspace == nil {
+		return errors.New(
+			"workspace with id %s not found", workspaceID,
+			errors.WithErrorCode(errors.ENotFound), errors.WithSpan(span))
+	}
+
+	lock, err := s.dbClient.WorkspaceRunLocks.GetWorkspaceRunLock(ctx, workspaceID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace run lock", errors.WithSpan(span))
+	}
+
+	if lock == nil {
+		tracing.RecordError(span, nil, "workspace is not locked by a run")
+		return ErrWorkspaceNotLockedByRun
+	}
+
+	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin DB transaction", errors.WithSpan(span))
+	}
+
+	defer func() {
+		if txErr := s.dbClient.Transactions.RollbackTx(txContext); txErr != nil {
+			s.logger.Errorf("failed to rollback tx for service layer ForceUnlockWorkspace: %v", txErr)
+		}
+	}()
+
+	if err = s.dbClient.WorkspaceRunLocks.DeleteWorkspaceRunLock(txContext, lock); err != nil {
+		return errors.Wrap(err, "failed to delete workspace run lock", errors.WithSpan(span))
+	}
+
+	if _, err = s.activityService.CreateActivityEvent(txContext,
+		&activityevent.CreateActivityEventInput{
+			NamespacePath: &workspace.FullPath,
+			Action:        models.ActionUnlock,
+			TargetType:    models.TargetWorkspace,
+			TargetID:      workspace.Metadata.ID,
+			Payload: &models.ActivityEventForceUnlockWorkspacePayload{
+				RunID: lock.RunID,
+			},
+		}); err != nil {
+		return errors.Wrap(err, "failed to create activity event", errors.WithSpan(span))
+	}
+
+	if err = s.dbClient.Transactions.CommitTx(txContext); err != nil {
+		return errors.Wrap(err, "failed to commit DB transaction", errors.WithSpan(span))
+	}
+
+	s.logger.Infow("Force unlocked a workspace.",
+		"caller", caller.GetSubject(),
+		"fullPath", workspace.FullPath,
+		"workspaceID", workspace.Metadata.ID,
+		"runID", lock.RunID,
+	)
+
+	return nil
+}
+
+// GetEffectiveRunnerTags returns the runner tags for a workspace, which are inherited
+// from the closest group in the workspace's hierarchy that defines runner tags.
+func (s *service) GetEffectiveRunnerTags(ctx context.Context, workspaceID string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "svc.GetEffectiveRunnerTags")
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "caller authorization failed", errors.WithSpan(span))
+	}
+
+	err = caller.RequirePermission(ctx, permissions.ViewWorkspacePermission, auth.WithWorkspaceID(workspaceID))
+	if err != nil {
+		return nil, errors.Wrap(err, "permission check failed", errors.WithSpan(span))
+	}
+
+	workspace, err := s.getWorkspaceByID(ctx, workspaceID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace by ID", errors.WithSpan(span))
+	}
+
+	tags, err := runner.GetEffectiveRunnerTags(ctx, s.dbClient, workspace.GroupID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get effective runner tags", errors.WithSpan(span))
+	}
+
+	return tags, nil
+}
+
 func (s *service) GetCurrentStateVersion(ctx context.Context, workspaceID string) (*models.StateVersion, error) {
 	ctx, span := tracer.Start(ctx, "svc.GetCurrentStateVersion")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -1040,6 +1223,121 @@ func (s *service) GetStateVersionDependencies(ctx context.Context, stateVersion
 	return response, nil
 }
 
+// VerifyStateLineage verifies that the incoming state has the same lineage as the workspace's
+// current state and that its serial has not regressed.
+func (s *service) VerifyStateLineage(ctx context.Context, workspaceID string, incoming *StateMeta) error {
+	ctx, span := tracer.Start(ctx, "svc.VerifyStateLineage")
+	// TODO: Consider setting trace/span attributes for the input.
+	defer span.End()
+
+	caller, err := auth.AuthorizeCaller(ctx)
+	if err != nil {
+		tracing.RecordError(span, err, "caller authorization failed")
+		return err
+	}
+
+	err = caller.RequirePermission(ctx, permissions.ViewStateVersionPermission, auth.WithWorkspaceID(workspaceID))
+	if err != nil {
+		tracing.RecordError(span, err, "permission check failed")
+		return err
+	}
+
+	if err = s.verifyStateLineage(ctx, workspaceID, incoming); err != nil {
+		tracing.RecordError(span, err, "failed to verify state lineage")
+		return err
+	}
+
+	return nil
+}
+
+// verifyStateLineage compares the incoming state with the workspace's current state without
+// checking the caller's permissions.
+func (s *service) verifyStateLineage(ctx context.Context, workspaceID string, incoming *StateMeta) error {
+	if incoming == nil {
+		return errors.New("incoming state must be specified", errors.WithErrorCode(errors.EInvalid))
+	}
+
+	workspace, err := s.getWorkspaceByID(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	// Nothing to compare against if the workspace doesn't have a state yet.
+	if workspace.CurrentStateVersionID == "" {
+		return nil
+	}
+
+	stateVersion, err := s.dbClient.StateVersions.GetStateVersion(ctx, workspace.CurrentStateVersionID)
+	if err != nil {
+		return err
+	}
+
+	if stateVersion == nil {
+		return errors.New("current state version with ID %s not found", workspace.CurrentStateVersionID, errors.WithErrorCode(errors.ENotFound))
+	}
+
+	reader, err := s.artifactStore.GetStateVersion(ctx, stateVersion)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	var current stateV4
+	if err = json.NewDecoder(reader).Decode(&current); err != nil {
+		return errors.Wrap(err, "failed to unmarshal current state")
+	}
+
+	if incoming.Lineage != current.Lineage {
+		return errors.New(
+			"state lineage %q does not match the workspace's current state lineage %q", incoming.Lineage, current.Lineage,
+			errors.WithErrorCode(errors.EInvalid),
+		)
+	}
+
+	if incoming.Serial < current.Serial {
+		return errors.New(
+			"state serial %d is older than the workspace's current state serial %d", incoming.Serial, current.Serial,
+			errors.WithErrorCode(errors.EInvalid),
+		)
+	}
+
+	return nil
+}
+
+// stateLineageVerificationRequired returns true if a managed identity assigned to the workspace has a
+// module attestation rule with the verify state lineage setting enabled.
+func (s *service) stateLineageVerificationRequired(ctx context.Context, workspaceID string) (bool, error) {
+	identities, err := s.dbClient.ManagedIdentities.GetManagedIdentitiesForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, identity := range identities {
+		// Access rules are defined on the source identity of an alias.
+		identityID := identity.Metadata.ID
+		if identity.IsAlias() {
+			identityID = *identity.AliasSourceID
+		}
+
+		rulesResult, err := s.dbClient.ManagedIdentities.GetManagedIdentityAccessRules(ctx, &db.GetManagedIdentityAccessRulesInput{
+			Filter: &db.ManagedIdentityAccessRuleFilter{
+				ManagedIdentityID: &identityID,
+			},
+		})
+		if err != nil {
+			return false, err
+		}
+
+		for _, rule := range rulesResult.ManagedIdentityAccessRules {
+			if rule.Type == models.ManagedIdentityAccessRuleModuleAttestation && rule.VerifyStateLineage {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
 func (s *service) CreateStateVersion(ctx context.Context, stateVersion *models.StateVersion, data *string) (*models.StateVersion, error) {
 	ctx, span := tracer.Start(ctx, "svc.CreateStateVersion")
 	// TODO: Consider setting trace/span attributes for the input.
@@ -1064,6 +1362,33 @@ func (s *service) CreateStateVersion(ctx context.Context, stateVersion *models.S
 		return nil, err
 	}
 
+	// Attempt to unmarshal to a stateV4:
+	var state stateV4
+	err = json.Unmarshal(decoded, &state)
+	if err != nil {
+		tracing.RecordError(span, nil, "failed to unmarshal decoded data: %s", err)
+		return nil, fmt.Errorf("failed to unmarshal decoded data: %s", err)
+	}
+	if state.Version != version4 {
+		tracing.RecordError(span, nil, "expected stateVersionV4, got %d", state.Version)
+		return nil, fmt.Errorf("expected stateVersionV4, got %d", state.Version)
+	}
+
+	// Only verify the lineage when a managed identity rule requires it since it downloads the current state.
+	verifyLineage, err := s.stateLineageVerificationRequired(ctx, stateVersion.WorkspaceID)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to check whether state lineage verification is required")
+		return nil, err
+	}
+
+	if verifyLineage {
+		// Reject a state that doesn't belong to the workspace's current state or would overwrite a newer one.
+		if err = s.verifyStateLineage(ctx, stateVersion.WorkspaceID, &StateMeta{Lineage: state.Lineage, Serial: state.Serial}); err != nil {
+			tracing.RecordError(span, err, "failed to verify state lineage")
+			return nil, err
+		}
+	}
+
 	// Wrap a transaction around persisting the state version and the state version outputs.
 	txContext, err := s.dbClient.Transactions.BeginTx(ctx)
 	if err != nil {
@@ -1124,18 +1449,6 @@ func (s *service) CreateStateVersion(ctx context.Context, stateVersion *models.S
 		return nil, err
 	}
 
-	// Attempt to unmarshal to a stateV4:
-	var state stateV4
-	err = json.Unmarshal(decoded, &state)
-	if err != nil {
-		tracing.RecordError(span, nil, "failed to unmarshal decoded data: %s", err)
-		return nil, fmt.Errorf("failed to unmarshal decoded data: %s", err)
-	}
-	if state.Version != version4 {
-		tracing.RecordError(span, nil, "expected stateVersionV4, got %d", state.Version)
-		return nil, fmt.Errorf("expected stateVersionV4, got %d", state.Version)
-	}
-
 	for outputName, outputInfo := range state.RootOutputs {
 
 		newOutput := models.StateVersionOutput{
@@ -1736,6 +2049,26 @@ func (s *service) MigrateWorkspace(ctx context.Context, workspaceID string, newG
 	return migratedWorkspace, nil
 }
 
+// validateEnvironment verifies the environment is one of the configured environments; an empty environment is always allowed.
+func (s *service) validateEnvironment(environment string) error {
+	if environment == "" {
+		return nil
+	}
+
+	for _, e := range s.environments {
+		if e == environment {
+			return nil
+		}
+	}
+
+	return errors.New(
+		"invalid environment %s, must be one of: %s",
+		environment,
+		strings.Join(s.environments, ", "),
+		errors.WithErrorCode(errors.EInvalid),
+	)
+}
+
 // validateMaxJobDuration validates if duration is within MaxJobDuration limits.
 func validateMaxJobDuration(duration int32) error {
 	if duration < int32(lowerLimitMaxJobDuration.Minutes()) || duration > int32(upperLimitMaxJobDuration.Minutes()) {
@@ -3,6 +3,8 @@ package workspace
 import (
 	"context"
 	"encoding/base64"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -36,6 +38,7 @@ func TestCreateWorkspace(t *testing.T) {
 	// Test cases
 	tests := []struct {
 		authError                error
+		ownerAuthError           error
 		expectCreatedWorkspace   *models.Workspace
 		name                     string
 		expectErrCode            errors.CodeType
@@ -80,6 +83,18 @@ func TestCreateWorkspace(t *testing.T) {
 			authError:     errors.New("Unauthorized", errors.WithErrorCode(errors.EForbidden)),
 			expectErrCode: errors.EForbidden,
 		},
+		{
+			name: "subject is not an owner and cannot enable auto apply",
+			input: models.Workspace{
+				Name:             workspaceName,
+				GroupID:          groupID,
+				MaxJobDuration:   ptr.Int32(1234),
+				TerraformVersion: terraformVersion,
+				AutoApply:        true,
+			},
+			ownerAuthError: errors.New("Unauthorized", errors.WithErrorCode(errors.EForbidden)),
+			expectErrCode:  errors.EForbidden,
+		},
 		{
 			name: "exceeds limit",
 			input: models.Workspace{
@@ -105,6 +120,35 @@ func TestCreateWorkspace(t *testing.T) {
 			exceedsLimit:             true,
 			expectErrCode:            errors.EInvalid,
 		},
+		{
+			name: "create workspace with environment",
+			input: models.Workspace{
+				Name:             workspaceName,
+				GroupID:          groupID,
+				TerraformVersion: terraformVersion,
+				Environment:      "production",
+			},
+			expectCreatedWorkspace: &models.Workspace{
+				Metadata:         models.ResourceMetadata{ID: workspaceID},
+				Name:             workspaceName,
+				GroupID:          groupID,
+				TerraformVersion: terraformVersion,
+				Environment:      "production",
+				FullPath:         workspacePath,
+			},
+			limit:                    5,
+			injectWorkspacesPerGroup: 1,
+		},
+		{
+			name: "invalid environment",
+			input: models.Workspace{
+				Name:             workspaceName,
+				GroupID:          groupID,
+				TerraformVersion: terraformVersion,
+				Environment:      "qa",
+			},
+			expectErrCode: errors.EInvalid,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -115,6 +159,7 @@ func TestCreateWorkspace(t *testing.T) {
 			mockCaller.Test(t)
 
 			mockCaller.On("RequirePermission", mock.Anything, permissions.CreateWorkspacePermission, mock.Anything).Return(test.authError)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateNamespaceMembershipPermission, mock.Anything).Return(test.ownerAuthError)
 
 			mockCaller.On("GetSubject").Return("mockSubject")
 
@@ -125,7 +170,7 @@ func TestCreateWorkspace(t *testing.T) {
 			mockCLIStore := cli.NewMockTerraformCLIStore(t)
 			// Apparently, it is not necessary to mock anything out, just have the interface instantiated.
 
-			if test.authError == nil {
+			if test.expectCreatedWorkspace != nil {
 				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
 				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
 				if !test.exceedsLimit {
@@ -146,7 +191,7 @@ func TestCreateWorkspace(t *testing.T) {
 
 			mockActivityEvents := activityevent.NewMockService(t)
 
-			if test.authError == nil && !test.exceedsLimit {
+			if test.expectCreatedWorkspace != nil && !test.exceedsLimit {
 				mockActivityEvents.On("CreateActivityEvent", mock.Anything, mock.Anything).Return(&models.ActivityEvent{}, nil)
 			}
 
@@ -177,7 +222,7 @@ func TestCreateWorkspace(t *testing.T) {
 			testLogger, _ := logger.NewForTest()
 			mockCLIService := cli.NewService(testLogger, nil, nil, mockCLIStore, ">= 1.0.0")
 
-			service := NewService(testLogger, &dbClient, limits.NewLimitChecker(&dbClient), nil, nil, mockCLIService, mockActivityEvents)
+			service := NewService(testLogger, &dbClient, limits.NewLimitChecker(&dbClient), nil, nil, mockCLIService, mockActivityEvents, []string{"production", "staging"})
 
 			workspace, err := service.CreateWorkspace(auth.WithCaller(ctx, &mockCaller), &test.input)
 			if test.expectErrCode != "" {
@@ -229,6 +274,16 @@ func TestGetWorkspaces(t *testing.T) {
 				sampleWorkspace,
 			},
 		},
+		{
+			name: "positive: successfully returns workspaces filtered by environment",
+			input: &GetWorkspacesInput{
+				Environment: ptr.String("production"),
+			},
+			accessPolicyAllowAll: true,
+			expectResult: []models.Workspace{
+				sampleWorkspace,
+			},
+		},
 		{
 			name:              "negative: failed to authorize caller",
 			input:             &GetWorkspacesInput{},
@@ -309,6 +364,7 @@ func TestGetWorkspaces(t *testing.T) {
 				Filter: &db.WorkspaceFilter{
 					Search:                    test.input.Search,
 					AssignedManagedIdentityID: test.input.AssignedManagedIdentityID,
+					Environment:               test.input.Environment,
 				},
 			}
 
@@ -340,7 +396,7 @@ func TestGetWorkspaces(t *testing.T) {
 				test.handleCaller = auth.HandleCaller
 			}
 
-			service := newService(nil, dbClient, nil, nil, nil, nil, nil, test.handleCaller)
+			service := newService(nil, dbClient, nil, nil, nil, nil, nil, nil, test.handleCaller)
 
 			result, err := service.GetWorkspaces(ctx, test.input)
 
@@ -358,6 +414,117 @@ func TestGetWorkspaces(t *testing.T) {
 	}
 }
 
+func TestUpdateWorkspaceAutoApply(t *testing.T) {
+	workspaceID := "workspace-id"
+	terraformVersion := "1.2.2"
+
+	// Test cases
+	tests := []struct {
+		ownerAuthError   error
+		expectPayload    *models.ActivityEventUpdateWorkspaceAutoApplyPayload
+		name             string
+		expectErrCode    errors.CodeType
+		currentAutoApply bool
+		autoApply        bool
+	}{
+		{
+			name:          "owner enables auto apply",
+			autoApply:     true,
+			expectPayload: &models.ActivityEventUpdateWorkspaceAutoApplyPayload{AutoApply: true},
+		},
+		{
+			name:           "subject is not an owner and cannot enable auto apply",
+			autoApply:      true,
+			ownerAuthError: errors.New("Unauthorized", errors.WithErrorCode(errors.EForbidden)),
+			expectErrCode:  errors.EForbidden,
+		},
+		{
+			name:             "subject that is not an owner can disable auto apply",
+			currentAutoApply: true,
+			ownerAuthError:   errors.New("Unauthorized", errors.WithErrorCode(errors.EForbidden)),
+			expectPayload:    &models.ActivityEventUpdateWorkspaceAutoApplyPayload{AutoApply: false},
+		},
+		{
+			name:             "auto apply is unchanged",
+			currentAutoApply: true,
+			autoApply:        true,
+			ownerAuthError:   errors.New("Unauthorized", errors.WithErrorCode(errors.EForbidden)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			input := &models.Workspace{
+				Metadata:         models.ResourceMetadata{ID: workspaceID},
+				Name:             "workspace-name",
+				FullPath:         "group/workspace-name",
+				MaxJobDuration:   ptr.Int32(1234),
+				TerraformVersion: terraformVersion,
+				AutoApply:        test.autoApply,
+			}
+
+			mockCaller := auth.NewMockCaller(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockCLIService := cli.NewMockService(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateWorkspacePermission, mock.Anything).Return(nil)
+			mockCaller.On("RequirePermission", mock.Anything, permissions.UpdateNamespaceMembershipPermission, mock.Anything).Return(test.ownerAuthError).Maybe()
+			mockCaller.On("GetSubject").Return("mockSubject").Maybe()
+
+			mockWorkspaces.On("GetWorkspaceByID", mock.Anything, workspaceID).Return(&models.Workspace{
+				Metadata:  models.ResourceMetadata{ID: workspaceID},
+				AutoApply: test.currentAutoApply,
+			}, nil)
+
+			if test.expectErrCode == "" {
+				mockCLIService.On("GetTerraformCLIVersions", mock.Anything).Return(cli.TerraformCLIVersions{terraformVersion}, nil)
+
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+
+				mockWorkspaces.On("UpdateWorkspace", mock.Anything, input).Return(input, nil)
+
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						eventInput := args.Get(1).(*activityevent.CreateActivityEventInput)
+						if test.expectPayload == nil {
+							assert.Nil(t, eventInput.Payload)
+						} else {
+							assert.Equal(t, test.expectPayload, eventInput.Payload)
+						}
+					}).
+					Return(&models.ActivityEvent{}, nil)
+			}
+
+			dbClient := &db.Client{
+				Transactions: mockTransactions,
+				Workspaces:   mockWorkspaces,
+			}
+
+			testLogger, _ := logger.NewForTest()
+			service := NewService(testLogger, dbClient, nil, nil, nil, mockCLIService, mockActivityEvents, nil)
+
+			workspace, err := service.UpdateWorkspace(auth.WithCaller(ctx, mockCaller), input)
+			if test.expectErrCode != "" {
+				assert.Equal(t, test.expectErrCode, errors.ErrorCode(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.autoApply, workspace.AutoApply)
+		})
+	}
+}
+
 func TestCreateStateVersion(t *testing.T) {
 	stateVersionID := "state-version-1"
 	workspaceID := "workspace-1"
@@ -365,6 +532,9 @@ func TestCreateStateVersion(t *testing.T) {
 	subject := "subject-1"
 	goodData := buildEncodedData("{\"version\": 4}")
 	badData := buildEncodedData("{\"version\": 4, \"serial\": \"bad-serial\"}")
+	newerData := buildEncodedData("{\"version\": 4, \"lineage\": \"lineage-1\", \"serial\": 2}")
+	otherLineageData := buildEncodedData("{\"version\": 4, \"lineage\": \"lineage-2\", \"serial\": 2}")
+	currentState := "{\"version\": 4, \"lineage\": \"lineage-1\", \"serial\": 1}"
 	currentTime := time.Now().UTC()
 
 	toCreate := &models.StateVersion{
@@ -384,9 +554,11 @@ func TestCreateStateVersion(t *testing.T) {
 		expectResult             *models.StateVersion
 		data                     []byte
 		name                     string
+		currentState             string
 		expectErrorCode          errors.CodeType
 		limit                    int
 		injectSVsPerWorkspace    int32
+		verifyStateLineage       bool
 	}
 
 	/*
@@ -468,6 +640,14 @@ func TestCreateStateVersion(t *testing.T) {
 			dataUnmarshalError:    errors.New("failed to unmarshal decoded data", errors.WithErrorCode(errors.EInternal)),
 			expectErrorCode:       errors.EInternal,
 		},
+		{
+			name:               "state lineage doesn't match the current state",
+			toCreate:           toCreate,
+			data:               otherLineageData,
+			currentState:       currentState,
+			verifyStateLineage: true,
+			expectErrorCode:    errors.EInvalid,
+		},
 		{
 			name:     "upload error",
 			toCreate: toCreate,
@@ -507,6 +687,59 @@ func TestCreateStateVersion(t *testing.T) {
 				RunID:       &runID,
 			},
 		},
+		{
+			name:               "successfully created with the current state's lineage",
+			toCreate:           toCreate,
+			data:               newerData,
+			currentState:       currentState,
+			verifyStateLineage: true,
+			injectCreated: &models.StateVersion{
+				Metadata: models.ResourceMetadata{
+					CreationTimestamp: &currentTime,
+					ID:                stateVersionID,
+				},
+				WorkspaceID: workspaceID,
+				RunID:       &runID,
+				CreatedBy:   subject,
+			},
+			limit:                 4,
+			injectSVsPerWorkspace: 4,
+			expectResult: &models.StateVersion{
+				Metadata: models.ResourceMetadata{
+					CreationTimestamp: &currentTime,
+					ID:                stateVersionID,
+				},
+				CreatedBy:   subject,
+				WorkspaceID: workspaceID,
+				RunID:       &runID,
+			},
+		},
+		{
+			name:         "state lineage isn't verified when no rule requires it",
+			toCreate:     toCreate,
+			data:         otherLineageData,
+			currentState: currentState,
+			injectCreated: &models.StateVersion{
+				Metadata: models.ResourceMetadata{
+					CreationTimestamp: &currentTime,
+					ID:                stateVersionID,
+				},
+				WorkspaceID: workspaceID,
+				RunID:       &runID,
+				CreatedBy:   subject,
+			},
+			limit:                 4,
+			injectSVsPerWorkspace: 4,
+			expectResult: &models.StateVersion{
+				Metadata: models.ResourceMetadata{
+					CreationTimestamp: &currentTime,
+					ID:                stateVersionID,
+				},
+				CreatedBy:   subject,
+				WorkspaceID: workspaceID,
+				RunID:       &runID,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -541,13 +774,46 @@ func TestCreateStateVersion(t *testing.T) {
 			mockResourceLimits.On("GetResourceLimit", mock.Anything, mock.Anything).
 				Return(&models.ResourceLimit{Value: test.limit}, nil).Maybe()
 
-			mockWorkspaces := db.NewMockWorkspaces(t)
-			mockWorkspaces.On("GetWorkspaceByID", mock.Anything, mock.Anything).
-				Return(&models.Workspace{
-					Metadata: models.ResourceMetadata{
-						ID: workspaceID,
+			mockArtifactStore := MockArtifactStore{}
+			mockArtifactStore.Test(t)
+
+			workspace := &models.Workspace{
+				Metadata: models.ResourceMetadata{
+					ID: workspaceID,
+				},
+			}
+
+			if test.currentState != "" {
+				currentStateVersion := &models.StateVersion{
+					Metadata:    models.ResourceMetadata{ID: "current-state-version"},
+					WorkspaceID: workspaceID,
+				}
+				workspace.CurrentStateVersionID = currentStateVersion.Metadata.ID
+
+				// The current state must only be downloaded when the lineage is verified.
+				if test.verifyStateLineage {
+					mockStateVersions.On("GetStateVersion", mock.Anything, currentStateVersion.Metadata.ID).Return(currentStateVersion, nil)
+					mockArtifactStore.On("GetStateVersion", mock.Anything, currentStateVersion).
+						Return(io.NopCloser(strings.NewReader(test.currentState)), nil)
+				}
+			}
+
+			mockManagedIdentities := db.NewMockManagedIdentities(t)
+			mockManagedIdentities.On("GetManagedIdentitiesForWorkspace", mock.Anything, workspaceID).
+				Return([]models.ManagedIdentity{{Metadata: models.ResourceMetadata{ID: "managed-identity-1"}}}, nil).Maybe()
+			mockManagedIdentities.On("GetManagedIdentityAccessRules", mock.Anything, mock.Anything).
+				Return(&db.ManagedIdentityAccessRulesResult{
+					ManagedIdentityAccessRules: []models.ManagedIdentityAccessRule{
+						{
+							Type:               models.ManagedIdentityAccessRuleModuleAttestation,
+							VerifyStateLineage: test.verifyStateLineage,
+						},
 					},
 				}, nil).Maybe()
+
+			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockWorkspaces.On("GetWorkspaceByID", mock.Anything, mock.Anything).
+				Return(workspace, nil).Maybe()
 			mockWorkspaces.On("UpdateWorkspace", mock.Anything, mock.Anything).
 				Return(&models.Workspace{
 					Metadata: models.ResourceMetadata{
@@ -555,9 +821,6 @@ func TestCreateStateVersion(t *testing.T) {
 					},
 				}, nil).Maybe()
 
-			mockArtifactStore := MockArtifactStore{}
-			mockArtifactStore.Test(t)
-
 			mockArtifactStore.On("UploadStateVersion", mock.Anything, mock.Anything, mock.Anything).
 				Return(test.uploadError)
 
@@ -568,13 +831,14 @@ func TestCreateStateVersion(t *testing.T) {
 
 			testLogger, _ := logger.NewForTest()
 			dbClient := &db.Client{
-				Transactions:   mockTransactions,
-				StateVersions:  mockStateVersions,
-				ResourceLimits: mockResourceLimits,
-				Workspaces:     mockWorkspaces,
+				Transactions:      mockTransactions,
+				StateVersions:     mockStateVersions,
+				ResourceLimits:    mockResourceLimits,
+				Workspaces:        mockWorkspaces,
+				ManagedIdentities: mockManagedIdentities,
 			}
 
-			service := NewService(testLogger, dbClient, limits.NewLimitChecker(dbClient), &mockArtifactStore, nil, nil, &mockActivityEvents)
+			service := NewService(testLogger, dbClient, limits.NewLimitChecker(dbClient), &mockArtifactStore, nil, nil, &mockActivityEvents, nil)
 
 			if !test.authFail {
 				ctx = auth.WithCaller(ctx, &mockCaller)
@@ -739,7 +1003,7 @@ func TestCreateConfigurationVersion(t *testing.T) {
 				ResourceLimits:        mockResourceLimits,
 			}
 
-			service := NewService(testLogger, dbClient, limits.NewLimitChecker(dbClient), nil, nil, nil, &mockActivityEvents)
+			service := NewService(testLogger, dbClient, limits.NewLimitChecker(dbClient), nil, nil, nil, &mockActivityEvents, nil)
 
 			if !test.authFail {
 				ctx = auth.WithCaller(ctx, &mockCaller)
@@ -958,7 +1222,7 @@ func TestMigrateWorkspace(t *testing.T) {
 			)
 
 			logger, _ := logger.NewForTest()
-			service := NewService(logger, &dbClient, limiter, nil, nil, nil, &mockActivityEvents)
+			service := NewService(logger, &dbClient, limiter, nil, nil, nil, &mockActivityEvents, nil)
 
 			migrated, err := service.MigrateWorkspace(auth.WithCaller(ctx, testCaller),
 				test.inputWorkspace.Metadata.ID, test.newParentID)
@@ -972,3 +1236,327 @@ func TestMigrateWorkspace(t *testing.T) {
 		})
 	}
 }
+
+func TestForceUnlockWorkspace(t *testing.T) {
+	workspaceID := "workspace-id"
+	runID := "run-id"
+
+	testWorkspace := &models.Workspace{
+		Metadata: models.ResourceMetadata{ID: workspaceID},
+		FullPath: "group/workspace",
+	}
+
+	// Test cases
+	tests := []struct {
+		existingLock    *models.WorkspaceRunLock
+		name            string
+		expectErrorCode errors.CodeType
+		isOwner         bool
+	}{
+		{
+			name: "owner successfully force unlocks the workspace",
+			existingLock: &models.WorkspaceRunLock{
+				Metadata:    models.ResourceMetadata{ID: "lock-id"},
+				WorkspaceID: workspaceID,
+				RunID:       runID,
+			},
+			isOwner: true,
+		},
+		{
+			name: "caller is not an owner of the workspace",
+			existingLock: &models.WorkspaceRunLock{
+				Metadata:    models.ResourceMetadata{ID: "lock-id"},
+				WorkspaceID: workspaceID,
+				RunID:       runID,
+			},
+			expectErrorCode: errors.EForbidden,
+		},
+		{
+			name:            "workspace is not locked by a run",
+			isOwner:         true,
+			expectErrorCode: errors.EConflict,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var accessError error
+			if !test.isOwner {
+				accessError = errors.New("test user is not owner of workspace", errors.WithErrorCode(errors.EForbidden))
+			}
+
+			mockAuthorizer := auth.NewMockAuthorizer(t)
+			mockAuthorizer.On("RequireAccess", mock.Anything, []permissions.Permission{permissions.UpdateNamespaceMembershipPermission}, mock.Anything).Return(accessError)
+
+			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockWorkspaceRunLocks := db.NewMockWorkspaceRunLocks(t)
+			mockTransactions := db.NewMockTransactions(t)
+			mockActivityEvents := activityevent.NewMockService(t)
+
+			if test.isOwner {
+				mockWorkspaces.On("GetWorkspaceByID", mock.Anything, workspaceID).Return(testWorkspace, nil)
+				mockWorkspaceRunLocks.On("GetWorkspaceRunLock", mock.Anything, workspaceID).Return(test.existingLock, nil)
+			}
+
+			if test.expectErrorCode == "" {
+				mockTransactions.On("BeginTx", mock.Anything).Return(ctx, nil)
+				mockTransactions.On("RollbackTx", mock.Anything).Return(nil)
+				mockTransactions.On("CommitTx", mock.Anything).Return(nil)
+
+				mockWorkspaceRunLocks.On("DeleteWorkspaceRunLock", mock.Anything, test.existingLock).Return(nil)
+
+				mockActivityEvents.On("CreateActivityEvent", mock.Anything, &activityevent.CreateActivityEventInput{
+					NamespacePath: &testWorkspace.FullPath,
+					Action:        models.ActionUnlock,
+					TargetType:    models.TargetWorkspace,
+					TargetID:      workspaceID,
+					Payload: &models.ActivityEventForceUnlockWorkspacePayload{
+						RunID: runID,
+					},
+				}).Return(&models.ActivityEvent{}, nil)
+			}
+
+			mockMaintenanceMonitor := maintenance.NewMockMonitor(t)
+			mockMaintenanceMonitor.On("InMaintenanceMode", mock.Anything).Return(false, nil).Maybe()
+
+			dbClient := &db.Client{
+				Workspaces:        mockWorkspaces,
+				WorkspaceRunLocks: mockWorkspaceRunLocks,
+				Transactions:      mockTransactions,
+			}
+
+			testCaller := auth.NewUserCaller(
+				&models.User{
+					Metadata: models.ResourceMetadata{
+						ID: "123",
+					},
+					Username: "user1",
+				},
+				mockAuthorizer,
+				dbClient,
+				mockMaintenanceMonitor,
+			)
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, nil, nil, nil, nil, mockActivityEvents, nil)
+
+			err := service.ForceUnlockWorkspace(auth.WithCaller(ctx, testCaller), workspaceID)
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestGetEffectiveRunnerTags(t *testing.T) {
+	workspaceID := "workspace-id"
+
+	// Test cases
+	tests := []struct {
+		groups          map[string]*models.Group
+		name            string
+		expectErrorCode errors.CodeType
+		expectTags      []string
+		isAuthorized    bool
+	}{
+		{
+			name: "tags are defined on the workspace's group",
+			groups: map[string]*models.Group{
+				"group-2": {Metadata: models.ResourceMetadata{ID: "group-2"}, ParentID: "group-1", RunnerTags: []string{"child"}},
+				"group-1": {Metadata: models.ResourceMetadata{ID: "group-1"}, RunnerTags: []string{"root"}},
+			},
+			expectTags:   []string{"child"},
+			isAuthorized: true,
+		},
+		{
+			name: "tags are inherited from the closest ancestor group",
+			groups: map[string]*models.Group{
+				"group-2": {Metadata: models.ResourceMetadata{ID: "group-2"}, ParentID: "group-1"},
+				"group-1": {Metadata: models.ResourceMetadata{ID: "group-1"}, RunnerTags: []string{"root", "shared"}},
+			},
+			expectTags:   []string{"root", "shared"},
+			isAuthorized: true,
+		},
+		{
+			name: "empty tags defined on a group override the ancestor's tags",
+			groups: map[string]*models.Group{
+				"group-2": {Metadata: models.ResourceMetadata{ID: "group-2"}, ParentID: "group-1", RunnerTags: []string{}},
+				"group-1": {Metadata: models.ResourceMetadata{ID: "group-1"}, RunnerTags: []string{"root"}},
+			},
+			expectTags:   []string{},
+			isAuthorized: true,
+		},
+		{
+			name: "no group in the hierarchy defines tags",
+			groups: map[string]*models.Group{
+				"group-2": {Metadata: models.ResourceMetadata{ID: "group-2"}, ParentID: "group-1"},
+				"group-1": {Metadata: models.ResourceMetadata{ID: "group-1"}},
+			},
+			expectTags:   []string{},
+			isAuthorized: true,
+		},
+		{
+			name:            "subject is not authorized to view the workspace",
+			expectErrorCode: errors.EForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var accessError error
+			if !test.isAuthorized {
+				accessError = errors.New("Forbidden", errors.WithErrorCode(errors.EForbidden))
+			}
+
+			mockAuthorizer := auth.NewMockAuthorizer(t)
+			mockAuthorizer.On("RequireAccess", mock.Anything, []permissions.Permission{permissions.ViewWorkspacePermission}, mock.Anything).Return(accessError)
+
+			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockGroups := db.NewMockGroups(t)
+
+			if test.isAuthorized {
+				mockWorkspaces.On("GetWorkspaceByID", mock.Anything, workspaceID).Return(&models.Workspace{
+					Metadata: models.ResourceMetadata{ID: workspaceID},
+					GroupID:  "group-2",
+				}, nil)
+
+				for id, group := range test.groups {
+					mockGroups.On("GetGroupByID", mock.Anything, id).Return(group, nil).Maybe()
+				}
+			}
+
+			mockMaintenanceMonitor := maintenance.NewMockMonitor(t)
+			mockMaintenanceMonitor.On("InMaintenanceMode", mock.Anything).Return(false, nil).Maybe()
+
+			dbClient := &db.Client{
+				Workspaces: mockWorkspaces,
+				Groups:     mockGroups,
+			}
+
+			testCaller := auth.NewUserCaller(
+				&models.User{
+					Metadata: models.ResourceMetadata{
+						ID: "123",
+					},
+					Username: "user1",
+				},
+				mockAuthorizer,
+				dbClient,
+				mockMaintenanceMonitor,
+			)
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, nil, nil, nil, nil, nil, nil)
+
+			tags, err := service.GetEffectiveRunnerTags(auth.WithCaller(ctx, testCaller), workspaceID)
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectTags, tags)
+		})
+	}
+}
+
+func TestVerifyStateLineage(t *testing.T) {
+	workspaceID := "workspace-1"
+	stateVersionID := "state-version-1"
+
+	currentState := `{"version": 4, "lineage": "lineage-1", "serial": 5}`
+
+	testCases := []struct {
+		name                  string
+		currentStateVersionID string
+		incoming              *StateMeta
+		expectErrorCode       errors.CodeType
+	}{
+		{
+			name:                  "matching lineage and newer serial",
+			currentStateVersionID: stateVersionID,
+			incoming:              &StateMeta{Lineage: "lineage-1", Serial: 6},
+		},
+		{
+			name:                  "matching lineage and same serial",
+			currentStateVersionID: stateVersionID,
+			incoming:              &StateMeta{Lineage: "lineage-1", Serial: 5},
+		},
+		{
+			name:     "workspace has no current state",
+			incoming: &StateMeta{Lineage: "lineage-2", Serial: 1},
+		},
+		{
+			name:                  "mismatched lineage",
+			currentStateVersionID: stateVersionID,
+			incoming:              &StateMeta{Lineage: "lineage-2", Serial: 6},
+			expectErrorCode:       errors.EInvalid,
+		},
+		{
+			name:                  "regressed serial",
+			currentStateVersionID: stateVersionID,
+			incoming:              &StateMeta{Lineage: "lineage-1", Serial: 4},
+			expectErrorCode:       errors.EInvalid,
+		},
+		{
+			name:                  "incoming state is nil",
+			currentStateVersionID: stateVersionID,
+			expectErrorCode:       errors.EInvalid,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockCaller := auth.NewMockCaller(t)
+			mockWorkspaces := db.NewMockWorkspaces(t)
+			mockStateVersions := db.NewMockStateVersions(t)
+			mockArtifactStore := NewMockArtifactStore(t)
+
+			mockCaller.On("RequirePermission", mock.Anything, permissions.ViewStateVersionPermission, mock.Anything).Return(nil)
+
+			mockWorkspaces.On("GetWorkspaceByID", mock.Anything, workspaceID).Return(&models.Workspace{
+				Metadata:              models.ResourceMetadata{ID: workspaceID},
+				CurrentStateVersionID: test.currentStateVersionID,
+			}, nil).Maybe()
+
+			if test.currentStateVersionID != "" && test.incoming != nil {
+				stateVersion := &models.StateVersion{
+					Metadata:    models.ResourceMetadata{ID: stateVersionID},
+					WorkspaceID: workspaceID,
+				}
+
+				mockStateVersions.On("GetStateVersion", mock.Anything, stateVersionID).Return(stateVersion, nil)
+				mockArtifactStore.On("GetStateVersion", mock.Anything, stateVersion).
+					Return(io.NopCloser(strings.NewReader(currentState)), nil)
+			}
+
+			dbClient := &db.Client{
+				Workspaces:    mockWorkspaces,
+				StateVersions: mockStateVersions,
+			}
+
+			logger, _ := logger.NewForTest()
+			service := NewService(logger, dbClient, nil, mockArtifactStore, nil, nil, nil, nil)
+
+			err := service.VerifyStateLineage(auth.WithCaller(ctx, mockCaller), workspaceID, test.incoming)
+			if test.expectErrorCode != "" {
+				assert.Equal(t, test.expectErrorCode, errors.ErrorCode(err))
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
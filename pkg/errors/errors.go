@@ -35,6 +35,7 @@ const (
 type config struct {
 	span      trace.Span
 	errorCode CodeType
+	details   map[string]string
 }
 
 // Option is is used to configure a TharsisError.
@@ -47,6 +48,14 @@ func WithErrorCode(code CodeType) Option {
 	}
 }
 
+// WithErrorDetails attaches structured details to the TharsisError, such as the
+// offending field names mapped to a description of what is wrong with each one.
+func WithErrorDetails(details map[string]string) Option {
+	return func(c *config) {
+		c.details = details
+	}
+}
+
 // WithSpan records the error on the span and sets the status to Error.
 func WithSpan(span trace.Span) Option {
 	return func(c *config) {
@@ -57,6 +66,7 @@ func WithSpan(span trace.Span) Option {
 // TharsisError is the internal error implementation for the Tharsis API
 type TharsisError struct {
 	err     error
+	details map[string]string
 	code    CodeType
 	message string
 }
@@ -74,6 +84,7 @@ func New(format string, a ...any) *TharsisError {
 	resultError := &TharsisError{
 		code:    code,
 		message: msg,
+		details: cfg.details,
 	}
 
 	if cfg.span != nil {
@@ -102,6 +113,7 @@ func Wrap(err error, format string, a ...any) *TharsisError {
 		code:    code,
 		message: msg,
 		err:     err,
+		details: cfg.details,
 	}
 }
 
@@ -183,6 +195,22 @@ func ErrorMessage(err error) string {
 	return internalErrorMessage
 }
 
+// ErrorDetails returns the structured details of the outermost error that has them, if any.
+func ErrorDetails(err error) map[string]string {
+	for {
+		e, ok := unwrapTharsisError(err)
+		if !ok {
+			return nil
+		}
+
+		if len(e.details) > 0 {
+			return e.details
+		}
+
+		err = e.err
+	}
+}
+
 // IsContextCanceledError returns true if the error is a context.Canceled error
 func IsContextCanceledError(err error) bool {
 	return errors.Is(err, context.Canceled)
@@ -7,7 +7,6 @@ package pagination
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"reflect"
 
@@ -59,14 +58,16 @@ type Options struct {
 	Last   *int32
 }
 
-// Validate returns an error if the options are not valid
-func (o *Options) validate() error {
-	// This is also checked in the service layer before getting here.
+// Validate returns an error if the options are not valid, a nil Options is valid
+func (o *Options) Validate() error {
+	if o == nil {
+		return nil
+	}
 	if (o.Before != nil) && (o.After != nil) {
-		return errors.New("only before or after can be defined, not both")
+		return te.New("only before or after can be defined, not both", te.WithErrorCode(te.EInvalid))
 	}
 	if (o.First != nil) && (o.Last != nil) {
-		return errors.New("only first or last can be defined, not both")
+		return te.New("only first or last can be defined, not both", te.WithErrorCode(te.EInvalid))
 	}
 
 	return nil
@@ -187,6 +188,7 @@ func (c *cursorPaginatedRows) GetPageInfo() *PageInfo {
 type extraOptions struct {
 	sortBy            *FieldDescriptor
 	sortTransformFunc SortTransformFunc
+	sortByNullValue   *string
 	sortDirection     SortDirection
 }
 
@@ -208,6 +210,15 @@ func WithSortByTransform(f SortTransformFunc) ExtraOptionFunc {
 	}
 }
 
+// WithSortByNullValue sets the value used in place of NULL for a nullable sort by field.
+// The value must match what the resource resolves the sort by key to when the field is
+// NULL, since it's used for both ordering and comparing against the cursor.
+func WithSortByNullValue(value string) ExtraOptionFunc {
+	return func(o *extraOptions) {
+		o.sortByNullValue = &value
+	}
+}
+
 // PaginatedQueryBuilder represents a paginated DB query
 type PaginatedQueryBuilder struct {
 	options           *Options
@@ -215,6 +226,7 @@ type PaginatedQueryBuilder struct {
 	sortBy            *FieldDescriptor
 	limit             *int32
 	cur               *cursor
+	sortByNullValue   *string
 	sortDirection     SortDirection
 	sortTransformFunc SortTransformFunc
 }
@@ -234,7 +246,7 @@ func NewPaginatedQueryBuilder(
 		options = &Options{}
 	}
 
-	if err := options.validate(); err != nil {
+	if err := options.Validate(); err != nil {
 		return nil, err
 	}
 
@@ -274,11 +286,27 @@ func NewPaginatedQueryBuilder(
 		sortBy:            extra.sortBy,
 		sortDirection:     extra.sortDirection,
 		sortTransformFunc: extra.sortTransformFunc,
+		sortByNullValue:   extra.sortByNullValue,
 		limit:             limit,
 		cur:               cur,
 	}, nil
 }
 
+// Count returns the number of rows matched by the query without fetching any of them
+func Count(ctx context.Context, conn Connection, query *goqu.SelectDataset) (int32, error) {
+	countSQL, countArgs, err := query.Prepared(true).Select(goqu.COUNT("*")).ToSQL()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int32
+	if err = conn.QueryRow(ctx, countSQL, countArgs...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to scan query count result: %w", err)
+	}
+
+	return count, nil
+}
+
 // Execute executes the paginated query using the DB Connection
 func (p *PaginatedQueryBuilder) Execute(ctx context.Context, conn Connection, query *goqu.SelectDataset) (PaginatedRows, error) {
 	// Copy original query which will be used to get the total count
@@ -306,19 +334,11 @@ func (p *PaginatedQueryBuilder) Execute(ctx context.Context, conn Connection, qu
 		return nil, err
 	}
 
-	// Build count query
-	countSQL, countArgs, err := originalQuery.Prepared(true).Select(goqu.COUNT("*")).ToSQL()
+	count, err := Count(ctx, conn, &originalQuery)
 	if err != nil {
 		return nil, err
 	}
 
-	row := conn.QueryRow(ctx, countSQL, countArgs...)
-
-	var count int32
-	if err = row.Scan(&count); err != nil {
-		return nil, fmt.Errorf("failed to scan query count result: %w", err)
-	}
-
 	rows, err := conn.Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
@@ -375,6 +395,23 @@ func (p *PaginatedQueryBuilder) buildWhereCondition() goqu.Expression {
 	}
 
 	if p.cur != nil {
+		if p.cur.secondary != nil && p.sortByNullValue != nil {
+			// NULL never compares equal, so compare against the value used in place of it.
+			sortByExpr := goqu.COALESCE(goqu.I(p.sortBy.getFullColName()), *p.sortByNullValue)
+
+			sortByCondition := sortByExpr.Lt(p.cur.secondary.value)
+			if op == gt {
+				sortByCondition = sortByExpr.Gt(p.cur.secondary.value)
+			}
+
+			return goqu.Or(
+				sortByCondition,
+				goqu.And(
+					sortByExpr.Eq(p.cur.secondary.value),
+					goqu.Ex{p.primaryKey.getFullColName(): goqu.Op{op: p.cur.primary.value}},
+				),
+			)
+		}
 		if p.cur.secondary != nil {
 			return goqu.Or(
 				goqu.Ex{
@@ -437,5 +474,8 @@ func (p *PaginatedQueryBuilder) buildSortByExpr(field string) exp.Orderable {
 	if p.sortTransformFunc != nil {
 		return goqu.L(p.sortTransformFunc(field))
 	}
+	if p.sortByNullValue != nil {
+		return goqu.COALESCE(goqu.I(field), *p.sortByNullValue)
+	}
 	return goqu.I(field)
 }
@@ -29,13 +29,71 @@ func (t testModel) ResolveMetadata(key string) (string, error) {
 	}
 }
 
+func TestValidate(t *testing.T) {
+	cursor := "cursor"
+	count := int32(5)
+
+	// Test cases
+	tests := []struct {
+		options     *Options
+		name        string
+		expectError string
+	}{
+		{
+			name: "nil options",
+		},
+		{
+			name:    "empty options",
+			options: &Options{},
+		},
+		{
+			name:    "first and after",
+			options: &Options{First: &count, After: &cursor},
+		},
+		{
+			name:    "last and before",
+			options: &Options{Last: &count, Before: &cursor},
+		},
+		{
+			name:        "both before and after",
+			options:     &Options{Before: &cursor, After: &cursor},
+			expectError: "only before or after can be defined, not both",
+		},
+		{
+			name:        "both first and last",
+			options:     &Options{First: &count, Last: &count},
+			expectError: "only first or last can be defined, not both",
+		},
+		{
+			name:        "both before and after with both first and last",
+			options:     &Options{Before: &cursor, After: &cursor, First: &count, Last: &count},
+			expectError: "only before or after can be defined, not both",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.options.Validate()
+			if test.expectError == "" {
+				assert.Nil(t, err)
+				return
+			}
+
+			assert.Equal(t, errors.EInvalid, errors.ErrorCode(err))
+			assert.Equal(t, test.expectError, errors.ErrorMessage(err))
+		})
+	}
+}
+
 func TestExecute(t *testing.T) {
 	optionsNum := int32(5)
+	nullValue := ""
 
 	// Test cases
 	tests := []struct {
 		paginationOptions   Options
 		sortByField         *FieldDescriptor
+		sortByNullValue     *string
 		name                string
 		sortDirection       SortDirection
 		expectSQL           string
@@ -111,6 +169,20 @@ func TestExecute(t *testing.T) {
 			expectHasPrevPage:   true,
 			expectedResultCount: 5,
 		},
+		{
+			name:                "limit results by first with after cursor and nullable sort by field",
+			paginationOptions:   Options{First: &optionsNum, After: buildTestCursor("1", "test1")},
+			sortByField:         &FieldDescriptor{Key: "name", Table: "tests", Col: "name"},
+			sortByNullValue:     &nullValue,
+			sortDirection:       AscSort,
+			resultCount:         6,
+			expectSQL:           `SELECT * FROM "tests" WHERE ((COALESCE("tests"."name", ?) > ?) OR ((COALESCE("tests"."name", ?) = ?) AND ("tests"."id" > ?))) ORDER BY COALESCE("tests"."name", ?) ASC, "tests"."id" ASC LIMIT ?`,
+			expectArguments:     []interface{}{"", "test1", "", "test1", "1", "", int64(6)},
+			expectCountSQL:      `SELECT COUNT(*) FROM "tests"`,
+			expectHasNextPage:   true,
+			expectHasPrevPage:   true,
+			expectedResultCount: 5,
+		},
 		{
 			name:                "limit results by last with before cursor",
 			paginationOptions:   Options{Last: &optionsNum, Before: buildTestCursor("1", "")},
@@ -164,10 +236,15 @@ func TestExecute(t *testing.T) {
 			mockDBConn.On("Query", queryArguments...).Return(&mockRows, nil)
 			mockDBConn.On("QueryRow", mock.Anything, mock.Anything).Return(&mockCountRows, nil)
 
+			extraOptions := []ExtraOptionFunc{WithSortByField(test.sortByField, test.sortDirection)}
+			if test.sortByNullValue != nil {
+				extraOptions = append(extraOptions, WithSortByNullValue(*test.sortByNullValue))
+			}
+
 			qBuilder, err := NewPaginatedQueryBuilder(
 				&test.paginationOptions,
 				&FieldDescriptor{Key: "id", Table: "tests", Col: "id"},
-				WithSortByField(test.sortByField, test.sortDirection),
+				extraOptions...,
 			)
 			if err != nil {
 				assert.Equal(t, test.expectErrCode, errors.ErrorCode(err))
@@ -220,3 +297,27 @@ func TestExecute(t *testing.T) {
 		})
 	}
 }
+
+func TestCount(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockCountRows := mocks.Rows{}
+	mockCountRows.Test(t)
+
+	mockCountRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*int32) = 7
+	}).Return(nil)
+
+	mockDBConn := MockConnection{}
+	mockDBConn.Test(t)
+
+	mockDBConn.On("QueryRow", mock.Anything, `SELECT COUNT(*) FROM "tests" WHERE ("name" = ?)`, "test1").Return(&mockCountRows, nil)
+
+	count, err := Count(ctx, &mockDBConn, goqu.From("tests").Where(goqu.Ex{"name": "test1"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, int32(7), count)
+}